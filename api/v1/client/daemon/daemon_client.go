@@ -42,8 +42,12 @@ type ClientService interface {
 
 	GetMapName(params *GetMapNameParams) (*GetMapNameOK, error)
 
+	GetProfile(params *GetProfileParams) (*GetProfileOK, error)
+
 	PatchConfig(params *PatchConfigParams) (*PatchConfigOK, error)
 
+	PutControllerNameTrigger(params *PutControllerNameTriggerParams) (*PutControllerNameTriggerOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
@@ -259,6 +263,47 @@ func (a *Client) GetMapName(params *GetMapNameParams) (*GetMapNameOK, error) {
 	panic(msg)
 }
 
+/*
+  GetProfile captures a runtime profile of the agent
+
+  Captures a pprof profile or a goroutine dump of the agent with a
+bounded duration and returns it together with a snapshot of the
+current agent state, avoiding the need for a permanently exposed
+pprof endpoint. Captures are rate limited and only a single
+capture may be in progress at any given time.
+
+*/
+func (a *Client) GetProfile(params *GetProfileParams) (*GetProfileOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetProfileParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetProfile",
+		Method:             "GET",
+		PathPattern:        "/profile",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetProfileReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetProfileOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetProfile: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   PatchConfig modifies daemon configuration
 
@@ -298,6 +343,44 @@ func (a *Client) PatchConfig(params *PatchConfigParams) (*PatchConfigOK, error)
 	panic(msg)
 }
 
+/*
+  PutControllerNameTrigger triggers a controller run
+
+  Manually triggers an immediate run of the specified controller and
+resets its exponential error back-off.
+
+*/
+func (a *Client) PutControllerNameTrigger(params *PutControllerNameTriggerParams) (*PutControllerNameTriggerOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPutControllerNameTriggerParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "PutControllerNameTrigger",
+		Method:             "PUT",
+		PathPattern:        "/controller/{name}/trigger",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PutControllerNameTriggerReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PutControllerNameTriggerOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for PutControllerNameTrigger: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
@@ -34,6 +34,8 @@ type ClientService interface {
 
 	GetEndpoint(params *GetEndpointParams) (*GetEndpointOK, error)
 
+	GetEndpointGroups(params *GetEndpointGroupsParams) (*GetEndpointGroupsOK, error)
+
 	GetEndpointID(params *GetEndpointIDParams) (*GetEndpointIDOK, error)
 
 	GetEndpointIDConfig(params *GetEndpointIDConfigParams) (*GetEndpointIDConfigOK, error)
@@ -138,6 +140,45 @@ func (a *Client) GetEndpoint(params *GetEndpointParams) (*GetEndpointOK, error)
 	panic(msg)
 }
 
+/*
+  GetEndpointGroups retrieves endpoints grouped by the values of the given labels
+
+  Retrieves aggregate counts of endpoints grouped by the values they carry
+for the given label keys, optionally restricted to endpoints matching the
+provided labels.
+
+*/
+func (a *Client) GetEndpointGroups(params *GetEndpointGroupsParams) (*GetEndpointGroupsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetEndpointGroupsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetEndpointGroups",
+		Method:             "GET",
+		PathPattern:        "/endpoint/groups",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetEndpointGroupsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetEndpointGroupsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetEndpointGroups: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   GetEndpointID gets endpoint by endpoint ID
 
@@ -56,6 +56,8 @@ type ClientService interface {
 
 	PutPolicy(params *PutPolicyParams) (*PutPolicyOK, error)
 
+	PutPolicyFlowOverride(params *PutPolicyFlowOverrideParams) (*PutPolicyFlowOverrideOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
@@ -529,6 +531,46 @@ func (a *Client) PutPolicy(params *PutPolicyParams) (*PutPolicyOK, error) {
 	panic(msg)
 }
 
+/*
+  PutPolicyFlowOverride installs a temporary allow entry for a specific flow
+
+  Installs a temporary allow entry into the policy map of the given
+endpoint for troubleshooting purposes. The entry is removed
+automatically when its time to live expires. Requires the agent to
+run with flow overrides enabled.
+
+*/
+func (a *Client) PutPolicyFlowOverride(params *PutPolicyFlowOverrideParams) (*PutPolicyFlowOverrideOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPutPolicyFlowOverrideParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "PutPolicyFlowOverride",
+		Method:             "PUT",
+		PathPattern:        "/policy/flow-override",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PutPolicyFlowOverrideReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PutPolicyFlowOverrideOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for PutPolicyFlowOverride: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
@@ -17,6 +17,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewPutPolicyParams creates a new PutPolicyParams object
@@ -68,6 +69,11 @@ type PutPolicyParams struct {
 
 	*/
 	Policy string
+	/*Shadow
+	  Evaluate the provided rules in shadow against the enforced policy instead of enforcing them. The response carries a per-endpoint divergence report instead of the policy tree.
+
+	*/
+	Shadow *bool
 
 	timeout    time.Duration
 	Context    context.Context
@@ -118,6 +124,17 @@ func (o *PutPolicyParams) SetPolicy(policy string) {
 	o.Policy = policy
 }
 
+// WithShadow adds the shadow to the put policy params
+func (o *PutPolicyParams) WithShadow(shadow *bool) *PutPolicyParams {
+	o.SetShadow(shadow)
+	return o
+}
+
+// SetShadow adds the shadow to the put policy params
+func (o *PutPolicyParams) SetShadow(shadow *bool) {
+	o.Shadow = shadow
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *PutPolicyParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -130,6 +147,22 @@ func (o *PutPolicyParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Reg
 		return err
 	}
 
+	if o.Shadow != nil {
+
+		// query param shadow
+		var qrShadow bool
+		if o.Shadow != nil {
+			qrShadow = *o.Shadow
+		}
+		qShadow := swag.FormatBool(qrShadow)
+		if qShadow != "" {
+			if err := r.SetQueryParam("shadow", qShadow); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
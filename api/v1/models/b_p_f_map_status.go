@@ -29,6 +29,9 @@ type BPFMapStatus struct {
 
 	// BPF maps
 	Maps []*BPFMapProperties `json:"maps"`
+
+	// BPF map migration plan computed at agent startup
+	Migration []*BPFMapMigration `json:"migration"`
 }
 
 // Validate validates this b p f map status
@@ -39,6 +42,10 @@ func (m *BPFMapStatus) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateMigration(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -70,6 +77,31 @@ func (m *BPFMapStatus) validateMaps(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *BPFMapStatus) validateMigration(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Migration) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Migration); i++ {
+		if swag.IsZero(m.Migration[i]) { // not required
+			continue
+		}
+
+		if m.Migration[i] != nil {
+			if err := m.Migration[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("migration" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *BPFMapStatus) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -22,6 +22,11 @@ import (
 // swagger:model DaemonConfigurationSpec
 type DaemonConfigurationSpec struct {
 
+	// When set, migrates the node to the given endpoint routes mode at
+	// runtime without recreating endpoints
+	//
+	EndpointRoutes *bool `json:"endpoint-routes,omitempty"`
+
 	// Changeable configuration
 	Options ConfigurationMap `json:"options,omitempty"`
 
@@ -21,6 +21,9 @@ import (
 // swagger:model DebugInfo
 type DebugInfo struct {
 
+	// agent events
+	AgentEvents []*AgentEvent `json:"agent-events"`
+
 	// cilium memory map
 	CiliumMemoryMap string `json:"cilium-memory-map,omitempty"`
 
@@ -51,6 +54,9 @@ type DebugInfo struct {
 	// service list
 	ServiceList []*Service `json:"service-list"`
 
+	// socket lb attachments
+	SocketLbAttachments []*SocketLBAttachment `json:"socket-lb-attachments"`
+
 	// subsystem
 	Subsystem map[string]string `json:"subsystem,omitempty"`
 }
@@ -59,6 +65,10 @@ type DebugInfo struct {
 func (m *DebugInfo) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAgentEvents(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateCiliumStatus(formats); err != nil {
 		res = append(res, err)
 	}
@@ -79,12 +89,41 @@ func (m *DebugInfo) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateSocketLbAttachments(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+func (m *DebugInfo) validateAgentEvents(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AgentEvents) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.AgentEvents); i++ {
+		if swag.IsZero(m.AgentEvents[i]) { // not required
+			continue
+		}
+
+		if m.AgentEvents[i] != nil {
+			if err := m.AgentEvents[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("agent-events" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *DebugInfo) validateCiliumStatus(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.CiliumStatus) { // not required
@@ -189,6 +228,31 @@ func (m *DebugInfo) validateServiceList(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DebugInfo) validateSocketLbAttachments(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SocketLbAttachments) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.SocketLbAttachments); i++ {
+		if swag.IsZero(m.SocketLbAttachments[i]) { // not required
+			continue
+		}
+
+		if m.SocketLbAttachments[i] != nil {
+			if err := m.SocketLbAttachments[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("socket-lb-attachments" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *DebugInfo) MarshalBinary() ([]byte, error) {
 	if m == nil {
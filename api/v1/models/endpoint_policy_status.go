@@ -14,6 +14,7 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // EndpointPolicyStatus Policy information of an endpoint
@@ -21,6 +22,11 @@ import (
 // swagger:model EndpointPolicyStatus
 type EndpointPolicyStatus struct {
 
+	// Time between the last policy repository change and the corresponding
+	// policy map update completing for this endpoint
+	// Format: duration
+	PolicyMapUpdateDelay strfmt.Duration `json:"policy-map-update-delay,omitempty"`
+
 	// The policy revision currently enforced in the proxy for this endpoint
 	ProxyPolicyRevision int64 `json:"proxy-policy-revision,omitempty"`
 
@@ -38,6 +44,10 @@ type EndpointPolicyStatus struct {
 func (m *EndpointPolicyStatus) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validatePolicyMapUpdateDelay(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateProxyStatistics(formats); err != nil {
 		res = append(res, err)
 	}
@@ -56,6 +66,19 @@ func (m *EndpointPolicyStatus) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *EndpointPolicyStatus) validatePolicyMapUpdateDelay(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PolicyMapUpdateDelay) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("policy-map-update-delay", "body", "duration", m.PolicyMapUpdateDelay.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *EndpointPolicyStatus) validateProxyStatistics(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ProxyStatistics) { // not required
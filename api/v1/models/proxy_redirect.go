@@ -18,6 +18,9 @@ import (
 // swagger:model ProxyRedirect
 type ProxyRedirect struct {
 
+	// Maximum TCP segment size applied to the proxied connections, zero if not clamped
+	ClampedMss int64 `json:"clamped-mss,omitempty"`
+
 	// Name of the proxy redirect
 	Name string `json:"name,omitempty"`
 
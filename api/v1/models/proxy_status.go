@@ -23,12 +23,18 @@ import (
 // swagger:model ProxyStatus
 type ProxyStatus struct {
 
+	// Datapath rule status of acknowledged proxy ports
+	DatapathRules []*ProxyDatapathRule `json:"datapath-rules"`
+
 	// IP address that the proxy listens on
 	IP string `json:"ip,omitempty"`
 
 	// Port range used for proxying
 	PortRange string `json:"port-range,omitempty"`
 
+	// Traffic statistics of configured redirects
+	RedirectStatistics []*ProxyRedirectStatistics `json:"redirect-statistics"`
+
 	// Detailed description of configured redirects
 	Redirects []*ProxyRedirect `json:"redirects"`
 
@@ -43,6 +49,14 @@ type ProxyStatus struct {
 func (m *ProxyStatus) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateDatapathRules(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateRedirectStatistics(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateRedirects(formats); err != nil {
 		res = append(res, err)
 	}
@@ -53,6 +67,56 @@ func (m *ProxyStatus) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ProxyStatus) validateDatapathRules(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.DatapathRules) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.DatapathRules); i++ {
+		if swag.IsZero(m.DatapathRules[i]) { // not required
+			continue
+		}
+
+		if m.DatapathRules[i] != nil {
+			if err := m.DatapathRules[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("datapath-rules" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *ProxyStatus) validateRedirectStatistics(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.RedirectStatistics) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.RedirectStatistics); i++ {
+		if swag.IsZero(m.RedirectStatistics[i]) { // not required
+			continue
+		}
+
+		if m.RedirectStatistics[i] != nil {
+			if err := m.RedirectStatistics[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("redirect-statistics" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *ProxyStatus) validateRedirects(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Redirects) { // not required
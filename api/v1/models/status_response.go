@@ -52,6 +52,9 @@ type StatusResponse struct {
 	// Status of all endpoint controllers
 	Controllers ControllerStatuses `json:"controllers,omitempty"`
 
+	// Status of the datapath self-test
+	DatapathSelftest *DatapathSelftestStatus `json:"datapath-selftest,omitempty"`
+
 	// Status of transparent encryption
 	Encryption *EncryptionStatus `json:"encryption,omitempty"`
 
@@ -122,6 +125,10 @@ func (m *StatusResponse) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateDatapathSelftest(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateEncryption(formats); err != nil {
 		res = append(res, err)
 	}
@@ -314,6 +321,24 @@ func (m *StatusResponse) validateControllers(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *StatusResponse) validateDatapathSelftest(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.DatapathSelftest) { // not required
+		return nil
+	}
+
+	if m.DatapathSelftest != nil {
+		if err := m.DatapathSelftest.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("datapath-selftest")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *StatusResponse) validateEncryption(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Encryption) { // not required
@@ -26,6 +26,17 @@ func (in *BPFMapStatus) DeepCopyInto(out *BPFMapStatus) {
 			}
 		}
 	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = make([]*BPFMapMigration, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(BPFMapMigration)
+				**out = **in
+			}
+		}
+	}
 	return
 }
 
@@ -236,6 +247,49 @@ func (in *ControllerStatusStatus) DeepCopy() *ControllerStatusStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatapathSelftestProbe) DeepCopyInto(out *DatapathSelftestProbe) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatapathSelftestProbe.
+func (in *DatapathSelftestProbe) DeepCopy() *DatapathSelftestProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(DatapathSelftestProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatapathSelftestStatus) DeepCopyInto(out *DatapathSelftestStatus) {
+	*out = *in
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]*DatapathSelftestProbe, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(DatapathSelftestProbe)
+				**out = **in
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatapathSelftestStatus.
+func (in *DatapathSelftestStatus) DeepCopy() *DatapathSelftestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatapathSelftestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EncryptionStatus) DeepCopyInto(out *EncryptionStatus) {
 	*out = *in
@@ -773,6 +827,28 @@ func (in *ProxyStatistics) DeepCopy() *ProxyStatistics {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyStatus) DeepCopyInto(out *ProxyStatus) {
 	*out = *in
+	if in.DatapathRules != nil {
+		in, out := &in.DatapathRules, &out.DatapathRules
+		*out = make([]*ProxyDatapathRule, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ProxyDatapathRule)
+				**out = **in
+			}
+		}
+	}
+	if in.RedirectStatistics != nil {
+		in, out := &in.RedirectStatistics, &out.RedirectStatistics
+		*out = make([]*ProxyRedirectStatistics, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ProxyRedirectStatistics)
+				**out = **in
+			}
+		}
+	}
 	if in.Redirects != nil {
 		in, out := &in.Redirects, &out.Redirects
 		*out = make([]*ProxyRedirect, len(*in))
@@ -889,6 +965,11 @@ func (in *StatusResponse) DeepCopyInto(out *StatusResponse) {
 			}
 		}
 	}
+	if in.DatapathSelftest != nil {
+		in, out := &in.DatapathSelftest, &out.DatapathSelftest
+		*out = new(DatapathSelftestStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Encryption != nil {
 		in, out := &in.Encryption, &out.Encryption
 		*out = new(EncryptionStatus)
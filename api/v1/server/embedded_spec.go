@@ -111,6 +111,28 @@ func init() {
         }
       }
     },
+    "/controller/{name}/trigger": {
+      "put": {
+        "description": "Manually triggers an immediate run of the specified controller and\nresets its exponential error back-off.\n",
+        "tags": [
+          "daemon"
+        ],
+        "summary": "Trigger a controller run",
+        "parameters": [
+          {
+            "$ref": "#/parameters/controller-name"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "404": {
+            "description": "Controller not found"
+          }
+        }
+      }
+    },
     "/debuginfo": {
       "get": {
         "tags": [
@@ -165,6 +187,43 @@ func init() {
         }
       }
     },
+    "/endpoint/groups": {
+      "get": {
+        "description": "Retrieves aggregate counts of endpoints grouped by the values they carry\nfor the given label keys, optionally restricted to endpoints matching the\nprovided labels.\n",
+        "tags": [
+          "endpoint"
+        ],
+        "summary": "Retrieves endpoints grouped by the values of the given labels.",
+        "parameters": [
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "description": "Label keys to group endpoints by",
+            "name": "group-by",
+            "in": "query"
+          },
+          {
+            "$ref": "#/parameters/labels"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/EndpointGroup"
+              }
+            }
+          },
+          "429": {
+            "description": "Rate-limiting too many requests in the given time frame"
+          }
+        }
+      }
+    },
     "/endpoint/{id}": {
       "get": {
         "description": "Returns endpoint information\n",
@@ -1028,6 +1087,12 @@ func init() {
         "parameters": [
           {
             "$ref": "#/parameters/policy-rules"
+          },
+          {
+            "type": "boolean",
+            "description": "Evaluate the provided rules in shadow against the enforced policy instead of enforcing them. The response carries a per-endpoint divergence report instead of the policy tree.",
+            "name": "shadow",
+            "in": "query"
           }
         ],
         "responses": {
@@ -1101,6 +1166,54 @@ func init() {
         }
       }
     },
+    "/policy/flow-override": {
+      "put": {
+        "description": "Installs a temporary allow entry into the policy map of the given\nendpoint for troubleshooting purposes. The entry is removed\nautomatically when its time to live expires. Requires the agent to\nrun with flow overrides enabled.\n",
+        "tags": [
+          "policy"
+        ],
+        "summary": "Install a temporary allow entry for a specific flow",
+        "parameters": [
+          {
+            "name": "flow-override",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/FlowOverride"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "400": {
+            "description": "Invalid flow override",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Invalid"
+          },
+          "403": {
+            "description": "Flow overrides are disabled in the agent",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Disabled"
+          },
+          "404": {
+            "description": "Endpoint not found"
+          },
+          "500": {
+            "description": "Flow override installation failed",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/policy/resolve": {
       "get": {
         "tags": [
@@ -1227,6 +1340,74 @@ func init() {
         }
       }
     },
+    "/profile": {
+      "get": {
+        "description": "Captures a pprof profile or a goroutine dump of the agent with a\nbounded duration and returns it together with a snapshot of the\ncurrent agent state, avoiding the need for a permanently exposed\npprof endpoint. Captures are rate limited and only a single\ncapture may be in progress at any given time.\n",
+        "tags": [
+          "daemon"
+        ],
+        "summary": "Capture a runtime profile of the agent",
+        "parameters": [
+          {
+            "enum": [
+              "cpu",
+              "heap",
+              "block",
+              "mutex",
+              "goroutine"
+            ],
+            "type": "string",
+            "default": "cpu",
+            "description": "Type of profile to capture",
+            "name": "profile",
+            "in": "query"
+          },
+          {
+            "maximum": 60,
+            "minimum": 1,
+            "type": "integer",
+            "default": 10,
+            "description": "Duration in seconds over which CPU, block and mutex profiles are sampled",
+            "name": "seconds",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ProfileResult"
+            }
+          },
+          "500": {
+            "description": "Profile capture failed",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
+    "/proxy/listeners": {
+      "get": {
+        "tags": [
+          "daemon"
+        ],
+        "summary": "Retrieve the list of active proxy listeners on the node",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/ProxyListener"
+              }
+            }
+          }
+        }
+      }
+    },
     "/recorder": {
       "get": {
         "tags": [
@@ -1462,6 +1643,25 @@ func init() {
       "description": "IP address",
       "type": "string"
     },
+    "AgentEvent": {
+      "description": "Datapath impacting event recorded by the agent",
+      "type": "object",
+      "properties": {
+        "message": {
+          "description": "Human readable description of the event",
+          "type": "string"
+        },
+        "reason": {
+          "description": "Reason classifying the event",
+          "type": "string"
+        },
+        "timestamp": {
+          "description": "Timestamp at which the event was recorded",
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
     "AddressPair": {
       "description": "Addressing information of an endpoint",
       "type": "object",
@@ -1548,6 +1748,28 @@ func init() {
         }
       }
     },
+    "BPFMapMigration": {
+      "description": "Migration plan entry for a pinned BPF map left behind by a previous agent version",
+      "type": "object",
+      "properties": {
+        "action": {
+          "description": "How the pinned map will be handled (reuse, migrate or recreate)",
+          "type": "string"
+        },
+        "disruption": {
+          "description": "Estimate of the datapath disruption caused by the action",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the pinned BPF map",
+          "type": "string"
+        },
+        "reason": {
+          "description": "Reason for the chosen action",
+          "type": "string"
+        }
+      }
+    },
     "BPFMapProperties": {
       "description": "BPF map properties",
       "type": "object",
@@ -1576,6 +1798,13 @@ func init() {
           "items": {
             "$ref": "#/definitions/BPFMapProperties"
           }
+        },
+        "migration": {
+          "description": "BPF map migration plan computed at agent startup",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/BPFMapMigration"
+          }
         }
       }
     },
@@ -1886,6 +2115,11 @@ func init() {
       "description": "The controllable configuration of the daemon.",
       "type": "object",
       "properties": {
+        "endpoint-routes": {
+          "description": "When set, migrates the node to the given endpoint routes mode at\nruntime without recreating endpoints\n",
+          "type": "boolean",
+          "x-nullable": true
+        },
         "options": {
           "description": "Changeable configuration",
           "$ref": "#/definitions/ConfigurationMap"
@@ -1984,10 +2218,59 @@ func init() {
         "ipvlan"
       ]
     },
+    "DatapathSelftestProbe": {
+      "description": "Result of a single datapath self-test probe",
+      "type": "object",
+      "properties": {
+        "msg": {
+          "description": "Explanation of the state, e.g. the failure cause",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the probe",
+          "type": "string"
+        },
+        "packets-sent": {
+          "description": "Number of packets injected by the probe",
+          "type": "integer"
+        },
+        "state": {
+          "description": "State of the probe (ok, failure or skipped)",
+          "type": "string"
+        }
+      }
+    },
+    "DatapathSelftestStatus": {
+      "description": "Status of the datapath packet generator self-test",
+      "type": "object",
+      "properties": {
+        "msg": {
+          "description": "Human readable summary of the self-test result",
+          "type": "string"
+        },
+        "probes": {
+          "description": "Per-probe results of the last self-test execution",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DatapathSelftestProbe"
+          }
+        },
+        "state": {
+          "description": "Overall state of the self-test (ok, failure or disabled)",
+          "type": "string"
+        }
+      }
+    },
     "DebugInfo": {
       "description": "groups some debugging related information on the agent",
       "type": "object",
       "properties": {
+        "agent-events": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/AgentEvent"
+          }
+        },
         "cilium-memory-map": {
           "type": "string"
         },
@@ -2033,6 +2316,12 @@ func init() {
             "$ref": "#/definitions/Service"
           }
         },
+        "socket-lb-attachments": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/SocketLBAttachment"
+          }
+        },
         "subsystem": {
           "type": "object",
           "additionalProperties": {
@@ -2226,6 +2515,30 @@ func init() {
         }
       }
     },
+    "EndpointGroup": {
+      "description": "Group of endpoints sharing the same values for a set of labels",
+      "type": "object",
+      "properties": {
+        "count": {
+          "description": "Number of endpoints in the group",
+          "type": "integer"
+        },
+        "endpoint-ids": {
+          "description": "IDs of the endpoints in the group",
+          "type": "array",
+          "items": {
+            "type": "integer"
+          }
+        },
+        "labels": {
+          "description": "Labels shared by all endpoints in the group",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "EndpointHealth": {
       "description": "Health of the endpoint\n\n+deepequal-gen=true",
       "type": "object",
@@ -2396,6 +2709,11 @@ func init() {
       "description": "Policy information of an endpoint",
       "type": "object",
       "properties": {
+        "policy-map-update-delay": {
+          "description": "Time between the last policy repository change and the corresponding\npolicy map update completing for this endpoint",
+          "type": "string",
+          "format": "duration"
+        },
         "proxy-policy-revision": {
           "description": "The policy revision currently enforced in the proxy for this endpoint",
           "type": "integer"
@@ -2520,6 +2838,36 @@ func init() {
     "Error": {
       "type": "string"
     },
+    "FlowOverride": {
+      "description": "Temporary allow entry for a specific flow installed for\ntroubleshooting purposes",
+      "type": "object",
+      "properties": {
+        "endpoint-id": {
+          "description": "Local endpoint whose policy is overridden",
+          "type": "integer"
+        },
+        "identity": {
+          "description": "Security identity of the remote peer",
+          "type": "integer"
+        },
+        "ingress": {
+          "description": "True if the override applies to ingress traffic, false for egress",
+          "type": "boolean"
+        },
+        "port": {
+          "description": "Destination port, zero for all ports",
+          "type": "integer"
+        },
+        "protocol": {
+          "description": "Layer 4 protocol (tcp, udp or any)",
+          "type": "string"
+        },
+        "ttl-seconds": {
+          "description": "Time in seconds after which the override is removed",
+          "type": "integer"
+        }
+      }
+    },
     "FrontendAddress": {
       "description": "Layer 4 address. The protocol is currently ignored, all services will\nbehave as if protocol any is specified. To restrict to a particular\nprotocol, use policy.\n",
       "type": "object",
@@ -3421,16 +3769,113 @@ func init() {
         }
       }
     },
-    "ProxyRedirect": {
-      "description": "Configured proxy redirection state",
+    "ProxyDatapathRule": {
+      "description": "Datapath rule status of an acknowledged proxy port",
       "type": "object",
       "properties": {
-        "name": {
-          "description": "Name of the proxy redirect",
-          "type": "string"
+        "installed": {
+          "description": "True when the datapath rules for the port are installed",
+          "type": "boolean"
         },
-        "proxy": {
-          "description": "Name of the proxy this redirect points to",
+        "last-install": {
+          "description": "Timestamp of the last successful datapath rule install for the port,\nomitted for rules restored from a previous agent run",
+          "type": "string",
+          "format": "date-time"
+        },
+        "listener": {
+          "description": "Name of the proxy listener the rules redirect to",
+          "type": "string"
+        },
+        "packets": {
+          "description": "Number of packets matched by the datapath rules",
+          "type": "integer"
+        },
+        "port": {
+          "description": "Proxy port the datapath rules redirect to",
+          "type": "integer"
+        }
+      }
+    },
+    "ProfileContext": {
+      "description": "Snapshot of agent state captured together with a runtime profile",
+      "type": "object",
+      "properties": {
+        "endpoints-regenerating": {
+          "description": "Number of endpoints currently waiting for or undergoing regeneration",
+          "type": "integer"
+        },
+        "proxy-redirects": {
+          "description": "Number of configured proxy redirects",
+          "type": "integer"
+        }
+      }
+    },
+    "ProfileResult": {
+      "description": "Captured runtime profile of the agent",
+      "type": "object",
+      "properties": {
+        "context": {
+          "$ref": "#/definitions/ProfileContext"
+        },
+        "data": {
+          "description": "Captured profile in pprof format, or a plain text goroutine dump",
+          "type": "string",
+          "format": "byte"
+        },
+        "duration-seconds": {
+          "description": "Duration in seconds over which the profile was sampled",
+          "type": "integer"
+        },
+        "type": {
+          "description": "Type of the captured profile",
+          "type": "string"
+        }
+      }
+    },
+    "ProxyListener": {
+      "description": "State of a single proxy listener on the node",
+      "type": "object",
+      "properties": {
+        "acknowledged": {
+          "description": "True when the datapath rules for the listener port have been installed",
+          "type": "boolean"
+        },
+        "ingress": {
+          "description": "True if the listener handles ingress traffic, false for egress",
+          "type": "boolean"
+        },
+        "name": {
+          "description": "Name of the listener",
+          "type": "string"
+        },
+        "parser": {
+          "description": "L7 parser type served by the listener",
+          "type": "string"
+        },
+        "port": {
+          "description": "Port the listener is listening on, zero if not allocated",
+          "type": "integer"
+        },
+        "redirects": {
+          "description": "Number of redirects using the listener",
+          "type": "integer"
+        }
+      }
+    },
+    "ProxyRedirect": {
+      "description": "Configured proxy redirection state",
+      "type": "object",
+      "properties": {
+        "clamped-mss": {
+          "description": "Maximum TCP segment size applied to the proxied connections, zero if not clamped",
+          "type": "integer"
+        },
+        "name": {
+          "description": "Name of the proxy redirect",
+          "type": "string"
+        },
+        "proxy": {
+          "description": "Name of the proxy this redirect points to",
           "type": "string"
         },
         "proxy-port": {
@@ -3439,6 +3884,36 @@ func init() {
         }
       }
     },
+    "ProxyRedirectStatistics": {
+      "description": "Traffic statistics of a configured proxy redirect",
+      "type": "object",
+      "properties": {
+        "active-connections": {
+          "description": "Number of currently proxied connections, zero for proxies that do\nnot track connections",
+          "type": "integer"
+        },
+        "denied-requests": {
+          "description": "Number of requests denied by policy",
+          "type": "integer"
+        },
+        "name": {
+          "description": "Name of the proxy redirect",
+          "type": "string"
+        },
+        "processed-requests": {
+          "description": "Number of requests received by the redirect",
+          "type": "integer"
+        },
+        "proxy": {
+          "description": "Name of the proxy this redirect points to",
+          "type": "string"
+        },
+        "upstream-errors": {
+          "description": "Number of errors reported by the upstream connection",
+          "type": "integer"
+        }
+      }
+    },
     "ProxyStatistics": {
       "description": "Statistics of a set of proxy redirects for an endpoint\n\n+k8s:deepcopy-gen=true",
       "type": "object",
@@ -3473,6 +3948,13 @@ func init() {
       "description": "Status of proxy\n\n+k8s:deepcopy-gen=true",
       "type": "object",
       "properties": {
+        "datapath-rules": {
+          "description": "Datapath rule status of acknowledged proxy ports",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ProxyDatapathRule"
+          }
+        },
         "ip": {
           "description": "IP address that the proxy listens on",
           "type": "string"
@@ -3481,6 +3963,13 @@ func init() {
           "description": "Port range used for proxying",
           "type": "string"
         },
+        "redirect-statistics": {
+          "description": "Traffic statistics of configured redirects",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ProxyRedirectStatistics"
+          }
+        },
         "redirects": {
           "description": "Detailed description of configured redirects",
           "type": "array",
@@ -3803,6 +4292,27 @@ func init() {
         }
       }
     },
+    "SocketLBAttachment": {
+      "description": "Socket-LB program attachment point in the cgroup hierarchy",
+      "type": "object",
+      "properties": {
+        "attach-type": {
+          "description": "Attach type of the programs (e.g. connect4, sendmsg6)",
+          "type": "string"
+        },
+        "cgroup-path": {
+          "description": "Path of the cgroup the programs are attached to",
+          "type": "string"
+        },
+        "prog-ids": {
+          "description": "IDs of the attached BPF programs",
+          "type": "array",
+          "items": {
+            "type": "integer"
+          }
+        }
+      }
+    },
     "Status": {
       "description": "Status of an individual component",
       "type": "object",
@@ -3863,6 +4373,10 @@ func init() {
           "description": "Status of all endpoint controllers",
           "$ref": "#/definitions/ControllerStatuses"
         },
+        "datapath-selftest": {
+          "description": "Status of the datapath self-test",
+          "$ref": "#/definitions/DatapathSelftestStatus"
+        },
         "encryption": {
           "description": "Status of transparent encryption",
           "$ref": "#/definitions/EncryptionStatus"
@@ -4034,6 +4548,13 @@ func init() {
       "name": "cidr",
       "in": "query"
     },
+    "controller-name": {
+      "type": "string",
+      "description": "Name of the controller",
+      "name": "name",
+      "in": "path",
+      "required": true
+    },
     "endpoint-change-request": {
       "name": "endpoint",
       "in": "body",
@@ -4279,6 +4800,32 @@ func init() {
         }
       }
     },
+    "/controller/{name}/trigger": {
+      "put": {
+        "description": "Manually triggers an immediate run of the specified controller and\nresets its exponential error back-off.\n",
+        "tags": [
+          "daemon"
+        ],
+        "summary": "Trigger a controller run",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the controller",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "404": {
+            "description": "Controller not found"
+          }
+        }
+      }
+    },
     "/debuginfo": {
       "get": {
         "tags": [
@@ -4338,6 +4885,48 @@ func init() {
         }
       }
     },
+    "/endpoint/groups": {
+      "get": {
+        "description": "Retrieves aggregate counts of endpoints grouped by the values they carry\nfor the given label keys, optionally restricted to endpoints matching the\nprovided labels.\n",
+        "tags": [
+          "endpoint"
+        ],
+        "summary": "Retrieves endpoints grouped by the values of the given labels.",
+        "parameters": [
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "description": "Label keys to group endpoints by",
+            "name": "group-by",
+            "in": "query"
+          },
+          {
+            "description": "List of labels\n",
+            "name": "labels",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/Labels"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/EndpointGroup"
+              }
+            }
+          },
+          "429": {
+            "description": "Rate-limiting too many requests in the given time frame"
+          }
+        }
+      }
+    },
     "/endpoint/{id}": {
       "get": {
         "description": "Returns endpoint information\n",
@@ -5312,6 +5901,12 @@ func init() {
             "schema": {
               "type": "string"
             }
+          },
+          {
+            "type": "boolean",
+            "description": "Evaluate the provided rules in shadow against the enforced policy instead of enforcing them. The response carries a per-endpoint divergence report instead of the policy tree.",
+            "name": "shadow",
+            "in": "query"
           }
         ],
         "responses": {
@@ -5385,6 +5980,54 @@ func init() {
         }
       }
     },
+    "/policy/flow-override": {
+      "put": {
+        "description": "Installs a temporary allow entry into the policy map of the given\nendpoint for troubleshooting purposes. The entry is removed\nautomatically when its time to live expires. Requires the agent to\nrun with flow overrides enabled.\n",
+        "tags": [
+          "policy"
+        ],
+        "summary": "Install a temporary allow entry for a specific flow",
+        "parameters": [
+          {
+            "name": "flow-override",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/FlowOverride"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "400": {
+            "description": "Invalid flow override",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Invalid"
+          },
+          "403": {
+            "description": "Flow overrides are disabled in the agent",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Disabled"
+          },
+          "404": {
+            "description": "Endpoint not found"
+          },
+          "500": {
+            "description": "Flow override installation failed",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/policy/resolve": {
       "get": {
         "tags": [
@@ -5528,6 +6171,74 @@ func init() {
         }
       }
     },
+    "/profile": {
+      "get": {
+        "description": "Captures a pprof profile or a goroutine dump of the agent with a\nbounded duration and returns it together with a snapshot of the\ncurrent agent state, avoiding the need for a permanently exposed\npprof endpoint. Captures are rate limited and only a single\ncapture may be in progress at any given time.\n",
+        "tags": [
+          "daemon"
+        ],
+        "summary": "Capture a runtime profile of the agent",
+        "parameters": [
+          {
+            "enum": [
+              "cpu",
+              "heap",
+              "block",
+              "mutex",
+              "goroutine"
+            ],
+            "type": "string",
+            "default": "cpu",
+            "description": "Type of profile to capture",
+            "name": "profile",
+            "in": "query"
+          },
+          {
+            "maximum": 60,
+            "minimum": 1,
+            "type": "integer",
+            "default": 10,
+            "description": "Duration in seconds over which CPU, block and mutex profiles are sampled",
+            "name": "seconds",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ProfileResult"
+            }
+          },
+          "500": {
+            "description": "Profile capture failed",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
+    "/proxy/listeners": {
+      "get": {
+        "tags": [
+          "daemon"
+        ],
+        "summary": "Retrieve the list of active proxy listeners on the node",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/ProxyListener"
+              }
+            }
+          }
+        }
+      }
+    },
     "/recorder": {
       "get": {
         "tags": [
@@ -5799,6 +6510,25 @@ func init() {
       "description": "IP address",
       "type": "string"
     },
+    "AgentEvent": {
+      "description": "Datapath impacting event recorded by the agent",
+      "type": "object",
+      "properties": {
+        "message": {
+          "description": "Human readable description of the event",
+          "type": "string"
+        },
+        "reason": {
+          "description": "Reason classifying the event",
+          "type": "string"
+        },
+        "timestamp": {
+          "description": "Timestamp at which the event was recorded",
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
     "AddressPair": {
       "description": "Addressing information of an endpoint",
       "type": "object",
@@ -5885,6 +6615,28 @@ func init() {
         }
       }
     },
+    "BPFMapMigration": {
+      "description": "Migration plan entry for a pinned BPF map left behind by a previous agent version",
+      "type": "object",
+      "properties": {
+        "action": {
+          "description": "How the pinned map will be handled (reuse, migrate or recreate)",
+          "type": "string"
+        },
+        "disruption": {
+          "description": "Estimate of the datapath disruption caused by the action",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the pinned BPF map",
+          "type": "string"
+        },
+        "reason": {
+          "description": "Reason for the chosen action",
+          "type": "string"
+        }
+      }
+    },
     "BPFMapProperties": {
       "description": "BPF map properties",
       "type": "object",
@@ -5913,6 +6665,13 @@ func init() {
           "items": {
             "$ref": "#/definitions/BPFMapProperties"
           }
+        },
+        "migration": {
+          "description": "BPF map migration plan computed at agent startup",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/BPFMapMigration"
+          }
         }
       }
     },
@@ -6275,6 +7034,11 @@ func init() {
       "description": "The controllable configuration of the daemon.",
       "type": "object",
       "properties": {
+        "endpoint-routes": {
+          "description": "When set, migrates the node to the given endpoint routes mode at\nruntime without recreating endpoints\n",
+          "type": "boolean",
+          "x-nullable": true
+        },
         "options": {
           "description": "Changeable configuration",
           "$ref": "#/definitions/ConfigurationMap"
@@ -6387,10 +7151,59 @@ func init() {
         "ipvlan"
       ]
     },
+    "DatapathSelftestProbe": {
+      "description": "Result of a single datapath self-test probe",
+      "type": "object",
+      "properties": {
+        "msg": {
+          "description": "Explanation of the state, e.g. the failure cause",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the probe",
+          "type": "string"
+        },
+        "packets-sent": {
+          "description": "Number of packets injected by the probe",
+          "type": "integer"
+        },
+        "state": {
+          "description": "State of the probe (ok, failure or skipped)",
+          "type": "string"
+        }
+      }
+    },
+    "DatapathSelftestStatus": {
+      "description": "Status of the datapath packet generator self-test",
+      "type": "object",
+      "properties": {
+        "msg": {
+          "description": "Human readable summary of the self-test result",
+          "type": "string"
+        },
+        "probes": {
+          "description": "Per-probe results of the last self-test execution",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DatapathSelftestProbe"
+          }
+        },
+        "state": {
+          "description": "Overall state of the self-test (ok, failure or disabled)",
+          "type": "string"
+        }
+      }
+    },
     "DebugInfo": {
       "description": "groups some debugging related information on the agent",
       "type": "object",
       "properties": {
+        "agent-events": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/AgentEvent"
+          }
+        },
         "cilium-memory-map": {
           "type": "string"
         },
@@ -6436,6 +7249,12 @@ func init() {
             "$ref": "#/definitions/Service"
           }
         },
+        "socket-lb-attachments": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/SocketLBAttachment"
+          }
+        },
         "subsystem": {
           "type": "object",
           "additionalProperties": {
@@ -6638,6 +7457,30 @@ func init() {
         }
       }
     },
+    "EndpointGroup": {
+      "description": "Group of endpoints sharing the same values for a set of labels",
+      "type": "object",
+      "properties": {
+        "count": {
+          "description": "Number of endpoints in the group",
+          "type": "integer"
+        },
+        "endpoint-ids": {
+          "description": "IDs of the endpoints in the group",
+          "type": "array",
+          "items": {
+            "type": "integer"
+          }
+        },
+        "labels": {
+          "description": "Labels shared by all endpoints in the group",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "EndpointHealth": {
       "description": "Health of the endpoint\n\n+deepequal-gen=true",
       "type": "object",
@@ -6808,6 +7651,11 @@ func init() {
       "description": "Policy information of an endpoint",
       "type": "object",
       "properties": {
+        "policy-map-update-delay": {
+          "description": "Time between the last policy repository change and the corresponding\npolicy map update completing for this endpoint",
+          "type": "string",
+          "format": "duration"
+        },
         "proxy-policy-revision": {
           "description": "The policy revision currently enforced in the proxy for this endpoint",
           "type": "integer"
@@ -6932,6 +7780,36 @@ func init() {
     "Error": {
       "type": "string"
     },
+    "FlowOverride": {
+      "description": "Temporary allow entry for a specific flow installed for\ntroubleshooting purposes",
+      "type": "object",
+      "properties": {
+        "endpoint-id": {
+          "description": "Local endpoint whose policy is overridden",
+          "type": "integer"
+        },
+        "identity": {
+          "description": "Security identity of the remote peer",
+          "type": "integer"
+        },
+        "ingress": {
+          "description": "True if the override applies to ingress traffic, false for egress",
+          "type": "boolean"
+        },
+        "port": {
+          "description": "Destination port, zero for all ports",
+          "type": "integer"
+        },
+        "protocol": {
+          "description": "Layer 4 protocol (tcp, udp or any)",
+          "type": "string"
+        },
+        "ttl-seconds": {
+          "description": "Time in seconds after which the override is removed",
+          "type": "integer"
+        }
+      }
+    },
     "FrontendAddress": {
       "description": "Layer 4 address. The protocol is currently ignored, all services will\nbehave as if protocol any is specified. To restrict to a particular\nprotocol, use policy.\n",
       "type": "object",
@@ -8061,10 +8939,107 @@ func init() {
         }
       }
     },
+    "ProxyDatapathRule": {
+      "description": "Datapath rule status of an acknowledged proxy port",
+      "type": "object",
+      "properties": {
+        "installed": {
+          "description": "True when the datapath rules for the port are installed",
+          "type": "boolean"
+        },
+        "last-install": {
+          "description": "Timestamp of the last successful datapath rule install for the port,\nomitted for rules restored from a previous agent run",
+          "type": "string",
+          "format": "date-time"
+        },
+        "listener": {
+          "description": "Name of the proxy listener the rules redirect to",
+          "type": "string"
+        },
+        "packets": {
+          "description": "Number of packets matched by the datapath rules",
+          "type": "integer"
+        },
+        "port": {
+          "description": "Proxy port the datapath rules redirect to",
+          "type": "integer"
+        }
+      }
+    },
+    "ProfileContext": {
+      "description": "Snapshot of agent state captured together with a runtime profile",
+      "type": "object",
+      "properties": {
+        "endpoints-regenerating": {
+          "description": "Number of endpoints currently waiting for or undergoing regeneration",
+          "type": "integer"
+        },
+        "proxy-redirects": {
+          "description": "Number of configured proxy redirects",
+          "type": "integer"
+        }
+      }
+    },
+    "ProfileResult": {
+      "description": "Captured runtime profile of the agent",
+      "type": "object",
+      "properties": {
+        "context": {
+          "$ref": "#/definitions/ProfileContext"
+        },
+        "data": {
+          "description": "Captured profile in pprof format, or a plain text goroutine dump",
+          "type": "string",
+          "format": "byte"
+        },
+        "duration-seconds": {
+          "description": "Duration in seconds over which the profile was sampled",
+          "type": "integer"
+        },
+        "type": {
+          "description": "Type of the captured profile",
+          "type": "string"
+        }
+      }
+    },
+    "ProxyListener": {
+      "description": "State of a single proxy listener on the node",
+      "type": "object",
+      "properties": {
+        "acknowledged": {
+          "description": "True when the datapath rules for the listener port have been installed",
+          "type": "boolean"
+        },
+        "ingress": {
+          "description": "True if the listener handles ingress traffic, false for egress",
+          "type": "boolean"
+        },
+        "name": {
+          "description": "Name of the listener",
+          "type": "string"
+        },
+        "parser": {
+          "description": "L7 parser type served by the listener",
+          "type": "string"
+        },
+        "port": {
+          "description": "Port the listener is listening on, zero if not allocated",
+          "type": "integer"
+        },
+        "redirects": {
+          "description": "Number of redirects using the listener",
+          "type": "integer"
+        }
+      }
+    },
     "ProxyRedirect": {
       "description": "Configured proxy redirection state",
       "type": "object",
       "properties": {
+        "clamped-mss": {
+          "description": "Maximum TCP segment size applied to the proxied connections, zero if not clamped",
+          "type": "integer"
+        },
         "name": {
           "description": "Name of the proxy redirect",
           "type": "string"
@@ -8079,6 +9054,36 @@ func init() {
         }
       }
     },
+    "ProxyRedirectStatistics": {
+      "description": "Traffic statistics of a configured proxy redirect",
+      "type": "object",
+      "properties": {
+        "active-connections": {
+          "description": "Number of currently proxied connections, zero for proxies that do\nnot track connections",
+          "type": "integer"
+        },
+        "denied-requests": {
+          "description": "Number of requests denied by policy",
+          "type": "integer"
+        },
+        "name": {
+          "description": "Name of the proxy redirect",
+          "type": "string"
+        },
+        "processed-requests": {
+          "description": "Number of requests received by the redirect",
+          "type": "integer"
+        },
+        "proxy": {
+          "description": "Name of the proxy this redirect points to",
+          "type": "string"
+        },
+        "upstream-errors": {
+          "description": "Number of errors reported by the upstream connection",
+          "type": "integer"
+        }
+      }
+    },
     "ProxyStatistics": {
       "description": "Statistics of a set of proxy redirects for an endpoint\n\n+k8s:deepcopy-gen=true",
       "type": "object",
@@ -8113,6 +9118,13 @@ func init() {
       "description": "Status of proxy\n\n+k8s:deepcopy-gen=true",
       "type": "object",
       "properties": {
+        "datapath-rules": {
+          "description": "Datapath rule status of acknowledged proxy ports",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ProxyDatapathRule"
+          }
+        },
         "ip": {
           "description": "IP address that the proxy listens on",
           "type": "string"
@@ -8121,6 +9133,13 @@ func init() {
           "description": "Port range used for proxying",
           "type": "string"
         },
+        "redirect-statistics": {
+          "description": "Traffic statistics of configured redirects",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ProxyRedirectStatistics"
+          }
+        },
         "redirects": {
           "description": "Detailed description of configured redirects",
           "type": "array",
@@ -8482,6 +9501,27 @@ func init() {
         }
       }
     },
+    "SocketLBAttachment": {
+      "description": "Socket-LB program attachment point in the cgroup hierarchy",
+      "type": "object",
+      "properties": {
+        "attach-type": {
+          "description": "Attach type of the programs (e.g. connect4, sendmsg6)",
+          "type": "string"
+        },
+        "cgroup-path": {
+          "description": "Path of the cgroup the programs are attached to",
+          "type": "string"
+        },
+        "prog-ids": {
+          "description": "IDs of the attached BPF programs",
+          "type": "array",
+          "items": {
+            "type": "integer"
+          }
+        }
+      }
+    },
     "Status": {
       "description": "Status of an individual component",
       "type": "object",
@@ -8542,6 +9582,10 @@ func init() {
           "description": "Status of all endpoint controllers",
           "$ref": "#/definitions/ControllerStatuses"
         },
+        "datapath-selftest": {
+          "description": "Status of the datapath self-test",
+          "$ref": "#/definitions/DatapathSelftestStatus"
+        },
         "encryption": {
           "description": "Status of transparent encryption",
           "$ref": "#/definitions/EncryptionStatus"
@@ -8713,6 +9757,13 @@ func init() {
       "name": "cidr",
       "in": "query"
     },
+    "controller-name": {
+      "type": "string",
+      "description": "Name of the controller",
+      "name": "name",
+      "in": "path",
+      "required": true
+    },
     "endpoint-change-request": {
       "name": "endpoint",
       "in": "body",
@@ -87,6 +87,9 @@ func NewCiliumAPIAPI(spec *loads.Document) *CiliumAPIAPI {
 		EndpointGetEndpointHandler: endpoint.GetEndpointHandlerFunc(func(params endpoint.GetEndpointParams) middleware.Responder {
 			return middleware.NotImplemented("operation endpoint.GetEndpoint has not yet been implemented")
 		}),
+		EndpointGetEndpointGroupsHandler: endpoint.GetEndpointGroupsHandlerFunc(func(params endpoint.GetEndpointGroupsParams) middleware.Responder {
+			return middleware.NotImplemented("operation endpoint.GetEndpointGroups has not yet been implemented")
+		}),
 		EndpointGetEndpointIDHandler: endpoint.GetEndpointIDHandlerFunc(func(params endpoint.GetEndpointIDParams) middleware.Responder {
 			return middleware.NotImplemented("operation endpoint.GetEndpointID has not yet been implemented")
 		}),
@@ -150,6 +153,12 @@ func NewCiliumAPIAPI(spec *loads.Document) *CiliumAPIAPI {
 		PrefilterGetPrefilterHandler: prefilter.GetPrefilterHandlerFunc(func(params prefilter.GetPrefilterParams) middleware.Responder {
 			return middleware.NotImplemented("operation prefilter.GetPrefilter has not yet been implemented")
 		}),
+		DaemonGetProfileHandler: daemon.GetProfileHandlerFunc(func(params daemon.GetProfileParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemon.GetProfile has not yet been implemented")
+		}),
+		DaemonGetProxyListenersHandler: daemon.GetProxyListenersHandlerFunc(func(params daemon.GetProxyListenersParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemon.GetProxyListeners has not yet been implemented")
+		}),
 		RecorderGetRecorderHandler: recorder.GetRecorderHandlerFunc(func(params recorder.GetRecorderParams) middleware.Responder {
 			return middleware.NotImplemented("operation recorder.GetRecorder has not yet been implemented")
 		}),
@@ -186,12 +195,18 @@ func NewCiliumAPIAPI(spec *loads.Document) *CiliumAPIAPI {
 		IpamPostIpamIPHandler: ipam.PostIpamIPHandlerFunc(func(params ipam.PostIpamIPParams) middleware.Responder {
 			return middleware.NotImplemented("operation ipam.PostIpamIP has not yet been implemented")
 		}),
+		DaemonPutControllerNameTriggerHandler: daemon.PutControllerNameTriggerHandlerFunc(func(params daemon.PutControllerNameTriggerParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemon.PutControllerNameTrigger has not yet been implemented")
+		}),
 		EndpointPutEndpointIDHandler: endpoint.PutEndpointIDHandlerFunc(func(params endpoint.PutEndpointIDParams) middleware.Responder {
 			return middleware.NotImplemented("operation endpoint.PutEndpointID has not yet been implemented")
 		}),
 		PolicyPutPolicyHandler: policy.PutPolicyHandlerFunc(func(params policy.PutPolicyParams) middleware.Responder {
 			return middleware.NotImplemented("operation policy.PutPolicy has not yet been implemented")
 		}),
+		PolicyPutPolicyFlowOverrideHandler: policy.PutPolicyFlowOverrideHandlerFunc(func(params policy.PutPolicyFlowOverrideParams) middleware.Responder {
+			return middleware.NotImplemented("operation policy.PutPolicyFlowOverride has not yet been implemented")
+		}),
 		RecorderPutRecorderIDHandler: recorder.PutRecorderIDHandlerFunc(func(params recorder.PutRecorderIDParams) middleware.Responder {
 			return middleware.NotImplemented("operation recorder.PutRecorderID has not yet been implemented")
 		}),
@@ -254,6 +269,8 @@ type CiliumAPIAPI struct {
 	DaemonGetDebuginfoHandler daemon.GetDebuginfoHandler
 	// EndpointGetEndpointHandler sets the operation handler for the get endpoint operation
 	EndpointGetEndpointHandler endpoint.GetEndpointHandler
+	// EndpointGetEndpointGroupsHandler sets the operation handler for the get endpoint groups operation
+	EndpointGetEndpointGroupsHandler endpoint.GetEndpointGroupsHandler
 	// EndpointGetEndpointIDHandler sets the operation handler for the get endpoint ID operation
 	EndpointGetEndpointIDHandler endpoint.GetEndpointIDHandler
 	// EndpointGetEndpointIDConfigHandler sets the operation handler for the get endpoint ID config operation
@@ -296,6 +313,10 @@ type CiliumAPIAPI struct {
 	PolicyGetPolicySelectorsHandler policy.GetPolicySelectorsHandler
 	// PrefilterGetPrefilterHandler sets the operation handler for the get prefilter operation
 	PrefilterGetPrefilterHandler prefilter.GetPrefilterHandler
+	// DaemonGetProfileHandler sets the operation handler for the get profile operation
+	DaemonGetProfileHandler daemon.GetProfileHandler
+	// DaemonGetProxyListenersHandler sets the operation handler for the get proxy listeners operation
+	DaemonGetProxyListenersHandler daemon.GetProxyListenersHandler
 	// RecorderGetRecorderHandler sets the operation handler for the get recorder operation
 	RecorderGetRecorderHandler recorder.GetRecorderHandler
 	// RecorderGetRecorderIDHandler sets the operation handler for the get recorder ID operation
@@ -320,10 +341,14 @@ type CiliumAPIAPI struct {
 	IpamPostIpamHandler ipam.PostIpamHandler
 	// IpamPostIpamIPHandler sets the operation handler for the post ipam IP operation
 	IpamPostIpamIPHandler ipam.PostIpamIPHandler
+	// DaemonPutControllerNameTriggerHandler sets the operation handler for the put controller name trigger operation
+	DaemonPutControllerNameTriggerHandler daemon.PutControllerNameTriggerHandler
 	// EndpointPutEndpointIDHandler sets the operation handler for the put endpoint ID operation
 	EndpointPutEndpointIDHandler endpoint.PutEndpointIDHandler
 	// PolicyPutPolicyHandler sets the operation handler for the put policy operation
 	PolicyPutPolicyHandler policy.PutPolicyHandler
+	// PolicyPutPolicyFlowOverrideHandler sets the operation handler for the put policy flow override operation
+	PolicyPutPolicyFlowOverrideHandler policy.PutPolicyFlowOverrideHandler
 	// RecorderPutRecorderIDHandler sets the operation handler for the put recorder ID operation
 	RecorderPutRecorderIDHandler recorder.PutRecorderIDHandler
 	// ServicePutServiceIDHandler sets the operation handler for the put service ID operation
@@ -437,6 +462,9 @@ func (o *CiliumAPIAPI) Validate() error {
 	if o.EndpointGetEndpointHandler == nil {
 		unregistered = append(unregistered, "endpoint.GetEndpointHandler")
 	}
+	if o.EndpointGetEndpointGroupsHandler == nil {
+		unregistered = append(unregistered, "endpoint.GetEndpointGroupsHandler")
+	}
 	if o.EndpointGetEndpointIDHandler == nil {
 		unregistered = append(unregistered, "endpoint.GetEndpointIDHandler")
 	}
@@ -500,6 +528,12 @@ func (o *CiliumAPIAPI) Validate() error {
 	if o.PrefilterGetPrefilterHandler == nil {
 		unregistered = append(unregistered, "prefilter.GetPrefilterHandler")
 	}
+	if o.DaemonGetProfileHandler == nil {
+		unregistered = append(unregistered, "daemon.GetProfileHandler")
+	}
+	if o.DaemonGetProxyListenersHandler == nil {
+		unregistered = append(unregistered, "daemon.GetProxyListenersHandler")
+	}
 	if o.RecorderGetRecorderHandler == nil {
 		unregistered = append(unregistered, "recorder.GetRecorderHandler")
 	}
@@ -536,12 +570,18 @@ func (o *CiliumAPIAPI) Validate() error {
 	if o.IpamPostIpamIPHandler == nil {
 		unregistered = append(unregistered, "ipam.PostIpamIPHandler")
 	}
+	if o.DaemonPutControllerNameTriggerHandler == nil {
+		unregistered = append(unregistered, "daemon.PutControllerNameTriggerHandler")
+	}
 	if o.EndpointPutEndpointIDHandler == nil {
 		unregistered = append(unregistered, "endpoint.PutEndpointIDHandler")
 	}
 	if o.PolicyPutPolicyHandler == nil {
 		unregistered = append(unregistered, "policy.PutPolicyHandler")
 	}
+	if o.PolicyPutPolicyFlowOverrideHandler == nil {
+		unregistered = append(unregistered, "policy.PutPolicyFlowOverrideHandler")
+	}
 	if o.RecorderPutRecorderIDHandler == nil {
 		unregistered = append(unregistered, "recorder.PutRecorderIDHandler")
 	}
@@ -683,6 +723,10 @@ func (o *CiliumAPIAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/endpoint/groups"] = endpoint.NewGetEndpointGroups(o.context, o.EndpointGetEndpointGroupsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/endpoint/{id}"] = endpoint.NewGetEndpointID(o.context, o.EndpointGetEndpointIDHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -767,6 +811,14 @@ func (o *CiliumAPIAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/profile"] = daemon.NewGetProfile(o.context, o.DaemonGetProfileHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/proxy/listeners"] = daemon.NewGetProxyListeners(o.context, o.DaemonGetProxyListenersHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/recorder"] = recorder.NewGetRecorder(o.context, o.RecorderGetRecorderHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -815,6 +867,10 @@ func (o *CiliumAPIAPI) initHandlerCache() {
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
+	o.handlers["PUT"]["/controller/{name}/trigger"] = daemon.NewPutControllerNameTrigger(o.context, o.DaemonPutControllerNameTriggerHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
 	o.handlers["PUT"]["/endpoint/{id}"] = endpoint.NewPutEndpointID(o.context, o.EndpointPutEndpointIDHandler)
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
@@ -823,6 +879,10 @@ func (o *CiliumAPIAPI) initHandlerCache() {
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
+	o.handlers["PUT"]["/policy/flow-override"] = policy.NewPutPolicyFlowOverride(o.context, o.PolicyPutPolicyFlowOverrideHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
 	o.handlers["PUT"]["/recorder/{id}"] = recorder.NewPutRecorderID(o.context, o.RecorderPutRecorderIDHandler)
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
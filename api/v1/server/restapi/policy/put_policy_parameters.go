@@ -15,6 +15,8 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewPutPolicyParams creates a new PutPolicyParams object
@@ -38,6 +40,10 @@ type PutPolicyParams struct {
 	  In: body
 	*/
 	Policy string
+	/*Evaluate the provided rules in shadow against the enforced policy instead of enforcing them. The response carries a per-endpoint divergence report instead of the policy tree.
+	  In: query
+	*/
+	Shadow *bool
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -49,6 +55,8 @@ func (o *PutPolicyParams) BindRequest(r *http.Request, route *middleware.Matched
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
 	if runtime.HasBody(r) {
 		defer r.Body.Close()
 		var body string
@@ -65,8 +73,36 @@ func (o *PutPolicyParams) BindRequest(r *http.Request, route *middleware.Matched
 	} else {
 		res = append(res, errors.Required("policy", "body", ""))
 	}
+
+	qShadow, qhkShadow, _ := qs.GetOK("shadow")
+	if err := o.bindShadow(qShadow, qhkShadow, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
+
+// bindShadow binds and validates parameter Shadow from query.
+func (o *PutPolicyParams) bindShadow(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("shadow", "query", "bool", raw)
+	}
+	o.Shadow = &value
+
+	return nil
+}
@@ -40,6 +40,10 @@
 #include "lib/fib.h"
 #include "lib/nodeport.h"
 #include "lib/policy_log.h"
+#include "lib/fault_injection.h"
+#include "lib/fqdn.h"
+#include "lib/identity_encoding.h"
+#include "lib/tunneled_proto.h"
 
 #if defined(ENABLE_ARP_PASSTHROUGH) && defined(ENABLE_ARP_RESPONDER)
 #error "Either ENABLE_ARP_PASSTHROUGH or ENABLE_ARP_RESPONDER can be defined"
@@ -230,7 +234,50 @@ skip_service_lookup:
 		return verdict;
 	}
 
+#ifdef ENABLE_FAULT_INJECTION
+	if (ret != CT_REPLY && ret != CT_RELATED) {
+		int fault = fault_injection_check(SECLABEL, *dst_id);
+
+		if (IS_ERR(fault))
+			return fault;
+	}
+#endif /* ENABLE_FAULT_INJECTION */
+
+#ifdef ENABLE_DNS_ANSWER_VALIDATION
+	/* Connections leaving the cluster must go to an IP which was returned
+	 * by a DNS answer the proxy forwarded. Packets redirected to an L7
+	 * proxy (verdict > 0), including DNS lookups themselves, are validated
+	 * by the proxy instead.
+	 */
+	if (ret != CT_REPLY && ret != CT_RELATED && verdict == 0 &&
+	    *dst_id == WORLD_ID && !fqdn_answer_allowed6(&orig_dip))
+		return DROP_DNS_ANSWER;
+#endif /* ENABLE_DNS_ANSWER_VALIDATION */
+
+#ifdef ENABLE_TUNNELED_PROTO_DETECTION
+	/* Deny protocols tunneled through connections the policy allowed
+	 * without proxy redirection, e.g. DNS hidden in ICMP echo payloads.
+	 */
+	if (ret != CT_REPLY && ret != CT_RELATED && verdict == 0) {
+		int tunneled = tunneled_proto_check6(ctx, tuple->nexthdr, l4_off);
+
+		if (IS_ERR(tunneled))
+			return tunneled;
+	}
+#endif /* ENABLE_TUNNELED_PROTO_DETECTION */
+
 skip_policy_enforcement:
+#ifdef ENABLE_IDENTITY_ENCODING
+	/* Encode the source identity into packets leaving the cluster so that
+	 * external firewalls can apply identity-aware rules.
+	 */
+	if (*dst_id == WORLD_ID) {
+		int encode_ret = identity_encode_egress6(ctx, ETH_HLEN, SECLABEL);
+
+		if (IS_ERR(encode_ret))
+			return encode_ret;
+	}
+#endif /* ENABLE_IDENTITY_ENCODING */
 	switch (ret) {
 	case CT_NEW:
 		if (!hairpin_flow)
@@ -244,7 +291,7 @@ ct_recreate6:
 		 * reverse NAT.
 		 */
 		ct_state_new.src_sec_id = SECLABEL;
-		ret = ct_create6(get_ct_map6(tuple), &CT_MAP_ANY6, tuple, ctx,
+		ret = ct_create6(get_ct_map6(tuple), get_ct_related_map6(), tuple, ctx,
 				 CT_EGRESS, &ct_state_new, verdict > 0);
 		if (IS_ERR(ret))
 			return ret;
@@ -263,6 +310,9 @@ ct_recreate6:
 		break;
 
 	case CT_RELATED:
+		update_metrics(ctx_full_len(ctx), METRIC_EGRESS,
+			       REASON_ACCEPTED_RELATED);
+		/* fall through */
 	case CT_REPLY:
 		policy_mark_skip(ctx);
 
@@ -493,6 +543,8 @@ int tail_handle_ipv6(struct __ctx_buff *ctx)
 		return send_drop_notify(ctx, SECLABEL, dst_id, 0, ret,
 					CTX_ACT_DROP, METRIC_EGRESS);
 
+	update_cluster_metrics(ctx_full_len(ctx), METRIC_EGRESS, dst_id);
+
 #ifdef ENABLE_CUSTOM_CALLS
 	if (!encode_custom_prog_meta(ctx, ret, dst_id)) {
 		tail_call_static(ctx, &CUSTOM_CALLS_MAP,
@@ -664,7 +716,50 @@ skip_service_lookup:
 		return verdict;
 	}
 
+#ifdef ENABLE_FAULT_INJECTION
+	if (ret != CT_REPLY && ret != CT_RELATED) {
+		int fault = fault_injection_check(SECLABEL, *dst_id);
+
+		if (IS_ERR(fault))
+			return fault;
+	}
+#endif /* ENABLE_FAULT_INJECTION */
+
+#ifdef ENABLE_DNS_ANSWER_VALIDATION
+	/* Connections leaving the cluster must go to an IP which was returned
+	 * by a DNS answer the proxy forwarded. Packets redirected to an L7
+	 * proxy (verdict > 0), including DNS lookups themselves, are validated
+	 * by the proxy instead.
+	 */
+	if (ret != CT_REPLY && ret != CT_RELATED && verdict == 0 &&
+	    *dst_id == WORLD_ID && !fqdn_answer_allowed4(orig_dip))
+		return DROP_DNS_ANSWER;
+#endif /* ENABLE_DNS_ANSWER_VALIDATION */
+
+#ifdef ENABLE_TUNNELED_PROTO_DETECTION
+	/* Deny protocols tunneled through connections the policy allowed
+	 * without proxy redirection, e.g. DNS hidden in ICMP echo payloads.
+	 */
+	if (ret != CT_REPLY && ret != CT_RELATED && verdict == 0) {
+		int tunneled = tunneled_proto_check4(ctx, tuple.nexthdr, l4_off);
+
+		if (IS_ERR(tunneled))
+			return tunneled;
+	}
+#endif /* ENABLE_TUNNELED_PROTO_DETECTION */
+
 skip_policy_enforcement:
+#ifdef ENABLE_IDENTITY_ENCODING
+	/* Encode the source identity into packets leaving the cluster so that
+	 * external firewalls can apply identity-aware rules.
+	 */
+	if (*dst_id == WORLD_ID) {
+		int encode_ret = identity_encode_egress4(ctx, ETH_HLEN, SECLABEL);
+
+		if (IS_ERR(encode_ret))
+			return encode_ret;
+	}
+#endif /* ENABLE_IDENTITY_ENCODING */
 	switch (ret) {
 	case CT_NEW:
 		if (!hairpin_flow)
@@ -681,7 +776,7 @@ ct_recreate4:
 		/* We could avoid creating related entries for legacy ClusterIP
 		 * handling here, but turns out that verifier cannot handle it.
 		 */
-		ret = ct_create4(get_ct_map4(&tuple), &CT_MAP_ANY4, &tuple, ctx,
+		ret = ct_create4(get_ct_map4(&tuple), get_ct_related_map4(), &tuple, ctx,
 				 CT_EGRESS, &ct_state_new, verdict > 0);
 		if (IS_ERR(ret))
 			return ret;
@@ -699,6 +794,9 @@ ct_recreate4:
 		break;
 
 	case CT_RELATED:
+		update_metrics(ctx_full_len(ctx), METRIC_EGRESS,
+			       REASON_ACCEPTED_RELATED);
+		/* fall through */
 	case CT_REPLY:
 		policy_mark_skip(ctx);
 
@@ -917,6 +1015,8 @@ int tail_handle_ipv4(struct __ctx_buff *ctx)
 		return send_drop_notify(ctx, SECLABEL, dst_id, 0, ret,
 					CTX_ACT_DROP, METRIC_EGRESS);
 
+	update_cluster_metrics(ctx_full_len(ctx), METRIC_EGRESS, dst_id);
+
 #ifdef ENABLE_CUSTOM_CALLS
 	if (!encode_custom_prog_meta(ctx, ret, dst_id)) {
 		tail_call_static(ctx, &CUSTOM_CALLS_MAP,
@@ -1107,6 +1207,19 @@ ipv6_policy(struct __ctx_buff *ctx, int ifindex, __u32 src_label, __u8 *reason,
 		return verdict;
 	}
 
+#ifdef ENABLE_FAULT_INJECTION
+	if (ret != CT_REPLY && ret != CT_RELATED) {
+		int fault = fault_injection_check(src_label, SECLABEL);
+
+		if (IS_ERR(fault))
+			return fault;
+	}
+#endif /* ENABLE_FAULT_INJECTION */
+
+	if (ret == CT_RELATED)
+		update_metrics(ctx_full_len(ctx), METRIC_INGRESS,
+			       REASON_ACCEPTED_RELATED);
+
 	if (skip_ingress_proxy)
 		verdict = 0;
 
@@ -1132,7 +1245,7 @@ ipv6_policy(struct __ctx_buff *ctx, int ifindex, __u32 src_label, __u8 *reason,
 		ct_state_new.src_sec_id = src_label;
 		ct_state_new.node_port = ct_state.node_port;
 		ct_state_new.ifindex = ct_state.ifindex;
-		ret = ct_create6(get_ct_map6(&tuple), &CT_MAP_ANY6, &tuple, ctx, CT_INGRESS,
+		ret = ct_create6(get_ct_map6(&tuple), get_ct_related_map6(), &tuple, ctx, CT_INGRESS,
 				 &ct_state_new, verdict > 0);
 		if (IS_ERR(ret))
 			return ret;
@@ -1192,6 +1305,8 @@ int tail_ipv6_policy(struct __ctx_buff *ctx)
 		return send_drop_notify(ctx, src_label, SECLABEL, LXC_ID,
 					ret, CTX_ACT_DROP, METRIC_INGRESS);
 
+	update_cluster_metrics(ctx_full_len(ctx), METRIC_INGRESS, src_label);
+
 	/* Store meta: essential for proxy ingress, see bpf_host.c */
 	ctx_store_meta(ctx, CB_PROXY_MAGIC, ctx->mark);
 
@@ -1405,6 +1520,19 @@ ipv4_policy(struct __ctx_buff *ctx, int ifindex, __u32 src_label, __u8 *reason,
 		return verdict;
 	}
 
+#ifdef ENABLE_FAULT_INJECTION
+	if (ret != CT_REPLY && ret != CT_RELATED) {
+		int fault = fault_injection_check(src_label, SECLABEL);
+
+		if (IS_ERR(fault))
+			return fault;
+	}
+#endif /* ENABLE_FAULT_INJECTION */
+
+	if (ret == CT_RELATED)
+		update_metrics(ctx_full_len(ctx), METRIC_INGRESS,
+			       REASON_ACCEPTED_RELATED);
+
 	if (skip_ingress_proxy)
 		verdict = 0;
 
@@ -1434,7 +1562,7 @@ skip_policy_enforcement:
 		ct_state_new.src_sec_id = src_label;
 		ct_state_new.node_port = ct_state.node_port;
 		ct_state_new.ifindex = ct_state.ifindex;
-		ret = ct_create4(get_ct_map4(&tuple), &CT_MAP_ANY4, &tuple, ctx, CT_INGRESS,
+		ret = ct_create4(get_ct_map4(&tuple), get_ct_related_map4(), &tuple, ctx, CT_INGRESS,
 				 &ct_state_new, verdict > 0);
 		if (IS_ERR(ret))
 			return ret;
@@ -1501,6 +1629,8 @@ int tail_ipv4_policy(struct __ctx_buff *ctx)
 		return send_drop_notify(ctx, src_label, SECLABEL, LXC_ID,
 					ret, CTX_ACT_DROP, METRIC_INGRESS);
 
+	update_cluster_metrics(ctx_full_len(ctx), METRIC_INGRESS, src_label);
+
 	/* Store meta: essential for proxy ingress, see bpf_host.c */
 	ctx_store_meta(ctx, CB_PROXY_MAGIC, ctx->mark);
 
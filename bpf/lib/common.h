@@ -258,7 +258,23 @@ struct edt_info {
 	__u64		bps;
 	__u64		t_last;
 	__u64		t_horizon_drop;
-	__u64		pad[4];
+	__u64		prio;
+	__u64		pad[3];
+};
+
+/* Egress scheduling bands selected by the pod network priority annotation,
+ * must match GetPriorityBand() in pkg/bandwidth.
+ */
+#define EDT_PRIO_BAND_LATENCY	0
+#define EDT_PRIO_BAND_NORMAL	1
+#define EDT_PRIO_BAND_BATCH	2
+#define EDT_PRIO_BANDS		3
+
+struct edt_prio_metrics {
+	__u64		packets;
+	__u64		bytes;
+	__u64		qdelay_ns;
+	__u64		pad;
 };
 
 struct remote_endpoint_info {
@@ -299,6 +315,19 @@ struct metrics_value {
 	__u64	bytes;
 };
 
+/* Layout of the cluster ID inside a numeric security identity, must match
+ * ClusterIDShift and LocalIdentityFlag in pkg/identity.
+ */
+#define IDENTITY_CLUSTER_ID_SHIFT	16
+#define IDENTITY_CLUSTER_ID_MAX		0xFF
+#define IDENTITY_LOCAL_SCOPE_FLAG	(1 << 24)
+
+struct cluster_metrics_key {
+	__u32	cluster_id;	/* cluster ID of the remote peer identity */
+	__u8	dir;		/* 1: ingress 2: egress */
+	__u8	pad[3];
+};
+
 enum {
 	POLICY_INGRESS = 1,
 	POLICY_EGRESS = 2,
@@ -431,6 +460,10 @@ enum {
 #define DROP_PROXY_UNKNOWN_PROTO	-180
 #define DROP_POLICY_DENY	-181
 #define DROP_VLAN_FILTERED	-182
+#define DROP_FAULT_INJECTED	-183
+#define DROP_DNS_ANSWER		-184
+#define DROP_SYN_FLOOD	-185
+#define DROP_TUNNELED_PROTO	-186
 
 #define NAT_PUNT_TO_STACK	DROP_NAT_NOT_NEEDED
 
@@ -451,6 +484,7 @@ enum {
 #define REASON_FRAG_PACKET		9
 #define REASON_FRAG_PACKET_UPDATE	10
 #define REASON_MISSED_CUSTOM_CALL	11
+#define REASON_ACCEPTED_RELATED		12
 
 /* Lookup scope for externalTrafficPolicy=Local */
 #define LB_LOOKUP_SCOPE_EXT	0
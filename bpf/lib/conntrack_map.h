@@ -45,6 +45,21 @@ get_ct_map6(const struct ipv6_ct_tuple *tuple)
 
 	return &CT_MAP_ANY6;
 }
+
+/* get_ct_related_map6 returns the map ICMPv6 error entries are created in
+ * alongside the main CT entry, so that errors related to an allowed flow
+ * (e.g. time exceeded, packet too big) match as RELATED. Returns NULL when
+ * acceptance of related errors is disabled.
+ */
+static __always_inline struct bpf_elf_map *
+get_ct_related_map6(void)
+{
+#ifdef ENABLE_RELATED_ICMP
+	return &CT_MAP_ANY6;
+#else
+	return NULL;
+#endif
+}
 #endif
 
 #ifdef ENABLE_IPV4
@@ -78,6 +93,21 @@ get_ct_map4(const struct ipv4_ct_tuple *tuple)
 
 	return &CT_MAP_ANY4;
 }
+
+/* get_ct_related_map4 returns the map ICMP error entries are created in
+ * alongside the main CT entry, so that errors related to an allowed flow
+ * (e.g. TTL exceeded, fragmentation needed) match as RELATED. Returns NULL
+ * when acceptance of related errors is disabled.
+ */
+static __always_inline struct bpf_elf_map *
+get_ct_related_map4(void)
+{
+#ifdef ENABLE_RELATED_ICMP
+	return &CT_MAP_ANY4;
+#else
+	return NULL;
+#endif
+}
 #endif
 #endif
 #endif /* __LIB_CONNTRACK_MAP_H_ */
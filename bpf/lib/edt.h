@@ -33,6 +33,39 @@ static __always_inline __u32 edt_get_aggregate(struct __ctx_buff *ctx)
 	return aggregate;
 }
 
+#ifdef ENABLE_NETWORK_PRIORITY
+/* Extra departure delay applied to the batch band. The delay is bounded,
+ * so an idle batch flow pays at most this much latency, while under uplink
+ * saturation the later timestamps dequeue batch packets after all other
+ * traffic in the time-ordered FQ heap.
+ */
+#define EDT_PRIO_BATCH_DELAY	(NSEC_PER_SEC / 1000)
+
+static __always_inline __u64 edt_prio_band_delay(__u64 prio)
+{
+	if (prio == EDT_PRIO_BAND_BATCH)
+		return EDT_PRIO_BATCH_DELAY;
+	return 0;
+}
+
+static __always_inline void edt_prio_account(struct __ctx_buff *ctx,
+					     __u64 prio, __u64 qdelay)
+{
+	__u32 band = prio < EDT_PRIO_BANDS ? (__u32)prio : EDT_PRIO_BAND_NORMAL;
+	struct edt_prio_metrics *m;
+
+	m = map_lookup_elem(&PRIO_METRICS_MAP, &band);
+	if (!m)
+		return;
+	m->packets += 1;
+	m->bytes += (__u64)ctx_wire_len(ctx);
+	/* Sampled rather than aggregated: the depth of the band's queue is
+	 * how far the last packet was scheduled into the future.
+	 */
+	m->qdelay_ns = qdelay;
+}
+#endif /* ENABLE_NETWORK_PRIORITY */
+
 static __always_inline int edt_sched_departure(struct __ctx_buff *ctx)
 {
 	__u64 delay, now, t, t_next;
@@ -58,10 +91,27 @@ static __always_inline int edt_sched_departure(struct __ctx_buff *ctx)
 	t = ctx->tstamp;
 	if (t < now)
 		t = now;
+#ifdef ENABLE_NETWORK_PRIORITY
+	t += edt_prio_band_delay(info->prio);
+	/* Aggregates without a bandwidth limit only carry a scheduling band,
+	 * nothing to pace.
+	 */
+	if (!info->bps) {
+		if (t > ctx->tstamp)
+			ctx->tstamp = t;
+		edt_prio_account(ctx, info->prio, t - now);
+		return CTX_ACT_OK;
+	}
+#endif /* ENABLE_NETWORK_PRIORITY */
 	delay = ((__u64)ctx_wire_len(ctx)) * NSEC_PER_SEC / info->bps;
 	t_next = READ_ONCE(info->t_last) + delay;
 	if (t_next <= t) {
 		WRITE_ONCE(info->t_last, t);
+#ifdef ENABLE_NETWORK_PRIORITY
+		if (t > ctx->tstamp)
+			ctx->tstamp = t;
+		edt_prio_account(ctx, info->prio, t - now);
+#endif /* ENABLE_NETWORK_PRIORITY */
 		return CTX_ACT_OK;
 	}
 	/* FQ implements a drop horizon, see also 39d010504e6b ("net_sched:
@@ -73,6 +123,9 @@ static __always_inline int edt_sched_departure(struct __ctx_buff *ctx)
 		return CTX_ACT_DROP;
 	WRITE_ONCE(info->t_last, t_next);
 	ctx->tstamp = t_next;
+#ifdef ENABLE_NETWORK_PRIORITY
+	edt_prio_account(ctx, info->prio, t_next - now);
+#endif /* ENABLE_NETWORK_PRIORITY */
 	return CTX_ACT_OK;
 }
 #else
@@ -0,0 +1,55 @@
+/* SPDX-License-Identifier: GPL-2.0 */
+/* Copyright (C) 2021 Authors of Cilium */
+
+#ifndef __LIB_FAULT_INJECTION_H_
+#define __LIB_FAULT_INJECTION_H_
+
+#include "common.h"
+
+#ifdef ENABLE_FAULT_INJECTION
+struct fault_injection_key {
+	__u32 src_sec_id;
+	__u32 dst_sec_id;
+};
+
+struct fault_injection_value {
+	__u8 probability;
+	__u8 pad1;
+	__u16 pad2;
+};
+
+struct bpf_elf_map __section_maps FAULT_INJECTION_MAP = {
+	.type		= BPF_MAP_TYPE_HASH,
+	.size_key	= sizeof(struct fault_injection_key),
+	.size_value	= sizeof(struct fault_injection_value),
+	.pinning	= PIN_GLOBAL_NS,
+	.max_elem	= FAULT_INJECTION_MAP_SIZE,
+	.flags		= BPF_F_NO_PREALLOC,
+};
+
+/* fault_injection_check drops the configured share of packets between the
+ * given identity pair. It is only compiled in when the agent runs with
+ * fault injection enabled and must never be called on the fast path of a
+ * production configuration.
+ */
+static __always_inline int
+fault_injection_check(__u32 src_sec_id, __u32 dst_sec_id)
+{
+	struct fault_injection_key key = {
+		.src_sec_id = src_sec_id,
+		.dst_sec_id = dst_sec_id,
+	};
+	struct fault_injection_value *fault;
+
+	fault = map_lookup_elem(&FAULT_INJECTION_MAP, &key);
+	if (!fault)
+		return CTX_ACT_OK;
+
+	if (get_prandom_u32() % 100 < fault->probability)
+		return DROP_FAULT_INJECTED;
+
+	return CTX_ACT_OK;
+}
+#endif /* ENABLE_FAULT_INJECTION */
+
+#endif /* __LIB_FAULT_INJECTION_H_ */
@@ -0,0 +1,60 @@
+/* SPDX-License-Identifier: GPL-2.0 */
+/* Copyright (C) 2021 Authors of Cilium */
+
+#ifndef __LIB_FQDN_H_
+#define __LIB_FQDN_H_
+
+#include "common.h"
+#include "time.h"
+
+#ifdef ENABLE_DNS_ANSWER_VALIDATION
+struct fqdn_allow_entry {
+	__u32 expires;	/* Monotonic time in seconds. */
+};
+
+#ifdef ENABLE_IPV4
+struct bpf_elf_map __section_maps FQDN_ALLOW_MAP4 = {
+	.type		= BPF_MAP_TYPE_LRU_HASH,
+	.size_key	= sizeof(__be32),
+	.size_value	= sizeof(struct fqdn_allow_entry),
+	.pinning	= PIN_GLOBAL_NS,
+	.max_elem	= FQDN_ALLOW_MAP_SIZE,
+};
+#endif /* ENABLE_IPV4 */
+
+#ifdef ENABLE_IPV6
+struct bpf_elf_map __section_maps FQDN_ALLOW_MAP6 = {
+	.type		= BPF_MAP_TYPE_LRU_HASH,
+	.size_key	= sizeof(union v6addr),
+	.size_value	= sizeof(struct fqdn_allow_entry),
+	.pinning	= PIN_GLOBAL_NS,
+	.max_elem	= FQDN_ALLOW_MAP_SIZE,
+};
+#endif /* ENABLE_IPV6 */
+
+/* fqdn_answer_allowed4 returns true if the given address was returned by an
+ * allowed DNS answer and the entry, including its grace window, has not
+ * expired yet. The DNS proxy populates the map from answers it forwards.
+ */
+#ifdef ENABLE_IPV4
+static __always_inline bool fqdn_answer_allowed4(__be32 addr)
+{
+	struct fqdn_allow_entry *entry;
+
+	entry = map_lookup_elem(&FQDN_ALLOW_MAP4, &addr);
+	return entry && entry->expires >= bpf_ktime_get_sec();
+}
+#endif /* ENABLE_IPV4 */
+
+#ifdef ENABLE_IPV6
+static __always_inline bool fqdn_answer_allowed6(const union v6addr *addr)
+{
+	struct fqdn_allow_entry *entry;
+
+	entry = map_lookup_elem(&FQDN_ALLOW_MAP6, addr);
+	return entry && entry->expires >= bpf_ktime_get_sec();
+}
+#endif /* ENABLE_IPV6 */
+#endif /* ENABLE_DNS_ANSWER_VALIDATION */
+
+#endif /* __LIB_FQDN_H_ */
@@ -73,7 +73,7 @@ ipv6_host_policy_egress(struct __ctx_buff *ctx, __u32 src_id, __u32 *monitor)
 					   tuple.nexthdr, POLICY_EGRESS, 1,
 					   verdict, policy_match_type, audited);
 		ct_state_new.src_sec_id = HOST_ID;
-		ret = ct_create6(get_ct_map6(&tuple), &CT_MAP_ANY6, &tuple,
+		ret = ct_create6(get_ct_map6(&tuple), get_ct_related_map6(), &tuple,
 				 ctx, CT_EGRESS, &ct_state_new, verdict > 0);
 		if (IS_ERR(ret))
 			return ret;
@@ -166,7 +166,7 @@ ipv6_host_policy_ingress(struct __ctx_buff *ctx, __u32 *src_id)
 		/* Create new entry for connection in conntrack map. */
 		ct_state_new.src_sec_id = *src_id;
 		ct_state_new.node_port = ct_state.node_port;
-		ret = ct_create6(get_ct_map6(&tuple), &CT_MAP_ANY6, &tuple,
+		ret = ct_create6(get_ct_map6(&tuple), get_ct_related_map6(), &tuple,
 				 ctx, CT_INGRESS, &ct_state_new, verdict > 0);
 		if (IS_ERR(ret))
 			return ret;
@@ -227,7 +227,7 @@ whitelist_snated_egress_connections(struct __ctx_buff *ctx, __u32 ipcache_srcid)
 		if (ret < 0)
 			return ret;
 		if (ret == CT_NEW) {
-			ret = ct_create4(get_ct_map4(&tuple), &CT_MAP_ANY4,
+			ret = ct_create4(get_ct_map4(&tuple), get_ct_related_map4(),
 					 &tuple, ctx, CT_EGRESS, &ct_state_new,
 					 false);
 			if (IS_ERR(ret))
@@ -300,7 +300,7 @@ ipv4_host_policy_egress(struct __ctx_buff *ctx, __u32 src_id,
 					   tuple.nexthdr, POLICY_EGRESS, 0,
 					   verdict, policy_match_type, audited);
 		ct_state_new.src_sec_id = HOST_ID;
-		ret = ct_create4(get_ct_map4(&tuple), &CT_MAP_ANY4, &tuple,
+		ret = ct_create4(get_ct_map4(&tuple), get_ct_related_map4(), &tuple,
 				 ctx, CT_EGRESS, &ct_state_new, verdict > 0);
 		if (IS_ERR(ret))
 			return ret;
@@ -396,7 +396,7 @@ ipv4_host_policy_ingress(struct __ctx_buff *ctx, __u32 *src_id)
 		/* Create new entry for connection in conntrack map. */
 		ct_state_new.src_sec_id = *src_id;
 		ct_state_new.node_port = ct_state.node_port;
-		ret = ct_create4(get_ct_map4(&tuple), &CT_MAP_ANY4, &tuple,
+		ret = ct_create4(get_ct_map4(&tuple), get_ct_related_map4(), &tuple,
 				 ctx, CT_INGRESS, &ct_state_new, verdict > 0);
 		if (IS_ERR(ret))
 			return ret;
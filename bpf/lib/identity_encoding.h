@@ -0,0 +1,92 @@
+/* SPDX-License-Identifier: GPL-2.0 */
+/* Copyright (C) 2021 Authors of Cilium */
+
+#ifndef __LIB_IDENTITY_ENCODING_H_
+#define __LIB_IDENTITY_ENCODING_H_
+
+#include "common.h"
+
+#ifdef ENABLE_IDENTITY_ENCODING
+/* Identity encoding writes the low bits of the source security identity into
+ * a header field of packets egressing to the world, so that external
+ * firewalls can apply identity-aware rules. Two encodings are supported:
+ *
+ * - IDENTITY_ENCODING_DSCP_BITS: the low 1-6 bits of the identity are written
+ *   into the low bits of the DSCP field, for IPv4 and IPv6 alike. The ECN
+ *   bits are preserved.
+ *
+ * - IDENTITY_ENCODING_FLOWLABEL: the low 20 bits of the identity are written
+ *   into the IPv6 flow label. IPv4 packets are left unchanged.
+ */
+
+#ifdef IDENTITY_ENCODING_DSCP_BITS
+static __always_inline int
+identity_encode_egress4(struct __ctx_buff *ctx, int l3_off, __u32 identity)
+{
+	__u8 dscp = identity & ((1 << IDENTITY_ENCODING_DSCP_BITS) - 1);
+	union {
+		__u16 word;
+		__u8 byte[2];
+	} old, new;
+
+	/* The first two header bytes cover version/ihl and tos. Replace the
+	 * full 16 bit word so that the checksum update is independent of the
+	 * byte position of the tos field.
+	 */
+	if (ctx_load_bytes(ctx, l3_off, &old, sizeof(old)) < 0)
+		return DROP_INVALID;
+
+	new.word = old.word;
+	new.byte[1] = (old.byte[1] & 0x3) | (__u8)(dscp << 2);
+	if (new.word == old.word)
+		return 0;
+
+	l3_csum_replace(ctx, l3_off + offsetof(struct iphdr, check),
+			old.word, new.word, 2);
+	if (ctx_store_bytes(ctx, l3_off + offsetof(struct iphdr, tos),
+			    &new.byte[1], 1, 0) < 0)
+		return DROP_WRITE_ERROR;
+
+	return 0;
+}
+#else
+static __always_inline int
+identity_encode_egress4(struct __ctx_buff *ctx __maybe_unused,
+		      int l3_off __maybe_unused, __u32 identity __maybe_unused)
+{
+	return 0;
+}
+#endif /* IDENTITY_ENCODING_DSCP_BITS */
+
+static __always_inline int
+identity_encode_egress6(struct __ctx_buff *ctx, int l3_off, __u32 identity)
+{
+	__be32 old, new;
+
+	/* The first four header bytes cover version, traffic class and flow
+	 * label.
+	 */
+	if (ctx_load_bytes(ctx, l3_off, &old, sizeof(old)) < 0)
+		return DROP_INVALID;
+
+#ifdef IDENTITY_ENCODING_FLOWLABEL
+	new = (old & bpf_htonl(0xFFF00000)) |
+	      bpf_htonl(identity & 0xFFFFF);
+#else
+	{
+		__u32 dscp = identity & ((1 << IDENTITY_ENCODING_DSCP_BITS) - 1);
+
+		new = (old & ~bpf_htonl(0x0FC00000)) |
+		      bpf_htonl(dscp << 22);
+	}
+#endif
+	if (new == old)
+		return 0;
+
+	if (ctx_store_bytes(ctx, l3_off, &new, sizeof(new), 0) < 0)
+		return DROP_WRITE_ERROR;
+
+	return 0;
+}
+#endif /* ENABLE_IDENTITY_ENCODING */
+#endif /* __LIB_IDENTITY_ENCODING_H_ */
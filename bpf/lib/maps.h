@@ -28,6 +28,17 @@ struct bpf_elf_map __section_maps METRICS_MAP = {
 	.flags		= CONDITIONAL_PREALLOC,
 };
 
+#ifdef ENABLE_CLUSTER_METRICS
+struct bpf_elf_map __section_maps CLUSTER_METRICS_MAP = {
+	.type		= BPF_MAP_TYPE_PERCPU_HASH,
+	.size_key	= sizeof(struct cluster_metrics_key),
+	.size_value	= sizeof(struct metrics_value),
+	.pinning	= PIN_GLOBAL_NS,
+	.max_elem	= CLUSTER_METRICS_MAP_SIZE,
+	.flags		= CONDITIONAL_PREALLOC,
+};
+#endif
+
 #ifndef SKIP_POLICY_MAP
 /* Global map to jump into policy enforcement of receiving endpoint */
 struct bpf_elf_map __section_maps POLICY_CALL_MAP = {
@@ -49,6 +60,15 @@ struct bpf_elf_map __section_maps THROTTLE_MAP = {
 	.max_elem	= THROTTLE_MAP_SIZE,
 	.flags		= BPF_F_NO_PREALLOC,
 };
+#ifdef ENABLE_NETWORK_PRIORITY
+struct bpf_elf_map __section_maps PRIO_METRICS_MAP = {
+	.type		= BPF_MAP_TYPE_PERCPU_ARRAY,
+	.size_key	= sizeof(__u32),
+	.size_value	= sizeof(struct edt_prio_metrics),
+	.pinning	= PIN_GLOBAL_NS,
+	.max_elem	= EDT_PRIO_BANDS,
+};
+#endif /* ENABLE_NETWORK_PRIORITY */
 #endif /* ENABLE_BANDWIDTH_MANAGER */
 
 /* Map to link endpoint id to per endpoint cilium_policy map */
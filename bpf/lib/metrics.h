@@ -42,6 +42,48 @@ static __always_inline void update_metrics(__u64 bytes, __u8 direction,
 	}
 }
 
+/**
+ * update_cluster_metrics
+ * @bytes:	number of bytes of the packet
+ * @direction:	1: Ingress 2: Egress
+ * @identity:	security identity of the remote peer
+ * Account forwarded traffic to the cluster embedded in the remote peer
+ * identity so that inter-cluster traffic can be attributed to a peer
+ * cluster. Traffic within the local cluster and to peers without a cluster
+ * ID is not accounted.
+ */
+static __always_inline void
+update_cluster_metrics(__u64 bytes __maybe_unused,
+		       __u8 direction __maybe_unused,
+		       __u32 identity __maybe_unused)
+{
+#ifdef ENABLE_CLUSTER_METRICS
+	struct metrics_value *entry, new_entry = {};
+	struct cluster_metrics_key key = {};
+
+	/* Locally scoped identities do not embed a cluster ID. */
+	if (identity & IDENTITY_LOCAL_SCOPE_FLAG)
+		return;
+
+	key.cluster_id = (identity >> IDENTITY_CLUSTER_ID_SHIFT) &
+			 IDENTITY_CLUSTER_ID_MAX;
+	key.dir = direction;
+
+	if (key.cluster_id == 0 || key.cluster_id == CLUSTER_ID)
+		return;
+
+	entry = map_lookup_elem(&CLUSTER_METRICS_MAP, &key);
+	if (entry) {
+		entry->count += 1;
+		entry->bytes += bytes;
+	} else {
+		new_entry.count = 1;
+		new_entry.bytes = bytes;
+		map_update_elem(&CLUSTER_METRICS_MAP, &key, &new_entry, 0);
+	}
+#endif
+}
+
 /**
  * ct_to_metrics_dir
  * @direction:	1: Ingress 2: Egress 3: Service
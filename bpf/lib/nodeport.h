@@ -21,6 +21,7 @@
 #include "ghash.h"
 #include "pcap.h"
 #include "host_firewall.h"
+#include "synflood.h"
 
 #define CB_SRC_IDENTITY	0
 
@@ -834,11 +835,19 @@ skip_service_lookup:
 				 CT_EGRESS, &ct_state, &monitor);
 		switch (ret) {
 		case CT_NEW:
+#ifdef ENABLE_SYN_FLOOD_PROTECTION
+			if (tuple.nexthdr == IPPROTO_TCP) {
+				int drop = synflood_check(svc->rev_nat_index);
+
+				if (IS_ERR(drop))
+					return drop;
+			}
+#endif /* ENABLE_SYN_FLOOD_PROTECTION */
 redo_all:
 			ct_state_new.src_sec_id = SECLABEL;
 			ct_state_new.node_port = 1;
 			ct_state_new.ifindex = NATIVE_DEV_IFINDEX;
-			ret = ct_create6(get_ct_map6(&tuple), NULL, &tuple, ctx,
+			ret = ct_create6(get_ct_map6(&tuple), get_ct_related_map6(), &tuple, ctx,
 					 CT_EGRESS, &ct_state_new, false);
 			if (IS_ERR(ret))
 				return ret;
@@ -846,7 +855,7 @@ redo_all:
 				ct_flip_tuple_dir6(&tuple);
 redo_local:
 				ct_state_new.rev_nat_index = 0;
-				ret = ct_create6(get_ct_map6(&tuple), NULL,
+				ret = ct_create6(get_ct_map6(&tuple), get_ct_related_map6(),
 						 &tuple, ctx, CT_INGRESS,
 						 &ct_state_new, false);
 				if (IS_ERR(ret))
@@ -1831,11 +1840,19 @@ skip_service_lookup:
 				 CT_EGRESS, &ct_state, &monitor);
 		switch (ret) {
 		case CT_NEW:
+#ifdef ENABLE_SYN_FLOOD_PROTECTION
+			if (tuple.nexthdr == IPPROTO_TCP) {
+				int drop = synflood_check(svc->rev_nat_index);
+
+				if (IS_ERR(drop))
+					return drop;
+			}
+#endif /* ENABLE_SYN_FLOOD_PROTECTION */
 redo_all:
 			ct_state_new.src_sec_id = SECLABEL;
 			ct_state_new.node_port = 1;
 			ct_state_new.ifindex = NATIVE_DEV_IFINDEX;
-			ret = ct_create4(get_ct_map4(&tuple), NULL, &tuple, ctx,
+			ret = ct_create4(get_ct_map4(&tuple), get_ct_related_map4(), &tuple, ctx,
 					 CT_EGRESS, &ct_state_new, false);
 			if (IS_ERR(ret))
 				return ret;
@@ -1846,7 +1863,7 @@ redo_local:
 				 * in bpf_lxc will do invalid xlation.
 				 */
 				ct_state_new.rev_nat_index = 0;
-				ret = ct_create4(get_ct_map4(&tuple), NULL,
+				ret = ct_create4(get_ct_map4(&tuple), get_ct_related_map4(),
 						 &tuple, ctx, CT_INGRESS,
 						 &ct_state_new, false);
 				if (IS_ERR(ret))
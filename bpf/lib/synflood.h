@@ -0,0 +1,53 @@
+/* SPDX-License-Identifier: GPL-2.0 */
+/* Copyright (C) 2021 Authors of Cilium */
+
+#ifndef __LIB_SYNFLOOD_H_
+#define __LIB_SYNFLOOD_H_
+
+#include "common.h"
+
+#ifdef ENABLE_SYN_FLOOD_PROTECTION
+struct synflood_key {
+	__u16 rev_nat_index;
+	__u16 pad;
+};
+
+struct synflood_value {
+	__u8 probability;
+	__u8 pad1;
+	__u16 pad2;
+};
+
+struct bpf_elf_map __section_maps SYNFLOOD_MAP = {
+	.type		= BPF_MAP_TYPE_HASH,
+	.size_key	= sizeof(struct synflood_key),
+	.size_value	= sizeof(struct synflood_value),
+	.pinning	= PIN_GLOBAL_NS,
+	.max_elem	= SYNFLOOD_MAP_SIZE,
+	.flags		= BPF_F_NO_PREALLOC,
+};
+
+/* synflood_check rate limits new connections toward a service frontend the
+ * agent has detected a SYN flood for. The agent adjusts the drop probability
+ * to the observed rate of half-open connections and removes the entry once
+ * the flood subsides.
+ */
+static __always_inline int synflood_check(__u16 rev_nat_index)
+{
+	struct synflood_key key = {
+		.rev_nat_index = rev_nat_index,
+	};
+	struct synflood_value *limit;
+
+	limit = map_lookup_elem(&SYNFLOOD_MAP, &key);
+	if (!limit)
+		return CTX_ACT_OK;
+
+	if (get_prandom_u32() % 100 < limit->probability)
+		return DROP_SYN_FLOOD;
+
+	return CTX_ACT_OK;
+}
+#endif /* ENABLE_SYN_FLOOD_PROTECTION */
+
+#endif /* __LIB_SYNFLOOD_H_ */
@@ -0,0 +1,147 @@
+/* SPDX-License-Identifier: GPL-2.0 */
+/* Copyright (C) 2021 Authors of Cilium */
+
+#ifndef __LIB_TUNNELED_PROTO_H_
+#define __LIB_TUNNELED_PROTO_H_
+
+#include <linux/icmp.h>
+#include <linux/icmpv6.h>
+
+#include "common.h"
+#include "l4.h"
+
+#ifdef ENABLE_TUNNELED_PROTO_DETECTION
+/* Lightweight classifier for protocols tunneled through connections the
+ * policy verdict allowed without redirecting them to an L7 proxy. Such
+ * tunnels, e.g. DNS carried in ICMP echo payloads or plaintext DNS spoken
+ * on TCP port 443, bypass the DNS proxy and are a common exfiltration
+ * vector. The classifier only probes the first payload bytes and errs on
+ * the side of not matching.
+ */
+
+/* Number of bytes inspected for DNS over TCP: a two byte length prefix
+ * followed by the twelve byte DNS header.
+ */
+#define TUNNELED_PROTO_PROBE_LEN	14
+
+/* tunneled_dns_header_plausible returns true if the given twelve bytes look
+ * like the header of a DNS query: QR bit clear, standard QUERY opcode, a
+ * plausible non-zero QDCOUNT and a zero ANCOUNT.
+ */
+static __always_inline bool tunneled_dns_header_plausible(const __u8 *hdr)
+{
+	__u16 qdcount = (__u16)(hdr[4] << 8) | hdr[5];
+	__u16 ancount = (__u16)(hdr[6] << 8) | hdr[7];
+
+	if (hdr[2] & 0xF8)
+		return false;
+
+	return qdcount > 0 && qdcount <= 4 && ancount == 0;
+}
+
+/* tunneled_proto_icmp_blocked returns true if the payload of the ICMP echo
+ * message starting at payload_off carries what looks like a DNS query. A
+ * payload too short to hold a DNS header never matches.
+ */
+static __always_inline bool
+tunneled_proto_icmp_blocked(struct __ctx_buff *ctx, int payload_off)
+{
+	__u8 probe[12];
+
+	if (ctx_load_bytes(ctx, payload_off, probe, sizeof(probe)) < 0)
+		return false;
+
+	return tunneled_dns_header_plausible(probe);
+}
+
+/* tunneled_proto_tcp443_blocked returns true if the TCP payload carries a
+ * length-prefixed DNS message as spoken on TCP port 53, instead of the TLS
+ * handshake expected on port 443. The length prefix must cover the payload
+ * exactly, which a TLS record never does.
+ */
+static __always_inline bool
+tunneled_proto_tcp443_blocked(struct __ctx_buff *ctx, int l4_off)
+{
+	__u8 probe[TUNNELED_PROTO_PROBE_LEN];
+	int payload_off, payload_len;
+	__u8 doff;
+
+	if (ctx_load_bytes(ctx, l4_off + 12, &doff, 1) < 0)
+		return false;
+
+	payload_off = l4_off + (int)(doff >> 4) * 4;
+	payload_len = (int)ctx_full_len(ctx) - payload_off;
+	if (payload_len < TUNNELED_PROTO_PROBE_LEN)
+		return false;
+
+	if (ctx_load_bytes(ctx, payload_off, probe, sizeof(probe)) < 0)
+		return false;
+
+	if ((int)((probe[0] << 8) | probe[1]) != payload_len - 2)
+		return false;
+
+	return tunneled_dns_header_plausible(probe + 2);
+}
+
+/* tunneled_proto_check4 classifies the packet for tunneled protocols.
+ * Returns DROP_TUNNELED_PROTO when the packet must be dropped, 0 otherwise.
+ */
+static __always_inline int
+tunneled_proto_check4(struct __ctx_buff *ctx, __u8 nexthdr, int l4_off)
+{
+	__u8 icmp_type;
+	__be16 dport;
+
+	switch (nexthdr) {
+	case IPPROTO_ICMP:
+		if (ctx_load_bytes(ctx, l4_off, &icmp_type, 1) < 0)
+			return 0;
+		if (icmp_type != ICMP_ECHO && icmp_type != ICMP_ECHOREPLY)
+			return 0;
+		if (tunneled_proto_icmp_blocked(ctx, l4_off + sizeof(struct icmphdr)))
+			return DROP_TUNNELED_PROTO;
+		break;
+	case IPPROTO_TCP:
+		if (ctx_load_bytes(ctx, l4_off + TCP_DPORT_OFF, &dport, 2) < 0)
+			return 0;
+		if (dport != bpf_htons(443))
+			return 0;
+		if (tunneled_proto_tcp443_blocked(ctx, l4_off))
+			return DROP_TUNNELED_PROTO;
+		break;
+	}
+
+	return 0;
+}
+
+/* tunneled_proto_check6 is the IPv6 counterpart of tunneled_proto_check4. */
+static __always_inline int
+tunneled_proto_check6(struct __ctx_buff *ctx, __u8 nexthdr, int l4_off)
+{
+	__u8 icmp_type;
+	__be16 dport;
+
+	switch (nexthdr) {
+	case IPPROTO_ICMPV6:
+		if (ctx_load_bytes(ctx, l4_off, &icmp_type, 1) < 0)
+			return 0;
+		if (icmp_type != ICMPV6_ECHO_REQUEST && icmp_type != ICMPV6_ECHO_REPLY)
+			return 0;
+		if (tunneled_proto_icmp_blocked(ctx, l4_off + sizeof(struct icmp6hdr)))
+			return DROP_TUNNELED_PROTO;
+		break;
+	case IPPROTO_TCP:
+		if (ctx_load_bytes(ctx, l4_off + TCP_DPORT_OFF, &dport, 2) < 0)
+			return 0;
+		if (dport != bpf_htons(443))
+			return 0;
+		if (tunneled_proto_tcp443_blocked(ctx, l4_off))
+			return DROP_TUNNELED_PROTO;
+		break;
+	}
+
+	return 0;
+}
+#endif /* ENABLE_TUNNELED_PROTO_DETECTION */
+
+#endif /* __LIB_TUNNELED_PROTO_H_ */
@@ -6,6 +6,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"strconv"
 	"time"
@@ -41,12 +42,18 @@ var (
 	timeDiff                bool
 	timeDiffClockSourceMode string
 	timeDiffClockSourceHz   int64
+	ctListFilter            string
+	ctListOffset            int
+	ctListLimit             int
 )
 
 func init() {
 	bpfCtListCmd.Flags().BoolVarP(&timeDiff, "time-diff", "d", false, "print time difference for entries")
 	bpfCtListCmd.Flags().StringVar(&timeDiffClockSourceMode, "time-diff-clocksource-mode", "", "manually set clock source mode (instead of contacting the server)")
 	bpfCtListCmd.Flags().Int64Var(&timeDiffClockSourceHz, "time-diff-clocksource-hz", 250, "manually set clock source Hz")
+	bpfCtListCmd.Flags().StringVar(&ctListFilter, "filter-ip", "", "list only entries with this source or destination IP")
+	bpfCtListCmd.Flags().IntVar(&ctListOffset, "offset", 0, "skip this number of matching entries per map")
+	bpfCtListCmd.Flags().IntVar(&ctListLimit, "limit", 0, "list at most this number of entries per map (0 means no limit)")
 	bpfCtCmd.AddCommand(bpfCtListCmd)
 	command.AddJSONOutput(bpfCtListCmd)
 }
@@ -98,6 +105,17 @@ func getClockSource() (*models.ClockSource, error) {
 	}
 }
 
+func ctDumpFilter() bpf.DumpFilter {
+	if ctListFilter == "" {
+		return nil
+	}
+	ip := net.ParseIP(ctListFilter)
+	if ip == nil {
+		Fatalf("Invalid filter IP address %q", ctListFilter)
+	}
+	return ctmap.FilterByIP(ip)
+}
+
 func doDumpEntries(m ctmap.CtMap) {
 	var (
 		out         string
@@ -112,7 +130,7 @@ func doDumpEntries(m ctmap.CtMap) {
 		}
 	}
 
-	out, err = ctmap.DumpEntriesWithTimeDiff(m, clockSource)
+	out, err = ctmap.DumpEntriesWithFilter(m, clockSource, ctDumpFilter(), ctListOffset, ctListLimit)
 	if err != nil {
 		Fatalf("Error while dumping BPF Map: %s", err)
 	}
@@ -147,6 +165,7 @@ func dumpCt(maps []interface{}, args ...interface{}) {
 				record := ctmap.CtMapRecord{Key: key.(ctmap.CtKey), Value: *value.(*ctmap.CtEntry)}
 				entries = append(entries, record)
 			}
+			callback = bpf.PaginatedCallback(ctDumpFilter(), ctListOffset, ctListLimit, callback)
 			if err = m.(ctmap.CtMap).DumpWithCallback(callback); err != nil {
 				Fatalf("Error while collecting BPF map entries: %s", err)
 			}
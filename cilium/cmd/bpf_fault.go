@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// bpfFaultCmd represents the bpf command
+var bpfFaultCmd = &cobra.Command{
+	Use:   "fault",
+	Short: "Manage the fault injection rules",
+}
+
+func init() {
+	bpfCmd.AddCommand(bpfFaultCmd)
+}
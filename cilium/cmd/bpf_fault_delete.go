@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/cilium/cilium/pkg/common"
+	"github.com/cilium/cilium/pkg/maps/faultinject"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	faultDeleteUsage = "Delete fault injection entries using source and destination identity.\n"
+)
+
+var bpfFaultDeleteCmd = &cobra.Command{
+	Args:  cobra.ExactArgs(2),
+	Use:   "delete",
+	Short: "Delete fault injection entries",
+	Long:  faultDeleteUsage,
+	Run: func(cmd *cobra.Command, args []string) {
+		common.RequireRootPrivilege("cilium bpf fault delete <src_identity> <dst_identity>")
+
+		srcIdentity, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			Fatalf("Unable to parse identity '%s': %s", args[0], err)
+		}
+
+		dstIdentity, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			Fatalf("Unable to parse identity '%s': %s", args[1], err)
+		}
+
+		if err := faultinject.Delete(uint32(srcIdentity), uint32(dstIdentity)); err != nil {
+			Fatalf("error deleting contents of map: %s\n", err)
+		}
+	},
+}
+
+func init() {
+	bpfFaultCmd.AddCommand(bpfFaultDeleteCmd)
+}
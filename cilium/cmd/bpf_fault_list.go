@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cilium/cilium/pkg/command"
+	"github.com/cilium/cilium/pkg/common"
+	"github.com/cilium/cilium/pkg/maps/faultinject"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	faultListUsage = "List fault injection entries.\n"
+)
+
+var bpfFaultListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List fault injection entries",
+	Long:    faultListUsage,
+	Run: func(cmd *cobra.Command, args []string) {
+		common.RequireRootPrivilege("cilium bpf fault list")
+
+		bpfFaultList := make(map[string][]string)
+		if err := faultinject.Map.Dump(bpfFaultList); err != nil {
+			Fatalf("error dumping contents of map: %s\n", err)
+		}
+
+		if command.OutputJSON() {
+			if err := command.PrintOutput(bpfFaultList); err != nil {
+				Fatalf("error getting output of map in JSON: %s\n", err)
+			}
+			return
+		}
+
+		if len(bpfFaultList) == 0 {
+			fmt.Fprintf(os.Stderr, "No entries found.\n")
+		} else {
+			TablePrinter("IDENTITY PAIR", "DROP PERCENTAGE", bpfFaultList)
+		}
+	},
+}
+
+func init() {
+	bpfFaultCmd.AddCommand(bpfFaultListCmd)
+	command.AddJSONOutput(bpfFaultListCmd)
+}
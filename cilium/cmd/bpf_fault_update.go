@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/cilium/cilium/pkg/common"
+	"github.com/cilium/cilium/pkg/maps/faultinject"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	faultUpdateUsage = "Create/Update fault injection entry. Drops the given " +
+		"percentage of packets sent from the source to the destination " +
+		"identity. Requires the agent to run with fault injection enabled.\n"
+)
+
+var bpfFaultUpdateCmd = &cobra.Command{
+	Args:    cobra.ExactArgs(3),
+	Use:     "update",
+	Short:   "Update fault injection entries",
+	Aliases: []string{"add"},
+	Long:    faultUpdateUsage,
+	Run: func(cmd *cobra.Command, args []string) {
+		common.RequireRootPrivilege("cilium bpf fault update <src_identity> <dst_identity> <drop_percentage>")
+
+		srcIdentity, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			Fatalf("Unable to parse identity '%s': %s", args[0], err)
+		}
+
+		dstIdentity, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			Fatalf("Unable to parse identity '%s': %s", args[1], err)
+		}
+
+		probability, err := strconv.ParseUint(args[2], 10, 8)
+		if err != nil || probability > 100 {
+			Fatalf("Invalid drop percentage '%s', must be between 0 and 100", args[2])
+		}
+
+		if err := faultinject.Update(uint32(srcIdentity), uint32(dstIdentity), uint8(probability)); err != nil {
+			Fatalf("error updating contents of map: %s\n", err)
+		}
+	},
+}
+
+func init() {
+	bpfFaultCmd.AddCommand(bpfFaultUpdateCmd)
+}
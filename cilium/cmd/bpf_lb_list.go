@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/cilium/cilium/pkg/bpf"
 	"github.com/cilium/cilium/pkg/command"
@@ -22,7 +23,10 @@ const (
 )
 
 var (
-	listRevNAT bool
+	listRevNAT   bool
+	lbListFilter string
+	lbListOffset int
+	lbListLimit  int
 )
 
 func dumpRevNat(serviceList map[string][]string) {
@@ -50,6 +54,27 @@ func dumpSVC(serviceList map[string][]string) {
 		Fatalf("Unable to dump IPv6 backends table: %s", err)
 	}
 
+	var filterIP net.IP
+	if lbListFilter != "" {
+		if filterIP = net.ParseIP(lbListFilter); filterIP == nil {
+			Fatalf("Invalid filter IP address %q", lbListFilter)
+		}
+	}
+
+	// A service entry is selected if either its frontend or the backend it
+	// references matches the filter IP.
+	frontendFilter := lbmap.FilterByFrontendIP(filterIP)
+	filter := func(key bpf.MapKey, value bpf.MapValue) bool {
+		if filterIP == nil {
+			return true
+		}
+		if frontendFilter(key, value) {
+			return true
+		}
+		backend, found := backendMap[value.(lbmap.ServiceValue).ToHost().GetBackendID()]
+		return found && backend.GetAddress().Equal(filterIP)
+	}
+
 	parseSVCEntry := func(key bpf.MapKey, value bpf.MapValue) {
 		var entry string
 
@@ -81,10 +106,13 @@ func dumpSVC(serviceList map[string][]string) {
 		serviceList[svc] = append(serviceList[svc], entry)
 	}
 
-	if err := lbmap.Service4MapV2.DumpWithCallbackIfExists(parseSVCEntry); err != nil {
+	// Share the callback between both dumps so that the pagination window
+	// spans the IPv4 and IPv6 tables.
+	cb := bpf.PaginatedCallback(filter, lbListOffset, lbListLimit, parseSVCEntry)
+	if err := lbmap.Service4MapV2.DumpWithCallbackIfExists(cb); err != nil {
 		Fatalf("Unable to dump IPv4 services table: %s", err)
 	}
-	if err := lbmap.Service6MapV2.DumpWithCallbackIfExists(parseSVCEntry); err != nil {
+	if err := lbmap.Service6MapV2.DumpWithCallbackIfExists(cb); err != nil {
 		Fatalf("Unable to dump IPv6 services table: %s", err)
 	}
 }
@@ -129,5 +157,8 @@ var bpfLBListCmd = &cobra.Command{
 func init() {
 	bpfLBCmd.AddCommand(bpfLBListCmd)
 	bpfLBListCmd.Flags().BoolVarP(&listRevNAT, "revnat", "", false, "List reverse NAT entries")
+	bpfLBListCmd.Flags().StringVar(&lbListFilter, "filter-ip", "", "List only services with this frontend or backend IP")
+	bpfLBListCmd.Flags().IntVar(&lbListOffset, "offset", 0, "Skip this number of matching service entries")
+	bpfLBListCmd.Flags().IntVar(&lbListLimit, "limit", 0, "List at most this number of service entries (0 means no limit)")
 	command.AddJSONOutput(bpfLBListCmd)
 }
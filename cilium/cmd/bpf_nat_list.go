@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"reflect"
 
@@ -16,6 +17,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	natListFilter string
+	natListOffset int
+	natListLimit  int
+)
+
 // bpfNatListCmd represents the bpf_nat_list command
 var bpfNatListCmd = &cobra.Command{
 	Use:     "list",
@@ -33,9 +40,23 @@ var bpfNatListCmd = &cobra.Command{
 
 func init() {
 	bpfNatCmd.AddCommand(bpfNatListCmd)
+	bpfNatListCmd.Flags().StringVar(&natListFilter, "filter-ip", "", "List only entries with this source or destination IP")
+	bpfNatListCmd.Flags().IntVar(&natListOffset, "offset", 0, "Skip this number of matching entries per map")
+	bpfNatListCmd.Flags().IntVar(&natListLimit, "limit", 0, "List at most this number of entries per map (0 means no limit)")
 	command.AddJSONOutput(bpfNatListCmd)
 }
 
+func natDumpFilter() bpf.DumpFilter {
+	if natListFilter == "" {
+		return nil
+	}
+	ip := net.ParseIP(natListFilter)
+	if ip == nil {
+		Fatalf("Invalid filter IP address %q", natListFilter)
+	}
+	return nat.FilterByIP(ip)
+}
+
 func dumpNat(maps []interface{}, args ...interface{}) {
 	entries := make([]nat.NatMapRecord, 0)
 
@@ -62,11 +83,12 @@ func dumpNat(maps []interface{}, args ...interface{}) {
 				record := nat.NatMapRecord{Key: key.(nat.NatKey), Value: value.(nat.NatEntry)}
 				entries = append(entries, record)
 			}
+			callback = bpf.PaginatedCallback(natDumpFilter(), natListOffset, natListLimit, callback)
 			if err = m.(nat.NatMap).DumpWithCallback(callback); err != nil {
 				Fatalf("Error while collecting BPF map entries: %s", err)
 			}
 		} else {
-			out, err := m.(nat.NatMap).DumpEntries()
+			out, err := nat.DumpEntriesWithFilter(m.(nat.NatMap), natDumpFilter(), natListOffset, natListLimit)
 			if err != nil {
 				Fatalf("Error while dumping BPF Map: %s", err)
 			}
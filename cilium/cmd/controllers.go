@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// controllersCmd represents the controllers command
+var controllersCmd = &cobra.Command{
+	Use:   "controllers",
+	Short: "Inspect and manually run controllers",
+}
+
+func init() {
+	rootCmd.AddCommand(controllersCmd)
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	daemonAPI "github.com/cilium/cilium/api/v1/client/daemon"
+	"github.com/cilium/cilium/pkg/api"
+	"github.com/cilium/cilium/pkg/command"
+
+	"github.com/spf13/cobra"
+)
+
+// controllersListCmd represents the controllers_list command
+var controllersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all registered controllers",
+	Run: func(cmd *cobra.Command, args []string) {
+		listControllers()
+	},
+}
+
+func listControllers() {
+	params := daemonAPI.NewGetHealthzParamsWithTimeout(api.ClientTimeout)
+	resp, err := client.Daemon.GetHealthz(params)
+	if err != nil {
+		Fatalf("%s", err)
+	}
+
+	controllers := resp.Payload.Controllers
+	sort.Slice(controllers, func(i, j int) bool {
+		return controllers[i].Name < controllers[j].Name
+	})
+
+	if command.OutputJSON() {
+		if err := command.PrintOutput(controllers); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 5, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "Name\tSuccess\tFailure\tConsecutive failures\tLast failure\n")
+	for _, ctrl := range controllers {
+		if ctrl.Status == nil {
+			continue
+		}
+
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\n",
+			ctrl.Name, ctrl.Status.SuccessCount, ctrl.Status.FailureCount,
+			ctrl.Status.ConsecutiveFailureCount, ctrl.Status.LastFailureMsg)
+	}
+	w.Flush()
+}
+
+func init() {
+	controllersCmd.AddCommand(controllersListCmd)
+	command.AddJSONOutput(controllersListCmd)
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// controllersRunCmd represents the controllers_run command
+var controllersRunCmd = &cobra.Command{
+	Use:     "run <name>",
+	Short:   "Trigger an immediate run of a controller",
+	Long:    "Triggers an immediate run of the given controller and resets its exponential error back-off",
+	Example: "cilium controllers run sync-to-k8s-ciliumendpoint",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			Fatalf("controller name must be specified")
+		}
+
+		if err := client.ControllerTrigger(args[0]); err != nil {
+			Fatalf("Cannot trigger controller %s: %s", args[0], err)
+		}
+
+		fmt.Printf("Controller %s triggered\n", args[0])
+	},
+}
+
+func init() {
+	controllersCmd.AddCommand(controllersRunCmd)
+}
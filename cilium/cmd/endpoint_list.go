@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/cilium/cilium/api/v1/models"
@@ -23,7 +25,11 @@ const (
 	UnknownState   = "Unknown"
 )
 
-var noHeaders bool
+var (
+	noHeaders   bool
+	listLabels  []string
+	listGroupBy []string
+)
 
 // endpointListCmd represents the endpoint_list command
 var endpointListCmd = &cobra.Command{
@@ -31,6 +37,10 @@ var endpointListCmd = &cobra.Command{
 	Aliases: []string{"ls"},
 	Short:   "List all endpoints",
 	Run: func(cmd *cobra.Command, args []string) {
+		if len(listGroupBy) > 0 {
+			listEndpointGroups()
+			return
+		}
 		listEndpoints()
 	},
 }
@@ -38,6 +48,8 @@ var endpointListCmd = &cobra.Command{
 func init() {
 	endpointCmd.AddCommand(endpointListCmd)
 	endpointListCmd.Flags().BoolVar(&noHeaders, "no-headers", false, "Do not print headers")
+	endpointListCmd.Flags().StringSliceVarP(&listLabels, "labels", "l", nil, "List only endpoints having all the given labels (e.g. key=value)")
+	endpointListCmd.Flags().StringSliceVar(&listGroupBy, "group-by", nil, "Print aggregate endpoint counts grouped by the given label keys")
 	command.AddJSONOutput(endpointListCmd)
 }
 
@@ -114,7 +126,7 @@ func listEndpoint(w *tabwriter.Writer, ep *models.Endpoint, id string, label str
 }
 
 func listEndpoints() {
-	eps, err := client.EndpointList()
+	eps, err := client.EndpointListByLabels(listLabels)
 	if err != nil {
 		Fatalf("cannot get endpoint list: %s\n", err)
 	}
@@ -122,6 +134,37 @@ func listEndpoints() {
 	printEndpointList(w, eps)
 }
 
+func listEndpointGroups() {
+	groups, err := client.EndpointGroups(listGroupBy, listLabels)
+	if err != nil {
+		Fatalf("cannot get endpoint groups: %s\n", err)
+	}
+
+	if command.OutputJSON() {
+		if err := command.PrintOutput(groups); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 5, 0, 3, ' ', 0)
+	if !noHeaders {
+		fmt.Fprintf(w, "LABELS\tCOUNT\tENDPOINTS\t\n")
+	}
+	for _, group := range groups {
+		lbls := "<none>"
+		if len(group.Labels) > 0 {
+			lbls = strings.Join(group.Labels, ",")
+		}
+		ids := make([]string, 0, len(group.EndpointIds))
+		for _, id := range group.EndpointIds {
+			ids = append(ids, strconv.FormatInt(id, 10))
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t\n", lbls, group.Count, strings.Join(ids, ","))
+	}
+	w.Flush()
+}
+
 func printEndpointList(w *tabwriter.Writer, eps []*models.Endpoint) {
 	sort.Slice(eps, func(i, j int) bool { return eps[i].ID < eps[j].ID })
 
@@ -4,16 +4,26 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/gob"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"os"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cilium/cilium/api/v1/client/policy"
 	"github.com/cilium/cilium/api/v1/models"
 	"github.com/cilium/cilium/pkg/command"
+	"github.com/cilium/cilium/pkg/monitor/agent/listener"
+	monitorAPI "github.com/cilium/cilium/pkg/monitor/api"
+	"github.com/cilium/cilium/pkg/monitor/payload"
 )
 
 var fqdnCmd = &cobra.Command{
@@ -56,12 +66,23 @@ var fqdnListCacheCmd = &cobra.Command{
 	},
 }
 
+var fqdnWatchCacheCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch fqdn cache updates and policy decisions",
+	Long: `Watch streams FQDN cache insertions, expirations and DNS proxy policy
+decisions from the agent in real time as JSON, one event per line.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		watchFQDNCache()
+	},
+}
+
 var fqdnCacheMatchPattern string
 var fqdnEndpointID string
 
 func init() {
 	fqdnCacheCmd.AddCommand(fqdnListCacheCmd)
 	fqdnCacheCmd.AddCommand(fqdnCleanCacheCmd)
+	fqdnCacheCmd.AddCommand(fqdnWatchCacheCmd)
 	fqdnCmd.AddCommand(fqdnCacheCmd)
 	fqdnCmd.AddCommand(fqdnNames)
 	rootCmd.AddCommand(fqdnCmd)
@@ -170,3 +191,70 @@ func listFQDNNames() {
 		Fatalf("Unable to print JSON output: %s\n", err)
 	}
 }
+
+// watchFQDNCache subscribes to the monitor socket and streams the FQDN
+// related agent notifications until interrupted.
+func watchFQDNCache() {
+	setupSigHandler()
+	fmt.Printf("Press Ctrl-C to quit\n")
+
+	// On EOF, retry
+	// On other errors, exit
+	// always wait connTimeout when retrying
+	for ; ; time.Sleep(connTimeout) {
+		conn, version, err := openMonitorSock(viper.GetString("monitor-socket"))
+		if err != nil {
+			log.WithError(err).Error("Cannot open monitor socket")
+			return
+		}
+
+		err = consumeFQDNEvents(conn, version)
+		switch {
+		case err == nil:
+		// no-op
+
+		case err == io.EOF, errors.Is(err, io.ErrUnexpectedEOF):
+			log.WithError(err).Warn("connection closed")
+			continue
+
+		default:
+			log.WithError(err).Fatal("decoding error")
+		}
+	}
+}
+
+// consumeFQDNEvents prints the FQDN cache and policy verdict notifications
+// received on a monitor connection, dropping all other events. It closes conn
+// on return, and returns on error, including io.EOF
+func consumeFQDNEvents(conn net.Conn, version listener.Version) error {
+	defer conn.Close()
+
+	getParsedPayload, err := getMonitorParser(conn, version)
+	if err != nil {
+		return err
+	}
+
+	for {
+		pl, err := getParsedPayload()
+		if err != nil {
+			return err
+		}
+		if pl.Type != payload.EventSample || len(pl.Data) == 0 ||
+			pl.Data[0] != monitorAPI.MessageTypeAgent {
+			continue
+		}
+
+		an := monitorAPI.AgentNotify{}
+		if err := gob.NewDecoder(bytes.NewBuffer(pl.Data[1:])).Decode(&an); err != nil {
+			log.WithError(err).Warn("Cannot decode agent notification")
+			continue
+		}
+
+		switch an.Type {
+		case monitorAPI.AgentNotifyFQDNCacheUpserted,
+			monitorAPI.AgentNotifyFQDNCacheExpired,
+			monitorAPI.AgentNotifyFQDNPolicyVerdict:
+			an.DumpJSON()
+		}
+	}
+}
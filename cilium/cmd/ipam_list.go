@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/cilium/cilium/pkg/command"
+	ipamTypes "github.com/cilium/cilium/pkg/ipam/types"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	ipamListOwner string
+	ipamListPool  string
+)
+
+// ipamListCmd represents the ipam_list command
+var ipamListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List IP address allocations",
+	Run: func(cmd *cobra.Command, args []string) {
+		listIPAMAllocations(cmd, args)
+	},
+}
+
+func init() {
+	ipamCmd.AddCommand(ipamListCmd)
+	ipamListCmd.Flags().StringVar(&ipamListOwner, "owner", "", "Only list allocations of the given owner")
+	ipamListCmd.Flags().StringVar(&ipamListPool, "pool", "", "Only list allocations from the given pool")
+	command.AddJSONOutput(ipamListCmd)
+}
+
+func listIPAMAllocations(cmd *cobra.Command, args []string) {
+	resp, err := client.Daemon.GetDebuginfo(nil)
+	if err != nil {
+		Fatalf("Cannot get ipam allocation list: %s", err)
+	}
+
+	status, ok := resp.Payload.Subsystem["ipam-allocations"]
+	if !ok {
+		Fatalf("Agent does not provide the ipam allocation list")
+	}
+
+	var allocations []ipamTypes.Allocation
+	if err := json.Unmarshal([]byte(status), &allocations); err != nil {
+		Fatalf("Cannot parse ipam allocation list: %s", err)
+	}
+
+	filtered := allocations[:0]
+	for _, allocation := range allocations {
+		if ipamListOwner != "" && allocation.Owner != ipamListOwner {
+			continue
+		}
+		if ipamListPool != "" && allocation.Pool != ipamListPool {
+			continue
+		}
+		filtered = append(filtered, allocation)
+	}
+
+	if command.OutputJSON() {
+		if err := command.PrintOutput(filtered); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 5, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "IP\tOwner\tPool\tResource\tAllocated")
+	for _, allocation := range filtered {
+		allocatedAt := ""
+		if !allocation.AllocatedAt.IsZero() {
+			allocatedAt = allocation.AllocatedAt.Format("2006-01-02T15:04:05Z07:00")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			allocation.IP, allocation.Owner, allocation.Pool, allocation.Resource, allocatedAt)
+	}
+	w.Flush()
+}
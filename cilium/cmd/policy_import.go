@@ -15,6 +15,7 @@ import (
 )
 
 var printPolicy bool
+var shadowPolicy bool
 
 // policyImportCmd represents the policy_import command
 var policyImportCmd = &cobra.Command{
@@ -46,6 +47,15 @@ var policyImportCmd = &cobra.Command{
 			if err != nil {
 				Fatalf("Cannot marshal policy: %s\n", err)
 			}
+			if shadowPolicy {
+				resp, err := client.PolicyShadowPut(string(jsonPolicy))
+				if err != nil {
+					Fatalf("Cannot evaluate policy in shadow: %s\n", err)
+				}
+				fmt.Printf("%s\n", resp.Policy)
+				return
+			}
+
 			if resp, err := client.PolicyPut(string(jsonPolicy)); err != nil {
 				Fatalf("Cannot import policy: %s\n", err)
 			} else if command.OutputJSON() {
@@ -64,5 +74,6 @@ var policyImportCmd = &cobra.Command{
 func init() {
 	policyCmd.AddCommand(policyImportCmd)
 	policyImportCmd.Flags().BoolVarP(&printPolicy, "print", "", false, "Print policy after import")
+	policyImportCmd.Flags().BoolVarP(&shadowPolicy, "shadow", "", false, "Evaluate the policy in shadow against the enforced policy and print the divergence report instead of importing it")
 	command.AddJSONOutput(policyImportCmd)
 }
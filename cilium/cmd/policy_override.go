@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cilium/cilium/api/v1/models"
+
+	"github.com/spf13/cobra"
+)
+
+var flowOverride models.FlowOverride
+
+// policyOverrideCmd represents the policy_override command
+var policyOverrideCmd = &cobra.Command{
+	Use:   "override",
+	Short: "Install a temporary allow entry for a specific flow",
+	Long: `Installs a temporary allow entry into the policy map of an endpoint
+for troubleshooting purposes. The entry is removed automatically when its
+time to live expires. Requires the agent to run with flow overrides enabled
+(--enable-flow-overrides).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if flowOverride.EndpointID == 0 {
+			Usagef(cmd, "Missing --endpoint argument")
+		}
+		if flowOverride.Identity == 0 {
+			Usagef(cmd, "Missing --identity argument")
+		}
+
+		if err := client.PolicyFlowOverridePut(&flowOverride); err != nil {
+			Fatalf("Cannot install flow override: %s\n", err)
+		}
+
+		direction := "egress"
+		if flowOverride.Ingress {
+			direction = "ingress"
+		}
+		fmt.Printf("Allowing identity %d on port %d/%s (%s) of endpoint %d for %d seconds\n",
+			flowOverride.Identity, flowOverride.Port, flowOverride.Protocol,
+			direction, flowOverride.EndpointID, flowOverride.TTLSeconds)
+	},
+}
+
+func init() {
+	policyCmd.AddCommand(policyOverrideCmd)
+	policyOverrideCmd.Flags().Int64Var(&flowOverride.EndpointID, "endpoint", 0, "Endpoint ID to install the override for")
+	policyOverrideCmd.Flags().Int64Var(&flowOverride.Identity, "identity", 0, "Security identity of the peer to allow")
+	policyOverrideCmd.Flags().Int64Var(&flowOverride.Port, "port", 0, "Destination port to allow (0 for all ports)")
+	policyOverrideCmd.Flags().StringVar(&flowOverride.Protocol, "protocol", "any", "Protocol to allow (tcp, udp or any)")
+	policyOverrideCmd.Flags().BoolVar(&flowOverride.Ingress, "ingress", false, "Install the override for ingress instead of egress traffic")
+	policyOverrideCmd.Flags().Int64Var(&flowOverride.TTLSeconds, "ttl", 60, "Time to live of the override in seconds")
+}
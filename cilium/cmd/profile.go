@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	daemonAPI "github.com/cilium/cilium/api/v1/client/daemon"
+	"github.com/cilium/cilium/pkg/api"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileType    string
+	profileSeconds int64
+	profileOutput  string
+)
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:     "profile",
+	Short:   "Capture a runtime profile of the agent",
+	Example: "cilium profile --type cpu --seconds 5 --output cpu.pprof",
+	Run: func(cmd *cobra.Command, args []string) {
+		params := daemonAPI.NewGetProfileParams().
+			WithProfile(&profileType).
+			WithSeconds(&profileSeconds).
+			WithTimeout(time.Duration(profileSeconds)*time.Second + api.ClientTimeout)
+
+		resp, err := client.Daemon.GetProfile(params)
+		if err != nil {
+			Fatalf("%s", err)
+		}
+
+		result := resp.Payload
+		if result == nil {
+			return
+		}
+
+		if profileOutput == "-" {
+			if _, err := os.Stdout.Write(result.Data); err != nil {
+				Fatalf("Cannot write profile to stdout: %s", err)
+			}
+			return
+		}
+
+		filename := profileOutput
+		if filename == "" {
+			suffix := "pprof"
+			if result.Type == "goroutine" {
+				suffix = "txt"
+			}
+			filename = fmt.Sprintf("cilium-profile-%s-%s.%s",
+				result.Type, time.Now().Format("20060102-150405"), suffix)
+		}
+
+		if err := os.WriteFile(filename, result.Data, 0644); err != nil {
+			Fatalf("Cannot write profile to %s: %s", filename, err)
+		}
+
+		fmt.Printf("Wrote %s profile to %s\n", result.Type, filename)
+		if result.Context != nil {
+			fmt.Printf("Agent state during capture: %d endpoints regenerating, %d proxy redirects\n",
+				result.Context.EndpointsRegenerating, result.Context.ProxyRedirects)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.Flags().StringVar(&profileType, "type", "cpu",
+		"Profile type to capture (cpu, heap, block, mutex, goroutine)")
+	profileCmd.Flags().Int64Var(&profileSeconds, "seconds", 10,
+		"Duration in seconds over which CPU, block and mutex profiles are sampled")
+	profileCmd.Flags().StringVarP(&profileOutput, "output", "o", "",
+		"File to write the profile to (\"-\" for stdout, default derived from the profile type)")
+}
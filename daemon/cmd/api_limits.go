@@ -16,6 +16,7 @@ const (
 	apiRequestEndpointGet    = "endpoint-get"
 	apiRequestEndpointPatch  = "endpoint-patch"
 	apiRequestEndpointList   = "endpoint-list"
+	apiRequestProfile        = "profile"
 )
 
 var apiRateLimitDefaults = map[string]rate.APILimiterParameters{
@@ -93,6 +94,20 @@ var apiRateLimitDefaults = map[string]rate.APILimiterParameters{
 		ParallelRequests:            2,
 		MinParallelRequests:         2,
 	},
+	// GET /profile
+	//
+	// Profile captures are expensive and mutate global runtime sampling
+	// state, so only a single capture may be in flight at any time.
+	// Additional requests fail quickly instead of queueing up behind a
+	// long-running capture. Logging is enabled for visibility as
+	// frequency should be very low.
+	apiRequestProfile: {
+		RateLimit:        0.1,
+		RateBurst:        1,
+		ParallelRequests: 1,
+		MaxWaitDuration:  time.Second,
+		Log:              true,
+	},
 }
 
 type apiRateLimitingMetrics struct{}
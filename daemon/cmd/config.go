@@ -11,6 +11,7 @@ import (
 	. "github.com/cilium/cilium/api/v1/server/restapi/daemon"
 	"github.com/cilium/cilium/pkg/api"
 	"github.com/cilium/cilium/pkg/eventqueue"
+	"github.com/cilium/cilium/pkg/features"
 	"github.com/cilium/cilium/pkg/k8s"
 	"github.com/cilium/cilium/pkg/logging/logfields"
 	"github.com/cilium/cilium/pkg/node"
@@ -35,7 +36,24 @@ func (h *patchConfig) configModify(params PatchConfigParams, resChan chan interf
 
 	cfgSpec := params.Configuration
 
-	om, err := option.Config.Opts.Library.ValidateConfigurationMap(cfgSpec.Options)
+	// Split toggles for runtime features from the daemon configuration
+	// options before validation.
+	featureToggles := map[string]bool{}
+	daemonOptions := make(models.ConfigurationMap, len(cfgSpec.Options))
+	for k, v := range cfgSpec.Options {
+		if features.IsRegistered(k) {
+			setting, err := option.NormalizeBool(v)
+			if err != nil {
+				resChan <- api.Error(PatchConfigBadRequestCode, err)
+				return
+			}
+			featureToggles[k] = setting == option.OptionEnabled
+			continue
+		}
+		daemonOptions[k] = v
+	}
+
+	om, err := option.Config.Opts.Library.ValidateConfigurationMap(daemonOptions)
 	if err != nil {
 		msg := fmt.Errorf("Invalid configuration option %s", err)
 		resChan <- api.Error(PatchConfigBadRequestCode, msg)
@@ -108,10 +126,91 @@ func (h *patchConfig) configModify(params PatchConfigParams, resChan chan interf
 		d.TriggerDatapathRegen(policyEnforcementChanged, "agent configuration update")
 	}
 
+	// Only migrate if an endpoint routes mode was provided.
+	if target := cfgSpec.EndpointRoutes; target != nil && *target != option.Config.EnableEndpointRoutes {
+		if err := d.migrateEndpointRoutes(*target); err != nil {
+			resChan <- api.Error(PatchConfigFailureCode, err)
+			return
+		}
+	}
+
+	for name, enable := range featureToggles {
+		var err error
+		if enable {
+			err = features.Enable(name)
+		} else {
+			err = features.Disable(name)
+		}
+		if err != nil {
+			resChan <- api.Error(PatchConfigFailureCode, err)
+			return
+		}
+	}
+
 	resChan <- NewPatchConfigOK()
 	return
 }
 
+// migrateEndpointRoutes migrates the node between endpoint routes modes at
+// runtime. The base programs and iptables rules are recompiled for the target
+// mode first, then the route and rule set of each endpoint is replaced and
+// verified as part of an endpoint regeneration. Previously this migration
+// required draining the node and recreating all endpoints.
+func (d *Daemon) migrateEndpointRoutes(enabled bool) error {
+	log.WithField(option.EnableEndpointRoutes, enabled).Info("Migrating endpoint routes mode")
+	option.Config.EnableEndpointRoutes = enabled
+
+	// The base programs and iptables rules depend on the endpoint routes
+	// mode, migrate them before the endpoints.
+	if err := d.Datapath().Loader().Reinitialize(d.ctx, d, d.mtuConfig.GetDeviceMTU(), d.Datapath(), d.l7Proxy); err != nil {
+		option.Config.EnableEndpointRoutes = !enabled
+		return fmt.Errorf("unable to recompile base programs: %w", err)
+	}
+
+	var failed int
+	for _, ep := range d.endpointManager.GetEndpoints() {
+		if err := ep.SetEndpointRoutes(enabled); err != nil {
+			log.WithError(err).WithField(logfields.EndpointID, ep.GetID()).
+				Error("Endpoint routes migration failed")
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("endpoint routes migration failed for %d endpoints", failed)
+	}
+
+	return nil
+}
+
+// ApplyHotConfig applies the given hot-reloadable configuration options
+// through the same serialized configuration modify path as PATCH /config. It
+// implements the configManager interface of the Kubernetes watcher and is
+// used to apply CiliumConfig custom resources.
+func (d *Daemon) ApplyHotConfig(options map[string]string, policyEnforcement string) error {
+	c := &ConfigModifyEvent{
+		params: PatchConfigParams{
+			Configuration: &models.DaemonConfigurationSpec{
+				Options:           models.ConfigurationMap(options),
+				PolicyEnforcement: policyEnforcement,
+			},
+		},
+		h: &patchConfig{daemon: d},
+	}
+	resChan, err := d.configModifyQueue.Enqueue(eventqueue.NewEvent(c))
+	if err != nil {
+		return fmt.Errorf("enqueue of ConfigModifyEvent failed: %w", err)
+	}
+
+	res, ok := <-resChan
+	if !ok {
+		return fmt.Errorf("config modify event was cancelled")
+	}
+	if err, ok := res.(error); ok {
+		return err
+	}
+	return nil
+}
+
 type patchConfig struct {
 	daemon *Daemon
 }
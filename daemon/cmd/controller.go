@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	restapi "github.com/cilium/cilium/api/v1/server/restapi/daemon"
+	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+type putControllerNameTrigger struct {
+	daemon *Daemon
+}
+
+// NewPutControllerNameTriggerHandler returns a new instance to handle
+// controller trigger requests.
+func NewPutControllerNameTriggerHandler(d *Daemon) restapi.PutControllerNameTriggerHandler {
+	return &putControllerNameTrigger{daemon: d}
+}
+
+func (h *putControllerNameTrigger) Handle(params restapi.PutControllerNameTriggerParams) middleware.Responder {
+	if err := controller.TriggerGlobalController(params.Name); err != nil {
+		return restapi.NewPutControllerNameTriggerNotFound()
+	}
+
+	log.WithField(logfields.Controller, params.Name).Info("Controller run triggered via API")
+
+	return restapi.NewPutControllerNameTriggerOK()
+}
@@ -47,6 +47,7 @@ import (
 	k8sConst "github.com/cilium/cilium/pkg/k8s/apis/cilium.io"
 	"github.com/cilium/cilium/pkg/k8s/watchers"
 	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/loadbalancer"
 	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging"
 	"github.com/cilium/cilium/pkg/logging/logfields"
@@ -66,10 +67,14 @@ import (
 	nodeTypes "github.com/cilium/cilium/pkg/node/types"
 	"github.com/cilium/cilium/pkg/nodediscovery"
 	"github.com/cilium/cilium/pkg/option"
+	"github.com/cilium/cilium/pkg/podstartup"
 	"github.com/cilium/cilium/pkg/policy"
 	policyApi "github.com/cilium/cilium/pkg/policy/api"
+	"github.com/cilium/cilium/pkg/prewarm"
 	"github.com/cilium/cilium/pkg/probe"
 	"github.com/cilium/cilium/pkg/proxy"
+	"github.com/cilium/cilium/pkg/proxy/logger"
+	"github.com/cilium/cilium/pkg/proxy/slo"
 	"github.com/cilium/cilium/pkg/rate"
 	"github.com/cilium/cilium/pkg/recorder"
 	"github.com/cilium/cilium/pkg/redirectpolicy"
@@ -141,6 +146,13 @@ type Daemon struct {
 	// ipam is the IP address manager of the agent
 	ipam *ipam.IPAM
 
+	// ipamPoolSelector resolves the IP pool a pod draws its IPs from
+	ipamPoolSelector *ipam.PoolSelector
+
+	// prewarmPool is the optional pool of pre-warmed endpoint entries,
+	// nil if pre-warming is disabled
+	prewarmPool *prewarm.Pool
+
 	netConf *cnitypes.NetConf
 
 	endpointManager *endpointmanager.EndpointManager
@@ -173,6 +185,10 @@ type Daemon struct {
 
 	// event queue for serializing configuration updates to the daemon.
 	configModifyQueue *eventqueue.EventQueue
+
+	// bpfMapMigration is the migration plan for the BPF maps pinned by a
+	// previous agent version, computed before the maps are opened.
+	bpfMapMigration []*models.BPFMapMigration
 }
 
 // GetPolicyRepository returns the policy repository of the daemon
@@ -325,6 +341,9 @@ func NewDaemon(ctx context.Context, cancel context.CancelFunc, epMgr *endpointma
 		return nil, nil, fmt.Errorf("unable to initialize Kube proxy replacement options: %w", err)
 	}
 
+	if option.Config.CTPartitionEntries > 0 {
+		ctmap.SetLocalMapEntries(option.Config.CTPartitionEntries)
+	}
 	ctmap.InitMapInfo(option.Config.CTMapEntriesGlobalTCP, option.Config.CTMapEntriesGlobalAny,
 		option.Config.EnableIPv4, option.Config.EnableIPv6, option.Config.EnableNodePort)
 	policymap.InitMapInfo(option.Config.PolicyMapEntries)
@@ -435,6 +454,7 @@ func NewDaemon(ctx context.Context, cancel context.CancelFunc, epMgr *endpointma
 		d.redirectPolicyManager,
 		d.bgpSpeaker,
 		d.egressPolicyManager,
+		&d,
 		option.Config,
 	)
 	nd.RegisterK8sNodeGetter(d.k8sWatcher)
@@ -515,7 +535,9 @@ func NewDaemon(ctx context.Context, cancel context.CancelFunc, epMgr *endpointma
 
 	debug.RegisterStatusObject("k8s-service-cache", &d.k8sWatcher.K8sSvcCache)
 	debug.RegisterStatusObject("ipam", d.ipam)
+	debug.RegisterStatusFunc("ipam-allocations", d.ipamAllocationsStatus)
 	debug.RegisterStatusObject("ongoing-endpoint-creations", d.endpointCreations)
+	debug.RegisterStatusFunc("datapath-objects", datapathObjectsStatus)
 
 	d.k8sWatcher.RunK8sServiceHandler()
 	treatRemoteNodeAsHost := option.Config.AlwaysAllowLocalhost() && !option.Config.EnableRemoteNodeIdentity
@@ -526,11 +548,51 @@ func NewDaemon(ctx context.Context, cancel context.CancelFunc, epMgr *endpointma
 	bootstrapStats.proxyStart.Start()
 	// FIXME: Make the port range configurable.
 	if option.Config.EnableL7Proxy {
-		d.l7Proxy = proxy.StartProxySupport(10000, 20000, option.Config.RunDir,
-			&d, option.Config.AgentLabels, d.datapath, d.endpointManager)
+		portRanges, err := proxy.ParsePortRanges(option.Config.ProxyPortRanges)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid proxy port ranges: %w", err)
+		}
+		d.l7Proxy = proxy.StartProxySupport(10000, 20000, portRanges, option.Config.RunDir,
+			&d, option.Config.AgentLabels, d.datapath, d.endpointManager, d.mtuConfig)
+
+		if len(option.Config.ProxyAccessLogSinks) > 0 {
+			sinks := make([]logger.Sink, 0, len(option.Config.ProxyAccessLogSinks))
+			for _, spec := range option.Config.ProxyAccessLogSinks {
+				sink, err := logger.NewSink(spec)
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid proxy access log sink: %w", err)
+				}
+				sinks = append(sinks, sink)
+			}
+			sampleRates, err := logger.ParseSampleRates(option.Config.ProxyAccessLogSampleRates)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid proxy access log sample rates: %w", err)
+			}
+			logger.SetPipeline(logger.NewPipeline(sinks, sampleRates))
+		}
+
+		if option.Config.MetricsConfig.ProxyServiceRequestsEnabled ||
+			option.Config.MetricsConfig.ProxyServiceRequestDurationEnabled {
+			logger.SetObserver(slo.NewAggregator(func(ip net.IP, port uint16) (string, string, bool) {
+				return d.svc.GetServiceNameByAddr(loadbalancer.L3n4Addr{
+					IP:     ip,
+					L4Addr: loadbalancer.L4Addr{Port: port},
+				})
+			}))
+		}
 	} else {
 		log.Info("L7 proxies are disabled")
 	}
+	if d.l7Proxy != nil {
+		// Restore the proxy port assignments of the previous agent run
+		// before any redirects are created, so that the re-created
+		// redirects re-adopt the existing datapath rules and listening
+		// ports instead of tearing them down.
+		d.l7Proxy.RestoreProxyPorts()
+		debug.RegisterStatusObject("proxy-port-events", d.l7Proxy)
+		debug.RegisterStatusFunc("proxy-redirects", d.proxyRedirectsStatus)
+		debug.RegisterStatusFunc("proxy-ports", proxyPortsStatus)
+	}
 	bootstrapStats.proxyStart.End(true)
 
 	bootstrapStats.restore.Start()
@@ -549,6 +611,27 @@ func NewDaemon(ctx context.Context, cancel context.CancelFunc, epMgr *endpointma
 	}
 	bootstrapStats.fqdn.End(true)
 
+	// The DNS proxy port restored above is now known, sweep any datapath
+	// proxy rules left behind by a previous agent run that no longer match
+	// the current proxy port state.
+	if d.l7Proxy != nil {
+		d.l7Proxy.StartStaleRuleSweeper()
+		d.l7Proxy.StartRedirectHealthChecker()
+		d.l7Proxy.StartRuleStatusUpdater()
+	}
+
+	// The subsystems tracked by the resource usage reporters have been
+	// bootstrapped at this point.
+	d.registerResourceReporters()
+
+	// Watch for runtime changes of the primary node address, e.g. after a
+	// DHCP lease renewal, and reprogram the datapath when they occur.
+	d.startNodeAddressMonitor()
+
+	// Serve the pod startup latency breakdowns recorded during endpoint
+	// creation.
+	podstartup.EnableHTTPHandler()
+
 	if k8s.IsEnabled() {
 		bootstrapStats.k8sInit.Start()
 		// Errors are handled inside WaitForCRDsToRegister. It will fatal on a
@@ -680,6 +763,10 @@ func NewDaemon(ctx context.Context, cancel context.CancelFunc, epMgr *endpointma
 		return nil, nil, fmt.Errorf(msg, option.Devices)
 	}
 
+	updateFeatureMetrics()
+	d.launchFeatureReporter()
+	d.launchSynFloodDetector()
+
 	bootstrapStats.cleanup.Start()
 	err = clearCiliumVeths()
 	bootstrapStats.cleanup.EndError(err)
@@ -839,6 +926,8 @@ func NewDaemon(ctx context.Context, cancel context.CancelFunc, epMgr *endpointma
 				return nil, nil, err
 			}
 		}
+
+		d.launchFlowExporter()
 	}
 
 	if err := d.syncEndpointsAndHostIPs(); err != nil {
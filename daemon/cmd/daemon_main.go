@@ -27,7 +27,9 @@ import (
 	"github.com/cilium/cilium/pkg/datapath/link"
 	linuxdatapath "github.com/cilium/cilium/pkg/datapath/linux"
 	"github.com/cilium/cilium/pkg/datapath/linux/ipsec"
+	"github.com/cilium/cilium/pkg/datapath/linux/ndpproxy"
 	"github.com/cilium/cilium/pkg/datapath/linux/probes"
+	"github.com/cilium/cilium/pkg/datapath/linux/routeradv"
 	linuxrouting "github.com/cilium/cilium/pkg/datapath/linux/routing"
 	"github.com/cilium/cilium/pkg/datapath/loader"
 	"github.com/cilium/cilium/pkg/datapath/maps"
@@ -35,6 +37,7 @@ import (
 	"github.com/cilium/cilium/pkg/defaults"
 	"github.com/cilium/cilium/pkg/endpoint"
 	"github.com/cilium/cilium/pkg/envoy"
+	"github.com/cilium/cilium/pkg/features"
 	"github.com/cilium/cilium/pkg/flowdebug"
 	"github.com/cilium/cilium/pkg/hubble/exporter/exporteroption"
 	"github.com/cilium/cilium/pkg/hubble/observer/observeroption"
@@ -64,6 +67,7 @@ import (
 	"github.com/cilium/cilium/pkg/pidfile"
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/pprof"
+	"github.com/cilium/cilium/pkg/prewarm"
 	"github.com/cilium/cilium/pkg/version"
 	wireguard "github.com/cilium/cilium/pkg/wireguard/agent"
 	wireguardTypes "github.com/cilium/cilium/pkg/wireguard/types"
@@ -263,6 +267,15 @@ func init() {
 	flags.Duration(option.ConntrackGCInterval, time.Duration(0), "Overwrite the connection-tracking garbage collection interval")
 	option.BindEnv(option.ConntrackGCInterval)
 
+	flags.StringSlice(option.ConntrackPartitionSelectors, nil, "Labels of endpoints whose conntrack state is kept in dedicated CT map partitions (e.g. \"k8s:app=ingress-gateway\")")
+	option.BindEnv(option.ConntrackPartitionSelectors)
+
+	flags.Int(option.ConntrackPartitionEntries, 0, "Number of entries in each partitioned CT map (0 selects the built-in local CT map size)")
+	option.BindEnv(option.ConntrackPartitionEntries)
+
+	flags.Duration(option.ConntrackPartitionGCInterval, time.Duration(0), "Garbage collection interval for partitioned CT maps (0 ties them to the global CT garbage collection)")
+	option.BindEnv(option.ConntrackPartitionGCInterval)
+
 	flags.BoolP(option.DebugArg, "D", false, "Enable debugging mode")
 	option.BindEnv(option.DebugArg)
 
@@ -296,6 +309,9 @@ func init() {
 	flags.Bool(option.EnableHealthCheckNodePort, defaults.EnableHealthCheckNodePort, "Enables a healthcheck nodePort server for NodePort services with 'healthCheckNodePort' being set")
 	option.BindEnv(option.EnableHealthCheckNodePort)
 
+	flags.Bool(option.EnableUnreachableRoutes, false, "Install unreachable routes for IPs in the IPAM pool which are not allocated to any endpoint")
+	option.BindEnv(option.EnableUnreachableRoutes)
+
 	flags.StringSlice(option.EndpointStatus, []string{},
 		"Enable additional CiliumEndpoint status features ("+strings.Join(option.EndpointStatusValues(), ",")+")")
 	option.BindEnv(option.EndpointStatus)
@@ -354,6 +370,12 @@ func init() {
 	flags.Bool(option.EnableAutoDirectRoutingName, defaults.EnableAutoDirectRouting, "Enable automatic L2 routing between nodes")
 	option.BindEnv(option.EnableAutoDirectRoutingName)
 
+	flags.Bool(option.EnableMultiUplinkEgressName, false, "Enable reachability probing of remote nodes over the multi-uplink devices and steering of direct node routes to the best uplink")
+	option.BindEnv(option.EnableMultiUplinkEgressName)
+
+	flags.StringSlice(option.MultiUplinkDevicesName, []string{}, "Ordered list of uplink devices eligible for multi-uplink egress, the first device being the preferred one (e.g. 'eth0,eth1')")
+	option.BindEnv(option.MultiUplinkDevicesName)
+
 	flags.Bool(option.EnableBPFTProxy, defaults.EnableBPFTProxy, "Enable BPF-based proxy redirection, if support available")
 	option.BindEnv(option.EnableBPFTProxy)
 
@@ -396,6 +418,9 @@ func init() {
 	flags.Bool(option.EnableWireguard, false, "Enable wireguard")
 	option.BindEnv(option.EnableWireguard)
 
+	flags.Bool(option.EnableEncryptionCoexistence, false, "Allow IPsec and wireguard to be enabled at the same time during an encryption migration")
+	option.BindEnv(option.EnableEncryptionCoexistence)
+
 	flags.Bool(option.ForceLocalPolicyEvalAtSource, defaults.ForceLocalPolicyEvalAtSource, "Force policy evaluation of all local communication at the source endpoint")
 	option.BindEnv(option.ForceLocalPolicyEvalAtSource)
 
@@ -424,6 +449,36 @@ func init() {
 	flags.Uint(option.ProxyConnectTimeout, 1, "Time after which a TCP connect attempt is considered failed unless completed (in seconds)")
 	option.BindEnv(option.ProxyConnectTimeout)
 
+	flags.Int(option.ProxyRedirectCreationAttempts, 5, "Number of attempts to create a proxy redirect before failing")
+	option.BindEnv(option.ProxyRedirectCreationAttempts)
+
+	flags.Duration(option.ProxyRedirectCreationBackoff, 100*time.Millisecond, "Base interval between proxy redirect creation attempts, grows exponentially with jitter (0 to retry immediately)")
+	option.BindEnv(option.ProxyRedirectCreationBackoff)
+
+	flags.Duration(option.ProxyRedirectCreationTimeout, 10*time.Second, "Maximum time to wait before a single proxy redirect creation retry")
+	option.BindEnv(option.ProxyRedirectCreationTimeout)
+
+	flags.StringSlice(option.ProxyPortRanges, nil, "Proxy port allocation ranges per proxy type (e.g. \"dns=10000-10100,http=11000-12000\"), proxy types without an entry allocate from the global range")
+	option.BindEnv(option.ProxyPortRanges)
+
+	flags.StringSlice(option.DelegatedIdentityHeaders, nil, "Request headers carrying a workload identity delegated from an external service mesh (e.g. \"x-spiffe-id\"), preserved in L7 access log records and Hubble flows")
+	option.BindEnv(option.DelegatedIdentityHeaders)
+
+	flags.StringSlice(option.ProxyAccessLogSinks, nil, "Sinks to emit L7 access log records to in addition to the agent monitor (e.g. \"file:/var/log/cilium-access.log\", \"syslog\")")
+	option.BindEnv(option.ProxyAccessLogSinks)
+
+	flags.StringSlice(option.ProxyAccessLogSampleRates, nil, "Sampling rates of the L7 access log sinks per L7 protocol (e.g. \"http=100\" to emit one of every 100 HTTP records)")
+	option.BindEnv(option.ProxyAccessLogSampleRates)
+
+	flags.Bool(option.EnableIPv6NDPProxy, false, "Answer neighbor solicitations for local endpoint IPv6 addresses on the direct routing device")
+	option.BindEnv(option.EnableIPv6NDPProxy)
+
+	flags.Bool(option.EnableIPv6RouterAdvertisements, false, "Emit ICMPv6 router advertisements (prefix, MTU, DNS servers) on endpoint interfaces")
+	option.BindEnv(option.EnableIPv6RouterAdvertisements)
+
+	flags.StringSlice(option.IPv6RADNSServers, nil, "IPv6 DNS servers advertised in router advertisements")
+	option.BindEnv(option.IPv6RADNSServers)
+
 	flags.Int(option.ProxyPrometheusPort, 0, "Port to serve Envoy metrics on. Default 0 (disabled).")
 	option.BindEnv(option.ProxyPrometheusPort)
 
@@ -445,6 +500,21 @@ func init() {
 	flags.String(option.IPAM, ipamOption.IPAMClusterPool, "Backend to use for IPAM")
 	option.BindEnv(option.IPAM)
 
+	flags.String(option.IPAMDelegatedPlugin, "", "Path of the external CNI IPAM plugin binary used in delegated-plugin IPAM mode")
+	option.BindEnv(option.IPAMDelegatedPlugin)
+
+	flags.String(option.IPAMWebhookURL, "", "Base URL of the external IPAM webhook used in webhook IPAM mode")
+	option.BindEnv(option.IPAMWebhookURL)
+
+	flags.Duration(option.IPAMWebhookLeaseTTL, 10*time.Minute, "Lease TTL requested for allocations made via the external IPAM webhook")
+	option.BindEnv(option.IPAMWebhookLeaseTTL)
+
+	flags.String(option.IPAMAllocationStrategy, ipamOption.IPAMAllocationStrategyFirst, "Strategy to select the next IP to allocate from the pool (\"first\", \"round-robin\", \"least-allocated\", \"subnet-affinity\")")
+	option.BindEnv(option.IPAMAllocationStrategy)
+
+	flags.Int(option.IPAMPoolLowWatermark, 0, "Number of free IPs in the IPAM allocation pool below which a warning event is emitted and a pool refresh is triggered (0 to disable)")
+	option.BindEnv(option.IPAMPoolLowWatermark)
+
 	flags.String(option.IPv4Range, AutoCIDR, "Per-node IPv4 endpoint prefix, e.g. 10.16.0.0/16")
 	option.BindEnv(option.IPv4Range)
 
@@ -463,6 +533,9 @@ func init() {
 	flags.Bool(option.K8sEventHandover, defaults.K8sEventHandover, "Enable k8s event handover to kvstore for improved scalability")
 	option.BindEnv(option.K8sEventHandover)
 
+	flags.Bool(option.EnableK8sAgentEvents, false, "Emit datapath impacting agent events as Kubernetes events on the local CiliumNode")
+	option.BindEnv(option.EnableK8sAgentEvents)
+
 	flags.String(option.K8sAPIServer, "", "Kubernetes API server URL")
 	option.BindEnv(option.K8sAPIServer)
 
@@ -507,6 +580,12 @@ func init() {
 	flags.Duration(option.KVstoreConnectivityTimeout, defaults.KVstoreConnectivityTimeout, "Time after which an incomplete kvstore operation  is considered failed")
 	option.BindEnv(option.KVstoreConnectivityTimeout)
 
+	flags.String(option.KVstoreNamespace, "", "Additional prefix prepended to all kvstore keys to allow multiple logical clusters to share one kvstore")
+	option.BindEnv(option.KVstoreNamespace)
+
+	flags.Bool(option.KVstoreNamespaceMigrate, false, "Copy existing unnamespaced kvstore keys into the kvstore namespace on startup")
+	option.BindEnv(option.KVstoreNamespaceMigrate)
+
 	flags.Duration(option.IPAllocationTimeout, defaults.IPAllocationTimeout, "Time after which an incomplete CIDR allocation is considered failed")
 	option.BindEnv(option.IPAllocationTimeout)
 
@@ -527,6 +606,9 @@ func init() {
 	flags.StringSlice(option.Labels, []string{}, "List of label prefixes used to determine identity of an endpoint")
 	option.BindEnv(option.Labels)
 
+	flags.StringSlice(option.RedactLabelKeys, []string{}, "List of regular expressions matching label keys whose values are redacted from identities, Hubble flows and the API")
+	option.BindEnv(option.RedactLabelKeys)
+
 	flags.String(option.KubeProxyReplacement, option.KubeProxyReplacementPartial, fmt.Sprintf(
 		"auto-enable available features for kube-proxy replacement (%q), "+
 			"or enable only selected features (will panic if any selected feature cannot be enabled) (%q) "+
@@ -551,6 +633,52 @@ func init() {
 	flags.Bool(option.EnableBandwidthManager, false, "Enable BPF bandwidth manager")
 	option.BindEnv(option.EnableBandwidthManager)
 
+	flags.Bool(option.EnableNetworkPriority, false, "Enable mapping of pod network priority class annotations to egress scheduling bands (requires bandwidth manager)")
+	option.BindEnv(option.EnableNetworkPriority)
+
+	flags.Bool(option.EnableFaultInjection, false, "Enable fault injection in the datapath for resilience testing")
+	flags.MarkHidden(option.EnableFaultInjection)
+	option.BindEnv(option.EnableFaultInjection)
+
+	flags.Bool(option.EnableSynFloodProtection, false, "Enable detection and mitigation of SYN floods toward NodePort and LoadBalancer service frontends")
+	option.BindEnv(option.EnableSynFloodProtection)
+
+	flags.Int(option.SynFloodThreshold, 1000, "Number of half-open connections toward a service frontend above which SYN flood mitigation kicks in")
+	option.BindEnv(option.SynFloodThreshold)
+
+	flags.Bool(option.EnableTunneledProtoDetection, false, "Drop protocols tunneled through allowed connections, such as DNS carried in ICMP echo payloads or plaintext DNS on TCP port 443")
+	option.BindEnv(option.EnableTunneledProtoDetection)
+
+	flags.StringSlice(option.FlowExportCollectors, nil, "IPFIX collectors (UDP host:port addresses) to export sampled flow records to")
+	option.BindEnv(option.FlowExportCollectors)
+
+	flags.Int(option.FlowExportSamplingRate, 1024, "Sample one out of every N datapath packets for flow export")
+	option.BindEnv(option.FlowExportSamplingRate)
+
+	flags.Bool(option.EnableK8sTerminatingEndpoint, true, "Keep terminating-but-serving endpoints as backends for established connections so that they drain instead of being reset")
+	option.BindEnv(option.EnableK8sTerminatingEndpoint)
+
+	flags.Bool(option.StandbyMode, false, "Run as a standby that pre-warms the datapath-independent bootstrap and only takes over datapath control once the primary agent on the node dies")
+	option.BindEnv(option.StandbyMode)
+
+	flags.Duration(option.StandbyProbeInterval, 200*time.Millisecond, "Interval between liveness probes of the primary agent in standby mode")
+	option.BindEnv(option.StandbyProbeInterval)
+
+	flags.Bool(option.ControlPlaneIPv6Only, false, "Restrict control-plane communication (kvstore, clustermesh, health, Hubble) to IPv6 and fail fast on IPv4 control-plane addresses")
+	option.BindEnv(option.ControlPlaneIPv6Only)
+
+	flags.Bool(option.EnableRelatedICMPErrors, true, "Accept ICMP errors related to an allowed connection (e.g. TTL exceeded, fragmentation needed) as RELATED by policy")
+	option.BindEnv(option.EnableRelatedICMPErrors)
+
+	flags.Bool(option.ToServicesNamespaceScoping, false, "Restrict toServices label selectors without an explicit namespace to services in the namespace of the policy")
+	option.BindEnv(option.ToServicesNamespaceScoping)
+
+	flags.Bool(option.EnableDatapathSelftest, false, "Inject crafted packets through the datapath at startup to verify its paths before workloads are scheduled")
+	option.BindEnv(option.EnableDatapathSelftest)
+
+	flags.Duration(option.FeatureReportInterval, 0, "Interval in which to publish the feature report as a CiliumNode annotation (0 to disable)")
+	option.BindEnv(option.FeatureReportInterval)
+
 	flags.Bool(option.EnableRecorder, false, "Enable BPF datapath pcap recorder")
 	option.BindEnv(option.EnableRecorder)
 
@@ -561,7 +689,7 @@ func init() {
 	flags.MarkHidden(option.NodePortMode)
 	option.BindEnv(option.NodePortMode)
 
-	flags.String(option.NodePortAlg, option.NodePortAlgRandom, "BPF load balancing algorithm (\"random\", \"maglev\")")
+	flags.String(option.NodePortAlg, option.NodePortAlgRandom, "BPF load balancing algorithm (\"random\", \"maglev\", \"latency\")")
 	flags.MarkHidden(option.NodePortAlg)
 	option.BindEnv(option.NodePortAlg)
 
@@ -574,7 +702,7 @@ func init() {
 	flags.String(option.LoadBalancerMode, option.NodePortModeSNAT, "BPF load balancing mode (\"snat\", \"dsr\", \"hybrid\")")
 	option.BindEnv(option.LoadBalancerMode)
 
-	flags.String(option.LoadBalancerAlg, option.NodePortAlgRandom, "BPF load balancing algorithm (\"random\", \"maglev\")")
+	flags.String(option.LoadBalancerAlg, option.NodePortAlgRandom, "BPF load balancing algorithm (\"random\", \"maglev\", \"latency\")")
 	option.BindEnv(option.LoadBalancerAlg)
 
 	flags.String(option.LoadBalancerDSRDispatch, option.DSRDispatchOption, "BPF load balancing DSR dispatch method (\"opt\", \"ipip\")")
@@ -617,6 +745,16 @@ func init() {
 	flags.Bool(option.EnableIdentityMark, true, "Enable setting identity mark for local traffic")
 	option.BindEnv(option.EnableIdentityMark)
 
+	flags.String(option.IdentityEncodingMode, option.IdentityEncodingModeDisabled,
+		"Encode the source security identity into packets egressing to external networks ('dscp': low 1-6 identity bits into the DSCP field, 'flowlabel': low 20 identity bits into the IPv6 flow label)")
+	option.BindEnv(option.IdentityEncodingMode)
+
+	flags.Int(option.IdentityEncodingDSCPBits, 6, "Number of identity bits encoded into the DSCP field in dscp identity encoding mode (1-6)")
+	option.BindEnv(option.IdentityEncodingDSCPBits)
+
+	flags.Bool(option.EnableFlowOverrides, false, "Enable the API to install temporary flow-level policy verdict overrides")
+	option.BindEnv(option.EnableFlowOverrides)
+
 	flags.Bool(option.EnableHostFirewall, false, "Enable host network policies")
 	option.BindEnv(option.EnableHostFirewall)
 
@@ -818,6 +956,12 @@ func init() {
 	flags.Int(option.ToFQDNsProxyPort, 0, "Global port on which the in-agent DNS proxy should listen. Default 0 is a OS-assigned port.")
 	option.BindEnv(option.ToFQDNsProxyPort)
 
+	flags.Int(option.ToFQDNsProxyInstances, 1, "Number of DNS proxy instances sharing the DNS proxy port")
+	option.BindEnv(option.ToFQDNsProxyInstances)
+
+	flags.Bool(option.ExternalDNSProxy, false, "Run the DNS proxy in a separate process supervised by the agent")
+	option.BindEnv(option.ExternalDNSProxy)
+
 	flags.StringVar(&option.Config.FQDNRejectResponse, option.FQDNRejectResponseCode, option.FQDNProxyDenyWithRefused, fmt.Sprintf("DNS response code for rejecting DNS requests, available options are '%v'", option.FQDNRejectOptions))
 	option.BindEnv(option.FQDNRejectResponseCode)
 
@@ -842,12 +986,27 @@ func init() {
 	flags.Bool(option.ToFQDNsEnableDNSCompression, defaults.ToFQDNsEnableDNSCompression, "Allow the DNS proxy to compress responses to endpoints that are larger than 512 Bytes or the EDNS0 option, if present")
 	option.BindEnv(option.ToFQDNsEnableDNSCompression)
 
+	flags.Int(option.ToFQDNsEndpointMaxQPS, 0, "Maximum number of DNS queries per second the DNS proxy forwards for a single endpoint (0 disables the limit)")
+	option.BindEnv(option.ToFQDNsEndpointMaxQPS)
+
+	flags.Int(option.ToFQDNsEndpointMaxConcurrent, 0, "Maximum number of concurrent DNS queries the DNS proxy forwards for a single endpoint (0 disables the limit)")
+	option.BindEnv(option.ToFQDNsEndpointMaxConcurrent)
+
+	flags.Bool(option.ToFQDNsEnableDatapathValidation, false, "Drop egress connections to IPs outside the cluster which were not returned by an allowed DNS answer")
+	option.BindEnv(option.ToFQDNsEnableDatapathValidation)
+
+	flags.Duration(option.ToFQDNsDatapathGracePeriod, defaults.ToFQDNsDatapathGracePeriod, "Time during which the datapath keeps accepting connections to an IP after the TTL of the DNS answer which returned it has expired")
+	option.BindEnv(option.ToFQDNsDatapathGracePeriod)
+
 	flags.Int(option.PolicyQueueSize, defaults.PolicyQueueSize, "size of queues for policy-related events")
 	option.BindEnv(option.PolicyQueueSize)
 
 	flags.Int(option.EndpointQueueSize, defaults.EndpointQueueSize, "size of EventQueue per-endpoint")
 	option.BindEnv(option.EndpointQueueSize)
 
+	flags.Int(option.EndpointPrewarmPoolSize, 0, "Number of pre-warmed endpoint entries with pre-allocated IPs kept ready for new pods (0 to disable)")
+	option.BindEnv(option.EndpointPrewarmPoolSize)
+
 	flags.Duration(option.EndpointGCInterval, 5*time.Minute, "Periodically monitor local endpoint health via link status on this interval and garbage collect them if they become unhealthy, set to 0 to disable")
 	flags.MarkHidden(option.EndpointGCInterval)
 	option.BindEnv(option.EndpointGCInterval)
@@ -890,6 +1049,9 @@ func init() {
 	flags.StringSlice(option.HubbleTLSClientCAFiles, []string{}, "Paths to one or more public key files of client CA certificates to use for TLS with mutual authentication (mTLS). The files must contain PEM encoded data. When provided, this option effectively enables mTLS.")
 	option.BindEnv(option.HubbleTLSClientCAFiles)
 
+	flags.String(option.HubbleAuthPolicyFile, "", "Path to a JSON file mapping Hubble client identities to the namespaces they are authorized to observe flows for. When set, unauthorized clients are rejected.")
+	option.BindEnv(option.HubbleAuthPolicyFile)
+
 	flags.Int(option.HubbleEventBufferCapacity, observeroption.Default.MaxFlows.AsInt(), "Capacity of Hubble events buffer. The provided value must be one less than an integer power of two and no larger than 65535 (ie: 1, 3, ..., 2047, 4095, ..., 65535)")
 	option.BindEnv(option.HubbleEventBufferCapacity)
 
@@ -1224,10 +1386,23 @@ func initEnv(cmd *cobra.Command) {
 	if !option.Config.EnableIPv4 && !option.Config.EnableIPv6 {
 		log.Fatal("Either IPv4 or IPv6 addressing must be enabled")
 	}
+	if option.Config.ControlPlaneIPv6Only {
+		if !option.Config.EnableIPv6 {
+			log.Fatalf("%s requires IPv6 addressing to be enabled (%s)",
+				option.ControlPlaneIPv6Only, option.EnableIPv6Name)
+		}
+		if err := validateIPv6OnlyControlPlane(); err != nil {
+			log.WithError(err).Fatal("Control plane is restricted to IPv6")
+		}
+	}
 	if err := labelsfilter.ParseLabelPrefixCfg(option.Config.Labels, option.Config.LabelPrefixFile); err != nil {
 		log.WithError(err).Fatal("Unable to parse Label prefix configuration")
 	}
 
+	if err := labelsfilter.ParseRedactedLabelKeys(option.Config.RedactLabelKeys); err != nil {
+		log.WithError(err).Fatal("Unable to parse label redaction configuration")
+	}
+
 	_, r, err := net.ParseCIDR(option.Config.NAT46Range)
 	if err != nil {
 		log.WithError(err).WithField(logfields.V6Prefix, option.Config.NAT46Range).Fatal("Invalid NAT46 prefix")
@@ -1332,6 +1507,15 @@ func initEnv(cmd *cobra.Command) {
 		log.Fatalf("%s cannot be used with tunneling. Packets must be routed through the tunnel device.", option.EnableAutoDirectRoutingName)
 	}
 
+	if option.Config.EnableMultiUplinkEgress {
+		if !option.Config.EnableAutoDirectRouting {
+			log.Fatalf("%s requires direct routing between nodes (%s)", option.EnableMultiUplinkEgressName, option.EnableAutoDirectRoutingName)
+		}
+		if len(option.Config.MultiUplinkDevices) < 2 {
+			log.Fatalf("%s requires at least two devices in %s", option.EnableMultiUplinkEgressName, option.MultiUplinkDevicesName)
+		}
+	}
+
 	initClockSourceOption()
 	initSockmapOption()
 
@@ -1463,6 +1647,8 @@ func initEnv(cmd *cobra.Command) {
 func (d *Daemon) initKVStore() {
 	goopts := &kvstore.ExtraOptions{
 		ClusterSizeDependantInterval: d.nodeDiscovery.Manager.ClusterSizeDependantInterval,
+		Namespace:                    option.Config.KVstoreNamespace,
+		MigrateNamespace:             option.Config.KVstoreNamespaceMigrate,
 	}
 
 	controller.NewManager().UpdateController("kvstore-locks-gc",
@@ -1513,6 +1699,22 @@ func runDaemon() {
 		HostDevice: option.Config.HostDevice,
 	}
 
+	// Bootstrap stages which do not touch the datapath or on-disk state
+	// run before a standby blocks, so that a takeover does not pay for
+	// them: configuration parsing and kernel feature probing have been
+	// done by initEnv, and the Kubernetes client is connected here.
+	if k8s.IsEnabled() {
+		bootstrapStats.k8sInit.Start()
+		if err := k8s.Init(option.Config); err != nil {
+			log.WithError(err).Fatal("Unable to initialize Kubernetes subsystem")
+		}
+		bootstrapStats.k8sInit.End(true)
+	}
+
+	if option.Config.StandbyMode {
+		waitForTakeover()
+	}
+
 	log.Info("Initializing daemon")
 
 	option.Config.RunMonitorAgent = true
@@ -1527,9 +1729,9 @@ func runDaemon() {
 	var wgAgent *wireguard.Agent
 	if option.Config.EnableWireguard {
 		switch {
-		case option.Config.EnableIPSec:
-			log.Fatalf("Wireguard (--%s) cannot be used with IPSec (--%s)",
-				option.EnableWireguard, option.EnableIPSecName)
+		case option.Config.EnableIPSec && !option.Config.EnableEncryptionCoexistence:
+			log.Fatalf("Wireguard (--%s) cannot be used with IPSec (--%s) unless --%s is set",
+				option.EnableWireguard, option.EnableIPSecName, option.EnableEncryptionCoexistence)
 		case option.Config.EnableL7Proxy:
 			log.Fatalf("Wireguard (--%s) is not compatible with L7 proxy (--%s)",
 				option.EnableWireguard, option.EnableL7Proxy)
@@ -1550,14 +1752,6 @@ func runDaemon() {
 		link.DeleteByName(wireguardTypes.IfaceName)
 	}
 
-	if k8s.IsEnabled() {
-		bootstrapStats.k8sInit.Start()
-		if err := k8s.Init(option.Config); err != nil {
-			log.WithError(err).Fatal("Unable to initialize Kubernetes subsystem")
-		}
-		bootstrapStats.k8sInit.End(true)
-	}
-
 	ctx, cancel := context.WithCancel(server.ServerCtx)
 	d, restoredEndpoints, err := NewDaemon(ctx, cancel,
 		WithDefaultEndpointManager(ctx, endpoint.CheckHealth),
@@ -1593,6 +1787,10 @@ func runDaemon() {
 	}
 	bootstrapStats.k8sInit.End(true)
 	restoreComplete := d.initRestore(restoredEndpoints)
+
+	if size := option.Config.EndpointPrewarmPoolSize; size > 0 {
+		d.prewarmPool = prewarm.NewPool(d.ipam, d.mtuConfig.GetDeviceMTU(), size)
+	}
 	if wgAgent != nil {
 		if err := wgAgent.RestoreFinished(); err != nil {
 			log.WithError(err).Error("Failed to set up wireguard peers")
@@ -1624,16 +1822,61 @@ func runDaemon() {
 			}
 			d.dnsNameManager.CompleteBootstrap()
 
+			// Do not pre-allocate IPs before the restoration of
+			// the existing endpoints has finished.
+			if d.prewarmPool != nil {
+				d.prewarmPool.Start()
+			}
+
 			ms := maps.NewMapSweeper(&EndpointMapManager{
 				EndpointManager: d.endpointManager,
 			})
 			ms.CollectStaleMapGarbage()
 			ms.RemoveDisabledMaps()
+
+			// Audit the CiliumEndpoint objects managed by this
+			// node only once all local endpoints have been
+			// restored, so that objects of restored endpoints are
+			// not mistaken for stale ones.
+			watchers.RunK8sCiliumEndpointAudit(d.endpointManager, option.Config)
 		}()
 		d.endpointManager.Subscribe(d)
 		defer d.endpointManager.Unsubscribe(d)
 	}
 
+	if option.Config.EnableIPv6 && option.Config.EnableIPv6NDPProxy {
+		if option.Config.DirectRoutingDevice == "" {
+			log.Fatalf("--%s requires --%s to be set", option.EnableIPv6NDPProxy, option.DirectRoutingDevice)
+		}
+		ndpProxy, err := ndpproxy.NewNDPProxy(option.Config.DirectRoutingDevice)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to initialize IPv6 NDP proxy")
+		}
+		d.endpointManager.Subscribe(ndpProxy)
+		defer d.endpointManager.Unsubscribe(ndpProxy)
+	}
+
+	if option.Config.EnableIPv6 && option.Config.EnableIPv6RouterAdvertisements {
+		dnsServers := make([]net.IP, 0, len(option.Config.IPv6RADNSServers))
+		for _, server := range option.Config.IPv6RADNSServers {
+			ip := net.ParseIP(server)
+			if ip == nil || ip.To4() != nil {
+				log.Fatalf("Invalid IPv6 address %q in --%s", server, option.IPv6RADNSServers)
+			}
+			dnsServers = append(dnsServers, ip)
+		}
+		routerAdv, err := routeradv.NewRouterAdvertiser(node.GetIPv6AllocRange().IPNet, d.mtuConfig.GetRouteMTU(), dnsServers)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to initialize IPv6 router advertisements")
+		}
+		d.endpointManager.Subscribe(routerAdv)
+		defer d.endpointManager.Unsubscribe(routerAdv)
+	}
+
+	if option.Config.EnableK8sAgentEvents && k8s.IsEnabled() {
+		registerK8sEventSink()
+	}
+
 	// Migrating the ENI datapath must happen before the API is served to
 	// prevent endpoints from being created. It also must be before the health
 	// initialization logic which creates the health endpoint, for the same
@@ -1667,6 +1910,8 @@ func runDaemon() {
 
 	d.startStatusCollector()
 
+	d.launchDatapathSelftest()
+
 	metricsErrs := initMetrics()
 
 	d.startAgentHealthHTTPService()
@@ -1732,7 +1977,30 @@ func runDaemon() {
 
 	bootstrapStats.overall.End(true)
 	bootstrapStats.updateMetrics()
-	go d.launchHubble()
+
+	// Register Hubble as a runtime feature so it can be toggled through the
+	// configuration API without restarting the agent.
+	features.SetContext(d.ctx)
+	if err := features.Register(&features.Feature{
+		Name: "hubble",
+		Start: func(ctx context.Context) error {
+			option.Config.EnableHubble = true
+			go d.launchHubble(ctx)
+			return nil
+		},
+		Stop: func() {
+			option.Config.EnableHubble = false
+		},
+	}); err != nil {
+		log.WithError(err).Error("Unable to register hubble feature")
+	}
+	if option.Config.EnableHubble {
+		if err := features.Enable("hubble"); err != nil {
+			log.WithError(err).Error("Unable to launch hubble")
+		}
+	} else {
+		log.Info("Hubble server is disabled")
+	}
 
 	err = option.Config.StoreInFile(option.Config.StateDir)
 	if err != nil {
@@ -1777,9 +2045,16 @@ func (d *Daemon) instantiateAPI() *restapi.CiliumAPIAPI {
 	restAPI.DaemonGetConfigHandler = NewGetConfigHandler(d)
 	restAPI.DaemonPatchConfigHandler = NewPatchConfigHandler(d)
 
+	// /controller/{name}/trigger
+	restAPI.DaemonPutControllerNameTriggerHandler = NewPutControllerNameTriggerHandler(d)
+
+	// /proxy/listeners
+	restAPI.DaemonGetProxyListenersHandler = NewGetProxyListenersHandler(d)
+
 	if option.Config.DatapathMode != datapathOption.DatapathModeLBOnly {
 		// /endpoint/
 		restAPI.EndpointGetEndpointHandler = NewGetEndpointHandler(d)
+		restAPI.EndpointGetEndpointGroupsHandler = NewGetEndpointGroupsHandler(d)
 
 		// /endpoint/{id}
 		restAPI.EndpointGetEndpointIDHandler = NewGetEndpointIDHandler(d)
@@ -1814,6 +2089,9 @@ func (d *Daemon) instantiateAPI() *restapi.CiliumAPIAPI {
 		restAPI.PolicyDeletePolicyHandler = newDeletePolicyHandler(d)
 		restAPI.PolicyGetPolicySelectorsHandler = newGetPolicyCacheHandler(d)
 
+		// /policy/flow-override
+		restAPI.PolicyPutPolicyFlowOverrideHandler = newPutPolicyFlowOverrideHandler(d)
+
 		// /policy/resolve/
 		restAPI.PolicyGetPolicyResolveHandler = NewGetPolicyResolveHandler(d)
 
@@ -1855,6 +2133,9 @@ func (d *Daemon) instantiateAPI() *restapi.CiliumAPIAPI {
 	// /debuginfo
 	restAPI.DaemonGetDebuginfoHandler = NewGetDebugInfoHandler(d)
 
+	// /profile
+	restAPI.DaemonGetProfileHandler = NewGetProfileHandler(d)
+
 	// /map
 	restAPI.DaemonGetMapHandler = NewGetMapHandler(d)
 	restAPI.DaemonGetMapNameHandler = NewGetMapNameHandler(d)
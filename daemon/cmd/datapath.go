@@ -4,12 +4,14 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/cilium/cilium/pkg/bpf"
 	"github.com/cilium/cilium/pkg/common"
 	"github.com/cilium/cilium/pkg/controller"
 	"github.com/cilium/cilium/pkg/datapath"
@@ -17,12 +19,16 @@ import (
 	"github.com/cilium/cilium/pkg/datapath/linux/ipsec"
 	"github.com/cilium/cilium/pkg/datapath/linux/probes"
 	"github.com/cilium/cilium/pkg/endpointmanager"
+	"github.com/cilium/cilium/pkg/eventjournal"
 	"github.com/cilium/cilium/pkg/identity"
 	"github.com/cilium/cilium/pkg/ipcache"
 	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/maps/clustermetricsmap"
 	"github.com/cilium/cilium/pkg/maps/ctmap"
 	"github.com/cilium/cilium/pkg/maps/egressmap"
 	"github.com/cilium/cilium/pkg/maps/eventsmap"
+	"github.com/cilium/cilium/pkg/maps/faultinject"
+	"github.com/cilium/cilium/pkg/maps/fqdnmap"
 	"github.com/cilium/cilium/pkg/maps/fragmap"
 	ipcachemap "github.com/cilium/cilium/pkg/maps/ipcache"
 	"github.com/cilium/cilium/pkg/maps/ipmasq"
@@ -32,7 +38,9 @@ import (
 	"github.com/cilium/cilium/pkg/maps/nat"
 	"github.com/cilium/cilium/pkg/maps/neighborsmap"
 	"github.com/cilium/cilium/pkg/maps/policymap"
+	"github.com/cilium/cilium/pkg/maps/priometricsmap"
 	"github.com/cilium/cilium/pkg/maps/signalmap"
+	"github.com/cilium/cilium/pkg/maps/synflood"
 	"github.com/cilium/cilium/pkg/maps/tunnel"
 	"github.com/cilium/cilium/pkg/node"
 	"github.com/cilium/cilium/pkg/option"
@@ -266,6 +274,33 @@ func (d *Daemon) syncEndpointsAndHostIPs() error {
 	return nil
 }
 
+// migrationCandidates returns the global maps which are inspected for the
+// BPF map migration plan on startup.
+func (d *Daemon) migrationCandidates() []*bpf.Map {
+	maps := []*bpf.Map{
+		lxcmap.LXCMap,
+		&ipcachemap.IPCache.Map,
+		tunnel.TunnelMap.Map,
+		egressmap.EgressMap,
+	}
+
+	for _, m := range ctmap.GlobalMaps(option.Config.EnableIPv4,
+		option.Config.EnableIPv6) {
+		maps = append(maps, &m.Map)
+	}
+
+	ipv4Nat, ipv6Nat := nat.GlobalMaps(option.Config.EnableIPv4,
+		option.Config.EnableIPv6, option.Config.EnableNodePort)
+	if ipv4Nat != nil {
+		maps = append(maps, &ipv4Nat.Map)
+	}
+	if ipv6Nat != nil {
+		maps = append(maps, &ipv6Nat.Map)
+	}
+
+	return maps
+}
+
 // initMaps opens all BPF maps (and creates them if they do not exist). This
 // must be done *before* any operations which read BPF maps, especially
 // restoring endpoints and services.
@@ -274,6 +309,11 @@ func (d *Daemon) initMaps() error {
 		return nil
 	}
 
+	// Compute the migration plan for the maps pinned by a previous agent
+	// version before any map is opened, as opening a map resolves property
+	// mismatches by removing the pinned map.
+	d.bpfMapMigration = bpf.GetMigrationPlan(d.migrationCandidates())
+
 	if _, err := lxcmap.LXCMap.OpenOrCreate(); err != nil {
 		return err
 	}
@@ -296,6 +336,12 @@ func (d *Daemon) initMaps() error {
 		return err
 	}
 
+	if option.Config.ClusterID != 0 {
+		if err := clustermetricsmap.ClusterMetrics.OpenOrCreate(); err != nil {
+			return err
+		}
+	}
+
 	if _, err := tunnel.TunnelMap.OpenOrCreate(); err != nil {
 		return err
 	}
@@ -388,6 +434,25 @@ func (d *Daemon) initMaps() error {
 		}
 	}
 
+	if option.Config.EnableFaultInjection {
+		if _, err := faultinject.Map.OpenOrCreate(); err != nil {
+			return err
+		}
+	}
+
+	if option.Config.EnableSynFloodProtection {
+		if _, err := synflood.Map.OpenOrCreate(); err != nil {
+			return err
+		}
+	}
+
+	if option.Config.ToFQDNsEnableDatapathValidation {
+		if err := fqdnmap.InitMaps(option.Config.EnableIPv4,
+			option.Config.EnableIPv6); err != nil {
+			return err
+		}
+	}
+
 	// Start the controller for periodic sync of the metrics map with
 	// the prometheus server.
 	controller.NewManager().UpdateController("metricsmap-bpf-prom-sync",
@@ -397,6 +462,28 @@ func (d *Daemon) initMaps() error {
 			Context:     d.ctx,
 		})
 
+	// The cluster metrics map only exists when the datapath attributes
+	// traffic to remote clusters, see ENABLE_CLUSTER_METRICS.
+	if option.Config.ClusterID != 0 {
+		controller.NewManager().UpdateController("clustermetricsmap-bpf-prom-sync",
+			controller.ControllerParams{
+				DoFunc:      clustermetricsmap.SyncClusterMetricsMap,
+				RunInterval: 5 * time.Second,
+				Context:     d.ctx,
+			})
+	}
+
+	// The priority metrics map only exists when the bandwidth manager
+	// schedules packets into bands, see ENABLE_NETWORK_PRIORITY.
+	if option.Config.EnableBandwidthManager && option.Config.EnableNetworkPriority {
+		controller.NewManager().UpdateController("priometricsmap-bpf-prom-sync",
+			controller.ControllerParams{
+				DoFunc:      priometricsmap.SyncPriorityMetricsMap,
+				RunInterval: 5 * time.Second,
+				Context:     d.ctx,
+			})
+	}
+
 	if !option.Config.RestoreState {
 		// If we are not restoring state, all endpoints can be
 		// deleted. Entries will be re-populated.
@@ -432,7 +519,8 @@ func (d *Daemon) initMaps() error {
 		}
 	}
 
-	if option.Config.NodePortAlg == option.NodePortAlgMaglev {
+	if option.Config.NodePortAlg == option.NodePortAlgMaglev ||
+		option.Config.NodePortAlg == option.NodePortAlgLatency {
 		if err := lbmap.InitMaglevMaps(option.Config.EnableIPv4, option.Config.EnableIPv6, uint32(option.Config.MaglevTableSize)); err != nil {
 			return err
 		}
@@ -454,6 +542,8 @@ func setupIPSec() (int, uint8, error) {
 	if err != nil {
 		return 0, 0, err
 	}
+	eventjournal.Record(eventjournal.ReasonEncryptionRekey,
+		"Loaded IPsec keys with SPI %d", spi)
 	node.SetIPsecKeyIdentity(spi)
 	return authKeySize, spi, nil
 }
@@ -462,3 +552,18 @@ func setupIPSec() (int, uint8, error) {
 func (d *Daemon) Datapath() datapath.Datapath {
 	return d.datapath
 }
+
+// datapathObjectsStatus returns the metadata of all pinned BPF maps and
+// programs as JSON, so that external backup and forensics tooling can
+// snapshot the datapath state via the debuginfo API.
+func datapathObjectsStatus() string {
+	objects, err := bpf.PinnedObjectsMetadata()
+	if err != nil {
+		return fmt.Sprintf("error: %s", err)
+	}
+	out, err := json.MarshalIndent(objects, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("error: %s", err)
+	}
+	return string(out)
+}
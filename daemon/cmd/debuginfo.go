@@ -10,7 +10,9 @@ import (
 	"github.com/cilium/cilium/api/v1/models"
 	restapi "github.com/cilium/cilium/api/v1/server/restapi/daemon"
 	"github.com/cilium/cilium/api/v1/server/restapi/endpoint"
+	"github.com/cilium/cilium/pkg/cgroups"
 	"github.com/cilium/cilium/pkg/debug"
+	"github.com/cilium/cilium/pkg/eventjournal"
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/version"
 
@@ -57,6 +59,14 @@ func (h *getDebugInfo) Handle(params restapi.GetDebuginfoParams) middleware.Resp
 
 	dr.ServiceList = getServiceList(d.svc)
 
+	dr.AgentEvents = eventjournal.GetModel()
+
+	if option.Config.EnableHostReachableServices {
+		if attachments, err := cgroups.ScanSockLBAttachments(); err == nil {
+			dr.SocketLbAttachments = attachments
+		}
+	}
+
 	dr.Encryption = &models.DebugInfoEncryption{}
 	if option.Config.EnableWireguard {
 		if wgStatus, err := d.datapath.WireguardAgent().Status(true); err == nil {
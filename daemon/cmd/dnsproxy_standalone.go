@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"github.com/cilium/cilium/pkg/defaults"
+	"github.com/cilium/cilium/pkg/fqdn/dnsproxy/remote"
+
+	"github.com/spf13/cobra"
+)
+
+// dnsProxyCmd runs the standalone DNS proxy. It is hidden because it is not
+// meant to be invoked by users: the agent spawns and supervises the process
+// itself when --external-dns-proxy is set.
+var dnsProxyCmd = &cobra.Command{
+	Use:    "dns-proxy",
+	Short:  "Run the standalone DNS proxy (launched by cilium-agent)",
+	Hidden: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		cfg := remote.Config{}
+		cfg.RunDir, _ = flags.GetString("run-dir")
+		bindPort, _ := flags.GetUint16("bind-port")
+		cfg.BindPort = bindPort
+		cfg.Instances, _ = flags.GetInt("instances")
+		cfg.EnableDNSCompression, _ = flags.GetBool("enable-dns-compression")
+		cfg.MaxRestoreDNSIPs, _ = flags.GetInt("max-restore-dns-ips")
+
+		// Run only returns on failure or when the supervising agent is
+		// gone; the process must not linger in either case.
+		log.WithError(remote.Run(cfg)).Fatal("Standalone DNS proxy exiting")
+	},
+}
+
+func init() {
+	flags := dnsProxyCmd.Flags()
+	flags.String("run-dir", defaults.RuntimePath, "Agent runtime directory holding the DNS proxy sockets")
+	flags.Uint16("bind-port", 0, "Port to bind the DNS listeners to, 0 to let the kernel pick one")
+	flags.Int("instances", 1, "Number of DNS proxy instances sharing the port")
+	flags.Bool("enable-dns-compression", true, "Allow the DNS proxy to compress responses")
+	flags.Int("max-restore-dns-ips", defaults.DNSMaxIPsPerRestoredRule, "Maximum number of IPs to maintain for each restored DNS rule")
+	RootCmd.AddCommand(dnsProxyCmd)
+}
@@ -10,6 +10,8 @@ import (
 	"net"
 	"net/http"
 	"runtime"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/cilium/cilium/pkg/logging/logfields"
 	monitorAPI "github.com/cilium/cilium/pkg/monitor/api"
 	"github.com/cilium/cilium/pkg/option"
+	"github.com/cilium/cilium/pkg/podstartup"
 	"github.com/cilium/cilium/pkg/proxy"
 
 	"github.com/go-openapi/runtime/middleware"
@@ -127,6 +130,82 @@ func (d *Daemon) getEndpointList(params GetEndpointParams) []*models.Endpoint {
 	return resEPs
 }
 
+type getEndpointGroups struct {
+	d *Daemon
+}
+
+func NewGetEndpointGroupsHandler(d *Daemon) GetEndpointGroupsHandler {
+	return &getEndpointGroups{d: d}
+}
+
+func (h *getEndpointGroups) Handle(params GetEndpointGroupsParams) middleware.Responder {
+	log.WithField(logfields.Params, logfields.Repr(params)).Debug("GET /endpoint/groups request")
+
+	r, err := h.d.apiLimiterSet.Wait(params.HTTPRequest.Context(), apiRequestEndpointList)
+	if err != nil {
+		return api.Error(http.StatusTooManyRequests, err)
+	}
+	defer r.Done()
+
+	return NewGetEndpointGroupsOK().WithPayload(h.d.getEndpointGroups(params))
+}
+
+// getEndpointGroups aggregates the local endpoints matching the label filter
+// of the given parameters into groups sharing the same values for the
+// requested label keys. Endpoints missing one of the keys are aggregated into
+// a group without the corresponding label. Grouping is done on the agent so
+// that clients interested in aggregate counts do not need to retrieve the
+// full endpoint models of large nodes.
+func (d *Daemon) getEndpointGroups(params GetEndpointGroupsParams) []*models.EndpointGroup {
+	var convertedLabels labels.Labels
+	if params.Labels != nil {
+		convertedLabels = labels.NewLabelsFromModel(params.Labels)
+	}
+
+	selectors := make([]labels.Label, 0, len(params.GroupBy))
+	for _, key := range params.GroupBy {
+		selectors = append(selectors, labels.ParseSelectLabel(key))
+	}
+
+	groups := map[string]*models.EndpointGroup{}
+	for _, ep := range d.endpointManager.GetEndpoints() {
+		if !ep.HasLabels(convertedLabels) {
+			continue
+		}
+
+		epLabels := labels.NewLabelsFromModel(ep.GetOpLabels())
+		groupLabels := make([]string, 0, len(selectors))
+		for _, sel := range selectors {
+			for _, lbl := range epLabels {
+				if lbl.Key == sel.Key && (sel.IsAnySource() || lbl.Source == sel.Source) {
+					groupLabels = append(groupLabels, lbl.String())
+					break
+				}
+			}
+		}
+		sort.Strings(groupLabels)
+
+		key := strings.Join(groupLabels, ",")
+		group, ok := groups[key]
+		if !ok {
+			group = &models.EndpointGroup{Labels: groupLabels}
+			groups[key] = group
+		}
+		group.Count++
+		group.EndpointIds = append(group.EndpointIds, int64(ep.GetID()))
+	}
+
+	resGroups := make([]*models.EndpointGroup, 0, len(groups))
+	for _, group := range groups {
+		sort.Slice(group.EndpointIds, func(i, j int) bool { return group.EndpointIds[i] < group.EndpointIds[j] })
+		resGroups = append(resGroups, group)
+	}
+	sort.Slice(resGroups, func(i, j int) bool {
+		return strings.Join(resGroups[i].Labels, ",") < strings.Join(resGroups[j].Labels, ",")
+	})
+	return resGroups
+}
+
 type getEndpointID struct {
 	d *Daemon
 }
@@ -295,6 +374,8 @@ func (m *endpointCreationManager) DebugStatus() (output string) {
 // createEndpoint attempts to create the endpoint corresponding to the change
 // request that was specified.
 func (d *Daemon) createEndpoint(ctx context.Context, owner regeneration.Owner, epTemplate *models.EndpointChangeRequest) (*endpoint.Endpoint, int, error) {
+	creationStart := time.Now()
+
 	if option.Config.EnableEndpointRoutes {
 		if epTemplate.DatapathConfiguration == nil {
 			epTemplate.DatapathConfiguration = &models.EndpointDatapathConfiguration{}
@@ -464,6 +545,12 @@ func (d *Daemon) createEndpoint(ctx context.Context, owner regeneration.Owner, e
 				return "", err
 			}
 			return p.Annotations[bandwidth.EgressBandwidth], nil
+		}, func(ns, podName string) (priority string, err error) {
+			p, err := d.k8sWatcher.GetCachedPod(ns, podName)
+			if err != nil {
+				return "", err
+			}
+			return p.Annotations[bandwidth.Priority], nil
 		})
 		ep.UpdateNoTrackRules(func(ns, podName string) (noTrackPort string, err error) {
 			p, err := d.k8sWatcher.GetCachedPod(ns, podName)
@@ -472,9 +559,18 @@ func (d *Daemon) createEndpoint(ctx context.Context, owner regeneration.Owner, e
 			}
 			return p.Annotations[annotation.NoTrack], nil
 		})
+		ep.UpdateMSSClampRules(func(ns, podName string) (mssClamp string, err error) {
+			p, err := d.k8sWatcher.GetCachedPod(ns, podName)
+			if err != nil {
+				return "", err
+			}
+			return p.Annotations[annotation.TCPMSSClamp], nil
+		})
 	}
 
+	identityResolutionStart := time.Now()
 	regenTriggered := ep.UpdateLabels(ctx, addLabels, infoLabels, true)
+	podstartup.RecordStage(ep.K8sNamespace, ep.K8sPodName, podstartup.StageIdentityResolution, time.Since(identityResolutionStart))
 
 	select {
 	case <-ctx.Done():
@@ -522,6 +618,8 @@ func (d *Daemon) createEndpoint(ctx context.Context, owner regeneration.Owner, e
 		}
 	}
 
+	podstartup.RecordStage(ep.K8sNamespace, ep.K8sPodName, podstartup.StageEndpointCreation, time.Since(creationStart))
+
 	return ep, 0, nil
 }
 
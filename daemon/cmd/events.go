@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"github.com/cilium/cilium/pkg/eventjournal"
+	"github.com/cilium/cilium/pkg/k8s"
+	cilium_v2 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2"
+	nodeTypes "github.com/cilium/cilium/pkg/node/types"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// registerK8sEventSink forwards agent events recorded in the event journal as
+// Kubernetes events on the CiliumNode resource of the local node.
+func registerK8sEventSink() {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedv1.EventSinkImpl{Interface: k8s.Client().CoreV1().Events("")})
+	recorder := broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "cilium-agent", Host: nodeTypes.GetName()})
+
+	nodeRef := &v1.ObjectReference{
+		Kind:       "CiliumNode",
+		APIVersion: cilium_v2.SchemeGroupVersion.String(),
+		Name:       nodeTypes.GetName(),
+	}
+
+	eventjournal.RegisterSink(func(ev eventjournal.Event) {
+		eventType := v1.EventTypeWarning
+		switch ev.Reason {
+		case eventjournal.ReasonEncryptionRekey, eventjournal.ReasonEndpointRecovery:
+			eventType = v1.EventTypeNormal
+		}
+		ref := nodeRef
+		if ev.PodName != "" {
+			ref = &v1.ObjectReference{
+				Kind:       "Pod",
+				APIVersion: "v1",
+				Namespace:  ev.PodNamespace,
+				Name:       ev.PodName,
+			}
+		}
+		recorder.Event(ref, eventType, string(ev.Reason), ev.Message)
+	})
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/cilium/cilium/pkg/annotation"
+	"github.com/cilium/cilium/pkg/k8s"
+	"github.com/cilium/cilium/pkg/metrics"
+	featureReport "github.com/cilium/cilium/pkg/metrics/features"
+	nodeTypes "github.com/cilium/cilium/pkg/node/types"
+	"github.com/cilium/cilium/pkg/option"
+	"github.com/cilium/cilium/pkg/proxy"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// staticFeatures returns the enablement state of the datapath security and
+// configuration features relevant for telemetry, derived from the finalized
+// agent configuration.
+func staticFeatures() map[string]bool {
+	features := map[string]bool{
+		"encryption-ipsec":     option.Config.EnableIPSec,
+		"encryption-wireguard": option.Config.EnableWireguard,
+		"encryption-none":      !option.Config.EnableIPSec && !option.Config.EnableWireguard,
+		"bpf-masquerade":       option.Config.EnableBPFMasquerade,
+		"host-firewall":        option.Config.EnableHostFirewall,
+		"bandwidth-manager":    option.Config.EnableBandwidthManager,
+	}
+
+	for _, mode := range []string{
+		option.KubeProxyReplacementDisabled,
+		option.KubeProxyReplacementProbe,
+		option.KubeProxyReplacementPartial,
+		option.KubeProxyReplacementStrict,
+	} {
+		features["kube-proxy-replacement-"+mode] = option.Config.KubeProxyReplacement == mode
+	}
+
+	return features
+}
+
+// updateFeatureMetrics publishes the enablement state of the datapath
+// security and configuration features relevant for telemetry, so dashboards
+// can correlate crashes with feature combinations. Must be called after the
+// configuration has been finalized, i.e. after the kube-proxy replacement and
+// BPF masquerade fallbacks have been applied.
+func updateFeatureMetrics() {
+	for name, enabled := range staticFeatures() {
+		value := float64(0)
+		if enabled {
+			value = 1
+		}
+		metrics.FeatureEnabled.WithLabelValues(name).Set(value)
+	}
+}
+
+// enabledL7Parsers returns the L7 parser types currently backed by a
+// configured proxy port, sorted for stable report output.
+func enabledL7Parsers() []string {
+	seen := map[string]struct{}{}
+	for _, pp := range proxy.GetProxyPortStates() {
+		if pp.Configured && pp.ParserType != "" {
+			seen[pp.ParserType] = struct{}{}
+		}
+	}
+
+	parsers := make([]string, 0, len(seen))
+	for parser := range seen {
+		parsers = append(parsers, parser)
+	}
+	sort.Strings(parsers)
+	return parsers
+}
+
+// publishFeatureReport serializes the feature report into an annotation on
+// the local CiliumNode resource so cluster-wide tooling can aggregate feature
+// usage without scraping the metrics endpoint of every agent.
+func publishFeatureReport(ctx context.Context, report *featureReport.Report) error {
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	annotations := map[string]string{
+		annotation.FeatureReport: string(raw),
+	}
+	rawAnnotations, err := json.Marshal(annotations)
+	if err != nil {
+		return err
+	}
+	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":%s}}`, rawAnnotations))
+
+	_, err = k8s.CiliumClient().CiliumV2().CiliumNodes().Patch(ctx,
+		report.NodeName, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// launchFeatureReporter starts the periodic publication of the feature
+// report if enabled. Must be called after the configuration has been
+// finalized, see updateFeatureMetrics.
+func (d *Daemon) launchFeatureReporter() {
+	if option.Config.FeatureReportInterval == 0 {
+		return
+	}
+
+	if !k8s.IsEnabled() {
+		log.Warningf("Feature report requires Kubernetes, ignoring --%s", option.FeatureReportInterval)
+		return
+	}
+
+	log.WithField("interval", option.Config.FeatureReportInterval).
+		Info("Starting periodic feature report")
+
+	reporter := featureReport.NewReporter(option.Config.FeatureReportInterval,
+		featureReport.Sources{
+			NodeName:  nodeTypes.GetName(),
+			L7Parsers: enabledL7Parsers,
+			Static:    staticFeatures,
+		}, publishFeatureReport)
+	reporter.Run(d.ctx)
+}
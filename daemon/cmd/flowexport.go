@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"github.com/cilium/cilium/pkg/ipfix"
+	"github.com/cilium/cilium/pkg/option"
+
+	"github.com/sirupsen/logrus"
+)
+
+// launchFlowExporter registers the IPFIX flow exporter as a monitor
+// consumer if collectors are configured. It must be called after the
+// monitor agent has been initialized.
+func (d *Daemon) launchFlowExporter() {
+	if len(option.Config.FlowExportCollectors) == 0 {
+		return
+	}
+	if d.monitorAgent == nil {
+		log.Warn("Flow export requires the monitor agent, IPFIX exporter not started")
+		return
+	}
+
+	exporter, err := ipfix.NewExporter(option.Config.FlowExportSamplingRate,
+		option.Config.FlowExportCollectors)
+	if err != nil {
+		log.WithError(err).Error("Unable to start IPFIX flow exporter")
+		return
+	}
+	d.monitorAgent.RegisterNewConsumer(exporter)
+	log.WithFields(logrus.Fields{
+		"collectors":   option.Config.FlowExportCollectors,
+		"samplingRate": option.Config.FlowExportSamplingRate,
+	}).Info("Started IPFIX flow exporter")
+}
@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
@@ -17,17 +18,22 @@ import (
 
 	"github.com/cilium/cilium/api/v1/models"
 	. "github.com/cilium/cilium/api/v1/server/restapi/policy"
+	"github.com/cilium/cilium/pkg/annotation"
 	"github.com/cilium/cilium/pkg/api"
 	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/debug"
 	"github.com/cilium/cilium/pkg/endpoint"
 	"github.com/cilium/cilium/pkg/fqdn"
 	"github.com/cilium/cilium/pkg/fqdn/dnsproxy"
+	"github.com/cilium/cilium/pkg/fqdn/dnsproxy/remote"
 	"github.com/cilium/cilium/pkg/fqdn/matchpattern"
 	"github.com/cilium/cilium/pkg/identity"
 	secIDCache "github.com/cilium/cilium/pkg/identity/cache"
 	"github.com/cilium/cilium/pkg/ipcache"
 	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/maps/fqdnmap"
 	"github.com/cilium/cilium/pkg/metrics"
+	monitorAPI "github.com/cilium/cilium/pkg/monitor/api"
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/policy"
 	policyApi "github.com/cilium/cilium/pkg/policy/api"
@@ -135,6 +141,7 @@ func (d *Daemon) bootstrapFQDN(possibleEndpoints map[uint16]*endpoint.Endpoint,
 	rg := fqdn.NewNameManager(cfg)
 	d.policy.GetSelectorCache().SetLocalIdentityNotifier(rg)
 	d.dnsNameManager = rg
+	debug.RegisterStatusObject("fqdn-selectors", rg)
 
 	// Controller to cleanup TTL expired entries from the DNS policies.
 	// dns-garbage-collector-job runs the logic to remove stale or undesired
@@ -220,6 +227,9 @@ func (d *Daemon) bootstrapFQDN(possibleEndpoints map[uint16]*endpoint.Endpoint,
 			cfg.Cache.ReplaceFromCacheByNames(namesToClean, caches...)
 
 			metrics.FQDNGarbageCollectorCleanedTotal.Add(float64(len(namesToClean)))
+			for _, name := range namesToClean {
+				d.SendNotification(monitorAPI.FQDNCacheExpiredMessage(name))
+			}
 			_, err := d.dnsNameManager.ForceGenerateDNS(context.TODO(), namesToClean)
 			namesCount := len(namesToClean)
 			// Limit the amount of info level logging to some sane amount
@@ -296,16 +306,43 @@ func (d *Daemon) bootstrapFQDN(possibleEndpoints map[uint16]*endpoint.Endpoint,
 	if err != nil {
 		return err
 	}
-	proxy.DefaultDNSProxy, err = dnsproxy.StartDNSProxy("", port, option.Config.ToFQDNsEnableDNSCompression,
-		option.Config.DNSMaxIPsPerRestoredRule, d.lookupEPByIP, d.LookupSecIDByIP, d.lookupIPsBySecID,
-		d.notifyOnDNSMsg)
+	if option.Config.ExternalDNSProxy {
+		// Run the DNS proxy in a separate supervised process so that a
+		// crash or OOM kill of the proxy does not take down the agent.
+		var client *remote.Client
+		client, err = remote.StartClient(remote.Config{
+			RunDir:               option.Config.RunDir,
+			BindPort:             port,
+			Instances:            option.Config.ToFQDNsProxyInstances,
+			EnableDNSCompression: option.Config.ToFQDNsEnableDNSCompression,
+			MaxRestoreDNSIPs:     option.Config.DNSMaxIPsPerRestoredRule,
+		}, remote.Callbacks{
+			LookupEPByIP:     d.lookupEPByIP,
+			LookupSecIDByIP:  d.LookupSecIDByIP,
+			LookupIPsBySecID: d.lookupIPsBySecID,
+			NotifyOnDNSMsg:   d.notifyOnDNSMsg,
+			LookupEndpointByID: func(id uint16) *endpoint.Endpoint {
+				return d.endpointManager.LookupCiliumID(id)
+			},
+		})
+		if err == nil {
+			cleaner.cleanupFuncs.Add(client.Stop)
+			proxy.DefaultDNSProxy = client
+		}
+	} else {
+		proxy.DefaultDNSProxy, err = dnsproxy.StartDNSProxies("", port, option.Config.ToFQDNsProxyInstances,
+			option.Config.ToFQDNsEnableDNSCompression, option.Config.DNSMaxIPsPerRestoredRule,
+			d.lookupEPByIP, d.LookupSecIDByIP, d.lookupIPsBySecID, d.notifyOnDNSMsg)
+	}
 	if err == nil {
 		// Increase the ProxyPort reference count so that it will never get released.
-		err = d.l7Proxy.SetProxyPort(listenerName, proxy.DefaultDNSProxy.BindPort)
-		if err == nil && port == proxy.DefaultDNSProxy.BindPort {
+		err = d.l7Proxy.SetProxyPort(listenerName, proxy.DefaultDNSProxy.GetBindPort())
+		if err == nil && port == proxy.DefaultDNSProxy.GetBindPort() {
 			log.Infof("Reusing previous DNS proxy port: %d", port)
 		}
 		proxy.DefaultDNSProxy.SetRejectReply(option.Config.FQDNRejectResponse)
+		proxy.DefaultDNSProxy.SetRateLimitOverride(d.lookupDNSRateLimitOverride)
+		debug.RegisterStatusFunc("dns-rule-stats", dnsRuleStatsStatus)
 		// Restore old rules
 		for _, possibleEP := range possibleEndpoints {
 			// Upgrades from old ciliums have this nil
@@ -317,6 +354,46 @@ func (d *Daemon) bootstrapFQDN(possibleEndpoints map[uint16]*endpoint.Endpoint,
 	return err // filled by StartDNSProxy
 }
 
+// lookupDNSRateLimitOverride resolves the DNS proxy rate limit override of
+// the pod backing the given endpoint from its DNSRateLimit annotation. ok is
+// false when the pod has no valid annotation and the configured default
+// limits apply.
+func (d *Daemon) lookupDNSRateLimitOverride(ep *endpoint.Endpoint) (qps, maxConcurrent int, ok bool) {
+	podNamespace, podName := ep.GetK8sNamespace(), ep.GetK8sPodName()
+	if podNamespace == "" || podName == "" {
+		return 0, 0, false
+	}
+	pod, err := d.k8sWatcher.GetCachedPod(podNamespace, podName)
+	if err != nil {
+		return 0, 0, false
+	}
+	value, exists := pod.ObjectMeta.Annotations[annotation.DNSRateLimit]
+	if !exists {
+		return 0, 0, false
+	}
+	qps, maxConcurrent, err = dnsproxy.ParseDNSRateLimitAnnotation(value)
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			logfields.K8sNamespace: podNamespace,
+			logfields.K8sPodName:   podName,
+		}).Warning("Ignoring invalid DNS rate limit annotation")
+		return 0, 0, false
+	}
+	return qps, maxConcurrent, true
+}
+
+// dnsRuleStatsStatus returns the per-rule enforcement counters of the DNS
+// proxy as JSON, so that users can verify via the debuginfo API that a DNS
+// allowlist is actually being exercised.
+func dnsRuleStatsStatus() string {
+	stats := proxy.DefaultDNSProxy.GetRuleStats()
+	out, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("error: %s", err)
+	}
+	return string(out)
+}
+
 // updateDNSDatapathRules updates the DNS proxy iptables rules. Must be
 // called after iptables has been initailized, and only after
 // successful bootstrapFQDN().
@@ -504,6 +581,10 @@ func (d *Daemon) notifyOnDNSMsg(lookupTime time.Time, ep *endpoint.Endpoint, epI
 	)
 	record.Log()
 
+	if !msg.Response {
+		d.SendNotification(monitorAPI.FQDNPolicyVerdictMessage(ep.GetID(), qname, string(verdict)))
+	}
+
 	if msg.Response && msg.Rcode == dns.RcodeSuccess && len(responseIPs) > 0 {
 		// This must happen before the NameManager update below, to ensure that
 		// this data is included in the serialized Endpoint object.
@@ -518,11 +599,26 @@ func (d *Daemon) notifyOnDNSMsg(lookupTime time.Time, ep *endpoint.Endpoint, epI
 			ep.SyncEndpointHeaderFile()
 		}
 
+		if option.Config.ToFQDNsEnableDatapathValidation {
+			lifetime := time.Duration(TTL)*time.Second + option.Config.ToFQDNsDatapathGracePeriod
+			for _, responseIP := range responseIPs {
+				if err := fqdnmap.Upsert(responseIP, lifetime); err != nil {
+					log.WithError(err).WithField(logfields.IPAddr, responseIP).Warning("Failed to update DNS answer validation map")
+				}
+			}
+		}
+
 		log.WithFields(logrus.Fields{
 			"qname": qname,
 			"ips":   responseIPs,
 		}).Debug("Updating DNS name in cache from response to to query")
 
+		ips := make([]string, 0, len(responseIPs))
+		for _, ip := range responseIPs {
+			ips = append(ips, ip.String())
+		}
+		d.SendNotification(monitorAPI.FQDNCacheUpsertedMessage(ep.GetID(), qname, ips, int(TTL)))
+
 		updateCtx, updateCancel := context.WithTimeout(context.TODO(), option.Config.FQDNProxyResponseMaxDelay)
 		defer updateCancel()
 		updateStart := time.Now()
@@ -15,6 +15,7 @@ import (
 	observerpb "github.com/cilium/cilium/api/v1/observer"
 	"github.com/cilium/cilium/pkg/crypto/certloader"
 	v1 "github.com/cilium/cilium/pkg/hubble/api/v1"
+	"github.com/cilium/cilium/pkg/hubble/auth"
 	"github.com/cilium/cilium/pkg/hubble/container"
 	"github.com/cilium/cilium/pkg/hubble/exporter"
 	"github.com/cilium/cilium/pkg/hubble/exporter/exporteroption"
@@ -38,6 +39,7 @@ import (
 	"github.com/cilium/cilium/pkg/logging/logfields"
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/policy"
+	"github.com/cilium/cilium/pkg/resources"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/sirupsen/logrus"
@@ -84,14 +86,33 @@ func (d *Daemon) getHubbleStatus(ctx context.Context) *models.HubbleStatus {
 	return hubbleStatus
 }
 
-func (d *Daemon) launchHubble() {
+// launchHubble starts the Hubble observer and servers. All servers and
+// background operations are bound to the given context and shut down when it
+// is cancelled.
+func (d *Daemon) launchHubble(ctx context.Context) {
 	logger := logging.DefaultLogger.WithField(logfields.LogSubsys, "hubble")
-	if !option.Config.EnableHubble {
-		logger.Info("Hubble server is disabled")
-		return
+
+	var authPolicy *auth.Policy
+	if option.Config.HubbleAuthPolicyFile != "" {
+		policy, err := auth.LoadPolicy(option.Config.HubbleAuthPolicyFile)
+		if err != nil {
+			logger.WithError(err).Error("Failed to load Hubble authorization policy")
+			return
+		}
+		authPolicy = policy
 	}
 
 	var observerOpts []observeroption.Option
+	if authPolicy != nil {
+		// Only deliver flows of namespaces the client is authorized for.
+		// Requests on servers without the authorization policy applied,
+		// e.g. via the local unix domain socket, are not restricted.
+		opt := observeroption.WithOnFlowDeliveryFunc(func(ctx context.Context, flow *flowpb.Flow) (bool, error) {
+			return !auth.AllowFlow(ctx, flow), nil
+		})
+		observerOpts = append(observerOpts, opt)
+	}
+
 	if option.Config.HubbleMetricsServer != "" {
 		logger.WithFields(logrus.Fields{
 			"address": option.Config.HubbleMetricsServer,
@@ -150,8 +171,22 @@ func (d *Daemon) launchHubble() {
 		logger.WithError(err).Error("Failed to initialize Hubble")
 		return
 	}
-	go d.hubbleObserver.Start()
-	d.monitorAgent.RegisterNewConsumer(monitor.NewConsumer(d.hubbleObserver))
+	hubbleObserver := d.hubbleObserver
+	go hubbleObserver.Start()
+	hubbleConsumer := monitor.NewConsumer(hubbleObserver)
+	d.monitorAgent.RegisterNewConsumer(hubbleConsumer)
+	go func() {
+		<-ctx.Done()
+		// Stop feeding the observer before closing its events channel,
+		// which terminates its event loop.
+		d.monitorAgent.RemoveConsumer(hubbleConsumer)
+		close(hubbleObserver.GetEventsChannel())
+	}()
+	resources.Register("hubble", func() resources.Usage {
+		return resources.Usage{
+			Objects: int64(d.hubbleObserver.GetRingBuffer().Len()),
+		}
+	})
 
 	// configure a local hubble instance that serves more gRPC services
 	sockPath := "unix://" + option.Config.HubbleSocketPath
@@ -159,6 +194,9 @@ func (d *Daemon) launchHubble() {
 	if option.Config.HubbleTLSDisabled {
 		peerServiceOptions = append(peerServiceOptions, serviceoption.WithoutTLSInfo())
 	}
+	if option.Config.ControlPlaneIPv6Only {
+		peerServiceOptions = append(peerServiceOptions, serviceoption.WithAddressFamilyIPv6Only())
+	}
 
 	localSrvOpts := []serveroption.Option{
 		serveroption.WithUnixSocketListener(sockPath),
@@ -195,7 +233,7 @@ func (d *Daemon) launchHubble() {
 		return
 	}
 	go func() {
-		<-d.ctx.Done()
+		<-ctx.Done()
 		localSrv.Stop()
 	}()
 
@@ -210,6 +248,9 @@ func (d *Daemon) launchHubble() {
 			serveroption.WithHealthService(),
 			serveroption.WithObserverService(d.hubbleObserver),
 		}
+		if authPolicy != nil {
+			options = append(options, serveroption.WithAuthPolicy(authPolicy))
+		}
 
 		// Hubble TLS/mTLS setup.
 		var tlsServerConfig *certloader.WatchedServerConfig
@@ -232,7 +273,7 @@ func (d *Daemon) launchHubble() {
 				case tlsServerConfig = <-tlsServerConfigChan:
 				case <-waitingMsgTimeout:
 					logger.Info("Waiting for Hubble server TLS certificate and key files to be created")
-				case <-d.ctx.Done():
+				case <-ctx.Done():
 					return
 				}
 			}
@@ -258,7 +299,7 @@ func (d *Daemon) launchHubble() {
 		}
 
 		go func() {
-			<-d.ctx.Done()
+			<-ctx.Done()
 			srv.Stop()
 			if tlsServerConfig != nil {
 				tlsServerConfig.Stop()
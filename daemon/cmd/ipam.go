@@ -4,6 +4,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"strings"
@@ -19,9 +20,11 @@ import (
 	"github.com/cilium/cilium/pkg/ipam"
 	ipamOption "github.com/cilium/cilium/pkg/ipam/option"
 	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/metrics"
 	"github.com/cilium/cilium/pkg/node"
 	nodeTypes "github.com/cilium/cilium/pkg/node/types"
 	"github.com/cilium/cilium/pkg/option"
+	"github.com/cilium/cilium/pkg/podstartup"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
@@ -44,9 +47,54 @@ func (h *postIPAM) Handle(params ipamapi.PostIpamParams) middleware.Responder {
 	if swag.BoolValue(params.Expiration) {
 		expirationTimeout = defaults.IPAMExpiration
 	}
-	ipv4Result, ipv6Result, err := h.daemon.ipam.AllocateNextWithExpiration(family, owner, expirationTimeout)
-	if err != nil {
-		return api.Error(ipamapi.PostIpamFailureCode, err)
+	allocationStart := time.Now()
+
+	// The CNI plugin uses "namespace/name" of the pod as owner. Resolve
+	// the pool the pod draws its IPs from and reject the allocation if the
+	// pod requests a pool which is not present on this node.
+	pool := ipam.PoolDefault
+	podNamespace, podName, isPod := parsePodOwner(owner)
+	if isPod {
+		var err error
+		pool, err = h.daemon.resolvePodIPPool(podNamespace, podName)
+		if err != nil {
+			return api.Error(ipamapi.PostIpamFailureCode, err)
+		}
+	}
+
+	var ipv4Result, ipv6Result *ipam.AllocationResult
+
+	// Try to claim the IPs of a pre-warmed endpoint entry first. The claim
+	// transfers pre-allocated IPs and is therefore much faster than a
+	// regular allocation.
+	if h.daemon.prewarmPool != nil && family == "" {
+		ipv4Result, ipv6Result = h.daemon.prewarmPool.Claim(owner)
+		if expirationTimeout != 0 {
+			for _, result := range []*ipam.AllocationResult{ipv4Result, ipv6Result} {
+				if result == nil {
+					continue
+				}
+				uuid, err := h.daemon.ipam.StartExpirationTimer(result.IP, expirationTimeout)
+				if err != nil {
+					return api.Error(ipamapi.PostIpamFailureCode, err)
+				}
+				result.ExpirationUUID = uuid
+			}
+		}
+	}
+
+	if ipv4Result == nil && ipv6Result == nil {
+		var err error
+		ipv4Result, ipv6Result, err = h.daemon.ipam.AllocateNextWithExpiration(family, owner, expirationTimeout)
+		if err != nil {
+			return api.Error(ipamapi.PostIpamFailureCode, err)
+		}
+	}
+
+	metrics.IpamPoolAllocation.WithLabelValues(string(pool)).Inc()
+
+	if isPod {
+		podstartup.RecordStage(podNamespace, podName, podstartup.StageIPAMAllocation, time.Since(allocationStart))
 	}
 
 	resp := &models.IPAMResponse{
@@ -81,6 +129,30 @@ func (h *postIPAM) Handle(params ipamapi.PostIpamParams) middleware.Responder {
 	return ipamapi.NewPostIpamCreated().WithPayload(resp)
 }
 
+// parsePodOwner splits an allocation owner in the "namespace/name" format
+// used by the CNI plugin for pods.
+func parsePodOwner(owner string) (namespace, name string, ok bool) {
+	parts := strings.SplitN(owner, "/", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// resolvePodIPPool resolves the IP pool for a pod from the pod and namespace
+// annotations. Lookup failures are treated as unannotated objects so that
+// allocation does not depend on the pod watcher being in sync.
+func (d *Daemon) resolvePodIPPool(namespace, name string) (ipam.Pool, error) {
+	var podAnnotations, nsAnnotations map[string]string
+	if pod, err := d.k8sWatcher.GetCachedPod(namespace, name); err == nil {
+		podAnnotations = pod.Annotations
+	}
+	if ns, err := d.k8sWatcher.GetCachedNamespace(namespace); err == nil {
+		nsAnnotations = ns.Annotations
+	}
+	return d.ipamPoolSelector.PoolForPod(podAnnotations, nsAnnotations)
+}
+
 type postIPAMIP struct {
 	daemon *Daemon
 }
@@ -163,6 +235,18 @@ func (d *Daemon) DumpIPAM() *models.IPAMStatus {
 	return status
 }
 
+// ipamAllocationsStatus returns the list of all allocated IPs with their
+// owner, pool, resource and allocation time as JSON, consumed by "cilium
+// ipam list" via the debuginfo API.
+func (d *Daemon) ipamAllocationsStatus() string {
+	allocations := d.ipam.ListAllocations("", "")
+	out, err := json.MarshalIndent(allocations, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("error: %s", err)
+	}
+	return string(out)
+}
+
 func (d *Daemon) allocateRouterIPv4(family datapath.NodeAddressingFamily) (net.IP, error) {
 	if option.Config.LocalRouterIPv4 != "" {
 		routerIP := net.ParseIP(option.Config.LocalRouterIPv4)
@@ -393,6 +477,7 @@ func (d *Daemon) startIPAM() {
 	log.Info("Initializing node addressing")
 	// Set up ipam conf after init() because we might be running d.conf.KVStoreIPv4Registration
 	d.ipam = ipam.NewIPAM(d.datapath.LocalNodeAddressing(), option.Config, d.nodeDiscovery, d.k8sWatcher, &d.mtuConfig)
+	d.ipamPoolSelector = ipam.NewPoolSelector()
 	bootstrapStats.ipam.End(true)
 }
 
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/cilium/cilium/pkg/kvstore"
+	"github.com/cilium/cilium/pkg/option"
+)
+
+// validateIPv6OnlyControlPlane verifies that no configured control-plane
+// address refers to an IPv4 address when the control plane is restricted to
+// IPv6. The checks cover the addresses known at startup; addresses that only
+// become known at runtime, such as clustermesh etcd endpoints, are validated
+// by the respective clients when they connect.
+func validateIPv6OnlyControlPlane() error {
+	for optName, addr := range map[string]string{
+		option.K8sAPIServer:      option.Config.K8sAPIServer,
+		kvstore.EtcdAddrOption:   option.Config.KVStoreOpt[kvstore.EtcdAddrOption],
+		kvstore.ConsulAddrOption: option.Config.KVStoreOpt[kvstore.ConsulAddrOption],
+	} {
+		if addr == "" {
+			continue
+		}
+		if host := endpointHost(addr); isIPv4Literal(host) {
+			return fmt.Errorf("%s %q is an IPv4 address, but %s is enabled",
+				optName, addr, option.ControlPlaneIPv6Only)
+		}
+	}
+
+	return nil
+}
+
+// endpointHost extracts the host from an endpoint given as "scheme://host:port",
+// "host:port" or plain "host".
+func endpointHost(endpoint string) string {
+	host := endpoint
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return host
+}
+
+func isIPv4Literal(host string) bool {
+	ip := net.ParseIP(host)
+	return ip != nil && ip.To4() != nil
+}
@@ -157,7 +157,8 @@ func initKubeProxyReplacementOptions() (bool, error) {
 		}
 
 		if option.Config.NodePortAlg != option.NodePortAlgRandom &&
-			option.Config.NodePortAlg != option.NodePortAlgMaglev {
+			option.Config.NodePortAlg != option.NodePortAlgMaglev &&
+			option.Config.NodePortAlg != option.NodePortAlgLatency {
 			return false, fmt.Errorf("Invalid value for --%s: %s", option.NodePortAlg, option.Config.NodePortAlg)
 		}
 
@@ -176,7 +177,8 @@ func initKubeProxyReplacementOptions() (bool, error) {
 			log.Warning("NodePort BPF configured without bind(2) protection against service ports")
 		}
 
-		if option.Config.NodePortAlg == option.NodePortAlgMaglev {
+		if option.Config.NodePortAlg == option.NodePortAlgMaglev ||
+			option.Config.NodePortAlg == option.NodePortAlgLatency {
 			// "Let N be the size of a VIP's backend pool." [...] "In practice, we choose M to be
 			// larger than 100 x N to ensure at most a 1% difference in hash space assigned to
 			// backends." (from Maglev paper, page 6)
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/cilium/cilium/pkg/controller"
+	monitorAPI "github.com/cilium/cilium/pkg/monitor/api"
+	"github.com/cilium/cilium/pkg/node"
+	"github.com/cilium/cilium/pkg/option"
+
+	"github.com/sirupsen/logrus"
+)
+
+// nodeAddressMonitorInterval is the interval in which the node address
+// detection is re-run
+const nodeAddressMonitorInterval = 30 * time.Second
+
+// startNodeAddressMonitor starts a controller detecting runtime changes of
+// the node's primary address, e.g. after a DHCP lease renewal or a bond
+// failover, and reprograms the datapath accordingly instead of requiring an
+// agent restart.
+func (d *Daemon) startNodeAddressMonitor() {
+	controller.NewManager().UpdateController("node-address-monitor",
+		controller.ControllerParams{
+			RunInterval: nodeAddressMonitorInterval,
+			DoFunc:      d.checkNodeAddresses,
+		})
+}
+
+// checkNodeAddresses re-runs the node address detection and reprograms the
+// datapath when the primary node address changed at runtime
+func (d *Daemon) checkNodeAddresses(ctx context.Context) error {
+	device := option.Config.DirectRoutingDevice
+	changed := false
+	oldIP, newIP := "", ""
+
+	if option.Config.EnableIPv4 {
+		if ip, err := node.AutoDetectIPv4Address(device); err == nil && ip != nil && !ip.Equal(node.GetIPv4()) {
+			if old := node.GetIPv4(); old != nil {
+				oldIP = old.String()
+			}
+			newIP = ip.String()
+			node.SetIPv4(ip)
+			changed = true
+		}
+	}
+
+	if option.Config.EnableIPv6 {
+		if ip, err := node.AutoDetectIPv6Address(device); err == nil && ip != nil && !ip.Equal(node.GetIPv6()) {
+			if old := node.GetIPv6(); old != nil {
+				oldIP = old.String()
+			}
+			newIP = ip.String()
+			node.SetIPv6(ip)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	log.WithFields(logrus.Fields{
+		"oldIP": oldIP,
+		"newIP": newIP,
+	}).Info("Detected node address change, reprogramming datapath")
+
+	// Re-derive the NodePort and masquerade addresses from the new device
+	// configuration
+	if option.Config.EnableNodePort {
+		if err := node.InitNodePortAddrs(option.Config.Devices, option.Config.LBDevInheritIPAddr); err != nil {
+			return err
+		}
+	}
+	if option.Config.EnableBPFMasquerade {
+		if err := node.InitBPFMasqueradeAddrs(option.Config.Devices); err != nil {
+			return err
+		}
+	}
+
+	// Propagate the new address to the cluster
+	d.nodeDiscovery.UpdateCiliumNodeResource()
+
+	// Reload the datapath to reprogram tunnels, BPF constants and proxy
+	// routing rules with the new address
+	wg, err := d.TriggerReloadWithoutCompile("node address change")
+	if err != nil {
+		return err
+	}
+	wg.Wait()
+
+	d.SendNotification(monitorAPI.NodeAddressChangeMessage(oldIP, newIP))
+	return nil
+}
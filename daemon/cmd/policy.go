@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	stdlog "log"
+	"math"
 	"net"
 	"strings"
 	"sync"
@@ -18,6 +19,7 @@ import (
 	"github.com/cilium/cilium/pkg/api"
 	"github.com/cilium/cilium/pkg/endpoint"
 	"github.com/cilium/cilium/pkg/endpoint/regeneration"
+	"github.com/cilium/cilium/pkg/eventjournal"
 	"github.com/cilium/cilium/pkg/eventqueue"
 	"github.com/cilium/cilium/pkg/identity"
 	"github.com/cilium/cilium/pkg/identity/cache"
@@ -30,10 +32,13 @@ import (
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/policy"
 	policyAPI "github.com/cilium/cilium/pkg/policy/api"
+	"github.com/cilium/cilium/pkg/policy/trafficdirection"
 	"github.com/cilium/cilium/pkg/safetime"
+	"github.com/cilium/cilium/pkg/u8proto"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 )
 
 type policyTriggerMetrics struct{}
@@ -191,6 +196,15 @@ func (h *getPolicyResolve) Handle(params GetPolicyResolveParams) middleware.Resp
 	}
 	if ingressVerdict == policyAPI.Allowed && egressVerdict == policyAPI.Allowed {
 		result.Verdict = policyAPI.Allowed.String()
+		// Surface how ICMP errors related to the allowed flow (e.g.
+		// TTL exceeded, fragmentation needed) are handled by the
+		// datapath.
+		if option.Config.EnableRelatedICMPErrors {
+			result.Log += "\nRelated ICMP errors for this flow are accepted as RELATED\n"
+		} else {
+			result.Log += "\nRelated ICMP errors for this flow are dropped (--" +
+				option.EnableRelatedICMPErrors + " is disabled)\n"
+		}
 	} else {
 		result.Verdict = policyAPI.Denied.String()
 	}
@@ -381,6 +395,17 @@ func (d *Daemon) policyAdd(sourceRules policyAPI.Rules, opts *policy.AddOptions,
 		metrics.PolicyImplementationDelay.WithLabelValues(source).Observe(duration.Seconds())
 	})
 
+	// Additionally track how long each individual endpoint takes to see its
+	// policy maps updated to newRev, so that enforcement lag of a single
+	// endpoint remains visible on busy nodes.
+	for _, ep := range d.endpointManager.GetEndpoints() {
+		ep := ep
+		ep.WaitForPolicyRevision(d.ctx, newRev, func(now time.Time) {
+			duration, _ := safetime.TimeSinceSafe(policyAddStartTime, logger)
+			ep.ObservePolicyMapUpdateDelay(source, duration)
+		})
+	}
+
 	// remove prefixes of replaced rules above. Refcounts have been incremented
 	// above, so any decrements here will be no-ops for CIDRs that are re-added,
 	// and will trigger deletions for those that are no longer used.
@@ -712,6 +737,10 @@ func (h *putPolicy) Handle(params PutPolicyParams) middleware.Responder {
 		}
 	}
 
+	if params.Shadow != nil && *params.Shadow {
+		return h.shadowEvaluate(rules)
+	}
+
 	rev, err := d.PolicyAdd(rules, &policy.AddOptions{Source: metrics.LabelEventSourceAPI})
 	if err != nil {
 		metrics.PolicyImportErrorsTotal.Inc()
@@ -725,6 +754,157 @@ func (h *putPolicy) Handle(params PutPolicyParams) middleware.Responder {
 	return NewPutPolicyOK().WithPayload(policy)
 }
 
+// shadowEvaluate compares the verdicts of the candidate rules against the
+// enforced policy for every local endpoint and every known peer identity,
+// without enforcing the candidate rules. The per-endpoint divergence report
+// is returned in the Policy field of the response in place of the policy
+// tree.
+func (h *putPolicy) shadowEvaluate(rules policyAPI.Rules) middleware.Responder {
+	d := h.daemon
+
+	idCache := d.identityAllocator.GetIdentityCache()
+	candidate := policy.NewShadowRepository(idCache, rules)
+
+	var (
+		reports     []*policy.ShadowEndpointReport
+		divergences int
+	)
+	for _, ep := range d.endpointManager.GetEndpoints() {
+		epLabels, ok := idCache[ep.GetIdentity()]
+		if !ok {
+			// The endpoint has no identity yet, e.g. while it is still
+			// being created. There is nothing to evaluate in that case.
+			continue
+		}
+		divs := d.policy.ShadowCompareForEndpoint(candidate, epLabels, idCache)
+		reports = append(reports, &policy.ShadowEndpointReport{
+			EndpointID:    ep.GetID(),
+			ComparedPeers: len(idCache),
+			Divergences:   divs,
+		})
+		divergences += len(divs)
+	}
+
+	report, err := json.Marshal(reports)
+	if err != nil {
+		return api.Error(PutPolicyFailureCode, err)
+	}
+
+	log.WithFields(logrus.Fields{
+		"endpoints":   len(reports),
+		"divergences": divergences,
+	}).Info("Shadow policy evaluation completed")
+
+	return NewPutPolicyOK().WithPayload(&models.Policy{
+		Revision: int64(d.policy.GetRevision()),
+		Policy:   string(report),
+	})
+}
+
+type putPolicyFlowOverride struct {
+	daemon *Daemon
+}
+
+func newPutPolicyFlowOverrideHandler(d *Daemon) PutPolicyFlowOverrideHandler {
+	return &putPolicyFlowOverride{daemon: d}
+}
+
+func (h *putPolicyFlowOverride) Handle(params PutPolicyFlowOverrideParams) middleware.Responder {
+	d := h.daemon
+	fo := params.FlowOverride
+
+	if !option.Config.EnableFlowOverrides {
+		return api.Error(PutPolicyFlowOverrideDisabledCode,
+			fmt.Errorf("flow overrides are disabled, enable with --%s", option.EnableFlowOverrides))
+	}
+
+	if fo.EndpointID <= 0 || fo.EndpointID > math.MaxUint16 {
+		return api.Error(PutPolicyFlowOverrideInvalidCode,
+			fmt.Errorf("invalid endpoint id %d", fo.EndpointID))
+	}
+	if fo.Identity <= 0 || fo.Identity > math.MaxUint32 {
+		return api.Error(PutPolicyFlowOverrideInvalidCode,
+			fmt.Errorf("invalid identity %d", fo.Identity))
+	}
+	if fo.Port < 0 || fo.Port > math.MaxUint16 {
+		return api.Error(PutPolicyFlowOverrideInvalidCode,
+			fmt.Errorf("invalid port %d", fo.Port))
+	}
+	if fo.TTLSeconds <= 0 {
+		return api.Error(PutPolicyFlowOverrideInvalidCode,
+			fmt.Errorf("invalid ttl %d, must be positive", fo.TTLSeconds))
+	}
+
+	var proto u8proto.U8proto
+	if fo.Protocol != "" && !strings.EqualFold(fo.Protocol, "any") {
+		var err error
+		proto, err = u8proto.ParseProtocol(fo.Protocol)
+		if err != nil {
+			return api.Error(PutPolicyFlowOverrideInvalidCode, err)
+		}
+	}
+
+	ep := d.endpointManager.LookupCiliumID(uint16(fo.EndpointID))
+	if ep == nil {
+		return NewPutPolicyFlowOverrideNotFound()
+	}
+
+	dir := trafficdirection.Egress
+	if fo.Ingress {
+		dir = trafficdirection.Ingress
+	}
+
+	key := policy.Key{
+		Identity:         uint32(fo.Identity),
+		DestPort:         uint16(fo.Port),
+		Nexthdr:          uint8(proto),
+		TrafficDirection: dir.Uint8(),
+	}
+
+	scopedLog := log.WithFields(logrus.Fields{
+		logfields.EndpointID: fo.EndpointID,
+		logfields.Identity:   fo.Identity,
+		logfields.Port:       fo.Port,
+		logfields.Protocol:   proto,
+		"ingress":            fo.Ingress,
+		"ttl":                time.Duration(fo.TTLSeconds) * time.Second,
+	})
+
+	prev, installed, err := ep.InstallPolicyMapOverride(key)
+	if err != nil {
+		return api.Error(PutPolicyFlowOverrideFailureCode, err)
+	}
+	if !installed {
+		// An allowing entry for the key already exists, possibly
+		// redirecting to a proxy. Leave it alone instead of resetting
+		// the proxy redirection.
+		scopedLog.Debug("Flow already allowed by policy, no override installed")
+		return NewPutPolicyFlowOverrideOK()
+	}
+
+	scopedLog.Warning("Temporary flow override installed")
+	eventjournal.Record(eventjournal.ReasonFlowOverride,
+		"temporary allow of identity %d port %d/%s (ingress=%t) installed into policy map of endpoint %d for %d seconds",
+		fo.Identity, fo.Port, proto, fo.Ingress, fo.EndpointID, fo.TTLSeconds)
+
+	// The override is removed from the policy map once the TTL expires,
+	// restoring the deny entry it replaced, if any. If the endpoint has
+	// been removed or regenerated in the meantime, the entry is left to
+	// the regenerated policy.
+	time.AfterFunc(time.Duration(fo.TTLSeconds)*time.Second, func() {
+		if err := ep.RemovePolicyMapOverride(key, prev); err != nil {
+			scopedLog.WithError(err).Warning("Unable to remove expired flow override")
+			return
+		}
+		scopedLog.Warning("Temporary flow override expired and removed")
+		eventjournal.Record(eventjournal.ReasonFlowOverride,
+			"temporary allow of identity %d port %d/%s (ingress=%t) of endpoint %d expired",
+			fo.Identity, fo.Port, proto, fo.Ingress, fo.EndpointID)
+	})
+
+	return NewPutPolicyFlowOverrideOK()
+}
+
 type getPolicy struct {
 	repo *policy.Repository
 }
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"github.com/cilium/cilium/api/v1/models"
+	restapi "github.com/cilium/cilium/api/v1/server/restapi/daemon"
+	"github.com/cilium/cilium/pkg/api"
+	"github.com/cilium/cilium/pkg/endpoint"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Profile types understood by captureProfile. They must match the enum
+// of the "profile" query parameter in the API specification.
+const (
+	profileTypeCPU       = "cpu"
+	profileTypeHeap      = "heap"
+	profileTypeBlock     = "block"
+	profileTypeMutex     = "mutex"
+	profileTypeGoroutine = "goroutine"
+)
+
+type getProfile struct {
+	daemon *Daemon
+}
+
+// NewGetProfileHandler returns the profile capture endpoint handler for the agent
+func NewGetProfileHandler(d *Daemon) restapi.GetProfileHandler {
+	return &getProfile{daemon: d}
+}
+
+func (h *getProfile) Handle(params restapi.GetProfileParams) middleware.Responder {
+	log.WithField(logfields.Params, logfields.Repr(params)).Debug("GET /profile request")
+
+	d := h.daemon
+
+	// The rate limiter permits only a single capture at a time as the
+	// block and mutex profiles mutate global runtime sampling state.
+	r, err := d.apiLimiterSet.Wait(params.HTTPRequest.Context(), apiRequestProfile)
+	if err != nil {
+		return api.Error(http.StatusTooManyRequests, err)
+	}
+	defer r.Done()
+
+	profile := *params.Profile
+
+	// Heap profiles and goroutine dumps are taken from the current
+	// state of the runtime and are not sampled over a duration.
+	var duration time.Duration
+	switch profile {
+	case profileTypeCPU, profileTypeBlock, profileTypeMutex:
+		duration = time.Duration(*params.Seconds) * time.Second
+	}
+
+	data, err := captureProfile(params.HTTPRequest.Context(), profile, duration)
+	if err != nil {
+		r.Error(err)
+		return api.Error(restapi.GetProfileFailureCode, err)
+	}
+
+	return restapi.NewGetProfileOK().WithPayload(&models.ProfileResult{
+		Context:         d.getProfileContext(),
+		Data:            data,
+		DurationSeconds: int64(duration / time.Second),
+		Type:            profile,
+	})
+}
+
+// captureProfile captures the requested profile type, sampling over the
+// given duration where applicable. The capture is cut short when the
+// context is cancelled, e.g. because the requesting client went away.
+func captureProfile(ctx context.Context, profile string, duration time.Duration) ([]byte, error) {
+	var buf bytes.Buffer
+
+	switch profile {
+	case profileTypeCPU:
+		if err := pprof.StartCPUProfile(&buf); err != nil {
+			return nil, err
+		}
+		waitOrDone(ctx, duration)
+		pprof.StopCPUProfile()
+	case profileTypeHeap:
+		// Get up-to-date statistics of the live heap.
+		runtime.GC()
+		if err := pprof.Lookup("heap").WriteTo(&buf, 0); err != nil {
+			return nil, err
+		}
+	case profileTypeBlock:
+		// Blocking event profiling is off by default due to its
+		// overhead, enable it only for the duration of the capture.
+		runtime.SetBlockProfileRate(1)
+		waitOrDone(ctx, duration)
+		err := pprof.Lookup("block").WriteTo(&buf, 0)
+		runtime.SetBlockProfileRate(0)
+		if err != nil {
+			return nil, err
+		}
+	case profileTypeMutex:
+		old := runtime.SetMutexProfileFraction(1)
+		waitOrDone(ctx, duration)
+		err := pprof.Lookup("mutex").WriteTo(&buf, 0)
+		runtime.SetMutexProfileFraction(old)
+		if err != nil {
+			return nil, err
+		}
+	case profileTypeGoroutine:
+		// Plain text dump with full stacks, same as delivered on
+		// receipt of SIGABRT.
+		if err := pprof.Lookup("goroutine").WriteTo(&buf, 2); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown profile type %q", profile)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func waitOrDone(ctx context.Context, duration time.Duration) {
+	select {
+	case <-time.After(duration):
+	case <-ctx.Done():
+	}
+}
+
+// getProfileContext snapshots the agent state most relevant for
+// interpreting a captured profile.
+func (d *Daemon) getProfileContext() *models.ProfileContext {
+	profileCtx := &models.ProfileContext{}
+
+	for _, ep := range d.endpointManager.GetEndpoints() {
+		switch ep.GetState() {
+		case endpoint.StateWaitingToRegenerate, endpoint.StateRegenerating:
+			profileCtx.EndpointsRegenerating++
+		}
+	}
+
+	if d.l7Proxy != nil {
+		profileCtx.ProxyRedirects = int64(len(d.l7Proxy.GetStatusModel().Redirects))
+	}
+
+	return profileCtx
+}
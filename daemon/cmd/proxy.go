@@ -4,7 +4,16 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/cilium/cilium/api/v1/models"
+	. "github.com/cilium/cilium/api/v1/server/restapi/daemon"
+	"github.com/cilium/cilium/pkg/logging/logfields"
 	monitorAPI "github.com/cilium/cilium/pkg/monitor/api"
+	"github.com/cilium/cilium/pkg/proxy"
 	"github.com/cilium/cilium/pkg/proxy/logger"
 )
 
@@ -12,3 +21,42 @@ import (
 func (d *Daemon) NewProxyLogRecord(l *logger.LogRecord) error {
 	return d.monitorAgent.SendEvent(monitorAPI.MessageTypeAccessLog, l.LogRecord)
 }
+
+type getProxyListeners struct {
+	daemon *Daemon
+}
+
+// NewGetProxyListenersHandler returns a new instance of the handler for the
+// proxy listeners API
+func NewGetProxyListenersHandler(d *Daemon) GetProxyListenersHandler {
+	return &getProxyListeners{daemon: d}
+}
+
+func (h *getProxyListeners) Handle(params GetProxyListenersParams) middleware.Responder {
+	log.WithField(logfields.Params, logfields.Repr(params)).Debug("GET /proxy/listeners request")
+	d := h.daemon
+
+	listeners := []*models.ProxyListener{}
+	if d.l7Proxy != nil {
+		listeners = d.l7Proxy.GetListenersModel()
+	}
+	return NewGetProxyListenersOK().WithPayload(listeners)
+}
+
+func (d *Daemon) proxyRedirectsStatus() string {
+	redirects := d.l7Proxy.GetRedirectStates()
+	out, err := json.MarshalIndent(redirects, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("error: %s", err)
+	}
+	return string(out)
+}
+
+func proxyPortsStatus() string {
+	ports := proxy.GetProxyPortStates()
+	out, err := json.MarshalIndent(ports, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("error: %s", err)
+	}
+	return string(out)
+}
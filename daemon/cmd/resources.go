@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/ipcache"
+	"github.com/cilium/cilium/pkg/option"
+	"github.com/cilium/cilium/pkg/resources"
+)
+
+// resourceUsageUpdateInterval is the interval at which the per-subsystem
+// resource usage metrics are updated.
+const resourceUsageUpdateInterval = 30 * time.Second
+
+// registerResourceReporters registers the resource usage reporters of the
+// subsystems managed by the daemon and exposes the collected usage via the
+// /debug/resources handler on the pprof server. The reported values are
+// best-effort estimates, see pkg/resources.
+func (d *Daemon) registerResourceReporters() {
+	resources.Register("policy", func() resources.Usage {
+		return resources.Usage{
+			Objects: int64(d.policy.NumRules()),
+		}
+	})
+	resources.Register("ipcache", func() resources.Usage {
+		return resources.Usage{
+			Objects: int64(ipcache.IPIdentityCache.NumEntries()),
+		}
+	})
+	resources.Register("fqdn", func() resources.Usage {
+		return resources.Usage{
+			Objects: int64(d.dnsNameManager.GetDNSCache().NumNames()),
+		}
+	})
+	if d.l7Proxy != nil {
+		resources.Register("proxy", func() resources.Usage {
+			return resources.Usage{
+				Objects: int64(d.l7Proxy.NumRedirects()),
+			}
+		})
+	}
+
+	resources.EnableHTTPHandler()
+
+	if option.Config.MetricsConfig.ResourceUsageBytesEnabled ||
+		option.Config.MetricsConfig.ResourceUsageGoroutinesEnabled {
+		controller.NewManager().UpdateController("resource-usage-metrics",
+			controller.ControllerParams{
+				DoFunc: func(ctx context.Context) error {
+					resources.UpdateMetrics()
+					return nil
+				},
+				RunInterval: resourceUsageUpdateInterval,
+				Context:     d.ctx,
+			})
+	}
+}
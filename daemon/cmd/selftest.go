@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/datapath/linux/linux_defaults"
+	"github.com/cilium/cilium/pkg/datapath/selftest"
+	lb "github.com/cilium/cilium/pkg/loadbalancer"
+	"github.com/cilium/cilium/pkg/maps/metricsmap"
+	"github.com/cilium/cilium/pkg/node"
+	"github.com/cilium/cilium/pkg/option"
+	"github.com/cilium/cilium/pkg/proxy"
+)
+
+// datapathSelftestController is the name of the controller executing the
+// datapath self-test. The controller has no run interval, re-runs can be
+// triggered on demand via the controller trigger API.
+const datapathSelftestController = "datapath-selftest"
+
+// launchDatapathSelftest runs the datapath self-test once at startup and
+// publishes the results in the health API.
+func (d *Daemon) launchDatapathSelftest() {
+	if !option.Config.EnableDatapathSelftest {
+		d.setDatapathSelftestStatus(&models.DatapathSelftestStatus{
+			State: selftest.StateDisabled,
+			Msg:   fmt.Sprintf("Disabled by configuration, enable with --%s", option.EnableDatapathSelftest),
+		})
+		return
+	}
+
+	controller.NewManager().UpdateController(datapathSelftestController,
+		controller.ControllerParams{
+			DoFunc:  d.runDatapathSelftest,
+			Context: d.ctx,
+		},
+	)
+}
+
+// runDatapathSelftest executes all datapath self-test probes and stores the
+// aggregated result for the status API.
+func (d *Daemon) runDatapathSelftest(ctx context.Context) error {
+	st := selftest.New(d.datapathSelftestConfig(), metricsmap.Metrics)
+	results := st.Run(ctx)
+
+	status := &models.DatapathSelftestStatus{
+		State: selftest.StateOk,
+	}
+
+	failures := 0
+	for _, result := range results {
+		status.Probes = append(status.Probes, &models.DatapathSelftestProbe{
+			Name:        result.Name,
+			State:       result.State,
+			Msg:         result.Msg,
+			PacketsSent: int64(result.PacketsSent),
+		})
+		if result.State == selftest.StateFailure {
+			failures++
+			log.WithField("probe", result.Name).Warningf("Datapath self-test probe failed: %s", result.Msg)
+		}
+	}
+
+	if failures > 0 {
+		status.State = selftest.StateFailure
+		status.Msg = fmt.Sprintf("%d out of %d datapath self-test probes failed", failures, len(results))
+	}
+
+	d.setDatapathSelftestStatus(status)
+
+	if failures > 0 {
+		return fmt.Errorf("%s", status.Msg)
+	}
+	return nil
+}
+
+func (d *Daemon) setDatapathSelftestStatus(status *models.DatapathSelftestStatus) {
+	d.statusCollectMutex.Lock()
+	d.statusResponse.DatapathSelftest = status
+	d.statusCollectMutex.Unlock()
+}
+
+// datapathSelftestConfig derives the self-test probe targets from the
+// current agent configuration and state. Probes without a target report
+// themselves as skipped.
+func (d *Daemon) datapathSelftestConfig() selftest.Config {
+	config := selftest.Config{
+		Device:     option.Config.HostDevice,
+		RouterIPv4: node.GetInternalIPv4Router(),
+	}
+
+	if option.Config.Tunnel != option.TunnelDisabled {
+		config.TunnelDevice = linux_defaults.TunnelDeviceName
+	}
+
+	// Pick the first NodePort frontend of a known service
+	if option.Config.EnableNodePort {
+		for _, svc := range d.svc.GetDeepCopyServices() {
+			if svc.Type == lb.SVCTypeNodePort {
+				config.NodePortAddr = svc.Frontend.IP
+				config.NodePort = svc.Frontend.Port
+				break
+			}
+		}
+	}
+
+	// Pick the first configured proxy port
+	for _, pp := range proxy.GetProxyPortStates() {
+		if pp.Configured && pp.ProxyPort != 0 {
+			config.ProxyPort = pp.ProxyPort
+			break
+		}
+	}
+
+	return config
+}
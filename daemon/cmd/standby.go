@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"time"
+
+	daemonAPI "github.com/cilium/cilium/api/v1/client/daemon"
+	clientPkg "github.com/cilium/cilium/pkg/client"
+	"github.com/cilium/cilium/pkg/option"
+)
+
+// standbyTakeoverThreshold is the number of consecutive failed liveness
+// probes of the primary agent after which the standby takes over. Together
+// with the probe interval this bounds the failover window: with the default
+// 200ms interval the standby starts its own bootstrap well under a second
+// after the primary stops responding.
+const standbyTakeoverThreshold = 3
+
+// waitForTakeover blocks while the primary agent on this node is healthy.
+//
+// Bootstrap stages which do not touch shared state are run before the
+// standby blocks: configuration is parsed, kernel features are probed and
+// the Kubernetes client is connected. The remaining bootstrap is deferred:
+// the standby does not touch sysctls, iptables, BPF maps or the on-disk
+// state directory while waiting, so the primary retains exclusive datapath
+// control. Those deferred stages, including the Kubernetes cache sync and
+// the datapath load, still run at takeover time; the BPF maps pinned by the
+// primary and the state directory remain in place when the primary dies, so
+// they proceed through the regular restore path without recreating datapath
+// state from scratch.
+//
+// The primary is probed through the agent API socket. Once
+// standbyTakeoverThreshold consecutive probes have failed, the function
+// returns and the caller continues with the normal agent bootstrap.
+func waitForTakeover() {
+	interval := option.Config.StandbyProbeInterval
+	if interval <= 0 {
+		interval = 200 * time.Millisecond
+	}
+
+	c, err := clientPkg.NewDefaultClientWithTimeout(interval)
+	if err != nil {
+		log.WithError(err).Fatal("Standby mode: unable to create agent API client")
+	}
+
+	log.Info("Standby mode: deferring bootstrap while the primary agent is healthy")
+
+	brief := true
+	sawPrimary := false
+	failures := 0
+	for {
+		params := daemonAPI.NewGetHealthzParamsWithTimeout(interval)
+		params.SetBrief(&brief)
+		if _, err := c.Daemon.GetHealthz(params); err != nil {
+			failures++
+			if failures >= standbyTakeoverThreshold {
+				if sawPrimary {
+					log.WithError(err).Warning("Standby mode: primary agent unresponsive, taking over datapath control")
+				} else {
+					log.Info("Standby mode: no primary agent detected, taking over datapath control")
+				}
+				return
+			}
+		} else {
+			if !sawPrimary {
+				log.Info("Standby mode: primary agent detected, standing by")
+				sawPrimary = true
+			}
+			failures = 0
+		}
+		time.Sleep(interval)
+	}
+}
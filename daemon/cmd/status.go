@@ -227,7 +227,8 @@ func (d *Daemon) getKubeProxyReplacementStatus() *models.KubeProxyReplacement {
 			features.NodePort.Mode = strings.Title(option.Config.NodePortMode)
 		}
 		features.NodePort.Algorithm = models.KubeProxyReplacementFeaturesNodePortAlgorithmRandom
-		if option.Config.NodePortAlg == option.NodePortAlgMaglev {
+		if option.Config.NodePortAlg == option.NodePortAlgMaglev ||
+			option.Config.NodePortAlg == option.NodePortAlgLatency {
 			features.NodePort.Algorithm = models.KubeProxyReplacementFeaturesNodePortAlgorithmMaglev
 			features.NodePort.LutSize = int64(option.Config.MaglevTableSize)
 		}
@@ -272,6 +273,7 @@ func (d *Daemon) getKubeProxyReplacementStatus() *models.KubeProxyReplacement {
 func (d *Daemon) getBPFMapStatus() *models.BPFMapStatus {
 	return &models.BPFMapStatus{
 		DynamicSizeRatio: option.Config.BPFMapsDynamicSizeRatio,
+		Migration:        d.bpfMapMigration,
 		Maps: []*models.BPFMapProperties{
 			{
 				Name: "Non-TCP connection tracking",
@@ -848,7 +850,9 @@ func (d *Daemon) startStatusCollector() {
 				if d.l7Proxy == nil {
 					return nil, nil
 				}
-				return d.l7Proxy.GetStatusModel(), nil
+				proxyStatus := d.l7Proxy.GetStatusModel()
+				proxyStatus.RedirectStatistics = d.l7Proxy.GetRedirectStats()
+				return proxyStatus, nil
 			},
 			OnStatusUpdate: func(status status.Status) {
 				d.statusCollectMutex.Lock()
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package cmd
+
+import (
+	"fmt"
+
+	lb "github.com/cilium/cilium/pkg/loadbalancer"
+	"github.com/cilium/cilium/pkg/option"
+	"github.com/cilium/cilium/pkg/synflood"
+)
+
+// launchSynFloodDetector starts the periodic scan of the connection tracking
+// table for SYN floods toward NodePort and LoadBalancer service frontends.
+func (d *Daemon) launchSynFloodDetector() {
+	if !option.Config.EnableSynFloodProtection {
+		return
+	}
+
+	detector := synflood.NewDetector(option.Config.SynFloodThreshold,
+		func(revNatIndex uint16) string {
+			if svc, ok := d.svc.GetDeepCopyServiceByID(lb.ServiceID(revNatIndex)); ok {
+				return svc.Frontend.String()
+			}
+			return fmt.Sprintf("%d", revNatIndex)
+		})
+	detector.Run(d.ctx)
+}
@@ -9,6 +9,7 @@ import (
 	"os/signal"
 
 	"github.com/cilium/cilium/pkg/crypto/certloader"
+	"github.com/cilium/cilium/pkg/hubble/auth"
 	"github.com/cilium/cilium/pkg/hubble/relay/defaults"
 	"github.com/cilium/cilium/pkg/hubble/relay/server"
 	"github.com/cilium/cilium/pkg/logging"
@@ -39,6 +40,7 @@ const (
 	keyTLSServerCertFile      = "tls-server-cert-file"
 	keyTLSServerKeyFile       = "tls-server-key-file"
 	keyTLSServerDisabled      = "disable-server-tls"
+	keyAuthPolicyFile         = "auth-policy-file"
 )
 
 // New creates a new serve command.
@@ -124,6 +126,11 @@ func New(vp *viper.Viper) *cobra.Command {
 		false,
 		"Disable TLS for the server and allow clients to connect over plaintext.",
 	)
+	flags.String(
+		keyAuthPolicyFile,
+		"",
+		"Path to a JSON file mapping client identities to the namespaces they are authorized to observe flows for. When set, unauthorized clients are rejected.",
+	)
 	vp.BindPFlags(flags)
 
 	return cmd
@@ -179,6 +186,14 @@ func runServe(vp *viper.Viper) error {
 		opts = append(opts, server.WithServerTLS(tlsServerConfig))
 	}
 
+	if path := vp.GetString(keyAuthPolicyFile); path != "" {
+		policy, err := auth.LoadPolicy(path)
+		if err != nil {
+			return fmt.Errorf("cannot load authorization policy: %v", err)
+		}
+		opts = append(opts, server.WithAuthPolicy(policy))
+	}
+
 	if vp.GetBool(keyPprof) {
 		pprof.Enable(vp.GetInt(keyPprofPort))
 	}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	operatorOption "github.com/cilium/cilium/operator/option"
+	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/k8s"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// canaryRolloutCheckInterval is the interval in which the canary nodes are
+// checked for health
+const canaryRolloutCheckInterval = 30 * time.Second
+
+// canaryRollout tracks the state of an operator driven canary rollout. Nodes
+// are admitted to a pending datapath configuration change by labeling them
+// with the configured rollout label, which the configuration (e.g. the node
+// selector of a DaemonSet carrying the new options) keys off. The nodes
+// matching the canary selector are admitted first and observed for the
+// configured period before the rollout either continues to all remaining
+// nodes or is rolled back.
+type canaryRollout struct {
+	// canaryNodes are the names of the nodes admitted during the canary
+	// phase
+	canaryNodes []string
+
+	// healthySince is the time since which all canary nodes have been
+	// continuously healthy
+	healthySince time.Time
+
+	// completed is true once all nodes have been admitted
+	completed bool
+
+	// rolledBack is true once the rollout has been rolled back after a
+	// canary node became unhealthy
+	rolledBack bool
+}
+
+func enableCanaryRolloutController() {
+	rollout := &canaryRollout{}
+
+	controller.NewManager().UpdateController("canary-rollout",
+		controller.ControllerParams{
+			RunInterval: canaryRolloutCheckInterval,
+			DoFunc:      rollout.run,
+		})
+}
+
+func (cr *canaryRollout) run(ctx context.Context) error {
+	if cr.completed || cr.rolledBack {
+		return nil
+	}
+
+	// Admit the canary nodes first
+	if cr.canaryNodes == nil {
+		nodes, err := k8s.Client().CoreV1().Nodes().List(ctx, metav1.ListOptions{
+			LabelSelector: operatorOption.Config.CanaryRolloutNodeSelector,
+		})
+		if err != nil {
+			return err
+		}
+		if len(nodes.Items) == 0 {
+			return fmt.Errorf("no nodes match canary rollout selector %q",
+				operatorOption.Config.CanaryRolloutNodeSelector)
+		}
+
+		for _, node := range nodes.Items {
+			if err := cr.admitNode(ctx, node.Name); err != nil {
+				return err
+			}
+			cr.canaryNodes = append(cr.canaryNodes, node.Name)
+		}
+		cr.healthySince = time.Now()
+		log.Infof("Admitted %d canary nodes to the pending configuration change", len(cr.canaryNodes))
+		return nil
+	}
+
+	healthy, err := cr.canaryNodesHealthy(ctx)
+	if err != nil {
+		return err
+	}
+	if !healthy {
+		log.Warning("Canary node became unhealthy, rolling back configuration change")
+		return cr.rollBack(ctx)
+	}
+
+	if time.Since(cr.healthySince) < operatorOption.Config.CanaryRolloutObservationPeriod {
+		return nil
+	}
+
+	// The canary nodes remained healthy for the observation period,
+	// continue the rollout to all remaining nodes.
+	nodes, err := k8s.Client().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	admitted := 0
+	for _, node := range nodes.Items {
+		if _, ok := node.Labels[operatorOption.Config.CanaryRolloutNodeLabel]; ok {
+			continue
+		}
+		if err := cr.admitNode(ctx, node.Name); err != nil {
+			return err
+		}
+		admitted++
+	}
+
+	cr.completed = true
+	log.Infof("Canary nodes remained healthy, admitted the remaining %d nodes to the configuration change", admitted)
+	return nil
+}
+
+// canaryNodesHealthy verifies that all canary nodes are still connected and
+// healthy. The Ready and NetworkUnavailable node conditions cover both the
+// kubelet heartbeat and the connectivity health reported by the agent.
+func (cr *canaryRollout) canaryNodesHealthy(ctx context.Context) (bool, error) {
+	for _, name := range cr.canaryNodes {
+		node, err := k8s.Client().CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		for _, condition := range node.Status.Conditions {
+			switch condition.Type {
+			case corev1.NodeReady:
+				if condition.Status != corev1.ConditionTrue {
+					log.WithField(logfields.NodeName, name).Warning("Canary node is not ready")
+					return false, nil
+				}
+			case corev1.NodeNetworkUnavailable:
+				if condition.Status == corev1.ConditionTrue {
+					log.WithField(logfields.NodeName, name).Warning("Canary node network is unavailable")
+					return false, nil
+				}
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// rollBack removes the rollout label from all canary nodes, reverting them to
+// the previous configuration
+func (cr *canaryRollout) rollBack(ctx context.Context) error {
+	for _, name := range cr.canaryNodes {
+		patch := []byte(fmt.Sprintf(`{"metadata":{"labels":{%q:null}}}`,
+			operatorOption.Config.CanaryRolloutNodeLabel))
+		_, err := k8s.Client().CoreV1().Nodes().Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	cr.rolledBack = true
+	return nil
+}
+
+// admitNode labels the given node to admit it to the pending configuration
+// change
+func (cr *canaryRollout) admitNode(ctx context.Context, name string) error {
+	patch := []byte(fmt.Sprintf(`{"metadata":{"labels":{%q:"true"}}}`,
+		operatorOption.Config.CanaryRolloutNodeLabel))
+	_, err := k8s.Client().CoreV1().Nodes().Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
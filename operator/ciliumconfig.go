@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package main
+
+import (
+	"context"
+
+	operatorOption "github.com/cilium/cilium/operator/option"
+	"github.com/cilium/cilium/pkg/controller"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// enableCiliumConfigStatusGC starts the garbage collector which removes
+// per-node application status entries from CiliumConfig resources for nodes
+// which have been removed from the cluster. The agents only ever write the
+// status entry of their own node, so reconciling the set of status entries
+// against the live nodes is the operator's responsibility.
+func enableCiliumConfigStatusGC() {
+	controller.NewManager().UpdateController("ciliumconfig-node-status-gc",
+		controller.ControllerParams{
+			RunInterval: operatorOption.Config.CNPNodeStatusGCInterval,
+			DoFunc:      runCiliumConfigStatusGC,
+		})
+}
+
+func runCiliumConfigStatusGC(ctx context.Context) error {
+	nodes, err := ciliumK8sClient.CiliumV2().CiliumNodes().List(ctx, meta_v1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	liveNodes := make(map[string]struct{}, len(nodes.Items))
+	for _, node := range nodes.Items {
+		liveNodes[node.Name] = struct{}{}
+	}
+
+	ccfgs, err := ciliumK8sClient.CiliumV2().CiliumConfigs().List(ctx, meta_v1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for i := range ccfgs.Items {
+		ccfg := &ccfgs.Items[i]
+		needsUpdate := false
+		for nodeName := range ccfg.Status.Nodes {
+			if _, exists := liveNodes[nodeName]; !exists {
+				delete(ccfg.Status.Nodes, nodeName)
+				needsUpdate = true
+			}
+		}
+		if !needsUpdate {
+			continue
+		}
+		if _, err := ciliumK8sClient.CiliumV2().CiliumConfigs().UpdateStatus(ctx, ccfg, meta_v1.UpdateOptions{}); err != nil {
+			log.WithError(err).WithField("name", ccfg.Name).
+				Warn("Unable to garbage collect CiliumConfig node status")
+		}
+	}
+
+	return nil
+}
@@ -35,6 +35,9 @@ func init() {
 		"Subnets IDs (separated by commas)")
 	option.BindEnv(operatorOption.IPAMSubnetsIDs)
 
+	flags.Int(operatorOption.ExcessIPReleaseDelay, 180, "Number of seconds operator would wait before it releases an IP previously marked as excess")
+	option.BindEnv(operatorOption.ExcessIPReleaseDelay)
+
 	flags.Int64(operatorOption.ParallelAllocWorkers, defaults.ParallelAllocWorkers, "Maximum number of parallel IPAM workers")
 	option.BindEnv(operatorOption.ParallelAllocWorkers)
 
@@ -59,6 +62,15 @@ func init() {
 	flags.Bool(option.K8sEventHandover, defaults.K8sEventHandover, "Enable k8s event handover to kvstore for improved scalability")
 	option.BindEnv(option.K8sEventHandover)
 
+	flags.String(operatorOption.CanaryRolloutNodeSelector, "", "Label selector of the nodes to roll out pending datapath configuration changes to first (empty to disable canary rollouts)")
+	option.BindEnv(operatorOption.CanaryRolloutNodeSelector)
+
+	flags.String(operatorOption.CanaryRolloutNodeLabel, "rollout.cilium.io/admitted", "Label set on nodes admitted to the new configuration during a canary rollout")
+	option.BindEnv(operatorOption.CanaryRolloutNodeLabel)
+
+	flags.Duration(operatorOption.CanaryRolloutObservationPeriod, 10*time.Minute, "Time the canary nodes must remain healthy before the rollout continues to all nodes")
+	option.BindEnv(operatorOption.CanaryRolloutObservationPeriod)
+
 	flags.Duration(operatorOption.CNPNodeStatusGCInterval, 2*time.Minute, "GC interval for nodes which have been removed from the cluster in CiliumNetworkPolicy Status")
 	option.BindEnv(operatorOption.CNPNodeStatusGCInterval)
 
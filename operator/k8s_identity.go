@@ -12,8 +12,11 @@ import (
 	operatorOption "github.com/cilium/cilium/operator/option"
 	"github.com/cilium/cilium/operator/watchers"
 	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/k8s"
+	ciliumio "github.com/cilium/cilium/pkg/k8s/apis/cilium.io"
 	v2 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2"
 	"github.com/cilium/cilium/pkg/k8s/informer"
+	"github.com/cilium/cilium/pkg/labels"
 	"github.com/cilium/cilium/pkg/logging/logfields"
 
 	"github.com/sirupsen/logrus"
@@ -129,6 +132,82 @@ func identityGCIteration(ctx context.Context) {
 	identityHeartbeat.GC()
 }
 
+// identityGCForNamespace deletes identities whose security labels reference
+// the given namespace. It is invoked when a namespace is deleted to reduce
+// the window during which stale identities linger after large namespace
+// churn, rather than waiting for the periodic GC interval.
+func identityGCForNamespace(ctx context.Context, namespace string) {
+	if identityStore == nil {
+		return
+	}
+	select {
+	case <-watchers.CiliumEndpointsSynced:
+	case <-ctx.Done():
+		return
+	}
+
+	namespaceLabel := labels.LabelSourceK8s + ":" + ciliumio.PodNamespaceLabel
+	deletedEntries := 0
+	for _, identityObject := range identityStore.List() {
+		identity, ok := identityObject.(*v2.CiliumIdentity)
+		if !ok {
+			continue
+		}
+		if identity.SecurityLabels[namespaceLabel] != namespace {
+			continue
+		}
+		// Skip identities still in use, the endpoints of the namespace may
+		// not be gone yet. The periodic GC will catch these later.
+		if watchers.HasCEWithIdentity(identity.Name) {
+			continue
+		}
+		if err := deleteIdentity(ctx, identity); err != nil {
+			log.WithError(err).WithFields(logrus.Fields{
+				logfields.Identity: identity,
+			}).Error("Deleting identity of deleted namespace")
+			// If Context was canceled we should break
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		deletedEntries++
+	}
+
+	if deletedEntries > 0 {
+		log.WithField(logfields.K8sNamespace, namespace).
+			Infof("Garbage collected %d identities of deleted namespace", deletedEntries)
+	}
+}
+
+// startNamespaceIdentityGC watches for namespace deletions and triggers a
+// scoped identity GC pass for each deleted namespace.
+func startNamespaceIdentityGC() {
+	_, namespaceInformer := informer.NewInformer(
+		cache.NewListWatchFromClient(k8s.Client().CoreV1().RESTClient(),
+			"namespaces", v1.NamespaceAll, fields.Everything()),
+		&v1.Namespace{},
+		0,
+		cache.ResourceEventHandlerFuncs{
+			DeleteFunc: func(obj interface{}) {
+				namespace, ok := obj.(*v1.Namespace)
+				if !ok {
+					deletedObj, ok := obj.(cache.DeletedFinalStateUnknown)
+					if ok {
+						namespace, ok = deletedObj.Obj.(*v1.Namespace)
+					}
+					if !ok {
+						return
+					}
+				}
+				go identityGCForNamespace(context.TODO(), namespace.Name)
+			},
+		},
+		nil,
+	)
+	go namespaceInformer.Run(wait.NeverStop)
+}
+
 func startCRDIdentityGC() {
 	if operatorOption.Config.EndpointGCInterval == 0 {
 		log.Fatal("The CiliumIdentity garbage collector requires the CiliumEndpoint garbage collector to be enabled")
@@ -144,6 +223,8 @@ func startCRDIdentityGC() {
 				return ctx.Err()
 			},
 		})
+
+	startNamespaceIdentityGC()
 }
 
 func startManagingK8sIdentities() {
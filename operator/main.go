@@ -353,6 +353,14 @@ func onOperatorStartLeading(ctx context.Context) {
 		go enableUnmanagedKubeDNSController()
 	}
 
+	if operatorOption.Config.CanaryRolloutNodeSelector != "" {
+		enableCanaryRolloutController()
+	}
+
+	if operatorOption.Config.CNPNodeStatusGCInterval != 0 {
+		enableCiliumConfigStatusGC()
+	}
+
 	var (
 		nodeManager *allocator.NodeEventHandler
 		err         error
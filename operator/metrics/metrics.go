@@ -11,6 +11,7 @@ import (
 	operatorOption "github.com/cilium/cilium/operator/option"
 	"github.com/cilium/cilium/pkg/logging"
 	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/option"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -37,8 +38,15 @@ func Register() {
 	Registry = prometheus.NewPedanticRegistry()
 	registerMetrics()
 
+	gatherers := prometheus.Gatherers{Registry}
+	if option.Config.BGPAnnounceLBIP {
+		// The MetalLB allocator used for LB IP allocation registers its
+		// per-pool statistics with the default prometheus registry.
+		gatherers = append(gatherers, prometheus.DefaultGatherer)
+	}
+
 	m := http.NewServeMux()
-	m.Handle("/metrics", promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+	m.Handle("/metrics", promhttp.HandlerFor(gatherers, promhttp.HandlerOpts{}))
 	srv := &http.Server{
 		Addr:    operatorOption.Config.OperatorPrometheusServeAddr,
 		Handler: m,
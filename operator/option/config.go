@@ -34,6 +34,19 @@ const (
 	// disabled for the operator
 	SkipCRDCreation = "skip-crd-creation"
 
+	// CanaryRolloutNodeSelector is the label selector of the nodes to roll
+	// out pending datapath configuration changes to first. An empty
+	// selector disables canary rollouts.
+	CanaryRolloutNodeSelector = "canary-rollout-node-selector"
+
+	// CanaryRolloutNodeLabel is the label set on nodes admitted to the new
+	// configuration during a canary rollout
+	CanaryRolloutNodeLabel = "canary-rollout-node-label"
+
+	// CanaryRolloutObservationPeriod is the time the canary nodes must
+	// remain healthy before the rollout continues to all nodes
+	CanaryRolloutObservationPeriod = "canary-rollout-observation-period"
+
 	// CNPNodeStatusGCInterval is the GC interval for nodes which have been
 	// removed from the cluster in CiliumNetworkPolicy and
 	// CiliumClusterwideNetworkPolicy Status.
@@ -107,6 +120,10 @@ const (
 	// IPAMSubnetsTags are optional tags used to filter subnets, and interfaces within those subnets
 	IPAMSubnetsTags = "subnet-tags-filter"
 
+	// ExcessIPReleaseDelay is the number of seconds operator would wait
+	// before an IP previously marked as excess is eligible for release
+	ExcessIPReleaseDelay = "excess-ip-release-delay"
+
 	// ClusterPoolIPv4CIDR is the cluster's IPv4 CIDR to allocate
 	// individual PodCIDR ranges from when using the ClusterPool ipam mode.
 	ClusterPoolIPv4CIDR = "cluster-pool-ipv4-cidr"
@@ -194,6 +211,19 @@ const (
 
 // OperatorConfig is the configuration used by the operator.
 type OperatorConfig struct {
+	// CanaryRolloutNodeSelector is the label selector of the nodes to roll
+	// out pending datapath configuration changes to first. An empty
+	// selector disables canary rollouts.
+	CanaryRolloutNodeSelector string
+
+	// CanaryRolloutNodeLabel is the label set on nodes admitted to the new
+	// configuration during a canary rollout
+	CanaryRolloutNodeLabel string
+
+	// CanaryRolloutObservationPeriod is the time the canary nodes must
+	// remain healthy before the rollout continues to all nodes
+	CanaryRolloutObservationPeriod time.Duration
+
 	// CNPNodeStatusGCInterval is the GC interval for nodes which have been
 	// removed from the cluster in CiliumNetworkPolicy and
 	// CiliumClusterwideNetworkPolicy Status.
@@ -285,6 +315,10 @@ type OperatorConfig struct {
 	// IPAMSubnetsTags are optional tags used to filter subnets, and interfaces within those subnets
 	IPAMSubnetsTags map[string]string
 
+	// ExcessIPReleaseDelay is the number of seconds operator would wait
+	// before an IP previously marked as excess is eligible for release
+	ExcessIPReleaseDelay int
+
 	// IPAM Operator options
 
 	// ClusterPoolIPv4CIDR is the cluster IPv4 podCIDR that should be used to
@@ -361,6 +395,9 @@ type OperatorConfig struct {
 
 // Populate sets all options with the values from viper.
 func (c *OperatorConfig) Populate() {
+	c.CanaryRolloutNodeSelector = viper.GetString(CanaryRolloutNodeSelector)
+	c.CanaryRolloutNodeLabel = viper.GetString(CanaryRolloutNodeLabel)
+	c.CanaryRolloutObservationPeriod = viper.GetDuration(CanaryRolloutObservationPeriod)
 	c.CNPNodeStatusGCInterval = viper.GetDuration(CNPNodeStatusGCInterval)
 	c.CNPStatusUpdateInterval = viper.GetDuration(CNPStatusUpdateInterval)
 	c.EnableMetrics = viper.GetBool(EnableMetrics)
@@ -377,6 +414,7 @@ func (c *OperatorConfig) Populate() {
 	c.SyncK8sServices = viper.GetBool(SyncK8sServices)
 	c.SyncK8sNodes = viper.GetBool(SyncK8sNodes)
 	c.UnmanagedPodWatcherInterval = viper.GetInt(UnmanagedPodWatcherInterval)
+	c.ExcessIPReleaseDelay = viper.GetInt(ExcessIPReleaseDelay)
 	c.NodeCIDRMaskSizeIPv4 = viper.GetInt(NodeCIDRMaskSizeIPv4)
 	c.NodeCIDRMaskSizeIPv6 = viper.GetInt(NodeCIDRMaskSizeIPv6)
 	c.ClusterPoolIPv4CIDR = viper.GetStringSlice(ClusterPoolIPv4CIDR)
@@ -38,6 +38,10 @@ const (
 	// key of the cilium host interface in the node's annotation.
 	CiliumEncryptionKey = Prefix + ".network.encryption-key"
 
+	// FeatureReport is the annotation name used to store the serialized
+	// feature report of the agent in the node's CiliumNode annotations.
+	FeatureReport = Prefix + ".agent.feature-report"
+
 	// GlobalService if set to true, marks a service to become a global
 	// service
 	GlobalService = Prefix + "/global-service"
@@ -49,19 +53,68 @@ const (
 	// sharing local endpoints.
 	SharedService = Prefix + "/shared-service"
 
+	// ServiceLoadBalancerAlgorithm is the annotation selecting the backend
+	// selection algorithm of a service. Valid values are "random", "maglev"
+	// and "latency". It takes effect only when the agent runs with a
+	// Maglev-capable load balancing algorithm ("maglev" or "latency").
+	ServiceLoadBalancerAlgorithm = Prefix + "/lb-algorithm"
+
 	// ProxyVisibility is the annotation name used to indicate whether proxy
 	// visibility should be enabled for a given pod (i.e., all traffic for the
 	// pod is redirected to the proxy for the given port / protocol in the
 	// annotation
 	ProxyVisibility = Prefix + ".proxy-visibility"
 
+	// DNSRateLimit is the annotation name used to override the configured
+	// per-endpoint DNS proxy limits for a given pod. The value is of the
+	// form "qps=<n>,concurrent=<m>", where either part may be omitted and
+	// 0 disables the respective limit.
+	DNSRateLimit = Prefix + ".dns-rate-limit"
+
+	// ProxyOriginalSourceAddr is the annotation on a CiliumNetworkPolicy
+	// or CiliumClusterwideNetworkPolicy selecting how the L7 proxy
+	// handles the source address of connections redirected by the policy.
+	// Valid values are "preserve" and "snat". Defaults to "preserve" when
+	// supported by the datapath.
+	ProxyOriginalSourceAddr = Prefix + ".proxy-original-source-addr"
+
 	// NoTrack is the annotation name used to store the port and protocol
 	// that we should bypass kernel conntrack for a given pod. This applies for
 	// both TCP and UDP connection. Current use case is NodeLocalDNS.
 	NoTrack = Prefix + ".no-track-port"
 
+	// TCPMSSClamp is the annotation name used to store the TCP MSS clamp
+	// that should be applied to traffic of a given pod. The value is the
+	// MSS in bytes, optionally followed by a colon and a comma-separated
+	// list of destination CIDRs the clamp is restricted to, e.g. "1360"
+	// or "1360:10.0.0.0/8,172.16.0.0/12".
+	TCPMSSClamp = Prefix + ".tcp-mss-clamp"
+
 	// WireguardPubKey is the annotation name used to store the Wireguard
 	// public key in the CiliumNode CRD that we need to use to encrypt traffic
 	// to that node.
 	WireguardPubKey = Prefix + ".network.wg-pub-key"
+
+	// LBIPAMSharingKey is the annotation used to mark a LoadBalancer
+	// service as willing to share its LB IP with other services carrying
+	// the same key, provided their ports do not overlap. It is translated
+	// to the MetalLB sharing annotation before LB IP allocation.
+	LBIPAMSharingKey = Prefix + "/lb-ipam-sharing-key"
+
+	// LBIPAMIPPool is the annotation requesting the LB IP of a
+	// LoadBalancer service to be allocated from the named address pool.
+	// It is translated to the MetalLB pool annotation before LB IP
+	// allocation.
+	LBIPAMIPPool = Prefix + "/lb-ipam-ip-pool"
+
+	// LBIPAMRequestedIP is the annotation requesting a specific LB IP for
+	// a LoadBalancer service. It takes effect only when
+	// spec.loadBalancerIP is unset.
+	LBIPAMRequestedIP = Prefix + "/lb-ipam-requested-ip"
+
+	// EncryptionTechnology is the annotation name used to select the
+	// encryption technology applied towards a node while both IPsec and
+	// Wireguard are enabled during an encryption migration. Valid values
+	// are "ipsec" and "wireguard".
+	EncryptionTechnology = Prefix + ".network.encryption"
 )
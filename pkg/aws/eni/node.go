@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"sync"
 
@@ -95,9 +96,44 @@ func (n *Node) PopulateStatusFields(k8sObj *v2.CiliumNode) {
 			return nil
 		})
 
+	n.populatePrefixUtilization(k8sObj)
+	populateValidationConditions(k8sObj)
+
 	return
 }
 
+// populatePrefixUtilization fills in the per-prefix address usage of all
+// prefix-delegated ENIs so that operators can see how fragmented the
+// delegated prefixes are.
+func (n *Node) populatePrefixUtilization(k8sObj *v2.CiliumNode) {
+	k8sObj.Status.ENI.PrefixUtilization = nil
+
+	for _, e := range k8sObj.Status.ENI.ENIs {
+		for _, prefix := range e.Prefixes {
+			_, cidr, err := net.ParseCIDR(prefix)
+			if err != nil {
+				continue
+			}
+
+			ones, bits := cidr.Mask.Size()
+			utilization := eniTypes.ENIPrefixUtilization{
+				TotalIPs: 1 << uint(bits-ones),
+			}
+
+			for address := range k8sObj.Status.IPAM.Used {
+				if ip := net.ParseIP(address); ip != nil && cidr.Contains(ip) {
+					utilization.UsedIPs++
+				}
+			}
+
+			if k8sObj.Status.ENI.PrefixUtilization == nil {
+				k8sObj.Status.ENI.PrefixUtilization = map[string]eniTypes.ENIPrefixUtilization{}
+			}
+			k8sObj.Status.ENI.PrefixUtilization[prefix] = utilization
+		}
+	}
+}
+
 // getLimits returns the interface and IP limits of this node
 func (n *Node) getLimits() (ipamTypes.Limits, bool) {
 	n.mutex.RLock()
@@ -4,6 +4,8 @@
 package types
 
 import (
+	"net"
+
 	"github.com/cilium/cilium/pkg/ipam/types"
 )
 
@@ -168,6 +170,12 @@ type ENI struct {
 	// +optional
 	Addresses []string `json:"addresses,omitempty"`
 
+	// Prefixes is the list of delegated prefixes (IPv4 /28 CIDRs)
+	// associated with the ENI
+	//
+	// +optional
+	Prefixes []string `json:"prefixes,omitempty"`
+
 	// SecurityGroups are the security groups associated with the ENI
 	SecurityGroups []string `json:"security-groups,omitempty"`
 }
@@ -177,6 +185,21 @@ func (e *ENI) InterfaceID() string {
 	return e.ID
 }
 
+// PrefixCapacity returns the total number of addresses covered by the
+// delegated prefixes of the ENI. Invalid prefixes are ignored.
+func (e *ENI) PrefixCapacity() int {
+	capacity := 0
+	for _, prefix := range e.Prefixes {
+		_, cidr, err := net.ParseCIDR(prefix)
+		if err != nil {
+			continue
+		}
+		ones, bits := cidr.Mask.Size()
+		capacity += 1 << uint(bits-ones)
+	}
+	return capacity
+}
+
 // ForeachAddress iterates over all addresses and calls fn
 func (e *ENI) ForeachAddress(id string, fn types.AddressIterator) error {
 	for _, address := range e.Addresses {
@@ -194,6 +217,29 @@ type ENIStatus struct {
 	//
 	// +optional
 	ENIs map[string]ENI `json:"enis,omitempty"`
+
+	// PrefixUtilization describes the address usage of each delegated
+	// prefix indexed by the prefix CIDR. It allows operators to see how
+	// fragmented the delegated prefixes are, i.e. how many prefixes are
+	// pinned by a small number of allocated addresses.
+	//
+	// +optional
+	PrefixUtilization map[string]ENIPrefixUtilization `json:"prefix-utilization,omitempty"`
+}
+
+// ENIPrefixUtilization describes the address usage of a single delegated
+// prefix
+type ENIPrefixUtilization struct {
+	// UsedIPs is the number of addresses of the prefix which are
+	// currently allocated
+	//
+	// +optional
+	UsedIPs int `json:"used-ips,omitempty"`
+
+	// TotalIPs is the total number of addresses covered by the prefix
+	//
+	// +optional
+	TotalIPs int `json:"total-ips,omitempty"`
 }
 
 // AwsSubnet stores information regarding an AWS subnet
@@ -203,6 +249,11 @@ type AwsSubnet struct {
 
 	// CIDR is the CIDR range associated with the subnet
 	CIDR string `json:"cidr,omitempty"`
+
+	// IPv6CIDR is the IPv6 CIDR range associated with the subnet
+	//
+	// +optional
+	IPv6CIDR string `json:"ipv6-cidr,omitempty"`
 }
 
 // AwsVPC stores information regarding an AWS VPC
@@ -215,4 +266,9 @@ type AwsVPC struct {
 
 	// CIDRs is the list of CIDR ranges associated with the VPC
 	CIDRs []string `json:"cidrs,omitempty"`
+
+	// IPv6CIDRs is the list of IPv6 CIDR ranges associated with the VPC
+	//
+	// +optional
+	IPv6CIDRs []string `json:"ipv6-cidrs,omitempty"`
 }
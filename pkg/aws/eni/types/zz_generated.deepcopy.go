@@ -32,6 +32,11 @@ func (in *AwsVPC) DeepCopyInto(out *AwsVPC) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPv6CIDRs != nil {
+		in, out := &in.IPv6CIDRs, &out.IPv6CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -55,6 +60,11 @@ func (in *ENI) DeepCopyInto(out *ENI) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.SecurityGroups != nil {
 		in, out := &in.SecurityGroups, &out.SecurityGroups
 		*out = make([]string, len(*in))
@@ -73,6 +83,22 @@ func (in *ENI) DeepCopy() *ENI {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ENIPrefixUtilization) DeepCopyInto(out *ENIPrefixUtilization) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ENIPrefixUtilization.
+func (in *ENIPrefixUtilization) DeepCopy() *ENIPrefixUtilization {
+	if in == nil {
+		return nil
+	}
+	out := new(ENIPrefixUtilization)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ENISpec) DeepCopyInto(out *ENISpec) {
 	*out = *in
@@ -133,6 +159,13 @@ func (in *ENIStatus) DeepCopyInto(out *ENIStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.PrefixUtilization != nil {
+		in, out := &in.PrefixUtilization, &out.PrefixUtilization
+		*out = make(map[string]ENIPrefixUtilization, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -21,6 +21,9 @@ func (in *AwsSubnet) DeepEqual(other *AwsSubnet) bool {
 	if in.CIDR != other.CIDR {
 		return false
 	}
+	if in.IPv6CIDR != other.IPv6CIDR {
+		return false
+	}
 
 	return true
 }
@@ -55,6 +58,23 @@ func (in *AwsVPC) DeepEqual(other *AwsVPC) bool {
 		}
 	}
 
+	if ((in.IPv6CIDRs != nil) && (other.IPv6CIDRs != nil)) || ((in.IPv6CIDRs == nil) != (other.IPv6CIDRs == nil)) {
+		in, other := &in.IPv6CIDRs, &other.IPv6CIDRs
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
 	return true
 }
 
@@ -108,6 +128,23 @@ func (in *ENI) DeepEqual(other *ENI) bool {
 		}
 	}
 
+	if ((in.Prefixes != nil) && (other.Prefixes != nil)) || ((in.Prefixes == nil) != (other.Prefixes == nil)) {
+		in, other := &in.Prefixes, &other.Prefixes
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
 	if ((in.SecurityGroups != nil) && (other.SecurityGroups != nil)) || ((in.SecurityGroups == nil) != (other.SecurityGroups == nil)) {
 		in, other := &in.SecurityGroups, &other.SecurityGroups
 		if other == nil {
@@ -128,6 +165,23 @@ func (in *ENI) DeepEqual(other *ENI) bool {
 	return true
 }
 
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *ENIPrefixUtilization) DeepEqual(other *ENIPrefixUtilization) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.UsedIPs != other.UsedIPs {
+		return false
+	}
+	if in.TotalIPs != other.TotalIPs {
+		return false
+	}
+
+	return true
+}
+
 // DeepEqual is an autogenerated deepequal function, deeply comparing the
 // receiver with other. in must be non-nil.
 func (in *ENISpec) DeepEqual(other *ENISpec) bool {
@@ -279,5 +333,26 @@ func (in *ENIStatus) DeepEqual(other *ENIStatus) bool {
 		}
 	}
 
+	if ((in.PrefixUtilization != nil) && (other.PrefixUtilization != nil)) || ((in.PrefixUtilization == nil) != (other.PrefixUtilization == nil)) {
+		in, other := &in.PrefixUtilization, &other.PrefixUtilization
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if !inValue.DeepEqual(&otherValue) {
+						return false
+					}
+				}
+			}
+		}
+	}
+
 	return true
 }
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package eni
+
+import (
+	"fmt"
+	"net"
+
+	ipamTypes "github.com/cilium/cilium/pkg/ipam/types"
+	v2 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2"
+)
+
+// Validator validates a single aspect of the ENI configuration of a node.
+// The validators are run whenever the CiliumNode status is synchronized to
+// the apiserver and failing validators are reported in
+// Status.IPAM.ValidationConditions so that operators can see why allocation
+// is stalled.
+type Validator interface {
+	// Name returns the unique name of the validator.
+	Name() string
+
+	// Validate returns an error if the ENI configuration of the node is
+	// inconsistent.
+	Validate(node *v2.CiliumNode) error
+}
+
+// validators is the pipeline of validators which is run against every node.
+var validators = []Validator{
+	&securityGroupValidator{},
+	&subnetMembershipValidator{},
+	&prefixDelegationValidator{},
+}
+
+// eniPrefixLen is the fixed prefix length of delegated IPv4 prefixes on AWS
+const eniPrefixLen = 28
+
+// securityGroupValidator verifies that the ENIs used for IP allocation carry
+// all security groups requested in the ENI specification.
+type securityGroupValidator struct{}
+
+func (v *securityGroupValidator) Name() string {
+	return "security-groups"
+}
+
+func (v *securityGroupValidator) Validate(node *v2.CiliumNode) error {
+	requested := node.Spec.ENI.SecurityGroups
+	if len(requested) == 0 {
+		return nil
+	}
+
+	for id, e := range node.Status.ENI.ENIs {
+		if index := node.Spec.ENI.FirstInterfaceIndex; index != nil && e.Number < *index {
+			continue
+		}
+
+		attached := make(map[string]struct{}, len(e.SecurityGroups))
+		for _, sg := range e.SecurityGroups {
+			attached[sg] = struct{}{}
+		}
+
+		for _, sg := range requested {
+			if _, ok := attached[sg]; !ok {
+				return fmt.Errorf("eni %s is missing security group %s", id, sg)
+			}
+		}
+	}
+
+	return nil
+}
+
+// subnetMembershipValidator verifies that all IPs associated with an ENI are
+// within the CIDR of the subnet the ENI is attached to.
+type subnetMembershipValidator struct{}
+
+func (v *subnetMembershipValidator) Name() string {
+	return "subnet-membership"
+}
+
+func (v *subnetMembershipValidator) Validate(node *v2.CiliumNode) error {
+	for id, e := range node.Status.ENI.ENIs {
+		if e.Subnet.CIDR == "" {
+			continue
+		}
+
+		_, cidr, err := net.ParseCIDR(e.Subnet.CIDR)
+		if err != nil {
+			return fmt.Errorf("eni %s has invalid subnet cidr %q: %w", id, e.Subnet.CIDR, err)
+		}
+
+		for _, address := range e.Addresses {
+			ip := net.ParseIP(address)
+			if ip == nil || !cidr.Contains(ip) {
+				return fmt.Errorf("ip %s on eni %s is not part of subnet %s (%s)", address, id, e.Subnet.ID, e.Subnet.CIDR)
+			}
+		}
+	}
+
+	return nil
+}
+
+// prefixDelegationValidator verifies that delegated prefixes are valid IPv4
+// /28 CIDRs inside the subnet of the ENI and that all IPs in the allocation
+// pool which belong to a prefix-delegated ENI fall inside one of its
+// delegated prefixes.
+type prefixDelegationValidator struct{}
+
+func (v *prefixDelegationValidator) Name() string {
+	return "prefix-delegation"
+}
+
+func (v *prefixDelegationValidator) Validate(node *v2.CiliumNode) error {
+	prefixes := map[string][]*net.IPNet{}
+
+	for id, e := range node.Status.ENI.ENIs {
+		for _, prefix := range e.Prefixes {
+			_, cidr, err := net.ParseCIDR(prefix)
+			if err != nil {
+				return fmt.Errorf("eni %s has invalid delegated prefix %q: %w", id, prefix, err)
+			}
+
+			if ones, bits := cidr.Mask.Size(); bits != 32 || ones != eniPrefixLen {
+				return fmt.Errorf("delegated prefix %s on eni %s is not an IPv4 /%d", prefix, id, eniPrefixLen)
+			}
+
+			if e.Subnet.CIDR != "" {
+				if _, subnet, err := net.ParseCIDR(e.Subnet.CIDR); err == nil && !subnet.Contains(cidr.IP) {
+					return fmt.Errorf("delegated prefix %s on eni %s is not part of subnet %s (%s)", prefix, id, e.Subnet.ID, e.Subnet.CIDR)
+				}
+			}
+
+			prefixes[id] = append(prefixes[id], cidr)
+		}
+	}
+
+	for address, ipInfo := range node.Spec.IPAM.Pool {
+		cidrs, ok := prefixes[ipInfo.Resource]
+		if !ok {
+			continue
+		}
+
+		ip := net.ParseIP(address)
+		covered := false
+		for _, cidr := range cidrs {
+			if ip != nil && cidr.Contains(ip) {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			return fmt.Errorf("pool ip %s is not covered by any delegated prefix of eni %s", address, ipInfo.Resource)
+		}
+	}
+
+	return nil
+}
+
+// populateValidationConditions runs the validator pipeline against the node
+// and fills in the aggregated results of the failing validators.
+func populateValidationConditions(node *v2.CiliumNode) {
+	node.Status.IPAM.ValidationConditions = nil
+	for _, v := range validators {
+		if err := v.Validate(node); err != nil {
+			node.Status.IPAM.ValidationConditions = append(node.Status.IPAM.ValidationConditions,
+				ipamTypes.ValidationCondition{
+					Validator: v.Name(),
+					Error:     err.Error(),
+				})
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package eni
+
+import (
+	"gopkg.in/check.v1"
+
+	eniTypes "github.com/cilium/cilium/pkg/aws/eni/types"
+	ipamTypes "github.com/cilium/cilium/pkg/ipam/types"
+	v2 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2"
+)
+
+func (e *ENISuite) TestSecurityGroupValidator(c *check.C) {
+	v := &securityGroupValidator{}
+
+	node := &v2.CiliumNode{}
+	node.Spec.ENI.SecurityGroups = []string{"sg-1", "sg-2"}
+	node.Status.ENI.ENIs = map[string]eniTypes.ENI{
+		"eni-1": {Number: 1, SecurityGroups: []string{"sg-1", "sg-2"}},
+	}
+
+	// All requested security groups are attached
+	c.Assert(v.Validate(node), check.IsNil)
+
+	// One requested security group is missing
+	node.Status.ENI.ENIs["eni-1"] = eniTypes.ENI{Number: 1, SecurityGroups: []string{"sg-1"}}
+	c.Assert(v.Validate(node), check.NotNil)
+
+	// ENIs below the first interface index are not considered
+	index := 2
+	node.Spec.ENI.FirstInterfaceIndex = &index
+	c.Assert(v.Validate(node), check.IsNil)
+
+	// Without requested security groups anything is valid
+	node.Spec.ENI.SecurityGroups = nil
+	node.Spec.ENI.FirstInterfaceIndex = nil
+	c.Assert(v.Validate(node), check.IsNil)
+}
+
+func (e *ENISuite) TestSubnetMembershipValidator(c *check.C) {
+	v := &subnetMembershipValidator{}
+
+	node := &v2.CiliumNode{}
+	node.Status.ENI.ENIs = map[string]eniTypes.ENI{
+		"eni-1": {
+			Subnet:    eniTypes.AwsSubnet{ID: "subnet-1", CIDR: "10.0.0.0/24"},
+			Addresses: []string{"10.0.0.10", "10.0.0.20"},
+		},
+	}
+
+	// All addresses are within the subnet CIDR
+	c.Assert(v.Validate(node), check.IsNil)
+
+	// One address is outside of the subnet CIDR
+	node.Status.ENI.ENIs["eni-1"] = eniTypes.ENI{
+		Subnet:    eniTypes.AwsSubnet{ID: "subnet-1", CIDR: "10.0.0.0/24"},
+		Addresses: []string{"10.0.1.10"},
+	}
+	c.Assert(v.Validate(node), check.NotNil)
+
+	// An invalid subnet CIDR is reported
+	node.Status.ENI.ENIs["eni-1"] = eniTypes.ENI{
+		Subnet:    eniTypes.AwsSubnet{ID: "subnet-1", CIDR: "invalid"},
+		Addresses: []string{"10.0.0.10"},
+	}
+	c.Assert(v.Validate(node), check.NotNil)
+
+	// ENIs without a subnet CIDR are skipped
+	node.Status.ENI.ENIs["eni-1"] = eniTypes.ENI{
+		Addresses: []string{"10.0.0.10"},
+	}
+	c.Assert(v.Validate(node), check.IsNil)
+}
+
+func (e *ENISuite) TestPrefixDelegationValidator(c *check.C) {
+	v := &prefixDelegationValidator{}
+
+	node := &v2.CiliumNode{}
+	node.Status.ENI.ENIs = map[string]eniTypes.ENI{
+		"eni-1": {
+			Subnet:   eniTypes.AwsSubnet{ID: "subnet-1", CIDR: "10.0.0.0/24"},
+			Prefixes: []string{"10.0.0.16/28"},
+		},
+	}
+	node.Spec.IPAM.Pool = ipamTypes.AllocationMap{
+		"10.0.0.20": ipamTypes.AllocationIP{Resource: "eni-1"},
+	}
+
+	// Pool IP is covered by the delegated prefix
+	c.Assert(v.Validate(node), check.IsNil)
+
+	// Pool IP outside of all delegated prefixes
+	node.Spec.IPAM.Pool["10.0.0.40"] = ipamTypes.AllocationIP{Resource: "eni-1"}
+	c.Assert(v.Validate(node), check.NotNil)
+	delete(node.Spec.IPAM.Pool, "10.0.0.40")
+
+	// Pool IPs of ENIs without delegated prefixes are not restricted
+	node.Spec.IPAM.Pool["10.0.0.40"] = ipamTypes.AllocationIP{Resource: "eni-2"}
+	c.Assert(v.Validate(node), check.IsNil)
+
+	// A delegated prefix must be a /28
+	node.Status.ENI.ENIs["eni-1"] = eniTypes.ENI{
+		Subnet:   eniTypes.AwsSubnet{ID: "subnet-1", CIDR: "10.0.0.0/24"},
+		Prefixes: []string{"10.0.0.0/27"},
+	}
+	c.Assert(v.Validate(node), check.NotNil)
+
+	// A delegated prefix must be inside the subnet CIDR
+	node.Status.ENI.ENIs["eni-1"] = eniTypes.ENI{
+		Subnet:   eniTypes.AwsSubnet{ID: "subnet-1", CIDR: "10.0.0.0/24"},
+		Prefixes: []string{"10.0.1.16/28"},
+	}
+	c.Assert(v.Validate(node), check.NotNil)
+
+	// An invalid prefix is reported
+	node.Status.ENI.ENIs["eni-1"] = eniTypes.ENI{
+		Prefixes: []string{"invalid"},
+	}
+	c.Assert(v.Validate(node), check.NotNil)
+}
@@ -4,10 +4,13 @@
 package bandwidth
 
 import (
+	"fmt"
+
 	"github.com/cilium/cilium/pkg/datapath/linux/probes"
 	"github.com/cilium/cilium/pkg/logging"
 	"github.com/cilium/cilium/pkg/logging/logfields"
 	"github.com/cilium/cilium/pkg/maps/bwmap"
+	"github.com/cilium/cilium/pkg/maps/priometricsmap"
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/sysctl"
 	"github.com/sirupsen/logrus"
@@ -23,6 +26,26 @@ const (
 	EgressBandwidth = "kubernetes.io/egress-bandwidth"
 	// IngressBandwidth is the K8s Pod annotation.
 	IngressBandwidth = "kubernetes.io/ingress-bandwidth"
+
+	// Priority is the Cilium Pod annotation selecting the network priority
+	// class of the Pod's egress traffic.
+	Priority = "io.cilium.network-priority"
+
+	// PriorityLatencyCritical is the priority class of Pods whose packets
+	// must dequeue before batch traffic during uplink saturation.
+	PriorityLatencyCritical = "latency-critical"
+	// PriorityNormal is the default priority class.
+	PriorityNormal = "normal"
+	// PriorityBatch is the priority class of Pods whose packets may be
+	// deferred in favor of the other classes.
+	PriorityBatch = "batch"
+
+	// PriorityBandLatencyCritical and friends are the scheduling bands the
+	// priority classes map to, shared with EDT_PRIO_BAND_* in
+	// bpf/lib/common.h.
+	PriorityBandLatencyCritical uint32 = 0
+	PriorityBandNormal          uint32 = 1
+	PriorityBandBatch           uint32 = 2
 )
 
 var log = logging.DefaultLogger.WithField(logfields.LogSubsys, subsystem)
@@ -35,14 +58,34 @@ func GetBytesPerSec(bandwidth string) (uint64, error) {
 	return uint64(res.Value() / 8), err
 }
 
+// GetPriorityBand maps the value of the network priority annotation to the
+// EDT scheduling band programmed into the datapath. An empty value selects
+// the normal band.
+func GetPriorityBand(priority string) (uint32, error) {
+	switch priority {
+	case PriorityLatencyCritical:
+		return PriorityBandLatencyCritical, nil
+	case PriorityNormal, "":
+		return PriorityBandNormal, nil
+	case PriorityBatch:
+		return PriorityBandBatch, nil
+	}
+	return PriorityBandNormal, fmt.Errorf("unknown network priority class %q", priority)
+}
+
 func ProbeBandwidthManager() {
 	if option.Config.DryMode || !option.Config.EnableBandwidthManager {
+		if option.Config.EnableNetworkPriority && !option.Config.DryMode {
+			log.Warn("Network priority classes require the BPF bandwidth manager. Disabling the feature.")
+			option.Config.EnableNetworkPriority = false
+		}
 		return
 	}
 
 	if _, err := sysctl.Read("net.core.default_qdisc"); err != nil {
 		log.Warn("BPF bandwidth manager could not read procfs. Disabling the feature.")
 		option.Config.EnableBandwidthManager = false
+		option.Config.EnableNetworkPriority = false
 		return
 	}
 
@@ -58,6 +101,7 @@ func ProbeBandwidthManager() {
 	if !kernelGood {
 		log.Warn("BPF bandwidth manager needs kernel 5.1 or newer. Disabling the feature.")
 		option.Config.EnableBandwidthManager = false
+		option.Config.EnableNetworkPriority = false
 		return
 	}
 }
@@ -70,6 +114,7 @@ func InitBandwidthManager() {
 	if len(option.Config.Devices) == 0 {
 		log.Warn("BPF bandwidth manager could not detect host devices. Disabling the feature.")
 		option.Config.EnableBandwidthManager = false
+		option.Config.EnableNetworkPriority = false
 		return
 	}
 
@@ -79,6 +124,12 @@ func InitBandwidthManager() {
 		log.WithError(err).Fatal("Failed to access ThrottleMap")
 	}
 
+	if option.Config.EnableNetworkPriority {
+		if err := priometricsmap.PriorityMetrics.OpenOrCreate(); err != nil {
+			log.WithError(err).Fatal("Failed to access PriorityMetrics map")
+		}
+	}
+
 	type setting struct {
 		name string
 		val  string
@@ -38,6 +38,14 @@ func (s *BGPConfigTestSuite) TestParse(c *C) {
 	c.Assert(config, IsNil)
 }
 
+func (s *BGPConfigTestSuite) TestParseIPv6Pool(c *C) {
+	config, err := Parse(strings.NewReader(yamlIPv6))
+	c.Assert(err, IsNil)
+	c.Assert(config, Not(IsNil))
+	c.Assert(config.Pools["default"], Not(IsNil))
+	c.Assert(config.Pools["v6"], Not(IsNil))
+}
+
 const (
 	yaml = `---
 peers:
@@ -51,4 +59,20 @@ address-pools:
       - 192.168.1.150/29
 `
 	json = `{"peers":[{"peer-address":"172.19.0.5","peer-asn":64512,"my-asn":64512}],"address-pools":[{"name":"default","protocol":"bgp","addresses":["192.168.1.150/29"]}]}`
+
+	yamlIPv6 = `---
+peers:
+  - peer-address: 172.19.0.5
+    peer-asn: 64512
+    my-asn: 64512
+address-pools:
+  - name: default
+    protocol: bgp
+    addresses:
+      - 192.168.1.150/29
+  - name: v6
+    protocol: bgp
+    addresses:
+      - 2001:db8:1::/64
+`
 )
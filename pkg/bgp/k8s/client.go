@@ -7,14 +7,31 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/cilium/cilium/pkg/k8s"
 	"github.com/sirupsen/logrus"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// allocatedConditionType is the condition recorded in the service
+	// status to explain the LB IP allocation decision taken for it.
+	allocatedConditionType = "cilium.io/LoadBalancerIPAllocated"
+
+	// eventIPAllocated is emitted by the MetalLB controller when an LB IP
+	// was assigned to a service.
+	eventIPAllocated = "IPAllocated"
+
+	// eventAllocationFailed is emitted by the MetalLB controller when no
+	// LB IP could be assigned to a service, e.g. due to a sharing key or
+	// port conflict.
+	eventAllocationFailed = "AllocationFailed"
+)
+
 func New(l *logrus.Logger) *Client {
 	return &Client{
 		K8sClient: k8s.Client(),
@@ -39,9 +56,31 @@ func (c *Client) UpdateStatus(svc *v1.Service) error {
 	return err
 }
 
-func (c *Client) Infof(_ *v1.Service, desc, fmt string, args ...interface{}) {
-	c.log.WithField("event", desc).Infof(fmt, args...)
+func (c *Client) Infof(svc *v1.Service, desc, msg string, args ...interface{}) {
+	c.log.WithField("event", desc).Infof(msg, args...)
+	if desc == eventIPAllocated {
+		setAllocatedCondition(svc, metav1.ConditionTrue, desc, fmt.Sprintf(msg, args...))
+	}
 }
-func (c *Client) Errorf(_ *v1.Service, desc, fmt string, args ...interface{}) {
-	c.log.WithField("event", desc).Errorf(fmt, args...)
+func (c *Client) Errorf(svc *v1.Service, desc, msg string, args ...interface{}) {
+	c.log.WithField("event", desc).Errorf(msg, args...)
+	if desc == eventAllocationFailed {
+		setAllocatedCondition(svc, metav1.ConditionFalse, desc, fmt.Sprintf(msg, args...))
+	}
+}
+
+// setAllocatedCondition records the allocation decision as a condition in the
+// status of the service. The condition is persisted by the MetalLB controller
+// together with the rest of the service status once reconciliation completed.
+func setAllocatedCondition(svc *v1.Service, status metav1.ConditionStatus, reason, message string) {
+	if svc == nil {
+		return
+	}
+	meta.SetStatusCondition(&svc.Status.Conditions, metav1.Condition{
+		Type:               allocatedConditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: svc.Generation,
+	})
 }
@@ -4,6 +4,7 @@
 package manager
 
 import (
+	"github.com/cilium/cilium/pkg/annotation"
 	"github.com/cilium/cilium/pkg/k8s"
 	slim_corev1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/api/core/v1"
 	"github.com/cilium/cilium/pkg/logging/logfields"
@@ -133,10 +134,48 @@ func (m *Manager) forceResync() {
 	}
 }
 
+// metallbAnnotations maps the Cilium LB IPAM service annotations to the
+// MetalLB annotations understood by the allocator.
+var metallbAnnotations = map[string]string{
+	annotation.LBIPAMSharingKey: "metallb.universe.tf/allow-shared-ip",
+	annotation.LBIPAMIPPool:     "metallb.universe.tf/address-pool",
+}
+
+// translateAnnotations maps the Cilium LB IPAM annotations to the MetalLB
+// annotations understood by the allocator. Explicitly set MetalLB annotations
+// take precedence and the annotations of the slim object are never modified.
+func translateAnnotations(in map[string]string) map[string]string {
+	var out map[string]string
+	for ciliumKey, metallbKey := range metallbAnnotations {
+		v, ok := in[ciliumKey]
+		if !ok {
+			continue
+		}
+		if _, ok := in[metallbKey]; ok {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]string, len(in)+len(metallbAnnotations))
+			for k, val := range in {
+				out[k] = val
+			}
+		}
+		out[metallbKey] = v
+	}
+	if out == nil {
+		return in
+	}
+	return out
+}
+
 func toV1Service(in *slim_corev1.Service) *v1.Service {
 	if in == nil {
 		return nil
 	}
+	loadBalancerIP := in.Spec.LoadBalancerIP
+	if loadBalancerIP == "" {
+		loadBalancerIP = in.ObjectMeta.Annotations[annotation.LBIPAMRequestedIP]
+	}
 	return &v1.Service{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       in.TypeMeta.Kind,
@@ -148,7 +187,7 @@ func toV1Service(in *slim_corev1.Service) *v1.Service {
 			ResourceVersion: in.ObjectMeta.ResourceVersion,
 			UID:             in.ObjectMeta.UID,
 			Labels:          in.ObjectMeta.Labels,
-			Annotations:     in.ObjectMeta.Annotations,
+			Annotations:     translateAnnotations(in.ObjectMeta.Annotations),
 		},
 		Spec: v1.ServiceSpec{
 			Ports:                 k8s.ConvertToK8sV1ServicePorts(in.Spec.Ports),
@@ -157,7 +196,7 @@ func toV1Service(in *slim_corev1.Service) *v1.Service {
 			Type:                  v1.ServiceType(in.Spec.Type),
 			ExternalIPs:           in.Spec.ExternalIPs,
 			SessionAffinity:       v1.ServiceAffinity(in.Spec.SessionAffinity),
-			LoadBalancerIP:        in.Spec.LoadBalancerIP,
+			LoadBalancerIP:        loadBalancerIP,
 			ExternalTrafficPolicy: v1.ServiceExternalTrafficPolicyType(in.Spec.ExternalTrafficPolicy),
 			HealthCheckNodePort:   in.Spec.HealthCheckNodePort,
 			SessionAffinityConfig: k8s.ConvertToK8sV1ServiceAffinityConfig(in.Spec.SessionAffinityConfig),
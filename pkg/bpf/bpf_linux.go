@@ -670,6 +670,45 @@ func TestDummyProg(progType ProgType, attachType uint32) error {
 	return errno
 }
 
+type bpfAttrProgQuery struct {
+	TargetFd    uint32
+	AttachType  uint32
+	QueryFlags  uint32
+	AttachFlags uint32
+	ProgIds     uint64
+	ProgCnt     uint32
+}
+
+// ProgQueryCgroup returns the IDs of the BPF programs attached with the
+// given attach type to the cgroup at the given path.
+func ProgQueryCgroup(path string, attachType uint32) ([]uint32, error) {
+	fd, err := unix.Open(path, unix.O_DIRECTORY|unix.O_RDONLY, 0)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open cgroup %s: %w", path, err)
+	}
+	defer unix.Close(fd)
+
+	progIds := make([]uint32, 64)
+	bpfAttr := bpfAttrProgQuery{
+		TargetFd:   uint32(fd),
+		AttachType: attachType,
+		ProgIds:    uint64(uintptr(unsafe.Pointer(&progIds[0]))),
+		ProgCnt:    uint32(len(progIds)),
+	}
+
+	ret, _, errno := unix.Syscall(unix.SYS_BPF, BPF_PROG_QUERY,
+		uintptr(unsafe.Pointer(&bpfAttr)),
+		unsafe.Sizeof(bpfAttr))
+	runtime.KeepAlive(&progIds)
+	runtime.KeepAlive(&bpfAttr)
+
+	if int(ret) < 0 || errno != 0 {
+		return nil, fmt.Errorf("unable to query cgroup %s: %w", path, errno)
+	}
+
+	return progIds[:bpfAttr.ProgCnt], nil
+}
+
 type BpfMapInfo struct {
 	Type       uint32
 	Id         uint32
@@ -693,6 +693,34 @@ func (m *Map) DumpWithCallback(cb DumpCallback) error {
 	}
 }
 
+// DumpFilter decides whether a dumped map entry is passed on to the dump
+// callback.
+type DumpFilter func(key MapKey, value MapValue) bool
+
+// PaginatedCallback wraps cb so that only the entries accepted by filter
+// within the window described by offset and limit are passed on. A nil filter
+// accepts all entries, a limit of 0 disables the limit. This allows dumps of
+// large maps to be consumed in pages without accumulating the full map
+// content in memory.
+func PaginatedCallback(filter DumpFilter, offset, limit int, cb DumpCallback) DumpCallback {
+	matched := 0
+	return func(key MapKey, value MapValue) {
+		if filter != nil && !filter(key, value) {
+			return
+		}
+
+		matched++
+		if matched <= offset {
+			return
+		}
+		if limit > 0 && matched > offset+limit {
+			return
+		}
+
+		cb(key, value)
+	}
+}
+
 // DumpWithCallbackIfExists is similar to DumpWithCallback, but returns earlier
 // if the given map does not exist.
 func (m *Map) DumpWithCallbackIfExists(cb DumpCallback) error {
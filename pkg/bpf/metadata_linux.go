@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build linux
+// +build linux
+
+package bpf
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"unsafe"
+)
+
+// ObjectMetadataSchemaVersion is the version of the ObjectMetadata schema.
+// It is incremented whenever the layout or the semantics of the fields
+// change, so that external tooling consuming the metadata can detect
+// incompatible changes.
+const ObjectMetadataSchemaVersion = 1
+
+// ObjectMetadata describes a single BPF object pinned in the BPF filesystem
+// in a machine-readable form, so that external backup and forensics tooling
+// can snapshot the datapath state without hardcoding pin paths or map
+// layouts per Cilium version.
+type ObjectMetadata struct {
+	// Kind is "map" for BPF maps and "prog" for BPF programs.
+	Kind string `json:"kind"`
+
+	// Name is the base name of the pinned object.
+	Name string `json:"name"`
+
+	// Path is the absolute pin path of the object.
+	Path string `json:"path"`
+
+	// Type is the map or program type name.
+	Type string `json:"type,omitempty"`
+
+	// KeySize is the key size of the map in bytes. Zero for programs.
+	KeySize uint32 `json:"key-size,omitempty"`
+
+	// ValueSize is the value size of the map in bytes. Zero for programs.
+	ValueSize uint32 `json:"value-size,omitempty"`
+
+	// MaxEntries is the maximum number of entries of the map. Zero for
+	// programs.
+	MaxEntries uint32 `json:"max-entries,omitempty"`
+
+	// Flags are the flags the map was created with. Zero for programs.
+	Flags uint32 `json:"flags,omitempty"`
+
+	// Entries is the number of entries currently in the map, or -1 when
+	// the entries could not be counted.
+	Entries int64 `json:"entries"`
+
+	// KeySchema is the Go type describing the map key, when the map is
+	// registered with the agent. The schema types change only with the
+	// SchemaVersion.
+	KeySchema string `json:"key-schema,omitempty"`
+
+	// ValueSchema is the Go type describing the map value, when the map
+	// is registered with the agent.
+	ValueSchema string `json:"value-schema,omitempty"`
+
+	// SchemaVersion is the ObjectMetadata schema version.
+	SchemaVersion int `json:"schema-version"`
+}
+
+// PinnedObjectsMetadata walks the BPF filesystem and returns the metadata of
+// all pinned maps and programs. Objects which cannot be opened are skipped.
+func PinnedObjectsMetadata() ([]ObjectMetadata, error) {
+	var objects []ObjectMetadata
+
+	err := filepath.Walk(GetMapRoot(), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		fd, err := ObjGet(path)
+		if err != nil {
+			log.WithError(err).WithField("path", path).
+				Debug("Skipping unopenable pinned BPF object")
+			return nil
+		}
+		defer ObjClose(fd)
+
+		objects = append(objects, objectMetadata(fd, path))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to walk the BPF filesystem: %w", err)
+	}
+
+	return objects, nil
+}
+
+// objectMetadata returns the metadata of the pinned object behind the given
+// file descriptor.
+func objectMetadata(fd int, path string) ObjectMetadata {
+	md := ObjectMetadata{
+		Name:          filepath.Base(path),
+		Path:          path,
+		Entries:       -1,
+		SchemaVersion: ObjectMetadataSchemaVersion,
+	}
+
+	// The fdinfo of maps and programs exposes the object type, which the
+	// BPF_OBJ_GET_INFO_BY_FD command does not distinguish.
+	mapType, progType := objectTypeFromFdinfo(fd)
+	if mapType == MapTypeUnspec {
+		md.Kind = "prog"
+		md.Type = progType.String()
+		return md
+	}
+
+	md.Kind = "map"
+	md.Type = mapType.String()
+	if info, err := GetMapInfoByFd(uint32(fd)); err == nil {
+		md.KeySize = info.KeySize
+		md.ValueSize = info.ValueSize
+		md.MaxEntries = info.MaxEntries
+		md.Flags = info.MapFlags
+		md.Entries = countMapEntries(fd, info.KeySize)
+	}
+	if m := GetMap(path); m != nil {
+		md.KeySchema = fmt.Sprintf("%T", m.MapKey)
+		md.ValueSchema = fmt.Sprintf("%T", m.MapValue)
+	}
+	return md
+}
+
+// objectTypeFromFdinfo reads the map or program type of the object behind
+// the given file descriptor from its fdinfo. MapTypeUnspec is returned for
+// programs.
+func objectTypeFromFdinfo(fd int) (MapType, ProgType) {
+	file, err := os.Open(fmt.Sprintf("/proc/self/fdinfo/%d", fd))
+	if err != nil {
+		return MapTypeUnspec, ProgTypeUnspec
+	}
+	defer file.Close()
+
+	mapType := MapTypeUnspec
+	progType := ProgTypeUnspec
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var value int
+
+		line := scanner.Text()
+		if n, err := fmt.Sscanf(line, "map_type:\t%d", &value); n == 1 && err == nil {
+			mapType = MapType(value)
+		} else if n, err := fmt.Sscanf(line, "prog_type:\t%d", &value); n == 1 && err == nil {
+			progType = ProgType(value)
+		}
+	}
+	return mapType, progType
+}
+
+// countMapEntries counts the entries of the map behind the given file
+// descriptor by iterating its keys. Returns -1 when the keys cannot be
+// iterated.
+func countMapEntries(fd int, keySize uint32) int64 {
+	if keySize == 0 {
+		return -1
+	}
+
+	key := make([]byte, keySize)
+	nextKey := make([]byte, keySize)
+
+	var entries int64
+	err := GetFirstKey(fd, unsafe.Pointer(&nextKey[0]))
+	for err == nil {
+		entries++
+		copy(key, nextKey)
+		err = GetNextKey(fd, unsafe.Pointer(&key[0]), unsafe.Pointer(&nextKey[0]))
+	}
+	if err != io.EOF {
+		return -1
+	}
+	return entries
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build linux
+// +build linux
+
+package bpf
+
+import (
+	"os"
+
+	"github.com/cilium/cilium/api/v1/models"
+)
+
+// MigrationAction describes how a map pinned by a previous agent version
+// will be handled by the currently running agent version.
+type MigrationAction string
+
+const (
+	// MigrationActionReuse indicates that the pinned map matches the
+	// properties expected by this agent version and is reused as-is.
+	MigrationActionReuse MigrationAction = "reuse"
+
+	// MigrationActionMigrate indicates that the pinned map is recreated
+	// with new properties and its contents are repopulated from agent
+	// state after recreation.
+	MigrationActionMigrate MigrationAction = "migrate"
+
+	// MigrationActionRecreate indicates that the pinned map is removed
+	// and recreated, losing the entries it contains.
+	MigrationActionRecreate MigrationAction = "recreate"
+)
+
+// planMigration compares the map pinned at m.path against the properties
+// this agent version expects and returns the resulting migration plan
+// entry. Returns nil if no map is pinned from a previous run.
+func planMigration(m *Map) *models.BPFMapMigration {
+	if err := m.setPathIfUnset(); err != nil {
+		return nil
+	}
+
+	fd, err := ObjGet(m.path)
+	if err != nil {
+		// Nothing was pinned by a previous agent run, the map is
+		// created from scratch and there is nothing to migrate.
+		return nil
+	}
+	defer ObjClose(fd)
+
+	pinned, err := GetMapInfo(os.Getpid(), fd)
+	if err != nil {
+		return nil
+	}
+
+	plan := &models.BPFMapMigration{
+		Name: m.name,
+	}
+
+	desiredType := GetMapType(m.MapType)
+	desiredFlags := m.Flags | GetPreAllocateMapFlags(desiredType)
+
+	switch {
+	case pinned.MapType != desiredType:
+		plan.Action = string(MigrationActionRecreate)
+		plan.Reason = "map type changed"
+		plan.Disruption = "entries are lost until the datapath repopulates the map"
+	case pinned.KeySize != m.KeySize || pinned.ValueSize != m.ValueSize:
+		plan.Action = string(MigrationActionRecreate)
+		plan.Reason = "key or value layout changed"
+		plan.Disruption = "entries are lost until the datapath repopulates the map"
+	case pinned.MaxEntries != m.MaxEntries || pinned.Flags != desiredFlags:
+		plan.Action = string(MigrationActionMigrate)
+		plan.Reason = "map size or flags changed"
+		plan.Disruption = "brief, entries are restored from agent state after recreation"
+	default:
+		plan.Action = string(MigrationActionReuse)
+		plan.Reason = "pinned map matches expected properties"
+		plan.Disruption = "none"
+	}
+
+	return plan
+}
+
+// GetMigrationPlan inspects the pinned state left behind by a previously
+// running agent version for each of the given maps and returns the
+// resulting migration plan. Maps without pinned state are not part of the
+// plan. This must be called *before* the maps are opened as opening a map
+// resolves any property mismatch by removing the pinned map.
+func GetMigrationPlan(maps []*Map) []*models.BPFMapMigration {
+	plans := []*models.BPFMapMigration{}
+
+	for _, m := range maps {
+		if plan := planMigration(m); plan != nil {
+			plans = append(plans, plan)
+		}
+	}
+
+	return plans
+}
@@ -4,11 +4,18 @@
 package cgroups
 
 import (
+	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/bpf"
+	"github.com/cilium/cilium/pkg/logging/logfields"
 	"github.com/cilium/cilium/pkg/mountinfo"
 
+	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
 )
 
@@ -54,3 +61,119 @@ func cgrpCheckOrMountLocation(cgroupRoot string) error {
 
 	return nil
 }
+
+// sockLBAttachTypes are the cgroup attach types used by the socket-LB
+// datapath programs.
+var sockLBAttachTypes = []struct {
+	name       string
+	attachType uint32
+}{
+	{"connect4", bpf.BPF_CGROUP_INET4_CONNECT},
+	{"connect6", bpf.BPF_CGROUP_INET6_CONNECT},
+	{"post_bind4", bpf.BPF_CGROUP_INET4_POST_BIND},
+	{"post_bind6", bpf.BPF_CGROUP_INET6_POST_BIND},
+	{"sendmsg4", bpf.BPF_CGROUP_UDP4_SENDMSG},
+	{"sendmsg6", bpf.BPF_CGROUP_UDP6_SENDMSG},
+	{"recvmsg4", bpf.BPF_CGROUP_UDP4_RECVMSG},
+	{"recvmsg6", bpf.BPF_CGROUP_UDP6_RECVMSG},
+	{"getpeername4", bpf.BPF_CGROUP_INET4_GETPEERNAME},
+	{"getpeername6", bpf.BPF_CGROUP_INET6_GETPEERNAME},
+}
+
+// GetOwnCgroupPath returns the cgroup2 path of the calling process relative
+// to the root of its cgroup namespace.
+func GetOwnCgroupPath() (string, error) {
+	f, err := os.Open("/proc/self/cgroup")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// The cgroup2 hierarchy has ID 0 and no controller list,
+		// i.e. "0::/path".
+		if path := strings.TrimPrefix(scanner.Text(), "0::"); path != scanner.Text() {
+			return path, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("no cgroup2 entry found in /proc/self/cgroup")
+}
+
+// ScanSockLBAttachments walks the mounted cgroup2 hierarchy and returns the
+// socket-LB program attachment points found. In nested hierarchies (e.g.
+// kind or containers running their own agent), this includes programs
+// attached below the hierarchy root by other agents.
+func ScanSockLBAttachments() ([]*models.SocketLBAttachment, error) {
+	attachments := []*models.SocketLBAttachment{}
+
+	err := filepath.Walk(cgroupRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// Cgroups can disappear while we walk the hierarchy.
+			return nil
+		}
+		if !info.IsDir() {
+			return nil
+		}
+
+		for _, at := range sockLBAttachTypes {
+			progIds, err := bpf.ProgQueryCgroup(path, at.attachType)
+			if err != nil || len(progIds) == 0 {
+				continue
+			}
+
+			ids := make([]int64, 0, len(progIds))
+			for _, id := range progIds {
+				ids = append(ids, int64(id))
+			}
+
+			attachments = append(attachments, &models.SocketLBAttachment{
+				CgroupPath: path,
+				AttachType: at.name,
+				ProgIds:    ids,
+			})
+		}
+
+		return nil
+	})
+
+	return attachments, err
+}
+
+// VerifySockLBAttachments validates the socket-LB attachments in the cgroup
+// hierarchy after the datapath has been loaded. It warns if the agent's own
+// cgroup is not covered by the mounted hierarchy or if programs of another
+// agent are attached at a nested cgroup, as those take precedence over the
+// attachments of this agent for the workloads in the nested subtree.
+func VerifySockLBAttachments() {
+	if path, err := GetOwnCgroupPath(); err != nil {
+		log.WithError(err).Debug("Unable to determine own cgroup2 path")
+	} else if path != "/" {
+		if _, err := os.Stat(filepath.Join(cgroupRoot, path)); err != nil {
+			log.WithField(logfields.Path, cgroupRoot).
+				Warn("Own cgroup is not visible under the mounted cgroup2 hierarchy. " +
+					"The mount likely belongs to an outer cgroup namespace and socket-based LB may not cover all local workloads.")
+		}
+	}
+
+	attachments, err := ScanSockLBAttachments()
+	if err != nil {
+		log.WithError(err).Warn("Unable to scan cgroup2 hierarchy for socket-based LB attachments")
+		return
+	}
+
+	for _, a := range attachments {
+		if a.CgroupPath == cgroupRoot {
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			logfields.Path: a.CgroupPath,
+			"attachType":   a.AttachType,
+		}).Warn("Conflicting socket-based LB program attached at a nested cgroup, likely by another agent. " +
+			"It takes precedence for the workloads running in that subtree.")
+	}
+}
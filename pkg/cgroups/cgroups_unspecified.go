@@ -6,7 +6,11 @@
 
 package cgroups
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/cilium/cilium/api/v1/models"
+)
 
 var ErrNotImplemented = errors.New("not implemented")
 
@@ -17,3 +21,14 @@ func mountCgroup() error {
 func cgrpCheckOrMountLocation(cgroupRoot string) error {
 	return ErrNotImplemented
 }
+
+func GetOwnCgroupPath() (string, error) {
+	return "", ErrNotImplemented
+}
+
+func ScanSockLBAttachments() ([]*models.SocketLBAttachment, error) {
+	return nil, ErrNotImplemented
+}
+
+func VerifySockLBAttachments() {
+}
@@ -482,12 +482,21 @@ func FormatStatusResponse(w io.Writer, sr *models.StatusResponse, sd StatusDetai
 		fmt.Fprintf(w, "Proxy Status:\tOK, ip %s, %d redirects active on ports %s\n",
 			sr.Proxy.IP, sr.Proxy.TotalRedirects, sr.Proxy.PortRange)
 		if sd.AllRedirects && sr.Proxy.TotalRedirects > 0 {
+			stats := make(map[string]*models.ProxyRedirectStatistics, len(sr.Proxy.RedirectStatistics))
+			for _, s := range sr.Proxy.RedirectStatistics {
+				stats[s.Name] = s
+			}
 			out := make([]string, 0, len(sr.Proxy.Redirects)+1)
 			for _, r := range sr.Proxy.Redirects {
-				out = append(out, fmt.Sprintf("  %s\t%s\t%d\n", r.Proxy, r.Name, r.ProxyPort))
+				line := fmt.Sprintf("  %s\t%s\t%d", r.Proxy, r.Name, r.ProxyPort)
+				if s, ok := stats[r.Name]; ok {
+					line += fmt.Sprintf("\t%d\t%d\t%d\t%d",
+						s.ProcessedRequests, s.DeniedRequests, s.UpstreamErrors, s.ActiveConnections)
+				}
+				out = append(out, line+"\n")
 			}
 			tab := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
-			fmt.Fprint(tab, "  Protocol\tRedirect\tProxy Port\n")
+			fmt.Fprint(tab, "  Protocol\tRedirect\tProxy Port\tRequests\tDenied\tErrors\tConnections\n")
 			sort.Strings(out)
 			for _, s := range out {
 				fmt.Fprint(tab, s)
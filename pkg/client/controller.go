@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package client
+
+import (
+	"github.com/cilium/cilium/api/v1/client/daemon"
+	"github.com/cilium/cilium/pkg/api"
+)
+
+// ControllerTrigger triggers an immediate run of the controller with the
+// given name and resets its error back-off.
+func (c *Client) ControllerTrigger(name string) error {
+	params := daemon.NewPutControllerNameTriggerParams().WithName(name).WithTimeout(api.ClientTimeout)
+	_, err := c.Daemon.PutControllerNameTrigger(params)
+	return Hint(err)
+}
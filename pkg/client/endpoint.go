@@ -20,6 +20,30 @@ func (c *Client) EndpointList() ([]*models.Endpoint, error) {
 	return resp.Payload, nil
 }
 
+// EndpointListByLabels returns the list of endpoints having all of the given
+// labels. The filtering is done by the agent, so that callers do not need to
+// retrieve all endpoints of large nodes.
+func (c *Client) EndpointListByLabels(lbls models.Labels) ([]*models.Endpoint, error) {
+	params := endpoint.NewGetEndpointParams().WithLabels(lbls).WithTimeout(api.ClientTimeout)
+	resp, err := c.Endpoint.GetEndpoint(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
+
+// EndpointGroups returns aggregate counts of endpoints grouped by the values
+// they carry for the given label keys, optionally restricted to endpoints
+// having all of the given labels.
+func (c *Client) EndpointGroups(groupBy []string, lbls models.Labels) ([]*models.EndpointGroup, error) {
+	params := endpoint.NewGetEndpointGroupsParams().WithGroupBy(groupBy).WithLabels(lbls).WithTimeout(api.ClientTimeout)
+	resp, err := c.Endpoint.GetEndpointGroups(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
+
 // EndpointGet returns endpoint by ID
 func (c *Client) EndpointGet(id string) (*models.Endpoint, error) {
 	params := endpoint.NewGetEndpointIDParams().WithID(id).WithTimeout(api.ClientTimeout)
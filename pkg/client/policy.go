@@ -19,6 +19,26 @@ func (c *Client) PolicyPut(policyJSON string) (*models.Policy, error) {
 	return resp.Payload, nil
 }
 
+// PolicyShadowPut evaluates the `policyJSON` in shadow against the enforced
+// policy without enforcing it. The Policy field of the result carries the
+// per-endpoint divergence report instead of the policy tree.
+func (c *Client) PolicyShadowPut(policyJSON string) (*models.Policy, error) {
+	shadow := true
+	params := policy.NewPutPolicyParams().WithPolicy(policyJSON).WithShadow(&shadow).WithTimeout(api.ClientTimeout)
+	resp, err := c.Policy.PutPolicy(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
+
+// PolicyFlowOverridePut installs a temporary allow entry for a specific flow
+func (c *Client) PolicyFlowOverridePut(override *models.FlowOverride) error {
+	params := policy.NewPutPolicyFlowOverrideParams().WithFlowOverride(override).WithTimeout(api.ClientTimeout)
+	_, err := c.Policy.PutPolicyFlowOverride(params)
+	return Hint(err)
+}
+
 // PolicyGet returns policy rules
 func (c *Client) PolicyGet(labels []string) (*models.Policy, error) {
 	params := policy.NewGetPolicyParams().WithLabels(labels).WithTimeout(api.ClientTimeout)
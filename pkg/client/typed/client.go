@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+// Package typed provides a typed client for the agent API on top of the
+// generated OpenAPI bindings, for building node-local automation against
+// the agent. All operations take a context, transient failures are retried
+// with exponential backoff, and watch helpers turn the polled agent state
+// into event streams.
+package typed
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/backoff"
+	"github.com/cilium/cilium/pkg/client"
+)
+
+const (
+	// defaultMaxRetries is the number of attempts per operation unless
+	// overridden with WithMaxRetries.
+	defaultMaxRetries = 3
+
+	// defaultWatchInterval is the polling interval of the watch helpers
+	// unless overridden with WithWatchInterval.
+	defaultWatchInterval = 5 * time.Second
+
+	// retryMin and retryMax bound the exponential backoff between
+	// attempts of a single operation.
+	retryMin = 100 * time.Millisecond
+	retryMax = 5 * time.Second
+)
+
+// API is the subset of the agent API client wrapped by this package. It is
+// implemented by *client.Client.
+type API interface {
+	EndpointList() ([]*models.Endpoint, error)
+	EndpointGet(id string) (*models.Endpoint, error)
+	EndpointCreate(ep *models.EndpointChangeRequest) error
+	EndpointDelete(id string) error
+	PolicyGet(labels []string) (*models.Policy, error)
+	PolicyPut(policyJSON string) (*models.Policy, error)
+	PolicyDelete(labels []string) (*models.Policy, error)
+	GetServices() ([]*models.Service, error)
+	GetServiceID(id int64) (*models.Service, error)
+	PutServiceID(id int64, svc *models.ServiceSpec) (bool, error)
+	DeleteServiceID(id int64) error
+	IPAMAllocate(family, owner string, expiration bool) (*models.IPAMResponse, error)
+	IPAMAllocateIP(ip, owner string) error
+	IPAMReleaseIP(ip string) error
+}
+
+// Client wraps the agent API with context support, retries and watch
+// helpers.
+type Client struct {
+	api           API
+	maxRetries    int
+	watchInterval time.Duration
+}
+
+// Option customizes the behavior of the typed client.
+type Option func(*Client)
+
+// WithMaxRetries sets the number of attempts per operation.
+func WithMaxRetries(maxRetries int) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+	}
+}
+
+// WithWatchInterval sets the polling interval of the watch helpers.
+func WithWatchInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.watchInterval = interval
+	}
+}
+
+// NewClient returns a typed client connected to the local agent via the
+// default UNIX domain socket.
+func NewClient(opts ...Option) (*Client, error) {
+	inner, err := client.NewDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return NewClientFor(inner, opts...), nil
+}
+
+// NewClientFor returns a typed client wrapping the given agent API client.
+func NewClientFor(api API, opts ...Option) *Client {
+	c := &Client{
+		api:           api,
+		maxRetries:    defaultMaxRetries,
+		watchInterval: defaultWatchInterval,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// retry invokes op up to maxRetries times, waiting with exponential backoff
+// between attempts. Only transient failures are retried, API errors such as
+// a missing endpoint are returned immediately.
+func (c *Client) retry(ctx context.Context, op func() error) error {
+	bo := backoff.Exponential{
+		Min:    retryMin,
+		Max:    retryMax,
+		Jitter: true,
+	}
+
+	var err error
+	for attempt := 0; attempt < c.maxRetries; attempt++ {
+		if attempt > 0 {
+			if werr := bo.Wait(ctx); werr != nil {
+				return werr
+			}
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		if err = op(); err == nil || !isTransient(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// isTransient reports whether the error is worth retrying. Typed API errors
+// carry a response from the agent and are terminal, only failures to reach
+// the agent are transient.
+func isTransient(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
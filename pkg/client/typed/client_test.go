@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package typed
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/lock"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	check.TestingT(t)
+}
+
+type TypedClientSuite struct{}
+
+var _ = check.Suite(&TypedClientSuite{})
+
+// fakeAPI implements the API interface against in-memory state.
+type fakeAPI struct {
+	mutex lock.Mutex
+
+	endpoints []*models.Endpoint
+	policy    *models.Policy
+
+	// errs is consumed one error per call by the endpoint operations,
+	// simulating failures to reach the agent.
+	errs  []error
+	calls int
+}
+
+func (f *fakeAPI) EndpointList() ([]*models.Endpoint, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.calls++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	return f.endpoints, nil
+}
+
+func (f *fakeAPI) setEndpoints(eps []*models.Endpoint) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.endpoints = eps
+}
+
+func (f *fakeAPI) EndpointGet(id string) (*models.Endpoint, error)       { return nil, nil }
+func (f *fakeAPI) EndpointCreate(ep *models.EndpointChangeRequest) error { return nil }
+func (f *fakeAPI) EndpointDelete(id string) error                        { return nil }
+func (f *fakeAPI) PolicyPut(policyJSON string) (*models.Policy, error)   { return nil, nil }
+func (f *fakeAPI) PolicyDelete(labels []string) (*models.Policy, error)  { return nil, nil }
+func (f *fakeAPI) GetServices() ([]*models.Service, error)               { return nil, nil }
+func (f *fakeAPI) GetServiceID(id int64) (*models.Service, error)        { return nil, nil }
+func (f *fakeAPI) DeleteServiceID(id int64) error                        { return nil }
+func (f *fakeAPI) IPAMAllocateIP(ip, owner string) error                 { return nil }
+func (f *fakeAPI) IPAMReleaseIP(ip string) error                         { return nil }
+
+func (f *fakeAPI) PolicyGet(labels []string) (*models.Policy, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.policy, nil
+}
+
+func (f *fakeAPI) PutServiceID(id int64, svc *models.ServiceSpec) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeAPI) IPAMAllocate(family, owner string, expiration bool) (*models.IPAMResponse, error) {
+	return nil, nil
+}
+
+func endpoint(id int64, state models.EndpointState) *models.Endpoint {
+	return &models.Endpoint{
+		ID:     id,
+		Status: &models.EndpointStatus{State: state},
+	}
+}
+
+func (s *TypedClientSuite) TestRetryTransient(c *check.C) {
+	transient := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+
+	// Transient failures are retried until the operation succeeds.
+	api := &fakeAPI{
+		errs:      []error{transient, transient},
+		endpoints: []*models.Endpoint{endpoint(1, models.EndpointStateReady)},
+	}
+	client := NewClientFor(api, WithMaxRetries(3))
+	eps, err := client.EndpointList(context.Background())
+	c.Assert(err, check.IsNil)
+	c.Assert(eps, check.HasLen, 1)
+	c.Assert(api.calls, check.Equals, 3)
+
+	// Retries are bounded.
+	api = &fakeAPI{errs: []error{transient, transient, transient}}
+	client = NewClientFor(api, WithMaxRetries(2))
+	_, err = client.EndpointList(context.Background())
+	c.Assert(err, check.Equals, error(transient))
+	c.Assert(api.calls, check.Equals, 2)
+
+	// Terminal errors are returned immediately.
+	terminal := errors.New("endpoint not found")
+	api = &fakeAPI{errs: []error{terminal}}
+	client = NewClientFor(api, WithMaxRetries(3))
+	_, err = client.EndpointList(context.Background())
+	c.Assert(err, check.Equals, terminal)
+	c.Assert(api.calls, check.Equals, 1)
+}
+
+func (s *TypedClientSuite) TestWatchEndpoints(c *check.C) {
+	api := &fakeAPI{
+		endpoints: []*models.Endpoint{endpoint(1, models.EndpointStateWaitingForIdentity)},
+	}
+	client := NewClientFor(api, WithWatchInterval(time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := client.WatchEndpoints(ctx)
+
+	event := <-events
+	c.Assert(event.Type, check.Equals, EventAdded)
+	c.Assert(event.Endpoint.ID, check.Equals, int64(1))
+
+	api.setEndpoints([]*models.Endpoint{
+		endpoint(1, models.EndpointStateReady),
+		endpoint(2, models.EndpointStateReady),
+	})
+	for i := 0; i < 2; i++ {
+		event = <-events
+		switch event.Endpoint.ID {
+		case 1:
+			c.Assert(event.Type, check.Equals, EventModified)
+		case 2:
+			c.Assert(event.Type, check.Equals, EventAdded)
+		}
+	}
+
+	api.setEndpoints(nil)
+	event = <-events
+	c.Assert(event.Type, check.Equals, EventDeleted)
+
+	cancel()
+	for range events {
+	}
+}
+
+func (s *TypedClientSuite) TestWatchPolicy(c *check.C) {
+	api := &fakeAPI{policy: &models.Policy{Revision: 1}}
+	client := NewClientFor(api, WithWatchInterval(time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := client.WatchPolicy(ctx)
+
+	event := <-events
+	c.Assert(event.Policy.Revision, check.Equals, int64(1))
+
+	// No event until the revision advances.
+	api.mutex.Lock()
+	api.policy = &models.Policy{Revision: 2}
+	api.mutex.Unlock()
+	event = <-events
+	c.Assert(event.Policy.Revision, check.Equals, int64(2))
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package typed
+
+import (
+	"context"
+
+	"github.com/cilium/cilium/api/v1/models"
+)
+
+// EndpointList returns all local endpoints.
+func (c *Client) EndpointList(ctx context.Context) ([]*models.Endpoint, error) {
+	var eps []*models.Endpoint
+	err := c.retry(ctx, func() error {
+		var err error
+		eps, err = c.api.EndpointList()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return eps, nil
+}
+
+// EndpointGet returns the endpoint with the given ID.
+func (c *Client) EndpointGet(ctx context.Context, id string) (*models.Endpoint, error) {
+	var ep *models.Endpoint
+	err := c.retry(ctx, func() error {
+		var err error
+		ep, err = c.api.EndpointGet(id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ep, nil
+}
+
+// EndpointCreate creates a new endpoint.
+func (c *Client) EndpointCreate(ctx context.Context, ep *models.EndpointChangeRequest) error {
+	return c.retry(ctx, func() error {
+		return c.api.EndpointCreate(ep)
+	})
+}
+
+// EndpointDelete deletes the endpoint with the given ID.
+func (c *Client) EndpointDelete(ctx context.Context, id string) error {
+	return c.retry(ctx, func() error {
+		return c.api.EndpointDelete(id)
+	})
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package typed
+
+import (
+	"context"
+
+	"github.com/cilium/cilium/api/v1/models"
+)
+
+// IPAMAllocate allocates an IP address out of the address family specific
+// pool.
+func (c *Client) IPAMAllocate(ctx context.Context, family, owner string, expiration bool) (*models.IPAMResponse, error) {
+	var resp *models.IPAMResponse
+	err := c.retry(ctx, func() error {
+		var err error
+		resp, err = c.api.IPAMAllocate(family, owner, expiration)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// IPAMAllocateIP tries to allocate a particular IP address.
+func (c *Client) IPAMAllocateIP(ctx context.Context, ip, owner string) error {
+	return c.retry(ctx, func() error {
+		return c.api.IPAMAllocateIP(ip, owner)
+	})
+}
+
+// IPAMReleaseIP releases an allocated IP address.
+func (c *Client) IPAMReleaseIP(ctx context.Context, ip string) error {
+	return c.retry(ctx, func() error {
+		return c.api.IPAMReleaseIP(ip)
+	})
+}
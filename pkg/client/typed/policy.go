@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package typed
+
+import (
+	"context"
+
+	"github.com/cilium/cilium/api/v1/models"
+)
+
+// PolicyGet returns the policy rules matching the given labels, all rules
+// if no labels are given.
+func (c *Client) PolicyGet(ctx context.Context, labels []string) (*models.Policy, error) {
+	var policy *models.Policy
+	err := c.retry(ctx, func() error {
+		var err error
+		policy, err = c.api.PolicyGet(labels)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// PolicyPut inserts the given policy rules.
+func (c *Client) PolicyPut(ctx context.Context, policyJSON string) (*models.Policy, error) {
+	var policy *models.Policy
+	err := c.retry(ctx, func() error {
+		var err error
+		policy, err = c.api.PolicyPut(policyJSON)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// PolicyDelete deletes the policy rules matching the given labels.
+func (c *Client) PolicyDelete(ctx context.Context, labels []string) (*models.Policy, error) {
+	var policy *models.Policy
+	err := c.retry(ctx, func() error {
+		var err error
+		policy, err = c.api.PolicyDelete(labels)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package typed
+
+import (
+	"context"
+
+	"github.com/cilium/cilium/api/v1/models"
+)
+
+// ServiceList returns all services.
+func (c *Client) ServiceList(ctx context.Context) ([]*models.Service, error) {
+	var svcs []*models.Service
+	err := c.retry(ctx, func() error {
+		var err error
+		svcs, err = c.api.GetServices()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return svcs, nil
+}
+
+// ServiceGet returns the service with the given ID.
+func (c *Client) ServiceGet(ctx context.Context, id int64) (*models.Service, error) {
+	var svc *models.Service
+	err := c.retry(ctx, func() error {
+		var err error
+		svc, err = c.api.GetServiceID(id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return svc, nil
+}
+
+// ServicePut creates or updates a service. Returns true if the service was
+// created.
+func (c *Client) ServicePut(ctx context.Context, id int64, svc *models.ServiceSpec) (bool, error) {
+	var created bool
+	err := c.retry(ctx, func() error {
+		var err error
+		created, err = c.api.PutServiceID(id, svc)
+		return err
+	})
+	return created, err
+}
+
+// ServiceDelete deletes the service with the given ID.
+func (c *Client) ServiceDelete(ctx context.Context, id int64) error {
+	return c.retry(ctx, func() error {
+		return c.api.DeleteServiceID(id)
+	})
+}
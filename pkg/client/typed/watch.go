@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package typed
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/cilium/cilium/api/v1/models"
+)
+
+// EventType describes how the watched object changed between two polls.
+type EventType string
+
+const (
+	EventAdded    EventType = "added"
+	EventModified EventType = "modified"
+	EventDeleted  EventType = "deleted"
+)
+
+// EndpointEvent is a change to a local endpoint.
+type EndpointEvent struct {
+	Type     EventType
+	Endpoint *models.Endpoint
+}
+
+// ServiceEvent is a change to a service.
+type ServiceEvent struct {
+	Type    EventType
+	Service *models.Service
+}
+
+// PolicyEvent is a new revision of the policy repository.
+type PolicyEvent struct {
+	Policy *models.Policy
+}
+
+// WatchEndpoints polls the endpoint list and streams the changes between
+// polls. Endpoints are considered modified when their state changes. The
+// channel is closed when the context is cancelled.
+func (c *Client) WatchEndpoints(ctx context.Context) <-chan EndpointEvent {
+	events := make(chan EndpointEvent)
+	go func() {
+		defer close(events)
+		known := map[int64]*models.Endpoint{}
+		c.poll(ctx, func() {
+			eps, err := c.EndpointList(ctx)
+			if err != nil {
+				return
+			}
+			current := map[int64]*models.Endpoint{}
+			for _, ep := range eps {
+				current[ep.ID] = ep
+			}
+			for id, ep := range current {
+				prev, ok := known[id]
+				switch {
+				case !ok:
+					select {
+					case events <- EndpointEvent{EventAdded, ep}:
+					case <-ctx.Done():
+						return
+					}
+				case endpointState(prev) != endpointState(ep):
+					select {
+					case events <- EndpointEvent{EventModified, ep}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			for id, ep := range known {
+				if _, ok := current[id]; !ok {
+					select {
+					case events <- EndpointEvent{EventDeleted, ep}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			known = current
+		})
+	}()
+	return events
+}
+
+// WatchServices polls the service list and streams the changes between
+// polls. Services are considered modified when their spec changes. The
+// channel is closed when the context is cancelled.
+func (c *Client) WatchServices(ctx context.Context) <-chan ServiceEvent {
+	events := make(chan ServiceEvent)
+	go func() {
+		defer close(events)
+		known := map[int64]*models.Service{}
+		c.poll(ctx, func() {
+			svcs, err := c.ServiceList(ctx)
+			if err != nil {
+				return
+			}
+			current := map[int64]*models.Service{}
+			for _, svc := range svcs {
+				if svc.Spec == nil {
+					continue
+				}
+				current[svc.Spec.ID] = svc
+			}
+			for id, svc := range current {
+				prev, ok := known[id]
+				switch {
+				case !ok:
+					select {
+					case events <- ServiceEvent{EventAdded, svc}:
+					case <-ctx.Done():
+						return
+					}
+				case !reflect.DeepEqual(prev.Spec, svc.Spec):
+					select {
+					case events <- ServiceEvent{EventModified, svc}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			for id, svc := range known {
+				if _, ok := current[id]; !ok {
+					select {
+					case events <- ServiceEvent{EventDeleted, svc}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			known = current
+		})
+	}()
+	return events
+}
+
+// WatchPolicy polls the policy repository and streams an event whenever the
+// policy revision advances. The channel is closed when the context is
+// cancelled.
+func (c *Client) WatchPolicy(ctx context.Context) <-chan PolicyEvent {
+	events := make(chan PolicyEvent)
+	go func() {
+		defer close(events)
+		var revision int64 = -1
+		c.poll(ctx, func() {
+			policy, err := c.PolicyGet(ctx, nil)
+			if err != nil || policy == nil {
+				return
+			}
+			if policy.Revision <= revision {
+				return
+			}
+			revision = policy.Revision
+			select {
+			case events <- PolicyEvent{policy}:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return events
+}
+
+// poll runs cycle immediately and then on every watch interval tick until
+// the context is cancelled.
+func (c *Client) poll(ctx context.Context, cycle func()) {
+	ticker := time.NewTicker(c.watchInterval)
+	defer ticker.Stop()
+	for {
+		cycle()
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// endpointState returns the state of the endpoint, tolerating a partially
+// populated status.
+func endpointState(ep *models.Endpoint) models.EndpointState {
+	if ep.Status == nil {
+		return ""
+	}
+	return ep.Status.State
+}
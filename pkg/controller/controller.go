@@ -282,6 +282,9 @@ func (c *Controller) runController() {
 
 		case <-runTimer.After(interval):
 		case <-c.trigger:
+			// A manual trigger restarts the error back-off from
+			// scratch.
+			errorRetries = 1
 			runFunc = true
 		}
 
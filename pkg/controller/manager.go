@@ -39,6 +39,33 @@ func GetGlobalStatus() models.ControllerStatuses {
 	return globalStatus.GetStatusModel()
 }
 
+// TriggerGlobalController triggers the controller with the given name,
+// regardless of which manager it is registered with, causing it to run
+// immediately and resetting any error back-off. An error is returned if no
+// controller with the name exists.
+func TriggerGlobalController(name string) error {
+	// Create a copy of pointers to matching controllers so we can unlock
+	// the global mutex quickly again
+	ctrls := []*Controller{}
+	globalStatus.mutex.RLock()
+	for _, c := range globalStatus.controllers {
+		if c.name == name {
+			ctrls = append(ctrls, c)
+		}
+	}
+	globalStatus.mutex.RUnlock()
+
+	if len(ctrls) == 0 {
+		return fmt.Errorf("unable to find controller %s", name)
+	}
+
+	for _, c := range ctrls {
+		c.Trigger()
+	}
+
+	return nil
+}
+
 // UpdateController installs or updates a controller in the manager. A
 // controller is identified by its name. If a controller with the name already
 // exists, the controller will be shut down and replaced with the provided
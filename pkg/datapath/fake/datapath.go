@@ -73,6 +73,14 @@ func (m *fakeDatapath) GetProxyPort(name string) uint16 {
 	return 0
 }
 
+func (m *fakeDatapath) RemoveStaleProxyRules(map[uint16]struct{}) int {
+	return 0
+}
+
+func (m *fakeDatapath) GetProxyRuleCounters() map[uint16]uint64 {
+	return nil
+}
+
 func (f *fakeDatapath) Loader() datapath.Loader {
 	return f.loader
 }
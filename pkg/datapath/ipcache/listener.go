@@ -13,6 +13,7 @@ import (
 
 	"github.com/cilium/cilium/pkg/bpf"
 	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/eventjournal"
 	"github.com/cilium/cilium/pkg/ipcache"
 	"github.com/cilium/cilium/pkg/logging"
 	"github.com/cilium/cilium/pkg/logging/logfields"
@@ -207,6 +208,9 @@ func handleMapShuffleFailure(src, dst string) {
 	backupPath := bpf.MapPath(src)
 	realizedPath := bpf.MapPath(dst)
 
+	eventjournal.Record(eventjournal.ReasonIPCacheRollback,
+		"Restoring ipcache map %s after failed replacement", dst)
+
 	if err := os.Rename(backupPath, realizedPath); err != nil {
 		log.WithError(err).WithFields(logrus.Fields{
 			logfields.BPFMapPath: realizedPath,
@@ -778,6 +778,115 @@ func (m *IptablesManager) addProxyRules(prog iptablesInterface, proxyPort uint16
 	return nil
 }
 
+var tproxyOnPortRegexp = regexp.MustCompile(`TPROXY --on-port ([1-9][0-9]*) `)
+
+// doRemoveStaleProxyRules deletes any Cilium TPROXY rules in the
+// CILIUM_PRE_mangle chain redirecting to a proxy port not listed in
+// 'validPorts'. Returns the number of rules removed.
+func (m *IptablesManager) doRemoveStaleProxyRules(prog iptablesInterface, validPorts map[uint16]struct{}) int {
+	output, err := prog.runProgCombinedOutput(append(m.waitArgs, "-t", "mangle", "-S", ciliumPreMangleChain), true)
+	if err != nil {
+		log.WithError(err).Warnf("Unable to list %s TPROXY rules: %s", prog, string(output))
+		return 0
+	}
+	removed := 0
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		rule := scanner.Text()
+		if !strings.Contains(rule, "-A "+ciliumPreMangleChain+" ") || !strings.Contains(rule, "cilium: TPROXY to host ") {
+			continue
+		}
+		matches := tproxyOnPortRegexp.FindStringSubmatch(rule)
+		if matches == nil {
+			continue
+		}
+		port, err := strconv.ParseUint(matches[1], 10, 16)
+		if err != nil {
+			log.WithError(err).WithField(logfields.Object, rule).Warnf("Unable to parse %s TPROXY rule port", prog)
+			continue
+		}
+		if _, valid := validPorts[uint16(port)]; valid {
+			continue
+		}
+		args, err := shellwords.Parse(strings.Replace(rule, "-A", "-D", 1))
+		if err != nil {
+			log.WithError(err).WithField(logfields.Object, rule).Warnf("Unable to parse %s TPROXY rule", prog)
+			continue
+		}
+		deleteRule := append(append(m.waitArgs, "-t", "mangle"), args...)
+		log.WithField(logfields.Object, logfields.Repr(rule)).Infof("Removing orphaned %s TPROXY rule from mangle table", prog)
+		if err := prog.runProg(deleteRule, true); err != nil {
+			log.WithError(err).WithField(logfields.Object, rule).Warnf("Unable to delete %s TPROXY rule", prog)
+			continue
+		}
+		removed++
+	}
+	return removed
+}
+
+// RemoveStaleProxyRules deletes any Cilium-owned TPROXY rules not redirecting
+// to one of the proxy ports in 'validPorts'. Returns the number of rules
+// removed.
+func (m *IptablesManager) RemoveStaleProxyRules(validPorts map[uint16]struct{}) int {
+	removed := 0
+	if option.Config.EnableIPv4 {
+		removed += m.doRemoveStaleProxyRules(ip4tables, validPorts)
+	}
+	if option.Config.EnableIPv6 {
+		removed += m.doRemoveStaleProxyRules(ip6tables, validPorts)
+	}
+	return removed
+}
+
+var tproxyRuleCountersRegexp = regexp.MustCompile(`-c ([0-9]+) [0-9]+$`)
+
+// doGetProxyRuleCounters adds the packet counters of the Cilium TPROXY rules
+// in the CILIUM_PRE_mangle chain to 'counters', keyed by the proxy port the
+// rules redirect to. Ports with multiple rules, e.g. for both TCP and UDP,
+// have their counters summed.
+func (m *IptablesManager) doGetProxyRuleCounters(prog iptablesInterface, counters map[uint16]uint64) {
+	output, err := prog.runProgCombinedOutput(append(m.waitArgs, "-t", "mangle", "-v", "-S", ciliumPreMangleChain), true)
+	if err != nil {
+		log.WithError(err).Warnf("Unable to list %s TPROXY rules: %s", prog, string(output))
+		return
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		rule := scanner.Text()
+		if !strings.Contains(rule, "-A "+ciliumPreMangleChain+" ") || !strings.Contains(rule, "cilium: TPROXY to host ") {
+			continue
+		}
+		matches := tproxyOnPortRegexp.FindStringSubmatch(rule)
+		if matches == nil {
+			continue
+		}
+		port, err := strconv.ParseUint(matches[1], 10, 16)
+		if err != nil {
+			log.WithError(err).WithField(logfields.Object, rule).Warnf("Unable to parse %s TPROXY rule port", prog)
+			continue
+		}
+		packets := uint64(0)
+		if c := tproxyRuleCountersRegexp.FindStringSubmatch(rule); c != nil {
+			packets, _ = strconv.ParseUint(c[1], 10, 64)
+		}
+		counters[uint16(port)] += packets
+	}
+}
+
+// GetProxyRuleCounters returns the packet counters of the Cilium-owned TPROXY
+// rules, keyed by the proxy port they redirect to. The presence of a port in
+// the result indicates that at least one datapath rule for it is installed.
+func (m *IptablesManager) GetProxyRuleCounters() map[uint16]uint64 {
+	counters := make(map[uint16]uint64)
+	if option.Config.EnableIPv4 {
+		m.doGetProxyRuleCounters(ip4tables, counters)
+	}
+	if option.Config.EnableIPv6 {
+		m.doGetProxyRuleCounters(ip6tables, counters)
+	}
+	return counters
+}
+
 // install or remove rules for a single proxy port
 func (m *IptablesManager) iptProxyRules(proxyPort uint16, ingress bool, name string) (err error) {
 	if option.Config.EnableIPv4 {
@@ -889,6 +998,79 @@ func RemoveNoTrackRules(IP string, port uint16, ipv6 bool) error {
 	return nil
 }
 
+func endpointMSSClampRules(prog iptablesInterface, cmd string, IP string, mss string, cidr string) error {
+	egressArgs := []string{"-t", "mangle", cmd, ciliumPostMangleChain, "-s", IP}
+	ingressArgs := []string{"-t", "mangle", cmd, ciliumPreMangleChain, "-d", IP}
+	if cidr != "" {
+		egressArgs = append(egressArgs, "-d", cidr)
+		ingressArgs = append(ingressArgs, "-s", cidr)
+	}
+	clampArgs := []string{"-p", "tcp", "--tcp-flags", "SYN,RST", "SYN", "-j", "TCPMSS", "--set-mss", mss}
+	if _, err := prog.runProgCombinedOutput(append(egressArgs, clampArgs...), false); err != nil {
+		return err
+	}
+	if _, err := prog.runProgCombinedOutput(append(ingressArgs, clampArgs...), false); err != nil {
+		return err
+	}
+	return nil
+}
+
+// mssClampCIDRs returns the destination CIDRs to restrict the MSS clamp
+// rules of an endpoint to. An empty entry matches all destinations.
+func mssClampCIDRs(cidrs []string) []string {
+	if len(cidrs) == 0 {
+		return []string{""}
+	}
+	return cidrs
+}
+
+// InstallMSSClampRules is explicitly called when a pod has a valid
+// "io.cilium.tcp-mss-clamp" annotation. It clamps the MSS of TCP SYN packets
+// exchanged between the pod and the given destination CIDRs, or all
+// destinations if no CIDR is given.
+func InstallMSSClampRules(IP string, mss uint16, cidrs []string, ipv6 bool) error {
+	prog := ip4tables
+	ipField := logfields.IPv4
+	if ipv6 {
+		prog = ip6tables
+		ipField = logfields.IPv6
+	}
+	m := strconv.FormatUint(uint64(mss), 10)
+	for _, cidr := range mssClampCIDRs(cidrs) {
+		if err := endpointMSSClampRules(prog, "-A", IP, m, cidr); err != nil {
+			log.WithFields(logrus.Fields{
+				ipField:        IP,
+				logfields.CIDR: cidr,
+				"mss":          m,
+			}).WithError(err).Warn("Unable to install TCP MSS clamp rules")
+			return err
+		}
+	}
+	return nil
+}
+
+// See comments for InstallMSSClampRules.
+func RemoveMSSClampRules(IP string, mss uint16, cidrs []string, ipv6 bool) error {
+	prog := ip4tables
+	ipField := logfields.IPv4
+	if ipv6 {
+		prog = ip6tables
+		ipField = logfields.IPv6
+	}
+	m := strconv.FormatUint(uint64(mss), 10)
+	for _, cidr := range mssClampCIDRs(cidrs) {
+		if err := endpointMSSClampRules(prog, "-D", IP, m, cidr); err != nil {
+			log.WithFields(logrus.Fields{
+				ipField:        IP,
+				logfields.CIDR: cidr,
+				"mss":          m,
+			}).WithError(err).Warn("Unable to remove TCP MSS clamp rules")
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *IptablesManager) InstallProxyRules(proxyPort uint16, ingress bool, name string) error {
 	if m.haveBPFSocketAssign {
 		log.WithField("port", proxyPort).
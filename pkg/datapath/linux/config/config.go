@@ -31,11 +31,14 @@ import (
 	"github.com/cilium/cilium/pkg/maglev"
 	"github.com/cilium/cilium/pkg/maps/bwmap"
 	"github.com/cilium/cilium/pkg/maps/callsmap"
+	"github.com/cilium/cilium/pkg/maps/clustermetricsmap"
 	"github.com/cilium/cilium/pkg/maps/ctmap"
 	"github.com/cilium/cilium/pkg/maps/egressmap"
 	"github.com/cilium/cilium/pkg/maps/encrypt"
 	"github.com/cilium/cilium/pkg/maps/eppolicymap"
 	"github.com/cilium/cilium/pkg/maps/eventsmap"
+	"github.com/cilium/cilium/pkg/maps/faultinject"
+	"github.com/cilium/cilium/pkg/maps/fqdnmap"
 	"github.com/cilium/cilium/pkg/maps/fragmap"
 	ipcachemap "github.com/cilium/cilium/pkg/maps/ipcache"
 	"github.com/cilium/cilium/pkg/maps/ipmasq"
@@ -45,9 +48,11 @@ import (
 	"github.com/cilium/cilium/pkg/maps/nat"
 	"github.com/cilium/cilium/pkg/maps/neighborsmap"
 	"github.com/cilium/cilium/pkg/maps/policymap"
+	"github.com/cilium/cilium/pkg/maps/priometricsmap"
 	"github.com/cilium/cilium/pkg/maps/recorder"
 	"github.com/cilium/cilium/pkg/maps/signalmap"
 	"github.com/cilium/cilium/pkg/maps/sockmap"
+	"github.com/cilium/cilium/pkg/maps/synflood"
 	"github.com/cilium/cilium/pkg/maps/tunnel"
 	"github.com/cilium/cilium/pkg/netns"
 	"github.com/cilium/cilium/pkg/node"
@@ -141,6 +146,8 @@ func (h *HeaderfileWriter) WriteNodeConfig(w io.Writer, cfg *datapath.LocalNodeC
 	cDefinesMap["ENDPOINTS_MAP_SIZE"] = fmt.Sprintf("%d", lxcmap.MaxEntries)
 	cDefinesMap["METRICS_MAP"] = metricsmap.MapName
 	cDefinesMap["METRICS_MAP_SIZE"] = fmt.Sprintf("%d", metricsmap.MaxEntries)
+	cDefinesMap["CLUSTER_METRICS_MAP"] = clustermetricsmap.MapName
+	cDefinesMap["CLUSTER_METRICS_MAP_SIZE"] = fmt.Sprintf("%d", clustermetricsmap.MaxEntries)
 	cDefinesMap["POLICY_MAP_SIZE"] = fmt.Sprintf("%d", policymap.MaxEntries)
 	cDefinesMap["IPCACHE_MAP"] = ipcachemap.Name
 	cDefinesMap["IPCACHE_MAP_SIZE"] = fmt.Sprintf("%d", ipcachemap.MaxEntries)
@@ -232,6 +239,14 @@ func (h *HeaderfileWriter) WriteNodeConfig(w io.Writer, cfg *datapath.LocalNodeC
 		cDefinesMap["ENABLE_EGRESS_GATEWAY"] = "1"
 	}
 
+	// Traffic exchanged with remote clusters is only distinguishable from
+	// local traffic when the local cluster has a cluster ID assigned,
+	// i.e. when it is part of a ClusterMesh.
+	if option.Config.ClusterID != 0 {
+		cDefinesMap["CLUSTER_ID"] = fmt.Sprintf("%d", option.Config.ClusterID)
+		cDefinesMap["ENABLE_CLUSTER_METRICS"] = "1"
+	}
+
 	if option.Config.EnableHostReachableServices {
 		if option.Config.EnableHostServicesTCP {
 			cDefinesMap["ENABLE_HOST_SERVICES_TCP"] = "1"
@@ -410,7 +425,8 @@ func (h *HeaderfileWriter) WriteNodeConfig(w io.Writer, cfg *datapath.LocalNodeC
 	cDefinesMap["LB_SELECTION_MAGLEV"] = fmt.Sprintf("%d", selectionMaglev)
 	if option.Config.NodePortAlg == option.NodePortAlgRandom {
 		cDefinesMap["LB_SELECTION"] = fmt.Sprintf("%d", selectionRandom)
-	} else if option.Config.NodePortAlg == option.NodePortAlgMaglev {
+	} else if option.Config.NodePortAlg == option.NodePortAlgMaglev ||
+		option.Config.NodePortAlg == option.NodePortAlgLatency {
 		cDefinesMap["LB_SELECTION"] = fmt.Sprintf("%d", selectionMaglev)
 		cDefinesMap["LB_MAGLEV_LUT_SIZE"] = fmt.Sprintf("%d", option.Config.MaglevTableSize)
 		if option.Config.EnableIPv6 {
@@ -471,6 +487,38 @@ func (h *HeaderfileWriter) WriteNodeConfig(w io.Writer, cfg *datapath.LocalNodeC
 		cDefinesMap["ENABLE_BANDWIDTH_MANAGER"] = "1"
 		cDefinesMap["THROTTLE_MAP"] = bwmap.MapName
 		cDefinesMap["THROTTLE_MAP_SIZE"] = fmt.Sprintf("%d", bwmap.MapSize)
+
+		if option.Config.EnableNetworkPriority {
+			cDefinesMap["ENABLE_NETWORK_PRIORITY"] = "1"
+			cDefinesMap["PRIO_METRICS_MAP"] = priometricsmap.MapName
+		}
+	}
+
+	if option.Config.EnableFaultInjection {
+		cDefinesMap["ENABLE_FAULT_INJECTION"] = "1"
+		cDefinesMap["FAULT_INJECTION_MAP"] = faultinject.MapName
+		cDefinesMap["FAULT_INJECTION_MAP_SIZE"] = fmt.Sprintf("%d", faultinject.MaxEntries)
+	}
+
+	if option.Config.EnableRelatedICMPErrors {
+		cDefinesMap["ENABLE_RELATED_ICMP"] = "1"
+	}
+
+	if option.Config.EnableSynFloodProtection {
+		cDefinesMap["ENABLE_SYN_FLOOD_PROTECTION"] = "1"
+		cDefinesMap["SYNFLOOD_MAP"] = synflood.MapName
+		cDefinesMap["SYNFLOOD_MAP_SIZE"] = fmt.Sprintf("%d", synflood.MaxEntries)
+	}
+
+	if option.Config.ToFQDNsEnableDatapathValidation {
+		cDefinesMap["ENABLE_DNS_ANSWER_VALIDATION"] = "1"
+		cDefinesMap["FQDN_ALLOW_MAP4"] = fqdnmap.Map4Name
+		cDefinesMap["FQDN_ALLOW_MAP6"] = fqdnmap.Map6Name
+		cDefinesMap["FQDN_ALLOW_MAP_SIZE"] = fmt.Sprintf("%d", fqdnmap.MaxEntries)
+	}
+
+	if option.Config.EnableTunneledProtoDetection {
+		cDefinesMap["ENABLE_TUNNELED_PROTO_DETECTION"] = "1"
 	}
 
 	if option.Config.EnableHostFirewall {
@@ -535,6 +583,15 @@ func (h *HeaderfileWriter) WriteNodeConfig(w io.Writer, cfg *datapath.LocalNodeC
 		cDefinesMap["ENABLE_IDENTITY_MARK"] = "1"
 	}
 
+	switch option.Config.IdentityEncodingMode {
+	case option.IdentityEncodingModeDSCP:
+		cDefinesMap["ENABLE_IDENTITY_ENCODING"] = "1"
+		cDefinesMap["IDENTITY_ENCODING_DSCP_BITS"] = fmt.Sprintf("%d", option.Config.IdentityEncodingDSCPBits)
+	case option.IdentityEncodingModeFlowLabel:
+		cDefinesMap["ENABLE_IDENTITY_ENCODING"] = "1"
+		cDefinesMap["IDENTITY_ENCODING_FLOWLABEL"] = "1"
+	}
+
 	if option.Config.EnableCustomCalls {
 		cDefinesMap["ENABLE_CUSTOM_CALLS"] = "1"
 	}
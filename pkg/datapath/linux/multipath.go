@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package linux
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/cilium/cilium/pkg/cidr"
+	"github.com/cilium/cilium/pkg/controller"
+	healthDefaults "github.com/cilium/cilium/pkg/health/defaults"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// multipathProbeInterval is the interval between reachability probe
+	// rounds over the configured uplink devices.
+	multipathProbeInterval = 15 * time.Second
+
+	// multipathProbeTimeout is the timeout of a single reachability probe.
+	multipathProbeTimeout = 2 * time.Second
+
+	// multipathScoreDecay is the weight of the previous reachability score
+	// when merging in the result of a new probe round. A single failed
+	// probe keeps a previously healthy uplink within the switch margin,
+	// two consecutive failures move the routes away.
+	multipathScoreDecay = 0.8
+
+	// multipathSwitchMargin is the score distance from the best uplink
+	// within which an uplink is still considered healthy. It provides the
+	// hysteresis preventing routes from flapping between uplinks on
+	// sporadic probe failures.
+	multipathSwitchMargin = 0.2
+)
+
+// multipathDestination is a remote allocation CIDR whose direct route is
+// steered to the best performing uplink.
+type multipathDestination struct {
+	cidr   *cidr.CIDR
+	nodeIP net.IP
+	// scores is the reachability score of the node IP per uplink device,
+	// between 0 (unreachable) and 1 (all recent probes succeeded).
+	scores map[string]float64
+	// activeDevice is the uplink the direct route is currently steered
+	// to, empty as long as the kernel's own route selection is in use.
+	activeDevice string
+}
+
+// multipathManager probes the reachability of remote nodes over each of the
+// configured uplink devices and steers the direct node routes to the best
+// performing uplink. The first configured uplink is preferred, so that
+// routes automatically fail back once it recovers from a failure.
+type multipathManager struct {
+	mutex        lock.RWMutex
+	devices      []string
+	destinations map[string]*multipathDestination
+}
+
+// newMultipathManager returns a multipath manager probing the reachability of
+// the steered destinations over 'devices' and starts the periodic prober.
+func newMultipathManager(devices []string) *multipathManager {
+	m := &multipathManager{
+		devices:      devices,
+		destinations: map[string]*multipathDestination{},
+	}
+	controller.NewManager().UpdateController("multipath-uplink-prober",
+		controller.ControllerParams{
+			DoFunc: func(ctx context.Context) error {
+				m.probeRound()
+				return nil
+			},
+			RunInterval: multipathProbeInterval,
+		})
+	return m
+}
+
+// UpsertDestination starts steering the direct route of the given allocation
+// CIDR based on the reachability of the owning node IP.
+func (m *multipathManager) UpsertDestination(c *cidr.CIDR, nodeIP net.IP) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if dest, ok := m.destinations[c.String()]; ok && dest.nodeIP.Equal(nodeIP) {
+		return
+	}
+	// New destination, or the node IP has changed, score from scratch.
+	m.destinations[c.String()] = &multipathDestination{
+		cidr:   c,
+		nodeIP: nodeIP,
+		scores: map[string]float64{},
+	}
+}
+
+// DeleteDestination stops steering the direct route of the given CIDR. The
+// destination is only removed while still owned by 'nodeIP', mirroring the
+// route deletion which only removes routes through that node.
+func (m *multipathManager) DeleteDestination(c *cidr.CIDR, nodeIP net.IP) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if dest, ok := m.destinations[c.String()]; ok && dest.nodeIP.Equal(nodeIP) {
+		delete(m.destinations, c.String())
+	}
+}
+
+// probeRound probes all destinations over all uplinks, updates their scores
+// and re-programs the direct routes whose preferred uplink has changed.
+func (m *multipathManager) probeRound() {
+	m.mutex.RLock()
+	dests := make([]*multipathDestination, 0, len(m.destinations))
+	for _, dest := range m.destinations {
+		dests = append(dests, dest)
+	}
+	m.mutex.RUnlock()
+
+	for _, dest := range dests {
+		// Probe without holding the lock, a blackholed uplink makes
+		// the probes wait for their timeout.
+		results := make(map[string]bool, len(m.devices))
+		for _, device := range m.devices {
+			results[device] = probeUplink(device, dest.nodeIP)
+		}
+
+		m.mutex.Lock()
+		for device, reachable := range results {
+			score, seen := dest.scores[device]
+			dest.scores[device] = mergeScore(score, seen, reachable)
+		}
+		best := pickBestUplink(m.devices, dest.scores)
+		switchUplink := best != dest.activeDevice
+		m.mutex.Unlock()
+
+		if !switchUplink {
+			continue
+		}
+		scopedLog := log.WithFields(logrus.Fields{
+			"allocCIDR":         dest.cidr,
+			logfields.IPAddr:    dest.nodeIP,
+			logfields.Interface: best,
+		})
+		if err := steerDirectRoute(dest.cidr, dest.nodeIP, best); err != nil {
+			// Leave the active device unchanged so that the switch
+			// is retried on the next probe round.
+			scopedLog.WithError(err).Warning("Unable to steer direct node route to uplink")
+			continue
+		}
+		scopedLog.Info("Steered direct node route to uplink")
+		m.mutex.Lock()
+		dest.activeDevice = best
+		m.mutex.Unlock()
+	}
+}
+
+// mergeScore merges the result of a reachability probe into the previous
+// score of an uplink using an exponentially weighted moving average. The
+// first probe of an uplink determines its score directly.
+func mergeScore(score float64, seen, reachable bool) float64 {
+	sample := float64(0)
+	if reachable {
+		sample = 1
+	}
+	if !seen {
+		return sample
+	}
+	return score*multipathScoreDecay + sample*(1-multipathScoreDecay)
+}
+
+// pickBestUplink returns the uplink a route should be steered to: the
+// earliest configured uplink scoring within the switch margin of the best
+// one. This makes the first configured uplink the preferred one and fails
+// routes back to it once its score has recovered, while the margin prevents
+// flapping between uplinks on sporadic probe failures.
+func pickBestUplink(devices []string, scores map[string]float64) string {
+	bestScore := float64(0)
+	for _, device := range devices {
+		if score := scores[device]; score > bestScore {
+			bestScore = score
+		}
+	}
+	for _, device := range devices {
+		if scores[device] >= bestScore-multipathSwitchMargin {
+			return device
+		}
+	}
+	return ""
+}
+
+// probeUplink probes the cilium-health responder of the given node over the
+// given uplink device. The probe socket is bound to the uplink so that the
+// kernel cannot route it over another path.
+func probeUplink(device string, nodeIP net.IP) bool {
+	dialer := net.Dialer{
+		Timeout: multipathProbeTimeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			var soErr error
+			if err := c.Control(func(fd uintptr) {
+				soErr = unix.SetsockoptString(int(fd), unix.SOL_SOCKET, unix.SO_BINDTODEVICE, device)
+			}); err != nil {
+				return err
+			}
+			return soErr
+		},
+	}
+	conn, err := dialer.Dial("tcp", net.JoinHostPort(nodeIP.String(), strconv.Itoa(healthDefaults.HTTPPathPort)))
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// steerDirectRoute replaces the direct route of the given allocation CIDR
+// with one through the given uplink device.
+func steerDirectRoute(c *cidr.CIDR, nodeIP net.IP, device string) error {
+	link, err := netlink.LinkByName(device)
+	if err != nil {
+		return fmt.Errorf("unable to lookup uplink %s: %s", device, err)
+	}
+	return netlink.RouteReplace(&netlink.Route{
+		Dst:       c.IPNet,
+		Gw:        nodeIP,
+		LinkIndex: link.Attrs().Index,
+	})
+}
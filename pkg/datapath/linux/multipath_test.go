@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package linux
+
+import (
+	"gopkg.in/check.v1"
+)
+
+func (s *linuxTestSuite) TestPickBestUplink(c *check.C) {
+	devices := []string{"eth0", "eth1"}
+
+	// The preferred uplink is picked while all uplinks are healthy.
+	scores := map[string]float64{"eth0": 1, "eth1": 1}
+	c.Assert(pickBestUplink(devices, scores), check.Equals, "eth0")
+
+	// A single failed probe keeps the preferred uplink within the switch
+	// margin, preventing route flapping on sporadic failures.
+	scores["eth0"] = mergeScore(scores["eth0"], true, false)
+	c.Assert(pickBestUplink(devices, scores), check.Equals, "eth0")
+
+	// Consecutive failures fail the routes over to the second uplink.
+	scores["eth0"] = mergeScore(scores["eth0"], true, false)
+	c.Assert(pickBestUplink(devices, scores), check.Equals, "eth1")
+
+	// The routes fail back to the preferred uplink once its score has
+	// recovered into the switch margin.
+	for i := 0; i < 10; i++ {
+		c.Assert(pickBestUplink(devices, scores), check.Equals, "eth1")
+		scores["eth0"] = mergeScore(scores["eth0"], true, true)
+		if scores["eth0"] >= scores["eth1"]-multipathSwitchMargin {
+			break
+		}
+	}
+	c.Assert(pickBestUplink(devices, scores), check.Equals, "eth0")
+}
+
+func (s *linuxTestSuite) TestMergeScore(c *check.C) {
+	// The first probe of an uplink determines its score directly.
+	c.Assert(mergeScore(0, false, true), check.Equals, float64(1))
+	c.Assert(mergeScore(0, false, false), check.Equals, float64(0))
+
+	// Subsequent probes are merged with an exponentially weighted moving
+	// average.
+	c.Assert(mergeScore(1, true, false), check.Equals, multipathScoreDecay)
+	c.Assert(mergeScore(0, true, true), check.Equals, 1-multipathScoreDecay)
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package ndpproxy
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/cilium/cilium/pkg/endpoint"
+	"github.com/cilium/cilium/pkg/logging"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/sysctl"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+)
+
+var log = logging.DefaultLogger.WithField(logfields.LogSubsys, "ndp-proxy")
+
+// NDPProxy installs proxy NDP entries for local endpoint IPv6 addresses on
+// the configured uplink device. With these entries the kernel answers
+// neighbor solicitations for pod addresses on the shared L2 segment, removing
+// the need for static neighbor entries on ToR routers when native routing is
+// used.
+//
+// NDPProxy implements endpointmanager.Subscriber to track the lifecycle of
+// local endpoints.
+type NDPProxy struct {
+	// device is the uplink device the proxy entries are installed on
+	// (immutable).
+	device string
+
+	// link is the netlink handle of 'device' (immutable).
+	link netlink.Link
+}
+
+// NewNDPProxy returns an NDP proxy installing proxy entries on the given
+// device, enabling proxy_ndp on the device as a side effect.
+func NewNDPProxy(device string) (*NDPProxy, error) {
+	link, err := netlink.LinkByName(device)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find NDP proxy device %s: %w", device, err)
+	}
+
+	// The kernel only answers neighbor solicitations for proxy entries when
+	// proxy_ndp is enabled on the device.
+	if err := sysctl.Enable(fmt.Sprintf("net.ipv6.conf.%s.proxy_ndp", device)); err != nil {
+		return nil, fmt.Errorf("unable to enable proxy_ndp on %s: %w", device, err)
+	}
+
+	return &NDPProxy{
+		device: device,
+		link:   link,
+	}, nil
+}
+
+func (p *NDPProxy) proxyEntry(ip net.IP) *netlink.Neigh {
+	return &netlink.Neigh{
+		LinkIndex: p.link.Attrs().Index,
+		Family:    netlink.FAMILY_V6,
+		Flags:     netlink.NTF_PROXY,
+		IP:        ip,
+	}
+}
+
+// EndpointCreated installs a proxy NDP entry for the IPv6 address of the
+// endpoint. Implements endpointmanager.Subscriber.
+func (p *NDPProxy) EndpointCreated(ep *endpoint.Endpoint) {
+	if !ep.IPv6.IsSet() {
+		return
+	}
+	scopedLog := log.WithFields(logrus.Fields{
+		logfields.EndpointID: ep.ID,
+		logfields.IPv6:       ep.IPv6,
+		logfields.Interface:  p.device,
+	})
+	if err := netlink.NeighSet(p.proxyEntry(ep.IPv6.IP())); err != nil {
+		scopedLog.WithError(err).Warning("Unable to install proxy NDP entry for endpoint")
+		return
+	}
+	scopedLog.Debug("Installed proxy NDP entry for endpoint")
+}
+
+// EndpointDeleted removes the proxy NDP entry of the endpoint. Implements
+// endpointmanager.Subscriber.
+func (p *NDPProxy) EndpointDeleted(ep *endpoint.Endpoint, conf endpoint.DeleteConfig) {
+	if !ep.IPv6.IsSet() {
+		return
+	}
+	scopedLog := log.WithFields(logrus.Fields{
+		logfields.EndpointID: ep.ID,
+		logfields.IPv6:       ep.IPv6,
+		logfields.Interface:  p.device,
+	})
+	if err := netlink.NeighDel(p.proxyEntry(ep.IPv6.IP())); err != nil {
+		scopedLog.WithError(err).Warning("Unable to remove proxy NDP entry of endpoint")
+		return
+	}
+	scopedLog.Debug("Removed proxy NDP entry of endpoint")
+}
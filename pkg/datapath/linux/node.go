@@ -68,11 +68,16 @@ type linuxNodeHandler struct {
 	neighByNextHop         map[string]*netlink.Neigh // key = string(net.IP)
 	neighLastPingByNextHop map[string]time.Time      // key = string(net.IP)
 	wgAgent                datapath.WireguardAgent
+	multipath              *multipathManager
 }
 
 // NewNodeHandler returns a new node handler to handle node events and
 // implement the implications in the Linux datapath
 func NewNodeHandler(datapathConfig DatapathConfiguration, nodeAddressing datapath.NodeAddressing, wgAgent datapath.WireguardAgent) datapath.NodeHandler {
+	var multipath *multipathManager
+	if option.Config.EnableMultiUplinkEgress {
+		multipath = newMultipathManager(option.Config.MultiUplinkDevices)
+	}
 	return &linuxNodeHandler{
 		nodeAddressing:         nodeAddressing,
 		datapathConfig:         datapathConfig,
@@ -82,6 +87,7 @@ func NewNodeHandler(datapathConfig DatapathConfiguration, nodeAddressing datapat
 		neighByNextHop:         map[string]*netlink.Neigh{},
 		neighLastPingByNextHop: map[string]time.Time{},
 		wgAgent:                wgAgent,
+		multipath:              multipath,
 	}
 }
 
@@ -261,6 +267,9 @@ func (n *linuxNodeHandler) updateDirectRoute(oldCIDR, newCIDR *cidr.CIDR, oldIP,
 			log.WithError(err).Warningf("Unable to install direct node route %s", routeSpec.String())
 			return err
 		}
+		if n.multipath != nil {
+			n.multipath.UpsertDestination(newCIDR, newIP)
+		}
 	}
 
 	// Determine whether an old route must be deleted. The below switch
@@ -286,6 +295,10 @@ func (n *linuxNodeHandler) deleteDirectRoute(CIDR *cidr.CIDR, nodeIP net.IP) {
 		return
 	}
 
+	if n.multipath != nil {
+		n.multipath.DeleteDestination(CIDR, nodeIP)
+	}
+
 	family := netlink.FAMILY_V4
 	if CIDR.IP.To4() == nil {
 		family = netlink.FAMILY_V6
@@ -916,6 +929,20 @@ func (n *linuxNodeHandler) subnetEncryption() bool {
 	return len(n.nodeConfig.IPv4PodSubnets) > 0 || len(n.nodeConfig.IPv6PodSubnets) > 0
 }
 
+// preferWireguard returns true if traffic towards the given node is to be
+// encrypted with wireguard rather than IPsec. With both technologies enabled
+// during an encryption migration, the selection is made per node via the
+// encryption preference annotation of the remote node.
+func (n *linuxNodeHandler) preferWireguard(node *nodeTypes.Node) bool {
+	if !option.Config.EnableWireguard || node.WireguardPubKey == "" {
+		return false
+	}
+	if !n.nodeConfig.EnableIPSec {
+		return true
+	}
+	return node.EncryptionPreference == nodeTypes.EncryptionWireguard
+}
+
 // Must be called with linuxNodeHandler.mutex held.
 func (n *linuxNodeHandler) nodeUpdate(oldNode, newNode *nodeTypes.Node, firstAddition bool) error {
 	var (
@@ -935,7 +962,8 @@ func (n *linuxNodeHandler) nodeUpdate(oldNode, newNode *nodeTypes.Node, firstAdd
 		oldKey = oldNode.EncryptionKey
 	}
 
-	if n.nodeConfig.EnableIPSec && !n.subnetEncryption() && !n.nodeConfig.EncryptNode {
+	if n.nodeConfig.EnableIPSec && !n.subnetEncryption() && !n.nodeConfig.EncryptNode &&
+		!n.preferWireguard(newNode) {
 		n.enableIPsec(newNode)
 		newKey = newNode.EncryptionKey
 	}
@@ -948,7 +976,7 @@ func (n *linuxNodeHandler) nodeUpdate(oldNode, newNode *nodeTypes.Node, firstAdd
 		go n.insertNeighbor(context.Background(), newNode, false)
 	}
 
-	if n.nodeConfig.EnableIPSec && !n.subnetEncryption() {
+	if n.nodeConfig.EnableIPSec && !n.subnetEncryption() && !n.preferWireguard(newNode) {
 		n.encryptNode(newNode)
 	}
 
@@ -965,12 +993,20 @@ func (n *linuxNodeHandler) nodeUpdate(oldNode, newNode *nodeTypes.Node, firstAdd
 		return nil
 	}
 
-	if option.Config.EnableWireguard && newNode.WireguardPubKey != "" {
+	if n.preferWireguard(newNode) {
 		if err := n.wgAgent.UpdatePeer(newNode.Name, newNode.WireguardPubKey, newIP4, newIP6); err != nil {
 			log.WithError(err).
 				WithField(logfields.NodeName, newNode.Name).
 				Warning("Failed to update wireguard configuration for peer")
 		}
+	} else if oldNode != nil && n.preferWireguard(oldNode) {
+		// The node switched back to IPsec during an encryption
+		// migration, remove the stale wireguard peer.
+		if err := n.wgAgent.DeletePeer(newNode.Name); err != nil {
+			log.WithError(err).
+				WithField(logfields.NodeName, newNode.Name).
+				Warning("Failed to remove wireguard configuration for peer")
+		}
 	}
 
 	if n.nodeConfig.EnableAutoDirectRouting {
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package routeradv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/cilium/cilium/pkg/endpoint"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/logging"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/net/ipv6"
+)
+
+var log = logging.DefaultLogger.WithField(logfields.LogSubsys, "router-adv")
+
+const (
+	// raInterval is the interval between unsolicited router advertisements
+	// sent to each endpoint.
+	raInterval = 60 * time.Second
+
+	// raHopLimit is the hop limit required for router advertisements by
+	// RFC 4861.
+	raHopLimit = 255
+
+	// icmpv6TypeRA is the ICMPv6 type of a router advertisement.
+	icmpv6TypeRA = 134
+)
+
+// allNodesAddr is the all-nodes link-local multicast address RAs are sent to.
+var allNodesAddr = &net.IPAddr{IP: net.ParseIP("ff02::1")}
+
+// RouterAdvertiser emits periodic ICMPv6 router advertisements on the host
+// side of endpoint interfaces for workloads that depend on RA-based discovery
+// of the prefix, MTU and DNS servers. Addresses remain assigned by IPAM, the
+// advertised prefix is not usable for autonomous address configuration.
+//
+// RouterAdvertiser implements endpointmanager.Subscriber to track the
+// lifecycle of local endpoints.
+type RouterAdvertiser struct {
+	mutex lock.Mutex
+
+	// conn is the ICMPv6 socket the advertisements are sent from.
+	conn *ipv6.PacketConn
+
+	// stop holds the stop channel of the advertisement goroutine of each
+	// endpoint.
+	stop map[uint16]chan struct{}
+
+	// payload is the precomputed advertisement message (immutable).
+	payload []byte
+}
+
+// NewRouterAdvertiser returns a RouterAdvertiser advertising the given
+// allocation prefix, route MTU and DNS servers.
+func NewRouterAdvertiser(prefix *net.IPNet, mtu int, dnsServers []net.IP) (*RouterAdvertiser, error) {
+	c, err := net.ListenPacket("ip6:ipv6-icmp", "::")
+	if err != nil {
+		return nil, fmt.Errorf("unable to open ICMPv6 socket: %w", err)
+	}
+	conn := ipv6.NewPacketConn(c)
+	// RFC 4861 requires a hop limit of 255 for all NDP messages.
+	if err := conn.SetMulticastHopLimit(raHopLimit); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("unable to set hop limit: %w", err)
+	}
+
+	return &RouterAdvertiser{
+		conn:    conn,
+		stop:    make(map[uint16]chan struct{}),
+		payload: buildRA(prefix, mtu, dnsServers),
+	}, nil
+}
+
+// buildRA returns the router advertisement message including the ICMPv6
+// header. The checksum is computed by the kernel.
+func buildRA(prefix *net.IPNet, mtu int, dnsServers []net.IP) []byte {
+	// ICMPv6 header and RA body. The router lifetime is left zero as the
+	// routes of the endpoint are installed by the agent, the advertisement
+	// only carries discovery options.
+	msg := make([]byte, 16)
+	msg[0] = icmpv6TypeRA
+
+	if mtu > 0 {
+		opt := make([]byte, 8)
+		opt[0] = 5 // MTU option
+		opt[1] = 1 // length in units of 8 octets
+		binary.BigEndian.PutUint32(opt[4:], uint32(mtu))
+		msg = append(msg, opt...)
+	}
+
+	if prefix != nil {
+		ones, _ := prefix.Mask.Size()
+		opt := make([]byte, 32)
+		opt[0] = 3 // Prefix Information option
+		opt[1] = 4 // length in units of 8 octets
+		opt[2] = byte(ones)
+		opt[3] = 0x80                                                       // on-link, no autonomous address configuration
+		binary.BigEndian.PutUint32(opt[4:], uint32(raInterval.Seconds())*3) // valid lifetime
+		binary.BigEndian.PutUint32(opt[8:], uint32(raInterval.Seconds())*3) // preferred lifetime
+		copy(opt[16:], prefix.IP.To16())
+		msg = append(msg, opt...)
+	}
+
+	if len(dnsServers) > 0 {
+		opt := make([]byte, 8, 8+16*len(dnsServers))
+		opt[0] = 25 // RDNSS option
+		opt[1] = byte(1 + 2*len(dnsServers))
+		binary.BigEndian.PutUint32(opt[4:], uint32(raInterval.Seconds())*3) // lifetime
+		for _, server := range dnsServers {
+			opt = append(opt, server.To16()...)
+		}
+		msg = append(msg, opt...)
+	}
+
+	return msg
+}
+
+// advertise sends a single router advertisement on the given interface.
+func (r *RouterAdvertiser) advertise(ifIndex int) error {
+	cm := &ipv6.ControlMessage{IfIndex: ifIndex}
+	_, err := r.conn.WriteTo(r.payload, cm, allNodesAddr)
+	return err
+}
+
+// EndpointCreated starts periodic router advertisements towards the endpoint.
+// Implements endpointmanager.Subscriber.
+func (r *RouterAdvertiser) EndpointCreated(ep *endpoint.Endpoint) {
+	ifName := ep.HostInterface()
+	if !ep.IPv6.IsSet() || ifName == "" {
+		return
+	}
+	link, err := netlink.LinkByName(ifName)
+	if err != nil {
+		log.WithError(err).WithField(logfields.Interface, ifName).
+			Warning("Unable to find endpoint interface for router advertisements")
+		return
+	}
+	ifIndex := link.Attrs().Index
+
+	stop := make(chan struct{})
+	r.mutex.Lock()
+	if _, exists := r.stop[ep.ID]; exists {
+		r.mutex.Unlock()
+		return
+	}
+	r.stop[ep.ID] = stop
+	r.mutex.Unlock()
+
+	scopedLog := log.WithFields(logrus.Fields{
+		logfields.EndpointID: ep.ID,
+		logfields.Interface:  ifName,
+	})
+	scopedLog.Debug("Starting router advertisements for endpoint")
+
+	go func() {
+		ticker := time.NewTicker(raInterval)
+		defer ticker.Stop()
+		for {
+			if err := r.advertise(ifIndex); err != nil {
+				scopedLog.WithError(err).Debug("Unable to send router advertisement")
+			}
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// EndpointDeleted stops the router advertisements of the endpoint.
+// Implements endpointmanager.Subscriber.
+func (r *RouterAdvertiser) EndpointDeleted(ep *endpoint.Endpoint, conf endpoint.DeleteConfig) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if stop, exists := r.stop[ep.ID]; exists {
+		close(stop)
+		delete(r.stop, ep.ID)
+	}
+}
@@ -62,4 +62,17 @@ type IptablesManager interface {
 	// GetProxyPort fetches the existing proxy port configured for the
 	// specified listener. Used early in bootstrap to reopen proxy ports.
 	GetProxyPort(listener string) uint16
+
+	// RemoveStaleProxyRules deletes any Cilium-owned datapath proxy rules
+	// redirecting to a proxy port not in 'validPorts'. Such rules can be
+	// left behind if the agent is restarted after rule installation but
+	// before the owning proxy port was acknowledged. Returns the number of
+	// rules removed.
+	RemoveStaleProxyRules(validPorts map[uint16]struct{}) int
+
+	// GetProxyRuleCounters returns the packet counters of the installed
+	// datapath proxy rules, keyed by the proxy port they redirect to. The
+	// presence of a port in the result indicates that its rules are
+	// installed.
+	GetProxyRuleCounters() map[uint16]uint64
 }
@@ -425,6 +425,13 @@ func (l *Loader) Reinitialize(ctx context.Context, o datapath.BaseProgramOwner,
 		return err
 	}
 
+	if option.Config.EnableHostReachableServices {
+		// The socket-LB programs have been attached at the cgroup root
+		// by the init script. Validate that no other agent interferes
+		// with them from a nested cgroup hierarchy.
+		cgroups.VerifySockLBAttachments()
+	}
+
 	if l.canDisableDwarfRelocations {
 		// Validate alignments of C and Go equivalent structs
 		if err := alignchecker.CheckStructAlignments(defaults.AlignCheckerName); err != nil {
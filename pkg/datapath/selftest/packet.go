@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package selftest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+)
+
+const (
+	etherTypeIPv4 = 0x0800
+
+	etherHdrLen = 14
+	ipv4HdrLen  = 20
+	udpHdrLen   = 8
+
+	// selftestSrcPort is the fixed UDP source port of injected packets,
+	// allowing them to be identified in packet captures
+	selftestSrcPort = 42424
+
+	// payload carried by every injected packet, identifying the packet as
+	// a self-test artifact in captures
+	payload = "cilium-selftest"
+)
+
+// craftUDP4 builds an Ethernet/IPv4/UDP frame from src to dst addressed at
+// the given destination port.
+func craftUDP4(src, dst net.IP, dstPort uint16) ([]byte, error) {
+	src4, dst4 := src.To4(), dst.To4()
+	if src4 == nil || dst4 == nil {
+		return nil, fmt.Errorf("source %s or destination %s is not an IPv4 address", src, dst)
+	}
+
+	frame := make([]byte, etherHdrLen+ipv4HdrLen+udpHdrLen+len(payload))
+
+	// Ethernet header: broadcast destination, the datapath program
+	// attached to the device classifies on L3 anyway
+	eth := frame[:etherHdrLen]
+	for i := 0; i < 6; i++ {
+		eth[i] = 0xff
+	}
+	binary.BigEndian.PutUint16(eth[12:], etherTypeIPv4)
+
+	// IPv4 header
+	ip := frame[etherHdrLen : etherHdrLen+ipv4HdrLen]
+	ip[0] = 0x45 // version 4, 5 words header length
+	binary.BigEndian.PutUint16(ip[2:], uint16(ipv4HdrLen+udpHdrLen+len(payload)))
+	ip[8] = 64 // TTL
+	ip[9] = 17 // UDP
+	copy(ip[12:16], src4)
+	copy(ip[16:20], dst4)
+	binary.BigEndian.PutUint16(ip[10:], ipv4Checksum(ip))
+
+	// UDP header, checksum left zero which is valid for IPv4
+	udp := frame[etherHdrLen+ipv4HdrLen:]
+	binary.BigEndian.PutUint16(udp[0:], selftestSrcPort)
+	binary.BigEndian.PutUint16(udp[2:], dstPort)
+	binary.BigEndian.PutUint16(udp[4:], uint16(udpHdrLen+len(payload)))
+	copy(udp[udpHdrLen:], payload)
+
+	return frame, nil
+}
+
+// ipv4Checksum computes the IPv4 header checksum of the given header with
+// the checksum field zeroed.
+func ipv4Checksum(header []byte) uint16 {
+	sum := uint32(0)
+	for i := 0; i < len(header); i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(header[i:]))
+	}
+	for sum > 0xffff {
+		sum = (sum & 0xffff) + (sum >> 16)
+	}
+	return ^uint16(sum)
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package selftest
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	check.TestingT(t)
+}
+
+type SelftestSuite struct{}
+
+var _ = check.Suite(&SelftestSuite{})
+
+func (s *SelftestSuite) TestCraftUDP4(c *check.C) {
+	frame, err := craftUDP4(net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2"), 80)
+	c.Assert(err, check.IsNil)
+	c.Assert(len(frame), check.Equals, etherHdrLen+ipv4HdrLen+udpHdrLen+len(payload))
+
+	// EtherType is IPv4
+	c.Assert(binary.BigEndian.Uint16(frame[12:]), check.Equals, uint16(etherTypeIPv4))
+
+	// IPv4 header addresses and protocol
+	ip := frame[etherHdrLen:]
+	c.Assert(net.IP(ip[12:16]).String(), check.Equals, "10.0.0.1")
+	c.Assert(net.IP(ip[16:20]).String(), check.Equals, "10.0.0.2")
+	c.Assert(ip[9], check.Equals, uint8(17))
+
+	// Header checksum verifies, i.e. the sum over the header is zero
+	sum := uint32(0)
+	for i := 0; i < ipv4HdrLen; i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(ip[i:]))
+	}
+	for sum > 0xffff {
+		sum = (sum & 0xffff) + (sum >> 16)
+	}
+	c.Assert(uint16(sum), check.Equals, uint16(0xffff))
+
+	// UDP ports and length
+	udp := frame[etherHdrLen+ipv4HdrLen:]
+	c.Assert(binary.BigEndian.Uint16(udp[0:]), check.Equals, uint16(selftestSrcPort))
+	c.Assert(binary.BigEndian.Uint16(udp[2:]), check.Equals, uint16(80))
+	c.Assert(binary.BigEndian.Uint16(udp[4:]), check.Equals, uint16(udpHdrLen+len(payload)))
+
+	// IPv6 destinations are rejected
+	_, err = craftUDP4(net.ParseIP("10.0.0.1"), net.ParseIP("f00d::1"), 80)
+	c.Assert(err, check.NotNil)
+}
@@ -120,6 +120,11 @@ const (
 	// still considered alive
 	ToFQDNsIdleConnectionGracePeriod = 0 * time.Second
 
+	// ToFQDNsDatapathGracePeriod is the time the datapath keeps accepting
+	// connections to an IP after the TTL of the DNS answer which returned
+	// it has expired
+	ToFQDNsDatapathGracePeriod = 10 * time.Second
+
 	// ToFQDNsPreCache is a path to a file with DNS cache data to insert into the
 	// global cache on startup.
 	// The file is not re-read after agent start.
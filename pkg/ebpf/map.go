@@ -19,9 +19,10 @@ import (
 type MapSpec = ciliumebpf.MapSpec
 
 const (
-	PerCPUHash = ciliumebpf.PerCPUHash
-	Array      = ciliumebpf.Array
-	HashOfMaps = ciliumebpf.HashOfMaps
+	PerCPUHash  = ciliumebpf.PerCPUHash
+	PerCPUArray = ciliumebpf.PerCPUArray
+	Array       = ciliumebpf.Array
+	HashOfMaps  = ciliumebpf.HashOfMaps
 
 	PinByName = ciliumebpf.PinByName
 )
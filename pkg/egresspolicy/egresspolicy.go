@@ -26,6 +26,7 @@ import (
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/policy/api"
 	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
 
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -38,6 +39,9 @@ type Config struct {
 	endpointSelectors []api.EndpointSelector
 	dstCIDRs          []*net.IPNet
 	egressIP          net.IP
+	// snatIP is the source address matched traffic is SNATed with on the
+	// gateway node. Defaults to egressIP.
+	snatIP net.IP
 }
 
 // PolicyID includes endpoint name and namespace
@@ -131,16 +135,61 @@ func Parse(cenp *v2alpha1.CiliumEgressNATPolicy) (*Config, error) {
 		}
 	}
 
+	egressIP := net.ParseIP(cenp.Spec.EgressSourceIP).To4()
+
+	// The SNAT source address defaults to the egress IP, but may be
+	// overridden with a secondary address of the gateway, either
+	// explicitly or derived from an interface of the gateway node. The
+	// SNAT source address is only consumed on the gateway node, so it is
+	// acceptable for the interface to only exist there.
+	snatIP := egressIP
+	if cenp.Spec.EgressSourceSNATIP != "" {
+		snatIP = net.ParseIP(cenp.Spec.EgressSourceSNATIP).To4()
+		if snatIP == nil {
+			return nil, fmt.Errorf("unable to parse egress source SNAT IP %s", cenp.Spec.EgressSourceSNATIP)
+		}
+	}
+	if cenp.Spec.EgressSourceInterface != "" {
+		if ifaceIP, err := firstGlobalV4Addr(cenp.Spec.EgressSourceInterface); err == nil {
+			snatIP = ifaceIP
+		} else {
+			log.WithError(err).WithFields(logrus.Fields{
+				logfields.CiliumEgressNATPolicyName: name,
+				logfields.Interface:                 cenp.Spec.EgressSourceInterface,
+			}).Debug("Unable to derive SNAT source address from interface, assuming this node is not the gateway")
+		}
+	}
+
 	return &Config{
 		endpointSelectors: endpointSelectorList,
 		dstCIDRs:          dstCidrList,
-		egressIP:          net.ParseIP(cenp.Spec.EgressSourceIP).To4(),
+		egressIP:          egressIP,
+		snatIP:            snatIP,
 		id: types.NamespacedName{
 			Name: name,
 		},
 	}, nil
 }
 
+// firstGlobalV4Addr returns the first global scope IPv4 address of the given
+// interface.
+func firstGlobalV4Addr(iface string) (net.IP, error) {
+	link, err := netlink.LinkByName(iface)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := netlink.AddrList(link, netlink.FAMILY_V4)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if addr.Scope == int(netlink.SCOPE_UNIVERSE) {
+			return addr.IP.To4(), nil
+		}
+	}
+	return nil, fmt.Errorf("no global IPv4 address found on %s", iface)
+}
+
 // ParseConfigID takes a CiliumEgressNATPolicy CR and returns only the config id
 func ParseConfigID(cenp *v2alpha1.CiliumEgressNATPolicy) types.NamespacedName {
 	return policyID{
@@ -18,13 +18,17 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"os"
 
 	k8sTypes "github.com/cilium/cilium/pkg/k8s/types"
 	"github.com/cilium/cilium/pkg/labels"
 	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging"
 	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/maps/ctmap"
 	"github.com/cilium/cilium/pkg/maps/egressmap"
+	"github.com/cilium/cilium/pkg/maps/nat"
+	"github.com/cilium/cilium/pkg/option"
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/types"
@@ -126,9 +130,52 @@ func (manager *Manager) DeleteEgressPolicy(configID policyID) error {
 		}
 	}
 	delete(manager.policyConfigs, configID)
+
+	// Flows still translated to the removed egress IP would continue to
+	// leave the node with a now-foreign source address, purge them.
+	if purged := purgeStaleNATEntries(storedConfig.snatIP); purged > 0 {
+		log.WithFields(logrus.Fields{
+			logfields.IPAddr: storedConfig.snatIP,
+			"purgedFlows":    purged,
+		}).Info("Purged NAT and CT entries of removed egress IP")
+	}
+
 	return nil
 }
 
+// purgeStaleNATEntries removes NAT and CT entries still referencing the given
+// egress IP after it has been removed and returns the number of purged flows.
+func purgeStaleNATEntries(egressIP net.IP) int {
+	purged := 0
+
+	if natMap4, _ := nat.GlobalMaps(true, false, option.Config.EnableNodePort); natMap4 != nil {
+		if err := natMap4.Open(); err == nil {
+			purged += natMap4.FlushAddr(egressIP)
+			natMap4.Close()
+		} else if !os.IsNotExist(err) {
+			log.WithError(err).Warn("Unable to open NAT map to purge stale egress IP entries")
+		}
+	}
+
+	filter := &ctmap.GCFilter{
+		MatchIPs: map[string]struct{}{
+			egressIP.String(): {},
+		},
+	}
+	for _, m := range ctmap.GlobalMaps(true, false) {
+		if err := m.Open(); err != nil {
+			if !os.IsNotExist(err) {
+				log.WithError(err).Warn("Unable to open CT map to purge stale egress IP entries")
+			}
+			continue
+		}
+		purged += ctmap.GC(m, filter)
+		m.Close()
+	}
+
+	return purged
+}
+
 // OnUpdateEndpoint is the event handler for endpoint additions and updates.
 func (manager *Manager) OnUpdateEndpoint(endpoint *k8sTypes.CiliumEndpoint) {
 	var epData *endpointMetadata
@@ -289,10 +336,12 @@ func (manager *Manager) updateEgressMap(ips []string, config *Config) error {
 		for _, dstCIDR := range config.dstCIDRs {
 			key := egressmap.NewKey(sip, dstCIDR.IP, dstCIDR.Mask)
 			value := &egressmap.EgressInfo4{}
-			// As currently designed, the egressIP serves two purposes, one for forwarding traffic
-			// to the gateway node, the other for SNATing the egress traffic on the gateway.
+			// The egressIP forwards the traffic to the gateway node, the
+			// snatIP is the source address the gateway SNATs the egress
+			// traffic with. Unless overridden in the policy, both are the
+			// same address.
 			copy(value.TunnelEndpoint[:], config.egressIP)
-			copy(value.EgressIP[:], config.egressIP)
+			copy(value.EgressIP[:], config.snatIP)
 
 			err := egressmap.EgressMap.Update(&key, value)
 			if err != nil {
@@ -11,6 +11,8 @@ import (
 	"context"
 	"sort"
 
+	"github.com/go-openapi/strfmt"
+
 	"github.com/cilium/cilium/api/v1/models"
 	"github.com/cilium/cilium/pkg/addressing"
 	"github.com/cilium/cilium/pkg/endpoint/regeneration"
@@ -277,23 +279,27 @@ func (e *Endpoint) GetHealthModel() *models.EndpointHealth {
 // Must be called with e.mutex RLock()ed.
 func (e *Endpoint) getNamedPortsModel() (np models.NamedPorts) {
 	k8sPorts := e.k8sPorts
-	// keep named ports ordered to avoid the unnecessary updates to
-	// kube-apiserver
-	names := make([]string, 0, len(k8sPorts))
-	for name := range k8sPorts {
-		names = append(names, name)
-	}
-	sort.Strings(names)
-
 	np = make(models.NamedPorts, 0, len(k8sPorts))
-	for _, name := range names {
-		value := k8sPorts[name]
-		np = append(np, &models.Port{
-			Name:     name,
-			Port:     value.Port,
-			Protocol: u8proto.U8proto(value.Proto).String(),
-		})
+	for name, ports := range k8sPorts {
+		for value := range ports {
+			np = append(np, &models.Port{
+				Name:     name,
+				Port:     value.Port,
+				Protocol: u8proto.U8proto(value.Proto).String(),
+			})
+		}
 	}
+	// keep named ports ordered to avoid the unnecessary updates to
+	// kube-apiserver
+	sort.Slice(np, func(i, j int) bool {
+		if np[i].Name != np[j].Name {
+			return np[i].Name < np[j].Name
+		}
+		if np[i].Port != np[j].Port {
+			return np[i].Port < np[j].Port
+		}
+		return np[i].Protocol < np[j].Protocol
+	})
 	return np
 }
 
@@ -402,10 +408,11 @@ func (e *Endpoint) GetPolicyModel() *models.EndpointPolicyStatus {
 	// FIXME GH-3280 Once we start returning revisions Realized should be the
 	// policy implemented in the data path
 	return &models.EndpointPolicyStatus{
-		Spec:                desiredMdl,
-		Realized:            mdl,
-		ProxyPolicyRevision: int64(e.proxyPolicyRevision),
-		ProxyStatistics:     proxyStats,
+		Spec:                 desiredMdl,
+		Realized:             mdl,
+		PolicyMapUpdateDelay: strfmt.Duration(e.policyMapUpdateDelay),
+		ProxyPolicyRevision:  int64(e.proxyPolicyRevision),
+		ProxyStatistics:      proxyStats,
 	}
 }
 
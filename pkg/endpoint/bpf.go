@@ -21,6 +21,7 @@ import (
 	"github.com/cilium/cilium/pkg/controller"
 	"github.com/cilium/cilium/pkg/datapath/loader"
 	"github.com/cilium/cilium/pkg/endpoint/regeneration"
+	"github.com/cilium/cilium/pkg/eventjournal"
 	"github.com/cilium/cilium/pkg/loadinfo"
 	"github.com/cilium/cilium/pkg/logging/logfields"
 	"github.com/cilium/cilium/pkg/maps/bwmap"
@@ -581,6 +582,25 @@ func (e *Endpoint) regenerateBPF(regenContext *regenerationContext) (revnum uint
 	datapathRegenCtxt.prepareForProxyUpdates(regenContext.parentContext)
 	defer datapathRegenCtxt.completionCancel()
 
+	// Pre-warm the proxy ports and listeners needed by the desired policy so
+	// that the redirect creation in the pre-compilation steps below only has
+	// to acknowledge already-listening proxies. This is best-effort and based
+	// on the policy of the previous regeneration, as the desired policy is
+	// only recalculated with the endpoint lock held, but the set of proxy
+	// ports it requires rarely changes between regenerations.
+	if !option.Config.DryMode && !e.isProxyDisabled() {
+		if lockErr := e.rlockAlive(); lockErr == nil {
+			var l4Policy *policy.L4Policy
+			if e.desiredPolicy != nil {
+				l4Policy = e.desiredPolicy.L4Policy
+			}
+			e.runlock()
+			if l4Policy != nil {
+				go e.proxy.PrewarmRedirects(l4Policy)
+			}
+		}
+	}
+
 	headerfileChanged, err = e.runPreCompilationSteps(regenContext)
 
 	// Keep track of the side-effects of the regeneration that need to be
@@ -1149,6 +1169,8 @@ func (e *Endpoint) addPolicyKey(keyToAdd policy.Key, entry policy.MapStateEntry,
 			logfields.BPFMapKey: policymapKey,
 			logfields.Port:      entry.ProxyPort,
 		}).Error("Failed to add PolicyMap key")
+		eventjournal.Record(eventjournal.ReasonPolicyMapOverflow,
+			"Unable to add key to policy map of endpoint %d: %s", e.ID, err)
 		return false
 	}
 
@@ -1164,6 +1186,74 @@ func (e *Endpoint) addPolicyKey(keyToAdd policy.Key, entry policy.MapStateEntry,
 	return true
 }
 
+// InstallPolicyMapOverride installs a temporary allow entry for 'key' into
+// the endpoint's policy map, keeping the realized policy map state in sync.
+// If the realized state already contains an allowing entry for 'key',
+// nothing is installed so that proxy redirections are left untouched; in
+// that case 'installed' is false. If the existing entry is a deny, it is
+// replaced and returned via 'prev' so that the caller can restore it with
+// RemovePolicyMapOverride once the override expires.
+func (e *Endpoint) InstallPolicyMapOverride(key policy.Key) (prev *policy.MapStateEntry, installed bool, err error) {
+	if err := e.lockAlive(); err != nil {
+		return nil, false, err
+	}
+	defer e.unlock()
+
+	if e.policyMap == nil {
+		return nil, false, fmt.Errorf("endpoint has no policy map")
+	}
+
+	if entry, exists := e.realizedPolicy.PolicyMapState[key]; exists {
+		if !entry.IsDeny {
+			// Traffic is already allowed, possibly redirected to a
+			// proxy. Overwriting the entry would reset the proxy
+			// port, so leave it alone.
+			return nil, false, nil
+		}
+		prev = &entry
+	}
+
+	if !e.addPolicyKey(key, policy.MapStateEntry{}, false) {
+		return nil, false, fmt.Errorf("unable to add override to policy map of endpoint %d", e.ID)
+	}
+
+	return prev, true, nil
+}
+
+// RemovePolicyMapOverride removes an expired override previously installed
+// with InstallPolicyMapOverride. If the realized entry for 'key' no longer
+// matches the installed override, e.g. because the endpoint was regenerated
+// in the meantime, it is left untouched. If 'prev' is not nil, the entry it
+// points to is restored in place of the override.
+func (e *Endpoint) RemovePolicyMapOverride(key policy.Key, prev *policy.MapStateEntry) error {
+	if err := e.lockAlive(); err != nil {
+		return err
+	}
+	defer e.unlock()
+
+	if e.policyMap == nil {
+		return nil
+	}
+
+	if entry, exists := e.realizedPolicy.PolicyMapState[key]; !exists || entry.IsDeny || entry.ProxyPort != 0 {
+		// The override has already been removed or replaced by a
+		// regular policy entry.
+		return nil
+	}
+
+	if prev != nil {
+		if !e.addPolicyKey(key, *prev, false) {
+			return fmt.Errorf("unable to restore entry replaced by override in policy map of endpoint %d", e.ID)
+		}
+		return nil
+	}
+
+	if !e.deletePolicyKey(key, false, nil) {
+		return fmt.Errorf("unable to remove override from policy map of endpoint %d", e.ID)
+	}
+	return nil
+}
+
 // ApplyPolicyMapChanges updates the Endpoint's PolicyMap with the changes
 // that have accumulated for the PolicyMap via various outside events (e.g.,
 // identities added / deleted).
@@ -15,6 +15,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unsafe"
 
@@ -249,6 +250,12 @@ type Endpoint struct {
 	// should be triggered once the policyRevision reaches the wanted wantedRev.
 	policyRevisionSignals map[*policySignal]bool
 
+	// policyMapUpdateDelay is the time the most recent policy repository
+	// change took to be reflected in the policy maps of this endpoint. To
+	// modify this field please use endpoint.ObservePolicyMapUpdateDelay
+	// instead.
+	policyMapUpdateDelay time.Duration
+
 	// proxyPolicyRevision is the policy revision that has been applied to
 	// the proxy.
 	proxyPolicyRevision uint64
@@ -333,11 +340,23 @@ type Endpoint struct {
 	aliveCancel     context.CancelFunc
 	regenFailedChan chan struct{}
 
+	// regenFailures is the number of consecutive failed regenerations.
+	// Accessed atomically.
+	regenFailures int32
+
 	allocator cache.IdentityAllocator
 
 	isHost bool
 
 	noTrackPort uint16
+
+	// mssClamp is the TCP MSS clamp currently applied to traffic of this
+	// endpoint via iptables rules, 0 if no clamp is applied.
+	mssClamp uint16
+
+	// mssClampCIDRs is the list of destination CIDRs the MSS clamp rules
+	// are restricted to, empty if the clamp applies to all destinations.
+	mssClampCIDRs []string
 }
 
 // EndpointSyncControllerName returns the controller name to synchronize
@@ -666,8 +685,15 @@ func (e *Endpoint) String() string {
 }
 
 // optionChanged is a callback used with pkg/option to apply the options to an
-// endpoint.  Not used for anything at the moment.
+// endpoint.
 func optionChanged(key string, value option.OptionSetting, data interface{}) {
+	e := data.(*Endpoint)
+	if key == option.PolicyEnforcement {
+		// Log enforcement mode changes for auditing, as the mode
+		// overrides the configured policy of the endpoint.
+		e.getLogger().WithField(logfields.Mode, option.FormatPolicyEnforcementMode(value)).
+			Info("Endpoint policy enforcement mode changed")
+	}
 }
 
 // applyOptsLocked applies the given options to the endpoint's options and
@@ -743,6 +769,49 @@ func (e *Endpoint) ConntrackLocalLocked() bool {
 	return true
 }
 
+// ctPartitionSelectors caches the labels parsed from the configured CT
+// partition selectors
+var ctPartitionSelectors struct {
+	once sync.Once
+	lbls []labels.Label
+}
+
+// conntrackPartitionLabels returns the labels of endpoints whose conntrack
+// state is kept in a dedicated CT map partition
+func conntrackPartitionLabels() []labels.Label {
+	ctPartitionSelectors.once.Do(func() {
+		for _, sel := range option.Config.CTPartitionSelectors {
+			ctPartitionSelectors.lbls = append(ctPartitionSelectors.lbls, labels.ParseLabel(sel))
+		}
+	})
+	return ctPartitionSelectors.lbls
+}
+
+// ConntrackPartitionedLocked returns true if the endpoint matches one of the
+// configured CT partition selectors and thus keeps its conntrack state in a
+// dedicated local CT map partition. Must be called with the endpoint locked.
+func (e *Endpoint) ConntrackPartitionedLocked() bool {
+	if e.SecurityIdentity == nil {
+		return false
+	}
+	for _, sel := range conntrackPartitionLabels() {
+		if lbl, ok := e.SecurityIdentity.Labels[sel.Key]; ok &&
+			lbl.Source == sel.Source && (sel.Value == "" || lbl.Value == sel.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConntrackPartitioned is the same as ConntrackPartitionedLocked, but takes
+// the endpoint lock.
+func (e *Endpoint) ConntrackPartitioned() bool {
+	e.unconditionalRLock()
+	defer e.runlock()
+
+	return e.ConntrackPartitionedLocked()
+}
+
 // base64 returns the endpoint in a base64 format.
 func (e *Endpoint) base64() (string, error) {
 	var (
@@ -1047,6 +1116,18 @@ func (e *Endpoint) replaceInformationLabels(l labels.Labels) {
 	if l == nil {
 		return
 	}
+	// Audit trail of the endpoints carrying sensitive labels whose values
+	// were redacted at ingestion time. Only newly redacted keys are
+	// reported to keep periodic label synchronization quiet.
+	var newKeys []string
+	for _, key := range labelsfilter.RedactedKeys(l) {
+		if lbl, ok := e.OpLabels.OrchestrationInfo[key]; !ok || lbl.Value != labelsfilter.RedactedValue {
+			newKeys = append(newKeys, key)
+		}
+	}
+	if len(newKeys) > 0 {
+		e.getLogger().WithField(logfields.Labels, newKeys).Info("Redacted sensitive label values of endpoint")
+	}
 	e.OpLabels.ReplaceInformationLabels(l, e.getLogger())
 }
 
@@ -1515,6 +1596,26 @@ func (e *Endpoint) UpdateProxyStatistics(l4Protocol string, port uint16, ingress
 	}
 }
 
+// GetProxyStatistics returns a copy of the Endpoint's proxy statistics for
+// the redirect identified by the given proxy ID, or nil if no statistics have
+// been collected for it.
+func (e *Endpoint) GetProxyStatistics(key string) *models.RequestResponseStatistics {
+	e.proxyStatisticsMutex.RLock()
+	defer e.proxyStatisticsMutex.RUnlock()
+
+	proxyStats, ok := e.proxyStatistics[key]
+	if !ok {
+		return nil
+	}
+
+	requests := *proxyStats.Statistics.Requests
+	responses := *proxyStats.Statistics.Responses
+	return &models.RequestResponseStatistics{
+		Requests:  &requests,
+		Responses: &responses,
+	}
+}
+
 // APICanModify determines whether API requests from a user are allowed to
 // modify this endpoint.
 func APICanModify(e *Endpoint) error {
@@ -1593,6 +1694,13 @@ func (e *Endpoint) RunMetadataResolver(resolveMetadata MetadataResolverCB) {
 					}
 					return annotations[annotation.NoTrack], nil
 				})
+				e.UpdateMSSClampRules(func(_, _ string) (mssClamp string, err error) {
+					_, _, _, _, annotations, err := resolveMetadata(ns, podName)
+					if err != nil {
+						return "", err
+					}
+					return annotations[annotation.TCPMSSClamp], nil
+				})
 				e.UpdateVisibilityPolicy(func(_, _ string) (proxyVisibility string, err error) {
 					_, _, _, _, annotations, err := resolveMetadata(ns, podName)
 					if err != nil {
@@ -1606,6 +1714,12 @@ func (e *Endpoint) RunMetadataResolver(resolveMetadata MetadataResolverCB) {
 						return "", err
 					}
 					return annotations[bandwidth.EgressBandwidth], nil
+				}, func(ns, podName string) (priority string, err error) {
+					_, _, _, _, annotations, err := resolveMetadata(ns, podName)
+					if err != nil {
+						return "", err
+					}
+					return annotations[bandwidth.Priority], nil
 				})
 				e.UpdateLabels(ctx, identityLabels, info, true)
 				close(done)
@@ -2039,6 +2153,18 @@ func (e *Endpoint) WaitForPolicyRevision(ctx context.Context, rev uint64, done f
 	return ch
 }
 
+// ObservePolicyMapUpdateDelay records the time taken from a policy repository
+// change until the corresponding policy map update for this endpoint has
+// completed. The most recent observation is exposed via the endpoint API.
+func (e *Endpoint) ObservePolicyMapUpdateDelay(source string, duration time.Duration) {
+	metrics.PolicyMapUpdateDelay.WithLabelValues(source).Observe(duration.Seconds())
+	if err := e.lockAlive(); err != nil {
+		return
+	}
+	e.policyMapUpdateDelay = duration
+	e.unlock()
+}
+
 // IPs returns the slice of valid IPs for this endpoint.
 func (e *Endpoint) IPs() []net.IP {
 	ips := []net.IP{}
@@ -2197,6 +2323,24 @@ func (e *Endpoint) Delete(conf DeleteConfig) []error {
 		}
 	}
 
+	if e.mssClamp > 0 {
+		e.getLogger().WithFields(logrus.Fields{
+			"ep":     e.GetID(),
+			"ipAddr": e.GetIPv4Address(),
+		}).Debug("Deleting endpoint TCP MSS clamp rules")
+
+		if e.IPv4.IsSet() {
+			if err := iptables.RemoveMSSClampRules(e.IPv4.String(), e.mssClamp, e.mssClampCIDRs, false); err != nil {
+				errs = append(errs, fmt.Errorf("unable to delete endpoint TCP MSS clamp ipv4 rules: %s", err))
+			}
+		}
+		if e.IPv6.IsSet() {
+			if err := iptables.RemoveMSSClampRules(e.IPv6.String(), e.mssClamp, e.mssClampCIDRs, true); err != nil {
+				errs = append(errs, fmt.Errorf("unable to delete endpoint TCP MSS clamp ipv6 rules: %s", err))
+			}
+		}
+	}
+
 	completionCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	proxyWaitGroup := completion.NewWaitGroup(completionCtx)
 
@@ -5,7 +5,9 @@ package endpoint
 
 import (
 	"fmt"
+	"net"
 	"strconv"
+	"strings"
 
 	"github.com/cilium/cilium/pkg/bandwidth"
 	"github.com/cilium/cilium/pkg/datapath/iptables"
@@ -186,6 +188,101 @@ func (ev *EndpointNoTrackEvent) Handle(res chan interface{}) {
 	return
 }
 
+// EndpointMSSClampEvent contains all fields necessary to update the TCP MSS
+// clamp rules.
+type EndpointMSSClampEvent struct {
+	ep     *Endpoint
+	annoCB AnnotationsResolverCB
+}
+
+// Handle handles the TCP MSS clamp rule update.
+func (ev *EndpointMSSClampEvent) Handle(res chan interface{}) {
+	var (
+		mss   uint16
+		cidrs []string
+	)
+
+	e := ev.ep
+
+	// If this endpoint is going away, nothing to do.
+	if err := e.lockAlive(); err != nil {
+		res <- &EndpointRegenerationResult{
+			err: nil,
+		}
+		return
+	}
+
+	defer e.unlock()
+
+	anno, err := ev.annoCB(e.K8sNamespace, e.K8sPodName)
+	if err != nil {
+		res <- &EndpointRegenerationResult{
+			err: err,
+		}
+		return
+	}
+
+	if anno != "" {
+		// Validate annotation before we do any actual alteration to the endpoint.
+		mssStr := anno
+		if idx := strings.Index(anno, ":"); idx >= 0 {
+			mssStr = anno[:idx]
+			for _, cidr := range strings.Split(anno[idx+1:], ",") {
+				if _, _, err := net.ParseCIDR(cidr); err != nil {
+					res <- &EndpointRegenerationResult{
+						err: err,
+					}
+					return
+				}
+				cidrs = append(cidrs, cidr)
+			}
+		}
+		m64, err := strconv.ParseUint(mssStr, 10, 16)
+		// MSS should be within [1-65535].
+		if err != nil || m64 == 0 {
+			res <- &EndpointRegenerationResult{
+				err: err,
+			}
+			return
+		}
+		mss = uint16(m64)
+	}
+
+	if mss != e.mssClamp || strings.Join(cidrs, ",") != strings.Join(e.mssClampCIDRs, ",") {
+		log.Debug("Updating TCP MSS clamp rules")
+		if e.IPv4.IsSet() {
+			if e.mssClamp > 0 {
+				if err := iptables.RemoveMSSClampRules(e.IPv4.String(), e.mssClamp, e.mssClampCIDRs, false); err != nil {
+					log.Warnf("Error removing iptable TCP MSS clamp rules %s", err)
+				}
+			}
+			if mss > 0 {
+				if err := iptables.InstallMSSClampRules(e.IPv4.String(), mss, cidrs, false); err != nil {
+					log.Warnf("Error installing iptable TCP MSS clamp rules %s", err)
+				}
+			}
+		}
+		if e.IPv6.IsSet() {
+			if e.mssClamp > 0 {
+				if err := iptables.RemoveMSSClampRules(e.IPv6.String(), e.mssClamp, e.mssClampCIDRs, true); err != nil {
+					log.Warnf("Error removing iptable TCP MSS clamp rules %s", err)
+				}
+			}
+			if mss > 0 {
+				if err := iptables.InstallMSSClampRules(e.IPv6.String(), mss, cidrs, true); err != nil {
+					log.Warnf("Error installing iptable TCP MSS clamp rules %s", err)
+				}
+			}
+		}
+		e.mssClamp = mss
+		e.mssClampCIDRs = cidrs
+	}
+
+	res <- &EndpointRegenerationResult{
+		err: nil,
+	}
+}
+
 // EndpointPolicyVisibilityEvent contains all fields necessary to update the
 // visibility policy.
 type EndpointPolicyVisibilityEvent struct {
@@ -257,6 +354,7 @@ func (ev *EndpointPolicyVisibilityEvent) Handle(res chan interface{}) {
 type EndpointPolicyBandwidthEvent struct {
 	ep     *Endpoint
 	annoCB AnnotationsResolverCB
+	prioCB AnnotationsResolverCB
 }
 
 // Handle handles the policy bandwidth update.
@@ -283,10 +381,26 @@ func (ev *EndpointPolicyBandwidthEvent) Handle(res chan interface{}) {
 		}
 		return
 	}
-	if bandwidthEgress != "" {
-		bps, err = bandwidth.GetBytesPerSec(bandwidthEgress)
+	priority := ""
+	prio := bandwidth.PriorityBandNormal
+	if option.Config.EnableNetworkPriority {
+		priority, err = ev.prioCB(e.K8sNamespace, e.K8sPodName)
+		if err == nil {
+			prio, err = bandwidth.GetPriorityBand(priority)
+		}
+		if err != nil {
+			res <- &EndpointRegenerationResult{
+				err: err,
+			}
+			return
+		}
+	}
+	if bandwidthEgress != "" || priority != "" {
+		if bandwidthEgress != "" {
+			bps, err = bandwidth.GetBytesPerSec(bandwidthEgress)
+		}
 		if err == nil {
-			err = bwmap.Update(e.ID, bps)
+			err = bwmap.Update(e.ID, bps, prio)
 		}
 	} else {
 		err = bwmap.Delete(e.ID)
@@ -24,10 +24,12 @@ import (
 	k8sConst "github.com/cilium/cilium/pkg/k8s/apis/cilium.io"
 	"github.com/cilium/cilium/pkg/labels"
 	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/metrics"
 	monitorAPI "github.com/cilium/cilium/pkg/monitor/api"
 	"github.com/cilium/cilium/pkg/node"
 	nodeTypes "github.com/cilium/cilium/pkg/node/types"
 	"github.com/cilium/cilium/pkg/option"
+	"github.com/cilium/cilium/pkg/podstartup"
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/revert"
 	"github.com/cilium/cilium/pkg/u8proto"
@@ -115,6 +117,13 @@ func (e *Endpoint) LookupRedirectPortLocked(ingress bool, protocol string, port
 	return e.realizedRedirects[policy.ProxyID(e.ID, ingress, protocol, port)]
 }
 
+// GetPolicyEnforcementMode returns the policy enforcement mode of the
+// endpoint, overriding the daemon policy enforcement mode when set to a
+// value other than option.PolicyEnforcementModeDefault.
+func (e *Endpoint) GetPolicyEnforcementMode() option.OptionSetting {
+	return e.Options.GetValue(option.PolicyEnforcement)
+}
+
 // Note that this function assumes that endpoint policy has already been generated!
 // must be called with endpoint.mutex held for reading
 func (e *Endpoint) updateNetworkPolicy(proxyWaitGroup *completion.WaitGroup) (reterr error, revertFunc revert.RevertFunc) {
@@ -296,11 +305,150 @@ func (e *Endpoint) updateAndOverrideEndpointOptions(opts option.OptionMap) (opts
 		}
 	}
 
+	// Endpoints matching a configured CT partition selector keep their
+	// conntrack state in a dedicated local CT map so that their churn
+	// cannot evict entries of other endpoints from the global maps.
+	if e.ConntrackPartitionedLocked() {
+		opts[option.ConntrackLocal] = option.OptionEnabled
+	}
+
 	optsChanged = e.applyOptsLocked(opts)
 	return
 }
 
 // Called with e.mutex UNlocked
+// regenerateL7Only attempts to realize the desired policy by only updating
+// the rules of the already-realized proxy redirects, skipping the datapath
+// regeneration entirely. This is only possible when the desired policy
+// differs from the realized policy at most in the L7 rules of redirects
+// present in both, so large L7-only policy updates do not trigger
+// regeneration storms. Returns done=true when the short-circuit applied,
+// in which case the regeneration is complete and reterr is its result.
+//
+// Must be called with e.buildMutex held and the endpoint state set to
+// StateRegenerating.
+func (e *Endpoint) regenerateL7Only(regenContext *regenerationContext) (done bool, reterr error) {
+	stats := &regenContext.Stats
+	datapathRegenCtxt := regenContext.datapathRegenerationContext
+
+	if option.Config.DryMode || e.isProxyDisabled() {
+		return false, nil
+	}
+
+	if err := e.lockAlive(); err != nil {
+		return false, nil
+	}
+
+	// The short-circuit can only apply once the endpoint has a realized
+	// policy and a datapath to keep serving traffic with.
+	if e.SecurityIdentity == nil || e.realizedPolicy == nil ||
+		e.policyMap == nil || !e.ctCleaned {
+		e.unlock()
+		return false, nil
+	}
+
+	stats.policyCalculation.Start()
+	err := e.regeneratePolicy()
+	stats.policyCalculation.End(err == nil)
+	if err != nil {
+		// Let the full regeneration path report the failure.
+		e.unlock()
+		return false, nil
+	}
+
+	if e.desiredPolicy == nil || e.desiredPolicy == e.realizedPolicy ||
+		!e.desiredPolicy.OnlyL7RulesChanged(e.realizedPolicy) {
+		e.unlock()
+		return false, nil
+	}
+
+	e.getLogger().Debug("Only L7 rules of existing redirects changed, skipping datapath regeneration")
+
+	datapathRegenCtxt.prepareForProxyUpdates(regenContext.parentContext)
+	defer datapathRegenCtxt.completionCancel()
+
+	defer func() {
+		e.finalizeProxyState(regenContext, reterr)
+	}()
+
+	// The regenerate() caller only restores the endpoint state after a
+	// full build, so it has to be restored here on all outcomes.
+	defer func() {
+		if err := e.lockAlive(); err == nil {
+			e.BuilderSetStateLocked(StateReady, "Completed endpoint policy update via existing proxy redirects")
+			e.unlock()
+		}
+	}()
+
+	// Walk the L4Policy to update the rules of the existing redirects.
+	stats.proxyConfiguration.Start()
+	desiredRedirects, err, finalizeFunc, revertFunc := e.addNewRedirects(datapathRegenCtxt.proxyWaitGroup)
+	stats.proxyConfiguration.End(err == nil)
+	if err != nil {
+		e.unlock()
+		return true, err
+	}
+	datapathRegenCtxt.finalizeList.Append(finalizeFunc)
+	datapathRegenCtxt.revertStack.Push(revertFunc)
+
+	// Configure the new network policy with the proxies.
+	stats.proxyPolicyCalculation.Start()
+	err, networkPolicyRevertFunc := e.updateNetworkPolicy(datapathRegenCtxt.proxyWaitGroup)
+	stats.proxyPolicyCalculation.End(err == nil)
+	if err != nil {
+		e.unlock()
+		return true, err
+	}
+	datapathRegenCtxt.revertStack.Push(networkPolicyRevertFunc)
+
+	// The redirects existed before, so their proxy ports in the policy
+	// map are unchanged. Sync anyway to realize the desired map state.
+	stats.mapSync.Start()
+	err = e.syncPolicyMap()
+	stats.mapSync.End(err == nil)
+	if err != nil {
+		e.unlock()
+		return true, fmt.Errorf("unable to regenerate policy because PolicyMap synchronization failed: %s", err)
+	}
+
+	finalizeFunc, revertFunc = e.removeOldRedirects(desiredRedirects, datapathRegenCtxt.proxyWaitGroup)
+	datapathRegenCtxt.finalizeList.Append(finalizeFunc)
+	datapathRegenCtxt.revertStack.Push(revertFunc)
+
+	e.unlock()
+
+	// Wait for the proxies to acknowledge the updated rules before
+	// realizing the desired policy.
+	stats.proxyWaitForAck.Start()
+	err = e.waitForProxyCompletions(datapathRegenCtxt.proxyWaitGroup)
+	stats.proxyWaitForAck.End(err == nil)
+	if err != nil {
+		return true, fmt.Errorf("Error while configuring proxy redirects: %s", err)
+	}
+
+	if err := e.lockAlive(); err != nil {
+		return true, err
+	}
+	defer e.unlock()
+
+	e.syncPolicyMapController()
+
+	if e.desiredPolicy != e.realizedPolicy {
+		// Remove references to the old policy
+		e.realizedPolicy.Detach()
+		// Set realized state to desired state.
+		e.realizedPolicy = e.desiredPolicy
+	}
+
+	// Mark the endpoint to be running the policy revision it was
+	// updated for
+	e.setPolicyRevision(e.nextPolicyRevision)
+
+	metrics.EndpointRegenerationsAvoided.Inc()
+
+	return true, nil
+}
+
 func (e *Endpoint) regenerate(context *regenerationContext) (retErr error) {
 	var revision uint64
 	var stateDirComplete bool
@@ -353,6 +501,16 @@ func (e *Endpoint) regenerate(context *regenerationContext) (retErr error) {
 
 	e.unlock()
 
+	// Policy updates that leave the traffic selected at L3/L4 and the set
+	// of redirects unchanged only require the L7 rules of the existing
+	// redirects to be updated in the proxy. Attempt this short-circuit
+	// before preparing a full datapath regeneration.
+	if context.datapathRegenerationContext.regenerationLevel == regeneration.RegenerateWithoutDatapath {
+		if done, err := e.regenerateL7Only(context); done {
+			return err
+		}
+	}
+
 	stats.prepareBuild.Start()
 	origDir := e.StateDirectoryPath()
 	context.datapathRegenerationContext.currentDir = origDir
@@ -474,9 +632,25 @@ func (e *Endpoint) updateRegenerationStatistics(context *regenerationContext, er
 	e.mutex.RLock()
 	stats.endpointID = e.ID
 	stats.policyStatus = e.policyStatus()
+	podNamespace, podName := e.K8sNamespace, e.K8sPodName
 	e.runlock()
 	stats.SendMetrics()
 
+	if success {
+		// Only the first successful regeneration is recorded per pod, so
+		// this reflects the initial pod startup.
+		podstartup.RecordStage(podNamespace, podName, podstartup.StagePolicyCalculation, stats.policyCalculation.Total())
+		podstartup.RecordStage(podNamespace, podName, podstartup.StageBPFLoad,
+			stats.datapathRealization.BpfCompilation.Total()+
+				stats.datapathRealization.BpfWaitForELF.Total()+
+				stats.datapathRealization.BpfWriteELF.Total()+
+				stats.datapathRealization.BpfLoadProg.Total())
+		podstartup.RecordStage(podNamespace, podName, podstartup.StageProxySetup,
+			stats.proxyConfiguration.Total()+
+				stats.proxyPolicyCalculation.Total()+
+				stats.proxyWaitForAck.Total())
+	}
+
 	fields := logrus.Fields{
 		logfields.Reason: context.Reason,
 	}
@@ -620,9 +794,14 @@ func (e *Endpoint) Regenerate(regenMetadata *regeneration.ExternalRegenerationMe
 			}
 		}
 
+		if buildSuccess {
+			e.reportRegenerationSuccess()
+		}
+
 		// If a build is canceled, that means that the Endpoint is being deleted
 		// not that the build failed.
 		if !buildSuccess && !canceled {
+			e.reportRegenerationFailure(regenError)
 			select {
 			case e.regenFailedChan <- struct{}{}:
 			default:
@@ -657,6 +836,18 @@ func (e *Endpoint) startRegenerationFailureHandler() {
 				return nil
 			}
 
+			// Delay the recovery attempt with an exponential backoff to
+			// avoid hot-looping regenerations of an endpoint that fails
+			// persistently, e.g. due to a compile error or map pressure.
+			if wait := e.regenRetryWait(); wait > 0 {
+				e.getLogger().WithField("wait", wait).Debug("waiting before regeneration recovery attempt")
+				select {
+				case <-time.After(wait):
+				case <-ctx.Done():
+					return nil
+				}
+			}
+
 			regenMetadata := &regeneration.ExternalRegenerationMetadata{
 				// TODO (ianvernon) - is there a way we can plumb a parent
 				// context to a controller (e.g., endpoint.aliveCtx)?
@@ -836,6 +1027,21 @@ func (e *Endpoint) UpdateNoTrackRules(annoCB AnnotationsResolverCB) {
 	<-ch
 }
 
+// UpdateMSSClampRules updates the TCP MSS clamping iptable rules for this
+// endpoint. If anno is empty, then any existing clamp rules will be removed.
+// If anno cannot be parsed, we remove existing clamp rules too if there's any.
+func (e *Endpoint) UpdateMSSClampRules(annoCB AnnotationsResolverCB) {
+	ch, err := e.eventQueue.Enqueue(eventqueue.NewEvent(&EndpointMSSClampEvent{
+		ep:     e,
+		annoCB: annoCB,
+	}))
+	if err != nil {
+		e.getLogger().WithError(err).Error("Unable to enqueue endpoint MSS clamp event")
+		return
+	}
+	<-ch
+}
+
 // UpdateVisibilityPolicy updates the visibility policy of this endpoint to
 // reflect the state stored in the provided proxy visibility annotation. If anno
 // is empty, then the VisibilityPolicy for the Endpoint will be empty, and will
@@ -853,12 +1059,13 @@ func (e *Endpoint) UpdateVisibilityPolicy(annoCB AnnotationsResolverCB) {
 	<-ch
 }
 
-// UpdateBandwidthPolicy updates the egress bandwidth of this endpoint to
-// progagate the throttle rate to the BPF data path.
-func (e *Endpoint) UpdateBandwidthPolicy(annoCB AnnotationsResolverCB) {
+// UpdateBandwidthPolicy updates the egress bandwidth and scheduling band of
+// this endpoint to progagate the throttle rate to the BPF data path.
+func (e *Endpoint) UpdateBandwidthPolicy(annoCB, prioCB AnnotationsResolverCB) {
 	ch, err := e.eventQueue.Enqueue(eventqueue.NewEvent(&EndpointPolicyBandwidthEvent{
 		ep:     e,
 		annoCB: annoCB,
+		prioCB: prioCB,
 	}))
 	if err != nil {
 		e.getLogger().WithError(err).Error("Unable to enqueue endpoint policy bandwidth event")
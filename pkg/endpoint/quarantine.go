@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package endpoint
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/cilium/cilium/pkg/backoff"
+	"github.com/cilium/cilium/pkg/eventjournal"
+)
+
+const (
+	// regenQuarantineThreshold is the number of consecutive failed
+	// regenerations after which an endpoint is considered quarantined.
+	regenQuarantineThreshold = 5
+
+	// regenRetryBaseDuration is the base interval between regeneration
+	// recovery attempts, growing exponentially with each consecutive
+	// failure.
+	regenRetryBaseDuration = 2 * time.Second
+
+	// regenRetryMaxDuration caps the interval between regeneration
+	// recovery attempts.
+	regenRetryMaxDuration = 5 * time.Minute
+)
+
+// reportRegenerationFailure accounts a failed regeneration of the endpoint.
+// Once the number of consecutive failures reaches the quarantine threshold,
+// the failure is reflected in the endpoint status and an event is recorded
+// against the pod, if any, so that persistent build failures are visible
+// without inspecting the agent log.
+func (e *Endpoint) reportRegenerationFailure(regenError error) {
+	failures := atomic.AddInt32(&e.regenFailures, 1)
+	if failures != regenQuarantineThreshold {
+		return
+	}
+
+	msg := fmt.Sprintf("Endpoint regeneration failed %d consecutive times, retrying with capped exponential backoff: %s",
+		failures, regenError)
+	e.getLogger().Warning(msg)
+	e.LogStatus(Other, Failure, msg)
+	if pod := e.GetK8sPodName(); pod != "" {
+		eventjournal.RecordForPod(eventjournal.ReasonEndpointQuarantine, e.GetK8sNamespace(), pod,
+			"%s", msg)
+	} else {
+		eventjournal.Record(eventjournal.ReasonEndpointQuarantine, "Endpoint %d: %s", e.ID, msg)
+	}
+}
+
+// reportRegenerationSuccess resets the consecutive failure count of the
+// endpoint and reports recovery if the endpoint was quarantined.
+func (e *Endpoint) reportRegenerationSuccess() {
+	failures := atomic.SwapInt32(&e.regenFailures, 0)
+	if failures < regenQuarantineThreshold {
+		return
+	}
+
+	msg := fmt.Sprintf("Endpoint recovered after %d failed regenerations", failures)
+	e.getLogger().Info(msg)
+	e.LogStatusOK(Other, msg)
+	if pod := e.GetK8sPodName(); pod != "" {
+		eventjournal.RecordForPod(eventjournal.ReasonEndpointRecovery, e.GetK8sNamespace(), pod,
+			"%s", msg)
+	} else {
+		eventjournal.Record(eventjournal.ReasonEndpointRecovery, "Endpoint %d: %s", e.ID, msg)
+	}
+}
+
+// regenRetryWait returns the time to wait before the next regeneration
+// recovery attempt. The wait grows exponentially with the number of
+// consecutive failures, has random jitter applied and is capped at
+// regenRetryMaxDuration, so that persistently failing endpoints do not
+// hot-loop regeneration attempts.
+func (e *Endpoint) regenRetryWait() time.Duration {
+	failures := atomic.LoadInt32(&e.regenFailures)
+	if failures <= 1 {
+		return 0
+	}
+	return backoff.CalculateDuration(regenRetryBaseDuration, regenRetryMaxDuration, 2.0, true, int(failures-1))
+}
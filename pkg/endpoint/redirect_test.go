@@ -48,6 +48,9 @@ func (r *RedirectSuiteProxy) CreateOrUpdateRedirect(l4 policy.ProxyPolicy, id st
 	return pp, nil, nil, nil
 }
 
+// PrewarmRedirects does nothing.
+func (r *RedirectSuiteProxy) PrewarmRedirects(l4Policy *policy.L4Policy) {}
+
 // RemoveRedirect does nothing.
 func (r *RedirectSuiteProxy) RemoveRedirect(id string, wg *completion.WaitGroup) (error, revert.FinalizeFunc, revert.RevertFunc) {
 	return nil, nil, nil
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package endpoint
+
+import (
+	"fmt"
+
+	"github.com/cilium/cilium/pkg/datapath/linux/route"
+	"github.com/cilium/cilium/pkg/endpoint/regeneration"
+
+	"github.com/vishvananda/netlink"
+)
+
+// endpointRoutes returns the per endpoint routes of the endpoint as installed
+// by the datapath loader.
+func (e *Endpoint) endpointRoutes() []route.Route {
+	var routes []route.Route
+	if ip := e.IPv4Address(); ip.IsSet() {
+		routes = append(routes, route.Route{
+			Prefix: *ip.IPNet(32),
+			Device: e.ifName,
+			Scope:  netlink.SCOPE_LINK,
+		})
+	}
+	if ip := e.IPv6Address(); ip.IsSet() {
+		routes = append(routes, route.Route{
+			Prefix: *ip.IPNet(128),
+			Device: e.ifName,
+			Scope:  netlink.SCOPE_LINK,
+		})
+	}
+	return routes
+}
+
+// SetEndpointRoutes switches the endpoint between routing via the cilium_host
+// interface and dedicated per endpoint routes without recreating the
+// endpoint. The datapath configuration is updated to the target mode and the
+// endpoint is regenerated so that BPF programs, routes and rules are replaced
+// together. Afterwards, routes left behind by the previous mode are removed
+// and the target route set is verified.
+func (e *Endpoint) SetEndpointRoutes(enabled bool) error {
+	if err := e.lockAlive(); err != nil {
+		return err
+	}
+	if e.IsHost() || e.HasIpvlanDataPath() ||
+		e.DatapathConfiguration.InstallEndpointRoute == enabled {
+		e.unlock()
+		return nil
+	}
+
+	e.DatapathConfiguration.InstallEndpointRoute = enabled
+	e.DatapathConfiguration.RequireEgressProg = enabled
+	if enabled {
+		// Delegate routing to the Linux stack rather than tail-calling
+		// between BPF programs.
+		disabled := false
+		e.DatapathConfiguration.RequireRouting = &disabled
+	} else {
+		e.DatapathConfiguration.RequireRouting = nil
+	}
+	e.unlock()
+
+	regen := e.RegenerateIfAlive(&regeneration.ExternalRegenerationMetadata{
+		Reason:            "endpoint routes mode changed",
+		RegenerationLevel: regeneration.RegenerateWithDatapathRebuild,
+	})
+	if buildSuccess := <-regen; !buildSuccess {
+		return fmt.Errorf("regeneration for endpoint routes migration failed")
+	}
+
+	if !enabled {
+		// The regeneration only installs routes of the target mode, the
+		// routes of the previous mode have to be removed explicitly.
+		for _, r := range e.endpointRoutes() {
+			if err := route.Delete(r); err != nil {
+				e.getLogger().WithError(err).WithField("route", r).
+					Warning("Unable to remove stale endpoint route")
+			}
+		}
+	}
+
+	return e.verifyEndpointRoutes(enabled)
+}
+
+// verifyEndpointRoutes verifies that the installed routes of the endpoint
+// match the given endpoint routes mode.
+func (e *Endpoint) verifyEndpointRoutes(enabled bool) error {
+	for _, r := range e.endpointRoutes() {
+		installed, err := route.Lookup(r)
+		if err != nil {
+			return fmt.Errorf("unable to verify route %s: %w", r.Prefix.String(), err)
+		}
+		if enabled && installed == nil {
+			return fmt.Errorf("route %s missing after migration", r.Prefix.String())
+		}
+		if !enabled && installed != nil {
+			return fmt.Errorf("route %s still installed after migration", r.Prefix.String())
+		}
+	}
+	return nil
+}
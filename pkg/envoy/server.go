@@ -123,6 +123,11 @@ type XDSServer struct {
 	// mutex must be held when accessing this.
 	networkPolicyEndpoints map[string]logger.EndpointUpdater
 
+	// listenerMSS is the TCP maximum segment size set on proxy listener
+	// sockets, clamping the segment size of the proxied connections to the
+	// route MTU. Zero leaves the segment size up to the kernel.
+	listenerMSS int64
+
 	// stopServer stops the xDS gRPC server.
 	stopServer context.CancelFunc
 }
@@ -188,6 +193,13 @@ func StartXDSServer(stateDir string) *XDSServer {
 	}
 }
 
+// SetListenerMSS sets the TCP maximum segment size applied to proxy listener
+// sockets. Accepted downstream sockets inherit the clamp from the listener
+// socket. Must be called before any listener is added.
+func (s *XDSServer) SetListenerMSS(mss int) {
+	s.listenerMSS = int64(mss)
+}
+
 func (s *XDSServer) getHttpFilterChainProto(clusterName string, tls bool) *envoy_config_listener.FilterChain {
 	denied403body := option.Config.HTTP403Message
 	requestTimeout := int64(option.Config.HTTPRequestTimeout) // seconds
@@ -295,12 +307,12 @@ func (s *XDSServer) getHttpFilterChainProto(clusterName string, tls bool) *envoy
 }
 
 // getTcpFilterChainProto creates a TCP filter chain with the Cilium network filter.
-// By default, the returned chain can be used with the Cilium Go extensions L7 parsers
-// in 'proxylib' directory in the Cilium repo.
+// When 'useProxylib' is true, the returned chain can be used with the Cilium Go
+// extensions L7 parsers in 'proxylib' directory in the Cilium repo.
 // When optional 'filterName' is given, it is configured as the first filter in the chain
 // and 'proxylib' is not configured. In this case the returned filter chain is only used
 // if the applicable network policy specifies 'filterName' as the L7 parser.
-func (s *XDSServer) getTcpFilterChainProto(clusterName string, filterName string, config *any.Any) *envoy_config_listener.FilterChain {
+func (s *XDSServer) getTcpFilterChainProto(clusterName string, filterName string, config *any.Any, useProxylib bool) *envoy_config_listener.FilterChain {
 	var filters []*envoy_config_listener.Filter
 
 	// 1. Add the filter 'filterName' to the beginning of the TCP chain with optional 'config', if needed.
@@ -316,8 +328,7 @@ func (s *XDSServer) getTcpFilterChainProto(clusterName string, filterName string
 
 	// 2. Add Cilium Network filter.
 	var ciliumConfig *cilium.NetworkFilter
-	if filterName == "" {
-		// Use proxylib by default
+	if useProxylib {
 		ciliumConfig = &cilium.NetworkFilter{
 			Proxylib: "libcilium.so",
 			ProxylibParams: map[string]string{
@@ -542,6 +553,19 @@ func (s *XDSServer) getListenerConf(name string, kind policy.L7ParserType, port
 		}},
 	}
 
+	// Clamp the maximum segment size of the proxied connections to the
+	// route MTU. The option is set before bind so that sockets accepted
+	// from the listener inherit it.
+	if s.listenerMSS > 0 {
+		listenerConf.SocketOptions = append(listenerConf.SocketOptions, &envoy_config_core.SocketOption{
+			Description: "Listener socket maximum segment size",
+			Level:       unix.IPPROTO_TCP,
+			Name:        unix.TCP_MAXSEG,
+			Value:       &envoy_config_core.SocketOption_IntValue{IntValue: s.listenerMSS},
+			State:       envoy_config_core.SocketOption_STATE_PREBIND,
+		})
+	}
+
 	// Add filter chains
 	if kind == policy.ParserTypeHTTP {
 		// Use tls_inspector only with HTTP, insert as the first filter
@@ -557,22 +581,26 @@ func (s *XDSServer) getListenerConf(name string, kind policy.L7ParserType, port
 			tlsClusterName = ingressTLSClusterName
 		}
 		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getHttpFilterChainProto(tlsClusterName, true))
+	} else if kind == policy.ParserTypeTCP {
+		// Data-agnostic TCP chain, proxies the connection without any L7
+		// parsing for connection-level observability and policy
+		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getTcpFilterChainProto(clusterName, "", nil, false))
 	} else {
 		// Default TCP chain, takes care of all parsers in proxylib
-		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getTcpFilterChainProto(clusterName, "", nil))
+		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getTcpFilterChainProto(clusterName, "", nil, true))
 
 		// Experimental TCP chain for MySQL 5.x
 		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getTcpFilterChainProto(clusterName,
 			"envoy.filters.network.mysql_proxy", toAny(&envoy_mysql_proxy.MySQLProxy{
 				StatPrefix: "mysql",
-			})))
+			}), false))
 
 		// Experimental TCP chain for MongoDB
 		listenerConf.FilterChains = append(listenerConf.FilterChains, s.getTcpFilterChainProto(clusterName,
 			"envoy.filters.network.mongo_proxy", toAny(&envoy_mongo_proxy.MongoProxy{
 				StatPrefix:          "mongo",
 				EmitDynamicMetadata: true,
-			})))
+			}), false))
 	}
 	return listenerConf
 }
@@ -1140,6 +1168,10 @@ func getPortNetworkPolicyRule(sel policy.CachedSelector, wildcard bool, l7Parser
 	case policy.ParserTypeDNS:
 		// TODO: Support DNS. For now, just ignore any DNS L7 rule.
 
+	case policy.ParserTypeTCP:
+		// Data-agnostic TCP proxying, policy is enforced on the connection
+		// level only and there are no L7 rules to pass to Envoy.
+
 	default:
 		// Assume unknown parser types use a Key-Value Pair policy
 		if len(l7Rules.L7) > 0 {
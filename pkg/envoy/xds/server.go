@@ -14,7 +14,9 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/metrics"
 
 	envoy_service_discovery "github.com/cilium/proxy/go/envoy/service/discovery/v3"
 	"github.com/golang/protobuf/proto"
@@ -76,6 +78,14 @@ type Server struct {
 	// lastStreamID is the identifier of the last processed stream.
 	// It is incremented atomically when starting the handling of a new stream.
 	lastStreamID uint64
+
+	// streamsMutex protects seenStreams.
+	streamsMutex lock.Mutex
+
+	// seenStreams is the set of type URLs for which a stream has already
+	// been handled, used to detect stream re-establishment, e.g. after a
+	// restart of the Envoy process.
+	seenStreams map[string]struct{}
 }
 
 // ResourceTypeConfiguration is the configuration of the XDS server for a
@@ -109,7 +119,16 @@ func NewServer(resourceTypes map[string]*ResourceTypeConfiguration,
 
 	// TODO: Unregister the watchers when stopping the server.
 
-	return &Server{watchers: watchers, ackObservers: ackObservers}
+	return &Server{watchers: watchers, ackObservers: ackObservers, seenStreams: make(map[string]struct{})}
+}
+
+// xdsResourceTypeLabel derives the metric label for a type URL, e.g.
+// "Listener" for "type.googleapis.com/envoy.config.listener.v3.Listener".
+func xdsResourceTypeLabel(typeURL string) string {
+	if i := strings.LastIndex(typeURL, "."); i >= 0 {
+		return typeURL[i+1:]
+	}
+	return typeURL
 }
 
 func getXDSRequestFields(req *envoy_service_discovery.DiscoveryRequest) logrus.Fields {
@@ -127,6 +146,20 @@ func (s *Server) HandleRequestStream(ctx context.Context, stream Stream, default
 
 	reqStreamLog := log.WithField(logfields.XDSStreamID, streamID)
 
+	if defaultTypeURL != AnyTypeURL {
+		s.streamsMutex.Lock()
+		_, replay := s.seenStreams[defaultTypeURL]
+		s.seenStreams[defaultTypeURL] = struct{}{}
+		s.streamsMutex.Unlock()
+		if replay {
+			// The configured resources of this type are served again from
+			// the resource cache on the new stream.
+			reqStreamLog.WithField(logfields.XDSTypeURL, defaultTypeURL).
+				Info("xDS stream re-established, replaying configured resources")
+			metrics.ProxyXDSStreamReplays.WithLabelValues(xdsResourceTypeLabel(defaultTypeURL)).Inc()
+		}
+	}
+
 	reqCh := make(chan *envoy_service_discovery.DiscoveryRequest)
 
 	stopRecv := make(chan struct{})
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+// Package eventjournal provides a bounded in-memory journal of datapath
+// impacting agent events such as proxy port allocation failures, policy map
+// overflows, ipcache rollbacks and encryption key changes. The journal is
+// exposed via the debuginfo API and registered sinks may forward events to
+// additional destinations, e.g. as Kubernetes events.
+package eventjournal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/lock"
+
+	"github.com/go-openapi/strfmt"
+)
+
+// Reason classifies an agent event.
+type Reason string
+
+const (
+	// ReasonProxyPortAllocation is used when allocation of a proxy port
+	// for a redirect fails.
+	ReasonProxyPortAllocation Reason = "ProxyPortAllocationFailure"
+
+	// ReasonPolicyMapOverflow is used when a policy map update of an
+	// endpoint fails, e.g. because the map is full.
+	ReasonPolicyMapOverflow Reason = "PolicyMapOverflow"
+
+	// ReasonIPCacheRollback is used when a replacement of the ipcache map
+	// failed and the previous map had to be restored.
+	ReasonIPCacheRollback Reason = "IPCacheRollback"
+
+	// ReasonEncryptionRekey is used when new encryption keys are loaded.
+	ReasonEncryptionRekey Reason = "EncryptionRekey"
+
+	// ReasonFlowOverride is used when a temporary flow-level policy
+	// verdict override is installed or expires.
+	ReasonFlowOverride Reason = "FlowOverride"
+
+	// ReasonEndpointQuarantine is used when an endpoint is quarantined
+	// after repeated regeneration failures.
+	ReasonEndpointQuarantine Reason = "EndpointQuarantine"
+
+	// ReasonEndpointRecovery is used when a quarantined endpoint recovers
+	// with a successful regeneration.
+	ReasonEndpointRecovery Reason = "EndpointRecovery"
+
+	// ReasonIPPoolLow is used when the number of available IPs in the
+	// IPAM allocation pool drops below the configured low watermark.
+	ReasonIPPoolLow Reason = "IPPoolLowWatermark"
+
+	// ReasonSynFloodMitigation is used when SYN flood mitigation for a
+	// service frontend is installed or removed.
+	ReasonSynFloodMitigation Reason = "SynFloodMitigation"
+)
+
+// journalSize is the maximum number of events kept in the journal. Once
+// exceeded, the oldest events are dropped.
+const journalSize = 128
+
+// Event is a single entry of the agent event journal.
+type Event struct {
+	// Timestamp is the time at which the event was recorded
+	Timestamp time.Time
+
+	// Reason classifies the event
+	Reason Reason
+
+	// Message is a human readable description of the event
+	Message string
+
+	// PodNamespace and PodName identify the pod the event relates to, if
+	// any. Sinks may use them to attach the event to the pod instead of
+	// the node.
+	PodNamespace string
+	PodName      string
+}
+
+// Sink is called for each recorded event. Sinks must not block.
+type Sink func(Event)
+
+var (
+	mutex  lock.RWMutex
+	events []Event
+	sinks  []Sink
+)
+
+// RegisterSink registers a sink to be called for each subsequently recorded
+// event.
+func RegisterSink(sink Sink) {
+	mutex.Lock()
+	sinks = append(sinks, sink)
+	mutex.Unlock()
+}
+
+// Record adds an event to the journal and forwards it to all registered
+// sinks.
+func Record(reason Reason, format string, args ...interface{}) {
+	record(Event{
+		Timestamp: time.Now(),
+		Reason:    reason,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// RecordForPod is like Record, but attributes the event to the given pod.
+func RecordForPod(reason Reason, podNamespace, podName, format string, args ...interface{}) {
+	record(Event{
+		Timestamp:    time.Now(),
+		Reason:       reason,
+		Message:      fmt.Sprintf(format, args...),
+		PodNamespace: podNamespace,
+		PodName:      podName,
+	})
+}
+
+func record(ev Event) {
+	mutex.Lock()
+	events = append(events, ev)
+	if len(events) > journalSize {
+		events = events[len(events)-journalSize:]
+	}
+	registered := make([]Sink, len(sinks))
+	copy(registered, sinks)
+	mutex.Unlock()
+
+	for _, sink := range registered {
+		sink(ev)
+	}
+}
+
+// GetModel returns the journal, oldest event first, in its Cilium API
+// representation.
+func GetModel() []*models.AgentEvent {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	evs := make([]*models.AgentEvent, 0, len(events))
+	for _, ev := range events {
+		evs = append(evs, &models.AgentEvent{
+			Timestamp: strfmt.DateTime(ev.Timestamp),
+			Reason:    string(ev.Reason),
+			Message:   ev.Message,
+		})
+	}
+
+	return evs
+}
@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+// Package features provides runtime enable and disable support for optional
+// agent subsystems. A subsystem registers a feature with its start logic and
+// optional teardown logic; the feature can then be toggled through the agent
+// configuration API without restarting the agent. Dependencies between
+// features are validated on both transitions: a feature can only be enabled
+// once all features it depends on are enabled, and can only be disabled once
+// no enabled feature depends on it.
+package features
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/logging"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/metrics"
+)
+
+var log = logging.DefaultLogger.WithField(logfields.LogSubsys, "features")
+
+// Feature is an optional agent subsystem which can be enabled and disabled
+// at runtime.
+type Feature struct {
+	// Name is the unique name under which the feature is registered and
+	// toggled.
+	Name string
+
+	// DependsOn lists the names of the features which must be enabled
+	// before this feature can be enabled.
+	DependsOn []string
+
+	// Start starts the subsystem. The passed context is cancelled when
+	// the feature is disabled and all background operations of the
+	// subsystem must terminate when it is.
+	Start func(ctx context.Context) error
+
+	// Stop is called after the feature context has been cancelled to
+	// perform any additional synchronous teardown. It may be nil.
+	Stop func()
+}
+
+var (
+	mutex      lock.RWMutex
+	baseCtx    = context.Background()
+	registered = map[string]*Feature{}
+	cancels    = map[string]context.CancelFunc{}
+)
+
+// SetContext sets the base context from which the per-feature contexts are
+// derived. It must be called before any feature is enabled.
+func SetContext(ctx context.Context) {
+	mutex.Lock()
+	baseCtx = ctx
+	mutex.Unlock()
+}
+
+// Register registers a feature under its name. Returns an error if the name
+// is already registered. Registering a feature does not enable it.
+func Register(f *Feature) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if _, ok := registered[f.Name]; ok {
+		return fmt.Errorf("feature %q already registered", f.Name)
+	}
+	registered[f.Name] = f
+	metrics.FeatureEnabled.WithLabelValues(f.Name).Set(0)
+	return nil
+}
+
+// IsRegistered returns true if a feature is registered under the given name.
+func IsRegistered(name string) bool {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	_, ok := registered[name]
+	return ok
+}
+
+// IsEnabled returns true if the feature with the given name is enabled.
+func IsEnabled(name string) bool {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	_, ok := cancels[name]
+	return ok
+}
+
+// Enable starts the feature with the given name. Enabling an already enabled
+// feature is a no-op. Returns an error if the feature is unknown, a feature
+// it depends on is not enabled, or its start function fails.
+func Enable(name string) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	f, ok := registered[name]
+	if !ok {
+		return fmt.Errorf("unknown feature %q", name)
+	}
+	if _, ok := cancels[name]; ok {
+		return nil
+	}
+	for _, dep := range f.DependsOn {
+		if _, ok := cancels[dep]; !ok {
+			return fmt.Errorf("feature %q requires feature %q to be enabled", name, dep)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(baseCtx)
+	if err := f.Start(ctx); err != nil {
+		cancel()
+		return fmt.Errorf("unable to start feature %q: %w", name, err)
+	}
+	cancels[name] = cancel
+	metrics.FeatureEnabled.WithLabelValues(name).Set(1)
+
+	log.WithField("feature", name).Info("Enabled feature")
+	return nil
+}
+
+// Disable stops the feature with the given name by cancelling its context
+// and invoking its stop function. Disabling a feature which is not enabled
+// is a no-op. Returns an error if the feature is unknown or an enabled
+// feature depends on it.
+func Disable(name string) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	f, ok := registered[name]
+	if !ok {
+		return fmt.Errorf("unknown feature %q", name)
+	}
+	cancel, ok := cancels[name]
+	if !ok {
+		return nil
+	}
+	for enabledName := range cancels {
+		for _, dep := range registered[enabledName].DependsOn {
+			if dep == name {
+				return fmt.Errorf("feature %q is required by enabled feature %q", name, enabledName)
+			}
+		}
+	}
+
+	cancel()
+	delete(cancels, name)
+	if f.Stop != nil {
+		f.Stop()
+	}
+	metrics.FeatureEnabled.WithLabelValues(name).Set(0)
+
+	log.WithField("feature", name).Info("Disabled feature")
+	return nil
+}
+
+// Status returns the enablement state of all registered features.
+func Status() map[string]bool {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	status := make(map[string]bool, len(registered))
+	for name := range registered {
+		_, status[name] = cancels[name]
+	}
+	return status
+}
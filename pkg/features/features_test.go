@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package features
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	. "gopkg.in/check.v1"
+
+	"github.com/cilium/cilium/pkg/metrics"
+)
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type FeaturesSuite struct{}
+
+var _ = Suite(&FeaturesSuite{})
+
+func (s *FeaturesSuite) TestFeatureEnabledGauge(c *C) {
+	oldGauge := metrics.FeatureEnabled
+	metrics.FeatureEnabled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Name:      "feature_enabled",
+	}, []string{metrics.LabelFeature})
+	defer func() {
+		metrics.FeatureEnabled = oldGauge
+		mutex.Lock()
+		delete(registered, "test-gauge")
+		delete(cancels, "test-gauge")
+		mutex.Unlock()
+	}()
+
+	err := Register(&Feature{
+		Name:  "test-gauge",
+		Start: func(ctx context.Context) error { return nil },
+	})
+	c.Assert(err, IsNil)
+	c.Assert(metrics.GetGaugeValue(metrics.FeatureEnabled.WithLabelValues("test-gauge")), Equals, float64(0))
+
+	c.Assert(Enable("test-gauge"), IsNil)
+	c.Assert(metrics.GetGaugeValue(metrics.FeatureEnabled.WithLabelValues("test-gauge")), Equals, float64(1))
+
+	// Enabling an already enabled feature must not change the gauge.
+	c.Assert(Enable("test-gauge"), IsNil)
+	c.Assert(metrics.GetGaugeValue(metrics.FeatureEnabled.WithLabelValues("test-gauge")), Equals, float64(1))
+
+	c.Assert(Disable("test-gauge"), IsNil)
+	c.Assert(metrics.GetGaugeValue(metrics.FeatureEnabled.WithLabelValues("test-gauge")), Equals, float64(0))
+
+	c.Assert(Enable("test-gauge"), IsNil)
+	c.Assert(metrics.GetGaugeValue(metrics.FeatureEnabled.WithLabelValues("test-gauge")), Equals, float64(1))
+}
@@ -394,6 +394,15 @@ func (c *DNSCache) Lookup(name string) (ips []net.IP) {
 	return c.lookupByTime(c.lastCleanup, name)
 }
 
+// NumNames returns the number of names cached, including expired entries that
+// have not yet been cleaned up.
+func (c *DNSCache) NumNames() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return len(c.forward)
+}
+
 // lookupByTime takes a timestamp for expiration comparisons, and is only
 // intended for testing.
 func (c *DNSCache) lookupByTime(now time.Time, name string) (ips []net.IP) {
@@ -48,6 +48,41 @@ const (
 	ProxyBindRetryInterval = ProxyBindTimeout / 5
 )
 
+// DNSProxier is the interface to the DNS proxy as needed by the rest of the
+// agent. It is implemented by the in-process DNSProxy, and allows the DNS
+// proxy to be run out of process behind a control API without the callers
+// having to know the difference.
+type DNSProxier interface {
+	// GetBindPort returns the port the proxy is bound to.
+	GetBindPort() uint16
+
+	// GetRules returns the current DNS rules of the endpoint in a
+	// restorable format.
+	GetRules(endpointID uint16) restore.DNSRules
+
+	// RemoveRestoredRules removes any restored rules of the endpoint.
+	RemoveRestoredRules(endpointID uint16)
+
+	// RestoreRules restores the DNS rules of an endpoint of a previous
+	// agent run.
+	RestoreRules(ep *endpoint.Endpoint)
+
+	// SetRejectReply sets the DNS response code for rejected DNS requests.
+	SetRejectReply(opt string)
+
+	// SetRateLimitOverride sets the callback which resolves per-endpoint
+	// limit overrides, e.g. from pod annotations.
+	SetRateLimitOverride(fn DNSRateLimitOverrideFunc)
+
+	// UpdateAllowed replaces the rules for the given endpoint and
+	// destination port with 'newRules'.
+	UpdateAllowed(endpointID uint64, destPort uint16, newRules policy.L7DataMap) error
+
+	// GetRuleStats returns the per-rule enforcement counters of the
+	// queries handled by the proxy.
+	GetRuleStats() []DNSRuleStats
+}
+
 // DNSProxy is a L7 proxy for DNS traffic. It keeps a list of allowed DNS
 // lookups that can be regexps and blocks lookups that are not allowed.
 // A singleton is always running inside cilium-agent.
@@ -113,6 +148,16 @@ type DNSProxy struct {
 	// restored DNS rule.
 	maxIPsPerRestoredDNSRule int
 
+	// sharedProxyState is the rule and policy state of the proxy. It is
+	// shared between all proxy instances serving the same port, see
+	// StartDNSProxies.
+	*sharedProxyState
+}
+
+// sharedProxyState is the mutable policy and rule state of the DNS proxy.
+// Multiple DNSProxy instances bound to the same port via SO_REUSEPORT share a
+// single instance of this state so that rule updates apply to all of them.
+type sharedProxyState struct {
 	// this mutex protects variables below this point
 	lock.Mutex
 
@@ -143,6 +188,32 @@ type DNSProxy struct {
 	// Keeping an LRU cache avoids excessive memory allocations when compiling
 	// regex strings via regex.Compile.
 	regexCompileLRU *lru.Cache
+
+	// ruleStats are the per-rule enforcement counters of the queries
+	// handled by the proxy.
+	ruleStats map[dnsRuleStatsKey]*dnsRuleStatsEntry
+
+	// rateLimiters holds the per-endpoint rate limiting state. Sharing it
+	// between the proxy instances makes the limits apply to the endpoint
+	// as a whole, no matter which instance serves its queries.
+	rateLimiters map[uint64]*epRateLimiter
+
+	// rateLimitOverride resolves per-endpoint limit overrides, e.g. from
+	// pod annotations. nil when no override mechanism is wired up.
+	rateLimitOverride DNSRateLimitOverrideFunc
+}
+
+// newSharedProxyState returns an initialized, empty proxy state.
+func newSharedProxyState() *sharedProxyState {
+	return &sharedProxyState{
+		usedServers:     make(map[string]struct{}),
+		allowed:         make(perEPAllow),
+		restored:        make(perEPRestored),
+		restoredEPs:     make(restoredEPs),
+		regexCompileLRU: lru.New(128),
+		ruleStats:       make(map[dnsRuleStatsKey]*dnsRuleStatsEntry),
+		rateLimiters:    make(map[uint64]*epRateLimiter),
+	}
 }
 
 // perEPAllow maps EndpointIDs to ports + selectors + rules
@@ -177,6 +248,11 @@ func (p *DNSProxy) checkRestored(endpointID uint64, destPort uint16, destIP stri
 	return false
 }
 
+// GetBindPort returns the port the proxy is bound to.
+func (p *DNSProxy) GetBindPort() uint16 {
+	return p.BindPort
+}
+
 // GetRules creates a fresh copy of EP's DNS rules to be stored
 // for later restoration.
 func (p *DNSProxy) GetRules(endpointID uint16) restore.DNSRules {
@@ -374,6 +450,13 @@ func (proxyStat *ProxyRequestContext) IsTimeout() bool {
 // requesting endpoint. Note that denied requests will not trigger this
 // callback.
 func StartDNSProxy(address string, port uint16, enableDNSCompression bool, maxRestoreDNSIPs int, lookupEPFunc LookupEndpointIDByIPFunc, lookupSecIDFunc LookupSecIDByIPFunc, lookupIPsFunc LookupIPsBySecIDFunc, notifyFunc NotifyOnDNSMsgFunc) (*DNSProxy, error) {
+	return startDNSProxy(address, port, enableDNSCompression, maxRestoreDNSIPs, lookupEPFunc, lookupSecIDFunc, lookupIPsFunc, notifyFunc, newSharedProxyState())
+}
+
+// startDNSProxy implements StartDNSProxy for a given, possibly shared, proxy
+// state. All listening sockets are bound with SO_REUSEPORT set, so any number
+// of instances may serve the same port concurrently.
+func startDNSProxy(address string, port uint16, enableDNSCompression bool, maxRestoreDNSIPs int, lookupEPFunc LookupEndpointIDByIPFunc, lookupSecIDFunc LookupSecIDByIPFunc, lookupIPsFunc LookupIPsBySecIDFunc, notifyFunc NotifyOnDNSMsgFunc, state *sharedProxyState) (*DNSProxy, error) {
 	if port == 0 {
 		log.Debug("DNS Proxy port is configured to 0. A random port will be assigned by the OS.")
 	}
@@ -388,13 +471,9 @@ func StartDNSProxy(address string, port uint16, enableDNSCompression bool, maxRe
 		LookupIPsBySecID:         lookupIPsFunc,
 		NotifyOnDNSMsg:           notifyFunc,
 		lookupTargetDNSServer:    lookupTargetDNSServer,
-		usedServers:              make(map[string]struct{}),
-		allowed:                  make(perEPAllow),
-		restored:                 make(perEPRestored),
-		restoredEPs:              make(restoredEPs),
 		EnableDNSCompression:     enableDNSCompression,
 		maxIPsPerRestoredDNSRule: maxRestoreDNSIPs,
-		regexCompileLRU:          lru.New(128),
+		sharedProxyState:         state,
 	}
 	atomic.StoreInt32(&p.rejectReply, dns.RcodeRefused)
 
@@ -451,6 +530,88 @@ func StartDNSProxy(address string, port uint16, enableDNSCompression bool, maxRe
 	return p, nil
 }
 
+// DNSProxyGroup is a set of DNSProxy instances bound to the same port via
+// SO_REUSEPORT. All instances share a single policy and rule state, so rule
+// updates apply to the whole group, while the kernel distributes incoming
+// queries across the instances and keeps the port served as long as at least
+// one listener is alive. It implements DNSProxier.
+type DNSProxyGroup struct {
+	proxies []*DNSProxy
+}
+
+// StartDNSProxies starts 'instances' DNS proxies on the same address and
+// port, sharing their policy and rule state. See StartDNSProxy for the
+// remaining parameters. With a single instance this is equivalent to
+// StartDNSProxy.
+func StartDNSProxies(address string, port uint16, instances int, enableDNSCompression bool, maxRestoreDNSIPs int, lookupEPFunc LookupEndpointIDByIPFunc, lookupSecIDFunc LookupSecIDByIPFunc, lookupIPsFunc LookupIPsBySecIDFunc, notifyFunc NotifyOnDNSMsgFunc) (*DNSProxyGroup, error) {
+	if instances < 1 {
+		return nil, fmt.Errorf("invalid number of DNS proxy instances %d, must be at least 1", instances)
+	}
+
+	state := newSharedProxyState()
+	group := &DNSProxyGroup{
+		proxies: make([]*DNSProxy, 0, instances),
+	}
+	for i := 0; i < instances; i++ {
+		p, err := startDNSProxy(address, port, enableDNSCompression, maxRestoreDNSIPs, lookupEPFunc, lookupSecIDFunc, lookupIPsFunc, notifyFunc, state)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start DNS proxy instance %d/%d: %w", i+1, instances, err)
+		}
+		group.proxies = append(group.proxies, p)
+		// When the port was picked by the kernel, all subsequent
+		// instances must bind to the port of the first one.
+		port = p.BindPort
+	}
+
+	log.WithFields(logrus.Fields{
+		"address":   group.proxies[0].BindAddr,
+		"instances": instances,
+	}).Debug("DNS Proxy instances bound to address")
+
+	return group, nil
+}
+
+// GetBindPort returns the port all proxy instances are bound to.
+func (g *DNSProxyGroup) GetBindPort() uint16 {
+	return g.proxies[0].GetBindPort()
+}
+
+// GetRules returns the current DNS rules of the endpoint in a restorable
+// format. The rule state is shared by all instances.
+func (g *DNSProxyGroup) GetRules(endpointID uint16) restore.DNSRules {
+	return g.proxies[0].GetRules(endpointID)
+}
+
+// RemoveRestoredRules removes any restored rules of the endpoint from the
+// shared rule state.
+func (g *DNSProxyGroup) RemoveRestoredRules(endpointID uint16) {
+	g.proxies[0].RemoveRestoredRules(endpointID)
+}
+
+// RestoreRules restores the DNS rules of an endpoint of a previous agent run
+// into the shared rule state.
+func (g *DNSProxyGroup) RestoreRules(ep *endpoint.Endpoint) {
+	g.proxies[0].RestoreRules(ep)
+}
+
+// SetRejectReply sets the DNS response code for rejected DNS requests for all
+// instances.
+func (g *DNSProxyGroup) SetRejectReply(opt string) {
+	g.proxies[0].SetRejectReply(opt)
+}
+
+// UpdateAllowed replaces the rules for the given endpoint and destination
+// port with 'newRules' in the shared rule state.
+func (g *DNSProxyGroup) UpdateAllowed(endpointID uint64, destPort uint16, newRules policy.L7DataMap) error {
+	return g.proxies[0].UpdateAllowed(endpointID, destPort, newRules)
+}
+
+// GetRuleStats returns the per-rule enforcement counters of the queries
+// handled by the proxy instances, accumulated in the shared rule state.
+func (g *DNSProxyGroup) GetRuleStats() []DNSRuleStats {
+	return g.proxies[0].GetRuleStats()
+}
+
 // LookupEndpointByIP wraps LookupRegisteredEndpoint by falling back to an restored EP, if available
 func (p *DNSProxy) LookupEndpointByIP(ip net.IP) (endpoint *endpoint.Endpoint, err error) {
 	endpoint, err = p.LookupRegisteredEndpoint(ip)
@@ -475,6 +636,15 @@ func (p *DNSProxy) UpdateAllowed(endpointID uint64, destPort uint16, newRules po
 		// Rules were updated based on policy, remove restored rules
 		p.removeRestoredRulesLocked(endpointID)
 	}
+	if err == nil && len(newRules) == 0 {
+		// The rules were removed, drop their enforcement statistics.
+		p.removeRuleStatsLocked(endpointID, destPort)
+	}
+	if _, exists := p.allowed[endpointID]; !exists {
+		// The endpoint has no DNS rules left, drop its rate limiting
+		// state.
+		delete(p.rateLimiters, endpointID)
+	}
 	return err
 }
 
@@ -482,23 +652,31 @@ func (p *DNSProxy) UpdateAllowed(endpointID uint64, destPort uint16, newRules po
 // added to the proxy or restored during restart, and only returns true if this all match
 // something that was added (via UpdateAllowed or RestoreRules) previously.
 func (p *DNSProxy) CheckAllowed(endpointID uint64, destPort uint16, destID identity.NumericIdentity, destIP net.IP, name string) (allowed bool, err error) {
+	allowed, _, err = p.checkAllowed(endpointID, destPort, destID, destIP, name)
+	return allowed, err
+}
+
+// checkAllowed is CheckAllowed, but also returns the pattern of the matched
+// rule for the enforcement statistics. The pattern is empty when no rule
+// matched.
+func (p *DNSProxy) checkAllowed(endpointID uint64, destPort uint16, destID identity.NumericIdentity, destIP net.IP, name string) (allowed bool, matchedRule string, err error) {
 	name = strings.ToLower(dns.Fqdn(name))
 	p.Lock()
 	defer p.Unlock()
 
 	epAllow, exists := p.allowed.getPortRulesForID(endpointID, destPort)
 	if !exists {
-		return p.checkRestored(endpointID, destPort, destIP.String(), name), nil
+		return p.checkRestored(endpointID, destPort, destIP.String(), name), "", nil
 	}
 
 	for selector, re := range epAllow {
 		// The port was matched in getPortRulesForID, above.
 		if selector.Selects(destID) && re.MatchString(name) {
-			return true, nil
+			return true, re.String(), nil
 		}
 	}
 
-	return false, nil
+	return false, "", nil
 }
 
 // ServeDNS handles individual DNS requests forwarded to the proxy, and meets
@@ -546,6 +724,20 @@ func (p *DNSProxy) ServeDNS(w dns.ResponseWriter, request *dns.Msg) {
 
 	scopedLog = scopedLog.WithField(logfields.EndpointID, ep.StringID())
 
+	// Enforce the per-endpoint limits before any upstream work is done for
+	// the query, so that a chatty endpoint cannot starve the others.
+	release, limitReason := p.enforceRateLimit(ep)
+	if limitReason != "" {
+		scopedLog.WithField("reason", limitReason).Debug("Rejecting DNS query from endpoint due to rate limiting")
+		stat.Err = p.sendRefused(scopedLog, w, request)
+		stat.ProcessingTime.End(true)
+		p.NotifyOnDNSMsg(time.Now(), ep, epIPPort, "", request, protocol, false, &stat)
+		return
+	}
+	if release != nil {
+		defer release()
+	}
+
 	targetServerIP, targetServerPort, targetServerAddr, err := p.lookupTargetDNSServer(w)
 	if err != nil {
 		log.WithError(err).Error("cannot extract destination IP:port from DNS request")
@@ -569,7 +761,7 @@ func (p *DNSProxy) ServeDNS(w dns.ResponseWriter, request *dns.Msg) {
 	// Note: The cache doesn't know about the source of the DNS data (yet) and so
 	// it won't enforce any separation between results from different endpoints.
 	// This isn't ideal but we are trusting the DNS responses anyway.
-	allowed, err := p.CheckAllowed(uint64(ep.ID), targetServerPort, targetServerID, targetServerIP, qname)
+	allowed, matchedRule, err := p.checkAllowed(uint64(ep.ID), targetServerPort, targetServerID, targetServerIP, qname)
 	switch {
 	case err != nil:
 		scopedLog.WithError(err).Error("Rejecting DNS query from endpoint due to error")
@@ -581,6 +773,7 @@ func (p *DNSProxy) ServeDNS(w dns.ResponseWriter, request *dns.Msg) {
 
 	case !allowed:
 		scopedLog.Debug("Rejecting DNS query from endpoint due to policy")
+		p.countRuleStat(uint64(ep.ID), targetServerPort, "", dnsRuleDenied)
 		stat.Err = p.sendRefused(scopedLog, w, request)
 		stat.ProcessingTime.End(true)
 		p.NotifyOnDNSMsg(time.Now(), ep, epIPPort, targetServerAddr, request, protocol, false, &stat)
@@ -588,6 +781,7 @@ func (p *DNSProxy) ServeDNS(w dns.ResponseWriter, request *dns.Msg) {
 	}
 
 	scopedLog.Debug("Forwarding DNS request for a name that is allowed")
+	p.countRuleStat(uint64(ep.ID), targetServerPort, matchedRule, dnsRuleAllowed)
 	p.NotifyOnDNSMsg(time.Now(), ep, epIPPort, targetServerAddr, request, protocol, true, &stat)
 
 	// Keep the same L4 protocol. This handles DNS re-requests over TCP, for
@@ -628,6 +822,10 @@ func (p *DNSProxy) ServeDNS(w dns.ResponseWriter, request *dns.Msg) {
 	scopedLog.WithField(logfields.Response, response).Debug("Received DNS response to proxied lookup")
 	stat.Success = true
 
+	if response.Rcode == dns.RcodeNameError {
+		p.countRuleStat(uint64(ep.ID), targetServerPort, matchedRule, dnsRuleNXAnswered)
+	}
+
 	scopedLog.Debug("Notifying with DNS response to original DNS query")
 	p.NotifyOnDNSMsg(time.Now(), ep, epIPPort, targetServerAddr, response, protocol, true, &stat)
 
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package dnsproxy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cilium/cilium/pkg/endpoint"
+	"github.com/cilium/cilium/pkg/metrics"
+	"github.com/cilium/cilium/pkg/option"
+
+	"golang.org/x/time/rate"
+)
+
+// Reasons reported in the reason label of the
+// cilium_fqdn_proxy_dropped_queries_total metric
+const (
+	metricReasonRateLimit   = "rate_limit"
+	metricReasonConcurrency = "concurrency_limit"
+)
+
+// DNSRateLimitOverrideFunc returns the DNS proxy limits of the given
+// endpoint. ok is false when no override exists for the endpoint and the
+// configured default limits apply.
+type DNSRateLimitOverrideFunc func(ep *endpoint.Endpoint) (qps, maxConcurrent int, ok bool)
+
+// epRateLimiter is the rate limiting state of a single endpoint. Accessed
+// with the sharedProxyState mutex held, so the limits apply across all proxy
+// instances serving the same port.
+type epRateLimiter struct {
+	// qps and maxConcurrent are the limits the state was built for. The
+	// state is rebuilt, resetting the token bucket, when they change.
+	qps           int
+	maxConcurrent int
+
+	// limiter enforces qps. nil when qps is 0.
+	limiter *rate.Limiter
+
+	// inFlight is the number of queries of the endpoint currently
+	// forwarded upstream.
+	inFlight int
+}
+
+// ParseDNSRateLimitAnnotation parses the value of the DNSRateLimit pod
+// annotation, of the form "qps=<n>,concurrent=<m>". Either part may be
+// omitted and 0 disables the respective limit.
+func ParseDNSRateLimitAnnotation(value string) (qps, maxConcurrent int, err error) {
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		keyValue := strings.SplitN(part, "=", 2)
+		if len(keyValue) != 2 {
+			return 0, 0, fmt.Errorf("invalid DNS rate limit %q, expected key=value", part)
+		}
+		limit, parseErr := strconv.Atoi(keyValue[1])
+		if parseErr != nil || limit < 0 {
+			return 0, 0, fmt.Errorf("invalid DNS rate limit value %q", keyValue[1])
+		}
+		switch keyValue[0] {
+		case "qps":
+			qps = limit
+		case "concurrent":
+			maxConcurrent = limit
+		default:
+			return 0, 0, fmt.Errorf("unknown DNS rate limit key %q", keyValue[0])
+		}
+	}
+	return qps, maxConcurrent, nil
+}
+
+// SetRateLimitOverride sets the callback which resolves per-endpoint limit
+// overrides, e.g. from pod annotations.
+func (p *DNSProxy) SetRateLimitOverride(fn DNSRateLimitOverrideFunc) {
+	p.Lock()
+	p.rateLimitOverride = fn
+	p.Unlock()
+}
+
+// SetRateLimitOverride sets the callback which resolves per-endpoint limit
+// overrides in the shared proxy state.
+func (g *DNSProxyGroup) SetRateLimitOverride(fn DNSRateLimitOverrideFunc) {
+	g.proxies[0].SetRateLimitOverride(fn)
+}
+
+// enforceRateLimit checks a query of the given endpoint against its QPS and
+// concurrency limits. When the query may proceed, reason is empty and release
+// must be called once the query has been answered; release is nil when no
+// concurrency limit applies. When the query must be dropped, reason names the
+// exhausted limit and the drop is accounted in the
+// cilium_fqdn_proxy_dropped_queries_total metric.
+func (p *DNSProxy) enforceRateLimit(ep *endpoint.Endpoint) (release func(), reason string) {
+	qps := option.Config.ToFQDNsEndpointMaxQPS
+	maxConcurrent := option.Config.ToFQDNsEndpointMaxConcurrent
+
+	p.Lock()
+	defer p.Unlock()
+
+	if p.rateLimitOverride != nil {
+		if oQPS, oMaxConcurrent, ok := p.rateLimitOverride(ep); ok {
+			qps, maxConcurrent = oQPS, oMaxConcurrent
+		}
+	}
+
+	endpointID := uint64(ep.ID)
+	if qps == 0 && maxConcurrent == 0 {
+		delete(p.rateLimiters, endpointID)
+		return nil, ""
+	}
+
+	limiter := p.rateLimiters[endpointID]
+	if limiter == nil || limiter.qps != qps || limiter.maxConcurrent != maxConcurrent {
+		limiter = &epRateLimiter{qps: qps, maxConcurrent: maxConcurrent}
+		if qps > 0 {
+			limiter.limiter = rate.NewLimiter(rate.Limit(qps), qps)
+		}
+		p.rateLimiters[endpointID] = limiter
+	}
+
+	if limiter.maxConcurrent > 0 && limiter.inFlight >= limiter.maxConcurrent {
+		metrics.FQDNProxyDroppedQueries.WithLabelValues(metricReasonConcurrency).Inc()
+		return nil, metricReasonConcurrency
+	}
+	if limiter.limiter != nil && !limiter.limiter.Allow() {
+		metrics.FQDNProxyDroppedQueries.WithLabelValues(metricReasonRateLimit).Inc()
+		return nil, metricReasonRateLimit
+	}
+
+	if limiter.maxConcurrent == 0 {
+		return nil, ""
+	}
+	limiter.inFlight++
+	return func() {
+		p.Lock()
+		limiter.inFlight--
+		p.Unlock()
+	}, ""
+}
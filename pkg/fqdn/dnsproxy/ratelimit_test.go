@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package dnsproxy
+
+import (
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the "go test" runner. The privileged proxy tests
+// provide their own hook, the two files are never built together.
+func Test(t *testing.T) { TestingT(t) }
+
+type DNSProxyRateLimitTestSuite struct{}
+
+var _ = Suite(&DNSProxyRateLimitTestSuite{})
+
+func (s *DNSProxyRateLimitTestSuite) TestParseDNSRateLimitAnnotation(c *C) {
+	qps, maxConcurrent, err := ParseDNSRateLimitAnnotation("qps=20,concurrent=5")
+	c.Assert(err, IsNil)
+	c.Assert(qps, Equals, 20)
+	c.Assert(maxConcurrent, Equals, 5)
+
+	qps, maxConcurrent, err = ParseDNSRateLimitAnnotation(" concurrent=3 ")
+	c.Assert(err, IsNil)
+	c.Assert(qps, Equals, 0)
+	c.Assert(maxConcurrent, Equals, 3)
+
+	qps, maxConcurrent, err = ParseDNSRateLimitAnnotation("")
+	c.Assert(err, IsNil)
+	c.Assert(qps, Equals, 0)
+	c.Assert(maxConcurrent, Equals, 0)
+
+	_, _, err = ParseDNSRateLimitAnnotation("qps")
+	c.Assert(err, NotNil)
+
+	_, _, err = ParseDNSRateLimitAnnotation("qps=-1")
+	c.Assert(err, NotNil)
+
+	_, _, err = ParseDNSRateLimitAnnotation("burst=10")
+	c.Assert(err, NotNil)
+}
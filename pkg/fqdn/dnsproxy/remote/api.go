@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+// Package remote runs the DNS proxy in a separate process supervised by the
+// agent, so that a crash or OOM kill of the proxy does not take down the
+// whole agent.
+//
+// The agent side is implemented by Client, which satisfies
+// dnsproxy.DNSProxier. It spawns the proxy process, restarts it when it
+// exits, and replays the proxy state (reject reply, restored rules and the
+// current DNS rules) after every restart. The proxy side is implemented by
+// Run, invoked through a hidden agent subcommand.
+//
+// The two processes talk over a pair of unix sockets in the agent runtime
+// directory using net/rpc: the agent pushes rules and queries state through
+// the proxy socket, and the proxy resolves endpoints and identities and
+// reports DNS messages back through the agent socket. DNS rules are pushed
+// with the selections of their selectors snapshotted at push time; the agent
+// re-pushes them on every policy update, so identity churn is reflected with
+// the same delay as a policy regeneration.
+package remote
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/cilium/cilium/pkg/fqdn/restore"
+	"github.com/cilium/cilium/pkg/identity"
+	"github.com/cilium/cilium/pkg/logging"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/policy/api"
+	"github.com/cilium/cilium/pkg/source"
+)
+
+var log = logging.DefaultLogger.WithField(logfields.LogSubsys, "dnsproxy-remote")
+
+const (
+	// agentSockName is the socket on which the agent serves the lookup and
+	// notification callbacks to the proxy process.
+	agentSockName = "dnsproxy-agent.sock"
+
+	// proxySockName is the socket on which the proxy process serves the
+	// control API to the agent.
+	proxySockName = "dnsproxy.sock"
+
+	// agentServiceName is the rpc service name of the agent callbacks.
+	agentServiceName = "DNSProxyAgent"
+
+	// proxyServiceName is the rpc service name of the proxy control API.
+	proxyServiceName = "DNSProxyControl"
+
+	// dialTimeout bounds how long one side waits for the socket of the
+	// other to appear.
+	dialTimeout = 10 * time.Second
+
+	// dialRetryInterval is the wait between connection attempts.
+	dialRetryInterval = 100 * time.Millisecond
+
+	// heartbeatInterval is the interval between liveness probes of the
+	// agent by the proxy process. The proxy exits when the agent goes
+	// away so that it never outlives the agent which supervises it.
+	heartbeatInterval = 5 * time.Second
+)
+
+// agentSocketPath returns the path of the agent callback socket.
+func agentSocketPath(runDir string) string {
+	return filepath.Join(runDir, agentSockName)
+}
+
+// proxySocketPath returns the path of the proxy control socket.
+func proxySocketPath(runDir string) string {
+	return filepath.Join(runDir, proxySockName)
+}
+
+// SelectorRule is the serialized form of the DNS rules of one selector of a
+// policy.L7DataMap. The selections of the selector are snapshotted when the
+// rule is serialized, as the live selector cannot cross the process
+// boundary.
+type SelectorRule struct {
+	// Selector is the string representation of the selector, used for
+	// rule statistics.
+	Selector string
+
+	// Wildcard is true when the selector selects all identities.
+	Wildcard bool
+
+	// Selections are the identities selected by the selector at the time
+	// the rule was pushed.
+	Selections []identity.NumericIdentity
+
+	// Rules are the DNS match rules of the selector.
+	Rules []api.PortRuleDNS
+}
+
+// UpdateAllowedRequest replaces the DNS rules of an endpoint and destination
+// port. Empty Rules removes them.
+type UpdateAllowedRequest struct {
+	EndpointID uint64
+	DestPort   uint16
+	Rules      []SelectorRule
+}
+
+// RestoreRulesRequest restores the IP-based DNS rules of an endpoint of a
+// previous agent run.
+type RestoreRulesRequest struct {
+	EndpointID uint16
+	IPv4       string
+	IPv6       string
+	Rules      restore.DNSRules
+}
+
+// HelloResponse is returned by the proxy control Hello call.
+type HelloResponse struct {
+	// BindPort is the port the proxy listeners are bound to.
+	BindPort uint16
+}
+
+// LookupEndpointResponse is the answer to an endpoint lookup by IP.
+type LookupEndpointResponse struct {
+	Exists     bool
+	EndpointID uint16
+}
+
+// LookupSecIDResponse is the answer to an ipcache identity lookup by IP.
+type LookupSecIDResponse struct {
+	Exists bool
+	ID     identity.NumericIdentity
+	Source source.Source
+}
+
+// RateLimitOverrideResponse is the answer to a rate limit override lookup.
+type RateLimitOverrideResponse struct {
+	QPS           int
+	MaxConcurrent int
+	OK            bool
+}
+
+// NotifyRequest carries one DNS message seen by the proxy back to the agent.
+// The message is transported in its wire format.
+type NotifyRequest struct {
+	LookupTime time.Time
+
+	// EndpointID is the endpoint that made the request, zero when the
+	// proxy could not attribute the request to an endpoint.
+	EndpointID uint16
+
+	EpIPPort   string
+	ServerAddr string
+	Msg        []byte
+	Protocol   string
+	Allowed    bool
+
+	// UpstreamTotal is the time spent forwarding the request to the
+	// upstream DNS server, attributed to success or failure via Success.
+	UpstreamTotal time.Duration
+	Success       bool
+
+	// Err is the error of the proxy request, empty when there was none.
+	// ErrIsTimeout is true when the error was a network timeout.
+	Err          string
+	ErrIsTimeout bool
+}
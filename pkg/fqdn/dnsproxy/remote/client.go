@@ -0,0 +1,538 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package remote
+
+import (
+	"fmt"
+	"net"
+	"net/rpc"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cilium/cilium/pkg/endpoint"
+	"github.com/cilium/cilium/pkg/fqdn/dnsproxy"
+	"github.com/cilium/cilium/pkg/fqdn/restore"
+	"github.com/cilium/cilium/pkg/identity"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/metrics"
+	"github.com/cilium/cilium/pkg/policy"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	"github.com/miekg/dns"
+)
+
+// dnsProxyBinaryName is the label under which proxy process starts are
+// accounted in the subprocess start metric.
+const dnsProxyBinaryName = "cilium-dns-proxy"
+
+// Callbacks are the agent functions the proxy process reaches back into
+// while serving DNS requests.
+type Callbacks struct {
+	// LookupEPByIP resolves the endpoint that owns the given IP.
+	LookupEPByIP dnsproxy.LookupEndpointIDByIPFunc
+
+	// LookupSecIDByIP resolves the security identity of an IP from the
+	// ipcache.
+	LookupSecIDByIP dnsproxy.LookupSecIDByIPFunc
+
+	// LookupIPsBySecID resolves the IPs of a security identity from the
+	// ipcache.
+	LookupIPsBySecID dnsproxy.LookupIPsBySecIDFunc
+
+	// NotifyOnDNSMsg ingests DNS messages seen by the proxy.
+	NotifyOnDNSMsg dnsproxy.NotifyOnDNSMsgFunc
+
+	// LookupEndpointByID resolves a local endpoint by its ID. It is used
+	// to attribute proxy notifications to the live endpoint.
+	LookupEndpointByID func(id uint16) *endpoint.Endpoint
+}
+
+// Client supervises the standalone DNS proxy process and implements
+// dnsproxy.DNSProxier against its control API. The DNS rules, restored rules
+// and the reject reply are kept in the client and replayed into the proxy
+// after every restart.
+type Client struct {
+	cfg      Config
+	cbs      Callbacks
+	binary   string
+	listener net.Listener
+
+	// mutex protects the fields below.
+	mutex lock.RWMutex
+
+	// ctrl is the control API connection, nil while the proxy process is
+	// down.
+	ctrl *rpc.Client
+
+	// bindPort is the port the proxy listeners are bound to, learned
+	// from the proxy. Respawned proxies are asked to bind the same port.
+	bindPort uint16
+
+	rejectReply       string
+	rateLimitOverride dnsproxy.DNSRateLimitOverrideFunc
+
+	// allowed and restored mirror the rule state pushed into the proxy
+	// for replay after a restart.
+	allowed  map[uint64]map[uint16][]SelectorRule
+	restored map[uint16]RestoreRulesRequest
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// StartClient spawns the standalone DNS proxy and returns once it is
+// running and its port is known. The proxy is restarted whenever it exits,
+// until Stop is called.
+func StartClient(cfg Config, cbs Callbacks) (*Client, error) {
+	binary, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine the agent binary path: %w", err)
+	}
+
+	c := &Client{
+		cfg:      cfg,
+		cbs:      cbs,
+		binary:   binary,
+		bindPort: cfg.BindPort,
+		allowed:  make(map[uint64]map[uint16][]SelectorRule),
+		restored: make(map[uint16]RestoreRulesRequest),
+		stopCh:   make(chan struct{}),
+	}
+
+	sockPath := agentSocketPath(cfg.RunDir)
+	os.Remove(sockPath)
+	c.listener, err = net.Listen("unix", sockPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen on the agent socket: %w", err)
+	}
+
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.RegisterName(agentServiceName, &agentAPI{client: c}); err != nil {
+		c.listener.Close()
+		return nil, fmt.Errorf("unable to register the agent callbacks: %w", err)
+	}
+	go rpcServer.Accept(c.listener)
+
+	started := make(chan error, 1)
+	go c.supervise(started)
+	if err := <-started; err != nil {
+		c.Stop()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Stop terminates the proxy process and releases the agent socket.
+func (c *Client) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stopCh)
+		c.listener.Close()
+	})
+}
+
+// supervise spawns the proxy process and restarts it when it exits. The
+// error of the first start attempt is reported on 'started'; a failed first
+// start is fatal, later failures lead to another attempt.
+func (c *Client) supervise(started chan<- error) {
+	first := true
+	ready := func() {
+		if first {
+			first = false
+			started <- nil
+		}
+	}
+	for {
+		err, stopped := c.runProxyOnce(ready)
+		if err != nil && first {
+			started <- err
+			return
+		}
+		if stopped {
+			return
+		}
+		if err != nil {
+			log.WithError(err).Warning("DNS proxy process failed, retrying")
+		}
+		// Wait a moment before the restart. If the agent exits, this
+		// is enough time to not spawn another proxy in that case.
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+// runProxyOnce spawns one proxy process and blocks until it exits or the
+// client is stopped. 'ready' is called once the control API of the process
+// is up and the proxy state has been replayed. err is non-nil when the
+// process could not be started or its control API could not be reached;
+// stopped is true when Stop was called.
+func (c *Client) runProxyOnce(ready func()) (err error, stopped bool) {
+	cmd := exec.Command(c.binary, "dns-proxy",
+		"--run-dir", c.cfg.RunDir,
+		"--bind-port", strconv.Itoa(int(c.currentBindPort())),
+		"--instances", strconv.Itoa(c.cfg.Instances),
+		"--enable-dns-compression="+strconv.FormatBool(c.cfg.EnableDNSCompression),
+		"--max-restore-dns-ips", strconv.Itoa(c.cfg.MaxRestoreDNSIPs))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("unable to start the DNS proxy process: %w", err), false
+	}
+	log.WithField("pid", cmd.Process.Pid).Info("DNS proxy process started")
+	metrics.SubprocessStart.WithLabelValues(dnsProxyBinaryName).Inc()
+
+	if err := c.connect(); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return fmt.Errorf("unable to connect to the DNS proxy process: %w", err), false
+	}
+	ready()
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+	select {
+	case err := <-exited:
+		c.disconnect()
+		log.WithError(err).Warning("DNS proxy process exited, restarting")
+		return nil, false
+	case <-c.stopCh:
+		log.WithField("pid", cmd.Process.Pid).Info("Stopping DNS proxy process")
+		cmd.Process.Kill()
+		cmd.Wait()
+		c.disconnect()
+		return nil, true
+	}
+}
+
+// connect dials the control API of a freshly spawned proxy process, learns
+// its bind port and replays the current proxy state into it.
+func (c *Client) connect() error {
+	conn, err := dialRetry(proxySocketPath(c.cfg.RunDir))
+	if err != nil {
+		return err
+	}
+	ctrl := rpc.NewClient(conn)
+
+	var hello HelloResponse
+	if err := ctrl.Call(proxyServiceName+".Hello", struct{}{}, &hello); err != nil {
+		ctrl.Close()
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.bindPort = hello.BindPort
+
+	// Replay the proxy state. The proxy starts empty after a restart.
+	if c.rejectReply != "" {
+		if err := ctrl.Call(proxyServiceName+".SetRejectReply", c.rejectReply, &struct{}{}); err != nil {
+			ctrl.Close()
+			return err
+		}
+	}
+	for _, req := range c.restored {
+		if err := ctrl.Call(proxyServiceName+".RestoreRules", req, &struct{}{}); err != nil {
+			ctrl.Close()
+			return err
+		}
+	}
+	for endpointID, ports := range c.allowed {
+		for destPort, rules := range ports {
+			req := UpdateAllowedRequest{EndpointID: endpointID, DestPort: destPort, Rules: rules}
+			if err := ctrl.Call(proxyServiceName+".UpdateAllowed", req, &struct{}{}); err != nil {
+				ctrl.Close()
+				return err
+			}
+		}
+	}
+
+	c.ctrl = ctrl
+	return nil
+}
+
+// disconnect drops the control API connection of a dead proxy process.
+func (c *Client) disconnect() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.ctrl != nil {
+		c.ctrl.Close()
+		c.ctrl = nil
+	}
+}
+
+// control returns the current control API connection, or nil while the
+// proxy process is down.
+func (c *Client) control() *rpc.Client {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.ctrl
+}
+
+func (c *Client) currentBindPort() uint16 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.bindPort
+}
+
+// GetBindPort returns the port the proxy is bound to.
+func (c *Client) GetBindPort() uint16 {
+	return c.currentBindPort()
+}
+
+// GetRules returns the current DNS rules of the endpoint in a restorable
+// format.
+func (c *Client) GetRules(endpointID uint16) restore.DNSRules {
+	ctrl := c.control()
+	if ctrl == nil {
+		return nil
+	}
+	var rules restore.DNSRules
+	if err := ctrl.Call(proxyServiceName+".GetRules", endpointID, &rules); err != nil {
+		log.WithError(err).Warning("Unable to fetch DNS rules from the DNS proxy process")
+		return nil
+	}
+	return rules
+}
+
+// RemoveRestoredRules removes any restored rules of the endpoint.
+func (c *Client) RemoveRestoredRules(endpointID uint16) {
+	c.mutex.Lock()
+	delete(c.restored, endpointID)
+	ctrl := c.ctrl
+	c.mutex.Unlock()
+	if ctrl == nil {
+		return
+	}
+	if err := ctrl.Call(proxyServiceName+".RemoveRestoredRules", endpointID, &struct{}{}); err != nil {
+		log.WithError(err).Warning("Unable to remove restored rules from the DNS proxy process")
+	}
+}
+
+// RestoreRules restores the DNS rules of an endpoint of a previous agent
+// run.
+func (c *Client) RestoreRules(ep *endpoint.Endpoint) {
+	req := RestoreRulesRequest{EndpointID: ep.ID, Rules: ep.DNSRules}
+	if ep.IPv4.IsSet() {
+		req.IPv4 = ep.IPv4.String()
+	}
+	if ep.IPv6.IsSet() {
+		req.IPv6 = ep.IPv6.String()
+	}
+
+	c.mutex.Lock()
+	c.restored[ep.ID] = req
+	ctrl := c.ctrl
+	c.mutex.Unlock()
+	if ctrl == nil {
+		return
+	}
+	if err := ctrl.Call(proxyServiceName+".RestoreRules", req, &struct{}{}); err != nil {
+		log.WithError(err).Warning("Unable to restore rules in the DNS proxy process")
+	}
+}
+
+// SetRejectReply sets the DNS response code for rejected DNS requests.
+func (c *Client) SetRejectReply(opt string) {
+	c.mutex.Lock()
+	c.rejectReply = opt
+	ctrl := c.ctrl
+	c.mutex.Unlock()
+	if ctrl == nil {
+		return
+	}
+	if err := ctrl.Call(proxyServiceName+".SetRejectReply", opt, &struct{}{}); err != nil {
+		log.WithError(err).Warning("Unable to set the reject reply of the DNS proxy process")
+	}
+}
+
+// SetRateLimitOverride sets the callback which resolves per-endpoint limit
+// overrides. The callback runs in the agent; the proxy process queries it
+// through the agent socket.
+func (c *Client) SetRateLimitOverride(fn dnsproxy.DNSRateLimitOverrideFunc) {
+	c.mutex.Lock()
+	c.rateLimitOverride = fn
+	c.mutex.Unlock()
+}
+
+// UpdateAllowed replaces the rules for the given endpoint and destination
+// port with 'newRules'. The rules are serialized with the selections of
+// their selectors snapshotted; identity churn is picked up with the next
+// policy update. When the proxy process is down the rules are recorded and
+// replayed once it is respawned.
+func (c *Client) UpdateAllowed(endpointID uint64, destPort uint16, newRules policy.L7DataMap) error {
+	rules := serializeRules(newRules)
+
+	c.mutex.Lock()
+	if rules == nil {
+		ports := c.allowed[endpointID]
+		delete(ports, destPort)
+		if len(ports) == 0 {
+			delete(c.allowed, endpointID)
+		}
+	} else {
+		ports, ok := c.allowed[endpointID]
+		if !ok {
+			ports = make(map[uint16][]SelectorRule)
+			c.allowed[endpointID] = ports
+		}
+		ports[destPort] = rules
+	}
+	ctrl := c.ctrl
+	c.mutex.Unlock()
+
+	if ctrl == nil {
+		// The rules are replayed when the proxy process comes back.
+		return nil
+	}
+	req := UpdateAllowedRequest{EndpointID: endpointID, DestPort: destPort, Rules: rules}
+	return ctrl.Call(proxyServiceName+".UpdateAllowed", req, &struct{}{})
+}
+
+// GetRuleStats returns the per-rule enforcement counters of the queries
+// handled by the proxy.
+func (c *Client) GetRuleStats() []dnsproxy.DNSRuleStats {
+	ctrl := c.control()
+	if ctrl == nil {
+		return nil
+	}
+	var stats []dnsproxy.DNSRuleStats
+	if err := ctrl.Call(proxyServiceName+".GetRuleStats", struct{}{}, &stats); err != nil {
+		log.WithError(err).Warning("Unable to fetch rule statistics from the DNS proxy process")
+		return nil
+	}
+	return stats
+}
+
+// serializeRules converts an L7DataMap into its wire form, snapshotting the
+// selections of the selectors. nil L7 rules of a selector allow all names,
+// mirroring the in-process proxy.
+func serializeRules(newRules policy.L7DataMap) []SelectorRule {
+	if len(newRules) == 0 {
+		return nil
+	}
+	rules := make([]SelectorRule, 0, len(newRules))
+	for selector, l7Rules := range newRules {
+		rule := SelectorRule{
+			Selector: selector.String(),
+			Wildcard: selector.IsWildcard(),
+		}
+		rule.Selections = append(rule.Selections, selector.GetSelections()...)
+		if l7Rules == nil {
+			rule.Rules = []api.PortRuleDNS{{MatchPattern: "*"}}
+		} else {
+			rule.Rules = l7Rules.DNS
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// agentAPI is the rpc receiver of the callbacks served to the proxy
+// process.
+type agentAPI struct {
+	client *Client
+}
+
+// Ping is the liveness probe of the proxy process.
+func (a *agentAPI) Ping(_ struct{}, _ *struct{}) error {
+	return nil
+}
+
+// LookupEndpointByIP resolves the endpoint that owns the given IP.
+func (a *agentAPI) LookupEndpointByIP(ip string, resp *LookupEndpointResponse) error {
+	ep, err := a.client.cbs.LookupEPByIP(net.ParseIP(ip))
+	if err != nil || ep == nil {
+		return nil
+	}
+	resp.Exists = true
+	resp.EndpointID = ep.ID
+	return nil
+}
+
+// LookupSecIDByIP resolves the security identity of an IP from the ipcache.
+func (a *agentAPI) LookupSecIDByIP(ip string, resp *LookupSecIDResponse) error {
+	id, exists := a.client.cbs.LookupSecIDByIP(net.ParseIP(ip))
+	resp.Exists = exists
+	resp.ID = id.ID
+	resp.Source = id.Source
+	return nil
+}
+
+// LookupIPsBySecID resolves the IPs of a security identity from the
+// ipcache.
+func (a *agentAPI) LookupIPsBySecID(nid identity.NumericIdentity, resp *[]string) error {
+	*resp = a.client.cbs.LookupIPsBySecID(nid)
+	return nil
+}
+
+// RateLimitOverride resolves the rate limit override of an endpoint.
+func (a *agentAPI) RateLimitOverride(endpointID uint16, resp *RateLimitOverrideResponse) error {
+	a.client.mutex.RLock()
+	fn := a.client.rateLimitOverride
+	a.client.mutex.RUnlock()
+	if fn == nil {
+		return nil
+	}
+	ep := a.client.cbs.LookupEndpointByID(endpointID)
+	if ep == nil {
+		return nil
+	}
+	resp.QPS, resp.MaxConcurrent, resp.OK = fn(ep)
+	return nil
+}
+
+// NotifyOnDNSMsg ingests a DNS message seen by the proxy process.
+func (a *agentAPI) NotifyOnDNSMsg(req NotifyRequest, _ *struct{}) error {
+	var ep *endpoint.Endpoint
+	if req.EndpointID != 0 {
+		if ep = a.client.cbs.LookupEndpointByID(req.EndpointID); ep == nil {
+			// The endpoint is gone, there is nothing to attribute
+			// the message to anymore.
+			return nil
+		}
+	}
+
+	msg := &dns.Msg{}
+	if len(req.Msg) > 0 {
+		if err := msg.Unpack(req.Msg); err != nil {
+			return err
+		}
+	}
+
+	stat := &dnsproxy.ProxyRequestContext{Success: req.Success}
+	stat.UpstreamTime.Add(req.Success, req.UpstreamTotal)
+	if req.Err != "" {
+		stat.Err = &proxyRequestError{msg: req.Err, timeout: req.ErrIsTimeout}
+	}
+
+	return a.client.cbs.NotifyOnDNSMsg(req.LookupTime, ep, req.EpIPPort, req.ServerAddr, msg, req.Protocol, req.Allowed, stat)
+}
+
+// proxyRequestError reconstructs a proxy request error received over the
+// wire. It implements net.Error so that timeouts are still classified as
+// such by ProxyRequestContext.IsTimeout.
+type proxyRequestError struct {
+	msg     string
+	timeout bool
+}
+
+func (e *proxyRequestError) Error() string {
+	return e.msg
+}
+
+func (e *proxyRequestError) Timeout() bool {
+	return e.timeout
+}
+
+func (e *proxyRequestError) Temporary() bool {
+	return e.timeout
+}
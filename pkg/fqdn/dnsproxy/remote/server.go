@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package remote
+
+import (
+	"fmt"
+	"net"
+	"net/rpc"
+	"os"
+	"time"
+
+	"github.com/cilium/cilium/pkg/addressing"
+	"github.com/cilium/cilium/pkg/endpoint"
+	"github.com/cilium/cilium/pkg/fqdn/dnsproxy"
+	"github.com/cilium/cilium/pkg/fqdn/restore"
+	"github.com/cilium/cilium/pkg/identity"
+	"github.com/cilium/cilium/pkg/ipcache"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/policy"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	"github.com/miekg/dns"
+)
+
+// Config is the configuration of the standalone DNS proxy process. It
+// mirrors the agent options the in-process proxy is started with.
+type Config struct {
+	// RunDir is the agent runtime directory holding the sockets.
+	RunDir string
+
+	// BindPort is the port to bind the DNS listeners to. Zero lets the
+	// kernel pick one; the agent learns the port through Hello.
+	BindPort uint16
+
+	// Instances is the number of proxy instances to bind to the port.
+	Instances int
+
+	// EnableDNSCompression allows the proxy to compress responses.
+	EnableDNSCompression bool
+
+	// MaxRestoreDNSIPs is the maximum number of IPs to maintain for each
+	// restored DNS rule.
+	MaxRestoreDNSIPs int
+}
+
+// server is the proxy process side of the remote DNS proxy. It hosts the
+// actual dnsproxy instances and bridges their callbacks to the agent over
+// the agent socket.
+type server struct {
+	proxy dnsproxy.DNSProxier
+	agent *rpc.Client
+
+	// mutex protects endpoints.
+	mutex lock.RWMutex
+
+	// endpoints caches the endpoint stubs handed to the proxy, keyed by
+	// endpoint ID. The proxy only reads the ID of the endpoints it looks
+	// up, the real endpoint state lives in the agent.
+	endpoints map[uint16]*endpoint.Endpoint
+}
+
+// Run starts the standalone DNS proxy and serves its control API until the
+// agent goes away or an error occurs. It is the entry point of the hidden
+// agent subcommand the supervising agent spawns.
+func Run(cfg Config) error {
+	agentConn, err := dialRetry(agentSocketPath(cfg.RunDir))
+	if err != nil {
+		return fmt.Errorf("unable to connect to the agent socket: %w", err)
+	}
+
+	s := &server{
+		agent:     rpc.NewClient(agentConn),
+		endpoints: make(map[uint16]*endpoint.Endpoint),
+	}
+
+	s.proxy, err = dnsproxy.StartDNSProxies("", cfg.BindPort, cfg.Instances,
+		cfg.EnableDNSCompression, cfg.MaxRestoreDNSIPs,
+		s.lookupEndpointByIP, s.lookupSecIDByIP, s.lookupIPsBySecID, s.notifyOnDNSMsg)
+	if err != nil {
+		return fmt.Errorf("unable to start the DNS proxy: %w", err)
+	}
+	s.proxy.SetRateLimitOverride(s.lookupRateLimitOverride)
+
+	sockPath := proxySocketPath(cfg.RunDir)
+	os.Remove(sockPath)
+	listener, err := net.Listen("unix", sockPath)
+	if err != nil {
+		return fmt.Errorf("unable to listen on the proxy socket: %w", err)
+	}
+	defer listener.Close()
+
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.RegisterName(proxyServiceName, &control{server: s}); err != nil {
+		return fmt.Errorf("unable to register the control API: %w", err)
+	}
+	go rpcServer.Accept(listener)
+
+	log.WithField("port", s.proxy.GetBindPort()).Info("Standalone DNS proxy running")
+
+	// The proxy must never outlive the agent that supervises it: it would
+	// keep the proxy port bound and block the restarted agent from
+	// spawning a fresh proxy. Probe the agent and exit when it goes away.
+	for {
+		time.Sleep(heartbeatInterval)
+		if err := s.agent.Call(agentServiceName+".Ping", struct{}{}, &struct{}{}); err != nil {
+			return fmt.Errorf("agent is gone: %w", err)
+		}
+	}
+}
+
+// dialRetry connects to a unix socket, retrying until dialTimeout while the
+// socket does not exist yet.
+func dialRetry(path string) (net.Conn, error) {
+	var (
+		conn net.Conn
+		err  error
+	)
+	for deadline := time.Now().Add(dialTimeout); ; {
+		conn, err = net.Dial("unix", path)
+		if err == nil || time.Now().After(deadline) {
+			return conn, err
+		}
+		time.Sleep(dialRetryInterval)
+	}
+}
+
+// endpointStub returns the cached endpoint stub for the given ID, creating
+// it when needed.
+func (s *server) endpointStub(id uint16) *endpoint.Endpoint {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	ep, ok := s.endpoints[id]
+	if !ok {
+		ep = &endpoint.Endpoint{ID: id}
+		s.endpoints[id] = ep
+	}
+	return ep
+}
+
+func (s *server) lookupEndpointByIP(ip net.IP) (*endpoint.Endpoint, error) {
+	var resp LookupEndpointResponse
+	if err := s.agent.Call(agentServiceName+".LookupEndpointByIP", ip.String(), &resp); err != nil {
+		return nil, err
+	}
+	if !resp.Exists {
+		return nil, fmt.Errorf("Cannot find endpoint with IP %s", ip.String())
+	}
+	return s.endpointStub(resp.EndpointID), nil
+}
+
+func (s *server) lookupSecIDByIP(ip net.IP) (ipcache.Identity, bool) {
+	var resp LookupSecIDResponse
+	if err := s.agent.Call(agentServiceName+".LookupSecIDByIP", ip.String(), &resp); err != nil {
+		log.WithError(err).Warning("Identity lookup in the agent failed")
+		return ipcache.Identity{}, false
+	}
+	return ipcache.Identity{ID: resp.ID, Source: resp.Source}, resp.Exists
+}
+
+func (s *server) lookupIPsBySecID(nid identity.NumericIdentity) []string {
+	var ips []string
+	if err := s.agent.Call(agentServiceName+".LookupIPsBySecID", nid, &ips); err != nil {
+		log.WithError(err).Warning("Identity to IP lookup in the agent failed")
+		return nil
+	}
+	return ips
+}
+
+func (s *server) lookupRateLimitOverride(ep *endpoint.Endpoint) (qps, maxConcurrent int, ok bool) {
+	var resp RateLimitOverrideResponse
+	if err := s.agent.Call(agentServiceName+".RateLimitOverride", ep.ID, &resp); err != nil {
+		log.WithError(err).Warning("Rate limit override lookup in the agent failed")
+		return 0, 0, false
+	}
+	return resp.QPS, resp.MaxConcurrent, resp.OK
+}
+
+func (s *server) notifyOnDNSMsg(lookupTime time.Time, ep *endpoint.Endpoint, epIPPort string, serverAddr string, msg *dns.Msg, protocol string, allowed bool, stat *dnsproxy.ProxyRequestContext) error {
+	req := NotifyRequest{
+		LookupTime: lookupTime,
+		EpIPPort:   epIPPort,
+		ServerAddr: serverAddr,
+		Protocol:   protocol,
+		Allowed:    allowed,
+	}
+	if ep != nil {
+		req.EndpointID = ep.ID
+	}
+	if msg != nil {
+		packed, err := msg.Pack()
+		if err != nil {
+			return err
+		}
+		req.Msg = packed
+	}
+	if stat != nil {
+		req.UpstreamTotal = stat.UpstreamTime.Total()
+		req.Success = stat.Success
+		if stat.Err != nil {
+			req.Err = stat.Err.Error()
+			req.ErrIsTimeout = stat.IsTimeout()
+		}
+	}
+	return s.agent.Call(agentServiceName+".NotifyOnDNSMsg", req, &struct{}{})
+}
+
+// control is the rpc receiver of the proxy control API served to the agent.
+type control struct {
+	server *server
+}
+
+// Hello reports the port the proxy listeners are bound to.
+func (c *control) Hello(_ struct{}, resp *HelloResponse) error {
+	resp.BindPort = c.server.proxy.GetBindPort()
+	return nil
+}
+
+// UpdateAllowed replaces the DNS rules of an endpoint and destination port
+// with the pushed rules.
+func (c *control) UpdateAllowed(req UpdateAllowedRequest, _ *struct{}) error {
+	var rules policy.L7DataMap
+	if len(req.Rules) > 0 {
+		rules = make(policy.L7DataMap, len(req.Rules))
+		for _, r := range req.Rules {
+			rules[&staticSelector{
+				name:       r.Selector,
+				wildcard:   r.Wildcard,
+				selections: r.Selections,
+			}] = &policy.PerSelectorPolicy{L7Rules: api.L7Rules{DNS: r.Rules}}
+		}
+	}
+	return c.server.proxy.UpdateAllowed(req.EndpointID, req.DestPort, rules)
+}
+
+// SetRejectReply sets the DNS response code for rejected DNS requests.
+func (c *control) SetRejectReply(reply string, _ *struct{}) error {
+	c.server.proxy.SetRejectReply(reply)
+	return nil
+}
+
+// GetRules returns the DNS rules of the endpoint in a restorable format.
+func (c *control) GetRules(endpointID uint16, resp *restore.DNSRules) error {
+	*resp = c.server.proxy.GetRules(endpointID)
+	return nil
+}
+
+// RestoreRules restores the DNS rules of an endpoint of a previous agent
+// run.
+func (c *control) RestoreRules(req RestoreRulesRequest, _ *struct{}) error {
+	ep := &endpoint.Endpoint{ID: req.EndpointID, DNSRules: req.Rules}
+	if req.IPv4 != "" {
+		ip, err := addressing.NewCiliumIPv4(req.IPv4)
+		if err != nil {
+			return err
+		}
+		ep.IPv4 = ip
+	}
+	if req.IPv6 != "" {
+		ip, err := addressing.NewCiliumIPv6(req.IPv6)
+		if err != nil {
+			return err
+		}
+		ep.IPv6 = ip
+	}
+	c.server.proxy.RestoreRules(ep)
+	return nil
+}
+
+// RemoveRestoredRules removes any restored rules of the endpoint.
+func (c *control) RemoveRestoredRules(endpointID uint16, _ *struct{}) error {
+	c.server.proxy.RemoveRestoredRules(endpointID)
+	return nil
+}
+
+// GetRuleStats returns the per-rule enforcement counters of the queries
+// handled by the proxy.
+func (c *control) GetRuleStats(_ struct{}, resp *[]dnsproxy.DNSRuleStats) error {
+	*resp = c.server.proxy.GetRuleStats()
+	return nil
+}
+
+// staticSelector is a policy.CachedSelector with the selections frozen to
+// those pushed by the agent. The agent re-pushes the rules on every policy
+// update, refreshing the selections.
+type staticSelector struct {
+	name       string
+	wildcard   bool
+	selections []identity.NumericIdentity
+}
+
+func (s *staticSelector) GetSelections() []identity.NumericIdentity {
+	return s.selections
+}
+
+func (s *staticSelector) Selects(nid identity.NumericIdentity) bool {
+	if s.wildcard {
+		return true
+	}
+	for _, id := range s.selections {
+		if id == nid {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *staticSelector) IsWildcard() bool {
+	return s.wildcard
+}
+
+func (s *staticSelector) IsNone() bool {
+	return false
+}
+
+func (s *staticSelector) String() string {
+	return s.name
+}
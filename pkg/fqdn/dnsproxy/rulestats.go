@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package dnsproxy
+
+import (
+	"sort"
+)
+
+// DNSRuleStats are the enforcement counters of a single DNS rule of an
+// endpoint, as observed by the DNS proxy. Queries that match no rule are
+// reported with an empty rule.
+type DNSRuleStats struct {
+	// EndpointID is the endpoint the rule applies to.
+	EndpointID uint64 `json:"endpoint-id"`
+
+	// DestPort is the destination port the rule applies to.
+	DestPort uint16 `json:"dest-port"`
+
+	// Rule is the compiled pattern of the matched DNS rule. Empty for
+	// queries that matched no rule.
+	Rule string `json:"rule,omitempty"`
+
+	// Allowed is the number of queries allowed by the rule.
+	Allowed uint64 `json:"allowed"`
+
+	// Denied is the number of queries denied. Only set for the empty
+	// rule, as denied queries match no rule by definition.
+	Denied uint64 `json:"denied"`
+
+	// NXAnswered is the number of allowed queries answered with NXDOMAIN
+	// by the upstream DNS server.
+	NXAnswered uint64 `json:"nx-answered"`
+}
+
+// dnsRuleStatsKey identifies the rule a statistics entry belongs to.
+type dnsRuleStatsKey struct {
+	endpointID uint64
+	destPort   uint16
+	rule       string
+}
+
+// dnsRuleStatsEntry holds the counters of a single rule. Accessed with the
+// sharedProxyState mutex held.
+type dnsRuleStatsEntry struct {
+	allowed    uint64
+	denied     uint64
+	nxAnswered uint64
+}
+
+type dnsRuleStat int
+
+const (
+	dnsRuleAllowed dnsRuleStat = iota
+	dnsRuleDenied
+	dnsRuleNXAnswered
+)
+
+// countRuleStat increments the given counter of the given rule.
+func (p *sharedProxyState) countRuleStat(endpointID uint64, destPort uint16, rule string, stat dnsRuleStat) {
+	p.Lock()
+	defer p.Unlock()
+
+	key := dnsRuleStatsKey{
+		endpointID: endpointID,
+		destPort:   destPort,
+		rule:       rule,
+	}
+	entry, ok := p.ruleStats[key]
+	if !ok {
+		entry = &dnsRuleStatsEntry{}
+		p.ruleStats[key] = entry
+	}
+	switch stat {
+	case dnsRuleAllowed:
+		entry.allowed++
+	case dnsRuleDenied:
+		entry.denied++
+	case dnsRuleNXAnswered:
+		entry.nxAnswered++
+	}
+}
+
+// removeRuleStatsLocked removes the statistics of the rules of the given
+// endpoint and destination port. Must be called with the sharedProxyState
+// mutex held.
+func (p *sharedProxyState) removeRuleStatsLocked(endpointID uint64, destPort uint16) {
+	for key := range p.ruleStats {
+		if key.endpointID == endpointID && key.destPort == destPort {
+			delete(p.ruleStats, key)
+		}
+	}
+}
+
+// GetRuleStats returns the enforcement counters of all DNS rules, sorted by
+// endpoint, destination port and rule.
+func (p *sharedProxyState) GetRuleStats() []DNSRuleStats {
+	p.Lock()
+	defer p.Unlock()
+
+	stats := make([]DNSRuleStats, 0, len(p.ruleStats))
+	for key, entry := range p.ruleStats {
+		stats = append(stats, DNSRuleStats{
+			EndpointID: key.endpointID,
+			DestPort:   key.destPort,
+			Rule:       key.rule,
+			Allowed:    entry.allowed,
+			Denied:     entry.denied,
+			NXAnswered: entry.nxAnswered,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].EndpointID != stats[j].EndpointID {
+			return stats[i].EndpointID < stats[j].EndpointID
+		}
+		if stats[i].DestPort != stats[j].DestPort {
+			return stats[i].DestPort < stats[j].DestPort
+		}
+		return stats[i].Rule < stats[j].Rule
+	})
+	return stats
+}
@@ -5,8 +5,11 @@ package fqdn
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"regexp"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,6 +19,7 @@ import (
 	"github.com/cilium/cilium/pkg/ipcache"
 	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/metrics"
 	"github.com/cilium/cilium/pkg/policy/api"
 
 	"github.com/sirupsen/logrus"
@@ -39,6 +43,11 @@ type NameManager struct {
 	// use these selectors to map selectors --> IPs.
 	allSelectors map[api.FQDNSelector]*regexp.Regexp
 
+	// selectorIPs tracks the set of IPs currently associated with each
+	// registered FQDNSelector, for per-selector metrics and the debug
+	// top-K view.
+	selectorIPs map[api.FQDNSelector]map[string]struct{}
+
 	// cache is a private copy of the pointer from config.
 	cache *DNSCache
 
@@ -131,6 +140,9 @@ func (n *NameManager) RegisterForIdentityUpdatesLocked(selector api.FQDNSelector
 // which correspond to said selector are propagated.
 func (n *NameManager) UnregisterForIdentityUpdatesLocked(selector api.FQDNSelector) {
 	delete(n.allSelectors, selector)
+	delete(n.selectorIPs, selector)
+	metrics.FQDNSelectorIPs.DeleteLabelValues(selector.String())
+	metrics.FQDNSelectorIPChurn.DeleteLabelValues(selector.String())
 }
 
 // NewNameManager creates an initialized NameManager.
@@ -150,6 +162,7 @@ func NewNameManager(config Config) *NameManager {
 	return &NameManager{
 		config:       config,
 		allSelectors: make(map[api.FQDNSelector]*regexp.Regexp),
+		selectorIPs:  make(map[api.FQDNSelector]map[string]struct{}),
 		cache:        config.Cache,
 	}
 
@@ -181,6 +194,7 @@ func (n *NameManager) UpdateGenerateDNS(ctx context.Context, lookupTime time.Tim
 		log.WithField(logfields.DNSName, namesMissingIPs).
 			Debug("No IPs to insert when generating DNS name selected by ToFQDN rule")
 	}
+	n.updateSelectorIPMetrics(selectorIPMapping)
 
 	return n.config.UpdateSelectors(ctx, selectorIPMapping, namesMissingIPs)
 }
@@ -208,6 +222,7 @@ func (n *NameManager) ForceGenerateDNS(ctx context.Context, namesToRegen []strin
 		log.WithField(logfields.DNSName, namesMissingIPs).
 			Debug("No IPs to insert when generating DNS name selected by ToFQDN rule")
 	}
+	n.updateSelectorIPMetrics(selectorIPMapping)
 
 	// Emit the new rules.
 	// Ignore newly allocated IDs (2nd result) as this is only used for deletes.
@@ -215,6 +230,63 @@ func (n *NameManager) ForceGenerateDNS(ctx context.Context, namesToRegen []strin
 	return wg, err
 }
 
+// updateSelectorIPMetrics records the per-selector IP count and churn for the
+// given selector to IP mapping.
+// Must be called with n.Mutex held.
+func (n *NameManager) updateSelectorIPMetrics(selectorIPMapping map[api.FQDNSelector][]net.IP) {
+	for sel, ips := range selectorIPMapping {
+		newIPs := make(map[string]struct{}, len(ips))
+		for _, ip := range ips {
+			newIPs[ip.String()] = struct{}{}
+		}
+		churn := 0
+		oldIPs := n.selectorIPs[sel]
+		for ip := range newIPs {
+			if _, exists := oldIPs[ip]; !exists {
+				churn++
+			}
+		}
+		for ip := range oldIPs {
+			if _, exists := newIPs[ip]; !exists {
+				churn++
+			}
+		}
+		n.selectorIPs[sel] = newIPs
+		metrics.FQDNSelectorIPs.WithLabelValues(sel.String()).Set(float64(len(newIPs)))
+		if churn > 0 {
+			metrics.FQDNSelectorIPChurn.WithLabelValues(sel.String()).Add(float64(churn))
+		}
+	}
+}
+
+// DebugStatus returns the registered ToFQDNs selectors with the most
+// associated IPs, implementing debug.StatusObject.
+func (n *NameManager) DebugStatus() string {
+	const topK = 25
+
+	n.Mutex.Lock()
+	selectors := make([]api.FQDNSelector, 0, len(n.selectorIPs))
+	counts := make(map[api.FQDNSelector]int, len(n.selectorIPs))
+	for sel, ips := range n.selectorIPs {
+		selectors = append(selectors, sel)
+		counts[sel] = len(ips)
+	}
+	n.Mutex.Unlock()
+
+	sort.Slice(selectors, func(i, j int) bool {
+		return counts[selectors[i]] > counts[selectors[j]]
+	})
+	if len(selectors) > topK {
+		selectors = selectors[:topK]
+	}
+
+	var sb strings.Builder
+	for _, sel := range selectors {
+		fmt.Fprintf(&sb, "%s: %d IPs\n", sel.String(), counts[sel])
+	}
+	return sb.String()
+}
+
 func (n *NameManager) CompleteBootstrap() {
 	n.Lock()
 	n.bootstrapCompleted = true
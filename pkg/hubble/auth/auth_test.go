@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy([]byte(`{"tenant-a": ["ns-a", "ns-b"], "admin": ["*"]}`))
+	require.NoError(t, err)
+
+	namespaces, ok := policy.AllowedNamespaces("tenant-a")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"ns-a", "ns-b"}, namespaces)
+
+	_, ok = policy.AllowedNamespaces("tenant-b")
+	assert.False(t, ok)
+
+	_, err = ParsePolicy([]byte(`{"tenant-a": []}`))
+	assert.Error(t, err)
+	_, err = ParsePolicy([]byte(`{"": ["ns-a"]}`))
+	assert.Error(t, err)
+	_, err = ParsePolicy([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestAllowFlow(t *testing.T) {
+	flow := func(srcNS, dstNS string) *flowpb.Flow {
+		return &flowpb.Flow{
+			Source:      &flowpb.Endpoint{Namespace: srcNS},
+			Destination: &flowpb.Endpoint{Namespace: dstNS},
+		}
+	}
+
+	// no authorization in place
+	assert.True(t, AllowFlow(context.Background(), flow("ns-a", "ns-b")))
+
+	ctx := newContext(context.Background(), []string{"ns-a"})
+	assert.True(t, AllowFlow(ctx, flow("ns-a", "ns-b")))
+	assert.True(t, AllowFlow(ctx, flow("ns-b", "ns-a")))
+	assert.False(t, AllowFlow(ctx, flow("ns-b", "ns-c")))
+	// flows without namespaces require the wildcard
+	assert.False(t, AllowFlow(ctx, flow("", "")))
+	assert.True(t, AllowFlow(ctx, nil))
+
+	wildcard := newContext(context.Background(), []string{Wildcard})
+	assert.True(t, AllowFlow(wildcard, flow("ns-b", "ns-c")))
+	assert.True(t, AllowFlow(wildcard, flow("", "")))
+}
+
+func TestAuthorize(t *testing.T) {
+	policy, err := ParsePolicy([]byte(`{"secret-token": ["ns-a"]}`))
+	require.NoError(t, err)
+
+	// no identity presented
+	_, err = policy.authorize(context.Background())
+	assert.Error(t, err)
+
+	// unknown bearer token
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs("authorization", "Bearer other-token"))
+	_, err = policy.authorize(ctx)
+	assert.Error(t, err)
+
+	// authorized bearer token
+	ctx = metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs("authorization", "Bearer secret-token"))
+	authorized, err := policy.authorize(ctx)
+	require.NoError(t, err)
+	assert.True(t, AllowFlow(authorized, &flowpb.Flow{
+		Source: &flowpb.Endpoint{Namespace: "ns-a"},
+	}))
+	assert.False(t, AllowFlow(authorized, &flowpb.Flow{
+		Source: &flowpb.Endpoint{Namespace: "ns-b"},
+	}))
+}
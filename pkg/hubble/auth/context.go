@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package auth
+
+import (
+	"context"
+
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+)
+
+type contextKey struct{}
+
+// namespacesKey carries the set of authorized namespaces in the request
+// context, placed there by the server interceptors.
+var namespacesKey = contextKey{}
+
+// newContext returns a context carrying the given set of authorized
+// namespaces.
+func newContext(ctx context.Context, namespaces []string) context.Context {
+	set := make(map[string]struct{}, len(namespaces))
+	for _, ns := range namespaces {
+		set[ns] = struct{}{}
+	}
+	return context.WithValue(ctx, namespacesKey, set)
+}
+
+// AllowFlow reports whether the given flow may be delivered on the request
+// context. Flows are allowed when no authorization is in place for the
+// request, or when the source or destination namespace of the flow is
+// authorized. Flows not scoped to any namespace (e.g. host or world traffic)
+// are only visible to clients holding the wildcard.
+func AllowFlow(ctx context.Context, flow *flowpb.Flow) bool {
+	set, ok := ctx.Value(namespacesKey).(map[string]struct{})
+	if !ok {
+		// no authorization in place for this request
+		return true
+	}
+	if flow == nil {
+		// non-flow responses, e.g. node status events, carry no
+		// namespaced payload
+		return true
+	}
+
+	if _, ok := set[Wildcard]; ok {
+		return true
+	}
+	if ns := flow.GetSource().GetNamespace(); ns != "" {
+		if _, ok := set[ns]; ok {
+			return true
+		}
+	}
+	if ns := flow.GetDestination().GetNamespace(); ns != "" {
+		if _, ok := set[ns]; ok {
+			return true
+		}
+	}
+	return false
+}
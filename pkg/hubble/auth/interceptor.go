@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// bearerPrefix is the scheme prefix of the authorization request metadata
+// carrying a bearer token.
+const bearerPrefix = "Bearer "
+
+// ClientIdentity returns the identity the client presented on the request
+// context: the common name of its TLS client certificate or, if none is
+// available, the bearer token from the authorization request metadata.
+// Returns an empty string when the client presented no identity.
+func ClientIdentity(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			if certs := tlsInfo.State.PeerCertificates; len(certs) > 0 {
+				if cn := certs[0].Subject.CommonName; cn != "" {
+					return cn
+				}
+			}
+		}
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, value := range md.Get("authorization") {
+			if strings.HasPrefix(value, bearerPrefix) {
+				return strings.TrimPrefix(value, bearerPrefix)
+			}
+		}
+	}
+
+	return ""
+}
+
+// authorize resolves the client identity of the request against the policy
+// and returns a context carrying the authorized namespaces.
+func (p *Policy) authorize(ctx context.Context) (context.Context, error) {
+	identity := ClientIdentity(ctx)
+	if identity == "" {
+		return nil, status.Error(codes.PermissionDenied, "no client identity presented")
+	}
+
+	namespaces, ok := p.AllowedNamespaces(identity)
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "client %q is not authorized to observe flows", identity)
+	}
+
+	return newContext(ctx, namespaces), nil
+}
+
+// UnaryServerInterceptor returns a server interceptor enforcing the policy
+// on unary RPCs.
+func UnaryServerInterceptor(p *Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := p.authorize(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor enforcing the policy
+// on streaming RPCs.
+func StreamServerInterceptor(p *Policy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := p.authorize(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, authorizedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorizedStream overrides the context of a server stream with the
+// authorized context.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (a authorizedStream) Context() context.Context {
+	return a.ctx
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+// Package auth implements per-namespace authorization for the Hubble flow
+// API. Clients are mapped to a set of namespaces based on the identity they
+// present (TLS client certificate or bearer token) and are only able to
+// observe flows belonging to namespaces they are authorized for.
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Wildcard grants access to flows of all namespaces, including flows which
+// are not scoped to any namespace (e.g. host or world traffic).
+const Wildcard = "*"
+
+// Policy maps client identities to the namespaces they are authorized to
+// observe flows for. The identity of a client is the common name of its TLS
+// client certificate or, alternatively, the bearer token presented in the
+// request metadata.
+type Policy struct {
+	rules map[string][]string
+}
+
+// ParsePolicy parses a policy from its JSON representation, a map from
+// client identity to the list of authorized namespaces:
+//
+//	{
+//	  "tenant-a.hubble-client.example.com": ["tenant-a", "tenant-a-staging"],
+//	  "cluster-admin.hubble-client.example.com": ["*"]
+//	}
+func ParsePolicy(raw []byte) (*Policy, error) {
+	rules := map[string][]string{}
+	if err := json.Unmarshal(raw, &rules); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+
+	for identity, namespaces := range rules {
+		if identity == "" {
+			return nil, fmt.Errorf("invalid policy: empty client identity")
+		}
+		if len(namespaces) == 0 {
+			return nil, fmt.Errorf("invalid policy: no namespaces for client identity %q", identity)
+		}
+	}
+
+	return &Policy{rules: rules}, nil
+}
+
+// LoadPolicy parses the policy stored at the given path.
+func LoadPolicy(path string) (*Policy, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read policy: %w", err)
+	}
+	return ParsePolicy(raw)
+}
+
+// AllowedNamespaces returns the namespaces the given client identity is
+// authorized to observe flows for. Returns false if the identity is not
+// authorized at all.
+func (p *Policy) AllowedNamespaces(identity string) ([]string, bool) {
+	namespaces, ok := p.rules[identity]
+	return namespaces, ok
+}
@@ -8,12 +8,25 @@ import (
 
 	observerTypes "github.com/cilium/cilium/pkg/hubble/observer/types"
 	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/metrics"
 	monitorConsumer "github.com/cilium/cilium/pkg/monitor/agent/consumer"
+	monitorAPI "github.com/cilium/cilium/pkg/monitor/api"
 	nodeTypes "github.com/cilium/cilium/pkg/node/types"
 
 	"github.com/sirupsen/logrus"
 )
 
+// Priority classes of monitor events. Drop and policy verdict events are
+// security-relevant and are prioritized over forwarded-flow events when the
+// events queue is under pressure.
+const (
+	eventClassDrop          = "drop"
+	eventClassPolicyVerdict = "policy-verdict"
+	eventClassFlow          = "flow"
+	eventClassLost          = "lost"
+	eventClassOther         = "other"
+)
+
 // Observer is the receiver of MonitorEvents
 type Observer interface {
 	GetEventsChannel() chan *observerTypes.MonitorEvent
@@ -22,20 +35,56 @@ type Observer interface {
 
 // consumer implements monitorConsumer.MonitorConsumer
 type consumer struct {
-	observer      Observer
-	numEventsLost uint64
-	lostLock      lock.Mutex
+	observer             Observer
+	numEventsLost        uint64
+	numEventsLostByClass map[string]uint64
+	lostLock             lock.Mutex
 }
 
 // NewConsumer returns an initialized pointer to consumer.
 func NewConsumer(observer Observer) monitorConsumer.MonitorConsumer {
 	mc := &consumer{
-		observer:      observer,
-		numEventsLost: 0,
+		observer:             observer,
+		numEventsLost:        0,
+		numEventsLostByClass: map[string]uint64{},
 	}
 	return mc
 }
 
+// eventClass returns the priority class of a perf event based on its monitor
+// message type.
+func eventClass(data []byte) string {
+	if len(data) > 0 {
+		switch data[0] {
+		case byte(monitorAPI.MessageTypeDrop):
+			return eventClassDrop
+		case byte(monitorAPI.MessageTypePolicyVerdict):
+			return eventClassPolicyVerdict
+		case byte(monitorAPI.MessageTypeTrace), byte(monitorAPI.MessageTypeCapture):
+			return eventClassFlow
+		}
+	}
+	return eventClassOther
+}
+
+// isPriorityClass returns true for event classes that may use the full
+// capacity of the events queue.
+func isPriorityClass(class string) bool {
+	switch class {
+	case eventClassDrop, eventClassPolicyVerdict, eventClassLost:
+		return true
+	}
+	return false
+}
+
+// lowPriorityThreshold returns the queue occupancy above which events of
+// non-priority classes are dropped. A small headroom of the queue capacity
+// is kept available to drop and policy verdict events so that they are not
+// the ones lost during traffic spikes.
+func lowPriorityThreshold(queue chan *observerTypes.MonitorEvent) int {
+	return cap(queue) - cap(queue)/16
+}
+
 // sendEventQueueLostEvents tries to send the current value of the lost events
 // counter to the observer. If it succeeds to enqueue a notification, it
 // resets the counter.
@@ -61,8 +110,9 @@ func (c *consumer) sendNumLostEvents() {
 		// We now now safely reset the counter, as at this point have
 		// successfully notified the observer about the amount of events
 		// that were lost since the previous LostEvent message
-		c.observer.GetLogger().Warningf("hubble events queue is processing messages again: %d messages were lost", c.numEventsLost)
+		c.observer.GetLogger().Warningf("hubble events queue is processing messages again: %d messages were lost (%v)", c.numEventsLost, c.numEventsLostByClass)
 		c.numEventsLost = 0
+		c.numEventsLostByClass = map[string]uint64{}
 	default:
 		// We do not need to bump the numEventsLost counter here, as we will
 		// try to send a new LostEvent notification again during the next
@@ -71,29 +121,38 @@ func (c *consumer) sendNumLostEvents() {
 }
 
 // sendEvent enqueues an event in the observer. If this is not possible, it
-// keeps a counter of lost events, which it will regularly try to send to the
-// observer as well
-func (c *consumer) sendEvent(event *observerTypes.MonitorEvent) {
+// keeps per-class counters of lost events, which it will regularly try to
+// send to the observer as well. Events of non-priority classes are dropped
+// early once the queue occupancy crosses the low priority threshold, leaving
+// the remaining capacity to the priority classes.
+func (c *consumer) sendEvent(event *observerTypes.MonitorEvent, class string) {
 	if c.numEventsLost > 0 {
 		c.sendNumLostEvents()
 	}
+	queue := c.observer.GetEventsChannel()
+	if !isPriorityClass(class) && len(queue) >= lowPriorityThreshold(queue) {
+		c.countLostEvent(class)
+		return
+	}
 	select {
-	case c.observer.GetEventsChannel() <- event:
+	case queue <- event:
 	default:
-		c.logStartedDropping()
+		c.countLostEvent(class)
 	}
 }
 
-// logStartedDropping logs that the events channel is full
-// and starts couting exactly how many messages it has
+// countLostEvent logs that the events channel is full
+// and counts exactly how many messages of each class it has
 // lost until the consumer can recover.
-func (c *consumer) logStartedDropping() {
+func (c *consumer) countLostEvent(class string) {
 	c.lostLock.Lock()
 	defer c.lostLock.Unlock()
 	if c.numEventsLost == 0 {
 		c.observer.GetLogger().Warning("hubble events queue is full; dropping messages")
 	}
 	c.numEventsLost++
+	c.numEventsLostByClass[class]++
+	metrics.HubbleLostEvents.WithLabelValues(class).Inc()
 }
 
 // NotifyAgentEvent implements monitorConsumer.MonitorConsumer
@@ -105,7 +164,7 @@ func (c *consumer) NotifyAgentEvent(typ int, message interface{}) {
 			Type:    typ,
 			Message: message,
 		},
-	})
+	}, eventClassOther)
 }
 
 // NotifyPerfEvent implements monitorConsumer.MonitorConsumer
@@ -117,7 +176,7 @@ func (c *consumer) NotifyPerfEvent(data []byte, cpu int) {
 			Data: data,
 			CPU:  cpu,
 		},
-	})
+	}, eventClass(data))
 }
 
 // NotifyPerfEventLost implements monitorConsumer.MonitorConsumer
@@ -130,5 +189,5 @@ func (c *consumer) NotifyPerfEventLost(numLostEvents uint64, cpu int) {
 			NumLostEvents: numLostEvents,
 			CPU:           cpu,
 		},
-	})
+	}, eventClassLost)
 }
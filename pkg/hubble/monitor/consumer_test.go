@@ -118,3 +118,52 @@ func TestHubbleConsumer(t *testing.T) {
 	default:
 	}
 }
+
+func TestHubbleConsumerPriority(t *testing.T) {
+	observer := fakeObserver{
+		events: make(chan *observerTypes.MonitorEvent, 32),
+		logger: logrus.NewEntry(logrus.New()),
+	}
+	mc := NewConsumer(observer)
+	threshold := lowPriorityThreshold(observer.GetEventsChannel())
+	assert.Equal(t, 30, threshold)
+
+	traceData := []byte{byte(api.MessageTypeTrace)}
+	dropData := []byte{byte(api.MessageTypeDrop)}
+	verdictData := []byte{byte(api.MessageTypePolicyVerdict)}
+
+	// Fill the queue up to the low priority threshold with flow events.
+	for i := 0; i < threshold; i++ {
+		mc.NotifyPerfEvent(traceData, 0)
+	}
+	assert.Equal(t, threshold, len(observer.GetEventsChannel()))
+
+	// Further flow events are dropped to keep headroom available...
+	mc.NotifyPerfEvent(traceData, 0)
+	assert.Equal(t, threshold, len(observer.GetEventsChannel()))
+	assert.Equal(t, uint64(1), mc.(*consumer).numEventsLostByClass[eventClassFlow])
+
+	// ...while drop events may still use the remaining capacity. The drop
+	// event is preceded by a lost event notification for the flow event.
+	mc.NotifyPerfEvent(dropData, 0)
+	assert.Equal(t, threshold+2, len(observer.GetEventsChannel()))
+
+	// The queue is now completely full, priority events are lost as well.
+	mc.NotifyPerfEvent(verdictData, 0)
+	assert.Equal(t, threshold+2, len(observer.GetEventsChannel()))
+	assert.Equal(t, uint64(1), mc.(*consumer).numEventsLostByClass[eventClassPolicyVerdict])
+
+	// Drain the queue: the flow events come first, followed by the lost
+	// event notification and the drop event.
+	for i := 0; i < threshold; i++ {
+		received := <-observer.GetEventsChannel()
+		assert.Equal(t, &observerTypes.PerfEvent{Data: traceData}, received.Payload)
+	}
+	received := <-observer.GetEventsChannel()
+	assert.Equal(t, &observerTypes.LostEvent{
+		Source:        observerTypes.LostEventSourceEventsQueue,
+		NumLostEvents: 1,
+	}, received.Payload)
+	received = <-observer.GetEventsChannel()
+	assert.Equal(t, &observerTypes.PerfEvent{Data: dropData}, received.Payload)
+}
@@ -482,17 +482,23 @@ func (p *Parser) getSummary(logRecord *accesslog.LogRecord, flow *pb.Flow) strin
 	if logRecord == nil {
 		return ""
 	}
+
+	var summary string
 	if http := logRecord.HTTP; http != nil {
-		return p.httpSummary(logRecord.Type, http, flow)
+		summary = p.httpSummary(logRecord.Type, http, flow)
 	} else if kafka := logRecord.Kafka; kafka != nil {
-		return kafkaSummary(flow)
+		summary = kafkaSummary(flow)
 	} else if dns := logRecord.DNS; dns != nil {
-		return dnsSummary(logRecord.Type, dns)
+		summary = dnsSummary(logRecord.Type, dns)
 	} else if generic := logRecord.L7; generic != nil {
-		return genericSummary(generic)
+		summary = genericSummary(generic)
 	}
 
-	return ""
+	if summary != "" && logRecord.DelegatedIdentity != "" {
+		summary = fmt.Sprintf("%s identity=%s", summary, logRecord.DelegatedIdentity)
+	}
+
+	return summary
 }
 
 func decodeKafka(flowType accesslog.FlowType, kafka *accesslog.LogRecordKafka) *pb.Layer7_Kafka {
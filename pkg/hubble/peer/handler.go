@@ -23,16 +23,18 @@ import (
 // handler to the node manager.
 // Once not used anymore, Close must be called to free resources.
 type handler struct {
-	stop chan struct{}
-	C    chan *peerpb.ChangeNotification
-	tls  bool
+	stop     chan struct{}
+	C        chan *peerpb.ChangeNotification
+	tls      bool
+	ipv6Only bool
 }
 
-func newHandler(withoutTLSInfo bool) *handler {
+func newHandler(withoutTLSInfo, ipv6Only bool) *handler {
 	return &handler{
-		stop: make(chan struct{}),
-		C:    make(chan *peerpb.ChangeNotification),
-		tls:  !withoutTLSInfo,
+		stop:     make(chan struct{}),
+		C:        make(chan *peerpb.ChangeNotification),
+		tls:      !withoutTLSInfo,
+		ipv6Only: ipv6Only,
 	}
 }
 
@@ -42,7 +44,7 @@ var _ datapath.NodeHandler = (*handler)(nil)
 
 // NodeAdd implements datapath.NodeHandler.NodeAdd.
 func (h *handler) NodeAdd(n types.Node) error {
-	cn := newChangeNotification(n, peerpb.ChangeNotificationType_PEER_ADDED, h.tls)
+	cn := newChangeNotification(n, peerpb.ChangeNotificationType_PEER_ADDED, h.tls, h.ipv6Only)
 	select {
 	case h.C <- cn:
 	case <-h.stop:
@@ -52,14 +54,14 @@ func (h *handler) NodeAdd(n types.Node) error {
 
 // NodeUpdate implements datapath.NodeHandler.NodeUpdate.
 func (h *handler) NodeUpdate(o, n types.Node) error {
-	oAddr, nAddr := nodeAddress(o), nodeAddress(n)
+	oAddr, nAddr := nodeAddress(o, h.ipv6Only), nodeAddress(n, h.ipv6Only)
 	if o.Fullname() == n.Fullname() {
 		if oAddr == nAddr {
 			// this corresponds to the same peer
 			// => no need to send a notification
 			return nil
 		}
-		cn := newChangeNotification(n, peerpb.ChangeNotificationType_PEER_UPDATED, h.tls)
+		cn := newChangeNotification(n, peerpb.ChangeNotificationType_PEER_UPDATED, h.tls, h.ipv6Only)
 		select {
 		case h.C <- cn:
 		case <-h.stop:
@@ -68,13 +70,13 @@ func (h *handler) NodeUpdate(o, n types.Node) error {
 	}
 	// the name has changed; from a service consumer perspective, this is the
 	// same as if the peer with the old name was removed and a new one added
-	ocn := newChangeNotification(o, peerpb.ChangeNotificationType_PEER_DELETED, h.tls)
+	ocn := newChangeNotification(o, peerpb.ChangeNotificationType_PEER_DELETED, h.tls, h.ipv6Only)
 	select {
 	case h.C <- ocn:
 	case <-h.stop:
 		return nil
 	}
-	ncn := newChangeNotification(n, peerpb.ChangeNotificationType_PEER_ADDED, h.tls)
+	ncn := newChangeNotification(n, peerpb.ChangeNotificationType_PEER_ADDED, h.tls, h.ipv6Only)
 	select {
 	case h.C <- ncn:
 	case <-h.stop:
@@ -84,7 +86,7 @@ func (h *handler) NodeUpdate(o, n types.Node) error {
 
 // NodeDelete implements datapath.NodeHandler.NodeDelete.
 func (h *handler) NodeDelete(n types.Node) error {
-	cn := newChangeNotification(n, peerpb.ChangeNotificationType_PEER_DELETED, h.tls)
+	cn := newChangeNotification(n, peerpb.ChangeNotificationType_PEER_DELETED, h.tls, h.ipv6Only)
 	select {
 	case h.C <- cn:
 	case <-h.stop:
@@ -133,7 +135,7 @@ func (h *handler) Close() {
 // newChangeNotification creates a new change notification with the provided
 // information. If withTLS is true, the TLS field is populated with the server
 // name derived from the node and cluster names.
-func newChangeNotification(n types.Node, t peerpb.ChangeNotificationType, withTLS bool) *peerpb.ChangeNotification {
+func newChangeNotification(n types.Node, t peerpb.ChangeNotificationType, withTLS, ipv6Only bool) *peerpb.ChangeNotification {
 	var tls *peerpb.TLS
 	if withTLS {
 		tls = &peerpb.TLS{
@@ -142,20 +144,22 @@ func newChangeNotification(n types.Node, t peerpb.ChangeNotificationType, withTL
 	}
 	return &peerpb.ChangeNotification{
 		Name:    n.Fullname(),
-		Address: nodeAddress(n),
+		Address: nodeAddress(n, ipv6Only),
 		Type:    t,
 		Tls:     tls,
 	}
 }
 
 // nodeAddress returns the node's address. If the node has both IPv4 and IPv6
-// addresses, IPv4 takes priority.
-func nodeAddress(n types.Node) string {
-	addr := n.GetNodeIP(false)
-	if addr.To4() != nil {
-		return addr.String()
+// addresses, IPv4 takes priority, unless ipv6Only is set in which case IPv4
+// addresses are never advertised.
+func nodeAddress(n types.Node, ipv6Only bool) string {
+	if !ipv6Only {
+		if addr := n.GetNodeIP(false); addr.To4() != nil {
+			return addr.String()
+		}
 	}
-	addr = n.GetNodeIP(true)
+	addr := n.GetNodeIP(true)
 	if addr == nil {
 		return ""
 	}
@@ -143,7 +143,7 @@ func TestNodeAdd(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			h := newHandler(tt.withoutTLS)
+			h := newHandler(tt.withoutTLS, false)
 			defer h.Close()
 
 			var got *peerpb.ChangeNotification
@@ -352,7 +352,7 @@ func TestNodeUpdate(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			h := newHandler(tt.withoutTLS)
+			h := newHandler(tt.withoutTLS, false)
 			defer h.Close()
 
 			var got []*peerpb.ChangeNotification
@@ -468,7 +468,7 @@ func TestNodeDelete(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			h := newHandler(tt.withoutTLS)
+			h := newHandler(tt.withoutTLS, false)
 			defer h.Close()
 
 			var got *peerpb.ChangeNotification
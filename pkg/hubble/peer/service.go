@@ -53,7 +53,7 @@ func (s *Service) Notify(_ *peerpb.NotifyRequest, stream peerpb.Peer_NotifyServe
 	g, ctx := errgroup.WithContext(ctx)
 
 	// monitor for global stop signal to tear down all routines
-	h := newHandler(s.opts.WithoutTLSInfo)
+	h := newHandler(s.opts.WithoutTLSInfo, s.opts.AddressFamilyIPv6Only)
 	g.Go(func() error {
 		defer h.Close()
 		select {
@@ -5,8 +5,9 @@ package serviceoption
 
 // Options stores all the configuration values for the peer service.
 type Options struct {
-	MaxSendBufferSize int
-	WithoutTLSInfo    bool
+	MaxSendBufferSize     int
+	WithoutTLSInfo        bool
+	AddressFamilyIPv6Only bool
 }
 
 // Option customizes then configuration of the peer service.
@@ -32,3 +33,12 @@ func WithoutTLSInfo() Option {
 		o.WithoutTLSInfo = true
 	}
 }
+
+// WithAddressFamilyIPv6Only configures the service to only advertise IPv6
+// peer addresses. Peers without an IPv6 address are advertised without an
+// address. This is used when the control plane is restricted to IPv6.
+func WithAddressFamilyIPv6Only() Option {
+	return func(o *Options) {
+		o.AddressFamilyIPv6Only = true
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	observerpb "github.com/cilium/cilium/api/v1/observer"
 	relaypb "github.com/cilium/cilium/api/v1/relay"
+	"github.com/cilium/cilium/pkg/hubble/auth"
 	"github.com/cilium/cilium/pkg/hubble/build"
 	poolTypes "github.com/cilium/cilium/pkg/hubble/relay/pool/types"
 
@@ -150,6 +151,9 @@ sortedFlowsLoop:
 			if !ok {
 				break sortedFlowsLoop
 			}
+			if !auth.AllowFlow(ctx, flow.GetFlow()) {
+				continue
+			}
 			if err := stream.Send(flow); err != nil {
 				return err
 			}
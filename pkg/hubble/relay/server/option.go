@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/cilium/cilium/pkg/crypto/certloader"
+	"github.com/cilium/cilium/pkg/hubble/auth"
 	"github.com/cilium/cilium/pkg/hubble/relay/defaults"
 	"github.com/cilium/cilium/pkg/hubble/relay/observer"
 	"github.com/cilium/cilium/pkg/logging"
@@ -33,6 +34,7 @@ type options struct {
 	clientTLSConfig certloader.ClientConfigBuilder
 	insecureClient  bool
 	observerOptions []observer.Option
+	authPolicy      *auth.Policy
 }
 
 // defaultOptions is the reference point for default values.
@@ -147,6 +149,17 @@ func WithInsecureServer() Option {
 	}
 }
 
+// WithAuthPolicy enforces the given authorization policy on the hubble-relay
+// server. Clients which are not authorized by the policy are rejected,
+// authorized clients only observe flows of the namespaces they are
+// authorized for.
+func WithAuthPolicy(p *auth.Policy) Option {
+	return func(o *options) error {
+		o.authPolicy = p
+		return nil
+	}
+}
+
 // WithClientTLS sets the transport credentials for connecting to peers based
 // on the provided TLS configuration.
 func WithClientTLS(cfg certloader.ClientConfigBuilder) Option {
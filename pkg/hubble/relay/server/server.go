@@ -11,6 +11,7 @@ import (
 
 	observerpb "github.com/cilium/cilium/api/v1/observer"
 	v1 "github.com/cilium/cilium/pkg/hubble/api/v1"
+	"github.com/cilium/cilium/pkg/hubble/auth"
 	peerTypes "github.com/cilium/cilium/pkg/hubble/peer/types"
 	"github.com/cilium/cilium/pkg/hubble/relay/observer"
 	"github.com/cilium/cilium/pkg/hubble/relay/pool"
@@ -90,15 +91,24 @@ func New(options ...Option) (*Server, error) {
 func (s *Server) Serve() error {
 	s.opts.log.WithField("options", fmt.Sprintf("%+v", s.opts)).Info("Starting server...")
 
+	var serverOpts []grpc.ServerOption
+	if s.opts.authPolicy != nil {
+		serverOpts = append(serverOpts,
+			grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(s.opts.authPolicy)),
+			grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(s.opts.authPolicy)),
+		)
+	}
+
 	switch {
 	case s.opts.insecureServer:
-		s.server = grpc.NewServer()
+		s.server = grpc.NewServer(serverOpts...)
 	case s.opts.serverTLSConfig != nil:
 		tlsConfig := s.opts.serverTLSConfig.ServerConfig(&tls.Config{
 			MinVersion: MinTLSVersion,
 		})
 		creds := credentials.NewTLS(tlsConfig)
-		s.server = grpc.NewServer(grpc.Creds(creds))
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+		s.server = grpc.NewServer(serverOpts...)
 	default:
 		return ErrNoServerTLSConfig
 	}
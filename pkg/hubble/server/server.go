@@ -12,6 +12,7 @@ import (
 	observerpb "github.com/cilium/cilium/api/v1/observer"
 	peerpb "github.com/cilium/cilium/api/v1/peer"
 	recorderpb "github.com/cilium/cilium/api/v1/recorder"
+	"github.com/cilium/cilium/pkg/hubble/auth"
 	"github.com/cilium/cilium/pkg/hubble/server/serveroption"
 
 	"github.com/sirupsen/logrus"
@@ -51,15 +52,25 @@ func NewServer(log logrus.FieldLogger, options ...serveroption.Option) (*Server,
 }
 
 func (s *Server) newGRPCServer() (*grpc.Server, error) {
+	var serverOpts []grpc.ServerOption
+
+	if s.opts.AuthPolicy != nil {
+		serverOpts = append(serverOpts,
+			grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(s.opts.AuthPolicy)),
+			grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(s.opts.AuthPolicy)),
+		)
+	}
+
 	switch {
 	case s.opts.Insecure:
-		return grpc.NewServer(), nil
+		return grpc.NewServer(serverOpts...), nil
 	case s.opts.ServerTLSConfig != nil:
 		tlsConfig := s.opts.ServerTLSConfig.ServerConfig(&tls.Config{
 			MinVersion: tls.VersionTLS13,
 		})
 		creds := credentials.NewTLS(tlsConfig)
-		return grpc.NewServer(grpc.Creds(creds)), nil
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+		return grpc.NewServer(serverOpts...), nil
 	default:
 		return nil, errNoServerTLSConfig
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/cilium/cilium/pkg/api"
 	"github.com/cilium/cilium/pkg/crypto/certloader"
 	v1 "github.com/cilium/cilium/pkg/hubble/api/v1"
+	"github.com/cilium/cilium/pkg/hubble/auth"
 
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc/health"
@@ -36,6 +37,7 @@ type Options struct {
 	RecorderService recorderpb.RecorderServer
 	ServerTLSConfig certloader.ServerConfigBuilder
 	Insecure        bool
+	AuthPolicy      *auth.Policy
 }
 
 // Option customizes then configuration of the hubble server.
@@ -126,6 +128,17 @@ func WithServerTLS(cfg certloader.ServerConfigBuilder) Option {
 	}
 }
 
+// WithAuthPolicy enforces the given authorization policy on all services
+// exposed by the server. Clients which are not authorized by the policy are
+// rejected, authorized clients only observe flows of the namespaces they are
+// authorized for.
+func WithAuthPolicy(p *auth.Policy) Option {
+	return func(o *Options) error {
+		o.AuthPolicy = p
+		return nil
+	}
+}
+
 // WithPeerService configures the server to expose the given peer server service.
 func WithRecorderService(svc recorderpb.RecorderServer) Option {
 	return func(o *Options) error {
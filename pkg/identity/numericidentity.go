@@ -67,6 +67,10 @@ const (
 	// local and remote clusters except for the local node.
 	ReservedIdentityRemoteNode
 
+	// ReservedIdentityKubeAPIServer is the identity given to remote node(s)
+	// which have backend(s) serving the kube-apiserver running.
+	ReservedIdentityKubeAPIServer
+
 	// --------------------------------------------------------------
 	// Special identities for well-known cluster components
 
@@ -268,21 +272,23 @@ func InitWellKnownIdentities(c Configuration) int {
 
 var (
 	reservedIdentities = map[string]NumericIdentity{
-		labels.IDNameHost:       ReservedIdentityHost,
-		labels.IDNameWorld:      ReservedIdentityWorld,
-		labels.IDNameUnmanaged:  ReservedIdentityUnmanaged,
-		labels.IDNameHealth:     ReservedIdentityHealth,
-		labels.IDNameInit:       ReservedIdentityInit,
-		labels.IDNameRemoteNode: ReservedIdentityRemoteNode,
+		labels.IDNameHost:          ReservedIdentityHost,
+		labels.IDNameWorld:         ReservedIdentityWorld,
+		labels.IDNameUnmanaged:     ReservedIdentityUnmanaged,
+		labels.IDNameHealth:        ReservedIdentityHealth,
+		labels.IDNameInit:          ReservedIdentityInit,
+		labels.IDNameRemoteNode:    ReservedIdentityRemoteNode,
+		labels.IDNameKubeAPIServer: ReservedIdentityKubeAPIServer,
 	}
 	reservedIdentityNames = map[NumericIdentity]string{
-		IdentityUnknown:            "unknown",
-		ReservedIdentityHost:       labels.IDNameHost,
-		ReservedIdentityWorld:      labels.IDNameWorld,
-		ReservedIdentityUnmanaged:  labels.IDNameUnmanaged,
-		ReservedIdentityHealth:     labels.IDNameHealth,
-		ReservedIdentityInit:       labels.IDNameInit,
-		ReservedIdentityRemoteNode: labels.IDNameRemoteNode,
+		IdentityUnknown:               "unknown",
+		ReservedIdentityHost:          labels.IDNameHost,
+		ReservedIdentityWorld:         labels.IDNameWorld,
+		ReservedIdentityUnmanaged:     labels.IDNameUnmanaged,
+		ReservedIdentityHealth:        labels.IDNameHealth,
+		ReservedIdentityInit:          labels.IDNameInit,
+		ReservedIdentityRemoteNode:    labels.IDNameRemoteNode,
+		ReservedIdentityKubeAPIServer: labels.IDNameKubeAPIServer,
 	}
 
 	// WellKnown identities stores global state of all well-known identities.
@@ -334,9 +340,10 @@ func DelReservedNumericIdentity(identity NumericIdentity) error {
 // NumericIdentity is the numeric representation of a security identity.
 //
 // Bits:
-//    0-15: identity identifier
-//   16-23: cluster identifier
-//      24: LocalIdentityFlag: Indicates that the identity has a local scope
+//
+//	 0-15: identity identifier
+//	16-23: cluster identifier
+//	   24: LocalIdentityFlag: Indicates that the identity has a local scope
 type NumericIdentity uint32
 
 func ParseNumericIdentity(id string) (NumericIdentity, error) {
@@ -28,7 +28,15 @@ func LookupReservedIdentity(ni NumericIdentity) *Identity {
 
 func init() {
 	IterateReservedIdentities(func(lbl string, ni NumericIdentity) {
-		identity := NewIdentity(ni, labels.Labels{lbl: labels.NewLabel(lbl, "", labels.LabelSourceReserved)})
+		lbls := labels.Labels{lbl: labels.NewLabel(lbl, "", labels.LabelSourceReserved)}
+		if ni == ReservedIdentityKubeAPIServer {
+			// The kube-apiserver identity is given to remote nodes which
+			// run a backend of the kube-apiserver service. Such nodes must
+			// keep matching policies written against the remote-node
+			// identity, so the identity carries both labels.
+			lbls.MergeLabels(labels.LabelRemoteNode)
+		}
+		identity := NewIdentity(ni, lbls)
 		// Pre-calculate the SHA256 hash.
 		identity.GetLabelsSHA256()
 		ReservedIdentityCache[ni] = identity
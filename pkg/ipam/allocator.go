@@ -7,9 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 	"time"
 
+	ipamTypes "github.com/cilium/cilium/pkg/ipam/types"
 	"github.com/cilium/cilium/pkg/metrics"
 
 	"github.com/google/uuid"
@@ -126,6 +128,7 @@ func (ipam *IPAM) allocateIP(ip net.IP, owner string, needSyncUpstream bool) (re
 	}).Debugf("Allocated specific IP")
 
 	ipam.owner[ip.String()] = owner
+	ipam.allocatedAt[ip.String()] = time.Now()
 	metrics.IpamEvent.WithLabelValues(metricAllocate, family).Inc()
 	return
 }
@@ -164,6 +167,7 @@ func (ipam *IPAM) allocateNextFamily(family Family, owner string, needSyncUpstre
 				"owner": owner,
 			}).Debugf("Allocated random IP")
 			ipam.owner[result.IP.String()] = owner
+			ipam.allocatedAt[result.IP.String()] = time.Now()
 			metrics.IpamEvent.WithLabelValues(metricAllocate, string(family)).Inc()
 			return
 		}
@@ -172,6 +176,7 @@ func (ipam *IPAM) allocateNextFamily(family Family, owner string, needSyncUpstre
 		// blacklisted IP is now allocated so it won't be allocated in
 		// the next iteration.
 		ipam.owner[result.IP.String()] = fmt.Sprintf("%s (blacklisted)", owner)
+		ipam.allocatedAt[result.IP.String()] = time.Now()
 	}
 }
 
@@ -278,6 +283,7 @@ func (ipam *IPAM) releaseIPLocked(ip net.IP) error {
 		"owner": owner,
 	}).Debugf("Released IP")
 	delete(ipam.owner, ip.String())
+	delete(ipam.allocatedAt, ip.String())
 	delete(ipam.expirationTimers, ip.String())
 
 	metrics.IpamEvent.WithLabelValues(metricRelease, family).Inc()
@@ -291,6 +297,49 @@ func (ipam *IPAM) ReleaseIP(ip net.IP) error {
 	return ipam.releaseIPLocked(ip)
 }
 
+// reservationAllocator returns the allocator responsible for the address
+// family of the given IP if it supports IP reservations.
+func (ipam *IPAM) reservationAllocator(ip net.IP) (ReservationAllocator, error) {
+	allocator := ipam.IPv6Allocator
+	if ip.To4() != nil {
+		allocator = ipam.IPv4Allocator
+	}
+	if allocator == nil {
+		if ip.To4() != nil {
+			return nil, ErrIPv4Disabled
+		}
+		return nil, ErrIPv6Disabled
+	}
+
+	reserver, ok := allocator.(ReservationAllocator)
+	if !ok {
+		return nil, fmt.Errorf("IP reservations are not supported in IPAM mode %s", ipam.config.IPAMMode())
+	}
+
+	return reserver, nil
+}
+
+// ReserveIP reserves the given IP for the given owner, so that only
+// allocations by this owner may use the IP. This is only supported by IPAM
+// modes that persist reservations, currently CRD-backed IPAM.
+func (ipam *IPAM) ReserveIP(ip net.IP, owner, pool string) error {
+	reserver, err := ipam.reservationAllocator(ip)
+	if err != nil {
+		return err
+	}
+	return reserver.ReserveIP(ip, owner, pool)
+}
+
+// ReleaseReservation releases a previously installed reservation of the given
+// IP.
+func (ipam *IPAM) ReleaseReservation(ip net.IP) error {
+	reserver, err := ipam.reservationAllocator(ip)
+	if err != nil {
+		return err
+	}
+	return reserver.ReleaseReservation(ip)
+}
+
 // ReleaseIPString is identical to ReleaseIP but takes a string and supports
 // referring to the IPs to be released with the IP itself or the owner name
 // used during allocation. If the owner can be referred to multiple IPs, then
@@ -352,6 +401,49 @@ func (ipam *IPAM) Dump() (allocv4 map[string]string, allocv6 map[string]string,
 	return
 }
 
+// ListAllocations returns the list of all allocated IPs together with their
+// owner, allocation pool, associated resource and allocation time. The
+// result can be filtered by owner and pool, empty filter values match any
+// allocation.
+func (ipam *IPAM) ListAllocations(ownerFilter, poolFilter string) []ipamTypes.Allocation {
+	ipam.allocatorMutex.RLock()
+	defer ipam.allocatorMutex.RUnlock()
+
+	allocations := []ipamTypes.Allocation{}
+	collect := func(allocator Allocator, family Family) {
+		if allocator == nil {
+			return
+		}
+		detailer, _ := allocator.(AllocationDetailer)
+		allocs, _ := allocator.Dump()
+		for ip := range allocs {
+			allocation := ipamTypes.Allocation{
+				IP:          ip,
+				Family:      string(family),
+				Owner:       ipam.owner[ip],
+				AllocatedAt: ipam.allocatedAt[ip],
+			}
+			if detailer != nil {
+				allocation.Pool, allocation.Resource = detailer.AllocationDetails(ip)
+			}
+			if ownerFilter != "" && allocation.Owner != ownerFilter {
+				continue
+			}
+			if poolFilter != "" && allocation.Pool != poolFilter {
+				continue
+			}
+			allocations = append(allocations, allocation)
+		}
+	}
+	collect(ipam.IPv4Allocator, IPv4)
+	collect(ipam.IPv6Allocator, IPv6)
+
+	sort.Slice(allocations, func(i, j int) bool {
+		return allocations[i].IP < allocations[j].IP
+	})
+	return allocations
+}
+
 // StartExpirationTimer installs an expiration timer for a previously allocated
 // IP. Unless StopExpirationTimer is called in time, the IP will be released
 // again after expiration of the specified timeout. The function will return a
@@ -24,6 +24,7 @@ import (
 	"github.com/cilium/cilium/pkg/k8s/informer"
 	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/metrics"
 	nodeTypes "github.com/cilium/cilium/pkg/node/types"
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/trigger"
@@ -68,6 +69,15 @@ type nodeStore struct {
 	// family
 	allocationPoolSize map[Family]int
 
+	// belowWatermark marks for each address family whether the number of
+	// available IPs is currently below the configured low watermark
+	belowWatermark map[Family]bool
+
+	// metricResources tracks the resources for which per-resource IP
+	// gauges have been reported, to drop the gauges of removed resources.
+	// Accessed only from the refresh trigger.
+	metricResources map[string]struct{}
+
 	// signal for completion of restoration
 	restoreFinished  chan struct{}
 	restoreCloseOnce sync.Once
@@ -84,6 +94,8 @@ func newNodeStore(nodeName string, conf Configuration, owner Owner, k8sEventReg
 	store := &nodeStore{
 		allocators:         []*crdAllocator{},
 		allocationPoolSize: map[Family]int{},
+		belowWatermark:     map[Family]bool{},
+		metricResources:    map[string]struct{}{},
 		conf:               conf,
 		mtuConfig:          mtuConfig,
 	}
@@ -118,6 +130,7 @@ func newNodeStore(nodeName string, conf Configuration, owner Owner, k8sEventReg
 				if node, ok := obj.(*ciliumv2.CiliumNode); ok {
 					valid = true
 					store.updateLocalNodeResource(node.DeepCopy())
+					store.checkPoolWatermark()
 					k8sEventReg.K8sEventProcessed("CiliumNode", "create", true)
 				} else {
 					log.Warningf("Unknown CiliumNode object type %s received: %+v", reflect.TypeOf(obj), obj)
@@ -134,6 +147,7 @@ func newNodeStore(nodeName string, conf Configuration, owner Owner, k8sEventReg
 						}
 						valid = true
 						store.updateLocalNodeResource(newNode.DeepCopy())
+						store.checkPoolWatermark()
 						k8sEventReg.K8sEventProcessed("CiliumNode", "update", true)
 					} else {
 						log.Warningf("Unknown CiliumNode object type %T received: %+v", oldNode, oldNode)
@@ -255,8 +269,27 @@ func (n *nodeStore) hasMinimumIPsInPool() (minimumReached bool, required, numAva
 
 	if n.ownNode.Spec.IPAM.Pool != nil {
 		numAvailable = len(n.ownNode.Spec.IPAM.Pool)
-		if len(n.ownNode.Spec.IPAM.Pool) >= required {
-			minimumReached = true
+
+		// With both address families enabled, the minimum must be
+		// available for each family individually for the node to be
+		// operational.
+		numIPv4, numIPv6 := 0, 0
+		for ipString := range n.ownNode.Spec.IPAM.Pool {
+			if ip := net.ParseIP(ipString); ip != nil {
+				if ip.To4() != nil {
+					numIPv4++
+				} else {
+					numIPv6++
+				}
+			}
+		}
+
+		minimumReached = true
+		if n.conf.IPv4Enabled() && numIPv4 < required {
+			minimumReached = false
+		}
+		if n.conf.IPv6Enabled() && numIPv6 < required {
+			minimumReached = false
 		}
 
 		if n.conf.IPAMMode() == ipamOption.IPAMENI || n.conf.IPAMMode() == ipamOption.IPAMAzure || n.conf.IPAMMode() == ipamOption.IPAMAlibabaCloud {
@@ -323,6 +356,10 @@ func (n *nodeStore) updateLocalNodeResource(node *ciliumv2.CiliumNode) {
 			}
 		}
 	}
+
+	if n.conf.UnreachableRoutesEnabled() {
+		n.reconcileUnreachableRoutes(node)
+	}
 }
 
 // refreshNodeTrigger is called to refresh the custom resource after taking the
@@ -335,7 +372,10 @@ func (n *nodeStore) refreshNodeTrigger(reasons []string) {
 	if err := n.refreshNode(); err != nil {
 		log.WithError(err).Warning("Unable to update CiliumNode custom resource")
 		n.refreshTrigger.TriggerWithReason("retry after error")
+		return
 	}
+
+	n.checkPoolWatermark()
 }
 
 // refreshNode updates the custom resource in the apiserver based on the latest
@@ -362,6 +402,8 @@ func (n *nodeStore) refreshNode() error {
 		a.mutex.RUnlock()
 	}
 
+	n.updateResourceMetrics(node)
+
 	var err error
 	ciliumClient := k8s.CiliumClient()
 	_, err = ciliumClient.CiliumV2().CiliumNodes().UpdateStatus(context.TODO(), node, metav1.UpdateOptions{})
@@ -369,6 +411,46 @@ func (n *nodeStore) refreshNode() error {
 	return err
 }
 
+// updateResourceMetrics updates the per-pool and per-resource IP gauges based
+// on the given copy of the custom resource with the up-to-date usage map.
+// Called only from the refresh trigger.
+func (n *nodeStore) updateResourceMetrics(node *ciliumv2.CiliumNode) {
+	capacity := map[string]int{}
+	used := map[string]int{}
+	for _, ipInfo := range node.Spec.IPAM.Pool {
+		capacity[ipInfo.Resource]++
+	}
+	for _, ipInfo := range node.Status.IPAM.Used {
+		used[ipInfo.Resource]++
+	}
+
+	// ENIs with delegated prefixes account their capacity by the size of
+	// the delegated prefixes rather than by the number of addresses
+	// currently expanded into the pool
+	for id, eni := range node.Status.ENI.ENIs {
+		if total := eni.PrefixCapacity(); total > 0 {
+			capacity[id] = total
+		}
+	}
+
+	// Drop the gauges of resources removed from the pool
+	for resource := range n.metricResources {
+		if _, ok := capacity[resource]; !ok {
+			for _, state := range []string{metricStateCapacity, metricStateUsed, metricStateAvailable} {
+				metrics.IpamResourceIPs.DeleteLabelValues(poolDefault, resource, state)
+			}
+			delete(n.metricResources, resource)
+		}
+	}
+
+	for resource, total := range capacity {
+		n.metricResources[resource] = struct{}{}
+		metrics.IpamResourceIPs.WithLabelValues(poolDefault, resource, metricStateCapacity).Set(float64(total))
+		metrics.IpamResourceIPs.WithLabelValues(poolDefault, resource, metricStateUsed).Set(float64(used[resource]))
+		metrics.IpamResourceIPs.WithLabelValues(poolDefault, resource, metricStateAvailable).Set(float64(total - used[resource]))
+	}
+}
+
 // addAllocator adds a new CRD allocator to the node store
 func (n *nodeStore) addAllocator(allocator *crdAllocator) {
 	n.mutex.Lock()
@@ -377,7 +459,7 @@ func (n *nodeStore) addAllocator(allocator *crdAllocator) {
 }
 
 // allocate checks if a particular IP can be allocated or return an error
-func (n *nodeStore) allocate(ip net.IP) (*ipamTypes.AllocationIP, error) {
+func (n *nodeStore) allocate(ip net.IP, owner string) (*ipamTypes.AllocationIP, error) {
 	n.mutex.RLock()
 	defer n.mutex.RUnlock()
 
@@ -386,7 +468,11 @@ func (n *nodeStore) allocate(ip net.IP) (*ipamTypes.AllocationIP, error) {
 	}
 
 	if n.ownNode.Spec.IPAM.Pool == nil {
-		return nil, fmt.Errorf("No IPs available")
+		return nil, errPoolExhausted
+	}
+
+	if reservation, ok := n.ownNode.Spec.IPAM.ReservedIPs[ip.String()]; ok && reservation.Owner != owner {
+		return nil, fmt.Errorf("IP %s is reserved for %s", ip.String(), reservation.Owner)
 	}
 
 	ipInfo, ok := n.ownNode.Spec.IPAM.Pool[ip.String()]
@@ -397,8 +483,9 @@ func (n *nodeStore) allocate(ip net.IP) (*ipamTypes.AllocationIP, error) {
 	return &ipInfo, nil
 }
 
-// allocateNext allocates the next available IP or returns an error
-func (n *nodeStore) allocateNext(allocated ipamTypes.AllocationMap, family Family) (net.IP, *ipamTypes.AllocationIP, error) {
+// allocateNext allocates the next available IP as selected by the given
+// allocation strategy or returns an error
+func (n *nodeStore) allocateNext(allocated ipamTypes.AllocationMap, family Family, owner string, strategy allocationStrategy) (net.IP, *ipamTypes.AllocationIP, error) {
 	n.mutex.RLock()
 	defer n.mutex.RUnlock()
 
@@ -406,10 +493,41 @@ func (n *nodeStore) allocateNext(allocated ipamTypes.AllocationMap, family Famil
 		return nil, nil, fmt.Errorf("CiliumNode for own node is not available")
 	}
 
+	// Hand out the IP reserved for this owner first, if any, so that pods
+	// with a fixed address reservation receive their pinned IP.
+	for ip, reservation := range n.ownNode.Spec.IPAM.ReservedIPs {
+		if reservation.Owner != owner {
+			continue
+		}
+		if _, ok := allocated[ip]; ok {
+			continue
+		}
+		ipInfo, ok := n.ownNode.Spec.IPAM.Pool[ip]
+		if !ok {
+			continue
+		}
+		parsedIP := net.ParseIP(ip)
+		if parsedIP == nil || DeriveFamily(parsedIP) != family {
+			continue
+		}
+		return parsedIP, &ipInfo, nil
+	}
+
 	// FIXME: This is currently using a brute-force method that can be
 	// optimized
+	candidates := map[string][]allocationCandidate{}
+	allocatedPerResource := map[string]int{}
+	for _, ipInfo := range allocated {
+		allocatedPerResource[ipInfo.Resource]++
+	}
 	for ip, ipInfo := range n.ownNode.Spec.IPAM.Pool {
 		if _, ok := allocated[ip]; !ok {
+			// IPs reserved for other owners are not available for
+			// regular allocation.
+			if reservation, ok := n.ownNode.Spec.IPAM.ReservedIPs[ip]; ok && reservation.Owner != owner {
+				continue
+			}
+
 			parsedIP := net.ParseIP(ip)
 			if parsedIP == nil {
 				log.WithFields(logrus.Fields{
@@ -423,11 +541,85 @@ func (n *nodeStore) allocateNext(allocated ipamTypes.AllocationMap, family Famil
 				continue
 			}
 
-			return parsedIP, &ipInfo, nil
+			candidates[ipInfo.Resource] = append(candidates[ipInfo.Resource], allocationCandidate{
+				ip:     parsedIP,
+				ipInfo: ipInfo,
+			})
 		}
 	}
 
-	return nil, nil, fmt.Errorf("No more IPs available")
+	if len(candidates) == 0 {
+		return nil, nil, errPoolExhausted
+	}
+
+	candidate := strategy.selectCandidate(candidates, allocatedPerResource)
+	return candidate.ip, &candidate.ipInfo, nil
+}
+
+// reserveIP persists a reservation of the given IP for the given owner in the
+// CiliumNode custom resource. The updated resource is propagated back to the
+// node store via the regular watcher.
+func (n *nodeStore) reserveIP(ip net.IP, owner, pool string) error {
+	n.mutex.RLock()
+
+	if n.ownNode == nil {
+		n.mutex.RUnlock()
+		return fmt.Errorf("CiliumNode for own node is not available")
+	}
+
+	ipInfo, ok := n.ownNode.Spec.IPAM.Pool[ip.String()]
+	if !ok {
+		n.mutex.RUnlock()
+		return fmt.Errorf("IP %s is not in the allocation pool", ip.String())
+	}
+
+	if pool != "" && ipInfo.Resource != pool {
+		n.mutex.RUnlock()
+		return fmt.Errorf("IP %s belongs to pool %s, not %s", ip.String(), ipInfo.Resource, pool)
+	}
+
+	if reservation, ok := n.ownNode.Spec.IPAM.ReservedIPs[ip.String()]; ok && reservation.Owner != owner {
+		n.mutex.RUnlock()
+		return fmt.Errorf("IP %s is already reserved for %s", ip.String(), reservation.Owner)
+	}
+
+	node := n.ownNode.DeepCopy()
+	n.mutex.RUnlock()
+
+	if node.Spec.IPAM.ReservedIPs == nil {
+		node.Spec.IPAM.ReservedIPs = ipamTypes.ReservationMap{}
+	}
+	node.Spec.IPAM.ReservedIPs[ip.String()] = ipamTypes.IPReservation{
+		Owner: owner,
+		Pool:  pool,
+	}
+
+	_, err := k8s.CiliumClient().CiliumV2().CiliumNodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+	return err
+}
+
+// releaseReservation removes the reservation of the given IP from the
+// CiliumNode custom resource, if any.
+func (n *nodeStore) releaseReservation(ip net.IP) error {
+	n.mutex.RLock()
+
+	if n.ownNode == nil {
+		n.mutex.RUnlock()
+		return fmt.Errorf("CiliumNode for own node is not available")
+	}
+
+	if _, ok := n.ownNode.Spec.IPAM.ReservedIPs[ip.String()]; !ok {
+		n.mutex.RUnlock()
+		return fmt.Errorf("IP %s is not reserved", ip.String())
+	}
+
+	node := n.ownNode.DeepCopy()
+	n.mutex.RUnlock()
+
+	delete(node.Spec.IPAM.ReservedIPs, ip.String())
+
+	_, err := k8s.CiliumClient().CiliumV2().CiliumNodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+	return err
 }
 
 // crdAllocator implements the CRD-backed IP allocator
@@ -445,6 +637,10 @@ type crdAllocator struct {
 	// family is the address family this allocator is allocator for
 	family Family
 
+	// strategy selects the next IP to hand out among the available IPs in
+	// the pool
+	strategy allocationStrategy
+
 	conf Configuration
 }
 
@@ -454,10 +650,16 @@ func newCRDAllocator(family Family, c Configuration, owner Owner, k8sEventReg K8
 		sharedNodeStore = newNodeStore(nodeTypes.GetName(), c, owner, k8sEventReg, mtuConfig)
 	})
 
+	strategy, err := newAllocationStrategy(option.Config.IPAMAllocationStrategy)
+	if err != nil {
+		log.WithError(err).Fatalf("Invalid %s", option.IPAMAllocationStrategy)
+	}
+
 	allocator := &crdAllocator{
 		allocated: ipamTypes.AllocationMap{},
 		family:    family,
 		store:     sharedNodeStore,
+		strategy:  strategy,
 		conf:      c,
 	}
 
@@ -498,16 +700,26 @@ func (a *crdAllocator) buildAllocationResult(ip net.IP, ipInfo *ipamTypes.Alloca
 		for _, eni := range a.store.ownNode.Status.ENI.ENIs {
 			if eni.ID == ipInfo.Resource {
 				result.PrimaryMAC = eni.MAC
-				result.CIDRs = []string{eni.VPC.PrimaryCIDR}
-				result.CIDRs = append(result.CIDRs, eni.VPC.CIDRs...)
-				// Add manually configured Native Routing CIDR
-				if a.conf.GetIPv4NativeRoutingCIDR() != nil {
-					result.CIDRs = append(result.CIDRs, a.conf.GetIPv4NativeRoutingCIDR().String())
-				}
-				if eni.Subnet.CIDR != "" {
-					// The gateway for a subnet and VPC is always x.x.x.1
-					// Ref: https://docs.aws.amazon.com/vpc/latest/userguide/VPC_Route_Tables.html
-					result.GatewayIP = deriveGatewayIP(eni.Subnet.CIDR, 1)
+				if ip.To4() == nil {
+					result.CIDRs = append(result.CIDRs, eni.VPC.IPv6CIDRs...)
+					if eni.Subnet.IPv6CIDR != "" {
+						// The gateway for a subnet and VPC is always the
+						// first IP of the subnet prefix.
+						// Ref: https://docs.aws.amazon.com/vpc/latest/userguide/VPC_Route_Tables.html
+						result.GatewayIP = deriveGatewayIP(eni.Subnet.IPv6CIDR, 1)
+					}
+				} else {
+					result.CIDRs = []string{eni.VPC.PrimaryCIDR}
+					result.CIDRs = append(result.CIDRs, eni.VPC.CIDRs...)
+					// Add manually configured Native Routing CIDR
+					if a.conf.GetIPv4NativeRoutingCIDR() != nil {
+						result.CIDRs = append(result.CIDRs, a.conf.GetIPv4NativeRoutingCIDR().String())
+					}
+					if eni.Subnet.CIDR != "" {
+						// The gateway for a subnet and VPC is always x.x.x.1
+						// Ref: https://docs.aws.amazon.com/vpc/latest/userguide/VPC_Route_Tables.html
+						result.GatewayIP = deriveGatewayIP(eni.Subnet.CIDR, 1)
+					}
 				}
 				result.InterfaceNumber = strconv.Itoa(eni.Number)
 
@@ -566,7 +778,9 @@ func (a *crdAllocator) buildAllocationResult(ip net.IP, ipInfo *ipamTypes.Alloca
 // allocate it if it is available. If the IP is unavailable or already
 // allocated, an error is returned. The custom resource will be updated to
 // reflect the newly allocated IP.
-func (a *crdAllocator) Allocate(ip net.IP, owner string) (*AllocationResult, error) {
+func (a *crdAllocator) Allocate(ip net.IP, owner string) (result *AllocationResult, err error) {
+	defer func(start time.Time) { observeAllocation(a.family, start, err) }(time.Now())
+
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
@@ -574,7 +788,7 @@ func (a *crdAllocator) Allocate(ip net.IP, owner string) (*AllocationResult, err
 		return nil, fmt.Errorf("IP already in use")
 	}
 
-	ipInfo, err := a.store.allocate(ip)
+	ipInfo, err := a.store.allocate(ip, owner)
 	if err != nil {
 		return nil, err
 	}
@@ -590,7 +804,9 @@ func (a *crdAllocator) Allocate(ip net.IP, owner string) (*AllocationResult, err
 // custom resource and allocate it if it is available. If the IP is
 // unavailable or already allocated, an error is returned. The custom resource
 // will not be updated.
-func (a *crdAllocator) AllocateWithoutSyncUpstream(ip net.IP, owner string) (*AllocationResult, error) {
+func (a *crdAllocator) AllocateWithoutSyncUpstream(ip net.IP, owner string) (result *AllocationResult, err error) {
+	defer func(start time.Time) { observeAllocation(a.family, start, err) }(time.Now())
+
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
@@ -598,7 +814,7 @@ func (a *crdAllocator) AllocateWithoutSyncUpstream(ip net.IP, owner string) (*Al
 		return nil, fmt.Errorf("IP already in use")
 	}
 
-	ipInfo, err := a.store.allocate(ip)
+	ipInfo, err := a.store.allocate(ip, owner)
 	if err != nil {
 		return nil, err
 	}
@@ -626,6 +842,20 @@ func (a *crdAllocator) Release(ip net.IP) error {
 	return nil
 }
 
+// ReserveIP reserves the given IP of the allocation pool for the given owner,
+// implementing ReservationAllocator. The reservation is persisted in the
+// CiliumNode custom resource and therefore survives agent restarts.
+func (a *crdAllocator) ReserveIP(ip net.IP, owner, pool string) error {
+	return a.store.reserveIP(ip, owner, pool)
+}
+
+// ReleaseReservation releases a previously installed reservation of the given
+// IP, implementing ReservationAllocator. Any current allocation of the IP
+// remains in place.
+func (a *crdAllocator) ReleaseReservation(ip net.IP) error {
+	return a.store.releaseReservation(ip)
+}
+
 // markAllocated marks a particular IP as allocated
 func (a *crdAllocator) markAllocated(ip net.IP, owner string, ipInfo ipamTypes.AllocationIP) {
 	ipInfo.Owner = owner
@@ -635,11 +865,13 @@ func (a *crdAllocator) markAllocated(ip net.IP, owner string, ipInfo ipamTypes.A
 // AllocateNext allocates the next available IP as offered by the custom
 // resource or return an error if no IP is available. The custom resource will
 // be updated to reflect the newly allocated IP.
-func (a *crdAllocator) AllocateNext(owner string) (*AllocationResult, error) {
+func (a *crdAllocator) AllocateNext(owner string) (result *AllocationResult, err error) {
+	defer func(start time.Time) { observeAllocation(a.family, start, err) }(time.Now())
+
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
-	ip, ipInfo, err := a.store.allocateNext(a.allocated, a.family)
+	ip, ipInfo, err := a.store.allocateNext(a.allocated, a.family, owner, a.strategy)
 	if err != nil {
 		return nil, err
 	}
@@ -654,11 +886,13 @@ func (a *crdAllocator) AllocateNext(owner string) (*AllocationResult, error) {
 // AllocateNextWithoutSyncUpstream allocates the next available IP as offered
 // by the custom resource or return an error if no IP is available. The custom
 // resource will not be updated.
-func (a *crdAllocator) AllocateNextWithoutSyncUpstream(owner string) (*AllocationResult, error) {
+func (a *crdAllocator) AllocateNextWithoutSyncUpstream(owner string) (result *AllocationResult, err error) {
+	defer func(start time.Time) { observeAllocation(a.family, start, err) }(time.Now())
+
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
-	ip, ipInfo, err := a.store.allocateNext(a.allocated, a.family)
+	ip, ipInfo, err := a.store.allocateNext(a.allocated, a.family, owner, a.strategy)
 	if err != nil {
 		return nil, err
 	}
@@ -691,6 +925,19 @@ func (a *crdAllocator) Dump() (map[string]string, string) {
 	return allocs, status
 }
 
+// AllocationDetails returns the pool and resource of the given allocated IP,
+// implementing AllocationDetailer. The pool of the custom resource backed
+// allocator is the allocation pool of the CiliumNode resource.
+func (a *crdAllocator) AllocationDetails(ip string) (pool, resource string) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	if ipInfo, ok := a.allocated[ip]; ok {
+		resource = ipInfo.Resource
+	}
+	return poolDefault, resource
+}
+
 // RestoreFinished marks the status of restoration as done
 func (a *crdAllocator) RestoreFinished() {
 	a.store.restoreCloseOnce.Do(func() {
@@ -110,6 +110,12 @@ func parseENIConfig(name string, eni *eniTypes.ENI, mtuConfig MtuConfiguration)
 		return cfg, fmt.Errorf("failed to parse eni subnet cidr %q: %w", eni.Subnet.CIDR, err)
 	}
 
+	if eni.Subnet.IPv6CIDR != "" {
+		if _, _, err := net.ParseCIDR(eni.Subnet.IPv6CIDR); err != nil {
+			return cfg, fmt.Errorf("failed to parse eni subnet ipv6 cidr %q: %w", eni.Subnet.IPv6CIDR, err)
+		}
+	}
+
 	return eniDeviceConfig{
 		name: name,
 		ip:   ip,
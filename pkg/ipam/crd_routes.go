@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package ipam
+
+import (
+	"net"
+
+	"github.com/cilium/cilium/pkg/ip"
+	ciliumv2 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2"
+	"github.com/cilium/cilium/pkg/metrics"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// unreachableRouteProto is the routing protocol unreachable routes are
+// installed with. The dedicated value allows the reconciler to identify the
+// routes it owns without touching routes installed by other entities.
+const unreachableRouteProto = 201
+
+// Actions reported in the action label of the
+// cilium_ipam_unreachable_routes_total metric
+const (
+	metricActionInstall = "install"
+	metricActionRemove  = "remove"
+)
+
+// reconcileUnreachableRoutes synchronizes the unreachable routes in the main
+// routing table with the IPAM pool of the given node resource. An unreachable
+// route is installed for each IP which is part of the pool but not allocated
+// by any of the attached allocators, and removed again when the IP is handed
+// out. This ensures that traffic to excess IPs is rejected locally instead of
+// being forwarded via the default route.
+//
+// Must be called with n.mutex held.
+func (n *nodeStore) reconcileUnreachableRoutes(node *ciliumv2.CiliumNode) {
+	excess := map[string]net.IP{}
+	for ipString := range node.Spec.IPAM.Pool {
+		parsedIP := net.ParseIP(ipString)
+		if parsedIP == nil {
+			continue
+		}
+		if parsedIP.To4() != nil {
+			if !n.conf.IPv4Enabled() {
+				continue
+			}
+		} else if !n.conf.IPv6Enabled() {
+			continue
+		}
+		excess[ipString] = parsedIP
+	}
+
+	// IPs handed out to endpoints must remain routable
+	for _, a := range n.allocators {
+		a.mutex.RLock()
+		for allocatedIP := range a.allocated {
+			delete(excess, allocatedIP)
+		}
+		a.mutex.RUnlock()
+	}
+
+	routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{
+		Table:    unix.RT_TABLE_MAIN,
+		Type:     unix.RTN_UNREACHABLE,
+		Protocol: unreachableRouteProto,
+	}, netlink.RT_FILTER_TABLE|netlink.RT_FILTER_TYPE|netlink.RT_FILTER_PROTOCOL)
+	if err != nil {
+		log.WithError(err).Warning("Unable to list unreachable routes")
+		return
+	}
+
+	for _, route := range routes {
+		if route.Dst == nil {
+			continue
+		}
+		ipString := route.Dst.IP.String()
+		if _, ok := excess[ipString]; ok {
+			// Route is already installed
+			delete(excess, ipString)
+			continue
+		}
+		// The IP has been allocated or left the pool, make it
+		// routable again
+		if err := netlink.RouteDel(&route); err != nil {
+			log.WithError(err).WithField("destination", route.Dst).Warning("Unable to remove unreachable route")
+			continue
+		}
+		metrics.IpamUnreachableRoutes.WithLabelValues(metricActionRemove).Inc()
+	}
+
+	for _, excessIP := range excess {
+		route := &netlink.Route{
+			Dst:      ip.IPToPrefix(excessIP),
+			Table:    unix.RT_TABLE_MAIN,
+			Type:     unix.RTN_UNREACHABLE,
+			Protocol: unreachableRouteProto,
+		}
+		if err := netlink.RouteReplace(route); err != nil {
+			log.WithError(err).WithField("destination", route.Dst).Warning("Unable to install unreachable route")
+			continue
+		}
+		metrics.IpamUnreachableRoutes.WithLabelValues(metricActionInstall).Inc()
+	}
+}
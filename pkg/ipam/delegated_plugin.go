@@ -0,0 +1,409 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package ipam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/option"
+
+	cniTypes "github.com/containernetworking/cni/pkg/types"
+	"github.com/containernetworking/cni/pkg/types/current"
+)
+
+const (
+	// delegatedPluginCNIVersion is the CNI spec version used when invoking
+	// the delegated IPAM plugin.
+	delegatedPluginCNIVersion = "0.3.1"
+
+	// delegatedPluginTimeout bounds a single invocation of the delegated
+	// IPAM plugin binary.
+	delegatedPluginTimeout = 30 * time.Second
+
+	// delegatedPluginProbeInterval is the interval in which the delegated
+	// IPAM plugin binary is probed for availability.
+	delegatedPluginProbeInterval = 30 * time.Second
+
+	// delegatedPluginProbeController is the name of the controller probing
+	// the delegated IPAM plugin binary.
+	delegatedPluginProbeController = "ipam-delegated-plugin-probe"
+)
+
+// delegatedPluginAllocation is the cached state of a single allocation
+// performed by the delegated IPAM plugin, persisted to disk so that
+// allocations of a previous agent run can be restored without invoking the
+// plugin again.
+type delegatedPluginAllocation struct {
+	// Owner is the owner the IP was allocated for.
+	Owner string `json:"owner"`
+
+	// ContainerID is the CNI container ID the plugin was invoked with,
+	// required to release the allocation again.
+	ContainerID string `json:"container-id"`
+
+	// Result is the allocation result translated from the plugin output.
+	Result *AllocationResult `json:"result"`
+
+	// restored is true when the allocation was loaded from the state file
+	// but has not been re-claimed since, only used during restoration.
+	restored bool
+}
+
+// delegatedPluginAllocator delegates IP allocation to an external, exec-based
+// CNI IPAM plugin. Allocations are cached in a state file under the runtime
+// state directory so that they survive agent restarts, and the plugin binary
+// is probed periodically so that a broken plugin surfaces in the overall IPAM
+// status rather than only on pod sandbox creation.
+type delegatedPluginAllocator struct {
+	mutex lock.RWMutex
+
+	// family is the address family this allocator manages
+	family Family
+
+	// plugin is the path of the delegated IPAM plugin binary
+	plugin string
+
+	// stateFile is the path of the file the allocation cache is persisted
+	// to
+	stateFile string
+
+	// allocated are all known allocations of the plugin, indexed by the
+	// string representation of the IP
+	allocated map[string]*delegatedPluginAllocation
+
+	// probeErr is the result of the last plugin probe, nil when the
+	// plugin is healthy
+	probeErr error
+
+	// controllers runs the plugin probe controller
+	controllers *controller.Manager
+}
+
+// delegatedPluginConf is the network configuration passed to the delegated
+// IPAM plugin on stdin.
+type delegatedPluginConf struct {
+	CNIVersion string                 `json:"cniVersion"`
+	Name       string                 `json:"name"`
+	Type       string                 `json:"type"`
+	IPAM       map[string]interface{} `json:"ipam"`
+}
+
+// newDelegatedPluginAllocator returns a new allocator delegating allocations
+// to the configured external CNI IPAM plugin.
+func newDelegatedPluginAllocator(family Family) Allocator {
+	plugin := option.Config.IPAMDelegatedPlugin
+	if plugin == "" {
+		log.Fatalf("Delegated IPAM requires --%s to be set", option.IPAMDelegatedPlugin)
+	}
+
+	a := &delegatedPluginAllocator{
+		family:      family,
+		plugin:      plugin,
+		stateFile:   filepath.Join(option.Config.StateDir, fmt.Sprintf("delegated_ipam_%s.json", family)),
+		allocated:   map[string]*delegatedPluginAllocation{},
+		controllers: controller.NewManager(),
+	}
+
+	if err := a.loadState(); err != nil {
+		log.WithError(err).Warning("Unable to restore delegated IPAM allocations, previous allocations may leak")
+	}
+
+	a.controllers.UpdateController(delegatedPluginProbeController,
+		controller.ControllerParams{
+			RunInterval: delegatedPluginProbeInterval,
+			DoFunc: func(ctx context.Context) error {
+				return a.probePlugin(ctx)
+			},
+		})
+
+	return a
+}
+
+// loadState restores the allocation cache of a previous agent run from the
+// state file. Restored entries are released again in RestoreFinished() unless
+// they have been re-claimed until then.
+func (d *delegatedPluginAllocator) loadState() error {
+	data, err := os.ReadFile(d.stateFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	allocated := map[string]*delegatedPluginAllocation{}
+	if err := json.Unmarshal(data, &allocated); err != nil {
+		return fmt.Errorf("invalid delegated IPAM state file %s: %w", d.stateFile, err)
+	}
+
+	for ip, alloc := range allocated {
+		alloc.restored = true
+		d.allocated[ip] = alloc
+	}
+	return nil
+}
+
+// saveState persists the allocation cache to the state file.
+// Must be called with the allocator mutex held.
+func (d *delegatedPluginAllocator) saveState() {
+	data, err := json.MarshalIndent(d.allocated, "", "  ")
+	if err != nil {
+		log.WithError(err).Warning("Unable to serialize delegated IPAM state")
+		return
+	}
+	if err := os.WriteFile(d.stateFile, data, 0600); err != nil {
+		log.WithError(err).Warning("Unable to write delegated IPAM state file")
+	}
+}
+
+// containerID derives a CNI container ID from the owner of an allocation.
+func containerID(owner string) string {
+	return "cilium-" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '-'
+		}
+	}, owner)
+}
+
+// invokePlugin executes the delegated IPAM plugin binary with the given CNI
+// command per the CNI spec: the command and container ID are passed in the
+// environment, the network configuration on stdin. For CNI_COMMAND=ADD, the
+// result is parsed from stdout.
+func (d *delegatedPluginAllocator) invokePlugin(ctx context.Context, command, id string, requestedIP net.IP) (*current.Result, error) {
+	conf := delegatedPluginConf{
+		CNIVersion: delegatedPluginCNIVersion,
+		Name:       "cilium-delegated-ipam",
+		Type:       filepath.Base(d.plugin),
+		IPAM: map[string]interface{}{
+			"type": filepath.Base(d.plugin),
+		},
+	}
+	stdin, err := json.Marshal(&conf)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, delegatedPluginTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, d.plugin)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Env = append(os.Environ(),
+		"CNI_COMMAND="+command,
+		"CNI_CONTAINERID="+id,
+		"CNI_NETNS=",
+		"CNI_IFNAME=eth0",
+		"CNI_PATH="+filepath.Dir(d.plugin),
+	)
+	if requestedIP != nil {
+		cmd.Env = append(cmd.Env, "CNI_ARGS=IP="+requestedIP.String())
+	}
+
+	stdout, err := cmd.Output()
+	if err != nil {
+		// On failure, plugins report a structured error on stdout.
+		pluginErr := &cniTypes.Error{}
+		if jsonErr := json.Unmarshal(stdout, pluginErr); jsonErr == nil && pluginErr.Msg != "" {
+			return nil, fmt.Errorf("delegated IPAM plugin %s %s failed: %s", d.plugin, command, pluginErr.Error())
+		}
+		return nil, fmt.Errorf("delegated IPAM plugin %s %s failed: %w", d.plugin, command, err)
+	}
+
+	if command != "ADD" {
+		return nil, nil
+	}
+
+	result := &current.Result{}
+	if err := json.Unmarshal(stdout, result); err != nil {
+		return nil, fmt.Errorf("unable to parse result of delegated IPAM plugin %s: %w", d.plugin, err)
+	}
+	return result, nil
+}
+
+// translateResult translates the CNI result of the delegated IPAM plugin into
+// an AllocationResult, selecting the address of the allocator's family.
+func (d *delegatedPluginAllocator) translateResult(result *current.Result) (*AllocationResult, error) {
+	for _, ipConfig := range result.IPs {
+		if DeriveFamily(ipConfig.Address.IP) != d.family {
+			continue
+		}
+
+		allocResult := &AllocationResult{
+			IP:    ipConfig.Address.IP,
+			CIDRs: []string{ipConfig.Address.String()},
+		}
+		if ipConfig.Gateway != nil {
+			allocResult.GatewayIP = ipConfig.Gateway.String()
+		}
+		return allocResult, nil
+	}
+
+	return nil, fmt.Errorf("delegated IPAM plugin %s returned no %s address", d.plugin, d.family)
+}
+
+// allocate invokes the delegated IPAM plugin to allocate an IP and caches the
+// translated result. A best-effort release is performed when the plugin
+// returns a result which cannot be used.
+func (d *delegatedPluginAllocator) allocate(ip net.IP, owner string) (*AllocationResult, error) {
+	id := containerID(owner)
+
+	result, err := d.invokePlugin(context.TODO(), "ADD", id, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	allocResult, err := d.translateResult(result)
+	if err == nil && ip != nil && !allocResult.IP.Equal(ip) {
+		err = fmt.Errorf("delegated IPAM plugin %s allocated %s instead of the requested %s",
+			d.plugin, allocResult.IP, ip)
+	}
+	if err != nil {
+		if _, delErr := d.invokePlugin(context.TODO(), "DEL", id, ip); delErr != nil {
+			log.WithError(delErr).Warning("Unable to release unusable allocation of delegated IPAM plugin")
+		}
+		return nil, err
+	}
+
+	d.mutex.Lock()
+	d.allocated[allocResult.IP.String()] = &delegatedPluginAllocation{
+		Owner:       owner,
+		ContainerID: id,
+		Result:      allocResult,
+	}
+	d.saveState()
+	d.mutex.Unlock()
+
+	return allocResult, nil
+}
+
+// Allocate allocates the given IP via the delegated IPAM plugin, passing the
+// requested IP in CNI_ARGS.
+func (d *delegatedPluginAllocator) Allocate(ip net.IP, owner string) (*AllocationResult, error) {
+	return d.allocate(ip, owner)
+}
+
+// AllocateWithoutSyncUpstream marks the given IP as allocated without
+// invoking the plugin, used to re-claim allocations of a previous agent run
+// from the cached state during endpoint restoration.
+func (d *delegatedPluginAllocator) AllocateWithoutSyncUpstream(ip net.IP, owner string) (*AllocationResult, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if alloc, ok := d.allocated[ip.String()]; ok {
+		alloc.restored = false
+		return alloc.Result, nil
+	}
+
+	// The allocation is unknown, e.g. the state file of the previous agent
+	// run was lost. Re-claim the IP without a result so that the endpoint
+	// can be restored regardless; the plugin remains the source of truth
+	// for the allocation.
+	d.allocated[ip.String()] = &delegatedPluginAllocation{
+		Owner:       owner,
+		ContainerID: containerID(owner),
+		Result:      &AllocationResult{IP: ip},
+	}
+	d.saveState()
+	return &AllocationResult{IP: ip}, nil
+}
+
+// Release releases the given IP via the delegated IPAM plugin.
+func (d *delegatedPluginAllocator) Release(ip net.IP) error {
+	d.mutex.Lock()
+	alloc, ok := d.allocated[ip.String()]
+	d.mutex.Unlock()
+	if !ok {
+		return fmt.Errorf("IP %s is not allocated", ip)
+	}
+
+	if _, err := d.invokePlugin(context.TODO(), "DEL", alloc.ContainerID, ip); err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	delete(d.allocated, ip.String())
+	d.saveState()
+	d.mutex.Unlock()
+	return nil
+}
+
+// AllocateNext allocates the next IP the delegated IPAM plugin hands out.
+func (d *delegatedPluginAllocator) AllocateNext(owner string) (*AllocationResult, error) {
+	return d.allocate(nil, owner)
+}
+
+// AllocateNextWithoutSyncUpstream is not supported without a specific IP, as
+// only the plugin can pick an address.
+func (d *delegatedPluginAllocator) AllocateNextWithoutSyncUpstream(owner string) (*AllocationResult, error) {
+	return nil, fmt.Errorf("delegated IPAM cannot allocate without invoking the plugin")
+}
+
+// Dump returns the cached allocations of the delegated IPAM plugin and a
+// status line including the result of the last plugin probe.
+func (d *delegatedPluginAllocator) Dump() (map[string]string, string) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	allocs := map[string]string{}
+	for ip, alloc := range d.allocated {
+		allocs[ip] = alloc.Owner
+	}
+
+	status := fmt.Sprintf("%d IPs allocated via delegated plugin %s", len(allocs), filepath.Base(d.plugin))
+	if d.probeErr != nil {
+		status += fmt.Sprintf(", plugin probe failed: %s", d.probeErr)
+	}
+	return allocs, status
+}
+
+// RestoreFinished releases all cached allocations of the previous agent run
+// which have not been re-claimed during endpoint restoration.
+func (d *delegatedPluginAllocator) RestoreFinished() {
+	d.mutex.Lock()
+	stale := map[string]*delegatedPluginAllocation{}
+	for ip, alloc := range d.allocated {
+		if alloc.restored {
+			stale[ip] = alloc
+			delete(d.allocated, ip)
+		}
+	}
+	d.saveState()
+	d.mutex.Unlock()
+
+	for ip, alloc := range stale {
+		log.WithField("ip", ip).Info("Releasing stale delegated IPAM allocation not re-claimed during restoration")
+		if _, err := d.invokePlugin(context.TODO(), "DEL", alloc.ContainerID, net.ParseIP(ip)); err != nil {
+			log.WithError(err).WithField("ip", ip).Warning("Unable to release stale delegated IPAM allocation")
+		}
+	}
+}
+
+// probePlugin executes the delegated IPAM plugin with CNI_COMMAND=VERSION to
+// detect a missing or broken plugin binary before pod sandbox creation fails.
+func (d *delegatedPluginAllocator) probePlugin(ctx context.Context) error {
+	_, err := d.invokePlugin(ctx, "VERSION", "cilium-probe", nil)
+
+	d.mutex.Lock()
+	d.probeErr = err
+	d.mutex.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("delegated IPAM plugin probe failed: %w", err)
+	}
+	return nil
+}
@@ -95,5 +95,12 @@ func (h *hostScopeAllocator) Dump() (map[string]string, string) {
 	return alloc, status
 }
 
+// AllocationDetails returns the pool and resource of the given allocated IP,
+// implementing AllocationDetailer. The pool of the host scope allocator is
+// its allocation CIDR, there is no associated resource.
+func (h *hostScopeAllocator) AllocationDetails(ip string) (pool, resource string) {
+	return h.allocCIDR.String(), ""
+}
+
 // RestoreFinished marks the status of restoration as done
 func (h *hostScopeAllocator) RestoreFinished() {}
@@ -5,6 +5,7 @@ package ipam
 
 import (
 	"net"
+	"time"
 
 	"github.com/cilium/cilium/pkg/cidr"
 	"github.com/cilium/cilium/pkg/datapath"
@@ -53,6 +54,10 @@ type Configuration interface {
 	// enabled
 	HealthCheckingEnabled() bool
 
+	// UnreachableRoutesEnabled must return true when unreachable routes
+	// are installed for IPs in the allocation pool which are not allocated
+	UnreachableRoutesEnabled() bool
+
 	// SetIPv4NativeRoutingCIDR is called by the IPAM module to announce
 	// the native IPv4 routing CIDR if it exists
 	SetIPv4NativeRoutingCIDR(cidr *cidr.CIDR)
@@ -90,6 +95,7 @@ func NewIPAM(nodeAddressing datapath.NodeAddressing, c Configuration, owner Owne
 		nodeAddressing:   nodeAddressing,
 		config:           c,
 		owner:            map[string]string{},
+		allocatedAt:      map[string]time.Time{},
 		expirationTimers: map[string]string{},
 		blacklist: IPBlacklist{
 			ips: map[string]string{},
@@ -119,6 +125,24 @@ func NewIPAM(nodeAddressing datapath.NodeAddressing, c Configuration, owner Owne
 		if c.IPv4Enabled() {
 			ipam.IPv4Allocator = newCRDAllocator(IPv4, c, owner, k8sEventReg, mtuConfig)
 		}
+	case ipamOption.IPAMDelegatedPlugin:
+		log.Info("Initializing delegated plugin IPAM")
+		if c.IPv6Enabled() {
+			ipam.IPv6Allocator = newDelegatedPluginAllocator(IPv6)
+		}
+
+		if c.IPv4Enabled() {
+			ipam.IPv4Allocator = newDelegatedPluginAllocator(IPv4)
+		}
+	case ipamOption.IPAMWebhook:
+		log.Info("Initializing webhook IPAM")
+		if c.IPv6Enabled() {
+			ipam.IPv6Allocator = newWebhookAllocator(IPv6)
+		}
+
+		if c.IPv4Enabled() {
+			ipam.IPv4Allocator = newWebhookAllocator(IPv4)
+		}
 	default:
 		log.Fatalf("Unknown IPAM backend %s", c.IPAMMode())
 	}
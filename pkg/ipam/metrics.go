@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package ipam
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cilium/cilium/pkg/metrics"
+)
+
+// poolDefault is the pool name reported for allocators which do not
+// distinguish multiple pools
+const poolDefault = "default"
+
+// States reported in the state label of the cilium_ipam_resource_ips metric
+const (
+	metricStateCapacity  = "capacity"
+	metricStateUsed      = "used"
+	metricStateAvailable = "available"
+)
+
+// Error types reported in the error label of the
+// cilium_ipam_allocation_errors_total metric
+const (
+	metricErrorPoolExhausted       = "pool_exhausted"
+	metricErrorIPReserved          = "ip_reserved"
+	metricErrorIPUnavailable       = "ip_unavailable"
+	metricErrorResourceUnavailable = "resource_unavailable"
+	metricErrorOther               = "other"
+)
+
+// errPoolExhausted is returned when an allocation fails because no more IPs
+// are available in the allocation pool
+var errPoolExhausted = errors.New("No more IPs available")
+
+// allocationErrorType maps an allocation error to the error label of the
+// cilium_ipam_allocation_errors_total metric
+func allocationErrorType(err error) string {
+	switch {
+	case errors.Is(err, errPoolExhausted):
+		return metricErrorPoolExhausted
+	case strings.Contains(err.Error(), "reserved"):
+		return metricErrorIPReserved
+	case strings.Contains(err.Error(), "in use"), strings.Contains(err.Error(), "not available"):
+		return metricErrorIPUnavailable
+	case strings.Contains(err.Error(), "CiliumNode"):
+		return metricErrorResourceUnavailable
+	}
+	return metricErrorOther
+}
+
+// observeAllocation records the outcome and duration of an allocation request
+func observeAllocation(family Family, start time.Time, err error) {
+	outcome := metrics.LabelValueOutcomeSuccess
+	if err != nil {
+		outcome = metrics.LabelValueOutcomeFail
+		metrics.IpamAllocationErrors.WithLabelValues(allocationErrorType(err)).Inc()
+	}
+	metrics.IpamAllocationDuration.WithLabelValues(string(family), outcome).Observe(time.Since(start).Seconds())
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"time"
 
+	operatorOption "github.com/cilium/cilium/operator/option"
 	"github.com/cilium/cilium/pkg/defaults"
 	ipamTypes "github.com/cilium/cilium/pkg/ipam/types"
 	v2 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2"
@@ -68,6 +69,11 @@ type Node struct {
 	// available is the map of IPs available to this node
 	available ipamTypes.AllocationMap
 
+	// ipsMarkedForRelease tracks when an IP was first selected as a
+	// candidate for release. The IP is only released to the provider once
+	// it has remained a candidate for the configured release delay.
+	ipsMarkedForRelease map[string]time.Time
+
 	// manager is the NodeManager responsible for this node
 	manager *NodeManager
 
@@ -516,16 +522,22 @@ func (n *Node) determineMaintenanceAction() (*maintenanceAction, error) {
 	// request may have been resolved in the meantime.
 	if n.manager.releaseExcessIPs && stats.ExcessIPs > 0 {
 		a.release = n.ops.PrepareIPRelease(stats.ExcessIPs, scopedLog)
-		scopedLog = scopedLog.WithFields(logrus.Fields{
-			"available":         stats.AvailableIPs,
-			"used":              stats.UsedIPs,
-			"excess":            stats.ExcessIPs,
-			"releasing":         a.release.IPsToRelease,
-			"selectedInterface": a.release.InterfaceID,
-			"selectedPoolID":    a.release.PoolID,
-		})
-		scopedLog.Info("Releasing excess IPs from node")
-		return a, nil
+		n.applyReleaseDelay(a.release)
+		if len(a.release.IPsToRelease) > 0 {
+			scopedLog = scopedLog.WithFields(logrus.Fields{
+				"available":         stats.AvailableIPs,
+				"used":              stats.UsedIPs,
+				"excess":            stats.ExcessIPs,
+				"releasing":         a.release.IPsToRelease,
+				"selectedInterface": a.release.InterfaceID,
+				"selectedPoolID":    a.release.PoolID,
+			})
+			scopedLog.Info("Releasing excess IPs from node")
+			return a, nil
+		}
+		// All excess IPs are still within the release delay, keep them
+		// around and re-evaluate on the next pool maintenance.
+		a.release = nil
 	}
 
 	// Validate that the node still requires addresses to be allocated, the
@@ -567,6 +579,42 @@ func (n *Node) determineMaintenanceAction() (*maintenanceAction, error) {
 	return a, nil
 }
 
+// applyReleaseDelay filters the IPs of a release action down to the IPs which
+// have been continuous candidates for release for at least the configured
+// excess-ip-release-delay. This cool-down window avoids releasing IPs which
+// were freed only momentarily during pod churn just to re-allocate them right
+// after, reducing API thrash against the provider. IPs which are no longer
+// candidates are unmarked again.
+func (n *Node) applyReleaseDelay(release *ReleaseAction) {
+	delay := time.Duration(operatorOption.Config.ExcessIPReleaseDelay) * time.Second
+	now := time.Now()
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	candidates := make(map[string]struct{}, len(release.IPsToRelease))
+	matured := make([]string, 0, len(release.IPsToRelease))
+	for _, ip := range release.IPsToRelease {
+		candidates[ip] = struct{}{}
+		markedAt, ok := n.ipsMarkedForRelease[ip]
+		if !ok {
+			markedAt = now
+			n.ipsMarkedForRelease[ip] = now
+		}
+		if now.Sub(markedAt) >= delay {
+			matured = append(matured, ip)
+		}
+	}
+
+	for ip := range n.ipsMarkedForRelease {
+		if _, ok := candidates[ip]; !ok {
+			delete(n.ipsMarkedForRelease, ip)
+		}
+	}
+
+	release.IPsToRelease = matured
+}
+
 // maintainIPPool attempts to allocate or release all required IPs to fulfill
 // the needed gap.
 func (n *Node) maintainIPPool(ctx context.Context) error {
@@ -587,6 +635,11 @@ func (n *Node) maintainIPPool(ctx context.Context) error {
 		err := n.ops.ReleaseIPs(ctx, a.release)
 		if err == nil {
 			n.manager.metricsAPI.AddIPRelease(string(a.release.PoolID), int64(len(a.release.IPsToRelease)))
+			n.mutex.Lock()
+			for _, ip := range a.release.IPsToRelease {
+				delete(n.ipsMarkedForRelease, ip)
+			}
+			n.mutex.Unlock()
 			return nil
 		}
 		n.manager.metricsAPI.IncAllocationAttempt("ip unassignment failed", string(a.release.PoolID))
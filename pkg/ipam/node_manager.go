@@ -257,8 +257,9 @@ func (n *NodeManager) Update(resource *v2.CiliumNode) (nodeSynced bool) {
 	}()
 	if !ok {
 		node = &Node{
-			name:    resource.Name,
-			manager: n,
+			name:                resource.Name,
+			manager:             n,
+			ipsMarkedForRelease: map[string]time.Time{},
 		}
 
 		node.ops = n.instancesAPI.CreateNode(resource, node)
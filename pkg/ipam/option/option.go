@@ -25,4 +25,32 @@ const (
 
 	// IPAMAlibabaCloud is the value to select the AlibabaCloud ENI IPAM plugin for option.IPAM
 	IPAMAlibabaCloud = "alibabacloud"
+
+	// IPAMDelegatedPlugin is the value to delegate IP allocation to an
+	// external CNI IPAM plugin for option.IPAM
+	IPAMDelegatedPlugin = "delegated-plugin"
+
+	// IPAMWebhook is the value to delegate IP allocation to an external
+	// IPAM webhook for option.IPAM
+	IPAMWebhook = "webhook"
+)
+
+const (
+	// IPAMAllocationStrategyFirst is the value to select the allocation
+	// strategy handing out the first available IP
+	IPAMAllocationStrategyFirst = "first"
+
+	// IPAMAllocationStrategyRoundRobin is the value to select the
+	// allocation strategy rotating across resources
+	IPAMAllocationStrategyRoundRobin = "round-robin"
+
+	// IPAMAllocationStrategyLeastAllocated is the value to select the
+	// allocation strategy preferring the resource with the fewest
+	// allocated IPs
+	IPAMAllocationStrategyLeastAllocated = "least-allocated"
+
+	// IPAMAllocationStrategySubnetAffinity is the value to select the
+	// allocation strategy preferring resources which already serve
+	// allocations
+	IPAMAllocationStrategySubnetAffinity = "subnet-affinity"
 )
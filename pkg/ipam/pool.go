@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package ipam
+
+import (
+	"fmt"
+
+	"github.com/cilium/cilium/pkg/lock"
+)
+
+// Pool is the name of an IP pool addresses can be drawn from.
+type Pool string
+
+const (
+	// PoolDefault is the pool used when no explicit pool has been
+	// requested for a pod.
+	PoolDefault Pool = "default"
+
+	// PodIPPoolAnnotation is the annotation used to request a named pool
+	// for a pod. The annotation may be set on the pod directly or on its
+	// namespace, the pod annotation takes precedence.
+	PodIPPoolAnnotation = "ipam.cilium.io/ip-pool"
+)
+
+// PoolSelector resolves the pool a pod draws its IPs from and verifies that
+// the resolved pool is present on the node. The default pool is always
+// present, additional pools are registered by the IPAM implementation.
+type PoolSelector struct {
+	mutex lock.RWMutex
+	pools map[Pool]struct{}
+}
+
+// NewPoolSelector returns a new pool selector with the default pool
+// registered.
+func NewPoolSelector() *PoolSelector {
+	return &PoolSelector{
+		pools: map[Pool]struct{}{PoolDefault: {}},
+	}
+}
+
+// RegisterPool makes a named pool available for selection.
+func (s *PoolSelector) RegisterPool(pool Pool) {
+	s.mutex.Lock()
+	s.pools[pool] = struct{}{}
+	s.mutex.Unlock()
+}
+
+// PoolForPod returns the pool a pod draws its IPs from based on the pod and
+// namespace annotations. The pod annotation takes precedence over the
+// namespace annotation. An error is returned if the pod requests a pool which
+// is not present on the node.
+func (s *PoolSelector) PoolForPod(podAnnotations, namespaceAnnotations map[string]string) (Pool, error) {
+	pool := PoolDefault
+	if name, ok := namespaceAnnotations[PodIPPoolAnnotation]; ok {
+		pool = Pool(name)
+	}
+	if name, ok := podAnnotations[PodIPPoolAnnotation]; ok {
+		pool = Pool(name)
+	}
+
+	s.mutex.RLock()
+	_, ok := s.pools[pool]
+	s.mutex.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("pod requests IP pool %q which is not present on this node", pool)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package ipam
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *IPAMSuite) TestPoolForPod(c *C) {
+	selector := NewPoolSelector()
+	selector.RegisterPool("mars")
+
+	// No annotations resolve to the default pool
+	pool, err := selector.PoolForPod(nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(pool, Equals, PoolDefault)
+
+	// Namespace annotation selects the pool
+	pool, err = selector.PoolForPod(nil, map[string]string{PodIPPoolAnnotation: "mars"})
+	c.Assert(err, IsNil)
+	c.Assert(pool, Equals, Pool("mars"))
+
+	// Pod annotation takes precedence over the namespace annotation
+	pool, err = selector.PoolForPod(
+		map[string]string{PodIPPoolAnnotation: string(PoolDefault)},
+		map[string]string{PodIPPoolAnnotation: "mars"})
+	c.Assert(err, IsNil)
+	c.Assert(pool, Equals, PoolDefault)
+
+	// Requesting a pool not present on the node fails
+	_, err = selector.PoolForPod(map[string]string{PodIPPoolAnnotation: "jupiter"}, nil)
+	c.Assert(err, ErrorMatches, ".*jupiter.*not present on this node")
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package ipam
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	ipamOption "github.com/cilium/cilium/pkg/ipam/option"
+	ipamTypes "github.com/cilium/cilium/pkg/ipam/types"
+	"github.com/cilium/cilium/pkg/lock"
+)
+
+// allocationCandidate is an available IP in the allocation pool together with
+// its metadata, most importantly the resource (e.g. ENI) the IP belongs to.
+type allocationCandidate struct {
+	ip     net.IP
+	ipInfo ipamTypes.AllocationIP
+}
+
+// allocationStrategy selects the IP to hand out next among the available
+// candidates. Strategies only choose an IP, marking it as allocated remains
+// the responsibility of the caller.
+type allocationStrategy interface {
+	// selectCandidate picks the IP to allocate among the available
+	// candidates, keyed by the resource each IP belongs to. The candidates
+	// map is guaranteed to be non-empty. allocatedPerResource holds the
+	// number of IPs already allocated from each resource, including
+	// resources without remaining candidates.
+	selectCandidate(candidates map[string][]allocationCandidate, allocatedPerResource map[string]int) allocationCandidate
+}
+
+// newAllocationStrategy returns the allocation strategy selected with the
+// given configuration value. The empty string selects the default strategy
+// handing out the first available IP.
+func newAllocationStrategy(name string) (allocationStrategy, error) {
+	switch name {
+	case "", ipamOption.IPAMAllocationStrategyFirst:
+		return &firstAvailableStrategy{}, nil
+	case ipamOption.IPAMAllocationStrategyRoundRobin:
+		return &roundRobinStrategy{}, nil
+	case ipamOption.IPAMAllocationStrategyLeastAllocated:
+		return &leastAllocatedStrategy{}, nil
+	case ipamOption.IPAMAllocationStrategySubnetAffinity:
+		return &subnetAffinityStrategy{}, nil
+	}
+
+	return nil, fmt.Errorf("unknown allocation strategy %q", name)
+}
+
+// sortedResources returns the names of all resources with available
+// candidates in lexicographic order
+func sortedResources(candidates map[string][]allocationCandidate) []string {
+	resources := make([]string, 0, len(candidates))
+	for resource := range candidates {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	return resources
+}
+
+// firstAvailableStrategy hands out an IP from the first resource with
+// available candidates. This matches the historic allocation behavior.
+type firstAvailableStrategy struct{}
+
+func (s *firstAvailableStrategy) selectCandidate(candidates map[string][]allocationCandidate, allocatedPerResource map[string]int) allocationCandidate {
+	return candidates[sortedResources(candidates)[0]][0]
+}
+
+// roundRobinStrategy rotates allocations across all resources with available
+// candidates to spread the allocated IPs evenly.
+type roundRobinStrategy struct {
+	mutex lock.Mutex
+
+	// lastResource is the resource the previous allocation was served
+	// from, the next allocation is served from the next resource in
+	// lexicographic order
+	lastResource string
+}
+
+func (s *roundRobinStrategy) selectCandidate(candidates map[string][]allocationCandidate, allocatedPerResource map[string]int) allocationCandidate {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	resources := sortedResources(candidates)
+	selected := resources[0]
+	for _, resource := range resources {
+		if resource > s.lastResource {
+			selected = resource
+			break
+		}
+	}
+
+	s.lastResource = selected
+	return candidates[selected][0]
+}
+
+// leastAllocatedStrategy hands out an IP from the resource with the fewest
+// allocated IPs, filling up underutilized resources first.
+type leastAllocatedStrategy struct{}
+
+func (s *leastAllocatedStrategy) selectCandidate(candidates map[string][]allocationCandidate, allocatedPerResource map[string]int) allocationCandidate {
+	selected := ""
+	for _, resource := range sortedResources(candidates) {
+		if selected == "" || allocatedPerResource[resource] < allocatedPerResource[selected] {
+			selected = resource
+		}
+	}
+
+	return candidates[selected][0]
+}
+
+// subnetAffinityStrategy prefers resources which already serve allocations,
+// keeping the allocated IPs packed into as few subnets as possible.
+type subnetAffinityStrategy struct{}
+
+func (s *subnetAffinityStrategy) selectCandidate(candidates map[string][]allocationCandidate, allocatedPerResource map[string]int) allocationCandidate {
+	selected := ""
+	for _, resource := range sortedResources(candidates) {
+		if selected == "" || allocatedPerResource[resource] > allocatedPerResource[selected] {
+			selected = resource
+		}
+	}
+
+	return candidates[selected][0]
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_test
+// +build !privileged_test
+
+package ipam
+
+import (
+	"net"
+
+	. "gopkg.in/check.v1"
+)
+
+func strategyCandidates() map[string][]allocationCandidate {
+	return map[string][]allocationCandidate{
+		"eni-1": {{ip: net.ParseIP("10.0.1.1")}},
+		"eni-2": {{ip: net.ParseIP("10.0.2.1")}, {ip: net.ParseIP("10.0.2.2")}},
+		"eni-3": {{ip: net.ParseIP("10.0.3.1")}},
+	}
+}
+
+func (s *IPAMSuite) TestNewAllocationStrategy(c *C) {
+	for _, name := range []string{"", "first", "round-robin", "least-allocated", "subnet-affinity"} {
+		strategy, err := newAllocationStrategy(name)
+		c.Assert(err, IsNil)
+		c.Assert(strategy, Not(IsNil))
+	}
+
+	_, err := newAllocationStrategy("invalid")
+	c.Assert(err, Not(IsNil))
+}
+
+func (s *IPAMSuite) TestFirstAvailableStrategy(c *C) {
+	strategy := &firstAvailableStrategy{}
+	candidate := strategy.selectCandidate(strategyCandidates(), map[string]int{})
+	c.Assert(candidate.ip.String(), Equals, "10.0.1.1")
+}
+
+func (s *IPAMSuite) TestRoundRobinStrategy(c *C) {
+	strategy := &roundRobinStrategy{}
+	allocated := map[string]int{}
+
+	candidate := strategy.selectCandidate(strategyCandidates(), allocated)
+	c.Assert(candidate.ip.String(), Equals, "10.0.1.1")
+	candidate = strategy.selectCandidate(strategyCandidates(), allocated)
+	c.Assert(candidate.ip.String(), Equals, "10.0.2.1")
+	candidate = strategy.selectCandidate(strategyCandidates(), allocated)
+	c.Assert(candidate.ip.String(), Equals, "10.0.3.1")
+	// After the last resource, the rotation wraps around.
+	candidate = strategy.selectCandidate(strategyCandidates(), allocated)
+	c.Assert(candidate.ip.String(), Equals, "10.0.1.1")
+}
+
+func (s *IPAMSuite) TestLeastAllocatedStrategy(c *C) {
+	strategy := &leastAllocatedStrategy{}
+	allocated := map[string]int{"eni-1": 5, "eni-2": 1, "eni-3": 3}
+
+	candidate := strategy.selectCandidate(strategyCandidates(), allocated)
+	c.Assert(candidate.ipInfo, Equals, strategyCandidates()["eni-2"][0].ipInfo)
+	c.Assert(candidate.ip.String(), Equals, "10.0.2.1")
+}
+
+func (s *IPAMSuite) TestSubnetAffinityStrategy(c *C) {
+	strategy := &subnetAffinityStrategy{}
+
+	// Without any allocations, the first resource is used.
+	candidate := strategy.selectCandidate(strategyCandidates(), map[string]int{})
+	c.Assert(candidate.ip.String(), Equals, "10.0.1.1")
+
+	// The resource already serving the most allocations is preferred.
+	candidate = strategy.selectCandidate(strategyCandidates(), map[string]int{"eni-3": 2, "eni-2": 1})
+	c.Assert(candidate.ip.String(), Equals, "10.0.3.1")
+}
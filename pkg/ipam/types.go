@@ -5,6 +5,7 @@ package ipam
 
 import (
 	"net"
+	"time"
 
 	"github.com/cilium/cilium/pkg/datapath"
 	"github.com/cilium/cilium/pkg/lock"
@@ -73,6 +74,28 @@ type Allocator interface {
 	RestoreFinished()
 }
 
+// ReservationAllocator is implemented by allocators that support pinning
+// specific IPs to an owner across agent restarts, currently only the
+// CRD-backed allocator.
+type ReservationAllocator interface {
+	// ReserveIP reserves the given IP of the allocation pool for the
+	// given owner. Only allocations by this owner may use the IP until
+	// the reservation is released. The pool may optionally restrict the
+	// reservation to a specific resource pool, e.g. an ENI ID.
+	ReserveIP(ip net.IP, owner, pool string) error
+
+	// ReleaseReservation releases a previously installed reservation
+	ReleaseReservation(ip net.IP) error
+}
+
+// AllocationDetailer is an optional interface an Allocator can implement to
+// provide the pool and resource details of its allocations.
+type AllocationDetailer interface {
+	// AllocationDetails returns the allocation pool and the associated
+	// resource, e.g. the ENI ID, of the given allocated IP.
+	AllocationDetails(ip string) (pool, resource string)
+}
+
 // IPBlacklist is a structure used to store information related to blacklisted
 // IPs and IPNetworks.
 type IPBlacklist struct {
@@ -91,6 +114,10 @@ type IPAM struct {
 	// owner maps an IP to the owner
 	owner map[string]string
 
+	// allocatedAt maps an IP to its allocation time. IPs allocated by a
+	// previous agent run have no entry.
+	allocatedAt map[string]time.Time
+
 	// expirationTimers is a map of all expiration timers. Each entry
 	// represents a IP allocation which is protected by an expiration
 	// timer.
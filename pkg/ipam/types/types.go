@@ -5,6 +5,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/cilium/cilium/pkg/cidr"
 	"github.com/cilium/cilium/pkg/lock"
@@ -47,6 +48,49 @@ type AllocationIP struct {
 // AllocationMap is a map of allocated IPs indexed by IP
 type AllocationMap map[string]AllocationIP
 
+// Allocation describes a single allocated IP of an agent in a
+// machine-readable form, e.g. for listing allocations via the API.
+type Allocation struct {
+	// IP is the allocated IP
+	IP string `json:"ip"`
+
+	// Family is the address family of the IP, "ipv4" or "ipv6"
+	Family string `json:"family"`
+
+	// Owner is the owner of the IP, e.g. the pod name
+	Owner string `json:"owner,omitempty"`
+
+	// Pool is the allocation pool the IP was allocated from
+	Pool string `json:"pool,omitempty"`
+
+	// Resource is the resource the IP is associated with, e.g. the ENI ID
+	Resource string `json:"resource,omitempty"`
+
+	// AllocatedAt is when the IP was allocated. Unset for allocations of
+	// a previous agent run.
+	AllocatedAt time.Time `json:"allocated-at,omitempty"`
+}
+
+// IPReservation pins an IP of the allocation pool to a specific owner
+type IPReservation struct {
+	// Owner is the owner the IP is reserved for. Only allocations by this
+	// owner may use the reserved IP, e.g. a pod requesting a fixed
+	// address via annotation
+	//
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// Pool optionally associates the reservation with a specific resource
+	// pool the IP must be allocated from, e.g. in combination with AWS
+	// ENI, the ID of the ENI
+	//
+	// +optional
+	Pool string `json:"pool,omitempty"`
+}
+
+// ReservationMap is a map of reserved IPs indexed by IP
+type ReservationMap map[string]IPReservation
+
 // IPAMSpec is the IPAM specification of the node
 //
 // This structure is embedded into v2.CiliumNode
@@ -58,6 +102,14 @@ type IPAMSpec struct {
 	// +optional
 	Pool AllocationMap `json:"pool,omitempty"`
 
+	// ReservedIPs is the set of IPs of Pool that are reserved for a
+	// specific owner. A reserved IP is only handed out to its owner and
+	// is skipped by regular allocation. Reservations survive agent
+	// restarts as they are persisted in the custom resource.
+	//
+	// +optional
+	ReservedIPs ReservationMap `json:"reserved-ips,omitempty"`
+
 	// PodCIDRs is the list of CIDRs available to the node for allocation.
 	// When an IP is used, the IP will be added to Status.IPAM.Used
 	//
@@ -114,6 +166,12 @@ type IPAMStatus struct {
 	//
 	// +optional
 	OperatorStatus OperatorStatus `json:"operator-status,omitempty"`
+
+	// ValidationConditions lists the configuration validators which have
+	// failed for the node. Only failing validators are reported.
+	//
+	// +optional
+	ValidationConditions []ValidationCondition `json:"validation-conditions,omitempty"`
 }
 
 // OperatorStatus is the status used by cilium-operator to report
@@ -125,6 +183,16 @@ type OperatorStatus struct {
 	Error string `json:"error,omitempty"`
 }
 
+// ValidationCondition reports the failure of a single configuration
+// validator.
+type ValidationCondition struct {
+	// Validator is the name of the validator which failed.
+	Validator string `json:"validator"`
+
+	// Error is the error message reported by the validator.
+	Error string `json:"error"`
+}
+
 // Tags implements generic key value tags
 type Tags map[string]string
 
@@ -56,6 +56,13 @@ func (in *IPAMSpec) DeepCopyInto(out *IPAMSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ReservedIPs != nil {
+		in, out := &in.ReservedIPs, &out.ReservedIPs
+		*out = make(ReservationMap, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.PodCIDRs != nil {
 		in, out := &in.PodCIDRs, &out.PodCIDRs
 		*out = make([]string, len(*in))
@@ -85,6 +92,11 @@ func (in *IPAMStatus) DeepCopyInto(out *IPAMStatus) {
 		}
 	}
 	out.OperatorStatus = in.OperatorStatus
+	if in.ValidationConditions != nil {
+		in, out := &in.ValidationConditions, &out.ValidationConditions
+		*out = make([]ValidationCondition, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -98,6 +110,22 @@ func (in *IPAMStatus) DeepCopy() *IPAMStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPReservation) DeepCopyInto(out *IPReservation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPReservation.
+func (in *IPReservation) DeepCopy() *IPReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(IPReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Limits) DeepCopyInto(out *Limits) {
 	*out = *in
@@ -168,6 +196,28 @@ func (in PoolQuotaMap) DeepCopy() PoolQuotaMap {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ReservationMap) DeepCopyInto(out *ReservationMap) {
+	{
+		in := &in
+		*out = make(ReservationMap, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationMap.
+func (in ReservationMap) DeepCopy() ReservationMap {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationMap)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Subnet) DeepCopyInto(out *Subnet) {
 	*out = *in
@@ -247,6 +297,22 @@ func (in Tags) DeepCopy() Tags {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationCondition) DeepCopyInto(out *ValidationCondition) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationCondition.
+func (in *ValidationCondition) DeepCopy() *ValidationCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualNetwork) DeepCopyInto(out *VirtualNetwork) {
 	*out = *in
@@ -63,6 +63,13 @@ func (in *IPAMSpec) DeepEqual(other *IPAMSpec) bool {
 		}
 	}
 
+	if ((in.ReservedIPs != nil) && (other.ReservedIPs != nil)) || ((in.ReservedIPs == nil) != (other.ReservedIPs == nil)) {
+		in, other := &in.ReservedIPs, &other.ReservedIPs
+		if other == nil || !in.DeepEqual(other) {
+			return false
+		}
+	}
+
 	if ((in.PodCIDRs != nil) && (other.PodCIDRs != nil)) || ((in.PodCIDRs == nil) != (other.PodCIDRs == nil)) {
 		in, other := &in.PodCIDRs, &other.PodCIDRs
 		if other == nil {
@@ -114,6 +121,40 @@ func (in *IPAMStatus) DeepEqual(other *IPAMStatus) bool {
 		return false
 	}
 
+	if ((in.ValidationConditions != nil) && (other.ValidationConditions != nil)) || ((in.ValidationConditions == nil) != (other.ValidationConditions == nil)) {
+		in, other := &in.ValidationConditions, &other.ValidationConditions
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if !inElement.DeepEqual(&(*other)[i]) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *IPReservation) DeepEqual(other *IPReservation) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Owner != other.Owner {
+		return false
+	}
+	if in.Pool != other.Pool {
+		return false
+	}
+
 	return true
 }
 
@@ -192,6 +233,30 @@ func (in *PoolQuotaMap) DeepEqual(other *PoolQuotaMap) bool {
 	return true
 }
 
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *ReservationMap) DeepEqual(other *ReservationMap) bool {
+	if other == nil {
+		return false
+	}
+
+	if len(*in) != len(*other) {
+		return false
+	} else {
+		for key, inValue := range *in {
+			if otherValue, present := (*other)[key]; !present {
+				return false
+			} else {
+				if !inValue.DeepEqual(&otherValue) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
 // DeepEqual is an autogenerated deepequal function, deeply comparing the
 // receiver with other. in must be non-nil.
 func (in *Subnet) DeepEqual(other *Subnet) bool {
@@ -294,6 +359,23 @@ func (in *Tags) DeepEqual(other *Tags) bool {
 	return true
 }
 
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *ValidationCondition) DeepEqual(other *ValidationCondition) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Validator != other.Validator {
+		return false
+	}
+	if in.Error != other.Error {
+		return false
+	}
+
+	return true
+}
+
 // DeepEqual is an autogenerated deepequal function, deeply comparing the
 // receiver with other. in must be non-nil.
 func (in *VirtualNetwork) DeepEqual(other *VirtualNetwork) bool {
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package ipam
+
+import (
+	"github.com/cilium/cilium/pkg/eventjournal"
+	"github.com/cilium/cilium/pkg/metrics"
+	"github.com/cilium/cilium/pkg/option"
+
+	"github.com/sirupsen/logrus"
+)
+
+// checkPoolWatermark recomputes the number of IPs available for allocation
+// per address family, exports it as a metric and, when the number drops below
+// the configured low watermark, emits a warning event and triggers a refresh
+// of the custom resource so that the operator can be asked for more IPs
+// before the pool is exhausted.
+//
+// The caller must not hold any allocator or store locks.
+func (n *nodeStore) checkPoolWatermark() {
+	n.mutex.RLock()
+	if n.ownNode == nil {
+		n.mutex.RUnlock()
+		return
+	}
+	poolSize := map[Family]int{
+		IPv4: n.allocationPoolSize[IPv4],
+		IPv6: n.allocationPoolSize[IPv6],
+	}
+	staleCopyOfAllocators := make([]*crdAllocator, len(n.allocators))
+	copy(staleCopyOfAllocators, n.allocators)
+	n.mutex.RUnlock()
+
+	used := map[Family]int{}
+	for _, a := range staleCopyOfAllocators {
+		a.mutex.RLock()
+		used[a.family] += len(a.allocated)
+		a.mutex.RUnlock()
+	}
+
+	watermark := option.Config.IPAMPoolLowWatermark
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for _, family := range []Family{IPv4, IPv6} {
+		switch family {
+		case IPv4:
+			if !n.conf.IPv4Enabled() {
+				continue
+			}
+		case IPv6:
+			if !n.conf.IPv6Enabled() {
+				continue
+			}
+		}
+
+		available := poolSize[family] - used[family]
+		if available < 0 {
+			available = 0
+		}
+
+		metrics.IpamAvailableIPs.WithLabelValues(string(family)).Set(float64(available))
+
+		if watermark <= 0 {
+			continue
+		}
+
+		below := available < watermark
+		if below && !n.belowWatermark[family] {
+			log.WithFields(logrus.Fields{
+				"family":    family,
+				"available": available,
+				"watermark": watermark,
+			}).Warning("Available IPs in allocation pool dropped below low watermark")
+			eventjournal.Record(eventjournal.ReasonIPPoolLow,
+				"Available %s IPs (%d) in allocation pool below low watermark (%d)",
+				family, available, watermark)
+			// Synchronize the allocation state to the custom resource
+			// immediately so that the operator can react and extend the
+			// pool before it runs out of IPs.
+			n.refreshTrigger.TriggerWithReason("pool below low watermark")
+		}
+		n.belowWatermark[family] = below
+	}
+}
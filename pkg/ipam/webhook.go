@@ -0,0 +1,409 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package ipam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/lock"
+	nodeTypes "github.com/cilium/cilium/pkg/node/types"
+	"github.com/cilium/cilium/pkg/option"
+)
+
+const (
+	// webhookTimeout bounds a single request to the IPAM webhook.
+	webhookTimeout = 10 * time.Second
+
+	// webhookHealthInterval is the interval in which the IPAM webhook is
+	// health checked.
+	webhookHealthInterval = 30 * time.Second
+
+	// webhookHealthController is the name of the controller health
+	// checking the IPAM webhook.
+	webhookHealthController = "ipam-webhook-health"
+
+	// webhookRenewController is the name of the controller renewing the
+	// leases of all allocations with the IPAM webhook.
+	webhookRenewController = "ipam-webhook-renew"
+
+	// webhookMaxErrorBody is the maximum number of bytes of an error
+	// response body included in the returned error.
+	webhookMaxErrorBody = 512
+)
+
+// webhookAllocateRequest is the request body sent to the allocate endpoint
+// of the IPAM webhook. An allocate call with the idempotency key of an
+// existing lease must return the same address and extend the lease instead
+// of allocating a new one; the agent relies on this both to retry failed
+// calls and to periodically renew its leases.
+type webhookAllocateRequest struct {
+	// IdempotencyKey uniquely identifies the allocation across retries
+	// and lease renewals.
+	IdempotencyKey string `json:"idempotency-key"`
+
+	// Owner is the owner the IP is allocated for, informational only.
+	Owner string `json:"owner"`
+
+	// Family is the requested address family, "ipv4" or "ipv6".
+	Family string `json:"family"`
+
+	// IP is the specific address requested, empty when the webhook is
+	// free to pick any address.
+	IP string `json:"ip,omitempty"`
+
+	// LeaseTTLSeconds is the number of seconds the webhook must keep the
+	// lease alive without a renewal. The webhook may reclaim the address
+	// once the lease has expired.
+	LeaseTTLSeconds int64 `json:"lease-ttl-seconds"`
+}
+
+// webhookAllocateResponse is the response body of the allocate endpoint of
+// the IPAM webhook.
+type webhookAllocateResponse struct {
+	// IP is the allocated address.
+	IP string `json:"ip"`
+
+	// CIDR optionally is the prefix the address was allocated from.
+	CIDR string `json:"cidr,omitempty"`
+
+	// Gateway optionally is the gateway to use for the address.
+	Gateway string `json:"gateway,omitempty"`
+}
+
+// webhookReleaseRequest is the request body sent to the release endpoint of
+// the IPAM webhook. Releasing a lease which does not exist must succeed so
+// that release calls can be retried.
+type webhookReleaseRequest struct {
+	// IdempotencyKey is the key the allocation was made with.
+	IdempotencyKey string `json:"idempotency-key"`
+
+	// IP is the address to release.
+	IP string `json:"ip"`
+}
+
+// webhookAllocation is the local record of a single lease held with the
+// IPAM webhook.
+type webhookAllocation struct {
+	owner          string
+	idempotencyKey string
+	result         *AllocationResult
+}
+
+// webhookAllocator allocates IPs by calling an external IPAM webhook over
+// HTTP, allowing external IPAM systems to be integrated without writing a
+// delegated CNI plugin. Allocations are leases which the agent renews
+// periodically; no local state is persisted as leases of a crashed agent
+// expire on their own and allocations of restored endpoints are
+// re-established via their idempotency keys on the next renewal.
+type webhookAllocator struct {
+	mutex lock.RWMutex
+
+	// family is the address family this allocator manages
+	family Family
+
+	// url is the base URL of the IPAM webhook
+	url string
+
+	// leaseTTL is the lease TTL requested for allocations
+	leaseTTL time.Duration
+
+	// client is the HTTP client used for all webhook requests
+	client *http.Client
+
+	// allocated are all leases held with the webhook, indexed by the
+	// string representation of the IP
+	allocated map[string]*webhookAllocation
+
+	// healthErr is the result of the last webhook health check, nil when
+	// the webhook is healthy
+	healthErr error
+
+	// controllers runs the health check and lease renewal controllers
+	controllers *controller.Manager
+}
+
+// newWebhookAllocator returns a new allocator calling the configured
+// external IPAM webhook for allocations.
+func newWebhookAllocator(family Family) Allocator {
+	url := option.Config.IPAMWebhookURL
+	if url == "" {
+		log.Fatalf("Webhook IPAM requires --%s to be set", option.IPAMWebhookURL)
+	}
+
+	a := &webhookAllocator{
+		family:      family,
+		url:         url,
+		leaseTTL:    option.Config.IPAMWebhookLeaseTTL,
+		client:      &http.Client{Timeout: webhookTimeout},
+		allocated:   map[string]*webhookAllocation{},
+		controllers: controller.NewManager(),
+	}
+
+	a.controllers.UpdateController(webhookHealthController,
+		controller.ControllerParams{
+			RunInterval: webhookHealthInterval,
+			DoFunc: func(ctx context.Context) error {
+				return a.checkHealth(ctx)
+			},
+		})
+
+	// Renew leases at half their TTL so a single failed renewal does not
+	// expire them.
+	a.controllers.UpdateController(webhookRenewController,
+		controller.ControllerParams{
+			RunInterval: a.leaseTTL / 2,
+			DoFunc: func(ctx context.Context) error {
+				return a.renewLeases(ctx)
+			},
+		})
+
+	return a
+}
+
+// idempotencyKey derives the idempotency key of an allocation. The key must
+// be stable across agent restarts so that allocations of restored endpoints
+// renew the existing lease instead of creating a new one.
+func (a *webhookAllocator) idempotencyKey(owner string) string {
+	return nodeTypes.GetName() + "/" + string(a.family) + "/" + owner
+}
+
+// post sends the given request body to the given endpoint of the IPAM
+// webhook, decoding the response body into out when it is non-nil.
+func (a *webhookAllocator) post(ctx context.Context, endpoint string, request, out interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url+endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("IPAM webhook %s failed: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxErrorBody))
+		return fmt.Errorf("IPAM webhook %s failed with status %s: %s", endpoint, resp.Status, msg)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("unable to parse response of IPAM webhook %s: %w", endpoint, err)
+		}
+	}
+	return nil
+}
+
+// allocate requests an allocation from the IPAM webhook and records the
+// returned lease.
+func (a *webhookAllocator) allocate(ip net.IP, owner string) (*AllocationResult, error) {
+	key := a.idempotencyKey(owner)
+	request := &webhookAllocateRequest{
+		IdempotencyKey:  key,
+		Owner:           owner,
+		Family:          string(a.family),
+		LeaseTTLSeconds: int64(a.leaseTTL / time.Second),
+	}
+	if ip != nil {
+		request.IP = ip.String()
+	}
+
+	response := &webhookAllocateResponse{}
+	if err := a.post(context.TODO(), "/allocate", request, response); err != nil {
+		return nil, err
+	}
+
+	result, err := a.translateResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	if ip != nil && !result.IP.Equal(ip) {
+		return nil, fmt.Errorf("IPAM webhook allocated %s instead of the requested %s", result.IP, ip)
+	}
+
+	a.mutex.Lock()
+	a.allocated[result.IP.String()] = &webhookAllocation{
+		owner:          owner,
+		idempotencyKey: key,
+		result:         result,
+	}
+	a.mutex.Unlock()
+
+	return result, nil
+}
+
+// translateResponse translates the response of the allocate endpoint into
+// an AllocationResult, validating the address family.
+func (a *webhookAllocator) translateResponse(response *webhookAllocateResponse) (*AllocationResult, error) {
+	ip := net.ParseIP(response.IP)
+	if ip == nil {
+		return nil, fmt.Errorf("IPAM webhook returned invalid IP %q", response.IP)
+	}
+	if DeriveFamily(ip) != a.family {
+		return nil, fmt.Errorf("IPAM webhook returned %s address %s", DeriveFamily(ip), ip)
+	}
+
+	result := &AllocationResult{IP: ip}
+	if response.CIDR != "" {
+		if _, _, err := net.ParseCIDR(response.CIDR); err != nil {
+			return nil, fmt.Errorf("IPAM webhook returned invalid CIDR %q: %w", response.CIDR, err)
+		}
+		result.CIDRs = []string{response.CIDR}
+	}
+	if response.Gateway != "" {
+		if gw := net.ParseIP(response.Gateway); gw == nil {
+			return nil, fmt.Errorf("IPAM webhook returned invalid gateway %q", response.Gateway)
+		}
+		result.GatewayIP = response.Gateway
+	}
+	return result, nil
+}
+
+// Allocate allocates the given IP via the IPAM webhook.
+func (a *webhookAllocator) Allocate(ip net.IP, owner string) (*AllocationResult, error) {
+	return a.allocate(ip, owner)
+}
+
+// AllocateWithoutSyncUpstream marks the given IP as allocated without
+// calling the webhook, used to re-claim allocations of a previous agent run
+// during endpoint restoration. The lease is re-established upstream on the
+// next run of the renewal controller via its idempotency key.
+func (a *webhookAllocator) AllocateWithoutSyncUpstream(ip net.IP, owner string) (*AllocationResult, error) {
+	result := &AllocationResult{IP: ip}
+
+	a.mutex.Lock()
+	a.allocated[ip.String()] = &webhookAllocation{
+		owner:          owner,
+		idempotencyKey: a.idempotencyKey(owner),
+		result:         result,
+	}
+	a.mutex.Unlock()
+
+	return result, nil
+}
+
+// Release releases the given IP via the IPAM webhook.
+func (a *webhookAllocator) Release(ip net.IP) error {
+	a.mutex.RLock()
+	alloc, ok := a.allocated[ip.String()]
+	a.mutex.RUnlock()
+	if !ok {
+		return fmt.Errorf("IP %s is not allocated", ip)
+	}
+
+	request := &webhookReleaseRequest{
+		IdempotencyKey: alloc.idempotencyKey,
+		IP:             ip.String(),
+	}
+	if err := a.post(context.TODO(), "/release", request, nil); err != nil {
+		return err
+	}
+
+	a.mutex.Lock()
+	delete(a.allocated, ip.String())
+	a.mutex.Unlock()
+	return nil
+}
+
+// AllocateNext allocates the next IP the IPAM webhook hands out.
+func (a *webhookAllocator) AllocateNext(owner string) (*AllocationResult, error) {
+	return a.allocate(nil, owner)
+}
+
+// AllocateNextWithoutSyncUpstream is not supported without a specific IP,
+// as only the webhook can pick an address.
+func (a *webhookAllocator) AllocateNextWithoutSyncUpstream(owner string) (*AllocationResult, error) {
+	return nil, fmt.Errorf("webhook IPAM cannot allocate without calling the webhook")
+}
+
+// Dump returns the leases held with the IPAM webhook and a status line
+// including the result of the last health check.
+func (a *webhookAllocator) Dump() (map[string]string, string) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	allocs := map[string]string{}
+	for ip, alloc := range a.allocated {
+		allocs[ip] = alloc.owner
+	}
+
+	status := fmt.Sprintf("%d IPs leased from IPAM webhook %s", len(allocs), a.url)
+	if a.healthErr != nil {
+		status += fmt.Sprintf(", health check failed: %s", a.healthErr)
+	}
+	return allocs, status
+}
+
+// RestoreFinished is a no-op for webhook IPAM. Leases of the previous agent
+// run which were not re-claimed during endpoint restoration are not renewed
+// and expire on their own.
+func (a *webhookAllocator) RestoreFinished() {}
+
+// renewLeases renews the lease of every allocation by repeating the
+// allocate call with the idempotency key the lease was created with. This
+// also re-establishes leases re-claimed during endpoint restoration without
+// calling the webhook.
+func (a *webhookAllocator) renewLeases(ctx context.Context) error {
+	a.mutex.RLock()
+	leases := make([]*webhookAllocation, 0, len(a.allocated))
+	for _, alloc := range a.allocated {
+		leases = append(leases, alloc)
+	}
+	a.mutex.RUnlock()
+
+	var lastErr error
+	for _, alloc := range leases {
+		request := &webhookAllocateRequest{
+			IdempotencyKey:  alloc.idempotencyKey,
+			Owner:           alloc.owner,
+			Family:          string(a.family),
+			IP:              alloc.result.IP.String(),
+			LeaseTTLSeconds: int64(a.leaseTTL / time.Second),
+		}
+		if err := a.post(ctx, "/allocate", request, nil); err != nil {
+			log.WithError(err).WithField("ip", alloc.result.IP).
+				Warning("Unable to renew IPAM webhook lease")
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// checkHealth probes the health endpoint of the IPAM webhook so that an
+// unreachable webhook surfaces in the overall IPAM status rather than only
+// on pod sandbox creation.
+func (a *webhookAllocator) checkHealth(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.url+"/healthz", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := a.client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+			err = fmt.Errorf("IPAM webhook health check failed with status %s", resp.Status)
+		}
+	}
+
+	a.mutex.Lock()
+	a.healthErr = err
+	a.mutex.Unlock()
+
+	return err
+}
@@ -125,6 +125,13 @@ func (ipc *IPCache) RUnlock() {
 	ipc.mutex.RUnlock()
 }
 
+// NumEntries returns the number of IP to identity mappings in the cache.
+func (ipc *IPCache) NumEntries() int {
+	ipc.mutex.RLock()
+	defer ipc.mutex.RUnlock()
+	return len(ipc.ipToIdentityCache)
+}
+
 // SetListeners sets the listeners for this IPCache.
 func (ipc *IPCache) SetListeners(listeners []IPIdentityMappingListener) {
 	ipc.mutex.Lock()
@@ -194,11 +201,13 @@ func (ipc *IPCache) updateNamedPorts() (namedPortsChanged bool) {
 	// Collect new named Ports
 	npm := make(policy.NamedPortMultiMap, len(ipc.namedPorts))
 	for _, km := range ipc.ipToK8sMetadata {
-		for name, port := range km.NamedPorts {
+		for name, ports := range km.NamedPorts {
 			if npm[name] == nil {
 				npm[name] = make(policy.PortProtoSet)
 			}
-			npm[name][port] = struct{}{}
+			for port := range ports {
+				npm[name][port] = struct{}{}
+			}
 		}
 	}
 	namedPortsChanged = !npm.Equal(ipc.namedPorts)
@@ -355,7 +364,7 @@ func (ipc *IPCache) Upsert(ip string, hostIP net.IP, hostKey uint8, k8sMeta *K8s
 		if !namedPortsChanged {
 			// Check for added new or changed entries
 			for k, v := range newNamedPorts {
-				if v2, ok := oldK8sMeta.NamedPorts[k]; !ok || v2 != v {
+				if v2, ok := oldK8sMeta.NamedPorts[k]; !ok || !v2.Equal(v) {
 					namedPortsChanged = true
 					break
 				}
@@ -598,7 +607,7 @@ func (m *K8sMetadata) Equal(o *K8sMetadata) bool {
 		return false
 	}
 	for k, v := range m.NamedPorts {
-		if v2, ok := o.NamedPorts[k]; !ok || v != v2 {
+		if v2, ok := o.NamedPorts[k]; !ok || !v.Equal(v2) {
 			return false
 		}
 	}
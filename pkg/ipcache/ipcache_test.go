@@ -264,8 +264,12 @@ func (s *IPCacheTestSuite) TestIPCacheNamedPorts(c *C) {
 		Namespace: "default",
 		PodName:   "app1",
 		NamedPorts: policy.NamedPortMap{
-			"http": policy.PortProto{Port: 80, Proto: uint8(u8proto.TCP)},
-			"dns":  policy.PortProto{Port: 53},
+			"http": policy.PortProtoSet{
+				policy.PortProto{Port: 80, Proto: uint8(u8proto.TCP)}: struct{}{},
+			},
+			"dns": policy.PortProtoSet{
+				policy.PortProto{Port: 53}: struct{}{},
+			},
 		},
 	}
 
@@ -313,8 +317,12 @@ func (s *IPCacheTestSuite) TestIPCacheNamedPorts(c *C) {
 		Namespace: "testing",
 		PodName:   "app2",
 		NamedPorts: policy.NamedPortMap{
-			"https": policy.PortProto{Port: 443, Proto: uint8(u8proto.TCP)},
-			"dns":   policy.PortProto{Port: 53},
+			"https": policy.PortProtoSet{
+				policy.PortProto{Port: 443, Proto: uint8(u8proto.TCP)}: struct{}{},
+			},
+			"dns": policy.PortProtoSet{
+				policy.PortProto{Port: 53}: struct{}{},
+			},
 		},
 	}
 
@@ -424,7 +432,9 @@ func (s *IPCacheTestSuite) TestIPCacheNamedPorts(c *C) {
 	endpointIPs := []string{"192.168.0.1", "20.3.75.3", "27.2.2.2", "127.0.0.1", "127.0.0.1"}
 	identities := []identityPkg.NumericIdentity{5, 67, 29, 29, 29}
 	k8sMeta.NamedPorts = policy.NamedPortMap{
-		"http2": policy.PortProto{Port: 8080, Proto: uint8(u8proto.TCP)},
+		"http2": policy.PortProtoSet{
+			policy.PortProto{Port: 8080, Proto: uint8(u8proto.TCP)}: struct{}{},
+		},
 	}
 
 	for index := range endpointIPs {
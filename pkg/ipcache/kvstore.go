@@ -100,15 +100,23 @@ func UpsertIPToKVStore(ctx context.Context, IP, hostIP net.IP, ID identity.Numer
 	metadata, k8sNamespace, k8sPodName string, npm policy.NamedPortMap) error {
 	// Sort named ports into a slice
 	namedPorts := make([]identity.NamedPort, 0, len(npm))
-	for name, value := range npm {
-		namedPorts = append(namedPorts, identity.NamedPort{
-			Name:     name,
-			Port:     value.Port,
-			Protocol: u8proto.U8proto(value.Proto).String(),
-		})
+	for name, ports := range npm {
+		for value := range ports {
+			namedPorts = append(namedPorts, identity.NamedPort{
+				Name:     name,
+				Port:     value.Port,
+				Protocol: u8proto.U8proto(value.Proto).String(),
+			})
+		}
 	}
 	sort.Slice(namedPorts, func(i, j int) bool {
-		return namedPorts[i].Name < namedPorts[j].Name
+		if namedPorts[i].Name != namedPorts[j].Name {
+			return namedPorts[i].Name < namedPorts[j].Name
+		}
+		if namedPorts[i].Port != namedPorts[j].Port {
+			return namedPorts[i].Port < namedPorts[j].Port
+		}
+		return namedPorts[i].Protocol < namedPorts[j].Protocol
 	})
 
 	ipKey := path.Join(IPIdentitiesPath, AddressSpace, IP.String())
@@ -0,0 +1,283 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+// Package ipfix exports sampled datapath flow records to external IPFIX
+// (RFC 7011) collectors, independent of Hubble. Records are derived from
+// datapath trace notifications, sampled at a configurable rate and sent as
+// UDP datagrams, so existing capacity-planning tools can consume Cilium
+// traffic data natively.
+package ipfix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/logging"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/monitor"
+	monitorAPI "github.com/cilium/cilium/pkg/monitor/api"
+)
+
+var log = logging.DefaultLogger.WithField(logfields.LogSubsys, "ipfix")
+
+const (
+	// ipfixVersion is the version number in the IPFIX message header.
+	ipfixVersion = 10
+
+	// templateSetID is the set ID reserved for template sets.
+	templateSetID = 2
+
+	// templateIDv4 and templateIDv6 identify the templates describing
+	// IPv4 and IPv6 flow records respectively.
+	templateIDv4 = 256
+	templateIDv6 = 257
+
+	// templateInterval is how often templates are re-sent, so collectors
+	// (re)started while the agent is running can decode the data records.
+	templateInterval = 10 * time.Minute
+
+	// messageHeaderLen and setHeaderLen are the fixed lengths of the
+	// IPFIX message header and set header.
+	messageHeaderLen = 16
+	setHeaderLen     = 4
+)
+
+// IANA information element identifiers used in the exported templates, see
+// https://www.iana.org/assignments/ipfix/ipfix.xhtml
+const (
+	ieOctetDeltaCount          = 1
+	iePacketDeltaCount         = 2
+	ieProtocolIdentifier       = 4
+	ieSourceTransportPort      = 7
+	ieSourceIPv4Address        = 8
+	ieDestinationTransportPort = 11
+	ieDestinationIPv4Address   = 12
+	ieSourceIPv6Address        = 27
+	ieDestinationIPv6Address   = 28
+)
+
+// templateField is a single information element in a template record.
+type templateField struct {
+	id     uint16
+	length uint16
+}
+
+var (
+	// commonFields describe the transport tuple and the sampling-scaled
+	// delta counters shared by both templates.
+	commonFields = []templateField{
+		{ieSourceTransportPort, 2},
+		{ieDestinationTransportPort, 2},
+		{ieProtocolIdentifier, 1},
+		{ieOctetDeltaCount, 8},
+		{iePacketDeltaCount, 8},
+	}
+
+	templateFieldsv4 = append([]templateField{
+		{ieSourceIPv4Address, 4},
+		{ieDestinationIPv4Address, 4},
+	}, commonFields...)
+
+	templateFieldsv6 = append([]templateField{
+		{ieSourceIPv6Address, 16},
+		{ieDestinationIPv6Address, 16},
+	}, commonFields...)
+)
+
+// Exporter samples datapath trace notifications and exports the sampled
+// flows as IPFIX data records. It implements the monitor consumer interface
+// and is fed directly from the perf event ring buffer.
+type Exporter struct {
+	// samplingRate selects one out of every samplingRate eligible packets
+	// for export. The exported delta counters are scaled back up by the
+	// same factor to estimate the total traffic volume.
+	samplingRate uint64
+
+	// counter counts eligible packets and is only manipulated atomically.
+	counter uint64
+
+	mutex lock.Mutex
+
+	// conns are the UDP connections toward the configured collectors.
+	conns []*net.UDPConn
+
+	// send transmits an encoded IPFIX message to all collectors. It
+	// defaults to writing to conns and is overridden in tests.
+	send func(msg []byte)
+
+	// seqNum is the number of data records sent so far, as required by
+	// the sequence number field of the message header.
+	seqNum uint32
+
+	// lastTemplate is when templates were last included in a message.
+	lastTemplate time.Time
+}
+
+// NewExporter returns an exporter sending one out of every samplingRate
+// eligible packets as a flow record to the given collectors.
+func NewExporter(samplingRate int, collectors []string) (*Exporter, error) {
+	if samplingRate < 1 {
+		return nil, fmt.Errorf("invalid sampling rate %d: must be at least 1", samplingRate)
+	}
+
+	e := &Exporter{
+		samplingRate: uint64(samplingRate),
+	}
+	for _, collector := range collectors {
+		addr, err := net.ResolveUDPAddr("udp", collector)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve collector address %q: %w", collector, err)
+		}
+		conn, err := net.DialUDP("udp", nil, addr)
+		if err != nil {
+			return nil, fmt.Errorf("cannot connect to collector %q: %w", collector, err)
+		}
+		e.conns = append(e.conns, conn)
+	}
+	e.send = e.sendToCollectors
+
+	return e, nil
+}
+
+// NotifyAgentEvent implements the monitor consumer interface. Agent events
+// carry no datapath flow information and are ignored.
+func (e *Exporter) NotifyAgentEvent(typ int, message interface{}) {
+}
+
+// NotifyPerfEvent samples trace notifications from the perf event ring
+// buffer and exports the sampled flows.
+func (e *Exporter) NotifyPerfEvent(data []byte, cpu int) {
+	if len(data) == 0 || data[0] != byte(monitorAPI.MessageTypeTrace) {
+		return
+	}
+
+	var tn monitor.TraceNotify
+	if err := monitor.DecodeTraceNotify(data, &tn); err != nil {
+		return
+	}
+
+	// Only count packets as they enter the datapath. The same packet is
+	// traced again at egress observation points and would otherwise be
+	// counted multiple times.
+	switch int(tn.ObsPoint) {
+	case monitorAPI.TraceFromLxc, monitorAPI.TraceFromProxy,
+		monitorAPI.TraceFromHost, monitorAPI.TraceFromStack,
+		monitorAPI.TraceFromOverlay, monitorAPI.TraceFromNetwork:
+	default:
+		return
+	}
+
+	if atomic.AddUint64(&e.counter, 1)%e.samplingRate != 0 {
+		return
+	}
+
+	offset := int(tn.DataOffset())
+	if len(data) <= offset {
+		return
+	}
+	ci := monitor.GetConnectionInfo(data[offset:])
+	if ci == nil || ci.SrcIP == nil || ci.DstIP == nil {
+		return
+	}
+
+	e.export(ci, uint64(tn.OrigLen))
+}
+
+// NotifyPerfEventLost implements the monitor consumer interface. Lost
+// events are acceptable for a sampling exporter and are ignored.
+func (e *Exporter) NotifyPerfEventLost(numLostEvents uint64, cpu int) {
+}
+
+// export encodes a single flow record into an IPFIX message and sends it to
+// all collectors.
+func (e *Exporter) export(ci *monitor.ConnectionInfo, origLen uint64) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	buf := &bytes.Buffer{}
+
+	// Reserve space for the message header, it is filled in last once the
+	// total length is known.
+	buf.Write(make([]byte, messageHeaderLen))
+
+	if time.Since(e.lastTemplate) > templateInterval {
+		writeTemplateSet(buf, templateIDv4, templateFieldsv4)
+		writeTemplateSet(buf, templateIDv6, templateFieldsv6)
+		e.lastTemplate = time.Now()
+	}
+
+	templateID := uint16(templateIDv4)
+	srcIP, dstIP := ci.SrcIP.To4(), ci.DstIP.To4()
+	if srcIP == nil || dstIP == nil {
+		templateID = templateIDv6
+		srcIP, dstIP = ci.SrcIP.To16(), ci.DstIP.To16()
+	}
+
+	record := &bytes.Buffer{}
+	record.Write(srcIP)
+	record.Write(dstIP)
+	binary.Write(record, binary.BigEndian, ci.SrcPort)
+	binary.Write(record, binary.BigEndian, ci.DstPort)
+	record.WriteByte(protocolNumber(ci.Proto))
+	// Scale the counters by the sampling rate to estimate the totals the
+	// sampled packet represents.
+	binary.Write(record, binary.BigEndian, origLen*e.samplingRate)
+	binary.Write(record, binary.BigEndian, e.samplingRate)
+
+	binary.Write(buf, binary.BigEndian, templateID)
+	binary.Write(buf, binary.BigEndian, uint16(setHeaderLen+record.Len()))
+	buf.Write(record.Bytes())
+
+	msg := buf.Bytes()
+	binary.BigEndian.PutUint16(msg[0:2], ipfixVersion)
+	binary.BigEndian.PutUint16(msg[2:4], uint16(len(msg)))
+	binary.BigEndian.PutUint32(msg[4:8], uint32(time.Now().Unix()))
+	binary.BigEndian.PutUint32(msg[8:12], e.seqNum)
+	// The observation domain ID is left zero as the exporting node is
+	// identified by the source address of the datagram.
+	e.seqNum++
+
+	e.send(msg)
+}
+
+// sendToCollectors transmits an encoded IPFIX message to all collectors.
+// Send errors are logged at debug level only, a temporarily unreachable
+// collector must not flood the agent log.
+func (e *Exporter) sendToCollectors(msg []byte) {
+	for _, conn := range e.conns {
+		if _, err := conn.Write(msg); err != nil {
+			log.WithError(err).WithField("collector", conn.RemoteAddr()).
+				Debug("Unable to send IPFIX message to collector")
+		}
+	}
+}
+
+// writeTemplateSet appends a template set describing the given fields.
+func writeTemplateSet(buf *bytes.Buffer, templateID uint16, fields []templateField) {
+	binary.Write(buf, binary.BigEndian, uint16(templateSetID))
+	binary.Write(buf, binary.BigEndian, uint16(setHeaderLen+4+4*len(fields)))
+	binary.Write(buf, binary.BigEndian, templateID)
+	binary.Write(buf, binary.BigEndian, uint16(len(fields)))
+	for _, f := range fields {
+		binary.Write(buf, binary.BigEndian, f.id)
+		binary.Write(buf, binary.BigEndian, f.length)
+	}
+}
+
+// protocolNumber maps the protocol name reported by the packet dissector to
+// the IANA protocol number.
+func protocolNumber(proto string) uint8 {
+	switch proto {
+	case "tcp":
+		return 6
+	case "udp":
+		return 17
+	default:
+		return 0
+	}
+}
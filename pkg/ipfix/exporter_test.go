@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package ipfix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/cilium/cilium/pkg/byteorder"
+	"github.com/cilium/cilium/pkg/monitor"
+	monitorAPI "github.com/cilium/cilium/pkg/monitor/api"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	check.TestingT(t)
+}
+
+type IPFIXSuite struct{}
+
+var _ = check.Suite(&IPFIXSuite{})
+
+func testExporter(samplingRate int) (*Exporter, *[][]byte) {
+	sent := &[][]byte{}
+	e := &Exporter{
+		samplingRate: uint64(samplingRate),
+	}
+	e.send = func(msg []byte) {
+		*sent = append(*sent, append([]byte{}, msg...))
+	}
+	return e, sent
+}
+
+// traceEvent returns a binary encoded trace notification carrying a TCP
+// packet, as delivered by the perf event ring buffer.
+func traceEvent(c *check.C, obsPoint uint8, origLen uint32) []byte {
+	eth := &layers.Ethernet{
+		SrcMAC:       net.HardwareAddr{1, 2, 3, 4, 5, 6},
+		DstMAC:       net.HardwareAddr{6, 5, 4, 3, 2, 1},
+		EthernetType: layers.EthernetTypeIPv4,
+	}
+	ip := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolTCP,
+		SrcIP:    net.ParseIP("10.0.0.1"),
+		DstIP:    net.ParseIP("10.0.0.2"),
+	}
+	tcp := &layers.TCP{
+		SrcPort: 32000,
+		DstPort: 80,
+	}
+	c.Assert(tcp.SetNetworkLayerForChecksum(ip), check.IsNil)
+
+	pkt := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	c.Assert(gopacket.SerializeLayers(pkt, opts, eth, ip, tcp), check.IsNil)
+
+	tn := monitor.TraceNotifyV0{
+		Type:     monitorAPI.MessageTypeTrace,
+		ObsPoint: obsPoint,
+		OrigLen:  origLen,
+		CapLen:   uint16(len(pkt.Bytes())),
+	}
+	buf := &bytes.Buffer{}
+	c.Assert(binary.Write(buf, byteorder.Native, tn), check.IsNil)
+	buf.Write(pkt.Bytes())
+	return buf.Bytes()
+}
+
+func (s *IPFIXSuite) TestSampling(c *check.C) {
+	e, sent := testExporter(4)
+
+	for i := 0; i < 8; i++ {
+		e.NotifyPerfEvent(traceEvent(c, monitorAPI.TraceFromLxc, 100), 0)
+	}
+	c.Assert(*sent, check.HasLen, 2)
+
+	// Egress observation points and non-trace events must neither be
+	// exported nor advance the sampling counter.
+	counter := e.counter
+	e.NotifyPerfEvent(traceEvent(c, monitorAPI.TraceToLxc, 100), 0)
+	e.NotifyPerfEvent([]byte{byte(monitorAPI.MessageTypeDrop)}, 0)
+	c.Assert(*sent, check.HasLen, 2)
+	c.Assert(e.counter, check.Equals, counter)
+}
+
+func (s *IPFIXSuite) TestExportMessage(c *check.C) {
+	e, sent := testExporter(16)
+
+	for i := 0; i < 16; i++ {
+		e.NotifyPerfEvent(traceEvent(c, monitorAPI.TraceFromNetwork, 1500), 0)
+	}
+	c.Assert(*sent, check.HasLen, 1)
+	msg := (*sent)[0]
+
+	c.Assert(binary.BigEndian.Uint16(msg[0:2]), check.Equals, uint16(ipfixVersion))
+	c.Assert(binary.BigEndian.Uint16(msg[2:4]), check.Equals, uint16(len(msg)))
+	c.Assert(binary.BigEndian.Uint32(msg[8:12]), check.Equals, uint32(0))
+
+	// The first message carries the IPv4 and IPv6 templates.
+	offset := messageHeaderLen
+	c.Assert(binary.BigEndian.Uint16(msg[offset:offset+2]), check.Equals, uint16(templateSetID))
+	c.Assert(binary.BigEndian.Uint16(msg[offset+4:offset+6]), check.Equals, uint16(templateIDv4))
+	offset += int(binary.BigEndian.Uint16(msg[offset+2 : offset+4]))
+	c.Assert(binary.BigEndian.Uint16(msg[offset:offset+2]), check.Equals, uint16(templateSetID))
+	c.Assert(binary.BigEndian.Uint16(msg[offset+4:offset+6]), check.Equals, uint16(templateIDv6))
+	offset += int(binary.BigEndian.Uint16(msg[offset+2 : offset+4]))
+
+	// Followed by a single IPv4 data record.
+	c.Assert(binary.BigEndian.Uint16(msg[offset:offset+2]), check.Equals, uint16(templateIDv4))
+	record := msg[offset+setHeaderLen:]
+	c.Assert(net.IP(record[0:4]).String(), check.Equals, "10.0.0.1")
+	c.Assert(net.IP(record[4:8]).String(), check.Equals, "10.0.0.2")
+	c.Assert(binary.BigEndian.Uint16(record[8:10]), check.Equals, uint16(32000))
+	c.Assert(binary.BigEndian.Uint16(record[10:12]), check.Equals, uint16(80))
+	c.Assert(record[12], check.Equals, uint8(6))
+	// Delta counters are scaled by the sampling rate.
+	c.Assert(binary.BigEndian.Uint64(record[13:21]), check.Equals, uint64(1500*16))
+	c.Assert(binary.BigEndian.Uint64(record[21:29]), check.Equals, uint64(16))
+
+	// Subsequent messages omit the templates and advance the sequence
+	// number.
+	for i := 0; i < 16; i++ {
+		e.NotifyPerfEvent(traceEvent(c, monitorAPI.TraceFromNetwork, 1500), 0)
+	}
+	c.Assert(*sent, check.HasLen, 2)
+	msg = (*sent)[1]
+	c.Assert(binary.BigEndian.Uint32(msg[8:12]), check.Equals, uint32(1))
+	c.Assert(binary.BigEndian.Uint16(msg[messageHeaderLen:messageHeaderLen+2]),
+		check.Equals, uint16(templateIDv4))
+}
@@ -54,6 +54,12 @@ const (
 	// CLRPCRDName is the full name of the CLRP CRD.
 	CLRPCRDName = k8sconstv2.CLRPKindDefinition + "/" + k8sconstv2.CustomResourceDefinitionVersion
 
+	// CCCCRDName is the full name of the CCC CRD.
+	CCCCRDName = k8sconstv2.CCCKindDefinition + "/" + k8sconstv2.CustomResourceDefinitionVersion
+
+	// CCFGCRDName is the full name of the CCFG CRD.
+	CCFGCRDName = k8sconstv2.CCFGKindDefinition + "/" + k8sconstv2.CustomResourceDefinitionVersion
+
 	// CENPCRDName is the full name of the CENP CRD.
 	CENPCRDName = k8sconstv2alpha1.CENPKindDefinition + "/" + k8sconstv2alpha1.CustomResourceDefinitionVersion
 )
@@ -98,6 +104,14 @@ func CreateCustomResourceDefinitions(clientset apiextensionsclient.Interface) er
 		return createCLRPCRD(clientset)
 	})
 
+	g.Go(func() error {
+		return createCCCCRD(clientset)
+	})
+
+	g.Go(func() error {
+		return createCCFGCRD(clientset)
+	})
+
 	g.Go(func() error {
 		return createCENPCRD(clientset)
 	})
@@ -127,6 +141,12 @@ var (
 	//go:embed crds/v2/ciliumlocalredirectpolicies.yaml
 	crdsCiliumlocalredirectpolicies []byte
 
+	//go:embed crds/v2/ciliumconnectivitychecks.yaml
+	crdsCiliumconnectivitychecks []byte
+
+	//go:embed crds/v2/ciliumconfigs.yaml
+	crdsCiliumconfigs []byte
+
 	//go:embed crds/v2alpha1/ciliumegressnatpolicies.yaml
 	crdsv2Alpha1Ciliumegressnatpolicies []byte
 )
@@ -158,6 +178,10 @@ func GetPregeneratedCRD(crdName string) apiextensionsv1.CustomResourceDefinition
 		crdBytes = crdsCiliumexternalworkloads
 	case CLRPCRDName:
 		crdBytes = crdsCiliumlocalredirectpolicies
+	case CCCCRDName:
+		crdBytes = crdsCiliumconnectivitychecks
+	case CCFGCRDName:
+		crdBytes = crdsCiliumconfigs
 	case CENPCRDName:
 		crdBytes = crdsv2Alpha1Ciliumegressnatpolicies
 	default:
@@ -262,6 +286,28 @@ func createCLRPCRD(clientset apiextensionsclient.Interface) error {
 	)
 }
 
+func createCCCCRD(clientset apiextensionsclient.Interface) error {
+	cccCRD := GetPregeneratedCRD(CCCCRDName)
+
+	return createUpdateCRD(
+		clientset,
+		CCCCRDName,
+		constructV1CRD(k8sconstv2.CCCName, cccCRD),
+		newDefaultPoller(),
+	)
+}
+
+func createCCFGCRD(clientset apiextensionsclient.Interface) error {
+	ccfgCRD := GetPregeneratedCRD(CCFGCRDName)
+
+	return createUpdateCRD(
+		clientset,
+		CCFGCRDName,
+		constructV1CRD(k8sconstv2.CCFGName, ccfgCRD),
+		newDefaultPoller(),
+	)
+}
+
 func createCENPCRD(clientset apiextensionsclient.Interface) error {
 	ciliumCRD := GetPregeneratedCRD(CENPCRDName)
 
@@ -0,0 +1,177 @@
+//  Copyright 2021 Authors of Cilium
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package v2
+
+import (
+	slimv1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/meta/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:singular="ciliumconnectivitycheck",path="ciliumconnectivitychecks",scope="Cluster",shortName={ccc}
+// +kubebuilder:subresource:status
+
+// CiliumConnectivityCheck is a Kubernetes Custom Resource that declares a set
+// of connectivity probes which are executed periodically by every Cilium
+// agent. The result of each probe is written back into the status of the
+// resource, keyed by the name of the node that executed it.
+type CiliumConnectivityCheck struct {
+	// +k8s:openapi-gen=false
+	// +deepequal-gen=false
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	// +deepequal-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec is the desired set of connectivity probes.
+	Spec CiliumConnectivityCheckSpec `json:"spec,omitempty"`
+
+	// Status is the most recent probe results as reported by the agents.
+	//
+	// +deepequal-gen=false
+	// +kubebuilder:validation:Optional
+	Status CiliumConnectivityCheckStatus `json:"status"`
+}
+
+const (
+	// ProbeTypePod probes the IP address of a pod.
+	ProbeTypePod = "pod"
+
+	// ProbeTypeService probes the cluster IP of a service.
+	ProbeTypeService = "service"
+
+	// ProbeTypeExternal probes an address outside of the cluster.
+	ProbeTypeExternal = "external"
+
+	// ProbeVerdictReachable expects the probe target to accept connections.
+	ProbeVerdictReachable = "reachable"
+
+	// ProbeVerdictUnreachable expects connections to the probe target to
+	// fail, e.g. because network policy is expected to drop them.
+	ProbeVerdictUnreachable = "unreachable"
+)
+
+// CiliumConnectivityCheckSpec specifies the probes to execute and how often
+// to execute them.
+//
+// +kubebuilder:validation:Type=object
+type CiliumConnectivityCheckSpec struct {
+	// IntervalSeconds is the period in seconds at which the probes are
+	// executed. Defaults to 60 seconds.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	IntervalSeconds int `json:"interval-seconds,omitempty"`
+
+	// Probes is the list of probes to execute.
+	//
+	// +kubebuilder:validation:Required
+	Probes []ConnectivityProbe `json:"probes"`
+}
+
+// ConnectivityProbe declares a single connectivity probe.
+type ConnectivityProbe struct {
+	// Name identifies the probe in the status.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Type selects how Target is resolved.
+	//
+	// +kubebuilder:validation:Enum=pod;service;external
+	// +kubebuilder:validation:Required
+	Type string `json:"type"`
+
+	// Target is the "namespace/name" of the pod or service to probe for
+	// the pod and service probe types, or an IP address or DNS name for
+	// the external probe type.
+	//
+	// +kubebuilder:validation:Required
+	Target string `json:"target"`
+
+	// Port is the TCP port to connect to on the target.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:Required
+	Port uint16 `json:"port"`
+
+	// Expected is the verdict the probe is expected to observe, either
+	// "reachable" or "unreachable". Defaults to "reachable".
+	//
+	// +kubebuilder:validation:Enum=reachable;unreachable
+	// +kubebuilder:validation:Optional
+	Expected string `json:"expected,omitempty"`
+}
+
+// CiliumConnectivityCheckStatus is the status of a connectivity check as
+// reported by the agents executing the probes.
+type CiliumConnectivityCheckStatus struct {
+	// Nodes maps the name of the node that executed the probes to the
+	// results observed from that node.
+	//
+	// +optional
+	Nodes map[string]ConnectivityCheckNodeStatus `json:"nodes,omitempty"`
+}
+
+// ConnectivityCheckNodeStatus is the result of the probes as executed by a
+// single node.
+type ConnectivityCheckNodeStatus struct {
+	// LastProbeTime is the time the probes were last executed.
+	//
+	// +optional
+	LastProbeTime slimv1.Time `json:"lastProbeTime,omitempty"`
+
+	// Results holds one entry per probe declared in the spec.
+	//
+	// +optional
+	Results []ConnectivityProbeResult `json:"results,omitempty"`
+}
+
+// ConnectivityProbeResult is the outcome of a single probe.
+type ConnectivityProbeResult struct {
+	// Probe is the name of the probe the result belongs to.
+	Probe string `json:"probe"`
+
+	// Success is true if the observed verdict matched the expected one.
+	Success bool `json:"success"`
+
+	// Latency is the observed connect latency in nanoseconds. Only set
+	// when the target accepted the connection.
+	//
+	// +optional
+	Latency int64 `json:"latency,omitempty"`
+
+	// Message describes why the probe failed.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=false
+// +deepequal-gen=false
+
+// CiliumConnectivityCheckList is a list of CiliumConnectivityCheck objects.
+type CiliumConnectivityCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items is a list of CiliumConnectivityCheck
+	Items []CiliumConnectivityCheck `json:"items"`
+}
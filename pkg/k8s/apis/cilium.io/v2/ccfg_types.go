@@ -0,0 +1,118 @@
+//  Copyright 2021 Authors of Cilium
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package v2
+
+import (
+	slimv1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/meta/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:singular="ciliumconfig",path="ciliumconfigs",scope="Cluster",shortName={ccfg}
+// +kubebuilder:subresource:status
+
+// CiliumConfig is a Kubernetes Custom Resource that carries the
+// hot-reloadable subset of the agent configuration. Every agent watches the
+// resource and applies changes through the regular configuration reload path,
+// without requiring a rolling restart of the DaemonSet. Each agent reports
+// whether it could apply the configuration in the status of the resource,
+// keyed by the name of its node.
+type CiliumConfig struct {
+	// +k8s:openapi-gen=false
+	// +deepequal-gen=false
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	// +deepequal-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec is the desired agent configuration.
+	Spec CiliumConfigSpec `json:"spec,omitempty"`
+
+	// Status is the per-node application status as reported by the agents.
+	//
+	// +deepequal-gen=false
+	// +kubebuilder:validation:Optional
+	Status CiliumConfigStatus `json:"status"`
+}
+
+// CiliumConfigSpec is the hot-reloadable agent configuration to apply.
+//
+// +kubebuilder:validation:Type=object
+type CiliumConfigSpec struct {
+	// Options maps names of hot-reloadable agent options to their desired
+	// values. The option names and values are the same as accepted by
+	// "cilium config", e.g. "Debug": "true". Options not listed here are
+	// left untouched.
+	//
+	// +kubebuilder:validation:Optional
+	Options map[string]string `json:"options,omitempty"`
+
+	// PolicyEnforcement optionally sets the policy enforcement mode, one
+	// of "default", "always" or "never". An empty value leaves the mode
+	// untouched.
+	//
+	// +kubebuilder:validation:Enum=default;always;never
+	// +kubebuilder:validation:Optional
+	PolicyEnforcement string `json:"policy-enforcement,omitempty"`
+}
+
+// CiliumConfigStatus is the application status of a configuration as reported
+// by the agents applying it.
+type CiliumConfigStatus struct {
+	// Nodes maps the name of a node to the outcome of the most recent
+	// configuration application on that node.
+	//
+	// +optional
+	Nodes map[string]ConfigNodeStatus `json:"nodes,omitempty"`
+}
+
+// ConfigNodeStatus is the outcome of applying the configuration on a single
+// node.
+type ConfigNodeStatus struct {
+	// ObservedGeneration is the generation of the CiliumConfig that was
+	// last applied on the node.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Applied is true if all options were applied successfully.
+	Applied bool `json:"applied"`
+
+	// Error describes why the configuration could not be applied.
+	//
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// LastAppliedTime is the time the configuration was last applied.
+	//
+	// +optional
+	LastAppliedTime slimv1.Time `json:"lastAppliedTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=false
+// +deepequal-gen=false
+
+// CiliumConfigList is a list of CiliumConfig objects.
+type CiliumConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items is a list of CiliumConfig
+	Items []CiliumConfig `json:"items"`
+}
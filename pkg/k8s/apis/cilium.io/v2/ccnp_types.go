@@ -137,5 +137,7 @@ func (r *CiliumClusterwideNetworkPolicy) Parse() (api.Rules, error) {
 		}
 	}
 
+	applyProxySourceAddrAnnotation(r.ObjectMeta.Annotations, retRules)
+
 	return retRules, nil
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/cilium/cilium/pkg/annotation"
 	"github.com/cilium/cilium/pkg/comparator"
 	k8sConst "github.com/cilium/cilium/pkg/k8s/apis/cilium.io"
 	k8sCiliumUtils "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/utils"
@@ -232,9 +233,26 @@ func (r *CiliumNetworkPolicy) Parse() (api.Rules, error) {
 		}
 	}
 
+	applyProxySourceAddrAnnotation(r.ObjectMeta.Annotations, retRules)
+
 	return retRules, nil
 }
 
+// applyProxySourceAddrAnnotation appends a rule label selecting the proxy
+// source address behavior when the policy carries the corresponding
+// annotation. The label is consulted when proxy redirects are created from
+// the rules.
+func applyProxySourceAddrAnnotation(annotations map[string]string, rules api.Rules) {
+	v, ok := annotations[annotation.ProxyOriginalSourceAddr]
+	if !ok {
+		return
+	}
+	lbl := labels.NewLabel(annotation.ProxyOriginalSourceAddr, v, labels.LabelSourceK8s)
+	for _, rule := range rules {
+		rule.Labels = append(rule.Labels, lbl)
+	}
+}
+
 // GetControllerName returns the unique name for the controller manager.
 func (r *CiliumNetworkPolicy) GetControllerName() string {
 	name := k8sUtils.GetObjNamespaceName(&r.ObjectMeta)
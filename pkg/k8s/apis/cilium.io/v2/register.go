@@ -23,7 +23,7 @@ const (
 	//
 	// Maintainers: Run ./Documentation/check-crd-compat-table.sh for each release
 	// Developers: Bump patch for each change in the CRD schema.
-	CustomResourceDefinitionSchemaVersion = "1.23.4"
+	CustomResourceDefinitionSchemaVersion = "1.23.9"
 
 	// CustomResourceDefinitionSchemaVersionKey is key to label which holds the CRD schema version
 	CustomResourceDefinitionSchemaVersionKey = "io.cilium.k8s.crd.schema.version"
@@ -125,6 +125,34 @@ const (
 
 	// CEWName is the full name of Cilium External Workload
 	CEWName = CEWPluralName + "." + CustomResourceDefinitionGroup
+
+	// Cilium Connectivity Check (CCC)
+
+	// CCCSingularName is the singular name of Cilium Connectivity Check
+	CCCSingularName = "ciliumconnectivitycheck"
+
+	// CCCPluralName is the plural name of Cilium Connectivity Check
+	CCCPluralName = "ciliumconnectivitychecks"
+
+	// CCCKindDefinition is the kind name for Cilium Connectivity Check
+	CCCKindDefinition = "CiliumConnectivityCheck"
+
+	// CCCName is the full name of Cilium Connectivity Check
+	CCCName = CCCPluralName + "." + CustomResourceDefinitionGroup
+
+	// Cilium Config (CCFG)
+
+	// CCFGSingularName is the singular name of Cilium Config
+	CCFGSingularName = "ciliumconfig"
+
+	// CCFGPluralName is the plural name of Cilium Config
+	CCFGPluralName = "ciliumconfigs"
+
+	// CCFGKindDefinition is the kind name for Cilium Config
+	CCFGKindDefinition = "CiliumConfig"
+
+	// CCFGName is the full name of Cilium Config
+	CCFGName = CCFGPluralName + "." + CustomResourceDefinitionGroup
 )
 
 // SchemeGroupVersion is group version used to register these objects
@@ -182,6 +210,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CiliumIdentityList{},
 		&CiliumLocalRedirectPolicy{},
 		&CiliumLocalRedirectPolicyList{},
+		&CiliumConnectivityCheck{},
+		&CiliumConnectivityCheckList{},
+		&CiliumConfig{},
+		&CiliumConfigList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -94,6 +94,161 @@ func (in *CiliumClusterwideNetworkPolicy) deepEqual(other *CiliumClusterwideNetw
 	return true
 }
 
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *CiliumConfig) DeepEqual(other *CiliumConfig) bool {
+	if other == nil {
+		return false
+	}
+
+	if !in.Spec.DeepEqual(&other.Spec) {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *CiliumConfigSpec) DeepEqual(other *CiliumConfigSpec) bool {
+	if other == nil {
+		return false
+	}
+
+	if ((in.Options != nil) && (other.Options != nil)) || ((in.Options == nil) != (other.Options == nil)) {
+		in, other := &in.Options, &other.Options
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if inValue != otherValue {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if in.PolicyEnforcement != other.PolicyEnforcement {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *CiliumConfigStatus) DeepEqual(other *CiliumConfigStatus) bool {
+	if other == nil {
+		return false
+	}
+
+	if ((in.Nodes != nil) && (other.Nodes != nil)) || ((in.Nodes == nil) != (other.Nodes == nil)) {
+		in, other := &in.Nodes, &other.Nodes
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if !inValue.DeepEqual(&otherValue) {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *CiliumConnectivityCheck) DeepEqual(other *CiliumConnectivityCheck) bool {
+	if other == nil {
+		return false
+	}
+
+	if !in.Spec.DeepEqual(&other.Spec) {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *CiliumConnectivityCheckSpec) DeepEqual(other *CiliumConnectivityCheckSpec) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.IntervalSeconds != other.IntervalSeconds {
+		return false
+	}
+	if ((in.Probes != nil) && (other.Probes != nil)) || ((in.Probes == nil) != (other.Probes == nil)) {
+		in, other := &in.Probes, &other.Probes
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if !inElement.DeepEqual(&(*other)[i]) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *CiliumConnectivityCheckStatus) DeepEqual(other *CiliumConnectivityCheckStatus) bool {
+	if other == nil {
+		return false
+	}
+
+	if ((in.Nodes != nil) && (other.Nodes != nil)) || ((in.Nodes == nil) != (other.Nodes == nil)) {
+		in, other := &in.Nodes, &other.Nodes
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if !inValue.DeepEqual(&otherValue) {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
 // DeepEqual is an autogenerated deepequal function, deeply comparing the
 // receiver with other. in must be non-nil.
 func (in *CiliumEndpoint) DeepEqual(other *CiliumEndpoint) bool {
@@ -379,6 +534,109 @@ func (in *CiliumNode) DeepEqual(other *CiliumNode) bool {
 	return true
 }
 
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *ConfigNodeStatus) DeepEqual(other *ConfigNodeStatus) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.ObservedGeneration != other.ObservedGeneration {
+		return false
+	}
+	if in.Applied != other.Applied {
+		return false
+	}
+	if in.Error != other.Error {
+		return false
+	}
+	if !in.LastAppliedTime.DeepEqual(&other.LastAppliedTime) {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *ConnectivityCheckNodeStatus) DeepEqual(other *ConnectivityCheckNodeStatus) bool {
+	if other == nil {
+		return false
+	}
+
+	if !in.LastProbeTime.DeepEqual(&other.LastProbeTime) {
+		return false
+	}
+
+	if ((in.Results != nil) && (other.Results != nil)) || ((in.Results == nil) != (other.Results == nil)) {
+		in, other := &in.Results, &other.Results
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if !inElement.DeepEqual(&(*other)[i]) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *ConnectivityProbe) DeepEqual(other *ConnectivityProbe) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if in.Type != other.Type {
+		return false
+	}
+	if in.Target != other.Target {
+		return false
+	}
+	if in.Port != other.Port {
+		return false
+	}
+	if in.Expected != other.Expected {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *ConnectivityProbeResult) DeepEqual(other *ConnectivityProbeResult) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Probe != other.Probe {
+		return false
+	}
+	if in.Success != other.Success {
+		return false
+	}
+	if in.Latency != other.Latency {
+		return false
+	}
+	if in.Message != other.Message {
+		return false
+	}
+
+	return true
+}
+
 // DeepEqual is an autogenerated deepequal function, deeply comparing the
 // receiver with other. in must be non-nil.
 func (in *ControllerList) DeepEqual(other *ControllerList) bool {
@@ -67,6 +67,14 @@ func (in *CiliumEgressNATPolicySpec) DeepEqual(other *CiliumEgressNATPolicySpec)
 		return false
 	}
 
+	if in.EgressSourceSNATIP != other.EgressSourceSNATIP {
+		return false
+	}
+
+	if in.EgressSourceInterface != other.EgressSourceInterface {
+		return false
+	}
+
 	return true
 }
 
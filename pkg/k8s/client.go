@@ -21,6 +21,7 @@ import (
 	slim_metav1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/meta/v1"
 	slim_metav1beta1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/meta/v1beta1"
 	watcher_client "github.com/cilium/cilium/pkg/k8s/slim/k8s/clientset"
+	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging/logfields"
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/version"
@@ -253,6 +254,33 @@ func setDialer(config *rest.Config) func() {
 	return dialer.CloseAll
 }
 
+var (
+	// reconnectSubscribersMutex protects reconnectSubscribers
+	reconnectSubscribersMutex lock.RWMutex
+
+	// reconnectSubscribers are called after the client connections to the
+	// kube-apiserver have been restarted due to a failed heartbeat, and
+	// thus after the watches may have missed events.
+	reconnectSubscribers []func()
+)
+
+// SubscribeToReconnects registers a function that is called whenever the
+// client connections to the kube-apiserver are restarted after a failed
+// heartbeat.
+func SubscribeToReconnects(fn func()) {
+	reconnectSubscribersMutex.Lock()
+	reconnectSubscribers = append(reconnectSubscribers, fn)
+	reconnectSubscribersMutex.Unlock()
+}
+
+func notifyReconnect() {
+	reconnectSubscribersMutex.RLock()
+	defer reconnectSubscribersMutex.RUnlock()
+	for _, fn := range reconnectSubscribers {
+		fn()
+	}
+}
+
 func runHeartbeat(heartBeat func(context.Context) error, timeout time.Duration, closeAllConns ...func()) {
 	expireDate := time.Now().Add(-timeout)
 	// Don't even perform a health check if we have received a successful
@@ -289,12 +317,14 @@ func runHeartbeat(heartBeat func(context.Context) error, timeout time.Duration,
 			for _, fn := range closeAllConns {
 				fn()
 			}
+			notifyReconnect()
 		}
 	case <-ctx.Done():
 		log.Warn("Heartbeat timed out, restarting client connections")
 		for _, fn := range closeAllConns {
 			fn()
 		}
+		notifyReconnect()
 	}
 }
 
@@ -14,6 +14,8 @@ import (
 type CiliumV2Interface interface {
 	RESTClient() rest.Interface
 	CiliumClusterwideNetworkPoliciesGetter
+	CiliumConfigsGetter
+	CiliumConnectivityChecksGetter
 	CiliumEndpointsGetter
 	CiliumExternalWorkloadsGetter
 	CiliumIdentitiesGetter
@@ -31,6 +33,14 @@ func (c *CiliumV2Client) CiliumClusterwideNetworkPolicies() CiliumClusterwideNet
 	return newCiliumClusterwideNetworkPolicies(c)
 }
 
+func (c *CiliumV2Client) CiliumConfigs() CiliumConfigInterface {
+	return newCiliumConfigs(c)
+}
+
+func (c *CiliumV2Client) CiliumConnectivityChecks() CiliumConnectivityCheckInterface {
+	return newCiliumConnectivityChecks(c)
+}
+
 func (c *CiliumV2Client) CiliumEndpoints(namespace string) CiliumEndpointInterface {
 	return newCiliumEndpoints(c, namespace)
 }
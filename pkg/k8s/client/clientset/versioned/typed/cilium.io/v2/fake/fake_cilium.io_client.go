@@ -19,6 +19,14 @@ func (c *FakeCiliumV2) CiliumClusterwideNetworkPolicies() v2.CiliumClusterwideNe
 	return &FakeCiliumClusterwideNetworkPolicies{c}
 }
 
+func (c *FakeCiliumV2) CiliumConfigs() v2.CiliumConfigInterface {
+	return &FakeCiliumConfigs{c}
+}
+
+func (c *FakeCiliumV2) CiliumConnectivityChecks() v2.CiliumConnectivityCheckInterface {
+	return &FakeCiliumConnectivityChecks{c}
+}
+
 func (c *FakeCiliumV2) CiliumEndpoints(namespace string) v2.CiliumEndpointInterface {
 	return &FakeCiliumEndpoints{c, namespace}
 }
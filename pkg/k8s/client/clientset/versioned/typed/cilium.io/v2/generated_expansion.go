@@ -7,6 +7,10 @@ package v2
 
 type CiliumClusterwideNetworkPolicyExpansion interface{}
 
+type CiliumConfigExpansion interface{}
+
+type CiliumConnectivityCheckExpansion interface{}
+
 type CiliumEndpointExpansion interface{}
 
 type CiliumExternalWorkloadExpansion interface{}
@@ -13,6 +13,10 @@ import (
 type Interface interface {
 	// CiliumClusterwideNetworkPolicies returns a CiliumClusterwideNetworkPolicyInformer.
 	CiliumClusterwideNetworkPolicies() CiliumClusterwideNetworkPolicyInformer
+	// CiliumConfigs returns a CiliumConfigInformer.
+	CiliumConfigs() CiliumConfigInformer
+	// CiliumConnectivityChecks returns a CiliumConnectivityCheckInformer.
+	CiliumConnectivityChecks() CiliumConnectivityCheckInformer
 	// CiliumEndpoints returns a CiliumEndpointInformer.
 	CiliumEndpoints() CiliumEndpointInformer
 	// CiliumExternalWorkloads returns a CiliumExternalWorkloadInformer.
@@ -43,6 +47,16 @@ func (v *version) CiliumClusterwideNetworkPolicies() CiliumClusterwideNetworkPol
 	return &ciliumClusterwideNetworkPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// CiliumConfigs returns a CiliumConfigInformer.
+func (v *version) CiliumConfigs() CiliumConfigInformer {
+	return &ciliumConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// CiliumConnectivityChecks returns a CiliumConnectivityCheckInformer.
+func (v *version) CiliumConnectivityChecks() CiliumConnectivityCheckInformer {
+	return &ciliumConnectivityCheckInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // CiliumEndpoints returns a CiliumEndpointInformer.
 func (v *version) CiliumEndpoints() CiliumEndpointInformer {
 	return &ciliumEndpointInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
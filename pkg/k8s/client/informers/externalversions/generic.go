@@ -43,6 +43,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=cilium.io, Version=v2
 	case v2.SchemeGroupVersion.WithResource("ciliumclusterwidenetworkpolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cilium().V2().CiliumClusterwideNetworkPolicies().Informer()}, nil
+	case v2.SchemeGroupVersion.WithResource("ciliumconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cilium().V2().CiliumConfigs().Informer()}, nil
+	case v2.SchemeGroupVersion.WithResource("ciliumconnectivitychecks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cilium().V2().CiliumConnectivityChecks().Informer()}, nil
 	case v2.SchemeGroupVersion.WithResource("ciliumendpoints"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cilium().V2().CiliumEndpoints().Informer()}, nil
 	case v2.SchemeGroupVersion.WithResource("ciliumexternalworkloads"):
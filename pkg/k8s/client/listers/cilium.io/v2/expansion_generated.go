@@ -9,6 +9,14 @@ package v2
 // CiliumClusterwideNetworkPolicyLister.
 type CiliumClusterwideNetworkPolicyListerExpansion interface{}
 
+// CiliumConfigListerExpansion allows custom methods to be added to
+// CiliumConfigLister.
+type CiliumConfigListerExpansion interface{}
+
+// CiliumConnectivityCheckListerExpansion allows custom methods to be added to
+// CiliumConnectivityCheckLister.
+type CiliumConnectivityCheckListerExpansion interface{}
+
 // CiliumEndpointListerExpansion allows custom methods to be added to
 // CiliumEndpointLister.
 type CiliumEndpointListerExpansion interface{}
@@ -54,8 +54,9 @@ func (e *Endpoints) DeepEqual(o *Endpoints) bool {
 // +k8s:deepcopy-gen=true
 // +deepequal-gen=true
 type Backend struct {
-	Ports    serviceStore.PortConfiguration
-	NodeName string
+	Ports       serviceStore.PortConfiguration
+	NodeName    string
+	Terminating bool
 }
 
 // String returns the string representation of an endpoints resource, with
@@ -163,13 +164,22 @@ func ParseEndpointSliceV1Beta1(ep *slim_discovery_v1beta1.EndpointSlice) (Endpoi
 		// indicates an unknown state. In most cases consumers should interpret this
 		// unknown state as ready.
 		// More info: vendor/k8s.io/api/discovery/v1beta1/types.go:114
+		terminating := false
 		if sub.Conditions.Ready != nil && !*sub.Conditions.Ready {
-			continue
+			// Terminating endpoints that are still serving are kept as
+			// backends for established connections, so that long-lived
+			// connections drain instead of being reset during rollouts.
+			if !option.Config.EnableK8sTerminatingEndpoint ||
+				sub.Conditions.Serving == nil || !*sub.Conditions.Serving ||
+				sub.Conditions.Terminating == nil || !*sub.Conditions.Terminating {
+				continue
+			}
+			terminating = true
 		}
 		for _, addr := range sub.Addresses {
 			backend, ok := endpoints.Backends[addr]
 			if !ok {
-				backend = &Backend{Ports: serviceStore.PortConfiguration{}}
+				backend = &Backend{Ports: serviceStore.PortConfiguration{}, Terminating: terminating}
 				endpoints.Backends[addr] = backend
 				if nodeName, ok := sub.Topology["kubernetes.io/hostname"]; ok {
 					backend.NodeName = nodeName
@@ -223,13 +233,22 @@ func ParseEndpointSliceV1(ep *slim_discovery_v1.EndpointSlice) (EndpointSliceID,
 		// indicates an unknown state. In most cases consumers should interpret this
 		// unknown state as ready.
 		// More info: vendor/k8s.io/api/discovery/v1/types.go:117
+		terminating := false
 		if sub.Conditions.Ready != nil && !*sub.Conditions.Ready {
-			continue
+			// Terminating endpoints that are still serving are kept as
+			// backends for established connections, so that long-lived
+			// connections drain instead of being reset during rollouts.
+			if !option.Config.EnableK8sTerminatingEndpoint ||
+				sub.Conditions.Serving == nil || !*sub.Conditions.Serving ||
+				sub.Conditions.Terminating == nil || !*sub.Conditions.Terminating {
+				continue
+			}
+			terminating = true
 		}
 		for _, addr := range sub.Addresses {
 			backend, ok := endpoints.Backends[addr]
 			if !ok {
-				backend = &Backend{Ports: serviceStore.PortConfiguration{}}
+				backend = &Backend{Ports: serviceStore.PortConfiguration{}, Terminating: terminating}
 				endpoints.Backends[addr] = backend
 				if sub.NodeName != nil {
 					backend.NodeName = *sub.NodeName
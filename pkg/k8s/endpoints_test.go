@@ -16,6 +16,7 @@ import (
 	slim_discovery_v1beta1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/api/discovery/v1beta1"
 	slim_metav1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/meta/v1"
 	"github.com/cilium/cilium/pkg/loadbalancer"
+	"github.com/cilium/cilium/pkg/option"
 	serviceStore "github.com/cilium/cilium/pkg/service/store"
 
 	"gopkg.in/check.v1"
@@ -1156,6 +1157,72 @@ func (s *K8sSuite) Test_parseK8sEPSlicev1(c *check.C) {
 	}
 }
 
+func (s *K8sSuite) Test_parseK8sEPSlicev1Terminating(c *check.C) {
+	enabled := option.Config.EnableK8sTerminatingEndpoint
+	option.Config.EnableK8sTerminatingEndpoint = true
+	defer func() { option.Config.EnableK8sTerminatingEndpoint = enabled }()
+
+	boolPtr := func(b bool) *bool { return &b }
+	eps := &slim_discovery_v1.EndpointSlice{
+		ObjectMeta: slim_metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Endpoints: []slim_discovery_v1.Endpoint{
+			{
+				Addresses: []string{"172.0.0.1"},
+			},
+			{
+				// Terminating but still serving, kept as a draining backend.
+				Conditions: slim_discovery_v1.EndpointConditions{
+					Ready:       boolPtr(false),
+					Serving:     boolPtr(true),
+					Terminating: boolPtr(true),
+				},
+				Addresses: []string{"172.0.0.2"},
+			},
+			{
+				// Terminating and no longer serving, skipped.
+				Conditions: slim_discovery_v1.EndpointConditions{
+					Ready:       boolPtr(false),
+					Serving:     boolPtr(false),
+					Terminating: boolPtr(true),
+				},
+				Addresses: []string{"172.0.0.3"},
+			},
+		},
+		Ports: []slim_discovery_v1.EndpointPort{
+			{
+				Name:     func() *string { a := "http-test-svc"; return &a }(),
+				Protocol: func() *slim_corev1.Protocol { a := slim_corev1.ProtocolTCP; return &a }(),
+				Port:     func() *int32 { a := int32(8080); return &a }(),
+			},
+		},
+	}
+
+	want := newEndpoints()
+	want.Backends["172.0.0.1"] = &Backend{
+		Ports: serviceStore.PortConfiguration{
+			"http-test-svc": loadbalancer.NewL4Addr(loadbalancer.TCP, 8080),
+		},
+	}
+	want.Backends["172.0.0.2"] = &Backend{
+		Ports: serviceStore.PortConfiguration{
+			"http-test-svc": loadbalancer.NewL4Addr(loadbalancer.TCP, 8080),
+		},
+		Terminating: true,
+	}
+
+	_, got := ParseEndpointSliceV1(eps)
+	c.Assert(got, checker.DeepEquals, want)
+
+	// With the option disabled, terminating endpoints are skipped entirely.
+	option.Config.EnableK8sTerminatingEndpoint = false
+	delete(want.Backends, "172.0.0.2")
+	_, got = ParseEndpointSliceV1(eps)
+	c.Assert(got, checker.DeepEquals, want)
+}
+
 func Test_parseEndpointPortV1(t *testing.T) {
 	type args struct {
 		port slim_discovery_v1.EndpointPort
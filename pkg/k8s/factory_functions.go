@@ -893,3 +893,94 @@ func ObjToCENP(obj interface{}) *cilium_v2alpha1.CiliumEgressNATPolicy {
 		Warn("Ignoring invalid v2 Cilium Egress Gateway Policy")
 	return nil
 }
+
+// ConvertToCiliumConnectivityCheck converts a *cilium_v2.CiliumConnectivityCheck
+// into a *cilium_v2.CiliumConnectivityCheck or a cache.DeletedFinalStateUnknown
+// into a cache.DeletedFinalStateUnknown with a *cilium_v2.CiliumConnectivityCheck
+// in its Obj. If the given obj can't be cast into either
+// *cilium_v2.CiliumConnectivityCheck nor cache.DeletedFinalStateUnknown, the
+// original obj is returned.
+func ConvertToCiliumConnectivityCheck(obj interface{}) interface{} {
+	switch concreteObj := obj.(type) {
+	case *cilium_v2.CiliumConnectivityCheck:
+		return concreteObj
+	case cache.DeletedFinalStateUnknown:
+		ciliumConnectivityCheck, ok := concreteObj.Obj.(*cilium_v2.CiliumConnectivityCheck)
+		if !ok {
+			return obj
+		}
+		return cache.DeletedFinalStateUnknown{
+			Key: concreteObj.Key,
+			Obj: ciliumConnectivityCheck,
+		}
+	default:
+		return obj
+	}
+}
+
+// ObjToCCC attempts to cast object to a CCC object and
+// returns a deep copy if the casting succeeds. Otherwise, nil is returned.
+func ObjToCCC(obj interface{}) *cilium_v2.CiliumConnectivityCheck {
+	ccc, ok := obj.(*cilium_v2.CiliumConnectivityCheck)
+	if ok {
+		return ccc
+	}
+	deletedObj, ok := obj.(cache.DeletedFinalStateUnknown)
+	if ok {
+		// Delete was not observed by the watcher but is
+		// removed from kube-apiserver. This is the last
+		// known state and the object no longer exists.
+		cn, ok := deletedObj.Obj.(*cilium_v2.CiliumConnectivityCheck)
+		if ok {
+			return cn
+		}
+	}
+	log.WithField(logfields.Object, logfields.Repr(obj)).
+		Warn("Ignoring invalid v2 Cilium Connectivity Check")
+	return nil
+}
+
+// ConvertToCiliumConfig converts a *cilium_v2.CiliumConfig into a
+// *cilium_v2.CiliumConfig or a cache.DeletedFinalStateUnknown into a
+// cache.DeletedFinalStateUnknown with a *cilium_v2.CiliumConfig in its Obj.
+// If the given obj can't be cast into either *cilium_v2.CiliumConfig nor
+// cache.DeletedFinalStateUnknown, the original obj is returned.
+func ConvertToCiliumConfig(obj interface{}) interface{} {
+	switch concreteObj := obj.(type) {
+	case *cilium_v2.CiliumConfig:
+		return concreteObj
+	case cache.DeletedFinalStateUnknown:
+		ciliumConfig, ok := concreteObj.Obj.(*cilium_v2.CiliumConfig)
+		if !ok {
+			return obj
+		}
+		return cache.DeletedFinalStateUnknown{
+			Key: concreteObj.Key,
+			Obj: ciliumConfig,
+		}
+	default:
+		return obj
+	}
+}
+
+// ObjToCCFG attempts to cast object to a CCFG object and
+// returns a deep copy if the casting succeeds. Otherwise, nil is returned.
+func ObjToCCFG(obj interface{}) *cilium_v2.CiliumConfig {
+	ccfg, ok := obj.(*cilium_v2.CiliumConfig)
+	if ok {
+		return ccfg
+	}
+	deletedObj, ok := obj.(cache.DeletedFinalStateUnknown)
+	if ok {
+		// Delete was not observed by the watcher but is
+		// removed from kube-apiserver. This is the last
+		// known state and the object no longer exists.
+		cn, ok := deletedObj.Obj.(*cilium_v2.CiliumConfig)
+		if ok {
+			return cn
+		}
+	}
+	log.WithField(logfields.Object, logfields.Repr(obj)).
+		Warn("Ignoring invalid v2 Cilium Config")
+	return nil
+}
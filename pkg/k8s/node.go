@@ -122,6 +122,8 @@ func ParseNode(k8sNode *slim_corev1.Node, source source.Source) *nodeTypes.Node
 		IPAddresses:   addrs,
 		Source:        source,
 		EncryptionKey: encryptKey,
+
+		EncryptionPreference: k8sNode.Annotations[annotation.EncryptionTechnology],
 	}
 
 	if len(k8sNode.Spec.PodCIDRs) != 0 {
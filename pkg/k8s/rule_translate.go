@@ -8,7 +8,10 @@ import (
 	"net"
 
 	"github.com/cilium/cilium/pkg/ipcache"
-	"github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/labels"
+	k8sConst "github.com/cilium/cilium/pkg/k8s/apis/cilium.io"
+	slim_labels "github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/labels"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/policy/api"
 )
@@ -24,10 +27,16 @@ type RuleTranslator struct {
 	ServiceLabels    map[string]string
 	Revert           bool
 	AllocatePrefixes bool
+
+	// policyNamespace is the namespace the currently translated rule was
+	// imported from, used to scope service selection when
+	// --to-services-namespace-scoping is enabled.
+	policyNamespace string
 }
 
 // Translate calls TranslateEgress on all r.Egress rules
 func (k RuleTranslator) Translate(r *api.Rule, result *policy.TranslationResult) error {
+	k.policyNamespace = policyNamespace(r)
 	for egressIndex := range r.Egress {
 		err := k.TranslateEgress(&r.Egress[egressIndex], result)
 		if err != nil {
@@ -52,6 +61,13 @@ func (k RuleTranslator) TranslateEgress(r *api.EgressRule, result *policy.Transl
 			return err
 		}
 	}
+	if len(r.ToServices) > 0 {
+		for _, c := range r.ToCIDRSet {
+			if c.Generated {
+				result.NumToServicesBackends++
+			}
+		}
+	}
 	return nil
 }
 
@@ -86,9 +102,8 @@ func (k RuleTranslator) serviceMatches(service api.Service) bool {
 	if service.K8sServiceSelector != nil {
 		es := api.EndpointSelector(service.K8sServiceSelector.Selector)
 		es.SyncRequirementsWithLabelSelector()
-		esMatches := es.Matches(labels.Set(k.ServiceLabels))
-		return esMatches &&
-			(service.K8sServiceSelector.Namespace == k.Service.Namespace || service.K8sServiceSelector.Namespace == "")
+		esMatches := es.Matches(slim_labels.Set(k.ServiceLabels))
+		return esMatches && k.namespaceMatches(service.K8sServiceSelector)
 	}
 
 	if service.K8sService != nil {
@@ -99,6 +114,39 @@ func (k RuleTranslator) serviceMatches(service api.Service) bool {
 	return false
 }
 
+// namespaceMatches checks the namespace of the service against the
+// namespaces the selector explicitly selects. Without explicit namespaces,
+// services in all namespaces match, unless resolution is scoped to the
+// namespace of the policy via --to-services-namespace-scoping.
+func (k RuleTranslator) namespaceMatches(sel *api.K8sServiceSelectorNamespace) bool {
+	if len(sel.Namespaces) > 0 {
+		for _, ns := range sel.Namespaces {
+			if ns == k.Service.Namespace {
+				return true
+			}
+		}
+		return false
+	}
+	if sel.Namespace != "" {
+		return sel.Namespace == k.Service.Namespace
+	}
+	if option.Config.ToServicesNamespaceScoping && k.policyNamespace != "" {
+		return k.policyNamespace == k.Service.Namespace
+	}
+	return true
+}
+
+// policyNamespace returns the namespace the rule was imported from, or an
+// empty string for cluster-scoped rules.
+func policyNamespace(r *api.Rule) string {
+	for _, l := range r.Labels {
+		if l.Source == labels.LabelSourceK8s && l.Key == k8sConst.PolicyLabelNamespace {
+			return l.Value
+		}
+	}
+	return ""
+}
+
 // generateToCidrFromEndpoint takes an egress rule and populates it with
 // ToCIDR rules based on provided endpoint object
 func generateToCidrFromEndpoint(
@@ -262,5 +310,11 @@ func NewK8sTranslator(
 	labels map[string]string,
 	allocatePrefixes bool) RuleTranslator {
 
-	return RuleTranslator{serviceInfo, endpoint, labels, revert, allocatePrefixes}
+	return RuleTranslator{
+		Service:          serviceInfo,
+		Endpoint:         endpoint,
+		ServiceLabels:    labels,
+		Revert:           revert,
+		AllocatePrefixes: allocatePrefixes,
+	}
 }
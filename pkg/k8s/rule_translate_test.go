@@ -13,6 +13,7 @@ import (
 	fakeDatapath "github.com/cilium/cilium/pkg/datapath/fake"
 	"github.com/cilium/cilium/pkg/labels"
 	"github.com/cilium/cilium/pkg/loadbalancer"
+	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/policy/api"
 
@@ -122,6 +123,51 @@ func (s *K8sSuite) TestServiceMatches(c *C) {
 	c.Assert(translator.serviceMatches(service), Equals, true)
 }
 
+func (s *K8sSuite) TestServiceMatchesNamespaces(c *C) {
+	svcLabels := map[string]string{
+		"app": "tested-service",
+	}
+
+	serviceInfo := ServiceID{
+		Name:      "doesn't matter",
+		Namespace: "default",
+	}
+
+	selector := api.ServiceSelector(api.NewESFromMatchRequirements(svcLabels, nil))
+	service := api.Service{
+		K8sServiceSelector: &api.K8sServiceSelectorNamespace{
+			Selector:   selector,
+			Namespaces: []string{"prod", "default"},
+		},
+	}
+
+	translator := NewK8sTranslator(serviceInfo, Endpoints{}, false, svcLabels, false)
+	c.Assert(translator.serviceMatches(service), Equals, true)
+
+	// services outside the listed namespaces do not match
+	service.K8sServiceSelector.Namespaces = []string{"prod"}
+	c.Assert(translator.serviceMatches(service), Equals, false)
+
+	// explicit namespaces take precedence over Namespace
+	service.K8sServiceSelector.Namespace = "default"
+	c.Assert(translator.serviceMatches(service), Equals, false)
+
+	// without explicit namespaces, scoping restricts matching to the
+	// namespace of the policy
+	service.K8sServiceSelector.Namespaces = nil
+	service.K8sServiceSelector.Namespace = ""
+	translator.policyNamespace = "prod"
+	oldScoping := option.Config.ToServicesNamespaceScoping
+	defer func() { option.Config.ToServicesNamespaceScoping = oldScoping }()
+	option.Config.ToServicesNamespaceScoping = true
+	c.Assert(translator.serviceMatches(service), Equals, false)
+	translator.policyNamespace = "default"
+	c.Assert(translator.serviceMatches(service), Equals, true)
+	option.Config.ToServicesNamespaceScoping = false
+	translator.policyNamespace = "prod"
+	c.Assert(translator.serviceMatches(service), Equals, true)
+}
+
 func (s *K8sSuite) TestTranslatorLabels(c *C) {
 	repo := policy.NewPolicyRepository(nil, nil)
 	svcLabels := map[string]string{
@@ -34,6 +34,19 @@ func getAnnotationIncludeExternal(svc *slim_corev1.Service) bool {
 	return false
 }
 
+func getAnnotationLoadBalancerAlgorithm(svc *slim_corev1.Service) loadbalancer.SVCLoadBalancerAlgorithm {
+	switch loadbalancer.SVCLoadBalancerAlgorithm(svc.ObjectMeta.Annotations[annotation.ServiceLoadBalancerAlgorithm]) {
+	case loadbalancer.SVCLoadBalancerAlgorithmRandom:
+		return loadbalancer.SVCLoadBalancerAlgorithmRandom
+	case loadbalancer.SVCLoadBalancerAlgorithmMaglev:
+		return loadbalancer.SVCLoadBalancerAlgorithmMaglev
+	case loadbalancer.SVCLoadBalancerAlgorithmLatency:
+		return loadbalancer.SVCLoadBalancerAlgorithmLatency
+	default:
+		return loadbalancer.SVCLoadBalancerAlgorithmDefault
+	}
+}
+
 func getAnnotationShared(svc *slim_corev1.Service) bool {
 	if value, ok := svc.ObjectMeta.Annotations[annotation.SharedService]; ok {
 		return strings.ToLower(value) == "true"
@@ -145,6 +158,7 @@ func ParseService(svc *slim_corev1.Service, nodeAddressing datapath.NodeAddressi
 
 	svcInfo.IncludeExternal = getAnnotationIncludeExternal(svc)
 	svcInfo.Shared = getAnnotationShared(svc)
+	svcInfo.LoadBalancerAlgorithm = getAnnotationLoadBalancerAlgorithm(svc)
 
 	if svc.Spec.SessionAffinity == slim_corev1.ServiceAffinityClientIP {
 		svcInfo.SessionAffinity = true
@@ -285,6 +299,10 @@ type Service struct {
 	// node-local backends are chosen
 	TrafficPolicy loadbalancer.SVCTrafficPolicy
 
+	// LoadBalancerAlgorithm is the backend selection algorithm requested for
+	// the service. If unset, the globally configured algorithm is used.
+	LoadBalancerAlgorithm loadbalancer.SVCLoadBalancerAlgorithm
+
 	// HealthCheckNodePort defines on which port the node runs a HTTP health
 	// check server which may be used by external loadbalancers to determine
 	// if a node has local backends. This will only have effect if both
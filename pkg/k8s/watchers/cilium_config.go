@@ -0,0 +1,133 @@
+//  Copyright 2021 Authors of Cilium
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package watchers
+
+import (
+	"context"
+
+	"github.com/cilium/cilium/pkg/k8s"
+	cilium_v2 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2"
+	"github.com/cilium/cilium/pkg/k8s/informer"
+	slimv1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/meta/v1"
+	nodeTypes "github.com/cilium/cilium/pkg/node/types"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+)
+
+func (k *K8sWatcher) ciliumConfigInit(ciliumNPClient *k8s.K8sCiliumClient) {
+	_, ccfgController := informer.NewInformer(
+		cache.NewListWatchFromClient(ciliumNPClient.CiliumV2().RESTClient(),
+			cilium_v2.CCFGPluralName, v1.NamespaceAll, fields.Everything()),
+		&cilium_v2.CiliumConfig{},
+		0,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				var valid, equal bool
+				defer func() { k.K8sEventReceived(metricCCFG, metricCreate, valid, equal) }()
+				if ccfg := k8s.ObjToCCFG(obj); ccfg != nil {
+					valid = true
+					err := k.applyCiliumConfig(ciliumNPClient, ccfg)
+					k.K8sEventProcessed(metricCCFG, metricCreate, err == nil)
+				}
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				var valid, equal bool
+				defer func() { k.K8sEventReceived(metricCCFG, metricUpdate, valid, equal) }()
+
+				newCCFG := k8s.ObjToCCFG(newObj)
+				if newCCFG == nil {
+					return
+				}
+				valid = true
+				if oldCCFG := k8s.ObjToCCFG(oldObj); oldCCFG != nil && oldCCFG.DeepEqual(newCCFG) {
+					equal = true
+					return
+				}
+				err := k.applyCiliumConfig(ciliumNPClient, newCCFG)
+				k.K8sEventProcessed(metricCCFG, metricUpdate, err == nil)
+			},
+			DeleteFunc: func(obj interface{}) {
+				var valid, equal bool
+				defer func() { k.K8sEventReceived(metricCCFG, metricDelete, valid, equal) }()
+				ccfg := k8s.ObjToCCFG(obj)
+				if ccfg == nil {
+					return
+				}
+				valid = true
+				// Deleting a CiliumConfig does not revert the
+				// options it carried, the agent keeps running
+				// with the last applied configuration.
+				log.WithField("name", ccfg.ObjectMeta.Name).
+					Debug("CiliumConfig deleted, keeping last applied configuration")
+				k.K8sEventProcessed(metricCCFG, metricDelete, true)
+			},
+		},
+		k8s.ConvertToCiliumConfig,
+	)
+
+	k.blockWaitGroupToSyncResources(
+		wait.NeverStop,
+		nil,
+		ccfgController.HasSynced,
+		k8sAPIGroupCiliumConfigV2,
+	)
+
+	go ccfgController.Run(wait.NeverStop)
+	k.k8sAPIGroups.AddAPI(k8sAPIGroupCiliumConfigV2)
+}
+
+// applyCiliumConfig applies the configuration carried by a CiliumConfig
+// through the regular configuration reload path and reports the outcome in
+// the status of the resource under the name of the local node. Application is
+// idempotent, options already at their desired value are left untouched, so
+// resyncs after an agent restart simply re-establish the desired
+// configuration.
+func (k *K8sWatcher) applyCiliumConfig(ciliumNPClient *k8s.K8sCiliumClient, ccfg *cilium_v2.CiliumConfig) error {
+	applyErr := k.configManager.ApplyHotConfig(ccfg.Spec.Options, ccfg.Spec.PolicyEnforcement)
+	if applyErr != nil {
+		log.WithError(applyErr).WithField("name", ccfg.ObjectMeta.Name).
+			Warn("Unable to apply CiliumConfig")
+	}
+
+	nodeStatus := cilium_v2.ConfigNodeStatus{
+		ObservedGeneration: ccfg.ObjectMeta.Generation,
+		Applied:            applyErr == nil,
+		LastAppliedTime:    slimv1.Now(),
+	}
+	if applyErr != nil {
+		nodeStatus.Error = applyErr.Error()
+	}
+
+	ctx := context.TODO()
+	ccfg, err := ciliumNPClient.CiliumV2().CiliumConfigs().Get(ctx, ccfg.ObjectMeta.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if ccfg.Status.Nodes == nil {
+		ccfg.Status.Nodes = map[string]cilium_v2.ConfigNodeStatus{}
+	}
+	ccfg.Status.Nodes[nodeTypes.GetName()] = nodeStatus
+
+	if _, err := ciliumNPClient.CiliumV2().CiliumConfigs().UpdateStatus(ctx, ccfg, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+
+	return applyErr
+}
@@ -0,0 +1,248 @@
+//  Copyright 2021 Authors of Cilium
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package watchers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/k8s"
+	cilium_v2 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2"
+	"github.com/cilium/cilium/pkg/k8s/informer"
+	slimv1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/meta/v1"
+	"github.com/cilium/cilium/pkg/loadbalancer"
+	nodeTypes "github.com/cilium/cilium/pkg/node/types"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// connectivityProbeTimeout bounds how long a single probe may take to
+	// connect before the target is considered unreachable.
+	connectivityProbeTimeout = 5 * time.Second
+
+	// connectivityDefaultInterval is the probe period used when the check
+	// does not specify one.
+	connectivityDefaultInterval = 60 * time.Second
+)
+
+// connectivityCheckControllers runs one controller per connectivity check
+// which periodically executes the probes and reports the results.
+var connectivityCheckControllers = controller.NewManager()
+
+func (k *K8sWatcher) ciliumConnectivityCheckInit(ciliumNPClient *k8s.K8sCiliumClient) {
+	_, cccController := informer.NewInformer(
+		cache.NewListWatchFromClient(ciliumNPClient.CiliumV2().RESTClient(),
+			cilium_v2.CCCPluralName, v1.NamespaceAll, fields.Everything()),
+		&cilium_v2.CiliumConnectivityCheck{},
+		0,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				var valid, equal bool
+				defer func() { k.K8sEventReceived(metricCCC, metricCreate, valid, equal) }()
+				if ccc := k8s.ObjToCCC(obj); ccc != nil {
+					valid = true
+					err := k.addCiliumConnectivityCheck(ciliumNPClient, ccc)
+					k.K8sEventProcessed(metricCCC, metricCreate, err == nil)
+				}
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				var valid, equal bool
+				defer func() { k.K8sEventReceived(metricCCC, metricUpdate, valid, equal) }()
+
+				newCCC := k8s.ObjToCCC(newObj)
+				if newCCC == nil {
+					return
+				}
+				valid = true
+				if oldCCC := k8s.ObjToCCC(oldObj); oldCCC != nil && oldCCC.DeepEqual(newCCC) {
+					equal = true
+					return
+				}
+				err := k.addCiliumConnectivityCheck(ciliumNPClient, newCCC)
+				k.K8sEventProcessed(metricCCC, metricUpdate, err == nil)
+			},
+			DeleteFunc: func(obj interface{}) {
+				var valid, equal bool
+				defer func() { k.K8sEventReceived(metricCCC, metricDelete, valid, equal) }()
+				ccc := k8s.ObjToCCC(obj)
+				if ccc == nil {
+					return
+				}
+				valid = true
+				err := k.deleteCiliumConnectivityCheck(ccc)
+				k.K8sEventProcessed(metricCCC, metricDelete, err == nil)
+			},
+		},
+		k8s.ConvertToCiliumConnectivityCheck,
+	)
+
+	k.blockWaitGroupToSyncResources(
+		wait.NeverStop,
+		nil,
+		cccController.HasSynced,
+		k8sAPIGroupCiliumConnectivityCheckV2,
+	)
+
+	go cccController.Run(wait.NeverStop)
+	k.k8sAPIGroups.AddAPI(k8sAPIGroupCiliumConnectivityCheckV2)
+}
+
+func (k *K8sWatcher) addCiliumConnectivityCheck(ciliumNPClient *k8s.K8sCiliumClient, ccc *cilium_v2.CiliumConnectivityCheck) error {
+	name := ccc.ObjectMeta.Name
+	interval := connectivityDefaultInterval
+	if ccc.Spec.IntervalSeconds > 0 {
+		interval = time.Duration(ccc.Spec.IntervalSeconds) * time.Second
+	}
+
+	probes := make([]cilium_v2.ConnectivityProbe, len(ccc.Spec.Probes))
+	copy(probes, ccc.Spec.Probes)
+
+	connectivityCheckControllers.UpdateController("ccc-"+name,
+		controller.ControllerParams{
+			RunInterval: interval,
+			DoFunc: func(ctx context.Context) error {
+				return k.runConnectivityProbes(ctx, ciliumNPClient, name, probes)
+			},
+		},
+	)
+
+	return nil
+}
+
+func (k *K8sWatcher) deleteCiliumConnectivityCheck(ccc *cilium_v2.CiliumConnectivityCheck) error {
+	return connectivityCheckControllers.RemoveController("ccc-" + ccc.ObjectMeta.Name)
+}
+
+// runConnectivityProbes executes all probes of a connectivity check and
+// reports the results in the status of the check under the name of the local
+// node.
+func (k *K8sWatcher) runConnectivityProbes(ctx context.Context, ciliumNPClient *k8s.K8sCiliumClient, name string, probes []cilium_v2.ConnectivityProbe) error {
+	results := make([]cilium_v2.ConnectivityProbeResult, 0, len(probes))
+	for i := range probes {
+		results = append(results, k.runConnectivityProbe(&probes[i]))
+	}
+
+	ccc, err := ciliumNPClient.CiliumV2().CiliumConnectivityChecks().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if ccc.Status.Nodes == nil {
+		ccc.Status.Nodes = map[string]cilium_v2.ConnectivityCheckNodeStatus{}
+	}
+	ccc.Status.Nodes[nodeTypes.GetName()] = cilium_v2.ConnectivityCheckNodeStatus{
+		LastProbeTime: slimv1.Now(),
+		Results:       results,
+	}
+
+	_, err = ciliumNPClient.CiliumV2().CiliumConnectivityChecks().UpdateStatus(ctx, ccc, metav1.UpdateOptions{})
+	return err
+}
+
+func (k *K8sWatcher) runConnectivityProbe(probe *cilium_v2.ConnectivityProbe) cilium_v2.ConnectivityProbeResult {
+	result := cilium_v2.ConnectivityProbeResult{
+		Probe: probe.Name,
+	}
+
+	addr, err := k.resolveProbeTarget(probe)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", addr, connectivityProbeTimeout)
+	latency := time.Since(start)
+	if conn != nil {
+		conn.Close()
+	}
+
+	expected := probe.Expected
+	if expected == "" {
+		expected = cilium_v2.ProbeVerdictReachable
+	}
+
+	switch {
+	case err == nil && expected == cilium_v2.ProbeVerdictReachable:
+		result.Success = true
+		result.Latency = latency.Nanoseconds()
+	case err != nil && expected == cilium_v2.ProbeVerdictUnreachable:
+		result.Success = true
+	case err == nil:
+		result.Latency = latency.Nanoseconds()
+		result.Message = fmt.Sprintf("%s is reachable, expected %s", addr, expected)
+	default:
+		result.Message = fmt.Sprintf("%s is unreachable: %s", addr, err)
+	}
+
+	return result
+}
+
+// resolveProbeTarget resolves the target of a probe to an "address:port" pair
+// the probe can connect to.
+func (k *K8sWatcher) resolveProbeTarget(probe *cilium_v2.ConnectivityProbe) (string, error) {
+	port := strconv.FormatUint(uint64(probe.Port), 10)
+
+	switch probe.Type {
+	case cilium_v2.ProbeTypePod:
+		namespace, name, err := splitProbeTarget(probe.Target)
+		if err != nil {
+			return "", err
+		}
+		pod, err := k.GetCachedPod(namespace, name)
+		if err != nil {
+			return "", err
+		}
+		if pod.Status.PodIP == "" {
+			return "", fmt.Errorf("pod %q has no IP address", probe.Target)
+		}
+		return net.JoinHostPort(pod.Status.PodIP, port), nil
+	case cilium_v2.ProbeTypeService:
+		namespace, name, err := splitProbeTarget(probe.Target)
+		if err != nil {
+			return "", err
+		}
+		frontendIP := k.K8sSvcCache.GetServiceFrontendIP(
+			k8s.ServiceID{Namespace: namespace, Name: name},
+			loadbalancer.SVCTypeClusterIP)
+		if frontendIP == nil {
+			return "", fmt.Errorf("service %q has no frontend IP", probe.Target)
+		}
+		return net.JoinHostPort(frontendIP.String(), port), nil
+	case cilium_v2.ProbeTypeExternal:
+		return net.JoinHostPort(probe.Target, port), nil
+	default:
+		return "", fmt.Errorf("unknown probe type %q", probe.Type)
+	}
+}
+
+// splitProbeTarget splits a probe target in the "namespace/name" format.
+func splitProbeTarget(target string) (namespace, name string, err error) {
+	parts := strings.SplitN(target, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("target %q is not in namespace/name format", target)
+	}
+	return parts[0], parts[1], nil
+}
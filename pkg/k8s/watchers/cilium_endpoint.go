@@ -18,7 +18,6 @@ import (
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/source"
-	"github.com/cilium/cilium/pkg/u8proto"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -170,14 +169,10 @@ func (k *K8sWatcher) endpointUpdated(oldEndpoint, endpoint *types.CiliumEndpoint
 		NamedPorts: make(policy.NamedPortMap, len(endpoint.NamedPorts)),
 	}
 	for _, port := range endpoint.NamedPorts {
-		p, err := u8proto.ParseProtocol(port.Protocol)
+		err := k8sMeta.NamedPorts.AddPort(port.Name, int(port.Port), port.Protocol)
 		if err != nil {
 			continue
 		}
-		k8sMeta.NamedPorts[port.Name] = policy.PortProto{
-			Port:  port.Port,
-			Proto: uint8(p),
-		}
 	}
 
 	for _, pair := range endpoint.Networking.Addressing {
@@ -85,10 +85,16 @@ func (k *K8sWatcher) updateK8sEndpointV1(oldEP, newEP *slim_corev1.Endpoints, sw
 	if option.Config.BGPAnnounceLBIP {
 		k.bgpSpeakerManager.OnUpdateEndpoints(newEP)
 	}
+	if isKubeAPIServerEndpoints(newEP) {
+		updateKubeAPIServerEndpoints(newEP)
+	}
 	return nil
 }
 
 func (k *K8sWatcher) deleteK8sEndpointV1(ep *slim_corev1.Endpoints, swg *lock.StoppableWaitGroup) error {
 	k.K8sSvcCache.DeleteEndpoints(ep, swg)
+	if isKubeAPIServerEndpoints(ep) {
+		updateKubeAPIServerEndpoints(nil)
+	}
 	return nil
 }
@@ -19,9 +19,13 @@ import (
 	v2 "github.com/cilium/cilium/pkg/k8s/client/clientset/versioned/typed/cilium.io/v2"
 	k8sversion "github.com/cilium/cilium/pkg/k8s/version"
 	pkgLabels "github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/metrics"
+	"github.com/cilium/cilium/pkg/node"
 	"github.com/cilium/cilium/pkg/option"
 
 	"github.com/blang/semver/v4"
+	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -30,6 +34,26 @@ import (
 const (
 	// subsysEndpointSync is the value for logfields.LogSubsys
 	subsysEndpointSync = "endpointsynchronizer"
+
+	// ciliumEndpointAuditControllerName is the name of the controller
+	// which audits the CiliumEndpoint objects managed by this node
+	// against local endpoint state.
+	ciliumEndpointAuditControllerName = "sync-to-k8s-ciliumendpoint-audit"
+
+	// ciliumEndpointAuditInterval is the interval at which the
+	// CiliumEndpoint objects managed by this node are compared against
+	// local endpoint state.
+	ciliumEndpointAuditInterval = 5 * time.Minute
+
+	// ciliumEndpointAuditGracePeriod is the minimum age of a
+	// CiliumEndpoint object before it may be considered stale. It guards
+	// against deleting objects of endpoints which have not been exposed
+	// via the endpoint manager yet.
+	ciliumEndpointAuditGracePeriod = 2 * time.Minute
+
+	// Values for the action label of the CiliumEndpoint repair metric.
+	repairActionDelete   = "delete"
+	repairActionRecreate = "recreate"
 )
 
 // EndpointSynchronizer currently is an empty type, which wraps around syncing
@@ -331,3 +355,137 @@ func deleteCEP(ctx context.Context, scopedLog *logrus.Entry, ciliumClient v2.Cil
 	}
 	return nil
 }
+
+// auditEndpointManager is the subset of the endpoint manager API required
+// to compare the CiliumEndpoint objects in Kubernetes against local
+// endpoint state.
+type auditEndpointManager interface {
+	GetEndpoints() []*endpoint.Endpoint
+	LookupPodName(name string) *endpoint.Endpoint
+	RunK8sCiliumEndpointSync(ep *endpoint.Endpoint, conf endpoint.EndpointStatusConfiguration)
+}
+
+// RunK8sCiliumEndpointAudit starts a controller which periodically compares
+// the CiliumEndpoint objects managed by this node against the endpoints
+// known to the endpoint manager and repairs any divergence found. The
+// per-endpoint sync controllers only push updates when the endpoint status
+// changes, so a CEP deleted behind the agent's back, e.g. when the
+// apiserver is restored from a backup, would otherwise remain missing
+// until the endpoint changes. Conversely, restored objects of endpoints
+// which no longer exist locally would linger until the operator garbage
+// collector correlates them with pods. The agent knows the authoritative
+// state of its own endpoints and repairs both cases directly.
+func RunK8sCiliumEndpointAudit(epMgr auditEndpointManager, conf endpoint.EndpointStatusConfiguration) {
+	if option.Config.DisableCiliumEndpointCRD {
+		return
+	}
+
+	if !k8s.IsEnabled() {
+		return
+	}
+
+	scopedLog := log.WithField("controller", ciliumEndpointAuditControllerName)
+	ciliumClient := k8s.CiliumClient().CiliumV2()
+
+	// this dummy manager is needed only to add this controller to the global list
+	controller.NewManager().UpdateController(ciliumEndpointAuditControllerName,
+		controller.ControllerParams{
+			RunInterval: ciliumEndpointAuditInterval,
+			DoFunc: func(ctx context.Context) error {
+				return auditCiliumEndpoints(ctx, scopedLog, ciliumClient, epMgr, conf)
+			},
+		})
+}
+
+func auditCiliumEndpoints(ctx context.Context, scopedLog *logrus.Entry, ciliumClient v2.CiliumV2Interface, epMgr auditEndpointManager, conf endpoint.EndpointStatusConfiguration) error {
+	cepList, err := ciliumClient.CiliumEndpoints(v1.NamespaceAll).List(ctx, meta_v1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	// The node IP ties a CEP to the agent which created it. It must be
+	// derived the same way as in GetCiliumEndpointStatus.
+	var nodeIP string
+	if option.Config.EnableIPv4 {
+		nodeIP = node.GetIPv4().String()
+	} else {
+		nodeIP = node.GetIPv6().String()
+	}
+
+	var lastErr error
+
+	// CiliumEndpoint objects have the same name as the pod they represent.
+	cepNames := make(map[string]struct{}, len(cepList.Items))
+	for i := range cepList.Items {
+		cep := &cepList.Items[i]
+		cepFullName := cep.Namespace + "/" + cep.Name
+		cepNames[cepFullName] = struct{}{}
+
+		// Only audit objects managed by this node.
+		if cep.Status.Networking == nil || cep.Status.Networking.NodeIP != nodeIP {
+			continue
+		}
+
+		if epMgr.LookupPodName(cepFullName) != nil {
+			continue
+		}
+
+		// A young object may belong to an endpoint which has not been
+		// exposed via the endpoint manager yet. Leave it to a later
+		// audit run.
+		if time.Since(cep.CreationTimestamp.Time) < ciliumEndpointAuditGracePeriod {
+			continue
+		}
+
+		scopedLog.WithFields(logrus.Fields{
+			logfields.K8sPodName: cepFullName,
+			logfields.EndpointID: cep.Status.ID,
+		}).Info("Deleting stale CiliumEndpoint without local endpoint")
+		propagationPolicy := meta_v1.DeletePropagationBackground // because these are const strings but the API wants pointers
+		err := ciliumClient.CiliumEndpoints(cep.Namespace).Delete(ctx, cep.Name,
+			meta_v1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+		switch {
+		case err == nil:
+			metrics.KubernetesCiliumEndpointRepairs.WithLabelValues(repairActionDelete).Inc()
+		case k8serrors.IsNotFound(err):
+			// The object is already gone.
+		default:
+			scopedLog.WithError(err).Warning("Unable to delete stale CiliumEndpoint")
+			lastErr = err
+		}
+	}
+
+	for _, e := range epMgr.GetEndpoints() {
+		// Mirror the conditions under which the sync controller creates
+		// the CEP for an endpoint.
+		if e.HasLabels(pkgLabels.LabelHealth) {
+			continue
+		}
+		if e.GetK8sPodName() == "" || e.GetK8sNamespace() == "" || !e.HaveK8sMetadata() {
+			continue
+		}
+		if identity, err := e.GetSecurityIdentity(); err != nil || identity == nil {
+			continue
+		}
+		switch e.GetState() {
+		case endpoint.StateDisconnecting, endpoint.StateDisconnected:
+			continue
+		}
+
+		cepFullName := e.GetK8sNamespaceAndPodName()
+		if _, ok := cepNames[cepFullName]; ok {
+			continue
+		}
+
+		// Replacing the sync controller of the endpoint resets its
+		// local view of the CEP and recreates the object.
+		scopedLog.WithFields(logrus.Fields{
+			logfields.K8sPodName: cepFullName,
+			logfields.EndpointID: e.ID,
+		}).Info("Recreating missing CiliumEndpoint of local endpoint")
+		epMgr.RunK8sCiliumEndpointSync(e, conf)
+		metrics.KubernetesCiliumEndpointRepairs.WithLabelValues(repairActionRecreate).Inc()
+	}
+
+	return lastErr
+}
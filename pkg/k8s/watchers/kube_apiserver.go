@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package watchers
+
+import (
+	"github.com/cilium/cilium/pkg/identity"
+	"github.com/cilium/cilium/pkg/ipcache"
+	slim_corev1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/api/core/v1"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/source"
+)
+
+// kubeAPIServerEndpoints tracks the backend IPs of the kube-apiserver as
+// published in the kubernetes service endpoints of the default namespace.
+// The IPs are associated with the kube-apiserver reserved identity in the
+// ipcache so that policy rules can select the kube-apiserver entity, also
+// when the control plane runs outside of the cluster or behind a load
+// balancer.
+var kubeAPIServerEndpoints = struct {
+	lock.Mutex
+
+	// ips is the set of IPs currently associated with the kube-apiserver
+	// identity in the ipcache
+	ips map[string]struct{}
+}{
+	ips: map[string]struct{}{},
+}
+
+// isKubeAPIServerEndpoints returns true if the given endpoints object carries
+// the backends of the kube-apiserver
+func isKubeAPIServerEndpoints(ep *slim_corev1.Endpoints) bool {
+	return ep.Namespace == "default" && ep.Name == "kubernetes"
+}
+
+// updateKubeAPIServerEndpoints associates all backend IPs of the given
+// endpoints object with the kube-apiserver identity in the ipcache and
+// releases the association for IPs that are no longer listed. Passing nil
+// releases all associations.
+func updateKubeAPIServerEndpoints(ep *slim_corev1.Endpoints) {
+	desired := map[string]struct{}{}
+	if ep != nil {
+		for _, sub := range ep.Subsets {
+			for _, addr := range sub.Addresses {
+				desired[addr.IP] = struct{}{}
+			}
+		}
+	}
+
+	kubeAPIServerEndpoints.Lock()
+	defer kubeAPIServerEndpoints.Unlock()
+
+	for ip := range kubeAPIServerEndpoints.ips {
+		if _, ok := desired[ip]; !ok {
+			ipcache.IPIdentityCache.Delete(ip, source.KubeAPIServer)
+			delete(kubeAPIServerEndpoints.ips, ip)
+		}
+	}
+
+	for ip := range desired {
+		if _, ok := kubeAPIServerEndpoints.ips[ip]; ok {
+			continue
+		}
+		// The upsert is rejected if the IP is owned by the local node,
+		// in which case traffic to the kube-apiserver is already
+		// selectable via the host entity.
+		if _, err := ipcache.IPIdentityCache.Upsert(ip, nil, 0, nil, ipcache.Identity{
+			ID:     identity.ReservedIdentityKubeAPIServer,
+			Source: source.KubeAPIServer,
+		}); err == nil {
+			kubeAPIServerEndpoints.ips[ip] = struct{}{}
+		}
+	}
+}
@@ -38,7 +38,6 @@ import (
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/source"
-	"github.com/cilium/cilium/pkg/u8proto"
 
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
@@ -278,9 +277,10 @@ func (k *K8sWatcher) updateK8sPodV1(oldK8sPod, newK8sPod *slim_corev1.Pod) error
 	oldAnno := oldK8sPod.ObjectMeta.Annotations
 	newAnno := newK8sPod.ObjectMeta.Annotations
 	annoChangedProxy := !k8s.AnnotationsEqual([]string{annotation.ProxyVisibility}, oldAnno, newAnno)
-	annoChangedBandwidth := !k8s.AnnotationsEqual([]string{bandwidth.EgressBandwidth}, oldAnno, newAnno)
+	annoChangedBandwidth := !k8s.AnnotationsEqual([]string{bandwidth.EgressBandwidth, bandwidth.Priority}, oldAnno, newAnno)
 	annoChangedNoTrack := !k8s.AnnotationsEqual([]string{annotation.NoTrack}, oldAnno, newAnno)
-	annotationsChanged := annoChangedProxy || annoChangedBandwidth || annoChangedNoTrack
+	annoChangedMSSClamp := !k8s.AnnotationsEqual([]string{annotation.TCPMSSClamp}, oldAnno, newAnno)
+	annotationsChanged := annoChangedProxy || annoChangedBandwidth || annoChangedNoTrack || annoChangedMSSClamp
 
 	// Check label updates too.
 	oldPodLabels := oldK8sPod.ObjectMeta.Labels
@@ -351,6 +351,12 @@ func (k *K8sWatcher) updateK8sPodV1(oldK8sPod, newK8sPod *slim_corev1.Pod) error
 					return "", nil
 				}
 				return p.ObjectMeta.Annotations[bandwidth.EgressBandwidth], nil
+			}, func(ns, podName string) (priority string, err error) {
+				p, err := k.GetCachedPod(ns, podName)
+				if err != nil {
+					return "", nil
+				}
+				return p.ObjectMeta.Annotations[bandwidth.Priority], nil
 			})
 		}
 		if annoChangedNoTrack {
@@ -362,6 +368,15 @@ func (k *K8sWatcher) updateK8sPodV1(oldK8sPod, newK8sPod *slim_corev1.Pod) error
 				return p.ObjectMeta.Annotations[annotation.NoTrack], nil
 			})
 		}
+		if annoChangedMSSClamp {
+			podEP.UpdateMSSClampRules(func(ns, podName string) (mssClamp string, err error) {
+				p, err := k.GetCachedPod(ns, podName)
+				if err != nil {
+					return "", nil
+				}
+				return p.ObjectMeta.Annotations[annotation.TCPMSSClamp], nil
+			})
+		}
 		realizePodAnnotationUpdate(podEP)
 	}
 	return nil
@@ -749,19 +764,12 @@ func (k *K8sWatcher) updatePodHostData(oldPod, newPod *slim_corev1.Pod, oldPodIP
 			if port.Name == "" {
 				continue
 			}
-			p, err := u8proto.ParseProtocol(string(port.Protocol))
-			if err != nil {
-				return fmt.Errorf("ContainerPort: invalid protocol: %s", port.Protocol)
-			}
-			if port.ContainerPort < 1 || port.ContainerPort > 65535 {
-				return fmt.Errorf("ContainerPort: invalid port: %d", port.ContainerPort)
-			}
 			if k8sMeta.NamedPorts == nil {
 				k8sMeta.NamedPorts = make(policy.NamedPortMap)
 			}
-			k8sMeta.NamedPorts[port.Name] = policy.PortProto{
-				Port:  uint16(port.ContainerPort),
-				Proto: uint8(p),
+			err := k8sMeta.NamedPorts.AddPort(port.Name, int(port.ContainerPort), string(port.Protocol))
+			if err != nil {
+				return fmt.Errorf("ContainerPort: %s", err)
 			}
 		}
 	}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package watchers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cilium/cilium/pkg/lock"
+)
+
+// resyncProgressInterval is the interval at which the progress of an ongoing
+// event replay after a kube-apiserver reconnect is evaluated and reported.
+const resyncProgressInterval = 5 * time.Second
+
+// resyncScopeOrder lists the watched resources in dependency order. The
+// progress of the event replay after a kube-apiserver reconnect is reported
+// in this order.
+var resyncScopeOrder = []string{
+	metricNS,
+	metricNode,
+	metricCiliumNode,
+	metricPod,
+	metricCiliumEndpoint,
+	metricEndpoint,
+	metricEndpointSlice,
+	metricService,
+	metricKNP,
+	metricCNP,
+	metricCCNP,
+	metricCLRP,
+	metricCENP,
+}
+
+// resyncJournal records the Kubernetes events applied per resource so that
+// the delta of changes missed during a kube-apiserver disconnection can be
+// tracked while the informers replay it after the reconnect.
+type resyncJournal struct {
+	lock.Mutex
+
+	// counts is the number of events applied per resource since agent
+	// startup. Events that do not change the received object are not
+	// counted.
+	counts map[string]uint64
+
+	// replaying is true while a post-reconnect event replay is in progress
+	replaying bool
+
+	// reconnectedAt is the time the reconnect was detected
+	reconnectedAt time.Time
+
+	// snapshot is a copy of counts taken when the reconnect was detected,
+	// used to compute the replayed delta
+	snapshot map[string]uint64
+
+	// settled is a copy of counts taken at the previous progress interval,
+	// used to detect when the replay has settled
+	settled map[string]uint64
+}
+
+var journal = resyncJournal{counts: map[string]uint64{}}
+
+// recordEvent accounts an applied event in the resync journal
+func (j *resyncJournal) recordEvent(scope string) {
+	j.Lock()
+	j.counts[scope]++
+	j.Unlock()
+}
+
+// copyCounts returns a copy of the event counts. Must be called with the
+// journal lock held.
+func (j *resyncJournal) copyCounts() map[string]uint64 {
+	counts := make(map[string]uint64, len(j.counts))
+	for scope, count := range j.counts {
+		counts[scope] = count
+	}
+	return counts
+}
+
+// handleAPIServerReconnect marks the beginning of an event replay after the
+// client connections to the kube-apiserver have been restarted and starts
+// reporting its progress. The informers replay any changes missed during the
+// disconnection on their own once the watches have been re-established, the
+// journal only tracks how much of the delta has been applied.
+func (k *K8sWatcher) handleAPIServerReconnect() {
+	journal.Lock()
+	defer journal.Unlock()
+
+	if journal.replaying {
+		return
+	}
+	journal.replaying = true
+	journal.reconnectedAt = time.Now()
+	journal.snapshot = journal.copyCounts()
+	journal.settled = journal.copyCounts()
+
+	log.Info("Reconnected to kube-apiserver, replaying missed events")
+	go k.reportResyncProgress()
+}
+
+// reportResyncProgress reports the progress of an ongoing post-reconnect
+// event replay until no further events have been applied for a full progress
+// interval, then triggers a policy update to recompute policy once on top of
+// the fully applied delta.
+func (k *K8sWatcher) reportResyncProgress() {
+	for {
+		time.Sleep(resyncProgressInterval)
+
+		journal.Lock()
+		progress := make([]string, 0, len(resyncScopeOrder))
+		settled := true
+		for _, scope := range resyncScopeOrder {
+			if journal.counts[scope] != journal.settled[scope] {
+				settled = false
+			}
+			journal.settled[scope] = journal.counts[scope]
+			if delta := journal.counts[scope] - journal.snapshot[scope]; delta > 0 {
+				progress = append(progress, fmt.Sprintf("%s:%d", scope, delta))
+			}
+		}
+
+		if !settled {
+			log.Infof("Replaying events missed during kube-apiserver disconnection: %s",
+				strings.Join(progress, " "))
+			journal.Unlock()
+			continue
+		}
+
+		duration := time.Since(journal.reconnectedAt)
+		journal.replaying = false
+		journal.Unlock()
+
+		if len(progress) > 0 {
+			log.Infof("Replayed events missed during kube-apiserver disconnection in %s: %s",
+				duration.Round(time.Second), strings.Join(progress, " "))
+			k.policyManager.TriggerPolicyUpdates(true, "kube-apiserver event replay completed")
+		} else {
+			log.Info("No events were missed during kube-apiserver disconnection")
+		}
+		return
+	}
+}
@@ -61,6 +61,8 @@ const (
 	k8sAPIGroupCiliumEndpointV2                 = "cilium/v2::CiliumEndpoint"
 	k8sAPIGroupCiliumLocalRedirectPolicyV2      = "cilium/v2::CiliumLocalRedirectPolicy"
 	k8sAPIGroupCiliumEgressNATPolicyV2          = "cilium/v2::CiliumEgressNATPolicy"
+	k8sAPIGroupCiliumConnectivityCheckV2        = "cilium/v2::CiliumConnectivityCheck"
+	k8sAPIGroupCiliumConfigV2                   = "cilium/v2::CiliumConfig"
 	K8sAPIGroupEndpointSliceV1Beta1Discovery    = "discovery/v1beta1::EndpointSlice"
 	K8sAPIGroupEndpointSliceV1Discovery         = "discovery/v1::EndpointSlice"
 
@@ -74,6 +76,8 @@ const (
 	metricCiliumEndpoint = "CiliumEndpoint"
 	metricCLRP           = "CiliumLocalRedirectPolicy"
 	metricCENP           = "CiliumEgressNATPolicy"
+	metricCCC            = "CiliumConnectivityCheck"
+	metricCCFG           = "CiliumConfig"
 	metricPod            = "Pod"
 	metricNode           = "Node"
 	metricService        = "Service"
@@ -162,6 +166,12 @@ type egressPolicyManager interface {
 	OnDeleteEndpoint(endpoint *k8sTypes.CiliumEndpoint)
 }
 
+// configManager applies the hot-reloadable subset of the agent configuration
+// through the regular configuration reload path.
+type configManager interface {
+	ApplyHotConfig(options map[string]string, policyEnforcement string) error
+}
+
 type K8sWatcher struct {
 	// k8sResourceSynced maps a resource name to a channel. Once the given
 	// resource name is synchronized with k8s, the channel for which that
@@ -186,6 +196,7 @@ type K8sWatcher struct {
 	redirectPolicyManager redirectPolicyManager
 	bgpSpeakerManager     bgpSpeakerManager
 	egressPolicyManager   egressPolicyManager
+	configManager         configManager
 
 	// controllersStarted is a channel that is closed when all controllers, i.e.,
 	// k8s watchers have started listening for k8s events.
@@ -218,6 +229,7 @@ func NewK8sWatcher(
 	redirectPolicyManager redirectPolicyManager,
 	bgpSpeakerManager bgpSpeakerManager,
 	egressPolicyManager egressPolicyManager,
+	configManager configManager,
 	cfg WatcherConfiguration,
 ) *K8sWatcher {
 	return &K8sWatcher{
@@ -233,6 +245,7 @@ func NewK8sWatcher(
 		redirectPolicyManager: redirectPolicyManager,
 		bgpSpeakerManager:     bgpSpeakerManager,
 		egressPolicyManager:   egressPolicyManager,
+		configManager:         configManager,
 		cfg:                   cfg,
 	}
 }
@@ -376,6 +389,10 @@ func (k *K8sWatcher) EnableK8sWatcher(ctx context.Context) error {
 	}
 	log.Info("Enabling k8s event listener")
 
+	// Track events missed during kube-apiserver disconnections so that
+	// their replay can be reported after the reconnect.
+	k8s.SubscribeToReconnects(k.handleAPIServerReconnect)
+
 	ciliumNPClient := k8s.CiliumClient()
 	asyncControllers := &sync.WaitGroup{}
 
@@ -437,6 +454,12 @@ func (k *K8sWatcher) EnableK8sWatcher(ctx context.Context) error {
 		k.ciliumEgressNATPolicyInit(ciliumNPClient)
 	}
 
+	// cilium connectivity checks
+	k.ciliumConnectivityCheckInit(ciliumNPClient)
+
+	// cilium cluster-wide configuration
+	k.ciliumConfigInit(ciliumNPClient)
+
 	// kubernetes pods
 	asyncControllers.Add(1)
 	go k.podsInit(k8s.WatcherClient(), asyncControllers)
@@ -489,6 +512,8 @@ func (k *K8sWatcher) k8sServiceHandler() {
 				break
 			} else if result.NumToServicesRules > 0 {
 				// Only trigger policy updates if ToServices rules are in effect
+				scopedLog.WithField("toServicesBackends", result.NumToServicesBackends).
+					Debug("Updated backends of ToServices rules")
 				k.policyManager.TriggerPolicyUpdates(true, "Kubernetes service endpoint added")
 			}
 
@@ -508,6 +533,8 @@ func (k *K8sWatcher) k8sServiceHandler() {
 				break
 			} else if result.NumToServicesRules > 0 {
 				// Only trigger policy updates if ToServices rules are in effect
+				scopedLog.WithField("toServicesBackends", result.NumToServicesBackends).
+					Debug("Updated backends of ToServices rules")
 				k.policyManager.TriggerPolicyUpdates(true, "Kubernetes service endpoint deleted")
 			}
 		}
@@ -618,6 +645,7 @@ func genCartesianProduct(
 						IP:     parsedIP,
 						L4Addr: *backendPort,
 					},
+					Terminating: backend.Terminating,
 				})
 			}
 		}
@@ -709,6 +737,7 @@ func datapathSVCs(svc *k8s.Service, endpoints *k8s.Endpoints) (svcs []loadbalanc
 	// apply common service properties
 	for i := range svcs {
 		svcs[i].TrafficPolicy = svc.TrafficPolicy
+		svcs[i].LoadBalancerAlgorithm = svc.LoadBalancerAlgorithm
 		svcs[i].HealthCheckNodePort = svc.HealthCheckNodePort
 		svcs[i].SessionAffinity = svc.SessionAffinity
 		svcs[i].SessionAffinityTimeoutSec = svc.SessionAffinityTimeoutSec
@@ -771,6 +800,7 @@ func (k *K8sWatcher) addK8sSVCs(svcID k8s.ServiceID, oldSvc, svc *k8s.Service, e
 			Backends:                  dpSvc.Backends,
 			Type:                      dpSvc.Type,
 			TrafficPolicy:             dpSvc.TrafficPolicy,
+			LoadBalancerAlgorithm:     dpSvc.LoadBalancerAlgorithm,
 			SessionAffinity:           dpSvc.SessionAffinity,
 			SessionAffinityTimeoutSec: dpSvc.SessionAffinityTimeoutSec,
 			HealthCheckNodePort:       dpSvc.HealthCheckNodePort,
@@ -801,6 +831,10 @@ func (k *K8sWatcher) K8sEventReceived(scope string, action string, valid, equal
 	metrics.EventTSK8s.SetToCurrentTime()
 	k8smetrics.LastInteraction.Reset()
 
+	if valid && !equal {
+		journal.recordEvent(scope)
+	}
+
 	metrics.KubernetesEventReceived.WithLabelValues(scope, action, strconv.FormatBool(valid), strconv.FormatBool(equal)).Inc()
 }
 
@@ -40,6 +40,10 @@ func (in *Backend) DeepEqual(other *Backend) bool {
 		return false
 	}
 
+	if in.Terminating != other.Terminating {
+		return false
+	}
+
 	return true
 }
 
@@ -142,6 +146,9 @@ func (in *Service) deepEqual(other *Service) bool {
 	if in.TrafficPolicy != other.TrafficPolicy {
 		return false
 	}
+	if in.LoadBalancerAlgorithm != other.LoadBalancerAlgorithm {
+		return false
+	}
 	if in.HealthCheckNodePort != other.HealthCheckNodePort {
 		return false
 	}
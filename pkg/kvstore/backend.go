@@ -34,6 +34,17 @@ type ExtraOptions struct {
 
 	// NoLockQuorumCheck disables the lock acquisition quorum check
 	NoLockQuorumCheck bool
+
+	// Namespace is an additional prefix prepended to all keys to allow
+	// multiple logical clusters to share one kvstore without key
+	// collisions. All keys handed back to consumers are stripped of the
+	// namespace again.
+	Namespace string
+
+	// MigrateNamespace enables copying existing unnamespaced keys into the
+	// configured Namespace after the kvstore connection has been
+	// established
+	MigrateNamespace bool
 }
 
 // StatusCheckInterval returns the interval of status checks depending on the
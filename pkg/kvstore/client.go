@@ -21,12 +21,19 @@ var (
 
 func initClient(ctx context.Context, module backendModule, opts *ExtraOptions) error {
 	scopedLog := log.WithField(fieldKVStoreModule, module.getName())
-	c, errChan := module.newClient(ctx, opts)
-	if c == nil {
+	backend, errChan := module.newClient(ctx, opts)
+	if backend == nil {
 		err := <-errChan
 		scopedLog.WithError(err).Fatal("Unable to create kvstore client")
 	}
 
+	c := backend
+	if opts != nil && opts.Namespace != "" {
+		scopedLog = scopedLog.WithField(fieldNamespace, opts.Namespace)
+		scopedLog.Info("Namespacing kvstore keys")
+		c = namespaceBackend(backend, opts.Namespace)
+	}
+
 	defaultClient = c
 	select {
 	case <-defaultClientSet:
@@ -40,6 +47,13 @@ func initClient(ctx context.Context, module backendModule, opts *ExtraOptions) e
 		if isErr && err != nil {
 			scopedLog.WithError(err).Fatal("Unable to connect to kvstore")
 		}
+		if opts != nil && opts.Namespace != "" && opts.MigrateNamespace {
+			// The migration runs on the unwrapped backend as it
+			// copies keys from outside of the namespace into it.
+			if err := migrateNamespaceKeys(ctx, backend, opts.Namespace); err != nil {
+				scopedLog.WithError(err).Fatal("Unable to migrate kvstore keys into namespace")
+			}
+		}
 		if !option.Config.JoinCluster {
 			deleteLegacyPrefixes(ctx)
 		}
@@ -154,6 +154,9 @@ func (c *consulModule) connectConsulClient(ctx context.Context, opts *ExtraOptio
 		c.config.Address = addr
 
 	}
+	if err := validateIPv6OnlyEndpoints([]string{c.config.Address}); err != nil {
+		return nil, err
+	}
 	client, err := newConsulClient(ctx, c.config, opts)
 	if err != nil {
 		return nil, err
@@ -9,6 +9,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"strconv"
@@ -159,6 +160,30 @@ func (e *etcdModule) getConfig() map[string]string {
 	return getOpts(e.opts)
 }
 
+// validateIPv6OnlyEndpoints returns an error if any of the given endpoints
+// refers to an IPv4 address while the control plane is restricted to IPv6.
+// Failing at connect time surfaces a misconfigured endpoint immediately
+// instead of leaving the client in an endless dial loop.
+func validateIPv6OnlyEndpoints(endpoints []string) error {
+	if !option.Config.ControlPlaneIPv6Only {
+		return nil
+	}
+	for _, ep := range endpoints {
+		host := ep
+		if u, err := url.Parse(ep); err == nil && u.Host != "" {
+			host = u.Host
+		}
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if ip := net.ParseIP(host); ip != nil && ip.To4() != nil {
+			return fmt.Errorf("kvstore endpoint %q is an IPv4 address, but %s is enabled",
+				ep, option.ControlPlaneIPv6Only)
+		}
+	}
+	return nil
+}
+
 func shuffleEndpoints(endpoints []string) {
 	randGen.Shuffle(len(endpoints), func(i, j int) {
 		endpoints[i], endpoints[j] = endpoints[j], endpoints[i]
@@ -660,6 +685,10 @@ func connectEtcdClient(ctx context.Context, config *client.Config, cfgPath strin
 		config = cfg
 	}
 
+	if err := validateIPv6OnlyEndpoints(config.Endpoints); err != nil {
+		return nil, err
+	}
+
 	// Shuffle the order of endpoints to avoid all agents connecting to the
 	// same etcd endpoint and to work around etcd client library failover
 	// bugs. (https://github.com/etcd-io/etcd/pull/9860)
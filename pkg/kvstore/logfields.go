@@ -43,4 +43,7 @@ const (
 
 	// fieldEtcdEndpoint is the etcd endpoint we talk to
 	fieldEtcdEndpoint = "etcdEndpoint"
+
+	// fieldNamespace is the configured kvstore key namespace
+	fieldNamespace = "namespace"
 )
@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package kvstore
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// namespacedBackend wraps a kvstore backend and prefixes all keys with a
+// configurable namespace, so that multiple logical clusters can share one
+// kvstore without key collisions. Keys reported back to consumers, e.g. in
+// watch events or list results, are stripped of the namespace again, making
+// the namespacing transparent to all kvstore users.
+type namespacedBackend struct {
+	BackendOperations
+
+	// prefix is the namespace prefix including the trailing slash
+	prefix string
+}
+
+// namespaceBackend wraps the given backend to prefix all keys with the given
+// namespace. The backend is returned unmodified when the namespace is empty.
+func namespaceBackend(backend BackendOperations, namespace string) BackendOperations {
+	namespace = strings.Trim(namespace, "/")
+	if namespace == "" {
+		return backend
+	}
+
+	return &namespacedBackend{
+		BackendOperations: backend,
+		prefix:            namespace + "/",
+	}
+}
+
+func (n *namespacedBackend) key(key string) string {
+	return n.prefix + key
+}
+
+func (n *namespacedBackend) trim(key string) string {
+	return strings.TrimPrefix(key, n.prefix)
+}
+
+func (n *namespacedBackend) LockPath(ctx context.Context, path string) (KVLocker, error) {
+	return n.BackendOperations.LockPath(ctx, n.key(path))
+}
+
+func (n *namespacedBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	return n.BackendOperations.Get(ctx, n.key(key))
+}
+
+func (n *namespacedBackend) GetIfLocked(ctx context.Context, key string, lock KVLocker) ([]byte, error) {
+	return n.BackendOperations.GetIfLocked(ctx, n.key(key), lock)
+}
+
+func (n *namespacedBackend) GetPrefix(ctx context.Context, prefix string) (string, []byte, error) {
+	key, value, err := n.BackendOperations.GetPrefix(ctx, n.key(prefix))
+	return n.trim(key), value, err
+}
+
+func (n *namespacedBackend) GetPrefixIfLocked(ctx context.Context, prefix string, lock KVLocker) (string, []byte, error) {
+	key, value, err := n.BackendOperations.GetPrefixIfLocked(ctx, n.key(prefix), lock)
+	return n.trim(key), value, err
+}
+
+func (n *namespacedBackend) Set(ctx context.Context, key string, value []byte) error {
+	return n.BackendOperations.Set(ctx, n.key(key), value)
+}
+
+func (n *namespacedBackend) Delete(ctx context.Context, key string) error {
+	return n.BackendOperations.Delete(ctx, n.key(key))
+}
+
+func (n *namespacedBackend) DeleteIfLocked(ctx context.Context, key string, lock KVLocker) error {
+	return n.BackendOperations.DeleteIfLocked(ctx, n.key(key), lock)
+}
+
+func (n *namespacedBackend) DeletePrefix(ctx context.Context, path string) error {
+	return n.BackendOperations.DeletePrefix(ctx, n.key(path))
+}
+
+func (n *namespacedBackend) Update(ctx context.Context, key string, value []byte, lease bool) error {
+	return n.BackendOperations.Update(ctx, n.key(key), value, lease)
+}
+
+func (n *namespacedBackend) UpdateIfLocked(ctx context.Context, key string, value []byte, lease bool, lock KVLocker) error {
+	return n.BackendOperations.UpdateIfLocked(ctx, n.key(key), value, lease, lock)
+}
+
+func (n *namespacedBackend) UpdateIfDifferent(ctx context.Context, key string, value []byte, lease bool) (bool, error) {
+	return n.BackendOperations.UpdateIfDifferent(ctx, n.key(key), value, lease)
+}
+
+func (n *namespacedBackend) UpdateIfDifferentIfLocked(ctx context.Context, key string, value []byte, lease bool, lock KVLocker) (bool, error) {
+	return n.BackendOperations.UpdateIfDifferentIfLocked(ctx, n.key(key), value, lease, lock)
+}
+
+func (n *namespacedBackend) CreateOnly(ctx context.Context, key string, value []byte, lease bool) (bool, error) {
+	return n.BackendOperations.CreateOnly(ctx, n.key(key), value, lease)
+}
+
+func (n *namespacedBackend) CreateOnlyIfLocked(ctx context.Context, key string, value []byte, lease bool, lock KVLocker) (bool, error) {
+	return n.BackendOperations.CreateOnlyIfLocked(ctx, n.key(key), value, lease, lock)
+}
+
+func (n *namespacedBackend) CreateIfExists(ctx context.Context, condKey, key string, value []byte, lease bool) error {
+	return n.BackendOperations.CreateIfExists(ctx, n.key(condKey), n.key(key), value, lease)
+}
+
+func (n *namespacedBackend) ListPrefix(ctx context.Context, prefix string) (KeyValuePairs, error) {
+	pairs, err := n.BackendOperations.ListPrefix(ctx, n.key(prefix))
+	return n.trimPairs(pairs), err
+}
+
+func (n *namespacedBackend) ListPrefixIfLocked(ctx context.Context, prefix string, lock KVLocker) (KeyValuePairs, error) {
+	pairs, err := n.BackendOperations.ListPrefixIfLocked(ctx, n.key(prefix), lock)
+	return n.trimPairs(pairs), err
+}
+
+func (n *namespacedBackend) trimPairs(pairs KeyValuePairs) KeyValuePairs {
+	trimmed := make(KeyValuePairs, len(pairs))
+	for key, value := range pairs {
+		trimmed[n.trim(key)] = value
+	}
+	return trimmed
+}
+
+// forwardEvents proxies the events of the namespaced inner watcher to the
+// watcher handed out to the consumer, stripping the namespace from the event
+// keys. Stopping the outer watcher stops the inner watcher and vice versa.
+func (n *namespacedBackend) forwardEvents(w, inner *Watcher) {
+	go func() {
+		<-w.stopWatch
+		inner.Stop()
+	}()
+	go func() {
+		for event := range inner.Events {
+			event.Key = n.trim(event.Key)
+			select {
+			case w.Events <- event:
+			case <-w.stopWatch:
+				// Drop the event, the watcher is being stopped and the
+				// inner watcher is drained until it is closed.
+			}
+		}
+		close(w.Events)
+		w.stopWait.Done()
+	}()
+}
+
+func (n *namespacedBackend) Watch(ctx context.Context, w *Watcher) {
+	inner := newWatcher(w.Name, n.key(w.Prefix), cap(w.Events))
+	n.forwardEvents(w, inner)
+	n.BackendOperations.Watch(ctx, inner)
+}
+
+func (n *namespacedBackend) ListAndWatch(ctx context.Context, name, prefix string, chanSize int) *Watcher {
+	w := newWatcher(name, prefix, chanSize)
+	inner := n.BackendOperations.ListAndWatch(ctx, name, n.key(prefix), chanSize)
+	n.forwardEvents(w, inner)
+	return w
+}
+
+// migrateNamespaceKeys copies all keys below the unnamespaced base prefix
+// into the configured namespace of the given backend. Keys which already
+// exist in the namespace are left untouched, so the migration is idempotent
+// and can run on every agent start. The unnamespaced keys are kept for the
+// old consumers until the operator removes them.
+func migrateNamespaceKeys(ctx context.Context, backend BackendOperations, namespace string) error {
+	namespace = strings.Trim(namespace, "/")
+
+	pairs, err := backend.ListPrefix(ctx, BaseKeyPrefix)
+	if err != nil {
+		return err
+	}
+
+	migrated := 0
+	for key, value := range pairs {
+		created, err := backend.CreateOnly(ctx, namespace+"/"+key, value.Data, false)
+		if err != nil {
+			return err
+		}
+		if created {
+			migrated++
+		}
+	}
+
+	if migrated > 0 {
+		log.WithFields(logrus.Fields{
+			fieldNumEntries: migrated,
+			fieldNamespace:  namespace,
+		}).Info("Migrated kvstore keys into namespace")
+	}
+	return nil
+}
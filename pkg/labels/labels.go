@@ -49,6 +49,11 @@ const (
 	// IDNameUnknown is the label used to to identify an endpoint with an
 	// unknown identity.
 	IDNameUnknown = "unknown"
+
+	// IDNameKubeAPIServer is the label used to identify the kube-apiserver. It
+	// is part of the reserved identity 7 and it is also used in conjunction
+	// with IDNameHost if the kube-apiserver is running on the local host.
+	IDNameKubeAPIServer = "kube-apiserver"
 )
 
 var (
@@ -57,6 +62,13 @@ var (
 
 	// LabelHost is the label used for the host endpoint.
 	LabelHost = Labels{IDNameHost: NewLabel(IDNameHost, "", LabelSourceReserved)}
+
+	// LabelKubeAPIServer is the label used for the kube-apiserver. See comment
+	// on IDNameKubeAPIServer.
+	LabelKubeAPIServer = Labels{IDNameKubeAPIServer: NewLabel(IDNameKubeAPIServer, "", LabelSourceReserved)}
+
+	// LabelRemoteNode is the label used for remote nodes.
+	LabelRemoteNode = Labels{IDNameRemoteNode: NewLabel(IDNameRemoteNode, "", LabelSourceReserved)}
 )
 
 const (
@@ -254,6 +254,15 @@ func (cfg *labelPrefixCfg) filterLabels(lbls labels.Labels) (identityLabels, inf
 	identityLabels = labels.Labels{}
 	informationLabels = labels.Labels{}
 	for k, v := range lbls {
+		// Labels matching a redaction pattern are never relevant for
+		// the identity and only leave the ingestion path with their
+		// value redacted.
+		if isRedactedKey(v.Key) {
+			v.Value = RedactedValue
+			informationLabels[k] = v
+			continue
+		}
+
 		included, ignored := 0, 0
 
 		for _, p := range cfg.LabelPrefixes {
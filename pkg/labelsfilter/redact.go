@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package labelsfilter
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/lock"
+)
+
+// RedactedValue replaces the value of labels matching a configured redaction
+// pattern before the label leaves the ingestion path.
+const RedactedValue = "<redacted>"
+
+var (
+	redactedLabelKeysMU lock.RWMutex
+	redactedLabelKeys   []*regexp.Regexp
+)
+
+// ParseRedactedLabelKeys compiles the configured label redaction patterns.
+// Each pattern is a regular expression matched against label keys; matching
+// labels are excluded from identity evaluation and only appear with their
+// value redacted in the API and in Hubble flows.
+func ParseRedactedLabelKeys(patterns []string) error {
+	exprs := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		expr, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("unable to compile redaction pattern %q: %s", pattern, err)
+		}
+		exprs = append(exprs, expr)
+	}
+
+	redactedLabelKeysMU.Lock()
+	redactedLabelKeys = exprs
+	redactedLabelKeysMU.Unlock()
+
+	if len(exprs) > 0 {
+		log.Infof("Redacting values of labels with keys matching: %v", patterns)
+	}
+	return nil
+}
+
+// isRedactedKey returns whether the label key matches one of the configured
+// redaction patterns.
+func isRedactedKey(key string) bool {
+	redactedLabelKeysMU.RLock()
+	defer redactedLabelKeysMU.RUnlock()
+
+	for _, expr := range redactedLabelKeys {
+		if expr.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactedKeys returns the keys of the given labels whose value has been
+// redacted at ingestion time, allowing callers to audit which endpoints
+// carry sensitive labels.
+func RedactedKeys(lbls labels.Labels) []string {
+	var keys []string
+	for _, lbl := range lbls {
+		if lbl.Value == RedactedValue {
+			keys = append(keys, lbl.Key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package labelsfilter
+
+import (
+	"github.com/cilium/cilium/pkg/labels"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *LabelsPrefCfgSuite) TestRedactedLabelKeys(c *C) {
+	defer func() {
+		c.Assert(ParseRedactedLabelKeys(nil), IsNil)
+	}()
+
+	err := ParseLabelPrefixCfg(nil, "")
+	c.Assert(err, IsNil)
+	err = ParseRedactedLabelKeys([]string{"user-id", ".*token.*"})
+	c.Assert(err, IsNil)
+
+	lbls := labels.Map2Labels(map[string]string{
+		"app":            "web",
+		"user-id":        "4321",
+		"borrowed-token": "hunter2",
+	}, labels.LabelSourceK8s)
+
+	identityLabels, informationLabels := Filter(lbls)
+
+	// Redacted labels are not identity-relevant and only appear in the
+	// information labels with their value redacted.
+	c.Assert(len(identityLabels), Equals, 1)
+	c.Assert(identityLabels["app"].Value, Equals, "web")
+	c.Assert(informationLabels["user-id"].Value, Equals, RedactedValue)
+	c.Assert(informationLabels["borrowed-token"].Value, Equals, RedactedValue)
+
+	keys := RedactedKeys(informationLabels)
+	c.Assert(len(keys), Equals, 2)
+
+	// Invalid patterns are rejected.
+	err = ParseRedactedLabelKeys([]string{"["})
+	c.Assert(err, NotNil)
+}
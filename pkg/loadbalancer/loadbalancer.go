@@ -35,6 +35,25 @@ const (
 	SVCTrafficPolicyLocal   = SVCTrafficPolicy("Local")
 )
 
+// SVCLoadBalancerAlgorithm defines which algorithm is used for backend
+// selection for a service
+type SVCLoadBalancerAlgorithm string
+
+const (
+	// SVCLoadBalancerAlgorithmDefault delegates backend selection to the
+	// globally configured load balancing algorithm
+	SVCLoadBalancerAlgorithmDefault = SVCLoadBalancerAlgorithm("")
+	// SVCLoadBalancerAlgorithmRandom selects backends randomly
+	SVCLoadBalancerAlgorithmRandom = SVCLoadBalancerAlgorithm("random")
+	// SVCLoadBalancerAlgorithmMaglev selects backends via Maglev consistent
+	// hashing
+	SVCLoadBalancerAlgorithmMaglev = SVCLoadBalancerAlgorithm("maglev")
+	// SVCLoadBalancerAlgorithmLatency selects backends via Maglev consistent
+	// hashing with backend weights derived from observed latency and
+	// connection failures
+	SVCLoadBalancerAlgorithmLatency = SVCLoadBalancerAlgorithm("latency")
+)
+
 // ServiceFlags is the datapath representation of the service flags that can be
 // used (lb{4,6}_service.flags)
 type ServiceFlags uint16
@@ -190,6 +209,10 @@ type Backend struct {
 	// a node.
 	NodeName string
 	L3n4Addr
+	// Terminating indicates that the backend is shutting down. Terminating
+	// backends are excluded from backend selection but kept in the backend
+	// map so that established connections drain instead of being reset.
+	Terminating bool
 }
 
 func (b *Backend) String() string {
@@ -198,10 +221,11 @@ func (b *Backend) String() string {
 
 // SVC is a structure for storing service details.
 type SVC struct {
-	Frontend                  L3n4AddrID       // SVC frontend addr and an allocated ID
-	Backends                  []Backend        // List of service backends
-	Type                      SVCType          // Service type
-	TrafficPolicy             SVCTrafficPolicy // Service traffic policy
+	Frontend                  L3n4AddrID               // SVC frontend addr and an allocated ID
+	Backends                  []Backend                // List of service backends
+	Type                      SVCType                  // Service type
+	TrafficPolicy             SVCTrafficPolicy         // Service traffic policy
+	LoadBalancerAlgorithm     SVCLoadBalancerAlgorithm // Service backend selection algorithm
 	SessionAffinity           bool
 	SessionAffinityTimeoutSec uint32
 	HealthCheckNodePort       uint16 // Service health check node port
@@ -277,6 +277,9 @@ const (
 	// CiliumEgressNATPolicyName is the name of a CiliumEgressNATPolicy
 	CiliumEgressNATPolicyName = "ciliumEgressNATPolicyName"
 
+	// CiliumConnectivityCheckName is the name of a CiliumConnectivityCheck
+	CiliumConnectivityCheckName = "ciliumConnectivityCheckName"
+
 	// BPFMapKey is a key from a BPF map
 	BPFMapKey = "bpfMapKey"
 
@@ -108,6 +108,17 @@ func getPermutation(backends []string, m uint64, numCPU int) []uint64 {
 // GetLookupTable returns the Maglev lookup table of the size "m" for the given
 // backends. The lookup table contains the indices of the given backends.
 func GetLookupTable(backends []string, m uint64) []int {
+	return getLookupTable(backends, nil, m)
+}
+
+// GetWeightedLookupTable returns the Maglev lookup table of the size "m" for
+// the given backends, with the table entries distributed between the backends
+// proportionally to the given weights. A weight of zero is treated as one.
+func GetWeightedLookupTable(backends []string, weights []uint16, m uint64) []int {
+	return getLookupTable(backends, weights, m)
+}
+
+func getLookupTable(backends []string, weights []uint16, m uint64) []int {
 	if len(backends) == 0 {
 		return nil
 	}
@@ -122,8 +133,28 @@ func GetLookupTable(backends []string, m uint64) []int {
 
 	l := len(backends)
 
+	// With weights, the backends take turns in filling the table
+	// proportionally to their weight instead of round-robin.
+	var turns []int
+	if weights != nil {
+		for i := range backends {
+			w := int(weights[i])
+			if w == 0 {
+				w = 1
+			}
+			for t := 0; t < w; t++ {
+				turns = append(turns, i)
+			}
+		}
+	}
+
 	for n := uint64(0); n < m; n++ {
-		i := int(n) % l
+		var i int
+		if turns != nil {
+			i = turns[int(n)%len(turns)]
+		} else {
+			i = int(n) % l
+		}
 		c := perm[i*int(m)+next[i]]
 		for entry[c] >= 0 {
 			next[i] += 1
@@ -78,6 +78,26 @@ func (s *MaglevTestSuite) TestBackendRemoval(c *C) {
 	c.Assert(float64(changesInExistingBackends)/float64(m)*float64(100) < 1.0, Equals, true)
 }
 
+func (s *MaglevTestSuite) TestWeightedLookupTable(c *C) {
+	m := uint64(1021)
+	backends := []string{"one", "two", "three"}
+
+	// Equal weights must yield the same table as the unweighted variant.
+	equal := GetWeightedLookupTable(backends, []uint16{1, 1, 1}, m)
+	c.Assert(equal, checker.DeepEquals, GetLookupTable(backends, m))
+
+	// A backend with a higher weight must occupy proportionally more slots.
+	weighted := GetWeightedLookupTable(backends, []uint16{4, 1, 1}, m)
+	count := make([]int, len(backends))
+	for _, backend := range weighted {
+		count[backend]++
+	}
+	c.Assert(count[0] > 2*count[1], Equals, true)
+	c.Assert(count[0] > 2*count[2], Equals, true)
+	c.Assert(count[1] > 0, Equals, true)
+	c.Assert(count[2] > 0, Equals, true)
+}
+
 func (s *MaglevTestSuite) BenchmarkGetMaglevTable(c *C) {
 	backendCount := 1000
 	m := uint64(131071)
@@ -37,13 +37,14 @@ type EdtInfo struct {
 	Bps             uint64    `align:"bps"`
 	TimeLast        uint64    `align:"t_last"`
 	TimeHorizonDrop uint64    `align:"t_horizon_drop"`
-	Pad             [4]uint64 `align:"pad"`
+	Prio            uint64    `align:"prio"`
+	Pad             [3]uint64 `align:"pad"`
 }
 
 func (v *EdtInfo) GetValuePtr() unsafe.Pointer { return unsafe.Pointer(v) }
 func (v *EdtInfo) String() string              { return fmt.Sprintf("%d", int(v.Bps)) }
 func (v *EdtInfo) DeepCopyMapValue() bpf.MapValue {
-	return &EdtInfo{v.Bps, v.TimeLast, v.TimeHorizonDrop, v.Pad}
+	return &EdtInfo{v.Bps, v.TimeLast, v.TimeHorizonDrop, v.Prio, v.Pad}
 }
 
 var ThrottleMap = bpf.NewMap(
@@ -56,10 +57,10 @@ var ThrottleMap = bpf.NewMap(
 	bpf.ConvertKeyValue,
 ).WithCache().WithPressureMetric()
 
-func Update(Id uint16, Bps uint64) error {
+func Update(Id uint16, Bps uint64, Prio uint32) error {
 	return ThrottleMap.Update(
 		&EdtId{Id: uint64(Id)},
-		&EdtInfo{Bps: Bps, TimeHorizonDrop: uint64(DefaultDropHorizon)})
+		&EdtInfo{Bps: Bps, Prio: uint64(Prio), TimeHorizonDrop: uint64(DefaultDropHorizon)})
 }
 
 func Delete(Id uint16) error {
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package clustermetricsmap
+
+import (
+	"context"
+	"strconv"
+	"unsafe"
+
+	"github.com/cilium/cilium/pkg/ebpf"
+	"github.com/cilium/cilium/pkg/logging"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/maps/metricsmap"
+	"github.com/cilium/cilium/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// IterateCallback represents the signature of the callback function expected
+// by the IterateWithCallback method, which in turn is used to iterate all the
+// keys/values of a cluster metrics map.
+type IterateCallback func(*Key, *Values)
+
+// ClusterMetricsMap interface represents a cluster metrics map, and can be
+// reused to implement mock maps for unit tests.
+type ClusterMetricsMap interface {
+	IterateWithCallback(IterateCallback) error
+}
+
+type clusterMetricsMap struct {
+	*ebpf.Map
+}
+
+var (
+	// ClusterMetrics is the bpf cluster metrics map
+	ClusterMetrics clusterMetricsMap
+	log            = logging.DefaultLogger.WithField(logfields.LogSubsys, "map-cluster-metrics")
+)
+
+const (
+	// MapName for the cluster metrics map.
+	MapName = "cilium_cluster_metrics"
+	// MaxEntries is the maximum number of keys that can be present in the
+	// cluster metrics map: 2**8 cluster IDs for each of the two
+	// directions.
+	MaxEntries = 512
+)
+
+// Key must be in sync with struct cluster_metrics_key in
+// <bpf/lib/common.h>
+type Key struct {
+	ClusterID uint32   `align:"cluster_id"`
+	Dir       uint8    `align:"dir"`
+	Pad       [3]uint8 `align:"pad"`
+}
+
+// Value must be in sync with struct metrics_value in <bpf/lib/common.h>
+type Value struct {
+	Count uint64 `align:"count"`
+	Bytes uint64 `align:"bytes"`
+}
+
+// Values is a slice of Values
+type Values []Value
+
+// IterateWithCallback iterates through all the keys/values of a cluster
+// metrics map, passing each key/value pair to the cb callback
+func (m clusterMetricsMap) IterateWithCallback(cb IterateCallback) error {
+	return m.Map.IterateWithCallback(&Key{}, &Values{}, func(k, v interface{}) {
+		key := k.(*Key)
+		values := v.(*Values)
+
+		cb(key, values)
+	})
+}
+
+// Direction gets the direction in human readable string format
+func (k *Key) Direction() string {
+	return metricsmap.MetricDirection(k.Dir)
+}
+
+// Count returns the sum of all the per-CPU count values
+func (vs Values) Count() uint64 {
+	c := uint64(0)
+	for _, v := range vs {
+		c += v.Count
+	}
+
+	return c
+}
+
+// Bytes returns the sum of all the per-CPU bytes values
+func (vs Values) Bytes() uint64 {
+	b := uint64(0)
+	for _, v := range vs {
+		b += v.Bytes
+	}
+
+	return b
+}
+
+func updateMetric(getCounter func() (prometheus.Counter, error), newValue float64) {
+	counter, err := getCounter()
+	if err != nil {
+		log.WithError(err).Warn("Failed to update prometheus metrics")
+		return
+	}
+
+	oldValue := metrics.GetCounterValue(counter)
+	if newValue > oldValue {
+		counter.Add(newValue - oldValue)
+	}
+}
+
+// updatePrometheusMetrics updates the prometheus metrics of the cluster the
+// key refers to with the given values.
+func updatePrometheusMetrics(key *Key, values *Values) {
+	clusterID := strconv.FormatUint(uint64(key.ClusterID), 10)
+
+	updateMetric(func() (prometheus.Counter, error) {
+		return metrics.ClusterTrafficCount.GetMetricWithLabelValues(clusterID, key.Direction())
+	}, float64(values.Count()))
+
+	updateMetric(func() (prometheus.Counter, error) {
+		return metrics.ClusterTrafficBytes.GetMetricWithLabelValues(clusterID, key.Direction())
+	}, float64(values.Bytes()))
+}
+
+// SyncClusterMetricsMap is called periodically to sync the cluster metrics
+// map into the per-remote-cluster traffic counters of the prometheus server.
+func SyncClusterMetricsMap(ctx context.Context) error {
+	return ClusterMetrics.IterateWithCallback(func(key *Key, values *Values) {
+		updatePrometheusMetrics(key, values)
+	})
+}
+
+func init() {
+	ClusterMetrics.Map = ebpf.NewMap(&ebpf.MapSpec{
+		Name:       MapName,
+		Type:       ebpf.PerCPUHash,
+		KeySize:    uint32(unsafe.Sizeof(Key{})),
+		ValueSize:  uint32(unsafe.Sizeof(Value{})),
+		MaxEntries: MaxEntries,
+		Pinning:    ebpf.PinByName,
+	})
+}
@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+// Package clustermetricsmap represents the BPF cluster metrics map in the BPF
+// programs. It is implemented as a hash table containing packet and byte
+// counts of traffic exchanged with endpoints of remote clusters, keyed by the
+// cluster ID of the remote peer and direction.
+// +groupName=maps
+package clustermetricsmap
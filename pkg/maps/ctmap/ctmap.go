@@ -124,6 +124,17 @@ type CtMapRecord struct {
 	Value CtEntry
 }
 
+// localMapEntries is the number of entries in each local (per-endpoint) CT
+// map. May be overridden with SetLocalMapEntries() before InitMapInfo() to
+// size CT map partitions of high-churn endpoints.
+var localMapEntries = mapNumEntriesLocal
+
+// SetLocalMapEntries overrides the number of entries in local (per-endpoint)
+// CT maps. Must be called before InitMapInfo().
+func SetLocalMapEntries(entries int) {
+	localMapEntries = entries
+}
+
 func setupMapInfo(m mapType, define string, mapKey bpf.MapKey, keySize int, maxEntries int, nat *nat.Map) {
 	mapInfo[m] = mapAttributes{
 		bpfDefine: define,
@@ -160,11 +171,11 @@ func InitMapInfo(tcpMaxEntries, anyMaxEntries int, v4, v6, nodeport bool) {
 
 	setupMapInfo(mapTypeIPv4TCPLocal, "CT_MAP_TCP4",
 		&CtKey4{}, int(unsafe.Sizeof(CtKey4{})),
-		mapNumEntriesLocal, natMaps[mapTypeIPv4TCPLocal])
+		localMapEntries, natMaps[mapTypeIPv4TCPLocal])
 
 	setupMapInfo(mapTypeIPv6TCPLocal, "CT_MAP_TCP6",
 		&CtKey6{}, int(unsafe.Sizeof(CtKey6{})),
-		mapNumEntriesLocal, natMaps[mapTypeIPv6TCPLocal])
+		localMapEntries, natMaps[mapTypeIPv6TCPLocal])
 
 	setupMapInfo(mapTypeIPv4TCPGlobal, "CT_MAP_TCP4",
 		&CtKey4Global{}, int(unsafe.Sizeof(CtKey4Global{})),
@@ -176,11 +187,11 @@ func InitMapInfo(tcpMaxEntries, anyMaxEntries int, v4, v6, nodeport bool) {
 
 	setupMapInfo(mapTypeIPv4AnyLocal, "CT_MAP_ANY4",
 		&CtKey4{}, int(unsafe.Sizeof(CtKey4{})),
-		mapNumEntriesLocal, natMaps[mapTypeIPv4AnyLocal])
+		localMapEntries, natMaps[mapTypeIPv4AnyLocal])
 
 	setupMapInfo(mapTypeIPv6AnyLocal, "CT_MAP_ANY6",
 		&CtKey6{}, int(unsafe.Sizeof(CtKey6{})),
-		mapNumEntriesLocal, natMaps[mapTypeIPv6AnyLocal])
+		localMapEntries, natMaps[mapTypeIPv6AnyLocal])
 
 	setupMapInfo(mapTypeIPv4AnyGlobal, "CT_MAP_ANY4",
 		&CtKey4Global{}, int(unsafe.Sizeof(CtKey4Global{})),
@@ -241,6 +252,15 @@ type EmitCTEntryCBFunc func(srcIP, dstIP net.IP, srcPort, dstPort uint16, nextHd
 // ct entries in m to a string. If clockSource is not nil, it uses it to
 // compute the time difference of each entry from now and prints that too.
 func DumpEntriesWithTimeDiff(m CtMap, clockSource *models.ClockSource) (string, error) {
+	return DumpEntriesWithFilter(m, clockSource, nil, 0, 0)
+}
+
+// DumpEntriesWithFilter is similar to DumpEntriesWithTimeDiff, but only
+// formats the entries accepted by filter within the window described by
+// offset and limit. This bounds the amount of memory required to dump a
+// large map. A nil filter accepts all entries, a limit of 0 disables the
+// limit.
+func DumpEntriesWithFilter(m CtMap, clockSource *models.ClockSource, filter bpf.DumpFilter, offset, limit int) (string, error) {
 	var toRemSecs func(uint32) string
 
 	if clockSource == nil {
@@ -284,13 +304,31 @@ func DumpEntriesWithTimeDiff(m CtMap, clockSource *models.ClockSource) (string,
 		sb.WriteString(value.StringWithTimeDiff(toRemSecs))
 	}
 	// DumpWithCallback() must be called before sb.String().
-	err := m.DumpWithCallback(cb)
+	err := m.DumpWithCallback(bpf.PaginatedCallback(filter, offset, limit, cb))
 	if err != nil {
 		return "", err
 	}
 	return sb.String(), err
 }
 
+// FilterByIP returns a dump filter which accepts entries with ip as source or
+// destination address.
+func FilterByIP(ip net.IP) bpf.DumpFilter {
+	return func(key bpf.MapKey, _ bpf.MapValue) bool {
+		switch k := key.(type) {
+		case *CtKey4:
+			return k.SourceAddr.IP().Equal(ip) || k.DestAddr.IP().Equal(ip)
+		case *CtKey4Global:
+			return k.SourceAddr.IP().Equal(ip) || k.DestAddr.IP().Equal(ip)
+		case *CtKey6:
+			return k.SourceAddr.IP().Equal(ip) || k.DestAddr.IP().Equal(ip)
+		case *CtKey6Global:
+			return k.SourceAddr.IP().Equal(ip) || k.DestAddr.IP().Equal(ip)
+		}
+		return true
+	}
+}
+
 // DoDumpEntries iterates through Map m and writes the values of the ct entries
 // in m to a string.
 func DoDumpEntries(m CtMap) (string, error) {
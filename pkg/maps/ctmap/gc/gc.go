@@ -38,6 +38,7 @@ func Enable(ipv4, ipv6 bool, restoredEndpoints []*endpoint.Endpoint, mgr Endpoin
 
 	go func() {
 		var wakeup = make(chan signal.SignalData)
+		var lastPartitionGC time.Time
 		ipv4Orig := ipv4
 		ipv6Orig := ipv6
 		triggeredBySignal := false
@@ -85,6 +86,14 @@ func Enable(ipv4, ipv6 bool, restoredEndpoints []*endpoint.Endpoint, mgr Endpoin
 				epsMap[e.GetIPv6Address()] = e
 			}
 
+			// Partitioned CT maps are garbage collected on their own
+			// cadence when one is configured, the churn of the
+			// workloads they serve does not pressure the global maps.
+			partitionGCDue := true
+			if interval := option.Config.CTPartitionGCInterval; interval > 0 {
+				partitionGCDue = initialScan || gcStart.Sub(lastPartitionGC) >= interval
+			}
+
 			if len(eps) > 0 || initialScan {
 				mapType, maxDeleteRatio = runGC(nil, ipv4, ipv6, triggeredBySignal, createGCFilter(initialScan, restoredEndpoints, emitEntryCB))
 			}
@@ -93,8 +102,15 @@ func Enable(ipv4, ipv6 bool, restoredEndpoints []*endpoint.Endpoint, mgr Endpoin
 					// Skip because GC was handled above.
 					continue
 				}
+				if !partitionGCDue && e.ConntrackPartitioned() {
+					// Skip until the partition GC interval has passed.
+					continue
+				}
 				runGC(e, ipv4, ipv6, triggeredBySignal, &ctmap.GCFilter{RemoveExpired: true, EmitCTEntryCB: emitEntryCB})
 			}
+			if partitionGCDue {
+				lastPartitionGC = gcStart
+			}
 
 			// Mark the CT GC as over in each EP DNSZombies instance
 			for _, e := range eps {
@@ -131,7 +147,7 @@ func Enable(ipv4, ipv6 bool, restoredEndpoints []*endpoint.Endpoint, mgr Endpoin
 						ipv6 = true
 					}
 				}
-			case <-ctTimer.After(ctmap.GetInterval(mapType, maxDeleteRatio)):
+			case <-ctTimer.After(nextGCInterval(mapType, maxDeleteRatio)):
 				ipv4 = ipv4Orig
 				ipv6 = ipv6Orig
 			}
@@ -147,6 +163,18 @@ func Enable(ipv4, ipv6 bool, restoredEndpoints []*endpoint.Endpoint, mgr Endpoin
 	}
 }
 
+// nextGCInterval returns the wait time until the next GC run. The regular
+// interval is derived from the observed delete ratio, but is capped by the
+// partition GC interval when one is configured so that partitioned CT maps
+// are not left uncollected longer than requested.
+func nextGCInterval(mapType bpf.MapType, maxDeleteRatio float64) time.Duration {
+	interval := ctmap.GetInterval(mapType, maxDeleteRatio)
+	if partitionInterval := option.Config.CTPartitionGCInterval; partitionInterval > 0 && partitionInterval < interval {
+		interval = partitionInterval
+	}
+	return interval
+}
+
 // runGC run CT's garbage collector for the given endpoint. `isLocal` refers if
 // the CT map is set to local. If `isIPv6` is set specifies that is the IPv6
 // map. `filter` represents the filter type to be used while looping all CT
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package faultinject
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/cilium/cilium/pkg/bpf"
+)
+
+const (
+	MapName = "cilium_fault_injection"
+
+	// MaxEntries limits the number of identity pairs faults can be
+	// injected for at the same time. The map is only meant to hold the
+	// handful of entries a resilience test programs.
+	MaxEntries = 256
+)
+
+// Key identifies the identity pair a fault is injected for.
+type Key struct {
+	SrcIdentity uint32 `align:"src_sec_id"`
+	DstIdentity uint32 `align:"dst_sec_id"`
+}
+
+func (k *Key) GetKeyPtr() unsafe.Pointer { return unsafe.Pointer(k) }
+func (k *Key) NewValue() bpf.MapValue    { return &Value{} }
+func (k *Key) String() string {
+	return fmt.Sprintf("%d -> %d", k.SrcIdentity, k.DstIdentity)
+}
+func (k *Key) DeepCopyMapKey() bpf.MapKey { return &Key{k.SrcIdentity, k.DstIdentity} }
+
+// Value holds the percentage of packets to drop for the identity pair.
+type Value struct {
+	Probability uint8  `align:"probability"`
+	Pad1        uint8  `align:"pad1"`
+	Pad2        uint16 `align:"pad2"`
+}
+
+func (v *Value) GetValuePtr() unsafe.Pointer { return unsafe.Pointer(v) }
+func (v *Value) String() string              { return fmt.Sprintf("%d%%", v.Probability) }
+func (v *Value) DeepCopyMapValue() bpf.MapValue {
+	return &Value{v.Probability, v.Pad1, v.Pad2}
+}
+
+// Map is consulted by the datapath on the policy enforcement path when
+// ENABLE_FAULT_INJECTION is set. Entries cause the configured share of
+// packets between the identity pair to be dropped with the
+// DROP_FAULT_INJECTED drop reason.
+var Map = bpf.NewMap(
+	MapName,
+	bpf.MapTypeHash,
+	&Key{}, int(unsafe.Sizeof(Key{})),
+	&Value{}, int(unsafe.Sizeof(Value{})),
+	MaxEntries,
+	bpf.BPF_F_NO_PREALLOC, 0,
+	bpf.ConvertKeyValue,
+).WithCache()
+
+// Update installs a fault dropping the given percentage of packets sent from
+// the source to the destination identity.
+func Update(srcIdentity, dstIdentity uint32, probability uint8) error {
+	if probability > 100 {
+		return fmt.Errorf("drop probability %d%% out of range", probability)
+	}
+	return Map.Update(
+		&Key{SrcIdentity: srcIdentity, DstIdentity: dstIdentity},
+		&Value{Probability: probability})
+}
+
+// Delete removes the fault installed for the given identity pair.
+func Delete(srcIdentity, dstIdentity uint32) error {
+	return Map.Delete(&Key{SrcIdentity: srcIdentity, DstIdentity: dstIdentity})
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package fqdnmap
+
+import (
+	"fmt"
+	"net"
+	"time"
+	"unsafe"
+
+	"github.com/cilium/cilium/pkg/bpf"
+	"github.com/cilium/cilium/pkg/types"
+)
+
+const (
+	// Map4Name is the name of the map holding IPv4 addresses returned by
+	// allowed DNS answers.
+	Map4Name = "cilium_fqdn_allow_v4"
+
+	// Map6Name is the name of the map holding IPv6 addresses returned by
+	// allowed DNS answers.
+	Map6Name = "cilium_fqdn_allow_v6"
+
+	// MaxEntries is the size of each map. The maps are LRU hashes, so
+	// entries of names which are no longer resolved age out on their own
+	// and no garbage collection is required.
+	MaxEntries = 65536
+)
+
+// Key4 is the IPv4 address returned in a DNS answer.
+type Key4 struct {
+	IP types.IPv4 `align:"addr"`
+}
+
+func (k *Key4) GetKeyPtr() unsafe.Pointer { return unsafe.Pointer(k) }
+func (k *Key4) NewValue() bpf.MapValue    { return &Value{} }
+func (k *Key4) String() string            { return k.IP.String() }
+func (k *Key4) DeepCopyMapKey() bpf.MapKey {
+	return &Key4{k.IP}
+}
+
+// Key6 is the IPv6 address returned in a DNS answer.
+type Key6 struct {
+	IP types.IPv6 `align:"addr"`
+}
+
+func (k *Key6) GetKeyPtr() unsafe.Pointer { return unsafe.Pointer(k) }
+func (k *Key6) NewValue() bpf.MapValue    { return &Value{} }
+func (k *Key6) String() string            { return k.IP.String() }
+func (k *Key6) DeepCopyMapKey() bpf.MapKey {
+	return &Key6{k.IP}
+}
+
+// Value holds the monotonic time in seconds after which the datapath no
+// longer considers the address to be covered by a DNS answer.
+type Value struct {
+	Expires uint32 `align:"expires"`
+}
+
+func (v *Value) GetValuePtr() unsafe.Pointer { return unsafe.Pointer(v) }
+func (v *Value) String() string              { return fmt.Sprintf("expires=%d", v.Expires) }
+func (v *Value) DeepCopyMapValue() bpf.MapValue {
+	return &Value{v.Expires}
+}
+
+// Map4 and Map6 are consulted by the datapath on the egress path when
+// ENABLE_DNS_ANSWER_VALIDATION is set. Connections to world destinations
+// which have no unexpired entry are dropped with the DROP_DNS_ANSWER drop
+// reason.
+var (
+	Map4 = bpf.NewMap(
+		Map4Name,
+		bpf.MapTypeLRUHash,
+		&Key4{}, int(unsafe.Sizeof(Key4{})),
+		&Value{}, int(unsafe.Sizeof(Value{})),
+		MaxEntries,
+		0, 0,
+		bpf.ConvertKeyValue,
+	)
+
+	Map6 = bpf.NewMap(
+		Map6Name,
+		bpf.MapTypeLRUHash,
+		&Key6{}, int(unsafe.Sizeof(Key6{})),
+		&Value{}, int(unsafe.Sizeof(Value{})),
+		MaxEntries,
+		0, 0,
+		bpf.ConvertKeyValue,
+	)
+)
+
+// InitMaps creates the maps in the kernel.
+func InitMaps(ipv4, ipv6 bool) error {
+	if ipv4 {
+		if _, err := Map4.OpenOrCreate(); err != nil {
+			return err
+		}
+	}
+	if ipv6 {
+		if _, err := Map6.OpenOrCreate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Upsert marks the given IP as returned by an allowed DNS answer for the
+// given lifetime. An existing entry is overwritten, which extends the
+// lifetime on repeated lookups of the same name.
+func Upsert(ip net.IP, lifetime time.Duration) error {
+	mtime, err := bpf.GetMtime()
+	if err != nil {
+		return err
+	}
+	value := &Value{Expires: uint32(mtime/uint64(time.Second)) + uint32(lifetime.Seconds())}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		key := &Key4{}
+		copy(key.IP[:], ip4)
+		return Map4.Update(key, value)
+	}
+
+	key := &Key6{}
+	copy(key.IP[:], ip.To16())
+	return Map6.Update(key, value)
+}
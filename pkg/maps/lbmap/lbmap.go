@@ -56,6 +56,7 @@ type UpsertServiceParams struct {
 	IP                        net.IP
 	Port                      uint16
 	Backends                  map[string]uint16
+	BackendWeights            map[string]uint16
 	PrevBackendCount          int
 	IPv6                      bool
 	Type                      loadbalancer.SVCType
@@ -91,7 +92,7 @@ func (lbmap *LBBPFMap) UpsertService(p *UpsertServiceParams) error {
 	svcVal := svcKey.NewValue().(ServiceValue)
 
 	if p.UseMaglev && len(p.Backends) != 0 {
-		if err := lbmap.UpsertMaglevLookupTable(p.ID, p.Backends, p.IPv6); err != nil {
+		if err := lbmap.UpsertMaglevLookupTable(p.ID, p.Backends, p.BackendWeights, p.IPv6); err != nil {
 			return err
 		}
 	}
@@ -150,8 +151,9 @@ func (lbmap *LBBPFMap) UpsertService(p *UpsertServiceParams) error {
 }
 
 // UpsertMaglevLookupTable calculates Maglev lookup table for given backends, and
-// inserts into the Maglev BPF map.
-func (lbmap *LBBPFMap) UpsertMaglevLookupTable(svcID uint16, backends map[string]uint16, ipv6 bool) error {
+// inserts into the Maglev BPF map. If weights is non-nil, the lookup table
+// entries are distributed between the backends proportionally to their weight.
+func (lbmap *LBBPFMap) UpsertMaglevLookupTable(svcID uint16, backends map[string]uint16, weights map[string]uint16, ipv6 bool) error {
 	backendNames := make([]string, 0, len(backends))
 	for name := range backends {
 		backendNames = append(backendNames, name)
@@ -161,7 +163,16 @@ func (lbmap *LBBPFMap) UpsertMaglevLookupTable(svcID uint16, backends map[string
 	// backends by name, as the names are the same on all nodes (in opposite
 	// to backend IDs which are node-local).
 	sort.Strings(backendNames)
-	table := maglev.GetLookupTable(backendNames, lbmap.maglevTableSize)
+	var table []int
+	if len(weights) != 0 {
+		w := make([]uint16, len(backendNames))
+		for i, name := range backendNames {
+			w[i] = weights[name]
+		}
+		table = maglev.GetWeightedLookupTable(backendNames, w, lbmap.maglevTableSize)
+	} else {
+		table = maglev.GetLookupTable(backendNames, lbmap.maglevTableSize)
+	}
 	for i, pos := range table {
 		lbmap.maglevBackendIDsBuffer[i] = backends[backendNames[pos]]
 	}
@@ -489,6 +500,28 @@ func (*LBBPFMap) DumpBackendMaps() ([]*loadbalancer.Backend, error) {
 	return lbBackends, nil
 }
 
+// FilterByFrontendIP returns a dump filter which accepts service entries with
+// ip as frontend address.
+func FilterByFrontendIP(ip net.IP) bpf.DumpFilter {
+	return func(key bpf.MapKey, _ bpf.MapValue) bool {
+		if svcKey, ok := key.(ServiceKey); ok {
+			return svcKey.GetAddress().Equal(ip)
+		}
+		return true
+	}
+}
+
+// FilterByBackendIP returns a dump filter which accepts backend entries with
+// ip as backend address.
+func FilterByBackendIP(ip net.IP) bpf.DumpFilter {
+	return func(_ bpf.MapKey, value bpf.MapValue) bool {
+		if backend, ok := value.(BackendValue); ok {
+			return backend.GetAddress().Equal(ip)
+		}
+		return true
+	}
+}
+
 // IsMaglevLookupTableRecreated returns true if the maglev lookup BPF map
 // was recreated due to the changed M param.
 func (*LBBPFMap) IsMaglevLookupTableRecreated(ipv6 bool) bool {
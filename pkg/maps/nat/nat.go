@@ -5,6 +5,7 @@ package nat
 
 import (
 	"fmt"
+	"net"
 	"strings"
 	"unsafe"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/cilium/cilium/pkg/logging/logfields"
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/tuple"
+	"github.com/cilium/cilium/pkg/types"
 )
 
 var (
@@ -124,6 +126,14 @@ func (m *Map) DumpReliablyWithCallback(cb bpf.DumpCallback, stats *bpf.DumpStats
 // DoDumpEntries iterates through Map m and writes the values of the
 // nat entries in m to a string.
 func DoDumpEntries(m NatMap) (string, error) {
+	return DumpEntriesWithFilter(m, nil, 0, 0)
+}
+
+// DumpEntriesWithFilter is similar to DoDumpEntries, but only formats the
+// entries accepted by filter within the window described by offset and limit.
+// This bounds the amount of memory required to dump a large map. A nil filter
+// accepts all entries, a limit of 0 disables the limit.
+func DumpEntriesWithFilter(m NatMap, filter bpf.DumpFilter, offset, limit int) (string, error) {
 	var sb strings.Builder
 
 	nsecStart, _ := bpf.GetMtime()
@@ -135,7 +145,7 @@ func DoDumpEntries(m NatMap) (string, error) {
 		val := v.(NatEntry)
 		sb.WriteString(val.ToHost().Dump(key, nsecStart))
 	}
-	err := m.DumpWithCallback(cb)
+	err := m.DumpWithCallback(bpf.PaginatedCallback(filter, offset, limit, cb))
 	return sb.String(), err
 }
 
@@ -145,6 +155,20 @@ func (m *Map) DumpEntries() (string, error) {
 	return DoDumpEntries(m)
 }
 
+// FilterByIP returns a dump filter which accepts entries with ip as source or
+// destination address.
+func FilterByIP(ip net.IP) bpf.DumpFilter {
+	return func(key bpf.MapKey, _ bpf.MapValue) bool {
+		switch k := key.(type) {
+		case *NatKey4:
+			return k.SourceAddr.IP().Equal(ip) || k.DestAddr.IP().Equal(ip)
+		case *NatKey6:
+			return k.SourceAddr.IP().Equal(ip) || k.DestAddr.IP().Equal(ip)
+		}
+		return true
+	}
+}
+
 type gcStats struct {
 	*bpf.DumpStats
 
@@ -197,6 +221,69 @@ func (m *Map) Flush() int {
 	return int(doFlush6(m).deleted)
 }
 
+func doFlushAddr4(m *Map, addr types.IPv4) gcStats {
+	stats := statStartGc(m)
+	filterCallback := func(key bpf.MapKey, value bpf.MapValue) {
+		k, kok := key.(*NatKey4)
+		v, vok := value.(*NatEntry4)
+		if !kok || !vok {
+			return
+		}
+		if v.Addr != addr && k.SourceAddr != addr && k.DestAddr != addr {
+			return
+		}
+		if deleted, err := m.Delete(key); err != nil {
+			log.WithError(err).WithField(logfields.Key, key.String()).Error("Unable to delete NAT entry")
+		} else if deleted {
+			stats.deleted++
+		}
+	}
+	stats.dumpError = m.DumpReliablyWithCallback(filterCallback, stats.DumpStats)
+	return stats
+}
+
+func doFlushAddr6(m *Map, addr types.IPv6) gcStats {
+	stats := statStartGc(m)
+	filterCallback := func(key bpf.MapKey, value bpf.MapValue) {
+		k, kok := key.(*NatKey6)
+		v, vok := value.(*NatEntry6)
+		if !kok || !vok {
+			return
+		}
+		if v.Addr != addr && k.SourceAddr != addr && k.DestAddr != addr {
+			return
+		}
+		if deleted, err := m.Delete(key); err != nil {
+			log.WithError(err).WithField(logfields.Key, key.String()).Error("Unable to delete NAT entry")
+		} else if deleted {
+			stats.deleted++
+		}
+	}
+	stats.dumpError = m.DumpReliablyWithCallback(filterCallback, stats.DumpStats)
+	return stats
+}
+
+// FlushAddr deletes all NAT mappings which translate to or from the given
+// address and returns the number of deleted entries. It is used when an
+// egress address is removed from the node so that flows do not continue to
+// leave the node with a now-foreign source address.
+func (m *Map) FlushAddr(ip net.IP) int {
+	if m.v4 {
+		if ip4 := ip.To4(); ip4 != nil {
+			var addr types.IPv4
+			copy(addr[:], ip4)
+			return int(doFlushAddr4(m, addr).deleted)
+		}
+		return 0
+	}
+	if ip.To4() == nil {
+		var addr types.IPv6
+		copy(addr[:], ip.To16())
+		return int(doFlushAddr6(m, addr).deleted)
+	}
+	return 0
+}
+
 func deleteMapping4(m *Map, ctKey *tuple.TupleKey4Global) error {
 	key := NatKey4{
 		TupleKey4Global: *ctKey,
@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+// Package priometricsmap represents the BPF priority metrics map in the BPF
+// programs. It is implemented as a per-CPU array with one slot per egress
+// scheduling band, containing packet and byte counts as well as the last
+// observed queueing delay of the band.
+// +groupName=maps
+package priometricsmap
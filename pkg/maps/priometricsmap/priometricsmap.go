@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package priometricsmap
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/cilium/cilium/pkg/ebpf"
+	"github.com/cilium/cilium/pkg/logging"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// IterateCallback represents the signature of the callback function expected
+// by the IterateWithCallback method, which in turn is used to iterate all the
+// keys/values of a priority metrics map.
+type IterateCallback func(*Key, *Values)
+
+// PriorityMetricsMap interface represents a priority metrics map, and can be
+// reused to implement mock maps for unit tests.
+type PriorityMetricsMap interface {
+	IterateWithCallback(IterateCallback) error
+}
+
+type priorityMetricsMap struct {
+	*ebpf.Map
+}
+
+var (
+	// PriorityMetrics is the bpf priority metrics map
+	PriorityMetrics priorityMetricsMap
+	log             = logging.DefaultLogger.WithField(logfields.LogSubsys, "map-prio-metrics")
+)
+
+const (
+	// MapName for the priority metrics map.
+	MapName = "cilium_prio_metrics"
+	// MaxEntries is the number of slots in the priority metrics map, one
+	// per egress scheduling band.
+	MaxEntries = 3
+)
+
+// Key must be in sync with the key of PRIO_METRICS_MAP in <bpf/lib/maps.h>
+type Key struct {
+	Band uint32 `align:"band"`
+}
+
+// Value must be in sync with struct edt_prio_metrics in <bpf/lib/common.h>
+type Value struct {
+	Packets  uint64 `align:"packets"`
+	Bytes    uint64 `align:"bytes"`
+	QdelayNs uint64 `align:"qdelay_ns"`
+	Pad      uint64 `align:"pad"`
+}
+
+// Values is a slice of Values
+type Values []Value
+
+// IterateWithCallback iterates through all the keys/values of a priority
+// metrics map, passing each key/value pair to the cb callback
+func (m priorityMetricsMap) IterateWithCallback(cb IterateCallback) error {
+	return m.Map.IterateWithCallback(&Key{}, &Values{}, func(k, v interface{}) {
+		key := k.(*Key)
+		values := v.(*Values)
+
+		cb(key, values)
+	})
+}
+
+// BandName returns the name of the scheduling band in human readable string
+// format, matching the values of the network priority annotation.
+func (k *Key) BandName() string {
+	// Must match the EDT_PRIO_BAND_* constants in bpf/lib/common.h and
+	// the priority classes in pkg/bandwidth.
+	switch k.Band {
+	case 0:
+		return "latency-critical"
+	case 2:
+		return "batch"
+	default:
+		return "normal"
+	}
+}
+
+// Packets returns the sum of all the per-CPU packets values
+func (vs Values) Packets() uint64 {
+	c := uint64(0)
+	for _, v := range vs {
+		c += v.Packets
+	}
+
+	return c
+}
+
+// Bytes returns the sum of all the per-CPU bytes values
+func (vs Values) Bytes() uint64 {
+	b := uint64(0)
+	for _, v := range vs {
+		b += v.Bytes
+	}
+
+	return b
+}
+
+// QdelayNs returns the largest of the per-CPU queueing delay samples
+func (vs Values) QdelayNs() uint64 {
+	q := uint64(0)
+	for _, v := range vs {
+		if v.QdelayNs > q {
+			q = v.QdelayNs
+		}
+	}
+
+	return q
+}
+
+func updateMetric(getCounter func() (prometheus.Counter, error), newValue float64) {
+	counter, err := getCounter()
+	if err != nil {
+		log.WithError(err).Warn("Failed to update prometheus metrics")
+		return
+	}
+
+	oldValue := metrics.GetCounterValue(counter)
+	if newValue > oldValue {
+		counter.Add(newValue - oldValue)
+	}
+}
+
+// updatePrometheusMetrics updates the prometheus metrics of the scheduling
+// band the key refers to with the given values.
+func updatePrometheusMetrics(key *Key, values *Values) {
+	band := key.BandName()
+
+	updateMetric(func() (prometheus.Counter, error) {
+		return metrics.PriorityBandCount.GetMetricWithLabelValues(band)
+	}, float64(values.Packets()))
+
+	updateMetric(func() (prometheus.Counter, error) {
+		return metrics.PriorityBandBytes.GetMetricWithLabelValues(band)
+	}, float64(values.Bytes()))
+
+	metrics.PriorityBandQueueDelay.WithLabelValues(band).
+		Set(float64(values.QdelayNs()) / float64(1e9))
+}
+
+// SyncPriorityMetricsMap is called periodically to sync the priority metrics
+// map into the per-band queue metrics of the prometheus server.
+func SyncPriorityMetricsMap(ctx context.Context) error {
+	return PriorityMetrics.IterateWithCallback(func(key *Key, values *Values) {
+		updatePrometheusMetrics(key, values)
+	})
+}
+
+func init() {
+	PriorityMetrics.Map = ebpf.NewMap(&ebpf.MapSpec{
+		Name:       MapName,
+		Type:       ebpf.PerCPUArray,
+		KeySize:    uint32(unsafe.Sizeof(Key{})),
+		ValueSize:  uint32(unsafe.Sizeof(Value{})),
+		MaxEntries: MaxEntries,
+		Pinning:    ebpf.PinByName,
+	})
+}
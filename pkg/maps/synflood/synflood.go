@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package synflood
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/cilium/cilium/pkg/bpf"
+	"github.com/cilium/cilium/pkg/byteorder"
+)
+
+const (
+	MapName = "cilium_synflood"
+
+	// MaxEntries limits the number of service frontends mitigations can
+	// be installed for at the same time. The map only holds the frontends
+	// currently under attack.
+	MaxEntries = 512
+)
+
+// Key identifies the service frontend a mitigation applies to by its reverse
+// NAT index, in network byte order as looked up by the datapath.
+type Key struct {
+	RevNatIndex uint16 `align:"rev_nat_index"`
+	Pad         uint16 `align:"pad"`
+}
+
+func (k *Key) GetKeyPtr() unsafe.Pointer { return unsafe.Pointer(k) }
+func (k *Key) NewValue() bpf.MapValue    { return &Value{} }
+func (k *Key) String() string {
+	return fmt.Sprintf("%d", byteorder.NetworkToHost16(k.RevNatIndex))
+}
+func (k *Key) DeepCopyMapKey() bpf.MapKey { return &Key{k.RevNatIndex, k.Pad} }
+
+// Value holds the percentage of new connections to drop for the frontend.
+type Value struct {
+	Probability uint8  `align:"probability"`
+	Pad1        uint8  `align:"pad1"`
+	Pad2        uint16 `align:"pad2"`
+}
+
+func (v *Value) GetValuePtr() unsafe.Pointer { return unsafe.Pointer(v) }
+func (v *Value) String() string              { return fmt.Sprintf("%d%%", v.Probability) }
+func (v *Value) DeepCopyMapValue() bpf.MapValue {
+	return &Value{v.Probability, v.Pad1, v.Pad2}
+}
+
+// Map is consulted by the datapath for new connections toward NodePort and
+// LoadBalancer service frontends when ENABLE_SYN_FLOOD_PROTECTION is set.
+// Entries cause the configured share of new connections toward the frontend
+// to be dropped with the DROP_SYN_FLOOD drop reason.
+var Map = bpf.NewMap(
+	MapName,
+	bpf.MapTypeHash,
+	&Key{}, int(unsafe.Sizeof(Key{})),
+	&Value{}, int(unsafe.Sizeof(Value{})),
+	MaxEntries,
+	bpf.BPF_F_NO_PREALLOC, 0,
+	bpf.ConvertKeyValue,
+).WithCache()
+
+// Update installs a mitigation dropping the given percentage of new
+// connections toward the service frontend with the given reverse NAT index.
+func Update(revNatIndex uint16, probability uint8) error {
+	if probability > 100 {
+		return fmt.Errorf("drop probability %d%% out of range", probability)
+	}
+	return Map.Update(
+		&Key{RevNatIndex: byteorder.HostToNetwork16(revNatIndex)},
+		&Value{Probability: probability})
+}
+
+// Delete removes the mitigation installed for the service frontend with the
+// given reverse NAT index.
+func Delete(revNatIndex uint16) error {
+	return Map.Delete(&Key{RevNatIndex: byteorder.HostToNetwork16(revNatIndex)})
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+// Package features exports the feature enablement matrix of the agent beyond
+// the Prometheus gauges: an optional reporter periodically serializes the
+// full matrix and publishes it through a pluggable publish function, e.g.
+// into an annotation on the node's CiliumNode resource, so that cluster-wide
+// tooling can aggregate feature usage without scraping the metrics endpoint
+// of every agent.
+package features
+
+import (
+	"context"
+	"time"
+
+	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/features"
+	"github.com/cilium/cilium/pkg/option"
+	"github.com/cilium/cilium/pkg/version"
+)
+
+// featureReportController is the name of the controller publishing the
+// periodic feature report.
+const featureReportController = "feature-report"
+
+// Report is the feature matrix of a single agent as published by the
+// reporter.
+type Report struct {
+	// Timestamp is the time the report was generated
+	Timestamp time.Time `json:"timestamp"`
+
+	// NodeName is the name of the node the agent runs on
+	NodeName string `json:"node-name"`
+
+	// AgentVersion is the Cilium version of the agent
+	AgentVersion string `json:"agent-version"`
+
+	// NetworkMode is the tunneling protocol in use, or "native" when
+	// tunneling is disabled
+	NetworkMode string `json:"network-mode"`
+
+	// DatapathMode is the configured datapath mode (veth or ipvlan)
+	DatapathMode string `json:"datapath-mode"`
+
+	// IPAMMode is the configured IPAM backend
+	IPAMMode string `json:"ipam-mode"`
+
+	// KubeProxyReplacement is the configured kube-proxy replacement mode
+	KubeProxyReplacement string `json:"kube-proxy-replacement"`
+
+	// L7Parsers is the list of L7 parser types currently backed by a
+	// configured proxy port
+	L7Parsers []string `json:"l7-parsers,omitempty"`
+
+	// Features is the enablement state of the individual features,
+	// matching the labels of the feature enablement gauge
+	Features map[string]bool `json:"features,omitempty"`
+}
+
+// Sources provides the dynamic inputs of the report which cannot be derived
+// from the static agent configuration.
+type Sources struct {
+	// NodeName is the name of the node the agent runs on
+	NodeName string
+
+	// L7Parsers returns the L7 parser types currently backed by a
+	// configured proxy port
+	L7Parsers func() []string
+
+	// Static returns the enablement state of the features published
+	// through the feature enablement gauge
+	Static func() map[string]bool
+}
+
+// PublishFunc publishes a generated report.
+type PublishFunc func(ctx context.Context, report *Report) error
+
+// Reporter periodically collects the feature matrix and publishes it.
+type Reporter struct {
+	interval time.Duration
+	sources  Sources
+	publish  PublishFunc
+}
+
+// NewReporter returns a new reporter publishing the feature matrix in the
+// given interval.
+func NewReporter(interval time.Duration, sources Sources, publish PublishFunc) *Reporter {
+	return &Reporter{
+		interval: interval,
+		sources:  sources,
+		publish:  publish,
+	}
+}
+
+// Run starts the periodic publication of the feature report. The report is
+// published immediately and then on every interval until the context is
+// cancelled.
+func (r *Reporter) Run(ctx context.Context) {
+	controller.NewManager().UpdateController(featureReportController,
+		controller.ControllerParams{
+			DoFunc: func(ctx context.Context) error {
+				return r.publish(ctx, r.Collect())
+			},
+			RunInterval: r.interval,
+			Context:     ctx,
+		},
+	)
+}
+
+// Collect assembles the current feature matrix of the agent.
+func (r *Reporter) Collect() *Report {
+	networkMode := option.Config.Tunnel
+	if networkMode == option.TunnelDisabled {
+		networkMode = "native"
+	}
+
+	report := &Report{
+		Timestamp:            time.Now(),
+		NodeName:             r.sources.NodeName,
+		AgentVersion:         version.GetCiliumVersion().Version,
+		NetworkMode:          networkMode,
+		DatapathMode:         option.Config.DatapathMode,
+		IPAMMode:             option.Config.IPAM,
+		KubeProxyReplacement: option.Config.KubeProxyReplacement,
+		Features:             map[string]bool{},
+	}
+
+	if r.sources.L7Parsers != nil {
+		report.L7Parsers = r.sources.L7Parsers()
+	}
+
+	if r.sources.Static != nil {
+		for name, enabled := range r.sources.Static() {
+			report.Features[name] = enabled
+		}
+	}
+
+	// Runtime-toggled features shadow the static state under the same name
+	for name, enabled := range features.Status() {
+		report.Features[name] = enabled
+	}
+
+	return report
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package features
+
+import (
+	"testing"
+
+	"github.com/cilium/cilium/pkg/option"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	check.TestingT(t)
+}
+
+type FeaturesSuite struct{}
+
+var _ = check.Suite(&FeaturesSuite{})
+
+func (s *FeaturesSuite) TestCollect(c *check.C) {
+	oldTunnel := option.Config.Tunnel
+	defer func() { option.Config.Tunnel = oldTunnel }()
+	option.Config.Tunnel = option.TunnelDisabled
+
+	reporter := NewReporter(0, Sources{
+		NodeName:  "node1",
+		L7Parsers: func() []string { return []string{"dns", "http"} },
+		Static:    func() map[string]bool { return map[string]bool{"host-firewall": true} },
+	}, nil)
+
+	report := reporter.Collect()
+	c.Assert(report.NodeName, check.Equals, "node1")
+	c.Assert(report.NetworkMode, check.Equals, "native")
+	c.Assert(report.L7Parsers, check.DeepEquals, []string{"dns", "http"})
+	c.Assert(report.Features["host-firewall"], check.Equals, true)
+
+	option.Config.Tunnel = option.TunnelVXLAN
+	report = reporter.Collect()
+	c.Assert(report.NetworkMode, check.Equals, option.TunnelVXLAN)
+}
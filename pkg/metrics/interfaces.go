@@ -12,11 +12,13 @@ type CounterVec interface {
 	WithLabelValues(lvls ...string) prometheus.Counter
 	GetMetricWithLabelValues(lvs ...string) (prometheus.Counter, error)
 	With(labels prometheus.Labels) prometheus.Counter
+	DeleteLabelValues(lvs ...string) bool
 	prometheus.Collector
 }
 
 type GaugeVec interface {
 	WithLabelValues(lvls ...string) prometheus.Gauge
+	DeleteLabelValues(lvs ...string) bool
 	prometheus.Collector
 }
 
@@ -70,6 +72,8 @@ func (cv *counterVec) GetMetricWithLabelValues(lvs ...string) (prometheus.Counte
 
 func (cv *counterVec) With(labels prometheus.Labels) prometheus.Counter { return NoOpCounter }
 
+func (cv *counterVec) DeleteLabelValues(lvs ...string) bool { return false }
+
 // Observer
 
 type observer struct{}
@@ -122,3 +126,5 @@ type gaugeVec struct {
 func (gv *gaugeVec) WithLabelValues(lvls ...string) prometheus.Gauge {
 	return NoOpGauge
 }
+
+func (gv *gaugeVec) DeleteLabelValues(lvs ...string) bool { return false }
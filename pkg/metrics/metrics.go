@@ -106,6 +106,17 @@ const (
 	// LabelDatapathFamily marks which protocol family (IPv4, IPV6) the metric is related to.
 	LabelDatapathFamily = "family"
 
+	// LabelPool marks the IP pool the metric is related to.
+	LabelPool = "pool"
+
+	// LabelResource marks the underlying resource (e.g. ENI) the metric is
+	// related to.
+	LabelResource = "resource"
+
+	// LabelState marks the state (e.g. capacity, used, available) the
+	// metric is related to.
+	LabelState = "state"
+
 	// LabelProtocol marks the L4 protocol (TCP, ANY) for the metric.
 	LabelProtocol = "protocol"
 
@@ -133,6 +144,33 @@ const (
 	// LabelProtocolL7 is the label used when working with layer 7 protocols.
 	LabelProtocolL7 = "protocol_l7"
 
+	// LabelDeploymentMode is the label for the proxy deployment mode
+	LabelDeploymentMode = "mode"
+
+	// LabelProxyRedirect is the label for the proxy redirect identifier
+	LabelProxyRedirect = "redirect"
+
+	// LabelProxyListener is the label for the proxy listener name
+	LabelProxyListener = "listener"
+
+	// LabelEventClass is the label for the priority class of a monitor event
+	LabelEventClass = "class"
+
+	// LabelXDSResourceType is the label for the xDS resource type of a stream
+	LabelXDSResourceType = "resource_type"
+
+	// LabelServiceNamespace is the label for the namespace of a service
+	LabelServiceNamespace = "namespace"
+
+	// LabelServiceName is the label for the name of a service
+	LabelServiceName = "service"
+
+	// LabelFeature is the label for the name of an optional agent feature
+	LabelFeature = "feature"
+
+	// LabelFQDNSelector is the label for a ToFQDNs selector
+	LabelFQDNSelector = "selector"
+
 	// LabelBuildState is the state a build queue entry is in
 	LabelBuildState = "state"
 
@@ -168,6 +206,12 @@ const (
 
 	// LabelDirection is the label for traffic direction
 	LabelDirection = "direction"
+
+	// LabelClusterID is the label for the numeric ID of a remote cluster
+	LabelClusterID = "cluster_id"
+
+	// LabelBand is the label for an egress scheduling band
+	LabelBand = "band"
 )
 
 var (
@@ -194,6 +238,11 @@ var (
 	// endpoints, labeled by span name and status ("success" or "failure")
 	EndpointRegenerationTimeStats = NoOpObserverVec
 
+	// EndpointRegenerationsAvoided is a count of the endpoint datapath
+	// regenerations avoided by only updating the rules of the existing
+	// proxy redirects
+	EndpointRegenerationsAvoided = NoOpCounter
+
 	// Policy
 	// Policy is the number of policies loaded into the agent
 	Policy = NoOpGauge
@@ -221,6 +270,14 @@ var (
 	// time taken to fully deploy an endpoint.
 	PolicyImplementationDelay = NoOpObserverVec
 
+	// PolicyMapUpdateDelay is a distribution of times taken from a policy
+	// repository change until the corresponding policy map update has
+	// completed for an individual endpoint. Unlike
+	// PolicyImplementationDelay, every endpoint contributes an observation
+	// as soon as its own maps are updated, quantifying enforcement lag on
+	// busy nodes.
+	PolicyMapUpdateDelay = NoOpObserverVec
+
 	// Identity
 
 	// Identity is the number of identities currently in use on the node
@@ -244,11 +301,61 @@ var (
 	// EventTSAPI is the timestamp of docker events
 	EventTSAPI = NoOpGauge
 
+	// HubbleLostEvents is the number of monitor events dropped before
+	// reaching the Hubble event queue, labeled by priority class
+	HubbleLostEvents = NoOpCounterVec
+
 	// L7 statistics
 
 	// ProxyRedirects is the number of redirects labeled by protocol
 	ProxyRedirects = NoOpGaugeVec
 
+	// ProxyDeploymentMode indicates the proxy deployment mode in use,
+	// labeled by mode. The embedded mode is set while at least one
+	// redirect is served by the embedded Envoy.
+	ProxyDeploymentMode = NoOpGaugeVec
+
+	// ProxyRedirectHealth indicates whether the listener serving each
+	// proxy redirect responded to the last health probe, labeled by
+	// redirect identifier
+	ProxyRedirectHealth = NoOpGaugeVec
+
+	// ProxyDatapathRulesInstalled indicates whether the datapath rules of
+	// each acknowledged proxy port are installed, labeled by listener
+	ProxyDatapathRulesInstalled = NoOpGaugeVec
+
+	// ProxyDatapathRulesPackets is the number of packets matched by the
+	// datapath rules of each acknowledged proxy port, labeled by listener
+	ProxyDatapathRulesPackets = NoOpGaugeVec
+
+	// ProxyDatapathRulesInstallTime is the time of the last successful
+	// datapath rule install of each acknowledged proxy port, labeled by
+	// listener
+	ProxyDatapathRulesInstallTime = NoOpGaugeVec
+
+	// ProxyXDSStreamReplays is the number of times the configured resources
+	// were replayed on a re-established xDS stream, labeled by resource
+	// type. A replay happens whenever the proxy reconnects, e.g. after a
+	// restart of the Envoy process.
+	ProxyXDSStreamReplays = NoOpCounterVec
+
+	// ProxyServiceRequests is the number of L7 requests observed by the
+	// proxy, labeled by service namespace, service name and outcome
+	ProxyServiceRequests = NoOpCounterVec
+
+	// ProxyServiceRequestDuration is the time between an L7 request and its
+	// response as observed by the proxy, labeled by service namespace and
+	// service name
+	ProxyServiceRequestDuration = NoOpObserverVec
+
+	// FQDNSelectorIPs is the number of IPs associated with each ToFQDNs
+	// selector
+	FQDNSelectorIPs = NoOpGaugeVec
+
+	// FQDNSelectorIPChurn is the total number of IP additions and removals
+	// per ToFQDNs selector
+	FQDNSelectorIPChurn = NoOpCounterVec
+
 	// ProxyPolicyL7Total is a count of all l7 requests handled by proxy
 	ProxyPolicyL7Total = NoOpCounterVec
 
@@ -290,6 +397,28 @@ var (
 	// tagged by ingress/egress direction
 	ForwardBytes = NoOpCounterVec
 
+	// ClusterTrafficCount is the total packets exchanged with endpoints
+	// of remote clusters, tagged by remote cluster ID and ingress/egress
+	// direction
+	ClusterTrafficCount = NoOpCounterVec
+
+	// ClusterTrafficBytes is the total bytes exchanged with endpoints of
+	// remote clusters, tagged by remote cluster ID and ingress/egress
+	// direction
+	ClusterTrafficBytes = NoOpCounterVec
+
+	// PriorityBandCount is the total packets scheduled through the
+	// bandwidth manager, tagged by egress scheduling band
+	PriorityBandCount = NoOpCounterVec
+
+	// PriorityBandBytes is the total bytes scheduled through the
+	// bandwidth manager, tagged by egress scheduling band
+	PriorityBandBytes = NoOpCounterVec
+
+	// PriorityBandQueueDelay is the last observed queueing delay of an
+	// egress scheduling band, tagged by band
+	PriorityBandQueueDelay = NoOpGaugeVec
+
 	// Datapath statistics
 
 	// ConntrackGCRuns is the number of times that the conntrack GC
@@ -321,6 +450,18 @@ var (
 	// ServicesCount number of services
 	ServicesCount = NoOpCounterVec
 
+	// TerminatingBackendsPreserved is the number of terminating backends
+	// preserved for established connections during service updates.
+	TerminatingBackendsPreserved = NoOpCounter
+
+	// SynFloodDetected is the number of SYN floods detected toward
+	// service frontends.
+	SynFloodDetected = NoOpCounter
+
+	// SynFloodMitigatedFrontends is the number of service frontends with
+	// an active SYN flood mitigation.
+	SynFloodMitigatedFrontends = NoOpGauge
+
 	// Errors and warnings
 
 	// ErrorsWarnings is the number of errors and warnings in cilium-agent instances
@@ -359,12 +500,41 @@ var (
 	// complete a CNP status update
 	KubernetesCNPStatusCompletion = NoOpObserverVec
 
+	// KubernetesCiliumEndpointRepairs is the number of CiliumEndpoint
+	// objects repaired by the agent because they went out of sync with
+	// local endpoint state, labeled by action
+	KubernetesCiliumEndpointRepairs = NoOpCounterVec
+
 	// IPAM events
 
 	// IpamEvent is the number of IPAM events received labeled by action and
 	// datapath family type
 	IpamEvent = NoOpCounterVec
 
+	// IpamAvailableIPs is the number of IPs available for allocation in
+	// the IPAM pool, labeled by datapath family type
+	IpamAvailableIPs = NoOpGaugeVec
+
+	// IpamPoolAllocation is the number of successful IPAM allocations
+	// labeled by the pool the IPs were drawn from
+	IpamPoolAllocation = NoOpCounterVec
+
+	// IpamResourceIPs is the number of IPs per pool and underlying
+	// resource, labeled by state (capacity, used, available)
+	IpamResourceIPs = NoOpGaugeVec
+
+	// IpamAllocationDuration is the duration of IPAM allocation requests
+	// labeled by datapath family type and outcome
+	IpamAllocationDuration = NoOpObserverVec
+
+	// IpamAllocationErrors is the number of failed IPAM allocations
+	// labeled by error type
+	IpamAllocationErrors = NoOpCounterVec
+
+	// IpamUnreachableRoutes is the number of unreachable routes installed
+	// or removed by the IPAM route reconciler, labeled by action
+	IpamUnreachableRoutes = NoOpCounterVec
+
 	// KVstore events
 
 	// KVStoreOperationsDuration records the duration of kvstore operations
@@ -381,6 +551,10 @@ var (
 	// GC job.
 	FQDNGarbageCollectorCleanedTotal = NoOpCounter
 
+	// FQDNProxyDroppedQueries is the number of DNS queries dropped by the
+	// DNS proxy due to per-endpoint limits, labeled by reason
+	FQDNProxyDroppedQueries = NoOpCounterVec
+
 	// BPFSyscallDuration is the metric for bpf syscalls duration.
 	BPFSyscallDuration = NoOpObserverVec
 
@@ -403,6 +577,11 @@ var (
 	// VersionMetric labelled by Cilium version
 	VersionMetric = NoOpGaugeVec
 
+	// FeatureEnabled reports whether an optional agent feature is enabled
+	// (1) or disabled (0), labeled by feature name. The gauge is updated
+	// whenever a feature is toggled at runtime.
+	FeatureEnabled = NoOpGaugeVec
+
 	// APILimiterWaitHistoryDuration is a histogram that measures the
 	// individual wait durations of API limiters
 	APILimiterWaitHistoryDuration = NoOpObserverVec
@@ -434,6 +613,18 @@ var (
 	// ArpingRequestsTotal is the counter of the number of sent
 	// (successful and failed) arping requests
 	ArpingRequestsTotal = NoOpCounterVec
+
+	// ResourceUsageBytes is the self-reported heap usage of agent
+	// subsystems in bytes, labeled by subsystem
+	ResourceUsageBytes = NoOpGaugeVec
+
+	// ResourceUsageGoroutines is the self-reported number of goroutines
+	// run by agent subsystems, labeled by subsystem
+	ResourceUsageGoroutines = NoOpGaugeVec
+
+	// PodStartupStageDuration is the time spent in the individual stages
+	// of the initial pod startup, labeled by stage
+	PodStartupStageDuration = NoOpObserverVec
 )
 
 type Configuration struct {
@@ -441,6 +632,7 @@ type Configuration struct {
 	EndpointRegenerationCountEnabled        bool
 	EndpointStateCountEnabled               bool
 	EndpointRegenerationTimeStatsEnabled    bool
+	EndpointRegenerationsAvoidedEnabled     bool
 	PolicyCountEnabled                      bool
 	PolicyRegenerationCountEnabled          bool
 	PolicyRegenerationTimeStatsEnabled      bool
@@ -448,12 +640,24 @@ type Configuration struct {
 	PolicyImportErrorsEnabled               bool
 	PolicyEndpointStatusEnabled             bool
 	PolicyImplementationDelayEnabled        bool
+	PolicyMapUpdateDelayEnabled             bool
 	IdentityCountEnabled                    bool
 	EventTSK8sEnabled                       bool
 	EventLagK8sEnabled                      bool
 	EventTSContainerdEnabled                bool
 	EventTSAPIEnabled                       bool
+	HubbleLostEventsEnabled                 bool
 	ProxyRedirectsEnabled                   bool
+	ProxyDeploymentModeEnabled              bool
+	ProxyRedirectHealthEnabled              bool
+	ProxyDatapathRulesInstalledEnabled      bool
+	ProxyDatapathRulesPacketsEnabled        bool
+	ProxyDatapathRulesInstallTimeEnabled    bool
+	ProxyXDSStreamReplaysEnabled            bool
+	ProxyServiceRequestsEnabled             bool
+	ProxyServiceRequestDurationEnabled      bool
+	FQDNSelectorIPsEnabled                  bool
+	FQDNSelectorIPChurnEnabled              bool
 	ProxyPolicyL7Enabled                    bool
 	ProxyParseErrorsEnabled                 bool
 	ProxyForwardedEnabled                   bool
@@ -464,6 +668,11 @@ type Configuration struct {
 	DropBytesEnabled                        bool
 	NoOpCounterVecEnabled                   bool
 	ForwardBytesEnabled                     bool
+	ClusterTrafficCountEnabled              bool
+	ClusterTrafficBytesEnabled              bool
+	PriorityBandCountEnabled                bool
+	PriorityBandBytesEnabled                bool
+	PriorityBandQueueDelayEnabled           bool
 	ConntrackGCRunsEnabled                  bool
 	ConntrackGCKeyFallbacksEnabled          bool
 	ConntrackGCSizeEnabled                  bool
@@ -471,6 +680,9 @@ type Configuration struct {
 	ConntrackDumpResetsEnabled              bool
 	SignalsHandledEnabled                   bool
 	ServicesCountEnabled                    bool
+	TerminatingBackendsPreservedEnabled     bool
+	SynFloodDetectedEnabled                 bool
+	SynFloodMitigatedFrontendsEnabled       bool
 	ErrorsWarningsEnabled                   bool
 	ControllerRunsEnabled                   bool
 	ControllerRunsDurationEnabled           bool
@@ -480,11 +692,19 @@ type Configuration struct {
 	KubernetesAPIInteractionsEnabled        bool
 	KubernetesAPICallsEnabled               bool
 	KubernetesCNPStatusCompletionEnabled    bool
+	KubernetesCiliumEndpointRepairsEnabled  bool
 	IpamEventEnabled                        bool
+	IpamAvailableIPsEnabled                 bool
+	IpamPoolAllocationEnabled               bool
+	IpamResourceIPsEnabled                  bool
+	IpamAllocationDurationEnabled           bool
+	IpamAllocationErrorsEnabled             bool
+	IpamUnreachableRoutesEnabled            bool
 	KVStoreOperationsDurationEnabled        bool
 	KVStoreEventsQueueDurationEnabled       bool
 	KVStoreQuorumErrorsEnabled              bool
 	FQDNGarbageCollectorCleanedTotalEnabled bool
+	FQDNProxyDroppedQueriesEnabled          bool
 	BPFSyscallDurationEnabled               bool
 	BPFMapOps                               bool
 	BPFMapPressure                          bool
@@ -492,6 +712,7 @@ type Configuration struct {
 	TriggerPolicyUpdateFolds                bool
 	TriggerPolicyUpdateCallDuration         bool
 	VersionMetric                           bool
+	FeatureEnabled                          bool
 	APILimiterWaitHistoryDuration           bool
 	APILimiterWaitDuration                  bool
 	APILimiterProcessingDuration            bool
@@ -500,6 +721,9 @@ type Configuration struct {
 	APILimiterAdjustmentFactor              bool
 	APILimiterProcessedRequests             bool
 	ArpingRequestsTotalEnabled              bool
+	ResourceUsageBytesEnabled               bool
+	ResourceUsageGoroutinesEnabled          bool
+	PodStartupStageDurationEnabled          bool
 }
 
 func DefaultMetrics() map[string]struct{} {
@@ -508,6 +732,7 @@ func DefaultMetrics() map[string]struct{} {
 		Namespace + "_endpoint_regenerations_total":                                  {},
 		Namespace + "_endpoint_state":                                                {},
 		Namespace + "_endpoint_regeneration_time_stats_seconds":                      {},
+		Namespace + "_endpoint_regenerations_avoided_total":                          {},
 		Namespace + "_policy":                                                        {},
 		Namespace + "_policy_regeneration_total":                                     {},
 		Namespace + "_policy_regeneration_time_stats_seconds":                        {},
@@ -515,9 +740,19 @@ func DefaultMetrics() map[string]struct{} {
 		Namespace + "_policy_import_errors_total":                                    {},
 		Namespace + "_policy_endpoint_enforcement_status":                            {},
 		Namespace + "_policy_implementation_delay":                                   {},
+		Namespace + "_policy_map_update_delay":                                       {},
 		Namespace + "_identity":                                                      {},
 		Namespace + "_event_ts":                                                      {},
+		Namespace + "_hubble_lost_events_total":                                      {},
 		Namespace + "_proxy_redirects":                                               {},
+		Namespace + "_proxy_deployment_mode":                                         {},
+		Namespace + "_proxy_redirect_health":                                         {},
+		Namespace + "_proxy_datapath_rules_installed":                                {},
+		Namespace + "_proxy_datapath_rules_packets":                                  {},
+		Namespace + "_proxy_datapath_rules_install_time_seconds":                     {},
+		Namespace + "_proxy_xds_stream_replays_total":                                {},
+		Namespace + "_proxy_service_requests_total":                                  {},
+		Namespace + "_proxy_service_request_duration_seconds":                        {},
 		Namespace + "_policy_l7_total":                                               {},
 		Namespace + "_policy_l7_parse_errors_total":                                  {},
 		Namespace + "_policy_l7_forwarded_total":                                     {},
@@ -528,6 +763,11 @@ func DefaultMetrics() map[string]struct{} {
 		Namespace + "_drop_bytes_total":                                              {},
 		Namespace + "_forward_count_total":                                           {},
 		Namespace + "_forward_bytes_total":                                           {},
+		Namespace + "_cluster_traffic_count_total":                                   {},
+		Namespace + "_cluster_traffic_bytes_total":                                   {},
+		Namespace + "_priority_band_count_total":                                     {},
+		Namespace + "_priority_band_bytes_total":                                     {},
+		Namespace + "_priority_band_queue_delay_seconds":                             {},
 		Namespace + "_" + SubsystemDatapath + "_conntrack_dump_resets_total":         {},
 		Namespace + "_" + SubsystemDatapath + "_conntrack_gc_runs_total":             {},
 		Namespace + "_" + SubsystemDatapath + "_conntrack_gc_key_fallbacks_total":    {},
@@ -535,6 +775,9 @@ func DefaultMetrics() map[string]struct{} {
 		Namespace + "_" + SubsystemDatapath + "_conntrack_gc_duration_seconds":       {},
 		Namespace + "_" + SubsystemDatapath + "_signals_handled_total":               {},
 		Namespace + "_services_events_total":                                         {},
+		Namespace + "_terminating_backends_preserved_total":                          {},
+		Namespace + "_synflood_detected_total":                                       {},
+		Namespace + "_synflood_mitigated_frontends":                                  {},
 		Namespace + "_errors_warnings_total":                                         {},
 		Namespace + "_controllers_runs_total":                                        {},
 		Namespace + "_controllers_runs_duration_seconds":                             {},
@@ -544,16 +787,19 @@ func DefaultMetrics() map[string]struct{} {
 		Namespace + "_" + SubsystemK8sClient + "_api_latency_time_seconds":           {},
 		Namespace + "_" + SubsystemK8sClient + "_api_calls_total":                    {},
 		Namespace + "_" + SubsystemK8s + "_cnp_status_completion_seconds":            {},
+		Namespace + "_" + SubsystemK8s + "_ciliumendpoint_repairs_total":             {},
 		Namespace + "_ipam_events_total":                                             {},
 		Namespace + "_" + SubsystemKVStore + "_operations_duration_seconds":          {},
 		Namespace + "_" + SubsystemKVStore + "_events_queue_seconds":                 {},
 		Namespace + "_" + SubsystemKVStore + "_quorum_errors_total":                  {},
 		Namespace + "_fqdn_gc_deletions_total":                                       {},
+		Namespace + "_fqdn_proxy_dropped_queries_total":                              {},
 		Namespace + "_" + SubsystemBPF + "_map_ops_total":                            {},
 		Namespace + "_" + SubsystemTriggers + "_policy_update_total":                 {},
 		Namespace + "_" + SubsystemTriggers + "_policy_update_folds":                 {},
 		Namespace + "_" + SubsystemTriggers + "_policy_update_call_duration_seconds": {},
 		Namespace + "_version":                                                       {},
+		Namespace + "_feature_enabled":                                               {},
 		Namespace + "_" + SubsystemAPILimiter + "_wait_duration_seconds":             {},
 		Namespace + "_" + SubsystemAPILimiter + "_processing_duration_seconds":       {},
 		Namespace + "_" + SubsystemAPILimiter + "_requests_in_flight":                {},
@@ -595,6 +841,16 @@ func CreateConfiguration(metricsEnabled []string) (Configuration, []prometheus.C
 			collectors = append(collectors, EndpointRegenerationTotal)
 			c.EndpointRegenerationCountEnabled = true
 
+		case Namespace + "_endpoint_regenerations_avoided_total":
+			EndpointRegenerationsAvoided = prometheus.NewCounter(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "endpoint_regenerations_avoided_total",
+				Help:      "Count of endpoint datapath regenerations avoided by only updating proxy redirect rules",
+			})
+
+			collectors = append(collectors, EndpointRegenerationsAvoided)
+			c.EndpointRegenerationsAvoidedEnabled = true
+
 		case Namespace + "_endpoint_state":
 			EndpointStateCount = prometheus.NewGaugeVec(
 				prometheus.GaugeOpts{
@@ -688,6 +944,16 @@ func CreateConfiguration(metricsEnabled []string) (Configuration, []prometheus.C
 			collectors = append(collectors, PolicyImplementationDelay)
 			c.PolicyImplementationDelayEnabled = true
 
+		case Namespace + "_policy_map_update_delay":
+			PolicyMapUpdateDelay = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Namespace: Namespace,
+				Name:      "policy_map_update_delay",
+				Help:      "Time between a policy change and the corresponding policy map update completing per endpoint",
+			}, []string{LabelPolicySource})
+
+			collectors = append(collectors, PolicyMapUpdateDelay)
+			c.PolicyMapUpdateDelayEnabled = true
+
 		case Namespace + "_identity":
 			Identity = prometheus.NewGauge(prometheus.GaugeOpts{
 				Namespace: Namespace,
@@ -739,6 +1005,16 @@ func CreateConfiguration(metricsEnabled []string) (Configuration, []prometheus.C
 			collectors = append(collectors, EventTSAPI)
 			c.EventTSAPIEnabled = true
 
+		case Namespace + "_hubble_lost_events_total":
+			HubbleLostEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "hubble_lost_events_total",
+				Help:      "Number of monitor events dropped before reaching the Hubble event queue, labeled by priority class",
+			}, []string{LabelEventClass})
+
+			collectors = append(collectors, HubbleLostEvents)
+			c.HubbleLostEventsEnabled = true
+
 		case Namespace + "_proxy_redirects":
 			ProxyRedirects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 				Namespace: Namespace,
@@ -749,6 +1025,106 @@ func CreateConfiguration(metricsEnabled []string) (Configuration, []prometheus.C
 			collectors = append(collectors, ProxyRedirects)
 			c.ProxyRedirectsEnabled = true
 
+		case Namespace + "_proxy_deployment_mode":
+			ProxyDeploymentMode = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "proxy_deployment_mode",
+				Help:      "Proxy deployment mode in use, labeled by mode",
+			}, []string{LabelDeploymentMode})
+
+			collectors = append(collectors, ProxyDeploymentMode)
+			c.ProxyDeploymentModeEnabled = true
+
+		case Namespace + "_proxy_redirect_health":
+			ProxyRedirectHealth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "proxy_redirect_health",
+				Help:      "Health of the listener serving each proxy redirect (1 healthy, 0 unhealthy)",
+			}, []string{LabelProxyRedirect})
+
+			collectors = append(collectors, ProxyRedirectHealth)
+			c.ProxyRedirectHealthEnabled = true
+
+		case Namespace + "_proxy_datapath_rules_installed":
+			ProxyDatapathRulesInstalled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "proxy_datapath_rules_installed",
+				Help:      "Datapath rule status of each acknowledged proxy port (1 installed, 0 missing)",
+			}, []string{LabelProxyListener})
+
+			collectors = append(collectors, ProxyDatapathRulesInstalled)
+			c.ProxyDatapathRulesInstalledEnabled = true
+
+		case Namespace + "_proxy_datapath_rules_packets":
+			ProxyDatapathRulesPackets = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "proxy_datapath_rules_packets",
+				Help:      "Number of packets matched by the datapath rules of each acknowledged proxy port",
+			}, []string{LabelProxyListener})
+
+			collectors = append(collectors, ProxyDatapathRulesPackets)
+			c.ProxyDatapathRulesPacketsEnabled = true
+
+		case Namespace + "_proxy_datapath_rules_install_time_seconds":
+			ProxyDatapathRulesInstallTime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "proxy_datapath_rules_install_time_seconds",
+				Help:      "Unix time of the last successful datapath rule install of each acknowledged proxy port",
+			}, []string{LabelProxyListener})
+
+			collectors = append(collectors, ProxyDatapathRulesInstallTime)
+			c.ProxyDatapathRulesInstallTimeEnabled = true
+
+		case Namespace + "_proxy_xds_stream_replays_total":
+			ProxyXDSStreamReplays = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "proxy_xds_stream_replays_total",
+				Help:      "Number of times the configured resources were replayed on a re-established xDS stream, labeled by resource type",
+			}, []string{LabelXDSResourceType})
+
+			collectors = append(collectors, ProxyXDSStreamReplays)
+			c.ProxyXDSStreamReplaysEnabled = true
+
+		case Namespace + "_proxy_service_requests_total":
+			ProxyServiceRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "proxy_service_requests_total",
+				Help:      "Number of L7 requests observed by the proxy, labeled by service and outcome",
+			}, []string{LabelServiceNamespace, LabelServiceName, LabelOutcome})
+
+			collectors = append(collectors, ProxyServiceRequests)
+			c.ProxyServiceRequestsEnabled = true
+
+		case Namespace + "_proxy_service_request_duration_seconds":
+			ProxyServiceRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Namespace: Namespace,
+				Name:      "proxy_service_request_duration_seconds",
+				Help:      "Time between an L7 request and its response as observed by the proxy, labeled by service",
+			}, []string{LabelServiceNamespace, LabelServiceName})
+
+			collectors = append(collectors, ProxyServiceRequestDuration)
+			c.ProxyServiceRequestDurationEnabled = true
+
+		case Namespace + "_fqdn_selector_ips":
+			FQDNSelectorIPs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "fqdn_selector_ips",
+				Help:      "Number of IPs associated with each ToFQDNs selector",
+			}, []string{LabelFQDNSelector})
+
+			collectors = append(collectors, FQDNSelectorIPs)
+			c.FQDNSelectorIPsEnabled = true
+
+		case Namespace + "_fqdn_selector_ip_churn_total":
+			FQDNSelectorIPChurn = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "fqdn_selector_ip_churn_total",
+				Help:      "Number of IP additions and removals per ToFQDNs selector",
+			}, []string{LabelFQDNSelector})
+
+			collectors = append(collectors, FQDNSelectorIPChurn)
+			c.FQDNSelectorIPChurnEnabled = true
+
 		case Namespace + "_policy_l7_total":
 			ProxyPolicyL7Total = prometheus.NewCounterVec(prometheus.CounterOpts{
 				Namespace: Namespace,
@@ -853,6 +1229,61 @@ func CreateConfiguration(metricsEnabled []string) (Configuration, []prometheus.C
 			collectors = append(collectors, ForwardBytes)
 			c.ForwardBytesEnabled = true
 
+		case Namespace + "_cluster_traffic_count_total":
+			ClusterTrafficCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "cluster_traffic_count_total",
+				Help:      "Total packets exchanged with endpoints of remote clusters, tagged by remote cluster ID and ingress/egress direction",
+			},
+				[]string{LabelClusterID, LabelDirection})
+
+			collectors = append(collectors, ClusterTrafficCount)
+			c.ClusterTrafficCountEnabled = true
+
+		case Namespace + "_cluster_traffic_bytes_total":
+			ClusterTrafficBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "cluster_traffic_bytes_total",
+				Help:      "Total bytes exchanged with endpoints of remote clusters, tagged by remote cluster ID and ingress/egress direction",
+			},
+				[]string{LabelClusterID, LabelDirection})
+
+			collectors = append(collectors, ClusterTrafficBytes)
+			c.ClusterTrafficBytesEnabled = true
+
+		case Namespace + "_priority_band_count_total":
+			PriorityBandCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "priority_band_count_total",
+				Help:      "Total packets scheduled through the bandwidth manager, tagged by egress scheduling band",
+			},
+				[]string{LabelBand})
+
+			collectors = append(collectors, PriorityBandCount)
+			c.PriorityBandCountEnabled = true
+
+		case Namespace + "_priority_band_bytes_total":
+			PriorityBandBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "priority_band_bytes_total",
+				Help:      "Total bytes scheduled through the bandwidth manager, tagged by egress scheduling band",
+			},
+				[]string{LabelBand})
+
+			collectors = append(collectors, PriorityBandBytes)
+			c.PriorityBandBytesEnabled = true
+
+		case Namespace + "_priority_band_queue_delay_seconds":
+			PriorityBandQueueDelay = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "priority_band_queue_delay_seconds",
+				Help:      "Last observed queueing delay of an egress scheduling band, tagged by band",
+			},
+				[]string{LabelBand})
+
+			collectors = append(collectors, PriorityBandQueueDelay)
+			c.PriorityBandQueueDelayEnabled = true
+
 		case Namespace + "_" + SubsystemDatapath + "_conntrack_gc_runs_total":
 			ConntrackGCRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
 				Namespace: Namespace,
@@ -943,6 +1374,36 @@ func CreateConfiguration(metricsEnabled []string) (Configuration, []prometheus.C
 			collectors = append(collectors, ServicesCount)
 			c.ServicesCountEnabled = true
 
+		case Namespace + "_terminating_backends_preserved_total":
+			TerminatingBackendsPreserved = prometheus.NewCounter(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "terminating_backends_preserved_total",
+				Help:      "Number of terminating backends preserved for established connections during service updates",
+			})
+
+			collectors = append(collectors, TerminatingBackendsPreserved)
+			c.TerminatingBackendsPreservedEnabled = true
+
+		case Namespace + "_synflood_detected_total":
+			SynFloodDetected = prometheus.NewCounter(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "synflood_detected_total",
+				Help:      "Number of SYN floods detected toward service frontends",
+			})
+
+			collectors = append(collectors, SynFloodDetected)
+			c.SynFloodDetectedEnabled = true
+
+		case Namespace + "_synflood_mitigated_frontends":
+			SynFloodMitigatedFrontends = prometheus.NewGauge(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "synflood_mitigated_frontends",
+				Help:      "Number of service frontends with an active SYN flood mitigation",
+			})
+
+			collectors = append(collectors, SynFloodMitigatedFrontends)
+			c.SynFloodMitigatedFrontendsEnabled = true
+
 		case Namespace + "_errors_warnings_total":
 			ErrorsWarnings = prometheus.NewCounterVec(prometheus.CounterOpts{
 				Namespace: Namespace,
@@ -1036,6 +1497,17 @@ func CreateConfiguration(metricsEnabled []string) (Configuration, []prometheus.C
 			collectors = append(collectors, KubernetesCNPStatusCompletion)
 			c.KubernetesCNPStatusCompletionEnabled = true
 
+		case Namespace + "_" + SubsystemK8s + "_ciliumendpoint_repairs_total":
+			KubernetesCiliumEndpointRepairs = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Subsystem: SubsystemK8s,
+				Name:      "ciliumendpoint_repairs_total",
+				Help:      "Number of CiliumEndpoint objects repaired because they were out of sync with local endpoint state, labeled by action",
+			}, []string{LabelAction})
+
+			collectors = append(collectors, KubernetesCiliumEndpointRepairs)
+			c.KubernetesCiliumEndpointRepairsEnabled = true
+
 		case Namespace + "_ipam_events_total":
 			IpamEvent = prometheus.NewCounterVec(prometheus.CounterOpts{
 				Namespace: Namespace,
@@ -1046,6 +1518,66 @@ func CreateConfiguration(metricsEnabled []string) (Configuration, []prometheus.C
 			collectors = append(collectors, IpamEvent)
 			c.IpamEventEnabled = true
 
+		case Namespace + "_ipam_available_ips":
+			IpamAvailableIPs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "ipam_available_ips",
+				Help:      "Number of IPs available for allocation in the IPAM pool labeled by datapath family type",
+			}, []string{LabelDatapathFamily})
+
+			collectors = append(collectors, IpamAvailableIPs)
+			c.IpamAvailableIPsEnabled = true
+
+		case Namespace + "_ipam_pool_allocations_total":
+			IpamPoolAllocation = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "ipam_pool_allocations_total",
+				Help:      "Number of successful IPAM allocations labeled by the pool the IPs were drawn from",
+			}, []string{LabelPool})
+
+			collectors = append(collectors, IpamPoolAllocation)
+			c.IpamPoolAllocationEnabled = true
+
+		case Namespace + "_ipam_resource_ips":
+			IpamResourceIPs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "ipam_resource_ips",
+				Help:      "Number of IPs per IPAM pool and underlying resource labeled by state (capacity, used, available)",
+			}, []string{LabelPool, LabelResource, LabelState})
+
+			collectors = append(collectors, IpamResourceIPs)
+			c.IpamResourceIPsEnabled = true
+
+		case Namespace + "_ipam_allocation_duration_seconds":
+			IpamAllocationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Namespace: Namespace,
+				Name:      "ipam_allocation_duration_seconds",
+				Help:      "Duration in seconds of IPAM allocation requests labeled by datapath family type and outcome",
+			}, []string{LabelDatapathFamily, LabelOutcome})
+
+			collectors = append(collectors, IpamAllocationDuration)
+			c.IpamAllocationDurationEnabled = true
+
+		case Namespace + "_ipam_allocation_errors_total":
+			IpamAllocationErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "ipam_allocation_errors_total",
+				Help:      "Number of failed IPAM allocations labeled by error type",
+			}, []string{LabelError})
+
+			collectors = append(collectors, IpamAllocationErrors)
+			c.IpamAllocationErrorsEnabled = true
+
+		case Namespace + "_ipam_unreachable_routes_total":
+			IpamUnreachableRoutes = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "ipam_unreachable_routes_total",
+				Help:      "Number of unreachable routes installed or removed by the IPAM route reconciler labeled by action",
+			}, []string{LabelAction})
+
+			collectors = append(collectors, IpamUnreachableRoutes)
+			c.IpamUnreachableRoutesEnabled = true
+
 		case Namespace + "_" + SubsystemKVStore + "_operations_duration_seconds":
 			KVStoreOperationsDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 				Namespace: Namespace,
@@ -1090,6 +1622,16 @@ func CreateConfiguration(metricsEnabled []string) (Configuration, []prometheus.C
 			collectors = append(collectors, FQDNGarbageCollectorCleanedTotal)
 			c.FQDNGarbageCollectorCleanedTotalEnabled = true
 
+		case Namespace + "_fqdn_proxy_dropped_queries_total":
+			FQDNProxyDroppedQueries = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "fqdn_proxy_dropped_queries_total",
+				Help:      "Number of DNS queries dropped by the DNS proxy due to per-endpoint limits",
+			}, []string{"reason"})
+
+			collectors = append(collectors, FQDNProxyDroppedQueries)
+			c.FQDNProxyDroppedQueriesEnabled = true
+
 		case Namespace + "_" + SubsystemBPF + "_syscall_duration_seconds":
 			BPFSyscallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 				Namespace: Namespace,
@@ -1160,6 +1702,16 @@ func CreateConfiguration(metricsEnabled []string) (Configuration, []prometheus.C
 			collectors = append(collectors, VersionMetric)
 			c.VersionMetric = true
 
+		case Namespace + "_feature_enabled":
+			FeatureEnabled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "feature_enabled",
+				Help:      "Whether an optional agent feature is enabled (1) or disabled (0), labeled by feature name",
+			}, []string{LabelFeature})
+
+			collectors = append(collectors, FeatureEnabled)
+			c.FeatureEnabled = true
+
 		case Namespace + "_" + SubsystemAPILimiter + "_wait_history_duration_seconds":
 			APILimiterWaitHistoryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 				Namespace: Namespace,
@@ -1247,6 +1799,38 @@ func CreateConfiguration(metricsEnabled []string) (Configuration, []prometheus.C
 
 			collectors = append(collectors, ArpingRequestsTotal)
 			c.ArpingRequestsTotalEnabled = true
+
+		case Namespace + "_" + SubsystemAgent + "_resource_usage_bytes":
+			ResourceUsageBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Subsystem: SubsystemAgent,
+				Name:      "resource_usage_bytes",
+				Help:      "Self-reported heap usage of agent subsystems in bytes",
+			}, []string{LabelSubsystem})
+
+			collectors = append(collectors, ResourceUsageBytes)
+			c.ResourceUsageBytesEnabled = true
+
+		case Namespace + "_" + SubsystemAgent + "_resource_usage_goroutines":
+			ResourceUsageGoroutines = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Subsystem: SubsystemAgent,
+				Name:      "resource_usage_goroutines",
+				Help:      "Self-reported number of goroutines run by agent subsystems",
+			}, []string{LabelSubsystem})
+
+			collectors = append(collectors, ResourceUsageGoroutines)
+			c.ResourceUsageGoroutinesEnabled = true
+
+		case Namespace + "_pod_startup_stage_duration_seconds":
+			PodStartupStageDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Namespace: Namespace,
+				Name:      "pod_startup_stage_duration_seconds",
+				Help:      "Time spent in the individual stages of the initial pod startup labeled by the scope",
+			}, []string{LabelScope})
+
+			collectors = append(collectors, PodStartupStageDuration)
+			c.PodStartupStageDurationEnabled = true
 		}
 	}
 
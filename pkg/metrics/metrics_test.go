@@ -10,6 +10,20 @@ import (
 	. "gopkg.in/check.v1"
 )
 
+func (s *MetricsSuite) TestFeatureEnabledConfiguration(c *C) {
+	defer func() { FeatureEnabled = NoOpGaugeVec }()
+
+	cfg, collectors := CreateConfiguration([]string{Namespace + "_feature_enabled"})
+	c.Assert(cfg.FeatureEnabled, Equals, true)
+	c.Assert(collectors, HasLen, 1)
+
+	FeatureEnabled.WithLabelValues("test-feature").Set(1)
+	c.Assert(GetGaugeValue(FeatureEnabled.WithLabelValues("test-feature")), Equals, float64(1))
+
+	FeatureEnabled.WithLabelValues("test-feature").Set(0)
+	c.Assert(GetGaugeValue(FeatureEnabled.WithLabelValues("test-feature")), Equals, float64(0))
+}
+
 func (s *MetricsSuite) TestGaugeWithThreshold(c *C) {
 	threshold := 1.0
 	underThreshold := threshold - 0.5
@@ -26,6 +26,7 @@ var errors = map[uint8]string{
 	9:   "Fragmented packet",
 	10:  "Fragmented packet entry update failed",
 	11:  "Missed tail call to custom program",
+	12:  "Related ICMP error accepted",
 	130: "Invalid source mac",      // Unused
 	131: "Invalid destination mac", // Unused
 	132: "Invalid source ip",
@@ -78,6 +79,10 @@ var errors = map[uint8]string{
 	180: "Proxy redirection not supported for protocol",
 	181: "Policy denied by denylist",
 	182: "VLAN traffic disallowed by VLAN filter",
+	183: "Fault injected for testing",
+	184: "Destination IP not returned by an allowed DNS answer",
+	185: "New connection dropped by SYN flood mitigation",
+	186: "Tunneled protocol denied",
 }
 
 // DropReason prints the drop reason in a human readable string
@@ -225,6 +225,10 @@ const (
 	AgentNotifyIPCacheDeleted
 	AgentNotifyServiceUpserted
 	AgentNotifyServiceDeleted
+	AgentNotifyFQDNCacheUpserted
+	AgentNotifyFQDNCacheExpired
+	AgentNotifyFQDNPolicyVerdict
+	AgentNotifyNodeAddressChanged
 )
 
 // AgentNotifications is a map of all supported agent notification types.
@@ -242,6 +246,10 @@ var AgentNotifications = map[AgentNotification]string{
 	AgentNotifyPolicyDeleted:             "Policy deleted",
 	AgentNotifyServiceDeleted:            "Service deleted",
 	AgentNotifyServiceUpserted:           "Service upserted",
+	AgentNotifyFQDNCacheUpserted:         "FQDN cache entry upserted",
+	AgentNotifyFQDNCacheExpired:          "FQDN cache entry expired",
+	AgentNotifyFQDNPolicyVerdict:         "FQDN policy verdict",
+	AgentNotifyNodeAddressChanged:        "Node address changed",
 }
 
 func resolveAgentType(t AgentNotification) string {
@@ -499,6 +507,86 @@ func ServiceDeleteMessage(id uint32) AgentNotifyMessage {
 	}
 }
 
+// FQDNCacheNotification structures FQDN cache update notifications
+type FQDNCacheNotification struct {
+	EndpointID uint64   `json:"endpoint-id,omitempty"`
+	FQDN       string   `json:"fqdn"`
+	IPs        []string `json:"ips,omitempty"`
+	TTL        int      `json:"ttl,omitempty"`
+}
+
+// FQDNCacheUpsertedMessage constructs an agent notification message for FQDN
+// cache upsertions
+func FQDNCacheUpsertedMessage(endpointID uint64, fqdn string, ips []string, ttl int) AgentNotifyMessage {
+	notification := FQDNCacheNotification{
+		EndpointID: endpointID,
+		FQDN:       fqdn,
+		IPs:        ips,
+		TTL:        ttl,
+	}
+
+	return AgentNotifyMessage{
+		Type:         AgentNotifyFQDNCacheUpserted,
+		Notification: notification,
+	}
+}
+
+// FQDNCacheExpiredMessage constructs an agent notification message for FQDN
+// cache expirations
+func FQDNCacheExpiredMessage(fqdn string) AgentNotifyMessage {
+	notification := FQDNCacheNotification{
+		FQDN: fqdn,
+	}
+
+	return AgentNotifyMessage{
+		Type:         AgentNotifyFQDNCacheExpired,
+		Notification: notification,
+	}
+}
+
+// FQDNPolicyVerdictNotification structures FQDN policy decision notifications
+type FQDNPolicyVerdictNotification struct {
+	EndpointID uint64 `json:"endpoint-id,omitempty"`
+	FQDN       string `json:"fqdn"`
+	Verdict    string `json:"verdict"`
+}
+
+// FQDNPolicyVerdictMessage constructs an agent notification message for the
+// policy decision taken by the DNS proxy for a query
+func FQDNPolicyVerdictMessage(endpointID uint64, fqdn, verdict string) AgentNotifyMessage {
+	notification := FQDNPolicyVerdictNotification{
+		EndpointID: endpointID,
+		FQDN:       fqdn,
+		Verdict:    verdict,
+	}
+
+	return AgentNotifyMessage{
+		Type:         AgentNotifyFQDNPolicyVerdict,
+		Notification: notification,
+	}
+}
+
+// NodeAddressChangeNotification structures notifications for runtime changes
+// of the primary node address
+type NodeAddressChangeNotification struct {
+	OldIP string `json:"old-ip,omitempty"`
+	NewIP string `json:"new-ip"`
+}
+
+// NodeAddressChangeMessage constructs an agent notification message for a
+// runtime change of the primary node address
+func NodeAddressChangeMessage(oldIP, newIP string) AgentNotifyMessage {
+	notification := NodeAddressChangeNotification{
+		OldIP: oldIP,
+		NewIP: newIP,
+	}
+
+	return AgentNotifyMessage{
+		Type:         AgentNotifyNodeAddressChanged,
+		Notification: notification,
+	}
+}
+
 const (
 	// PolicyIngress is the value of Flags&PolicyNotifyFlagDirection for ingress traffic
 	PolicyIngress = 1
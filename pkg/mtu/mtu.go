@@ -62,6 +62,15 @@ const (
 	//                                 ---
 	//      Total extra bytes:         80B
 	WireguardOverhead = 80
+
+	// TCPOverhead is an approximation for the bytes used by the TCP and IP
+	// headers without options. The larger IPv6 header is assumed so that
+	// the resulting segment size is safe for both protocol families:
+	//      IPv6 header: 40B
+	//      TCP header:  20B
+	//                   ---
+	//      Total:       60B
+	TCPOverhead = 60
 )
 
 // Configuration is an MTU configuration as returned by NewConfiguration
@@ -184,6 +193,14 @@ func (c *Configuration) GetRouteMTU() int {
 	return c.tunnelMTU
 }
 
+// GetRouteMSS returns the maximum TCP segment size fitting into the route
+// MTU. It is used to clamp the MSS of connections which are hairpinned
+// through the node, e.g. by the L7 proxy, and would otherwise inherit the
+// potentially larger MTU of the device they terminate on.
+func (c *Configuration) GetRouteMSS() int {
+	return c.GetRouteMTU() - TCPOverhead
+}
+
 // GetDeviceMTU returns the MTU to be used on workload facing devices.
 func (c *Configuration) GetDeviceMTU() int {
 	if c.standardMTU == 0 {
@@ -131,6 +131,20 @@ func InitDefaultPrefix(device string) {
 	}
 }
 
+// AutoDetectIPv4Address re-runs the primary IPv4 node address detection on
+// the given device and returns the detected address without modifying the
+// node state. The current primary address is preferred and returned as long
+// as it remains assigned to the device.
+func AutoDetectIPv4Address(device string) (net.IP, error) {
+	return firstGlobalV4Addr(device, GetIPv4(), preferPublicIP)
+}
+
+// AutoDetectIPv6Address re-runs the primary IPv6 node address detection on
+// the given device. See AutoDetectIPv4Address for more details.
+func AutoDetectIPv6Address(device string) (net.IP, error) {
+	return firstGlobalV6Addr(device, GetIPv6(), preferPublicIP)
+}
+
 // InitNodePortAddrs initializes NodePort IPv{4,6} addrs for the given devices.
 // If inheritIPAddrFromDevice is non-empty, then the IP addr for the devices
 // will be derived from it.
@@ -33,6 +33,16 @@ func (nn Identity) String() string {
 	return path.Join(nn.Cluster, nn.Name)
 }
 
+const (
+	// EncryptionIPsec selects IPsec as the encryption technology applied
+	// towards a node during an encryption migration.
+	EncryptionIPsec = "ipsec"
+
+	// EncryptionWireguard selects WireGuard as the encryption technology
+	// applied towards a node during an encryption migration.
+	EncryptionWireguard = "wireguard"
+)
+
 // ParseCiliumNode parses a CiliumNode custom resource and returns a Node
 // instance. Invalid IP and CIDRs are silently ignored
 func ParseCiliumNode(n *ciliumv2.CiliumNode) (node Node) {
@@ -45,6 +55,8 @@ func ParseCiliumNode(n *ciliumv2.CiliumNode) (node Node) {
 		Labels:          n.ObjectMeta.Labels,
 		NodeIdentity:    uint32(n.Spec.NodeIdentity),
 		WireguardPubKey: n.ObjectMeta.Annotations[annotation.WireguardPubKey],
+
+		EncryptionPreference: n.ObjectMeta.Annotations[annotation.EncryptionTechnology],
 	}
 
 	for _, cidrString := range n.Spec.IPAM.PodCIDRs {
@@ -108,6 +120,10 @@ func (n *Node) ToCiliumNode() *ciliumv2.CiliumNode {
 		annotations[annotation.WireguardPubKey] = n.WireguardPubKey
 	}
 
+	if n.EncryptionPreference != "" {
+		annotations[annotation.EncryptionTechnology] = n.EncryptionPreference
+	}
+
 	return &ciliumv2.CiliumNode{
 		ObjectMeta: v1.ObjectMeta{
 			Name:        n.Name,
@@ -193,6 +209,12 @@ type Node struct {
 
 	// WireguardPubKey is the WireGuard public key of this node
 	WireguardPubKey string
+
+	// EncryptionPreference selects the encryption technology applied
+	// towards this node while both IPsec and WireGuard are enabled during
+	// an encryption migration. See EncryptionIPsec and
+	// EncryptionWireguard.
+	EncryptionPreference string
 }
 
 // Fullname returns the node's full name including the cluster name if a
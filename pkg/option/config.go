@@ -102,6 +102,19 @@ const (
 	// ConntrackGCInterval is the name of the ConntrackGCInterval option
 	ConntrackGCInterval = "conntrack-gc-interval"
 
+	// ConntrackPartitionSelectors is the name of the option to configure
+	// the labels of endpoints whose conntrack state is kept in dedicated
+	// CT map partitions
+	ConntrackPartitionSelectors = "ct-partition-selectors"
+
+	// ConntrackPartitionEntries is the name of the option to configure the
+	// number of entries in each partitioned CT map
+	ConntrackPartitionEntries = "ct-partition-entries"
+
+	// ConntrackPartitionGCInterval is the name of the option to configure
+	// the garbage collection interval for partitioned CT maps
+	ConntrackPartitionGCInterval = "ct-partition-gc-interval"
+
 	// DebugArg is the argument enables debugging mode
 	DebugArg = "debug"
 
@@ -220,6 +233,14 @@ const (
 	// LabelPrefixFile is the valid label prefixes file path
 	LabelPrefixFile = "label-prefix-file"
 
+	// RedactLabelKeys is the list of label key patterns whose label values
+	// are redacted at ingestion time
+	RedactLabelKeys = "redact-label-keys"
+
+	// EnableFlowOverrides enables the API to install temporary flow-level
+	// policy verdict overrides
+	EnableFlowOverrides = "enable-flow-overrides"
+
 	// EnableHostFirewall enables network policies for the host
 	EnableHostFirewall = "enable-host-firewall"
 
@@ -297,12 +318,85 @@ const (
 	// conflicting marks.
 	EnableIdentityMark = "enable-identity-mark"
 
+	// IdentityEncodingMode selects the header field the source security
+	// identity is encoded into on egress to external networks
+	IdentityEncodingMode = "identity-encoding-mode"
+
+	// IdentityEncodingDSCPBits is the number of identity bits encoded
+	// into the DSCP field in dscp identity encoding mode
+	IdentityEncodingDSCPBits = "identity-encoding-dscp-bits"
+
 	// EnableBandwidthManager enables EDT-based pacing
 	EnableBandwidthManager = "enable-bandwidth-manager"
 
+	// EnableNetworkPriority enables mapping of pod network priority class
+	// annotations to EDT scheduling bands
+	EnableNetworkPriority = "enable-network-priority"
+
 	// EnableRecorder enables the datapath pcap recorder
 	EnableRecorder = "enable-recorder"
 
+	// EnableFaultInjection enables the datapath fault injection facility
+	// used by resilience tests
+	EnableFaultInjection = "enable-fault-injection"
+
+	// EnableSynFloodProtection enables detection and mitigation of SYN
+	// floods toward NodePort and LoadBalancer service frontends
+	EnableSynFloodProtection = "enable-syn-flood-protection"
+
+	// EnableTunneledProtoDetection enables dropping of protocols tunneled
+	// through allowed connections, such as DNS carried in ICMP echo
+	// payloads or plaintext DNS on TCP port 443
+	EnableTunneledProtoDetection = "enable-tunneled-protocol-detection"
+
+	// EnableRelatedICMPErrors enables acceptance of ICMP errors related
+	// to an allowed connection as RELATED by policy
+	EnableRelatedICMPErrors = "enable-related-icmp-errors"
+
+	// ToServicesNamespaceScoping restricts toServices label selectors
+	// without an explicit namespace to services in the namespace of the
+	// policy
+	ToServicesNamespaceScoping = "to-services-namespace-scoping"
+
+	// SynFloodThreshold is the number of half-open connections toward a
+	// service frontend above which SYN flood mitigation kicks in
+	SynFloodThreshold = "synflood-threshold"
+
+	// FlowExportCollectors is the list of IPFIX collectors to export
+	// sampled flow records to, an empty list disables the exporter
+	FlowExportCollectors = "flow-export-collectors"
+
+	// FlowExportSamplingRate selects one out of every N datapath packets
+	// for flow export
+	FlowExportSamplingRate = "flow-export-sampling-rate"
+
+	// EnableK8sTerminatingEndpoint keeps terminating-but-serving endpoints
+	// of a service as backends for established connections, so that
+	// existing connections drain instead of being reset during rollouts
+	EnableK8sTerminatingEndpoint = "enable-k8s-terminating-endpoint"
+
+	// StandbyMode makes the agent pre-warm the datapath-independent
+	// bootstrap, then wait and only take over datapath control once the
+	// primary agent on the node dies
+	StandbyMode = "standby-mode"
+
+	// StandbyProbeInterval is the interval between liveness probes of the
+	// primary agent in standby mode
+	StandbyProbeInterval = "standby-probe-interval"
+
+	// ControlPlaneIPv6Only restricts all control-plane communication
+	// (kvstore, clustermesh, health, Hubble) to IPv6 and fails fast when
+	// an IPv4 control-plane address is configured
+	ControlPlaneIPv6Only = "control-plane-ipv6-only"
+
+	// EnableDatapathSelftest enables the packet generator self-test
+	// exercising the datapath at startup
+	EnableDatapathSelftest = "enable-datapath-selftest"
+
+	// FeatureReportInterval is the interval in which the agent publishes
+	// its feature report, 0 disables the report
+	FeatureReportInterval = "feature-report-interval"
+
 	// EnableLocalRedirectPolicy enables support for local redirect policy
 	EnableLocalRedirectPolicy = "enable-local-redirect-policy"
 
@@ -406,6 +500,14 @@ const (
 	// ToFQDNsProxyPort is the global port on which the in-agent DNS proxy should listen. Default 0 is a OS-assigned port.
 	ToFQDNsProxyPort = "tofqdns-proxy-port"
 
+	// ToFQDNsProxyInstances is the number of DNS proxy instances to run on the
+	// shared DNS proxy port.
+	ToFQDNsProxyInstances = "tofqdns-proxy-instances"
+
+	// ExternalDNSProxy runs the DNS proxy in a separate process supervised
+	// by the agent
+	ExternalDNSProxy = "external-dns-proxy"
+
 	// ToFQDNsMaxIPsPerHost defines the maximum number of IPs to maintain
 	// for each FQDN name in an endpoint's FQDN cache
 	ToFQDNsMaxIPsPerHost = "tofqdns-endpoint-max-ip-per-hostname"
@@ -418,6 +520,24 @@ const (
 	// previously active connections with expired DNS lookups are still considered alive
 	ToFQDNsIdleConnectionGracePeriod = "tofqdns-idle-connection-grace-period"
 
+	// ToFQDNsEndpointMaxQPS is the maximum number of DNS queries per second
+	// the DNS proxy forwards for a single endpoint. 0 disables the limit.
+	ToFQDNsEndpointMaxQPS = "tofqdns-endpoint-max-qps"
+
+	// ToFQDNsEndpointMaxConcurrent is the maximum number of concurrent DNS
+	// queries the DNS proxy forwards for a single endpoint. 0 disables the
+	// limit.
+	ToFQDNsEndpointMaxConcurrent = "tofqdns-endpoint-max-concurrent"
+
+	// ToFQDNsEnableDatapathValidation enables dropping egress connections to
+	// world IPs which were not returned by an allowed DNS answer
+	ToFQDNsEnableDatapathValidation = "tofqdns-enable-datapath-validation"
+
+	// ToFQDNsDatapathGracePeriod defines how long the datapath keeps
+	// accepting connections to an IP after the TTL of the DNS answer which
+	// returned it has expired
+	ToFQDNsDatapathGracePeriod = "tofqdns-datapath-grace-period"
+
 	// ToFQDNsPreCache is a path to a file with DNS cache data to insert into the
 	// global cache on startup.
 	// The file is not re-read after agent start.
@@ -637,6 +757,14 @@ const (
 	// EnableAutoDirectRoutingName is the name for the EnableAutoDirectRouting option
 	EnableAutoDirectRoutingName = "auto-direct-node-routes"
 
+	// EnableMultiUplinkEgressName is the name of the option to steer
+	// direct node routes across multiple uplink devices
+	EnableMultiUplinkEgressName = "enable-multi-uplink-egress"
+
+	// MultiUplinkDevicesName is the name of the option listing the uplink
+	// devices eligible for multi-uplink egress
+	MultiUplinkDevicesName = "multi-uplink-devices"
+
 	// EnableIPSecName is the name of the option to enable IPSec
 	EnableIPSecName = "enable-ipsec"
 
@@ -646,6 +774,11 @@ const (
 	// EnableWireguard is the name of the option to enable wireguard
 	EnableWireguard = "enable-wireguard"
 
+	// EnableEncryptionCoexistence is the name of the option allowing IPsec
+	// and wireguard to be enabled at the same time during an encryption
+	// migration
+	EnableEncryptionCoexistence = "enable-encryption-coexistence"
+
 	// KVstoreLeaseTTL is the time-to-live for lease in kvstore.
 	KVstoreLeaseTTL = "kvstore-lease-ttl"
 
@@ -660,6 +793,13 @@ const (
 	// KVstoreConnectivityTimeout is the timeout when performing kvstore operations
 	KVstoreConnectivityTimeout = "kvstore-connectivity-timeout"
 
+	// KVstoreNamespace is an additional prefix prepended to all kvstore keys
+	KVstoreNamespace = "kvstore-namespace"
+
+	// KVstoreNamespaceMigrate enables copying existing unnamespaced kvstore
+	// keys into the configured kvstore namespace on startup
+	KVstoreNamespaceMigrate = "kvstore-namespace-migrate"
+
 	// IPAllocationTimeout is the timeout when allocating CIDRs
 	IPAllocationTimeout = "ip-allocation-timeout"
 
@@ -676,6 +816,9 @@ const (
 	// EnableHealthCheckNodePort is the name of the EnableHealthCheckNodePort option
 	EnableHealthCheckNodePort = "enable-health-check-nodeport"
 
+	// EnableUnreachableRoutes is the name of the EnableUnreachableRoutes option
+	EnableUnreachableRoutes = "enable-unreachable-routes"
+
 	// PolicyQueueSize is the size of the queues utilized by the policy
 	// repository.
 	PolicyQueueSize = "policy-queue-size"
@@ -683,6 +826,10 @@ const (
 	// EndpointQueueSize is the size of the EventQueue per-endpoint.
 	EndpointQueueSize = "endpoint-queue-size"
 
+	// EndpointPrewarmPoolSize is the number of pre-warmed endpoint entries
+	// to keep ready for claiming by new pods.
+	EndpointPrewarmPoolSize = "endpoint-prewarm-pool-size"
+
 	// EndpointGCInterval interval to attempt garbage collection of
 	// endpoints that are no longer alive and healthy.
 	EndpointGCInterval = "endpoint-gc-interval"
@@ -694,6 +841,10 @@ const (
 	// K8sEventHandover is the name of the K8sEventHandover option
 	K8sEventHandover = "enable-k8s-event-handover"
 
+	// EnableK8sAgentEvents enables emission of agent events recorded in
+	// the event journal as Kubernetes events
+	EnableK8sAgentEvents = "enable-k8s-agent-events"
+
 	// Metrics represents the metrics subsystem that Cilium should expose
 	// to prometheus.
 	Metrics = "metrics"
@@ -739,6 +890,26 @@ const (
 	// IPAM is the IPAM method to use
 	IPAM = "ipam"
 
+	// IPAMAllocationStrategy is the strategy to select the next IP to
+	// allocate from the pool
+	IPAMAllocationStrategy = "ipam-allocation-strategy"
+
+	// IPAMDelegatedPlugin is the path of the external CNI IPAM plugin
+	// binary allocation is delegated to in delegated-plugin IPAM mode
+	IPAMDelegatedPlugin = "ipam-delegated-plugin"
+
+	// IPAMWebhookURL is the base URL of the external IPAM webhook called
+	// in webhook IPAM mode
+	IPAMWebhookURL = "ipam-webhook-url"
+
+	// IPAMWebhookLeaseTTL is the lease TTL requested for allocations made
+	// via the external IPAM webhook
+	IPAMWebhookLeaseTTL = "ipam-webhook-lease-ttl"
+
+	// IPAMPoolLowWatermark is the name of the option to configure the low
+	// watermark of free IPs in the IPAM allocation pool
+	IPAMPoolLowWatermark = "ipam-pool-low-watermark"
+
 	// XDPModeNative for loading progs with XDPModeLinkDriver
 	XDPModeNative = "native"
 
@@ -838,6 +1009,11 @@ const (
 	// must contain PEM encoded data.
 	HubbleTLSClientCAFiles = "hubble-tls-client-ca-files"
 
+	// HubbleAuthPolicyFile specifies the path to a JSON file mapping Hubble
+	// client identities to the namespaces they are authorized to observe
+	// flows for. When set, unauthorized clients are rejected.
+	HubbleAuthPolicyFile = "hubble-auth-policy-file"
+
 	// HubbleEventBufferCapacity specifies the capacity of Hubble events buffer.
 	HubbleEventBufferCapacity = "hubble-event-buffer-capacity"
 
@@ -980,6 +1156,28 @@ const (
 	TunnelDisabled = "disabled"
 )
 
+// Available options for DaemonConfig.IdentityEncodingMode
+const (
+	// IdentityEncodingModeDisabled disables encoding the source security
+	// identity into packets egressing to external networks
+	IdentityEncodingModeDisabled = ""
+
+	// IdentityEncodingModeDSCP encodes the low bits of the source
+	// security identity into the low bits of the DSCP field, leaving the
+	// ECN bits untouched. The bit budget is configured with
+	// --identity-encoding-dscp-bits and is at most 6 bits.
+	IdentityEncodingModeDSCP = "dscp"
+
+	// IdentityEncodingModeFlowLabel encodes the low 20 bits of the source
+	// security identity into the IPv6 flow label. IPv4 packets are left
+	// unchanged.
+	IdentityEncodingModeFlowLabel = "flowlabel"
+
+	// IdentityEncodingModeGeneve is reserved for encoding the full 24 bit
+	// identity into a Geneve option and is not implemented yet.
+	IdentityEncodingModeGeneve = "geneve"
+)
+
 // Envoy option names
 const (
 	// HTTPNormalizePath switches on Envoy HTTP path normalization options, which currently
@@ -1013,6 +1211,46 @@ const (
 	// is considered timed out
 	ProxyConnectTimeout = "proxy-connect-timeout"
 
+	// ProxyRedirectCreationAttempts specifies the number of attempts to
+	// create a proxy redirect before failing
+	ProxyRedirectCreationAttempts = "proxy-redirect-creation-attempts"
+
+	// ProxyRedirectCreationBackoff specifies the base interval between
+	// proxy redirect creation attempts
+	ProxyRedirectCreationBackoff = "proxy-redirect-creation-backoff"
+
+	// ProxyRedirectCreationTimeout specifies the maximum time to wait
+	// before a single proxy redirect creation retry
+	ProxyRedirectCreationTimeout = "proxy-redirect-creation-timeout"
+
+	// ProxyPortRanges specifies the proxy port allocation ranges per proxy
+	// type
+	ProxyPortRanges = "proxy-port-ranges"
+
+	// DelegatedIdentityHeaders specifies the request headers carrying a
+	// workload identity delegated from an external service mesh
+	DelegatedIdentityHeaders = "delegated-identity-headers"
+
+	// ProxyAccessLogSinks specifies the sinks L7 access log records are
+	// emitted to in addition to the agent monitor
+	ProxyAccessLogSinks = "proxy-accesslog-sinks"
+
+	// ProxyAccessLogSampleRates specifies the sampling rates of the L7
+	// access log sinks per L7 protocol
+	ProxyAccessLogSampleRates = "proxy-accesslog-sample-rates"
+
+	// EnableIPv6NDPProxy answers neighbor solicitations for local endpoint
+	// IPv6 addresses on the direct routing device
+	EnableIPv6NDPProxy = "enable-ipv6-ndp-proxy"
+
+	// EnableIPv6RouterAdvertisements emits ICMPv6 router advertisements on
+	// endpoint interfaces
+	EnableIPv6RouterAdvertisements = "enable-ipv6-router-advertisements"
+
+	// IPv6RADNSServers is the list of DNS servers advertised in router
+	// advertisements
+	IPv6RADNSServers = "ipv6-ra-dns-servers"
+
 	// ReadCNIConfiguration reads the CNI configuration file and extracts
 	// Cilium relevant information. This can be used to pass per node
 	// configuration to Cilium.
@@ -1044,6 +1282,10 @@ const (
 	// NodePortAlgMaglev is for using maglev consistent hashing for backend selection
 	NodePortAlgMaglev = "maglev"
 
+	// NodePortAlgLatency is for using maglev consistent hashing with backend
+	// weights derived from observed backend latency and connection failures
+	NodePortAlgLatency = "latency"
+
 	// NodePortModeHybrid is a dual mode of the above, that is, DSR for TCP and SNAT for UDP
 	NodePortModeHybrid = "hybrid"
 
@@ -1347,6 +1589,66 @@ type DaemonConfig struct {
 	// connection attempt to have timed out.
 	ProxyConnectTimeout int
 
+	// EnableIPv6NDPProxy is true when the agent should answer neighbor
+	// solicitations for local endpoint IPv6 addresses on the direct routing
+	// device. This allows pod addresses to be reachable over a shared L2
+	// segment with native routing without static neighbor entries on the
+	// peer routers.
+	EnableIPv6NDPProxy bool
+
+	// EnableIPv6RouterAdvertisements is true when the agent should emit
+	// periodic ICMPv6 router advertisements on endpoint interfaces for
+	// workloads that rely on RA-based discovery of the prefix, MTU and DNS
+	// servers. Addresses are still assigned by IPAM.
+	EnableIPv6RouterAdvertisements bool
+
+	// IPv6RADNSServers is the list of recursive DNS servers included in
+	// router advertisements when EnableIPv6RouterAdvertisements is set.
+	IPv6RADNSServers []string
+
+	// ProxyRedirectCreationAttempts is the number of attempts to create a
+	// proxy redirect before endpoint regeneration fails. Retries cover
+	// transient failures such as proxy port bind errors under heavy port
+	// pressure.
+	ProxyRedirectCreationAttempts int
+
+	// ProxyRedirectCreationBackoff is the base interval between proxy
+	// redirect creation attempts. The interval grows exponentially with
+	// each failed attempt and random jitter is applied to avoid
+	// synchronized retries. 0 disables the backoff.
+	ProxyRedirectCreationBackoff time.Duration
+
+	// ProxyRedirectCreationTimeout is the maximum time to wait before a
+	// single proxy redirect creation retry. When the timeout expires
+	// before the backoff interval has passed, redirect creation fails.
+	ProxyRedirectCreationTimeout time.Duration
+
+	// ProxyPortRanges is the list of proxy port allocation ranges per
+	// proxy type, each in the form "<type>=<min>-<max>". Proxy types
+	// without an entry allocate ports from the global range. Restricting
+	// each proxy type to its own range allows for more fine-grained host
+	// firewall rules for the proxy listening ports.
+	ProxyPortRanges []string
+
+	// DelegatedIdentityHeaders is the list of request headers carrying a
+	// workload identity asserted by an external service mesh, e.g. the
+	// SPIFFE ID injected by a sidecar proxy, in order of precedence. When
+	// a matching header is present, its value is preserved in L7 access
+	// log records and Hubble flows instead of being treated as opaque.
+	DelegatedIdentityHeaders []string
+
+	// ProxyAccessLogSinks is the list of sinks L7 access log records are
+	// emitted to in addition to the agent monitor, each in the form
+	// "<type>" or "<type>:<target>", e.g.
+	// "file:/var/log/cilium-access.log" or "syslog".
+	ProxyAccessLogSinks []string
+
+	// ProxyAccessLogSampleRates is the list of sampling rates of the L7
+	// access log sinks, each in the form "<protocol>=<n>" where only one
+	// of every n records of the given L7 protocol is emitted to the
+	// sinks.
+	ProxyAccessLogSampleRates []string
+
 	// ProxyPrometheusPort specifies the port to serve Envoy metrics on.
 	ProxyPrometheusPort int
 
@@ -1401,6 +1703,12 @@ type DaemonConfig struct {
 	// EnableWireguard enables Wireguard encryption
 	EnableWireguard bool
 
+	// EnableEncryptionCoexistence allows IPsec and WireGuard to be enabled
+	// at the same time during an encryption migration. The technology
+	// applied towards a remote node is selected per node via the node
+	// encryption annotation.
+	EnableEncryptionCoexistence bool
+
 	// MonitorQueueSize is the size of the monitor event queue
 	MonitorQueueSize int
 
@@ -1441,6 +1749,7 @@ type DaemonConfig struct {
 	KVStoreOpt                    map[string]string
 	LabelPrefixFile               string
 	Labels                        []string
+	RedactLabelKeys               []string
 	LogDriver                     []string
 	LogOpt                        map[string]string
 	Logstash                      bool
@@ -1480,6 +1789,17 @@ type DaemonConfig struct {
 	// DefaultDNSProxy below.
 	ToFQDNsProxyPort int
 
+	// ToFQDNsProxyInstances is the number of DNS proxy instances listening on
+	// ToFQDNsProxyPort. All instances share the listen port via SO_REUSEPORT
+	// and share their rule state, allowing DNS redirects to scale across CPUs
+	// and to survive the crash of a single listener.
+	ToFQDNsProxyInstances int
+
+	// ExternalDNSProxy runs the DNS proxy in a separate process supervised
+	// by the agent, so that a crash of the proxy does not take down the
+	// agent and vice versa.
+	ExternalDNSProxy bool
+
 	// ToFQDNsMaxIPsPerHost defines the maximum number of IPs to maintain
 	// for each FQDN name in an endpoint's FQDN cache
 	ToFQDNsMaxIPsPerHost int
@@ -1493,6 +1813,24 @@ type DaemonConfig struct {
 	// still considered alive
 	ToFQDNsIdleConnectionGracePeriod time.Duration
 
+	// ToFQDNsEndpointMaxQPS is the maximum number of DNS queries per second
+	// the DNS proxy forwards for a single endpoint. 0 disables the limit.
+	ToFQDNsEndpointMaxQPS int
+
+	// ToFQDNsEndpointMaxConcurrent is the maximum number of concurrent DNS
+	// queries the DNS proxy forwards for a single endpoint. 0 disables the
+	// limit.
+	ToFQDNsEndpointMaxConcurrent int
+
+	// ToFQDNsEnableDatapathValidation enables dropping egress connections
+	// to world IPs which were not returned by an allowed DNS answer
+	ToFQDNsEnableDatapathValidation bool
+
+	// ToFQDNsDatapathGracePeriod is the time the datapath keeps accepting
+	// connections to an IP after the TTL of the DNS answer which returned
+	// it has expired
+	ToFQDNsDatapathGracePeriod time.Duration
+
 	// FQDNRejectResponse is the dns-proxy response for invalid dns-proxy request
 	FQDNRejectResponse string
 
@@ -1523,6 +1861,15 @@ type DaemonConfig struct {
 	// other nodes when available
 	EnableAutoDirectRouting bool
 
+	// EnableMultiUplinkEgress enables probing of the per-CIDR reachability
+	// of remote nodes over the configured uplink devices and steering of
+	// the direct node routes to the best performing uplink.
+	EnableMultiUplinkEgress bool
+
+	// MultiUplinkDevices is the ordered list of uplink devices eligible
+	// for multi-uplink egress, the first device being the preferred one.
+	MultiUplinkDevices []string
+
 	// EnableLocalNodeRoute controls installation of the route which points
 	// the allocation prefix of the local node.
 	EnableLocalNodeRoute bool
@@ -1531,6 +1878,11 @@ type DaemonConfig struct {
 	// health endpoints
 	EnableHealthChecking bool
 
+	// EnableUnreachableRoutes enables reconciling the node routing table
+	// against the IPAM allocation pool, installing unreachable routes for
+	// IPs which are part of the pool but not allocated to any endpoint
+	EnableUnreachableRoutes bool
+
 	// EnableEndpointHealthChecking enables health checking between virtual
 	// health endpoints
 	EnableEndpointHealthChecking bool
@@ -1558,6 +1910,14 @@ type DaemonConfig struct {
 	// KVstoreConnectivityTimeout is the timeout when performing kvstore operations
 	KVstoreConnectivityTimeout time.Duration
 
+	// KVstoreNamespace is an additional prefix prepended to all kvstore keys
+	// to allow multiple logical clusters to share one kvstore
+	KVstoreNamespace string
+
+	// KVstoreNamespaceMigrate enables copying existing unnamespaced kvstore
+	// keys into the configured kvstore namespace on startup
+	KVstoreNamespaceMigrate bool
+
 	// IPAllocationTimeout is the timeout when allocating CIDRs
 	IPAllocationTimeout time.Duration
 
@@ -1578,6 +1938,11 @@ type DaemonConfig struct {
 	// events, specifically those which cause many regenerations.
 	EndpointQueueSize int
 
+	// EndpointPrewarmPoolSize is the number of pre-warmed endpoint entries,
+	// consisting of a veth pair and pre-allocated IPs, kept ready for
+	// claiming by new pods. 0 disables pre-warming.
+	EndpointPrewarmPoolSize int
+
 	// EndpointGCInterval is interval to attempt garbage collection of
 	// endpoints that are no longer alive and healthy.
 	EndpointGCInterval time.Duration
@@ -1593,12 +1958,31 @@ type DaemonConfig struct {
 	// interval
 	ConntrackGCInterval time.Duration
 
+	// CTPartitionSelectors is the list of labels of endpoints whose
+	// conntrack state is kept in dedicated CT map partitions with their
+	// own size and garbage collection settings
+	CTPartitionSelectors []string
+
+	// CTPartitionEntries is the number of entries in each partitioned CT
+	// map. Zero selects the built-in local CT map size.
+	CTPartitionEntries int
+
+	// CTPartitionGCInterval is the garbage collection interval for
+	// partitioned CT maps. Zero ties the partitions to the global CT
+	// garbage collection cadence.
+	CTPartitionGCInterval time.Duration
+
 	// K8sEventHandover enables use of the kvstore to optimize Kubernetes
 	// event handling by listening for k8s events in the operator and
 	// mirroring it into the kvstore for reduced overhead in large
 	// clusters.
 	K8sEventHandover bool
 
+	// EnableK8sAgentEvents enables emission of agent events recorded in
+	// the event journal as Kubernetes events on the CiliumNode resource
+	// of the local node.
+	EnableK8sAgentEvents bool
+
 	// MetricsConfig is the configuration set in metrics
 	MetricsConfig metrics.Configuration
 
@@ -1715,15 +2099,84 @@ type DaemonConfig struct {
 	// EnableBandwidthManager enables EDT-based pacing
 	EnableBandwidthManager bool
 
+	// EnableNetworkPriority enables mapping of pod network priority class
+	// annotations to EDT scheduling bands
+	EnableNetworkPriority bool
+
 	// EnableRecorder enables the datapath pcap recorder
 	EnableRecorder bool
 
+	// EnableFaultInjection enables the datapath fault injection facility
+	// used by resilience tests
+	EnableFaultInjection bool
+
+	// EnableSynFloodProtection enables detection and mitigation of SYN
+	// floods toward NodePort and LoadBalancer service frontends
+	EnableSynFloodProtection bool
+
+	// EnableTunneledProtoDetection enables dropping of protocols tunneled
+	// through allowed connections, such as DNS carried in ICMP echo
+	// payloads or plaintext DNS on TCP port 443
+	EnableTunneledProtoDetection bool
+
+	// EnableRelatedICMPErrors enables acceptance of ICMP errors related
+	// to an allowed connection as RELATED by policy
+	EnableRelatedICMPErrors bool
+
+	// ToServicesNamespaceScoping restricts toServices label selectors
+	// without an explicit namespace to services in the namespace of the
+	// policy
+	ToServicesNamespaceScoping bool
+
+	// SynFloodThreshold is the number of half-open connections toward a
+	// service frontend above which SYN flood mitigation kicks in
+	SynFloodThreshold int
+
+	// FlowExportCollectors is the list of IPFIX collectors to export
+	// sampled flow records to, an empty list disables the exporter
+	FlowExportCollectors []string
+
+	// FlowExportSamplingRate selects one out of every N datapath packets
+	// for flow export
+	FlowExportSamplingRate int
+
+	// EnableK8sTerminatingEndpoint keeps terminating-but-serving endpoints
+	// of a service as backends for established connections, so that
+	// existing connections drain instead of being reset during rollouts
+	EnableK8sTerminatingEndpoint bool
+
+	// StandbyMode makes the agent pre-warm the datapath-independent
+	// bootstrap, then wait and only take over datapath control once the
+	// primary agent on the node dies
+	StandbyMode bool
+
+	// StandbyProbeInterval is the interval between liveness probes of the
+	// primary agent in standby mode
+	StandbyProbeInterval time.Duration
+
+	// ControlPlaneIPv6Only restricts all control-plane communication
+	// (kvstore, clustermesh, health, Hubble) to IPv6 and fails fast when
+	// an IPv4 control-plane address is configured
+	ControlPlaneIPv6Only bool
+
+	// EnableDatapathSelftest enables the packet generator self-test
+	// exercising the datapath at startup
+	EnableDatapathSelftest bool
+
+	// FeatureReportInterval is the interval in which the agent publishes
+	// its feature report, 0 disables the report
+	FeatureReportInterval time.Duration
+
 	// KubeProxyReplacementHealthzBindAddr is the KubeProxyReplacement healthz server bind addr
 	KubeProxyReplacementHealthzBindAddr string
 
 	// EnableExternalIPs enables implementation of k8s services with externalIPs in datapath
 	EnableExternalIPs bool
 
+	// EnableFlowOverrides enables the API to install temporary flow-level
+	// policy verdict overrides
+	EnableFlowOverrides bool
+
 	// EnableHostFirewall enables network policies for the host
 	EnableHostFirewall bool
 
@@ -1751,6 +2204,14 @@ type DaemonConfig struct {
 	// conflicting marks.
 	EnableIdentityMark bool
 
+	// IdentityEncodingMode selects the header field the source security
+	// identity is encoded into on egress to external networks
+	IdentityEncodingMode string
+
+	// IdentityEncodingDSCPBits is the number of identity bits encoded
+	// into the DSCP field in dscp identity encoding mode
+	IdentityEncodingDSCPBits int
+
 	// KernelHz is the HZ rate the kernel is operating in
 	KernelHz int
 
@@ -1767,6 +2228,28 @@ type DaemonConfig struct {
 	// IPAM is the IPAM method to use
 	IPAM string
 
+	// IPAMAllocationStrategy is the strategy to select the next IP to
+	// allocate from the pool, e.g. spreading allocations across ENIs
+	// instead of filling up the first ENI with a free IP
+	IPAMAllocationStrategy string
+
+	// IPAMDelegatedPlugin is the path of the external CNI IPAM plugin
+	// binary allocation is delegated to in delegated-plugin IPAM mode
+	IPAMDelegatedPlugin string
+
+	// IPAMWebhookURL is the base URL of the external IPAM webhook called
+	// in webhook IPAM mode
+	IPAMWebhookURL string
+
+	// IPAMWebhookLeaseTTL is the lease TTL requested for allocations made
+	// via the external IPAM webhook
+	IPAMWebhookLeaseTTL time.Duration
+
+	// IPAMPoolLowWatermark is the number of free IPs in the IPAM
+	// allocation pool below which a warning event is emitted and a pool
+	// refresh is triggered proactively. 0 disables the watermark check.
+	IPAMPoolLowWatermark int
+
 	// AutoCreateCiliumNodeResource enables automatic creation of a
 	// CiliumNode resource for the local node
 	AutoCreateCiliumNodeResource bool
@@ -1839,6 +2322,11 @@ type DaemonConfig struct {
 	// must contain PEM encoded data.
 	HubbleTLSClientCAFiles []string
 
+	// HubbleAuthPolicyFile specifies the path to a JSON file mapping Hubble
+	// client identities to the namespaces they are authorized to observe
+	// flows for. When set, unauthorized clients are rejected.
+	HubbleAuthPolicyFile string
+
 	// HubbleEventBufferCapacity specifies the capacity of Hubble events buffer.
 	HubbleEventBufferCapacity int
 
@@ -2125,6 +2613,12 @@ func (c *DaemonConfig) IPAMMode() string {
 	return strings.ToLower(c.IPAM)
 }
 
+// UnreachableRoutesEnabled returns true if unreachable routes are installed
+// for IPs in the IPAM pool which are not allocated
+func (c *DaemonConfig) UnreachableRoutesEnabled() bool {
+	return c.EnableUnreachableRoutes
+}
+
 // TracingEnabled returns if tracing policy (outlining which rules apply to a
 // specific set of labels) is enabled.
 func (c *DaemonConfig) TracingEnabled() bool {
@@ -2229,6 +2723,25 @@ func (c *DaemonConfig) Validate() error {
 		return fmt.Errorf("invalid tunnel mode '%s', valid modes = {%s}", c.Tunnel, GetTunnelModes())
 	}
 
+	switch c.IdentityEncodingMode {
+	case IdentityEncodingModeDisabled:
+	case IdentityEncodingModeDSCP:
+		if c.IdentityEncodingDSCPBits < 1 || c.IdentityEncodingDSCPBits > 6 {
+			return fmt.Errorf("option --%s must be in range 1..6, the DSCP field has a budget of 6 bits",
+				IdentityEncodingDSCPBits)
+		}
+	case IdentityEncodingModeFlowLabel:
+		if !c.EnableIPv6 {
+			return fmt.Errorf("identity encoding mode '%s' requires IPv6 to be enabled",
+				IdentityEncodingModeFlowLabel)
+		}
+	case IdentityEncodingModeGeneve:
+		return fmt.Errorf("identity encoding mode '%s' is not implemented yet", IdentityEncodingModeGeneve)
+	default:
+		return fmt.Errorf("invalid identity encoding mode '%s', valid modes = {%s, %s}",
+			c.IdentityEncodingMode, IdentityEncodingModeDSCP, IdentityEncodingModeFlowLabel)
+	}
+
 	if c.ClusterID < clustermeshTypes.ClusterIDMin || c.ClusterID > clustermeshTypes.ClusterIDMax {
 		return fmt.Errorf("invalid cluster id %d: must be in range %d..%d",
 			c.ClusterID, clustermeshTypes.ClusterIDMin, clustermeshTypes.ClusterIDMax)
@@ -2393,6 +2906,7 @@ func (c *DaemonConfig) Populate() {
 	c.IPv6MCastDevice = viper.GetString(IPv6MCastDevice)
 	c.EnableIPSec = viper.GetBool(EnableIPSecName)
 	c.EnableWireguard = viper.GetBool(EnableWireguard)
+	c.EnableEncryptionCoexistence = viper.GetBool(EnableEncryptionCoexistence)
 	c.EnableWellKnownIdentities = viper.GetBool(EnableWellKnownIdentities)
 	c.EndpointInterfaceNamePrefix = viper.GetString(EndpointInterfaceNamePrefix)
 	c.DevicePreFilter = viper.GetString(PrefilterDevice)
@@ -2404,10 +2918,13 @@ func (c *DaemonConfig) Populate() {
 	c.EnableBPFTProxy = viper.GetBool(EnableBPFTProxy)
 	c.EnableXTSocketFallback = viper.GetBool(EnableXTSocketFallbackName)
 	c.EnableAutoDirectRouting = viper.GetBool(EnableAutoDirectRoutingName)
+	c.EnableMultiUplinkEgress = viper.GetBool(EnableMultiUplinkEgressName)
+	c.MultiUplinkDevices = viper.GetStringSlice(MultiUplinkDevicesName)
 	c.EnableEndpointRoutes = viper.GetBool(EnableEndpointRoutes)
 	c.EnableHealthChecking = viper.GetBool(EnableHealthChecking)
 	c.EnableEndpointHealthChecking = viper.GetBool(EnableEndpointHealthChecking)
 	c.EnableHealthCheckNodePort = viper.GetBool(EnableHealthCheckNodePort)
+	c.EnableUnreachableRoutes = viper.GetBool(EnableUnreachableRoutes)
 	c.EnableLocalNodeRoute = viper.GetBool(EnableLocalNodeRoute)
 	c.EnablePolicy = strings.ToLower(viper.GetString(EnablePolicy))
 	c.EnableExternalIPs = viper.GetBool(EnableExternalIPs)
@@ -2425,7 +2942,23 @@ func (c *DaemonConfig) Populate() {
 	c.KubeProxyReplacement = viper.GetString(KubeProxyReplacement)
 	c.EnableSessionAffinity = viper.GetBool(EnableSessionAffinity)
 	c.EnableBandwidthManager = viper.GetBool(EnableBandwidthManager)
+	c.EnableNetworkPriority = viper.GetBool(EnableNetworkPriority)
 	c.EnableRecorder = viper.GetBool(EnableRecorder)
+	c.EnableFaultInjection = viper.GetBool(EnableFaultInjection)
+	c.EnableSynFloodProtection = viper.GetBool(EnableSynFloodProtection)
+	c.EnableTunneledProtoDetection = viper.GetBool(EnableTunneledProtoDetection)
+	c.EnableRelatedICMPErrors = viper.GetBool(EnableRelatedICMPErrors)
+	c.ToServicesNamespaceScoping = viper.GetBool(ToServicesNamespaceScoping)
+	c.SynFloodThreshold = viper.GetInt(SynFloodThreshold)
+	c.FlowExportCollectors = viper.GetStringSlice(FlowExportCollectors)
+	c.FlowExportSamplingRate = viper.GetInt(FlowExportSamplingRate)
+	c.EnableK8sTerminatingEndpoint = viper.GetBool(EnableK8sTerminatingEndpoint)
+	c.StandbyMode = viper.GetBool(StandbyMode)
+	c.StandbyProbeInterval = viper.GetDuration(StandbyProbeInterval)
+	c.ControlPlaneIPv6Only = viper.GetBool(ControlPlaneIPv6Only)
+	c.EnableDatapathSelftest = viper.GetBool(EnableDatapathSelftest)
+	c.FeatureReportInterval = viper.GetDuration(FeatureReportInterval)
+	c.EnableFlowOverrides = viper.GetBool(EnableFlowOverrides)
 	c.EnableHostFirewall = viper.GetBool(EnableHostFirewall)
 	c.EnableLocalRedirectPolicy = viper.GetBool(EnableLocalRedirectPolicy)
 	c.EncryptInterface = viper.GetStringSlice(EncryptInterface)
@@ -2441,6 +2974,11 @@ func (c *DaemonConfig) Populate() {
 	c.HTTPRetryTimeout = viper.GetInt(HTTPRetryTimeout)
 	c.IdentityChangeGracePeriod = viper.GetDuration(IdentityChangeGracePeriod)
 	c.IPAM = viper.GetString(IPAM)
+	c.IPAMAllocationStrategy = viper.GetString(IPAMAllocationStrategy)
+	c.IPAMDelegatedPlugin = viper.GetString(IPAMDelegatedPlugin)
+	c.IPAMWebhookURL = viper.GetString(IPAMWebhookURL)
+	c.IPAMWebhookLeaseTTL = viper.GetDuration(IPAMWebhookLeaseTTL)
+	c.IPAMPoolLowWatermark = viper.GetInt(IPAMPoolLowWatermark)
 	c.IPv4Range = viper.GetString(IPv4Range)
 	c.IPv4NodeAddr = viper.GetString(IPv4NodeAddr)
 	c.IPv4ServiceRange = viper.GetString(IPv4ServiceRange)
@@ -2459,6 +2997,7 @@ func (c *DaemonConfig) Populate() {
 	c.K8sRequireIPv6PodCIDR = viper.GetBool(K8sRequireIPv6PodCIDRName)
 	c.K8sServiceCacheSize = uint(viper.GetInt(K8sServiceCacheSize))
 	c.K8sEventHandover = viper.GetBool(K8sEventHandover)
+	c.EnableK8sAgentEvents = viper.GetBool(EnableK8sAgentEvents)
 	c.K8sSyncTimeout = viper.GetDuration(K8sSyncTimeoutName)
 	c.AllocatorListTimeout = viper.GetDuration(AllocatorListTimeoutName)
 	c.K8sWatcherEndpointSelector = viper.GetString(K8sWatcherEndpointSelector)
@@ -2468,10 +3007,13 @@ func (c *DaemonConfig) Populate() {
 	c.KVstoreKeepAliveInterval = c.KVstoreLeaseTTL / defaults.KVstoreKeepAliveIntervalFactor
 	c.KVstorePeriodicSync = viper.GetDuration(KVstorePeriodicSync)
 	c.KVstoreConnectivityTimeout = viper.GetDuration(KVstoreConnectivityTimeout)
+	c.KVstoreNamespace = viper.GetString(KVstoreNamespace)
+	c.KVstoreNamespaceMigrate = viper.GetBool(KVstoreNamespaceMigrate)
 	c.KVstoreMaxConsecutiveQuorumErrors = viper.GetInt(KVstoreMaxConsecutiveQuorumErrorsName)
 	c.IPAllocationTimeout = viper.GetDuration(IPAllocationTimeout)
 	c.LabelPrefixFile = viper.GetString(LabelPrefixFile)
 	c.Labels = viper.GetStringSlice(Labels)
+	c.RedactLabelKeys = viper.GetStringSlice(RedactLabelKeys)
 	c.LibDir = viper.GetString(LibDir)
 	c.LogDriver = viper.GetStringSlice(LogDriver)
 	c.LogSystemLoadConfig = viper.GetBool(LogSystemLoadConfigName)
@@ -2501,6 +3043,16 @@ func (c *DaemonConfig) Populate() {
 	c.PrependIptablesChains = viper.GetBool(PrependIptablesChainsName)
 	c.PrometheusServeAddr = viper.GetString(PrometheusServeAddr)
 	c.ProxyConnectTimeout = viper.GetInt(ProxyConnectTimeout)
+	c.ProxyRedirectCreationAttempts = viper.GetInt(ProxyRedirectCreationAttempts)
+	c.ProxyRedirectCreationBackoff = viper.GetDuration(ProxyRedirectCreationBackoff)
+	c.ProxyRedirectCreationTimeout = viper.GetDuration(ProxyRedirectCreationTimeout)
+	c.ProxyPortRanges = viper.GetStringSlice(ProxyPortRanges)
+	c.DelegatedIdentityHeaders = viper.GetStringSlice(DelegatedIdentityHeaders)
+	c.ProxyAccessLogSinks = viper.GetStringSlice(ProxyAccessLogSinks)
+	c.ProxyAccessLogSampleRates = viper.GetStringSlice(ProxyAccessLogSampleRates)
+	c.EnableIPv6NDPProxy = viper.GetBool(EnableIPv6NDPProxy)
+	c.EnableIPv6RouterAdvertisements = viper.GetBool(EnableIPv6RouterAdvertisements)
+	c.IPv6RADNSServers = viper.GetStringSlice(IPv6RADNSServers)
 	c.ProxyPrometheusPort = viper.GetInt(ProxyPrometheusPort)
 	c.ReadCNIConfiguration = viper.GetString(ReadCNIConfiguration)
 	c.RestoreState = viper.GetBool(Restore)
@@ -2573,6 +3125,8 @@ func (c *DaemonConfig) Populate() {
 
 	c.ClockSource = ClockSourceKtime
 	c.EnableIdentityMark = viper.GetBool(EnableIdentityMark)
+	c.IdentityEncodingMode = viper.GetString(IdentityEncodingMode)
+	c.IdentityEncodingDSCPBits = viper.GetInt(IdentityEncodingDSCPBits)
 
 	// toFQDNs options
 	c.DNSMaxIPsPerRestoredRule = viper.GetInt(DNSMaxIPsPerRestoredRule)
@@ -2590,8 +3144,14 @@ func (c *DaemonConfig) Populate() {
 		c.ToFQDNsMinTTL = defaults.ToFQDNsMinTTL
 	}
 	c.ToFQDNsProxyPort = viper.GetInt(ToFQDNsProxyPort)
+	c.ToFQDNsProxyInstances = viper.GetInt(ToFQDNsProxyInstances)
+	c.ExternalDNSProxy = viper.GetBool(ExternalDNSProxy)
 	c.ToFQDNsPreCache = viper.GetString(ToFQDNsPreCache)
 	c.ToFQDNsEnableDNSCompression = viper.GetBool(ToFQDNsEnableDNSCompression)
+	c.ToFQDNsEnableDatapathValidation = viper.GetBool(ToFQDNsEnableDatapathValidation)
+	c.ToFQDNsEndpointMaxQPS = viper.GetInt(ToFQDNsEndpointMaxQPS)
+	c.ToFQDNsEndpointMaxConcurrent = viper.GetInt(ToFQDNsEndpointMaxConcurrent)
+	c.ToFQDNsDatapathGracePeriod = viper.GetDuration(ToFQDNsDatapathGracePeriod)
 
 	// Convert IP strings into net.IPNet types
 	subnets, invalid := ip.ParseCIDRs(viper.GetStringSlice(IPv4PodSubnets))
@@ -2644,6 +3204,9 @@ func (c *DaemonConfig) Populate() {
 	}
 
 	c.ConntrackGCInterval = viper.GetDuration(ConntrackGCInterval)
+	c.CTPartitionSelectors = viper.GetStringSlice(ConntrackPartitionSelectors)
+	c.CTPartitionEntries = viper.GetInt(ConntrackPartitionEntries)
+	c.CTPartitionGCInterval = viper.GetDuration(ConntrackPartitionGCInterval)
 
 	if m := viper.GetStringMapString(KVStoreOpt); len(m) != 0 {
 		c.KVStoreOpt = m
@@ -2732,6 +3295,7 @@ func (c *DaemonConfig) Populate() {
 	c.HubbleTLSCertFile = viper.GetString(HubbleTLSCertFile)
 	c.HubbleTLSKeyFile = viper.GetString(HubbleTLSKeyFile)
 	c.HubbleTLSClientCAFiles = viper.GetStringSlice(HubbleTLSClientCAFiles)
+	c.HubbleAuthPolicyFile = viper.GetString(HubbleAuthPolicyFile)
 	c.HubbleEventBufferCapacity = viper.GetInt(HubbleEventBufferCapacity)
 	c.HubbleEventQueueSize = viper.GetInt(HubbleEventQueueSize)
 	if c.HubbleEventQueueSize == 0 {
@@ -2757,6 +3321,7 @@ func (c *DaemonConfig) Populate() {
 	c.MaxControllerInterval = viper.GetInt(MaxCtrlIntervalName)
 	c.PolicyQueueSize = sanitizeIntParam(PolicyQueueSize, defaults.PolicyQueueSize)
 	c.EndpointQueueSize = sanitizeIntParam(EndpointQueueSize, defaults.EndpointQueueSize)
+	c.EndpointPrewarmPoolSize = viper.GetInt(EndpointPrewarmPoolSize)
 	c.EndpointGCInterval = viper.GetDuration(EndpointGCInterval)
 	c.SelectiveRegeneration = viper.GetBool(SelectiveRegeneration)
 	c.DisableCNPStatusUpdates = viper.GetBool(DisableCNPStatusUpdates)
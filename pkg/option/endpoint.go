@@ -15,6 +15,7 @@ var (
 		TraceNotify:         &specTraceNotify,
 		PolicyVerdictNotify: &specPolicyVerdictNotify,
 		PolicyAuditMode:     &specPolicyAuditMode,
+		PolicyEnforcement:   &specPolicyEnforcement,
 		MonitorAggregation:  &specMonitorAggregation,
 		NAT46:               &specNAT46,
 	}
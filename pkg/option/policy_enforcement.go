@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package option
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// PolicyEnforcementModeDefault derives the endpoint's policy
+	// enforcement from the daemon policy enforcement mode and the rules
+	// which select the endpoint.
+	PolicyEnforcementModeDefault OptionSetting = 0
+
+	// PolicyEnforcementModeAlways enforces policy on the endpoint in
+	// both directions, regardless of the daemon policy enforcement mode.
+	PolicyEnforcementModeAlways OptionSetting = 1
+
+	// PolicyEnforcementModeNever disables policy enforcement on the
+	// endpoint in both directions, regardless of the daemon policy
+	// enforcement mode and any rules which select the endpoint.
+	PolicyEnforcementModeNever OptionSetting = 2
+)
+
+// policyEnforcementOption maps a user-specified string to a per-endpoint
+// policy enforcement mode.
+var policyEnforcementOption = map[string]OptionSetting{
+	DefaultEnforcement: PolicyEnforcementModeDefault,
+	AlwaysEnforce:      PolicyEnforcementModeAlways,
+	NeverEnforce:       PolicyEnforcementModeNever,
+}
+
+// policyEnforcementFormat maps a per-endpoint policy enforcement mode to its
+// string representation.
+var policyEnforcementFormat = map[OptionSetting]string{
+	PolicyEnforcementModeDefault: DefaultEnforcement,
+	PolicyEnforcementModeAlways:  AlwaysEnforce,
+	PolicyEnforcementModeNever:   NeverEnforce,
+}
+
+// VerifyPolicyEnforcementMode validates the specified key/value for a
+// per-endpoint policy enforcement mode.
+func VerifyPolicyEnforcementMode(key, value string) error {
+	_, err := ParsePolicyEnforcementMode(value)
+	return err
+}
+
+// ParsePolicyEnforcementMode parses a string as a per-endpoint policy
+// enforcement mode.
+func ParsePolicyEnforcementMode(value string) (OptionSetting, error) {
+	if mode, ok := policyEnforcementOption[strings.ToLower(value)]; ok {
+		return mode, nil
+	}
+	return 0, fmt.Errorf("invalid policy enforcement mode %q, must be one of %s, %s or %s",
+		value, DefaultEnforcement, AlwaysEnforce, NeverEnforce)
+}
+
+// FormatPolicyEnforcementMode maps a per-endpoint policy enforcement mode to
+// a user-readable string.
+func FormatPolicyEnforcementMode(value OptionSetting) string {
+	if mode, ok := policyEnforcementFormat[value]; ok {
+		return mode
+	}
+	return "unknown"
+}
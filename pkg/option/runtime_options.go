@@ -21,6 +21,7 @@ const (
 	TraceNotify         = "TraceNotification"
 	PolicyVerdictNotify = "PolicyVerdictNotification"
 	PolicyAuditMode     = "PolicyAuditMode"
+	PolicyEnforcement   = "PolicyEnforcement"
 	MonitorAggregation  = "MonitorAggregationLevel"
 	NAT46               = "NAT46"
 	AlwaysEnforce       = "always"
@@ -87,6 +88,13 @@ var (
 		Description: "Enable audit mode for policies",
 	}
 
+	specPolicyEnforcement = Option{
+		Description: "Set the policy enforcement mode of the endpoint (default, always, never)",
+		Verify:      VerifyPolicyEnforcementMode,
+		Parse:       ParsePolicyEnforcementMode,
+		Format:      FormatPolicyEnforcementMode,
+	}
+
 	specMonitorAggregation = Option{
 		Define:      "MONITOR_AGGREGATION",
 		Description: "Set the level of aggregation for monitor events in the datapath",
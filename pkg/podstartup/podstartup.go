@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+// Package podstartup records a per-pod breakdown of the time spent in the
+// individual stages of CNI ADD processing. Each stage is recorded at most
+// once per pod, so the breakdown reflects the initial pod startup and is not
+// overwritten by later regenerations. The breakdown is served via the
+// /debug/pod-startup HTTP handler on the pprof server and exported as a
+// histogram labeled by stage, so slow pod starts can be attributed to the
+// stage responsible.
+package podstartup
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/metrics"
+)
+
+const (
+	// StageIPAMAllocation is the allocation of the pod IPs.
+	StageIPAMAllocation = "ipamAllocation"
+
+	// StageEndpointCreation is the total handling of the endpoint creation
+	// API request, including all other endpoint-scoped stages.
+	StageEndpointCreation = "endpointCreation"
+
+	// StageIdentityResolution is the resolution of the endpoint labels
+	// into a security identity.
+	StageIdentityResolution = "identityResolution"
+
+	// StagePolicyCalculation is the calculation of the endpoint policy
+	// during the first regeneration.
+	StagePolicyCalculation = "policyCalculation"
+
+	// StageBPFLoad is the compilation and loading of the endpoint BPF
+	// programs during the first regeneration.
+	StageBPFLoad = "bpfLoad"
+
+	// StageProxySetup is the configuration of L7 proxy redirects during
+	// the first regeneration.
+	StageProxySetup = "proxySetup"
+)
+
+// maxPods is the maximum number of pods for which a breakdown is kept. When
+// the limit is reached, the oldest breakdown is evicted.
+const maxPods = 256
+
+// Breakdown maps a stage name to the time spent in that stage.
+type Breakdown map[string]time.Duration
+
+var (
+	mutex      lock.RWMutex
+	breakdowns = make(map[string]Breakdown)
+	order      []string
+)
+
+// RecordStage records the duration of a startup stage for the given pod. Only
+// the first observation of each stage is kept and exported as a metric;
+// subsequent observations for the same pod and stage are ignored so that
+// recurring operations such as regenerations do not distort the startup
+// breakdown.
+func RecordStage(podNamespace, podName, stage string, duration time.Duration) {
+	if podNamespace == "" || podName == "" {
+		return
+	}
+	pod := podNamespace + "/" + podName
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	breakdown, ok := breakdowns[pod]
+	if !ok {
+		for len(order) >= maxPods {
+			delete(breakdowns, order[0])
+			order = order[1:]
+		}
+		breakdown = Breakdown{}
+		breakdowns[pod] = breakdown
+		order = append(order, pod)
+	}
+
+	if _, ok := breakdown[stage]; ok {
+		return
+	}
+	breakdown[stage] = duration
+
+	metrics.PodStartupStageDuration.WithLabelValues(stage).Observe(duration.Seconds())
+}
+
+// GetBreakdowns returns a copy of all recorded breakdowns, keyed by
+// "namespace/name" of the pod.
+func GetBreakdowns() map[string]Breakdown {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	out := make(map[string]Breakdown, len(breakdowns))
+	for pod, breakdown := range breakdowns {
+		copied := make(Breakdown, len(breakdown))
+		for stage, duration := range breakdown {
+			copied[stage] = duration
+		}
+		out[pod] = copied
+	}
+	return out
+}
+
+// EnableHTTPHandler registers the /debug/pod-startup handler on the default
+// HTTP mux served by the pprof server. The handler returns the recorded
+// breakdowns as JSON, with per-stage durations in seconds.
+func EnableHTTPHandler() {
+	http.HandleFunc("/debug/pod-startup", func(w http.ResponseWriter, r *http.Request) {
+		out := map[string]map[string]float64{}
+		for pod, breakdown := range GetBreakdowns() {
+			stages := make(map[string]float64, len(breakdown))
+			for stage, duration := range breakdown {
+				stages[stage] = duration.Seconds()
+			}
+			out[pod] = stages
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		e := json.NewEncoder(w)
+		e.SetIndent("", "  ")
+		e.Encode(out)
+	})
+}
@@ -12,7 +12,7 @@ import (
 // individual identities.  Entities are used to describe "outside of cluster",
 // "host", etc.
 //
-// +kubebuilder:validation:Enum=all;world;cluster;host;init;unmanaged;remote-node;health;none
+// +kubebuilder:validation:Enum=all;world;cluster;host;init;unmanaged;remote-node;health;none;kube-apiserver
 type Entity string
 
 const (
@@ -42,6 +42,9 @@ const (
 	// EntityHealth is an entity that represents all health endpoints.
 	EntityHealth Entity = "health"
 
+	// EntityKubeAPIServer is an entity that represents the kube-apiserver.
+	EntityKubeAPIServer Entity = "kube-apiserver"
+
 	// EntityNone is an entity that can be selected but never exist
 	EntityNone Entity = "none"
 )
@@ -57,6 +60,8 @@ var (
 
 	endpointSelectorHealth = NewESFromLabels(labels.NewLabel(labels.IDNameHealth, "", labels.LabelSourceReserved))
 
+	endpointSelectorKubeAPIServer = NewESFromLabels(labels.LabelKubeAPIServer[labels.IDNameKubeAPIServer])
+
 	EndpointSelectorNone = NewESFromLabels(labels.NewLabel(labels.IDNameNone, "", labels.LabelSourceReserved))
 
 	endpointSelectorUnmanaged = NewESFromLabels(labels.NewLabel(labels.IDNameUnmanaged, "", labels.LabelSourceReserved))
@@ -64,14 +69,15 @@ var (
 	// EntitySelectorMapping maps special entity names that come in
 	// policies to selectors
 	EntitySelectorMapping = map[Entity]EndpointSelectorSlice{
-		EntityAll:        {WildcardEndpointSelector},
-		EntityWorld:      {endpointSelectorWorld},
-		EntityHost:       {endpointSelectorHost},
-		EntityInit:       {endpointSelectorInit},
-		EntityRemoteNode: {endpointSelectorRemoteNode},
-		EntityHealth:     {endpointSelectorHealth},
-		EntityUnmanaged:  {endpointSelectorUnmanaged},
-		EntityNone:       {EndpointSelectorNone},
+		EntityAll:           {WildcardEndpointSelector},
+		EntityWorld:         {endpointSelectorWorld},
+		EntityHost:          {endpointSelectorHost},
+		EntityInit:          {endpointSelectorInit},
+		EntityRemoteNode:    {endpointSelectorRemoteNode},
+		EntityHealth:        {endpointSelectorHealth},
+		EntityUnmanaged:     {endpointSelectorUnmanaged},
+		EntityNone:          {EndpointSelectorNone},
+		EntityKubeAPIServer: {endpointSelectorKubeAPIServer},
 
 		// EntityCluster is populated with an empty entry to allow the
 		// cilium client importing this package to perform basic rule
@@ -106,6 +112,7 @@ func InitEntities(clusterName string, treatRemoteNodeAsHost bool) {
 	EntitySelectorMapping[EntityCluster] = EndpointSelectorSlice{
 		endpointSelectorHost,
 		endpointSelectorRemoteNode,
+		endpointSelectorKubeAPIServer,
 		endpointSelectorInit,
 		endpointSelectorHealth,
 		endpointSelectorUnmanaged,
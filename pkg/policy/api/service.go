@@ -25,4 +25,8 @@ type K8sServiceSelectorNamespace struct {
 	// +kubebuilder:validation:Required
 	Selector  ServiceSelector `json:"selector"`
 	Namespace string          `json:"namespace,omitempty"`
+
+	// Namespaces selects services in any of the listed namespaces. When
+	// set, Namespace is ignored.
+	Namespaces []string `json:"namespaces,omitempty"`
 }
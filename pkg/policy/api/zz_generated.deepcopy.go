@@ -571,6 +571,11 @@ func (in *K8sServiceNamespace) DeepCopy() *K8sServiceNamespace {
 func (in *K8sServiceSelectorNamespace) DeepCopyInto(out *K8sServiceSelectorNamespace) {
 	*out = *in
 	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
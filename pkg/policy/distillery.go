@@ -4,12 +4,16 @@
 package policy
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"sync/atomic"
 	"unsafe"
 
 	identityPkg "github.com/cilium/cilium/pkg/identity"
 	"github.com/cilium/cilium/pkg/identity/identitymanager"
+	k8sConst "github.com/cilium/cilium/pkg/k8s/apis/cilium.io"
+	"github.com/cilium/cilium/pkg/labels"
 	"github.com/cilium/cilium/pkg/lock"
 )
 
@@ -32,13 +36,31 @@ type PolicyCache struct {
 	// collected.
 	repo     *Repository
 	policies map[identityPkg.NumericIdentity]*cachedSelectorPolicy
+
+	// artifacts is a content-addressed cache of computed selectorPolicies,
+	// keyed by the fingerprint of the (revision, selected rule set) they
+	// were resolved from. Identities selecting the identical set of rules
+	// share a single selectorPolicy through this map instead of resolving
+	// their own copy, bounding policy computation by the number of distinct
+	// rule sets rather than the number of identities. Entries are reference
+	// counted and detached from the selector cache once the last identity
+	// using them is released.
+	artifacts map[string]*policyArtifact
+}
+
+// policyArtifact is a reference counted selectorPolicy shared by all
+// identities whose fingerprint matches the one it was computed for.
+type policyArtifact struct {
+	policy *selectorPolicy
+	refs   int
 }
 
 // NewPolicyCache creates a new cache of SelectorPolicy.
 func NewPolicyCache(repo *Repository, subscribe bool) *PolicyCache {
 	cache := &PolicyCache{
-		repo:     repo,
-		policies: make(map[identityPkg.NumericIdentity]*cachedSelectorPolicy),
+		repo:      repo,
+		policies:  make(map[identityPkg.NumericIdentity]*cachedSelectorPolicy),
+		artifacts: make(map[string]*policyArtifact),
 	}
 	if subscribe {
 		identitymanager.Subscribe(cache)
@@ -78,11 +100,52 @@ func (cache *PolicyCache) delete(identity *identityPkg.Identity) bool {
 	cip, ok := cache.policies[identity.ID]
 	if ok {
 		delete(cache.policies, identity.ID)
-		cip.getPolicy().Detach()
+		cache.releasePolicyLocked(cip.getPolicy())
 	}
 	return ok
 }
 
+// releasePolicyLocked releases the reference the caller holds on the given
+// selectorPolicy. Policies shared through the artifact cache are detached
+// from the selector cache only when the last reference is released, policies
+// never inserted into the artifact cache are detached immediately.
+//
+// Must be called with cache.Mutex held.
+func (cache *PolicyCache) releasePolicyLocked(policy *selectorPolicy) {
+	if policy == nil {
+		return
+	}
+	if policy.fingerprint == "" {
+		policy.Detach()
+		return
+	}
+	artifact, ok := cache.artifacts[policy.fingerprint]
+	if !ok {
+		// Unreachable as long as references are balanced, but leaking the
+		// artifact is preferable to detaching a policy still in use.
+		return
+	}
+	artifact.refs--
+	if artifact.refs == 0 {
+		delete(cache.artifacts, policy.fingerprint)
+		artifact.policy.Detach()
+	}
+}
+
+// policyFingerprint returns the content address of the policy computation
+// inputs for an identity: the repository revision, the enforcement state, the
+// namespace the policy is resolved in and the set of rules selecting the
+// identity. Rules are canonical shared objects within the repository, so
+// their addresses identify the rule set content for a given revision.
+func policyFingerprint(revision uint64, ingressEnabled, egressEnabled bool, ns string, matchingRules ruleSlice) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d/%t/%t/%s", revision, ingressEnabled, egressEnabled, ns)
+	for _, r := range matchingRules {
+		fmt.Fprintf(h, "/%p", r)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // updateSelectorPolicy resolves the policy for the security identity of the
 // specified endpoint and stores it internally. It will skip policy resolution
 // if the cached policy is already at the revision specified in the repo.
@@ -115,11 +178,43 @@ func (cache *PolicyCache) updateSelectorPolicy(identity *identityPkg.Identity) (
 		return false, nil
 	}
 
+	// Determine the rules selecting this identity and look for an existing
+	// artifact computed from the identical rule set by another identity.
+	ingressEnabled, egressEnabled, matchingRules := cache.repo.computePolicyEnforcementAndRules(identity)
+	ns := identity.LabelArray.Get(labels.LabelSourceK8sKeyPrefix + k8sConst.PodNamespaceLabel)
+	fingerprint := policyFingerprint(cache.repo.GetRevision(), ingressEnabled, egressEnabled, ns, matchingRules)
+
+	cache.Lock()
+	if artifact, ok := cache.artifacts[fingerprint]; ok {
+		artifact.refs++
+		cache.releasePolicyLocked(cip.getPolicy())
+		cache.Unlock()
+		cip.setPolicy(artifact.policy)
+		return true, nil
+	}
+	cache.Unlock()
+
 	// Resolve the policies, which could fail
-	selPolicy, err := cache.repo.resolvePolicyLocked(identity)
+	selPolicy, err := cache.repo.resolveSelectorPolicy(identity, ingressEnabled, egressEnabled, matchingRules)
 	if err != nil {
 		return false, err
 	}
+	selPolicy.fingerprint = fingerprint
+
+	cache.Lock()
+	if artifact, ok := cache.artifacts[fingerprint]; ok {
+		// Another identity with the same fingerprint raced us to resolve
+		// the same artifact, use theirs and discard ours.
+		artifact.refs++
+		cache.releasePolicyLocked(cip.getPolicy())
+		cache.Unlock()
+		selPolicy.Detach()
+		cip.setPolicy(artifact.policy)
+		return true, nil
+	}
+	cache.artifacts[fingerprint] = &policyArtifact{policy: selPolicy, refs: 1}
+	cache.releasePolicyLocked(cip.getPolicy())
+	cache.Unlock()
 
 	cip.setPolicy(selPolicy)
 
@@ -180,13 +275,11 @@ func (cip *cachedSelectorPolicy) getPolicy() *selectorPolicy {
 }
 
 // setPolicy updates the reference to the SelectorPolicy that is cached.
-// Calls Detach() on the old policy, if any.
+// The caller is responsible for releasing the previously cached policy, via
+// PolicyCache.releasePolicyLocked() for policies shared through the artifact
+// cache.
 func (cip *cachedSelectorPolicy) setPolicy(policy *selectorPolicy) {
-	oldPolicy := (*selectorPolicy)(atomic.SwapPointer(&cip.policy, unsafe.Pointer(policy)))
-	if oldPolicy != nil {
-		// Release the references the previous policy holds on the selector cache.
-		oldPolicy.Detach()
-	}
+	atomic.SwapPointer(&cip.policy, unsafe.Pointer(policy))
 }
 
 // Consume returns the EndpointPolicy that defines connectivity policy to
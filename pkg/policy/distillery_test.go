@@ -129,6 +129,40 @@ func (s *DistilleryTestSuite) TestCachePopulation(c *C) {
 	//c.Assert(err, NotNil)
 }
 
+func (s *DistilleryTestSuite) TestCacheArtifactSharing(c *C) {
+	repo := NewPolicyRepository(nil, nil)
+	repo.revision = 42
+	cache := repo.policyCache
+
+	// Two distinct identities selecting the identical (empty) rule set.
+	identity1 := ep1.GetSecurityIdentity()
+	ep3 := testutils.NewTestEndpoint()
+	ep3.SetIdentity(1234, true)
+	identity3 := ep3.GetSecurityIdentity()
+	c.Assert(identity3, Not(Equals), identity1)
+
+	cache.insert(identity1)
+	cache.insert(identity3)
+	_, err := cache.updateSelectorPolicy(identity1)
+	c.Assert(err, IsNil)
+	_, err = cache.updateSelectorPolicy(identity3)
+	c.Assert(err, IsNil)
+
+	// Both identities share a single computed artifact.
+	idp1 := cache.policies[identity1.ID].getPolicy()
+	idp3 := cache.policies[identity3.ID].getPolicy()
+	c.Assert(idp1, Equals, idp3)
+	c.Assert(len(cache.artifacts), Equals, 1)
+	c.Assert(cache.artifacts[idp1.fingerprint].refs, Equals, 2)
+
+	// The artifact is only released with its last user.
+	cache.delete(identity1)
+	c.Assert(len(cache.artifacts), Equals, 1)
+	c.Assert(cache.artifacts[idp3.fingerprint].refs, Equals, 1)
+	cache.delete(identity3)
+	c.Assert(len(cache.artifacts), Equals, 0)
+}
+
 //
 // Distillery integration tests
 //
@@ -14,6 +14,7 @@ import (
 	"unsafe"
 
 	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/annotation"
 	"github.com/cilium/cilium/pkg/iana"
 	"github.com/cilium/cilium/pkg/identity"
 	"github.com/cilium/cilium/pkg/labels"
@@ -241,6 +242,10 @@ const (
 	ParserTypeKafka L7ParserType = "kafka"
 	// ParserTypeDNS specifies a DNS parser type
 	ParserTypeDNS L7ParserType = "dns"
+	// ParserTypeTCP specifies a TCP proxy without any L7 parsing, used for
+	// connection-level observability and policy for protocols Cilium can
+	// not parse
+	ParserTypeTCP L7ParserType = "tcp"
 )
 
 // L4Filter represents the policy (allowed remote sources / destinations of
@@ -302,6 +307,36 @@ func (l4 *L4Filter) GetL7Parser() L7ParserType {
 	return l4.L7Parser
 }
 
+// EqualExceptL7 returns true if 'l4' and 'o' select the same traffic and
+// redirect it in the same way, with at most the content of the L7 rules
+// passed to the proxy differing. TLS contexts, deny state and whether a
+// selector redirects at all must match, as changes to those require
+// datapath regeneration.
+func (l4 *L4Filter) EqualExceptL7(o *L4Filter) bool {
+	if l4.Port != o.Port || l4.PortName != o.PortName ||
+		l4.Protocol != o.Protocol || l4.L7Parser != o.L7Parser ||
+		l4.Ingress != o.Ingress ||
+		len(l4.L7RulesPerSelector) != len(o.L7RulesPerSelector) {
+		return false
+	}
+	for cs, l7 := range l4.L7RulesPerSelector {
+		ol7, ok := o.L7RulesPerSelector[cs]
+		if !ok || (l7 == nil) != (ol7 == nil) {
+			return false
+		}
+		if l7 == nil {
+			continue
+		}
+		if l7.IsDeny != ol7.IsDeny ||
+			l7.IsRedirect() != ol7.IsRedirect() ||
+			!l7.TerminatingTLS.Equal(ol7.TerminatingTLS) ||
+			!l7.OriginatingTLS.Equal(ol7.OriginatingTLS) {
+			return false
+		}
+	}
+	return true
+}
+
 // GetIngress returns whether the L4Filter applies at ingress or egress.
 func (l4 *L4Filter) GetIngress() bool {
 	return l4.Ingress
@@ -312,6 +347,22 @@ func (l4 *L4Filter) GetPort() uint16 {
 	return uint16(l4.Port)
 }
 
+// GetPreserveOriginalSourceAddr returns whether proxy redirects created for
+// the L4Filter should preserve the original source address of redirected
+// connections. Returns false only when one of the rules the filter was
+// derived from requests SNAT to the proxy address via the
+// io.cilium.proxy-original-source-addr annotation.
+func (l4 *L4Filter) GetPreserveOriginalSourceAddr() bool {
+	for _, lbls := range l4.DerivedFromRules {
+		for _, lbl := range lbls {
+			if lbl.Key == annotation.ProxyOriginalSourceAddr && lbl.Value == ProxySourceAddrSNAT {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // ToMapState converts filter into a MapState with two possible values:
 // - Entry with ProxyPort = 0: No proxy redirection is needed for this key
 // - Entry with any other port #: Proxy redirection is required for this key,
@@ -709,7 +760,7 @@ func (l4 *L4Filter) IsEnvoyRedirect() bool {
 
 // IsProxylibRedirect returns true if the L4 filter contains a port redirected to Proxylib (via Envoy)
 func (l4 *L4Filter) IsProxylibRedirect() bool {
-	return l4.IsEnvoyRedirect() && l4.L7Parser != ParserTypeHTTP
+	return l4.IsEnvoyRedirect() && l4.L7Parser != ParserTypeHTTP && l4.L7Parser != ParserTypeTCP
 }
 
 // MarshalIndent returns the `L4Filter` in indented JSON string.
@@ -810,6 +861,21 @@ func (l4 L4PolicyMap) Attach(ctx PolicyContext, l4Policy *L4Policy) {
 	}
 }
 
+// equalExceptL7 returns true if both maps contain the same L4 filters,
+// with at most the L7 rules of the filters differing.
+func (l4 L4PolicyMap) equalExceptL7(o L4PolicyMap) bool {
+	if len(l4) != len(o) {
+		return false
+	}
+	for k, filter := range l4 {
+		oFilter, ok := o[k]
+		if !ok || !filter.EqualExceptL7(oFilter) {
+			return false
+		}
+	}
+	return true
+}
+
 // HasRedirect returns true if at least one L4 filter contains a port
 // redirection
 func (l4 L4PolicyMap) HasRedirect() bool {
@@ -1086,6 +1152,17 @@ func (l4 *L4Policy) GetModel() *models.L4Policy {
 	}
 }
 
+const (
+	// ProxySourceAddrPreserve requests that the L7 proxy preserves the
+	// original source address of redirected connections. This is the
+	// default when supported by the datapath.
+	ProxySourceAddrPreserve = "preserve"
+
+	// ProxySourceAddrSNAT requests that the L7 proxy translates the source
+	// address of redirected connections to the proxy address.
+	ProxySourceAddrSNAT = "snat"
+)
+
 // ProxyPolicy is any type which encodes state needed to redirect to an L7
 // proxy.
 type ProxyPolicy interface {
@@ -1093,4 +1170,5 @@ type ProxyPolicy interface {
 	GetL7Parser() L7ParserType
 	GetIngress() bool
 	GetPort() uint16
+	GetPreserveOriginalSourceAddr() bool
 }
@@ -10,6 +10,7 @@ import (
 	"sort"
 
 	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/annotation"
 	"github.com/cilium/cilium/pkg/checker"
 	"github.com/cilium/cilium/pkg/labels"
 	"github.com/cilium/cilium/pkg/policy/api"
@@ -52,6 +53,24 @@ func (s *PolicyTestSuite) TestCreateL4Filter(c *C) {
 	}
 }
 
+func (s *PolicyTestSuite) TestGetPreserveOriginalSourceAddr(c *C) {
+	filter := &L4Filter{}
+	c.Assert(filter.GetPreserveOriginalSourceAddr(), Equals, true)
+
+	filter.DerivedFromRules = labels.LabelArrayList{
+		labels.LabelArray{
+			labels.NewLabel(annotation.ProxyOriginalSourceAddr, ProxySourceAddrPreserve, labels.LabelSourceK8s),
+		},
+	}
+	c.Assert(filter.GetPreserveOriginalSourceAddr(), Equals, true)
+
+	filter.DerivedFromRules = append(filter.DerivedFromRules,
+		labels.LabelArray{
+			labels.NewLabel(annotation.ProxyOriginalSourceAddr, ProxySourceAddrSNAT, labels.LabelSourceK8s),
+		})
+	c.Assert(filter.GetPreserveOriginalSourceAddr(), Equals, false)
+}
+
 func (s *PolicyTestSuite) TestCreateL4FilterMissingSecret(c *C) {
 	tuple := api.PortProtocol{Port: "80", Protocol: api.ProtoTCP}
 	portrule := &api.PortRule{
@@ -27,8 +27,10 @@ type PortProto struct {
 	Proto uint8  // 0 for any
 }
 
-// NamedPortMap maps port names to port numbers and protocols.
-type NamedPortMap map[string]PortProto
+// NamedPortMap maps port names to the sets of port numbers and protocols
+// declared under the name. A name may have multiple entries when it is
+// declared for multiple protocols, e.g. both TCP and UDP.
+type NamedPortMap map[string]PortProtoSet
 
 // PortProtoSet is a set of unique PortProto values.
 type PortProtoSet map[PortProto]struct{}
@@ -96,7 +98,8 @@ func newPortProto(port int, protocol string) (pp PortProto, err error) {
 	}, nil
 }
 
-// AddPort adds a new PortProto to the NamedPortMap
+// AddPort adds a new PortProto to the NamedPortMap. Multiple entries may be
+// added under the same name when the name is declared for multiple protocols.
 func (npm NamedPortMap) AddPort(name string, port int, protocol string) error {
 	name, err := ValidatePortName(name)
 	if err != nil {
@@ -106,39 +109,18 @@ func (npm NamedPortMap) AddPort(name string, port int, protocol string) error {
 	if err != nil {
 		return err
 	}
-	npm[name] = pp
-	return nil
-}
-
-// GetNamedPort returns the port number for the named port, if any.
-func (npm NamedPortMap) GetNamedPort(name string, proto uint8) (uint16, error) {
-	if npm == nil {
-		return 0, ErrNilMap
-	}
-	pp, ok := npm[name]
+	pps, ok := npm[name]
 	if !ok {
-		return 0, ErrUnknownNamedPort
-	}
-	if pp.Proto != 0 && proto != pp.Proto {
-		return 0, ErrIncompatibleProtocol
-	}
-	if pp.Port == 0 {
-		return 0, ErrNamedPortIsZero
+		pps = make(PortProtoSet, 1)
+		npm[name] = pps
 	}
-	return pp.Port, nil
+	pps[pp] = struct{}{}
+	return nil
 }
 
-// GetNamedPort returns the port number for the named port, if any.
-func (npm NamedPortMultiMap) GetNamedPort(name string, proto uint8) (uint16, error) {
-	if npm == nil {
-		return 0, ErrNilMap
-	}
-	pps, ok := npm[name]
-	if !ok {
-		// Return an error the caller can filter out as this happens only for egress policy
-		// and it is likely the destination POD with the port name is simply not scheduled yet.
-		return 0, ErrUnknownNamedPort
-	}
+// getNamedPort returns the single port number of the set compatible with the
+// given protocol, if any.
+func (pps PortProtoSet) getNamedPort(proto uint8) (uint16, error) {
 	// Find if there is a single port that has no proto conflict and no zero port value
 	port := uint16(0)
 	err := ErrUnknownNamedPort
@@ -161,3 +143,29 @@ func (npm NamedPortMultiMap) GetNamedPort(name string, proto uint8) (uint16, err
 	}
 	return port, nil
 }
+
+// GetNamedPort returns the port number for the named port, if any.
+func (npm NamedPortMap) GetNamedPort(name string, proto uint8) (uint16, error) {
+	if npm == nil {
+		return 0, ErrNilMap
+	}
+	pps, ok := npm[name]
+	if !ok {
+		return 0, ErrUnknownNamedPort
+	}
+	return pps.getNamedPort(proto)
+}
+
+// GetNamedPort returns the port number for the named port, if any.
+func (npm NamedPortMultiMap) GetNamedPort(name string, proto uint8) (uint16, error) {
+	if npm == nil {
+		return 0, ErrNilMap
+	}
+	pps, ok := npm[name]
+	if !ok {
+		// Return an error the caller can filter out as this happens only for egress policy
+		// and it is likely the destination POD with the port name is simply not scheduled yet.
+		return 0, ErrUnknownNamedPort
+	}
+	return pps.getNamedPort(proto)
+}
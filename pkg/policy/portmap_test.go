@@ -80,9 +80,27 @@ func (ds *PolicyTestSuite) TestPolicyNamedPortMap(c *C) {
 	c.Assert(err, Equals, ErrIncompatibleProtocol)
 	c.Assert(port, Equals, uint16(0))
 
+	// The same name can be declared for multiple protocols.
+	err = npm.AddPort("dns", 53, "TCP")
+	c.Assert(err, IsNil)
+	c.Assert(npm, HasLen, 2)
+	c.Assert(npm["dns"], HasLen, 2)
+
+	port, err = npm.GetNamedPort("dns", uint8(6))
+	c.Assert(err, IsNil)
+	c.Assert(port, Equals, uint16(53))
+
+	port, err = npm.GetNamedPort("dns", uint8(proto))
+	c.Assert(err, IsNil)
+	c.Assert(port, Equals, uint16(53))
+
 	port, err = npm.GetNamedPort("unknown", uint8(proto))
 	c.Assert(err, Equals, ErrUnknownNamedPort)
 	c.Assert(port, Equals, uint16(0))
+
+	port, err = NamedPortMap(nil).GetNamedPort("dns", uint8(proto))
+	c.Assert(err, Equals, ErrNilMap)
+	c.Assert(port, Equals, uint16(0))
 }
 
 func (ds *PolicyTestSuite) TestPolicyPortProtoSet(c *C) {
@@ -601,6 +601,10 @@ type TranslationResult struct {
 	// NumToServicesRules is the number of ToServices rules processed while
 	// translating the rules
 	NumToServicesRules int
+
+	// NumToServicesBackends is the number of generated backend entries
+	// currently selected by the processed ToServices rules
+	NumToServicesBackends int
 }
 
 // TranslateRules traverses rules and applies provided translator to rules
@@ -655,6 +659,15 @@ func (p *Repository) resolvePolicyLocked(securityIdentity *identity.Identity) (*
 		matchingRules :=
 		p.computePolicyEnforcementAndRules(securityIdentity)
 
+	return p.resolveSelectorPolicy(securityIdentity, ingressEnabled, egressEnabled, matchingRules)
+}
+
+// resolveSelectorPolicy computes the selectorPolicy for the provided identity
+// from the pre-computed policy enforcement state and set of matching rules,
+// as returned by computePolicyEnforcementAndRules().
+//
+// Must be performed while holding the Repository lock.
+func (p *Repository) resolveSelectorPolicy(securityIdentity *identity.Identity, ingressEnabled, egressEnabled bool, matchingRules ruleSlice) (*selectorPolicy, error) {
 	calculatedPolicy := &selectorPolicy{
 		Revision:             p.GetRevision(),
 		SelectorCache:        p.GetSelectorCache(),
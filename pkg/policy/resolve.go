@@ -5,6 +5,7 @@ package policy
 
 import (
 	"github.com/cilium/cilium/pkg/identity"
+	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/policy/trafficdirection"
 
 	"github.com/sirupsen/logrus"
@@ -34,6 +35,11 @@ type selectorPolicy struct {
 	// EgressPolicyEnabled specifies whether this policy contains any policy
 	// at egress.
 	EgressPolicyEnabled bool
+
+	// fingerprint identifies the (revision, selected rule set) this policy
+	// was computed from. It is non-empty only when the policy is shared
+	// through the artifact cache of the PolicyCache.
+	fingerprint string
 }
 
 func (p *selectorPolicy) Attach(ctx PolicyContext) {
@@ -49,6 +55,14 @@ type EndpointPolicy struct {
 	// referring to a shared selectorPolicy!
 	*selectorPolicy
 
+	// IngressPolicyEnabled and EgressPolicyEnabled are the effective
+	// enforcement flags of the endpoint: the flags of the shared
+	// selectorPolicy combined with the per-endpoint policy enforcement
+	// mode of the PolicyOwner. They shadow the selectorPolicy fields of
+	// the same name.
+	IngressPolicyEnabled bool
+	EgressPolicyEnabled  bool
+
 	// PolicyMapState contains the state of this policy as it relates to the
 	// datapath. In the future, this will be factored out of this object to
 	// decouple the policy as it relates to the datapath vs. its userspace
@@ -69,6 +83,7 @@ type EndpointPolicy struct {
 // PolicyOwner is anything which consumes a EndpointPolicy.
 type PolicyOwner interface {
 	GetID() uint64
+	GetPolicyEnforcementMode() option.OptionSetting
 	LookupRedirectPortLocked(ingress bool, protocol string, port uint16) uint16
 	GetNamedPort(ingress bool, name string, proto uint8) uint16
 	GetNamedPortLocked(ingress bool, name string, proto uint8) uint16
@@ -115,15 +130,28 @@ func (p *selectorPolicy) Detach() {
 // Must be performed while holding the Repository lock.
 // PolicyOwner (aka Endpoint) is also locked during this call.
 func (p *selectorPolicy) DistillPolicy(policyOwner PolicyOwner, isHost bool) *EndpointPolicy {
+	// The per-endpoint policy enforcement mode of the owner overrides the
+	// enforcement flags derived from the daemon policy enforcement mode
+	// and the rules selecting the identity.
+	ingressEnabled, egressEnabled := p.IngressPolicyEnabled, p.EgressPolicyEnabled
+	switch policyOwner.GetPolicyEnforcementMode() {
+	case option.PolicyEnforcementModeAlways:
+		ingressEnabled, egressEnabled = true, true
+	case option.PolicyEnforcementModeNever:
+		ingressEnabled, egressEnabled = false, false
+	}
+
 	calculatedPolicy := &EndpointPolicy{
-		selectorPolicy: p,
-		PolicyMapState: make(MapState),
-		PolicyOwner:    policyOwner,
+		selectorPolicy:       p,
+		IngressPolicyEnabled: ingressEnabled,
+		EgressPolicyEnabled:  egressEnabled,
+		PolicyMapState:       make(MapState),
+		PolicyOwner:          policyOwner,
 	}
 
-	if !p.IngressPolicyEnabled || !p.EgressPolicyEnabled {
+	if !ingressEnabled || !egressEnabled {
 		calculatedPolicy.PolicyMapState.AllowAllIdentities(
-			!p.IngressPolicyEnabled, !p.EgressPolicyEnabled)
+			!ingressEnabled, !egressEnabled)
 	}
 
 	// Register the new EndpointPolicy as a receiver of delta
@@ -156,6 +184,21 @@ func (p *EndpointPolicy) Detach() {
 	p.selectorPolicy.removeUser(p)
 }
 
+// OnlyL7RulesChanged returns true if 'p' and 'old' allow the same traffic
+// through the same set of redirects, i.e. they differ at most in the
+// content of the L7 rules enforced by the proxy. Such policies can be
+// realized by updating the rules on the existing redirects without
+// regenerating the datapath.
+func (p *EndpointPolicy) OnlyL7RulesChanged(old *EndpointPolicy) bool {
+	if p.L4Policy == nil || old.L4Policy == nil ||
+		p.IngressPolicyEnabled != old.IngressPolicyEnabled ||
+		p.EgressPolicyEnabled != old.EgressPolicyEnabled {
+		return false
+	}
+	return p.L4Policy.Ingress.equalExceptL7(old.L4Policy.Ingress) &&
+		p.L4Policy.Egress.equalExceptL7(old.L4Policy.Egress)
+}
+
 // computeDesiredL4PolicyMapEntries transforms the EndpointPolicy.L4Policy into
 // the datapath-friendly format inside EndpointPolicy.PolicyMapState.
 func (p *EndpointPolicy) computeDesiredL4PolicyMapEntries() {
@@ -167,7 +167,8 @@ func (ds *PolicyTestSuite) TestL3WithIngressDenyWildcard(c *C) {
 			IngressPolicyEnabled: true,
 			CIDRPolicy:           policy.CIDRPolicy,
 		},
-		PolicyOwner: DummyOwner{},
+		IngressPolicyEnabled: true,
+		PolicyOwner:          DummyOwner{},
 		// inherit this from the result as it is outside of the scope
 		// of this test
 		PolicyMapState: policy.PolicyMapState,
@@ -251,7 +252,8 @@ func (ds *PolicyTestSuite) TestL3WithLocalHostWildcardd(c *C) {
 			IngressPolicyEnabled: true,
 			CIDRPolicy:           policy.CIDRPolicy,
 		},
-		PolicyOwner: DummyOwner{},
+		IngressPolicyEnabled: true,
+		PolicyOwner:          DummyOwner{},
 		// inherit this from the result as it is outside of the scope
 		// of this test
 		PolicyMapState: policy.PolicyMapState,
@@ -334,7 +336,8 @@ func (ds *PolicyTestSuite) TestMapStateWithIngressDenyWildcard(c *C) {
 			IngressPolicyEnabled: true,
 			CIDRPolicy:           policy.CIDRPolicy,
 		},
-		PolicyOwner: DummyOwner{},
+		IngressPolicyEnabled: true,
+		PolicyOwner:          DummyOwner{},
 		PolicyMapState: MapState{
 			// Although we have calculated deny policies, the overall policy
 			// will still allow egress to world.
@@ -473,7 +476,8 @@ func (ds *PolicyTestSuite) TestMapStateWithIngressDeny(c *C) {
 			IngressPolicyEnabled: true,
 			CIDRPolicy:           policy.CIDRPolicy,
 		},
-		PolicyOwner: DummyOwner{},
+		IngressPolicyEnabled: true,
+		PolicyOwner:          DummyOwner{},
 		PolicyMapState: MapState{
 			// Although we have calculated deny policies, the overall policy
 			// will still allow egress to world.
@@ -188,6 +188,10 @@ func (d DummyOwner) GetID() uint64 {
 	return 1234
 }
 
+func (d DummyOwner) GetPolicyEnforcementMode() option.OptionSetting {
+	return option.PolicyEnforcementModeDefault
+}
+
 func (d DummyOwner) PolicyDebug(fields logrus.Fields, msg string) {
 	log.WithFields(fields).Info(msg)
 }
@@ -323,7 +327,8 @@ func (ds *PolicyTestSuite) TestL7WithIngressWildcard(c *C) {
 			IngressPolicyEnabled: true,
 			EgressPolicyEnabled:  false,
 		},
-		PolicyOwner: DummyOwner{},
+		IngressPolicyEnabled: true,
+		PolicyOwner:          DummyOwner{},
 		// inherit this from the result as it is outside of the scope
 		// of this test
 		PolicyMapState: policy.PolicyMapState,
@@ -419,7 +424,8 @@ func (ds *PolicyTestSuite) TestL7WithLocalHostWildcardd(c *C) {
 			IngressPolicyEnabled: true,
 			EgressPolicyEnabled:  false,
 		},
-		PolicyOwner: DummyOwner{},
+		IngressPolicyEnabled: true,
+		PolicyOwner:          DummyOwner{},
 		// inherit this from the result as it is outside of the scope
 		// of this test
 		PolicyMapState: policy.PolicyMapState,
@@ -504,7 +510,8 @@ func (ds *PolicyTestSuite) TestMapStateWithIngressWildcard(c *C) {
 			IngressPolicyEnabled: true,
 			EgressPolicyEnabled:  false,
 		},
-		PolicyOwner: DummyOwner{},
+		IngressPolicyEnabled: true,
+		PolicyOwner:          DummyOwner{},
 		PolicyMapState: MapState{
 			{TrafficDirection: trafficdirection.Egress.Uint8()}: allowEgressMapStateEntry,
 			{DestPort: 80, Nexthdr: 6}:                          rule1MapStateEntry,
@@ -644,7 +651,8 @@ func (ds *PolicyTestSuite) TestMapStateWithIngress(c *C) {
 			IngressPolicyEnabled: true,
 			EgressPolicyEnabled:  false,
 		},
-		PolicyOwner: DummyOwner{},
+		IngressPolicyEnabled: true,
+		PolicyOwner:          DummyOwner{},
 		PolicyMapState: MapState{
 			{TrafficDirection: trafficdirection.Egress.Uint8()}:                          allowEgressMapStateEntry,
 			{Identity: uint32(identity.ReservedIdentityWorld), DestPort: 80, Nexthdr: 6}: rule1MapStateEntry.WithOwners(cachedSelectorWorld),
@@ -860,8 +868,10 @@ func TestEndpointPolicy_AllowsIdentity(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &EndpointPolicy{
-				selectorPolicy: tt.fields.selectorPolicy,
-				PolicyMapState: tt.fields.PolicyMapState,
+				selectorPolicy:       tt.fields.selectorPolicy,
+				IngressPolicyEnabled: tt.fields.selectorPolicy.IngressPolicyEnabled,
+				EgressPolicyEnabled:  tt.fields.selectorPolicy.EgressPolicyEnabled,
+				PolicyMapState:       tt.fields.PolicyMapState,
 			}
 			gotIngress, gotEgress := p.AllowsIdentity(tt.args.identity)
 			if gotIngress != tt.wantIngress {
@@ -873,3 +883,69 @@ func TestEndpointPolicy_AllowsIdentity(t *testing.T) {
 		})
 	}
 }
+
+func (ds *PolicyTestSuite) TestOnlyL7RulesChanged(c *C) {
+	httpIngress := func(path string) L4PolicyMap {
+		return L4PolicyMap{
+			"80/TCP": {
+				Port:     80,
+				Protocol: api.ProtoTCP,
+				L7Parser: ParserTypeHTTP,
+				L7RulesPerSelector: L7DataMap{
+					cachedFooSelector: &PerSelectorPolicy{
+						L7Rules: api.L7Rules{
+							HTTP: []api.PortRuleHTTP{{Path: path, Method: "GET"}},
+						},
+					},
+				},
+				Ingress: true,
+			},
+		}
+	}
+	testPolicy := func(ingress L4PolicyMap) *EndpointPolicy {
+		return &EndpointPolicy{
+			selectorPolicy: &selectorPolicy{
+				L4Policy: &L4Policy{
+					Ingress: ingress,
+					Egress:  L4PolicyMap{},
+				},
+			},
+			IngressPolicyEnabled: true,
+			EgressPolicyEnabled:  true,
+		}
+	}
+
+	realized := testPolicy(httpIngress("/foo"))
+
+	// Identical policies and policies differing only in the L7 rule
+	// content qualify.
+	c.Assert(testPolicy(httpIngress("/foo")).OnlyL7RulesChanged(realized), Equals, true)
+	c.Assert(testPolicy(httpIngress("/bar")).OnlyL7RulesChanged(realized), Equals, true)
+
+	// A new filter does not.
+	added := httpIngress("/foo")
+	added["8080/TCP"] = &L4Filter{Port: 8080, Protocol: api.ProtoTCP, Ingress: true}
+	c.Assert(testPolicy(added).OnlyL7RulesChanged(realized), Equals, false)
+
+	// Neither does a change of the L7 parser.
+	parser := httpIngress("/foo")
+	parser["80/TCP"].L7Parser = ParserTypeKafka
+	c.Assert(testPolicy(parser).OnlyL7RulesChanged(realized), Equals, false)
+
+	// Nor a new selector on an existing filter.
+	selector := httpIngress("/foo")
+	selector["80/TCP"].L7RulesPerSelector[wildcardCachedSelector] = &PerSelectorPolicy{
+		L7Rules: api.L7Rules{HTTP: []api.PortRuleHTTP{{}}},
+	}
+	c.Assert(testPolicy(selector).OnlyL7RulesChanged(realized), Equals, false)
+
+	// Nor removing the redirect from an existing selector.
+	noRedirect := httpIngress("/foo")
+	noRedirect["80/TCP"].L7RulesPerSelector[cachedFooSelector] = nil
+	c.Assert(testPolicy(noRedirect).OnlyL7RulesChanged(realized), Equals, false)
+
+	// Nor a change of the effective enforcement flags.
+	enforcement := testPolicy(httpIngress("/foo"))
+	enforcement.EgressPolicyEnabled = false
+	c.Assert(enforcement.OnlyL7RulesChanged(realized), Equals, false)
+}
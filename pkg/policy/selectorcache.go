@@ -117,7 +117,9 @@ type CachedSelectionUser interface {
 // cached selections are updated as necessary.
 //
 // In both of the above cases the set of existing identitySelectors is
-// write locked.
+// write locked. Lookups of already cached identitySelectors are served
+// from a copy-on-write snapshot of the set without taking the lock, so
+// that they do not block on (2) while endpoints are regenerated.
 //
 // To minimize the upkeep the identity selectors are shared across
 // all IdentityPolicies, so that only one copy exists for each
@@ -138,6 +140,10 @@ type identitySelector interface {
 	CachedSelector
 	addUser(CachedSelectionUser) (added bool)
 
+	// May be called without holding the SelectorCache lock. Fails if
+	// the selector has been removed from the selector cache.
+	addUserConcurrent(CachedSelectionUser) (added, ok bool)
+
 	// Called with NameManager and SelectorCache locks held
 	removeUser(CachedSelectionUser, identityNotifier) (last bool)
 
@@ -201,6 +207,14 @@ type SelectorCache struct {
 	// map key is the string representation of the selector being cached.
 	selectors map[string]identitySelector
 
+	// selectorSnapshot is an immutable copy-on-write snapshot of
+	// 'selectors' that can be read without holding 'mutex'. It is
+	// republished via publishSelectorsLocked() whenever a selector is
+	// added to or removed from 'selectors', so that selector lookups on
+	// the policy distillation path never block on identity update
+	// writers holding 'mutex'.
+	selectorSnapshot unsafe.Pointer // *map[string]identitySelector
+
 	localIdentityNotifier identityNotifier
 
 	// userCond is a condition variable for receiving signals
@@ -212,6 +226,29 @@ type SelectorCache struct {
 	userNotes []userNotification
 }
 
+// getSelectorSnapshot returns the current snapshot of the cached
+// selectors without taking any locks. The snapshot is immutable once
+// published and must not be modified by the caller. The set of cached
+// selectors can be concurrently updated, in which case the snapshot may
+// miss a selector that was just added; callers needing an up-to-date
+// view must fall back to reading 'selectors' with 'mutex' held.
+func (sc *SelectorCache) getSelectorSnapshot() map[string]identitySelector {
+	return *(*map[string]identitySelector)(atomic.LoadPointer(&sc.selectorSnapshot))
+}
+
+// publishSelectorsLocked publishes a new immutable snapshot of
+// 'selectors' for the lock-free read paths. Must be called after each
+// addition to or removal from 'selectors'.
+//
+// lock must be held
+func (sc *SelectorCache) publishSelectorsLocked() {
+	snapshot := make(map[string]identitySelector, len(sc.selectors))
+	for key, sel := range sc.selectors {
+		snapshot[key] = sel
+	}
+	atomic.StorePointer(&sc.selectorSnapshot, unsafe.Pointer(&snapshot))
+}
+
 // GetModel returns the API model of the SelectorCache.
 func (sc *SelectorCache) GetModel() models.SelectorCache {
 	sc.mutex.RLock()
@@ -275,6 +312,7 @@ func NewSelectorCache(ids cache.IdentityCache) *SelectorCache {
 		idCache:   getIdentityCache(ids),
 		selectors: make(map[string]identitySelector),
 	}
+	sc.publishSelectorsLocked()
 	sc.userCond = sync.NewCond(&sc.userMutex)
 	go sc.handleUserNotifications()
 
@@ -300,9 +338,19 @@ var (
 )
 
 type selectorManager struct {
-	key              string
-	selections       unsafe.Pointer // *[]identity.NumericIdentity
-	users            map[CachedSelectionUser]struct{}
+	key        string
+	selections unsafe.Pointer // *[]identity.NumericIdentity
+
+	// usersLock protects 'users' and 'removed' so that users can be
+	// added via the lock-free selector lookup fast path while identity
+	// update writers hold the SelectorCache lock.
+	usersLock lock.Mutex
+	users     map[CachedSelectionUser]struct{}
+	// removed is set when the last user is removed and the selector is
+	// about to be deleted from the selector cache, so that lock-free
+	// user additions racing with the removal can detect it and retry.
+	removed bool
+
 	cachedSelections map[identity.NumericIdentity]struct{}
 }
 
@@ -360,6 +408,8 @@ func (s *selectorManager) String() string {
 
 // lock must be held
 func (s *selectorManager) addUser(user CachedSelectionUser) (added bool) {
+	s.usersLock.Lock()
+	defer s.usersLock.Unlock()
 	if _, exists := s.users[user]; exists {
 		return false
 	}
@@ -367,24 +417,52 @@ func (s *selectorManager) addUser(user CachedSelectionUser) (added bool) {
 	return true
 }
 
+// addUserConcurrent adds a user without holding the SelectorCache lock.
+// It fails ('ok' is false) if the selector has been removed from the
+// selector cache after the caller looked it up, in which case the caller
+// must retry the lookup with the SelectorCache lock held.
+func (s *selectorManager) addUserConcurrent(user CachedSelectionUser) (added, ok bool) {
+	s.usersLock.Lock()
+	defer s.usersLock.Unlock()
+	if s.removed {
+		return false, false
+	}
+	if _, exists := s.users[user]; exists {
+		return false, true
+	}
+	s.users[user] = struct{}{}
+	return true, true
+}
+
 // lock must be held
 func (s *selectorManager) removeUser(user CachedSelectionUser, dnsProxy identityNotifier) (last bool) {
+	s.usersLock.Lock()
+	defer s.usersLock.Unlock()
 	delete(s.users, user)
-	return len(s.users) == 0
+	if len(s.users) == 0 {
+		s.removed = true
+		return true
+	}
+	return false
 }
 
 // locks must be held for the dnsProxy and the SelectorCache
 func (f *fqdnSelector) removeUser(user CachedSelectionUser, dnsProxy identityNotifier) (last bool) {
+	f.usersLock.Lock()
+	defer f.usersLock.Unlock()
 	delete(f.users, user)
 	if len(f.users) == 0 {
+		f.removed = true
 		dnsProxy.UnregisterForIdentityUpdatesLocked(f.selector)
 		return true
 	}
 	return false
 }
 
-// lock must be held
+// may be called without holding the SelectorCache lock
 func (s *selectorManager) numUsers() int {
+	s.usersLock.Lock()
+	defer s.usersLock.Unlock()
 	return len(s.users)
 }
 
@@ -426,6 +504,8 @@ type fqdnSelector struct {
 // The caller is responsible for making sure the same identity is not
 // present in both 'added' and 'deleted'.
 func (f *fqdnSelector) notifyUsers(sc *SelectorCache, added, deleted []identity.NumericIdentity, wg *sync.WaitGroup) {
+	f.usersLock.Lock()
+	defer f.usersLock.Unlock()
 	for user := range f.users {
 		// pass 'f' to the user as '*fqdnSelector'
 		sc.queueUserNotification(user, f, added, deleted, wg)
@@ -485,6 +565,8 @@ type labelIdentitySelector struct {
 // The caller is responsible for making sure the same identity is not
 // present in both 'added' and 'deleted'.
 func (l *labelIdentitySelector) notifyUsers(sc *SelectorCache, added, deleted []identity.NumericIdentity, wg *sync.WaitGroup) {
+	l.usersLock.Lock()
+	defer l.usersLock.Unlock()
 	for user := range l.users {
 		// pass 'l' to the user as '*labelIdentitySelector'
 		sc.queueUserNotification(user, l, added, deleted, wg)
@@ -552,6 +634,7 @@ func (sc *SelectorCache) updateFQDNSelector(fqdnSelec api.FQDNSelector, identiti
 			selector: fqdnSelec,
 		}
 		sc.selectors[fqdnKey] = fqdnSel
+		sc.publishSelectorsLocked()
 	} else {
 		fqdnSel = selector.(*fqdnSelector)
 	}
@@ -629,6 +712,17 @@ func (sc *SelectorCache) updateFQDNSelector(fqdnSelec api.FQDNSelector, identiti
 func (sc *SelectorCache) AddFQDNSelector(user CachedSelectionUser, fqdnSelec api.FQDNSelector) (cachedSelector CachedSelector, added bool) {
 	key := fqdnSelec.String()
 
+	// Fast path: if the selector is already cached, add the user via
+	// the lock-free snapshot without blocking on the NameManager or
+	// the SelectorCache locks. The concurrent user addition fails if
+	// the selector is removed from the cache in between, in which case
+	// we fall back to the locked slow path below.
+	if fqdnSel, exists := sc.getSelectorSnapshot()[key]; exists {
+		if added, ok := fqdnSel.addUserConcurrent(user); ok {
+			return fqdnSel, added
+		}
+	}
+
 	// Lock NameManager before the SelectorCache
 	sc.localIdentityNotifier.Lock()
 	defer sc.localIdentityNotifier.Unlock()
@@ -692,6 +786,7 @@ func (sc *SelectorCache) AddFQDNSelector(user CachedSelectionUser, fqdnSelec api
 		newFQDNSel = sel.(*fqdnSelector)
 	} else {
 		sc.selectors[key] = newFQDNSel
+		sc.publishSelectorsLocked()
 	}
 
 	// Add the ids from the slice above to the FQDN selector in the cache.
@@ -710,9 +805,10 @@ func (sc *SelectorCache) AddFQDNSelector(user CachedSelectionUser, fqdnSelec api
 // selector cache, returning nil if one can not be found.
 func (sc *SelectorCache) FindCachedIdentitySelector(selector api.EndpointSelector) CachedSelector {
 	key := selector.CachedString()
-	sc.mutex.Lock()
-	idSel := sc.selectors[key]
-	sc.mutex.Unlock()
+	idSel, exists := sc.getSelectorSnapshot()[key]
+	if !exists {
+		return nil
+	}
 	return idSel
 }
 
@@ -726,6 +822,19 @@ func (sc *SelectorCache) AddIdentitySelector(user CachedSelectionUser, selector
 	// in different orders. When this happens we'll be tracking
 	// essentially two copies of the same selector.
 	key := selector.CachedString()
+
+	// Fast path: the selector is typically already cached, so look it
+	// up from the lock-free snapshot first to avoid blocking on
+	// identity update writers holding the write lock. The concurrent
+	// user addition fails if the selector is removed from the cache in
+	// between, in which case we fall back to the locked slow path
+	// below.
+	if idSel, exists := sc.getSelectorSnapshot()[key]; exists {
+		if added, ok := idSel.addUserConcurrent(user); ok {
+			return idSel, added
+		}
+	}
+
 	sc.mutex.Lock()
 	defer sc.mutex.Unlock()
 	idSel, exists := sc.selectors[key]
@@ -769,6 +878,7 @@ func (sc *SelectorCache) AddIdentitySelector(user CachedSelectionUser, selector
 	// is already cached, or is a new one).
 
 	sc.selectors[key] = newIDSel
+	sc.publishSelectorsLocked()
 	return newIDSel, true
 }
 
@@ -779,6 +889,7 @@ func (sc *SelectorCache) removeSelectorLocked(selector CachedSelector, user Cach
 	if exists {
 		if sel.removeUser(user, sc.localIdentityNotifier) {
 			delete(sc.selectors, key)
+			sc.publishSelectorsLocked()
 		}
 	}
 }
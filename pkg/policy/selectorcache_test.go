@@ -7,7 +7,10 @@
 package policy
 
 import (
+	"fmt"
 	"sync"
+	"sync/atomic"
+	"testing"
 
 	"github.com/cilium/cilium/pkg/checker"
 	"github.com/cilium/cilium/pkg/identity"
@@ -677,3 +680,80 @@ func testNewSelectorCache(ids cache.IdentityCache) *SelectorCache {
 	sc.SetLocalIdentityNotifier(testutils.NewDummyIdentityNotifier())
 	return sc
 }
+
+// benchCachedSelectionUser is a no-op user with a unique identity for
+// each instance, unlike DummySelectorCacheUser, whose zero-sized
+// instances may share the same address and thus count as a single user.
+type benchCachedSelectionUser struct {
+	id int
+}
+
+func (b *benchCachedSelectionUser) IdentitySelectionUpdated(selector CachedSelector, added, deleted []identity.NumericIdentity) {
+}
+
+func benchmarkAddIdentitySelector(b *testing.B, churn bool) {
+	idCache := cache.IdentityCache{}
+	for i := 0; i < 1000; i++ {
+		idCache[identity.NumericIdentity(1000+i)] = labels.Labels{
+			"app": labels.NewLabel("app", fmt.Sprintf("test-%d", i%10), labels.LabelSourceK8s),
+		}.LabelArray()
+	}
+	sc := testNewSelectorCache(idCache)
+
+	// Pre-cache the selectors with a user that is never removed, so
+	// that the parallel users below always find them in the cache.
+	holder := &benchCachedSelectionUser{id: -1}
+	selectors := make([]api.EndpointSelector, 10)
+	for i := range selectors {
+		selectors[i] = api.NewESFromLabels(labels.NewLabel("app", fmt.Sprintf("test-%d", i), labels.LabelSourceK8s))
+		sc.AddIdentitySelector(holder, selectors[i])
+	}
+
+	// Churn identity updates in the background for the duration of the
+	// benchmark to expose contention between the selector lookup fast
+	// path and identity update writers holding the write lock.
+	stop := make(chan struct{})
+	var churnWG sync.WaitGroup
+	if churn {
+		churnWG.Add(1)
+		go func() {
+			defer churnWG.Done()
+			lbls := labels.Labels{"app": labels.NewLabel("app", "churn", labels.LabelSourceK8s)}.LabelArray()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				nid := identity.NumericIdentity(2000 + i%100)
+				wg := &sync.WaitGroup{}
+				sc.UpdateIdentities(cache.IdentityCache{nid: lbls}, nil, wg)
+				wg.Wait()
+				wg = &sync.WaitGroup{}
+				sc.UpdateIdentities(nil, cache.IdentityCache{nid: lbls}, wg)
+				wg.Wait()
+			}
+		}()
+	}
+
+	var nextUser int32
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		user := &benchCachedSelectionUser{id: int(atomic.AddInt32(&nextUser, 1))}
+		for i := 0; pb.Next(); i++ {
+			sel, _ := sc.AddIdentitySelector(user, selectors[i%len(selectors)])
+			sc.RemoveSelector(sel, user)
+		}
+	})
+	b.StopTimer()
+	close(stop)
+	churnWG.Wait()
+}
+
+func BenchmarkAddIdentitySelector(b *testing.B) {
+	benchmarkAddIdentitySelector(b, false)
+}
+
+func BenchmarkAddIdentitySelectorContended(b *testing.B) {
+	benchmarkAddIdentitySelector(b, true)
+}
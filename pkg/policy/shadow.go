@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package policy
+
+import (
+	"github.com/cilium/cilium/pkg/identity"
+	"github.com/cilium/cilium/pkg/identity/cache"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/option"
+	"github.com/cilium/cilium/pkg/policy/api"
+)
+
+// ShadowVerdictDivergence describes a single decision divergence between the
+// enforced policy and a candidate policy for one peer identity.
+type ShadowVerdictDivergence struct {
+	// Direction is "ingress" or "egress", seen from the endpoint the
+	// divergence was computed for.
+	Direction string `json:"direction"`
+
+	// PeerIdentity is the security identity of the peer of the flow.
+	PeerIdentity identity.NumericIdentity `json:"peer-identity"`
+
+	// PeerLabels are the labels of PeerIdentity.
+	PeerLabels labels.LabelArray `json:"peer-labels"`
+
+	// Enforced is the verdict of the currently enforced policy.
+	Enforced string `json:"enforced"`
+
+	// Candidate is the verdict of the candidate policy.
+	Candidate string `json:"candidate"`
+}
+
+// ShadowEndpointReport summarizes the decision divergences between the
+// enforced and a candidate policy for a single endpoint.
+type ShadowEndpointReport struct {
+	// EndpointID is the endpoint the report was computed for.
+	EndpointID uint64 `json:"endpoint-id"`
+
+	// ComparedPeers is the number of peer identities that were evaluated
+	// in both directions.
+	ComparedPeers int `json:"compared-peers"`
+
+	// Divergences lists all decisions that differ between the enforced
+	// and the candidate policy. Empty if both policies agree.
+	Divergences []*ShadowVerdictDivergence `json:"divergences,omitempty"`
+}
+
+// NewShadowRepository creates a detached policy repository holding the given,
+// already sanitized, candidate rules for shadow evaluation. Unlike
+// NewPolicyRepository it does not start the repository event queues and does
+// not account the rules in the policy metrics, as the candidate rules are
+// never enforced.
+func NewShadowRepository(idCache cache.IdentityCache, rules api.Rules) *Repository {
+	repo := &Repository{
+		revision:      1,
+		selectorCache: NewSelectorCache(idCache),
+	}
+	repo.policyCache = NewPolicyCache(repo, false)
+
+	newList := make(ruleSlice, len(rules))
+	for i := range rules {
+		newList[i] = &rule{
+			Rule:     *rules[i],
+			metadata: newRuleMetadata(),
+		}
+	}
+	repo.rules = newList
+	return repo
+}
+
+// enforcementRLocked returns whether ingress and egress policy would be
+// enforced for an endpoint with the given labels, mirroring the per-endpoint
+// enforcement logic for the configured policy enforcement mode. The
+// repository mutex must be held.
+func (p *Repository) enforcementRLocked(epLabels labels.LabelArray) (ingress, egress bool) {
+	switch GetPolicyEnabled() {
+	case option.NeverEnforce:
+		return false, false
+	case option.AlwaysEnforce:
+		return true, true
+	}
+	return p.GetRulesMatching(epLabels)
+}
+
+// shadowVerdictRLocked returns the verdict of the repository for the given
+// search context, taking the enforcement state of the evaluated direction
+// into account. The repository mutex must be held.
+func (p *Repository) shadowVerdictRLocked(ctx *SearchContext, ingress, enforced bool) api.Decision {
+	if !enforced {
+		return api.Allowed
+	}
+	if ingress {
+		return p.AllowsIngressRLocked(ctx)
+	}
+	return p.AllowsEgressRLocked(ctx)
+}
+
+// ShadowCompareForEndpoint evaluates the enforced repository and the
+// candidate repository for an endpoint with the given labels against every
+// peer identity in 'peers', in both directions, and returns all decisions
+// that diverge. Decisions are compared at L3, matching the granularity of
+// policy trace. Both repository mutexes are taken for reading.
+func (p *Repository) ShadowCompareForEndpoint(candidate *Repository, epLabels labels.LabelArray, peers cache.IdentityCache) []*ShadowVerdictDivergence {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+	candidate.Mutex.RLock()
+	defer candidate.Mutex.RUnlock()
+
+	enforcedIngress, enforcedEgress := p.enforcementRLocked(epLabels)
+	candidateIngress, candidateEgress := candidate.enforcementRLocked(epLabels)
+
+	var divergences []*ShadowVerdictDivergence
+	for peerID, peerLabels := range peers {
+		ingressCtx := SearchContext{
+			From: peerLabels,
+			To:   epLabels,
+		}
+		enforced := p.shadowVerdictRLocked(&ingressCtx, true, enforcedIngress)
+		shadowed := candidate.shadowVerdictRLocked(&ingressCtx, true, candidateIngress)
+		if enforced != shadowed {
+			divergences = append(divergences, &ShadowVerdictDivergence{
+				Direction:    "ingress",
+				PeerIdentity: peerID,
+				PeerLabels:   peerLabels,
+				Enforced:     enforced.String(),
+				Candidate:    shadowed.String(),
+			})
+		}
+
+		egressCtx := SearchContext{
+			From: epLabels,
+			To:   peerLabels,
+		}
+		enforced = p.shadowVerdictRLocked(&egressCtx, false, enforcedEgress)
+		shadowed = candidate.shadowVerdictRLocked(&egressCtx, false, candidateEgress)
+		if enforced != shadowed {
+			divergences = append(divergences, &ShadowVerdictDivergence{
+				Direction:    "egress",
+				PeerIdentity: peerID,
+				PeerLabels:   peerLabels,
+				Enforced:     enforced.String(),
+				Candidate:    shadowed.String(),
+			})
+		}
+	}
+
+	return divergences
+}
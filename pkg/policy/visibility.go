@@ -210,3 +210,10 @@ func (v *VisibilityMetadata) GetIngress() bool {
 func (v *VisibilityMetadata) GetPort() uint16 {
 	return v.Port
 }
+
+// GetPreserveOriginalSourceAddr returns whether the original source address
+// should be preserved for redirects created for visibility, which is always
+// the case.
+func (v *VisibilityMetadata) GetPreserveOriginalSourceAddr() bool {
+	return true
+}
@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+// Package prewarm maintains an optional pool of pre-created veth pairs with
+// pre-allocated IPs. When a new pod is started, the pre-allocated IPs can be
+// claimed without scanning the allocation pool, removing the most expensive
+// IPAM work from the CNI ADD critical path. Claimed veth pairs are kept and
+// re-armed with fresh IPs in the background so the pool stays warm.
+package prewarm
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/datapath/connector"
+	"github.com/cilium/cilium/pkg/ipam"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/logging"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+
+	"github.com/vishvananda/netlink"
+)
+
+var log = logging.DefaultLogger.WithField(logfields.LogSubsys, "endpoint-prewarm")
+
+// refillControllerName is the name of the controller which keeps the pool
+// filled up to the configured size.
+const refillControllerName = "endpoint-prewarm-refill"
+
+// ownerPrefix is the prefix of the IPAM owner under which the IPs of
+// unclaimed pool entries are allocated.
+const ownerPrefix = "prewarm:"
+
+// Allocator is the subset of the IPAM allocator used by the pool.
+type Allocator interface {
+	AllocateNext(family, owner string) (ipv4Result, ipv6Result *ipam.AllocationResult, err error)
+	AllocateIPWithAllocationResult(ip net.IP, owner string) (result *ipam.AllocationResult, err error)
+	ReleaseIP(ip net.IP) error
+}
+
+// entry is a single pre-warmed veth pair. While the entry is ready, ipv4 and
+// ipv6 hold the IPs pre-allocated for it.
+type entry struct {
+	id         string
+	hostIfName string
+	ipv4       net.IP
+	ipv6       net.IP
+}
+
+// Pool maintains the pre-warmed entries and keeps the pool filled up to the
+// configured size.
+type Pool struct {
+	// mutex protects access to ready, idle and nextID
+	mutex lock.Mutex
+
+	// ready are the entries with pre-allocated IPs available for claiming
+	ready []*entry
+
+	// idle are the entries whose IPs have been claimed and which are
+	// waiting to be re-armed with fresh IPs
+	idle []*entry
+
+	// nextID is the sequence number used to derive unique interface names
+	nextID int
+
+	allocator Allocator
+	mtu       int
+	size      int
+	manager   *controller.Manager
+}
+
+// NewPool returns a new pool of the given size which allocates IPs from the
+// given allocator. The pool remains empty until Start() is called.
+func NewPool(allocator Allocator, mtu, size int) *Pool {
+	return &Pool{
+		allocator: allocator,
+		mtu:       mtu,
+		size:      size,
+		manager:   controller.NewManager(),
+	}
+}
+
+// Start begins filling the pool in the background.
+func (p *Pool) Start() {
+	p.triggerRefill()
+}
+
+// triggerRefill triggers an immediate controller run to fill the pool back up
+// to the configured size.
+func (p *Pool) triggerRefill() {
+	p.manager.UpdateController(refillControllerName,
+		controller.ControllerParams{
+			DoFunc: p.refill,
+		})
+}
+
+// refill re-arms idle entries with fresh IPs and creates new veth pairs until
+// the pool has reached the configured size.
+func (p *Pool) refill(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		p.mutex.Lock()
+		var e *entry
+		switch {
+		case len(p.idle) > 0:
+			e = p.idle[len(p.idle)-1]
+			p.idle = p.idle[:len(p.idle)-1]
+		case len(p.ready) < p.size:
+			e = &entry{id: fmt.Sprintf("prewarm-%d", p.nextID)}
+			p.nextID++
+		default:
+			p.mutex.Unlock()
+			return nil
+		}
+		p.mutex.Unlock()
+
+		if err := p.arm(e); err != nil {
+			// Put the entry back so the next run can retry.
+			p.mutex.Lock()
+			if e.hostIfName != "" {
+				p.idle = append(p.idle, e)
+			}
+			p.mutex.Unlock()
+			return fmt.Errorf("unable to pre-warm entry %s: %w", e.id, err)
+		}
+
+		p.mutex.Lock()
+		p.ready = append(p.ready, e)
+		p.mutex.Unlock()
+	}
+}
+
+// arm creates the veth pair of the entry if it does not exist yet and
+// allocates the IPs handed out when the entry is claimed.
+func (p *Pool) arm(e *entry) error {
+	if e.hostIfName == "" {
+		epModel := &models.EndpointChangeRequest{}
+		veth, _, _, err := connector.SetupVeth(e.id, p.mtu, epModel)
+		if err != nil {
+			return fmt.Errorf("unable to create veth pair: %w", err)
+		}
+		e.hostIfName = veth.Name
+	}
+
+	ipv4Result, ipv6Result, err := p.allocator.AllocateNext("", ownerPrefix+e.id)
+	if err != nil {
+		return fmt.Errorf("unable to pre-allocate IPs: %w", err)
+	}
+	if ipv4Result != nil {
+		e.ipv4 = ipv4Result.IP
+	}
+	if ipv6Result != nil {
+		e.ipv6 = ipv6Result.IP
+	}
+
+	log.WithField(logfields.Veth, e.hostIfName).Debug("Pre-warmed endpoint entry")
+	return nil
+}
+
+// Claim hands the pre-allocated IPs of a ready entry over to the given owner.
+// Returns nil results if the pool is empty or the handover failed, in which
+// case the caller must fall back to regular allocation. The veth pair of the
+// claimed entry is kept and re-armed in the background.
+func (p *Pool) Claim(owner string) (ipv4Result, ipv6Result *ipam.AllocationResult) {
+	p.mutex.Lock()
+	if len(p.ready) == 0 {
+		p.mutex.Unlock()
+		return nil, nil
+	}
+	e := p.ready[len(p.ready)-1]
+	p.ready = p.ready[:len(p.ready)-1]
+	p.mutex.Unlock()
+
+	ipv4Result, ipv6Result, err := p.transfer(e, owner)
+
+	e.ipv4 = nil
+	e.ipv6 = nil
+	p.mutex.Lock()
+	p.idle = append(p.idle, e)
+	p.mutex.Unlock()
+	p.triggerRefill()
+
+	if err != nil {
+		log.WithError(err).Warning("Unable to claim pre-warmed entry, falling back to regular allocation")
+		return nil, nil
+	}
+
+	return ipv4Result, ipv6Result
+}
+
+// transfer re-allocates the IPs of the entry to the given owner.
+func (p *Pool) transfer(e *entry, owner string) (ipv4Result, ipv6Result *ipam.AllocationResult, err error) {
+	if e.ipv4 != nil {
+		if err = p.allocator.ReleaseIP(e.ipv4); err != nil {
+			return nil, nil, err
+		}
+		ipv4Result, err = p.allocator.AllocateIPWithAllocationResult(e.ipv4, owner)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if e.ipv6 != nil {
+		if err = p.allocator.ReleaseIP(e.ipv6); err == nil {
+			ipv6Result, err = p.allocator.AllocateIPWithAllocationResult(e.ipv6, owner)
+		}
+		if err != nil {
+			// Do not leak the already transferred IPv4 address
+			if ipv4Result != nil {
+				p.allocator.ReleaseIP(ipv4Result.IP)
+			}
+			return nil, nil, err
+		}
+	}
+
+	return ipv4Result, ipv6Result, nil
+}
+
+// Dump returns the current state of the pool for debugging purposes.
+func (p *Pool) Dump() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return fmt.Sprintf("%d/%d entries ready, %d awaiting re-arm", len(p.ready), p.size, len(p.idle))
+}
+
+// Shutdown releases all pre-allocated IPs and deletes the veth pairs of the
+// pool.
+func (p *Pool) Shutdown() {
+	p.manager.RemoveAllAndWait()
+
+	p.mutex.Lock()
+	entries := append(p.ready, p.idle...)
+	p.ready = nil
+	p.idle = nil
+	p.mutex.Unlock()
+
+	for _, e := range entries {
+		for _, ip := range []net.IP{e.ipv4, e.ipv6} {
+			if ip == nil {
+				continue
+			}
+			if err := p.allocator.ReleaseIP(ip); err != nil {
+				log.WithError(err).WithField(logfields.IPAddr, ip).Warning("Unable to release pre-allocated IP")
+			}
+		}
+		if link, err := netlink.LinkByName(e.hostIfName); err == nil {
+			netlink.LinkDel(link)
+		}
+	}
+}
@@ -160,6 +160,12 @@ type LogRecord struct {
 	// Metadata is additional arbitrary metadata
 	Metadata []string
 
+	// DelegatedIdentity is the workload identity asserted by an external
+	// service mesh, e.g. the SPIFFE ID injected by a sidecar proxy. It is
+	// extracted from the request headers configured via
+	// --delegated-identity-headers and empty otherwise.
+	DelegatedIdentity string `json:"DelegatedIdentity,omitempty"`
+
 	// TransportProtocol identifies the flow's transport layer (layer 4) protocol
 	TransportProtocol TransportProtocol
 
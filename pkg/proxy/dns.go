@@ -15,7 +15,7 @@ import (
 
 var (
 	// DefaultDNSProxy is the global, shared, DNS Proxy singleton.
-	DefaultDNSProxy *dnsproxy.DNSProxy
+	DefaultDNSProxy dnsproxy.DNSProxier
 )
 
 // dnsRedirect implements the Redirect interface for an l7 proxy
@@ -26,9 +26,8 @@ type envoyRedirect struct {
 
 var envoyOnce sync.Once
 
-// createEnvoyRedirect creates a redirect with corresponding proxy
-// configuration. This will launch a proxy instance.
-func createEnvoyRedirect(r *Redirect, stateDir string, xdsServer *envoy.XDSServer, mayUseOriginalSourceAddr bool, wg *completion.WaitGroup) (RedirectImplementation, error) {
+// startEnvoy starts the global Envoy instance on the first invocation.
+func startEnvoy(stateDir string, xdsServer *envoy.XDSServer, wg *completion.WaitGroup) {
 	envoyOnce.Do(func() {
 		// Start Envoy on first invocation
 		envoyProxy = envoy.StartEnvoy(stateDir, option.Config.EnvoyLogPath, 0)
@@ -40,6 +39,12 @@ func createEnvoyRedirect(r *Redirect, stateDir string, xdsServer *envoy.XDSServe
 			xdsServer.AddMetricsListener(uint16(option.Config.ProxyPrometheusPort), wg)
 		}
 	})
+}
+
+// createEnvoyRedirect creates a redirect with corresponding proxy
+// configuration. This will launch a proxy instance.
+func createEnvoyRedirect(r *Redirect, stateDir string, xdsServer *envoy.XDSServer, mayUseOriginalSourceAddr bool, wg *completion.WaitGroup) (RedirectImplementation, error) {
+	startEnvoy(stateDir, xdsServer, wg)
 
 	l := r.listener
 	if envoyProxy != nil {
@@ -51,6 +56,10 @@ func createEnvoyRedirect(r *Redirect, stateDir string, xdsServer *envoy.XDSServe
 		if l.ingress {
 			mayUseOriginalSourceAddr = false
 		}
+		// Policy may request SNAT to the proxy address for this redirect
+		if !r.preserveOriginalSourceAddr {
+			mayUseOriginalSourceAddr = false
+		}
 		xdsServer.AddListener(redir.listenerName, l.parserType, l.proxyPort, l.ingress,
 			mayUseOriginalSourceAddr, wg)
 
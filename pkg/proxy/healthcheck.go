@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/cilium/cilium/pkg/backoff"
+	"github.com/cilium/cilium/pkg/completion"
+	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/metrics"
+	"github.com/cilium/cilium/pkg/option"
+)
+
+const (
+	// redirectHealthCheckInterval is the interval between periodic health
+	// probes of the proxy redirect listeners.
+	redirectHealthCheckInterval = 30 * time.Second
+
+	// redirectHealthCheckTimeout is the timeout of a single listener
+	// health probe.
+	redirectHealthCheckTimeout = 5 * time.Second
+
+	// redirectRepairTimeout is the maximum time to wait for the proxy
+	// configuration of a repaired redirect to be acknowledged.
+	redirectRepairTimeout = 30 * time.Second
+)
+
+// StartRedirectHealthChecker starts the periodic health checker of the proxy
+// redirect listeners. Redirects whose listener no longer accepts connections
+// are automatically re-created.
+func (p *Proxy) StartRedirectHealthChecker() {
+	controller.NewManager().UpdateController("proxy-redirect-health-check",
+		controller.ControllerParams{
+			DoFunc: func(ctx context.Context) error {
+				return p.checkRedirectHealth()
+			},
+			RunInterval: redirectHealthCheckInterval,
+		})
+}
+
+// redirectListening probes whether a listener accepts connections on the
+// given proxy port.
+func redirectListening(port uint16) bool {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", port), redirectHealthCheckTimeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// checkRedirectHealth probes the listener of each current redirect and
+// repairs the redirects whose listener is found dead. Returns the last
+// repair error, if any, so that the controller reports and retries the
+// failed repairs.
+func (p *Proxy) checkRedirectHealth() error {
+	p.mutex.RLock()
+	ports := make(map[string]uint16, len(p.redirects))
+	for id, redir := range p.redirects {
+		ports[id] = redir.listener.proxyPort
+	}
+	p.mutex.RUnlock()
+
+	// Drop the metrics of redirects removed since the previous round.
+	for id := range p.healthCheckedRedirects {
+		if _, ok := ports[id]; !ok {
+			metrics.ProxyRedirectHealth.DeleteLabelValues(id)
+			delete(p.healthCheckedRedirects, id)
+		}
+	}
+
+	var lastErr error
+	for id, port := range ports {
+		p.healthCheckedRedirects[id] = struct{}{}
+
+		healthy := redirectListening(port)
+		health := float64(0)
+		if healthy {
+			health = 1
+		}
+		metrics.ProxyRedirectHealth.WithLabelValues(id).Set(health)
+
+		if healthy {
+			continue
+		}
+
+		log.WithField(fieldProxyRedirectID, id).
+			Warningf("Proxy listener on port %d found dead, re-creating the redirect", port)
+		if err := p.repairRedirect(id); err != nil {
+			log.WithError(err).WithField(fieldProxyRedirectID, id).
+				Error("Unable to repair proxy redirect")
+			lastErr = err
+		} else {
+			metrics.ProxyRedirectHealth.WithLabelValues(id).Set(1)
+		}
+	}
+	return lastErr
+}
+
+// repairRedirect re-creates the proxy instance serving the given redirect
+// after its listener has been found dead, using the same creation retry loop
+// as CreateOrUpdateRedirect.
+func (p *Proxy) repairRedirect(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redirectRepairTimeout)
+	defer cancel()
+	wg := completion.NewWaitGroup(ctx)
+
+	p.mutex.Lock()
+	redir, ok := p.redirects[id]
+	if !ok {
+		// Removed since the health probe, nothing to repair.
+		p.mutex.Unlock()
+		return nil
+	}
+	redir.mutex.Lock()
+
+	// Close the dead implementation, ignoring errors as the listener is
+	// already gone.
+	if redir.implementation != nil {
+		if implFinalizeFunc, _ := redir.implementation.Close(wg); implFinalizeFunc != nil {
+			implFinalizeFunc()
+		}
+	}
+
+	creationBackoff := backoff.Exponential{
+		Min:    option.Config.ProxyRedirectCreationBackoff,
+		Jitter: true,
+		Name:   "proxy-redirect-repair-" + id,
+	}
+	var impl RedirectImplementation
+	var err error
+	for nRetry := 0; nRetry < option.Config.ProxyRedirectCreationAttempts; nRetry++ {
+		if nRetry > 0 {
+			log.WithError(err).WithField(fieldProxyRedirectID, id).
+				Warningf("Unable to re-create %s proxy, retrying", redir.listener.name)
+			if backoffErr := waitForRedirectRetry(&creationBackoff); backoffErr != nil {
+				break
+			}
+		}
+		impl, err = p.createRedirectImpl(redir, redir.listener.parserType, wg)
+		if err == nil {
+			redir.implementation = impl
+			break
+		}
+	}
+	redir.mutex.Unlock()
+	p.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+	// Wait for the proxy configuration to complete before reporting the
+	// redirect healthy again.
+	return wg.Wait()
+}
@@ -0,0 +1,453 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+
+	"github.com/cilium/cilium/pkg/completion"
+	"github.com/cilium/cilium/pkg/datapath/linux/linux_defaults"
+	"github.com/cilium/cilium/pkg/identity"
+	"github.com/cilium/cilium/pkg/kafka"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/option"
+	kafka_api "github.com/cilium/cilium/pkg/policy/api/kafka"
+	"github.com/cilium/cilium/pkg/proxy/accesslog"
+	"github.com/cilium/cilium/pkg/proxy/logger"
+	"github.com/cilium/cilium/pkg/revert"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// kafkaListener is a TCP listener on a proxy port which is shared by all
+// Kafka redirects of the same direction. Each accepted connection is
+// demultiplexed to the redirect it belongs to based on the original
+// destination port and the IP addresses of the proxied endpoint, so that
+// redirects do not consume one proxy port per listener.
+type kafkaListener struct {
+	socket    net.Listener
+	proxyPort uint16
+	ingress   bool
+
+	// mutex protects the set of redirects sharing this listener
+	mutex     lock.RWMutex
+	redirects map[*kafkaRedirect]struct{}
+}
+
+var (
+	// kafkaListenersMutex protects kafkaListeners
+	kafkaListenersMutex lock.Mutex
+
+	// kafkaListeners maps proxy ports to the listener shared by all Kafka
+	// redirects on that port
+	kafkaListeners = make(map[uint16]*kafkaListener)
+)
+
+// kafkaRedirect implements the RedirectImplementation interface for an l7
+// Kafka proxy running natively in the agent.
+type kafkaRedirect struct {
+	redirect             *Redirect
+	endpointInfoRegistry logger.EndpointInfoRegistry
+	conf                 kafkaConfiguration
+	listener             *kafkaListener
+}
+
+type kafkaConfiguration struct {
+}
+
+// createKafkaRedirect creates a redirect to the native Kafka proxy. The
+// redirect structure passed in is safe to access for reading and writing.
+func createKafkaRedirect(r *Redirect, conf kafkaConfiguration, endpointInfoRegistry logger.EndpointInfoRegistry) (RedirectImplementation, error) {
+	kr := &kafkaRedirect{
+		redirect:             r,
+		conf:                 conf,
+		endpointInfoRegistry: endpointInfoRegistry,
+	}
+
+	listener, err := getKafkaListener(r.listener)
+	if err != nil {
+		return nil, err
+	}
+	listener.attach(kr)
+	kr.listener = listener
+
+	log.WithFields(logrus.Fields{
+		logfields.EndpointID: r.endpointID,
+		logfields.Port:       r.listener.proxyPort,
+		"ingress":            r.listener.ingress,
+	}).Debug("Created Kafka proxy redirect")
+
+	return kr, nil
+}
+
+// getKafkaListener returns the shared listener for the given proxy port,
+// creating it on first use.
+func getKafkaListener(pp *ProxyPort) (*kafkaListener, error) {
+	kafkaListenersMutex.Lock()
+	defer kafkaListenersMutex.Unlock()
+
+	if listener, ok := kafkaListeners[pp.proxyPort]; ok {
+		return listener, nil
+	}
+
+	marker := linux_defaults.GetMagicProxyMark(pp.ingress, 0)
+
+	// Listen needs to be in the transparent mode to accept the connections
+	// redirected by the datapath with their original destination address.
+	// The socket may receive both IPv4 and IPv6 connections, so set the
+	// option for both families, if enabled.
+	lc := net.ListenConfig{
+		Control: func(network, address string, c syscall.RawConn) error {
+			var soerr error
+			if err := c.Control(func(fd uintptr) {
+				if soerr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEADDR, 1); soerr != nil {
+					return
+				}
+				if soerr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_MARK, marker); soerr != nil {
+					return
+				}
+				if option.Config.EnableIPv6 {
+					if soerr = unix.SetsockoptInt(int(fd), unix.SOL_IPV6, unix.IPV6_TRANSPARENT, 1); soerr != nil {
+						return
+					}
+				}
+				if option.Config.EnableIPv4 {
+					soerr = unix.SetsockoptInt(int(fd), unix.SOL_IP, unix.IP_TRANSPARENT, 1)
+				}
+			}); err != nil {
+				return err
+			}
+			return soerr
+		},
+	}
+
+	socket, err := lc.Listen(context.Background(), "tcp",
+		fmt.Sprintf(":%d", pp.proxyPort))
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen on %s proxy port %d: %s",
+			pp.name, pp.proxyPort, err)
+	}
+
+	listener := &kafkaListener{
+		socket:    socket,
+		proxyPort: pp.proxyPort,
+		ingress:   pp.ingress,
+		redirects: make(map[*kafkaRedirect]struct{}),
+	}
+	kafkaListeners[pp.proxyPort] = listener
+
+	go listener.serve()
+
+	return listener, nil
+}
+
+// attach registers a redirect with the listener.
+func (l *kafkaListener) attach(kr *kafkaRedirect) {
+	l.mutex.Lock()
+	l.redirects[kr] = struct{}{}
+	l.mutex.Unlock()
+}
+
+// detach unregisters a redirect from the listener. The listener is closed
+// when the last redirect sharing it is removed.
+func (l *kafkaListener) detach(kr *kafkaRedirect) {
+	l.mutex.Lock()
+	delete(l.redirects, kr)
+	empty := len(l.redirects) == 0
+	l.mutex.Unlock()
+
+	if empty {
+		kafkaListenersMutex.Lock()
+		delete(kafkaListeners, l.proxyPort)
+		kafkaListenersMutex.Unlock()
+		l.socket.Close()
+	}
+}
+
+// serve accepts incoming redirected connections until the listener is closed.
+func (l *kafkaListener) serve() {
+	for {
+		conn, err := l.socket.Accept()
+		if err != nil {
+			// The listener is closed when the last redirect is removed.
+			return
+		}
+		go l.handleConnection(conn)
+	}
+}
+
+// findRedirect returns the redirect an accepted connection belongs to.
+// Redirects are matched on the original destination port and the IP of the
+// proxied endpoint: the source IP for egress and the destination IP for
+// ingress connections.
+func (l *kafkaListener) findRedirect(srcAddr, dstAddr *net.TCPAddr) *kafkaRedirect {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	epIP := srcAddr.IP.String()
+	if l.ingress {
+		epIP = dstAddr.IP.String()
+	}
+
+	var fallback *kafkaRedirect
+	portMatches := 0
+	for kr := range l.redirects {
+		if kr.redirect.dstPort != uint16(dstAddr.Port) {
+			continue
+		}
+		fallback = kr
+		portMatches++
+
+		_, ipv4, ipv6, _, _, _, err := kr.redirect.localEndpoint.GetProxyInfoByFields()
+		if err == nil && (epIP == ipv4 || epIP == ipv6) {
+			return kr
+		}
+	}
+
+	// If the endpoint IP did not match any redirect, but the destination
+	// port is only redirected for a single endpoint, there is no ambiguity.
+	if portMatches == 1 {
+		return fallback
+	}
+
+	return nil
+}
+
+// handleConnection demultiplexes a single accepted connection to the
+// redirect it belongs to and proxies it to the original destination.
+func (l *kafkaListener) handleConnection(client net.Conn) {
+	defer client.Close()
+
+	scopedLog := log.WithField("from", client.RemoteAddr().String())
+
+	// With the transparent listener the local address of the accepted
+	// connection is the original destination of the redirected connection.
+	srcAddr, sok := client.RemoteAddr().(*net.TCPAddr)
+	dstAddr, dok := client.LocalAddr().(*net.TCPAddr)
+	if !sok || !dok || uint16(dstAddr.Port) == l.proxyPort {
+		scopedLog.Error("Kafka proxy received connection without original destination address")
+		return
+	}
+
+	kr := l.findRedirect(srcAddr, dstAddr)
+	if kr == nil {
+		scopedLog.WithField("destination", dstAddr.String()).
+			Warn("No Kafka redirect found for connection, closing")
+		return
+	}
+
+	kr.serveConnection(client, srcAddr, dstAddr)
+}
+
+// serveConnection proxies a single client connection to the original
+// destination, enforcing the Kafka rules of the redirect on each request.
+func (k *kafkaRedirect) serveConnection(client net.Conn, srcAddr, dstAddr *net.TCPAddr) {
+	k.redirect.connOpened()
+	defer k.redirect.connClosed()
+
+	scopedLog := log.WithFields(logrus.Fields{
+		logfields.EndpointID: k.redirect.endpointID,
+		"from":               srcAddr.String(),
+	})
+
+	srcIdentity := k.lookupSourceIdentity(srcAddr)
+
+	// Connect to the original destination. The connection is marked with
+	// the source identity so that the datapath does not redirect it to the
+	// proxy again and policy can be enforced with the identity of the
+	// original source.
+	marker := linux_defaults.GetMagicProxyMark(k.listener.ingress, int(srcIdentity))
+	d := net.Dialer{
+		Control: func(network, address string, c syscall.RawConn) error {
+			var soerr error
+			if err := c.Control(func(fd uintptr) {
+				soerr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_MARK, marker)
+			}); err != nil {
+				return err
+			}
+			return soerr
+		},
+	}
+
+	backend, err := d.Dial("tcp", dstAddr.String())
+	if err != nil {
+		scopedLog.WithError(err).WithField("destination", dstAddr.String()).
+			Warn("Unable to connect Kafka proxy to original destination")
+		return
+	}
+	defer backend.Close()
+
+	addressing := logger.AddressingInfo{
+		SrcIPPort:   srcAddr.String(),
+		DstIPPort:   dstAddr.String(),
+		SrcIdentity: uint32(srcIdentity),
+	}
+
+	go k.handleResponses(client, backend, addressing)
+	k.handleRequests(client, backend, srcIdentity, addressing)
+}
+
+// lookupSourceIdentity resolves the security identity of the connection
+// source via the endpoint info registry. Returns 0 if the source is not a
+// known local endpoint.
+func (k *kafkaRedirect) lookupSourceIdentity(addr *net.TCPAddr) identity.NumericIdentity {
+	info := accesslog.EndpointInfo{}
+	if !k.endpointInfoRegistry.FillEndpointIdentityByIP(addr.IP, &info) {
+		return 0
+	}
+
+	return identity.NumericIdentity(info.Identity)
+}
+
+// canAccess returns true if the request is allowed by the Kafka rules
+// currently in effect for the redirect.
+func (k *kafkaRedirect) canAccess(req *kafka.RequestMessage, srcIdentity identity.NumericIdentity) bool {
+	k.redirect.mutex.RLock()
+	defer k.redirect.mutex.RUnlock()
+
+	rules := k.redirect.rules
+	if len(rules) == 0 {
+		// L4-only redirect, nothing to enforce on L7
+		return true
+	}
+
+	for cs, l7 := range rules {
+		if !cs.Selects(srcIdentity) {
+			continue
+		}
+
+		if l7 == nil || len(l7.Kafka) == 0 {
+			// Allow-all for this selector
+			return true
+		}
+
+		for _, pr := range l7.Kafka {
+			rule := kafka.NewRule(pr.GetAPIVersion(), pr.GetAPIKeys(), pr.ClientID, pr.Topic)
+			if rule.Matches(req) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// handleRequests reads Kafka requests from the client, enforces the rules of
+// the redirect and forwards allowed requests to the original destination.
+// Denied requests are answered directly with a topic authorization failure.
+func (k *kafkaRedirect) handleRequests(client, backend net.Conn, srcIdentity identity.NumericIdentity, addressing logger.AddressingInfo) {
+	scopedLog := log.WithField(logfields.EndpointID, k.redirect.endpointID)
+
+	for {
+		req, err := kafka.ReadRequest(client)
+		if err != nil {
+			if err != io.EOF {
+				scopedLog.WithError(err).Debug("Unable to parse Kafka request, closing connection")
+			}
+			return
+		}
+
+		if k.canAccess(req, srcIdentity) {
+			k.logRequest(req, addressing, accesslog.VerdictForwarded, "")
+			if _, err := backend.Write(req.GetRaw()); err != nil {
+				scopedLog.WithError(err).Debug("Unable to forward Kafka request")
+				return
+			}
+			continue
+		}
+
+		k.logRequest(req, addressing, accesslog.VerdictDenied, "Kafka request rejected by policy")
+
+		resp, err := req.CreateAuthErrorResponse()
+		if err != nil {
+			scopedLog.WithError(err).Debug("Unable to create Kafka authorization error response")
+			continue
+		}
+		if _, err := client.Write(resp.GetRaw()); err != nil {
+			scopedLog.WithError(err).Debug("Unable to send Kafka authorization error response")
+			return
+		}
+	}
+}
+
+// handleResponses forwards Kafka responses from the original destination back
+// to the client.
+func (k *kafkaRedirect) handleResponses(client, backend net.Conn, addressing logger.AddressingInfo) {
+	for {
+		res, err := kafka.ReadResponse(backend)
+		if err != nil {
+			return
+		}
+
+		k.logResponse(res, addressing)
+
+		if _, err := client.Write(res.GetRaw()); err != nil {
+			return
+		}
+	}
+}
+
+// logRequest logs an access log record for the given request, one record per
+// topic contained in the request.
+func (k *kafkaRedirect) logRequest(req *kafka.RequestMessage, addressing logger.AddressingInfo, verdict accesslog.FlowVerdict, info string) {
+	ingress := k.listener.ingress
+
+	k.redirect.localEndpoint.UpdateProxyStatistics("TCP", k.redirect.dstPort, ingress, true, verdict)
+
+	topics := req.GetTopics()
+	if len(topics) == 0 {
+		// Requests without a topic still generate a single record.
+		topics = []string{""}
+	}
+
+	for _, topic := range topics {
+		record := logger.NewLogRecord(k.endpointInfoRegistry, k.redirect.localEndpoint,
+			accesslog.TypeRequest, ingress,
+			logger.LogTags.Verdict(verdict, info),
+			logger.LogTags.Addressing(addressing),
+			logger.LogTags.Kafka(&accesslog.LogRecordKafka{
+				APIVersion:    req.GetVersion(),
+				APIKey:        kafka_api.ApiKeyToString(req.GetAPIKey()),
+				CorrelationID: int32(req.GetCorrelationID()),
+				Topic:         accesslog.KafkaTopic{Topic: topic},
+			}))
+		record.Log()
+	}
+}
+
+// logResponse logs an access log record for the given response.
+func (k *kafkaRedirect) logResponse(res *kafka.ResponseMessage, addressing logger.AddressingInfo) {
+	ingress := k.listener.ingress
+
+	k.redirect.localEndpoint.UpdateProxyStatistics("TCP", k.redirect.dstPort, ingress, false, accesslog.VerdictForwarded)
+
+	record := logger.NewLogRecord(k.endpointInfoRegistry, k.redirect.localEndpoint,
+		accesslog.TypeResponse, ingress,
+		logger.LogTags.Verdict(accesslog.VerdictForwarded, ""),
+		logger.LogTags.Addressing(addressing),
+		logger.LogTags.Kafka(&accesslog.LogRecordKafka{
+			CorrelationID: int32(res.GetCorrelationID()),
+		}))
+	record.Log()
+}
+
+// UpdateRules atomically replaces the proxy rules in effect for this redirect.
+// The rules are read from the redirect on each request, so there is nothing
+// to push to the proxy here.
+func (k *kafkaRedirect) UpdateRules(wg *completion.WaitGroup) (revert.RevertFunc, error) {
+	return func() error { return nil }, nil
+}
+
+// Close the redirect.
+func (k *kafkaRedirect) Close(wg *completion.WaitGroup) (revert.FinalizeFunc, revert.RevertFunc) {
+	return func() {
+		k.listener.detach(k)
+	}, nil
+}
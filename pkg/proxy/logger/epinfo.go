@@ -6,6 +6,7 @@ package logger
 import (
 	"net"
 
+	"github.com/cilium/cilium/api/v1/models"
 	"github.com/cilium/cilium/pkg/fqdn/restore"
 	"github.com/cilium/cilium/pkg/identity"
 	"github.com/cilium/cilium/pkg/proxy/accesslog"
@@ -61,6 +62,11 @@ type EndpointUpdater interface {
 	// for a new observed flow with the given characteristics.
 	UpdateProxyStatistics(l4Protocol string, port uint16, ingress, request bool, verdict accesslog.FlowVerdict)
 
+	// GetProxyStatistics returns a copy of the Endpoint's proxy statistics
+	// for the redirect identified by the given proxy ID, or nil if no
+	// statistics have been collected for it.
+	GetProxyStatistics(key string) *models.RequestResponseStatistics
+
 	// OnDNSPolicyUpdateLocked is called when the Endpoint's DNS policy has been updated.
 	// 'rules' is a fresh copy of the DNS rules passed to the callee.
 	OnDNSPolicyUpdateLocked(rules restore.DNSRules)
@@ -25,6 +25,13 @@ var (
 	logMutex lock.Mutex
 	notifier LogRecordNotifier
 	metadata []string
+	pipeline *Pipeline
+	observer RecordObserver
+
+	// delegatedIdentityHeaders are the request headers carrying a workload
+	// identity delegated from an external service mesh, in order of
+	// precedence. Set once at startup, read-only afterwards.
+	delegatedIdentityHeaders []string
 )
 
 // fields used for structured logging
@@ -242,6 +249,12 @@ func (logTags) Addressing(i AddressingInfo) LogTag {
 func (logTags) HTTP(h *accesslog.LogRecordHTTP) LogTag {
 	return func(lr *LogRecord) {
 		lr.HTTP = h
+		for _, name := range delegatedIdentityHeaders {
+			if value := h.Headers.Get(name); value != "" {
+				lr.DelegatedIdentity = value
+				break
+			}
+		}
 	}
 }
 
@@ -317,6 +330,14 @@ func (lr *LogRecord) Log() {
 	if notifier != nil {
 		notifier.NewProxyLogRecord(lr)
 	}
+
+	if pipeline != nil {
+		pipeline.enqueue(lr)
+	}
+
+	if observer != nil {
+		observer.ObserveRecord(lr)
+	}
 }
 
 // LogRecordNotifier is the interface to implement LogRecord notifications
@@ -332,6 +353,20 @@ func SetNotifier(n LogRecordNotifier) {
 	logMutex.Unlock()
 }
 
+// RecordObserver is the interface to implement in-agent aggregation of L7
+// records, e.g. into service-level metrics
+type RecordObserver interface {
+	// ObserveRecord is called for each new log record
+	ObserveRecord(lr *LogRecord)
+}
+
+// SetObserver sets the observer to call for all L7 records
+func SetObserver(o RecordObserver) {
+	logMutex.Lock()
+	observer = o
+	logMutex.Unlock()
+}
+
 // SetMetadata sets the metadata to include in each record
 func SetMetadata(md []string) {
 	logMutex.Lock()
@@ -339,3 +374,12 @@ func SetMetadata(md []string) {
 
 	metadata = md
 }
+
+// SetDelegatedIdentityHeaders sets the request headers to extract a delegated
+// workload identity from, in order of precedence
+func SetDelegatedIdentityHeaders(headers []string) {
+	logMutex.Lock()
+	defer logMutex.Unlock()
+
+	delegatedIdentityHeaders = headers
+}
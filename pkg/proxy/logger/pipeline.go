@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// pipelineBufferSize is the number of access log records buffered for
+	// the sinks. Records are dropped rather than blocking the proxy when
+	// the buffer is full.
+	pipelineBufferSize = 1024
+
+	// pipelineDropLogInterval determines how often a drop due to
+	// backpressure is logged, to avoid amplifying the load when the sinks
+	// cannot keep up.
+	pipelineDropLogInterval = 1000
+)
+
+// Pipeline dispatches access log records to the configured sinks from a
+// separate goroutine, applying sampling and dropping records under
+// backpressure so that high-QPS redirects never block the proxy.
+type Pipeline struct {
+	sinks   []Sink
+	records chan *LogRecord
+
+	// sampleRates maps an L7 protocol to n where only one of every n
+	// records is emitted to the sinks. Protocols without an entry are
+	// always emitted. Only read after the pipeline has been created.
+	sampleRates map[string]uint64
+
+	// counts tracks the number of records seen per L7 protocol for
+	// sampling. Only accessed from enqueue under logMutex.
+	counts map[string]uint64
+
+	// dropped is the number of records dropped due to backpressure. Only
+	// accessed from enqueue under logMutex.
+	dropped uint64
+}
+
+// NewPipeline creates a new access log pipeline emitting to the given sinks
+// and starts its dispatcher
+func NewPipeline(sinks []Sink, sampleRates map[string]uint64) *Pipeline {
+	p := &Pipeline{
+		sinks:       sinks,
+		records:     make(chan *LogRecord, pipelineBufferSize),
+		sampleRates: sampleRates,
+		counts:      map[string]uint64{},
+	}
+
+	go p.run()
+
+	return p
+}
+
+// ParseSampleRates parses the access log sampling configuration. Each entry
+// has the form "<protocol>=<n>" where only one of every n records of the
+// given L7 protocol (http, kafka, dns or l7) is emitted to the sinks.
+func ParseSampleRates(entries []string) (map[string]uint64, error) {
+	rates := map[string]uint64{}
+	for _, entry := range entries {
+		idx := strings.Index(entry, "=")
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid sample rate %q, expected <protocol>=<n>", entry)
+		}
+
+		protocol := entry[:idx]
+		switch protocol {
+		case "http", "kafka", "dns", "l7":
+		default:
+			return nil, fmt.Errorf("unknown protocol %q in sample rate %q", protocol, entry)
+		}
+		if _, ok := rates[protocol]; ok {
+			return nil, fmt.Errorf("duplicate sample rate for protocol %q", protocol)
+		}
+
+		n, err := strconv.ParseUint(entry[idx+1:], 10, 64)
+		if err != nil || n == 0 {
+			return nil, fmt.Errorf("invalid sample rate %q, <n> must be a positive integer", entry)
+		}
+		rates[protocol] = n
+	}
+
+	return rates, nil
+}
+
+// recordProtocol returns the L7 protocol key of the record used for sampling
+func recordProtocol(lr *LogRecord) string {
+	switch {
+	case lr.HTTP != nil:
+		return "http"
+	case lr.Kafka != nil:
+		return "kafka"
+	case lr.DNS != nil:
+		return "dns"
+	}
+	return "l7"
+}
+
+// enqueue hands a record to the dispatcher without blocking. Must be called
+// with logMutex held.
+func (p *Pipeline) enqueue(lr *LogRecord) {
+	protocol := recordProtocol(lr)
+	p.counts[protocol]++
+	if rate, ok := p.sampleRates[protocol]; ok && p.counts[protocol]%rate != 0 {
+		return
+	}
+
+	select {
+	case p.records <- lr:
+	default:
+		p.dropped++
+		if p.dropped%pipelineDropLogInterval == 1 {
+			log.WithField("dropped", p.dropped).
+				Warning("Access log sinks cannot keep up, dropping access log records")
+		}
+	}
+}
+
+// run dispatches the buffered records to all sinks
+func (p *Pipeline) run() {
+	for lr := range p.records {
+		record, err := json.Marshal(&lr.LogRecord)
+		if err != nil {
+			log.WithError(err).Debug("Unable to encode access log record")
+			continue
+		}
+
+		for _, sink := range p.sinks {
+			if err := sink.Write(record); err != nil {
+				log.WithError(err).Debug("Unable to write access log record")
+			}
+		}
+	}
+
+	for _, sink := range p.sinks {
+		sink.Close()
+	}
+}
+
+// Close stops the dispatcher and closes all sinks
+func (p *Pipeline) Close() {
+	close(p.records)
+}
+
+// SetPipeline sets the access log pipeline receiving all L7 records
+func SetPipeline(p *Pipeline) {
+	logMutex.Lock()
+	pipeline = p
+	logMutex.Unlock()
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package logger
+
+import (
+	"fmt"
+	"log/syslog"
+	"os"
+	"strings"
+)
+
+const (
+	// sinkTypeFile emits access log records to a file with rotation
+	sinkTypeFile = "file"
+
+	// sinkTypeSyslog emits access log records to the local syslog daemon
+	sinkTypeSyslog = "syslog"
+
+	// fileSinkMaxSize is the size in bytes at which the access log file is
+	// rotated
+	fileSinkMaxSize = 100 * 1024 * 1024
+)
+
+// Sink consumes encoded access log records dispatched by the access log
+// pipeline. Implementations must not retain the passed record beyond the
+// Write call.
+type Sink interface {
+	// Write consumes a single JSON encoded access log record
+	Write(record []byte) error
+
+	// Close flushes and closes the sink
+	Close() error
+}
+
+// NewSink creates the sink described by the given specification of the form
+// "<type>" or "<type>:<target>", e.g. "file:/var/log/cilium-access.log" or
+// "syslog".
+func NewSink(spec string) (Sink, error) {
+	sinkType, target := spec, ""
+	if idx := strings.Index(spec, ":"); idx >= 0 {
+		sinkType, target = spec[:idx], spec[idx+1:]
+	}
+
+	switch sinkType {
+	case sinkTypeFile:
+		if target == "" {
+			return nil, fmt.Errorf("file sink requires a path, e.g. \"file:/var/log/cilium-access.log\"")
+		}
+		return newFileSink(target)
+	case sinkTypeSyslog:
+		return newSyslogSink()
+	}
+
+	return nil, fmt.Errorf("unknown access log sink type %q", sinkType)
+}
+
+// fileSink writes access log records to a file, rotating it once to a ".1"
+// suffix when it grows beyond fileSinkMaxSize
+type fileSink struct {
+	path    string
+	file    *os.File
+	written int64
+}
+
+func newFileSink(path string) (Sink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open access log %s: %w", path, err)
+	}
+
+	s := &fileSink{path: path, file: f}
+	if info, err := f.Stat(); err == nil {
+		s.written = info.Size()
+	}
+	return s, nil
+}
+
+func (s *fileSink) Write(record []byte) error {
+	if s.written+int64(len(record))+1 > fileSinkMaxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(append(record, '\n'))
+	s.written += int64(n)
+	return err
+}
+
+// rotate moves the current access log aside and starts a new one
+func (s *fileSink) rotate() error {
+	s.file.Close()
+	if err := os.Rename(s.path, s.path+".1"); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	s.file = f
+	s.written = 0
+	return nil
+}
+
+func (s *fileSink) Close() error {
+	return s.file.Close()
+}
+
+// syslogSink writes access log records to the local syslog daemon
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+func newSyslogSink() (Sink, error) {
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, "cilium-accesslog")
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to syslog: %w", err)
+	}
+	return &syslogSink{writer: w}, nil
+}
+
+func (s *syslogSink) Write(record []byte) error {
+	return s.writer.Info(string(record))
+}
+
+func (s *syslogSink) Close() error {
+	return s.writer.Close()
+}
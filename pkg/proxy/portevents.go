@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package proxy
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/policy"
+)
+
+// proxyPortEventLogSize bounds the number of proxy port operations kept in
+// memory for debugging.
+const proxyPortEventLogSize = 128
+
+type proxyPortOp string
+
+const (
+	proxyPortOpAllocate proxyPortOp = "allocate"
+	proxyPortOpSet      proxyPortOp = "set"
+	proxyPortOpAck      proxyPortOp = "ack"
+	proxyPortOpRelease  proxyPortOp = "release"
+)
+
+// proxyPortEvent is a single proxy port operation kept for debugging port
+// churn, port exhaustion and port leaks.
+type proxyPortEvent struct {
+	timestamp time.Time
+	op        proxyPortOp
+	listener  string
+	port      uint16
+	caller    string
+}
+
+// proxyPortEvents is a bounded log of proxy port operations, oldest first.
+// Protected by proxyPortsMutex.
+var proxyPortEvents []proxyPortEvent
+
+// logProxyPortEvent appends an event to the bounded event log, evicting the
+// oldest event when full, and notifies any subscribed observers.
+// Must be called with proxyPortsMutex held!
+func logProxyPortEvent(op proxyPortOp, pp *ProxyPort) {
+	e := proxyPortEvent{
+		timestamp: time.Now(),
+		op:        op,
+		listener:  pp.name,
+		port:      pp.proxyPort,
+	}
+	// Record the caller of the proxy port operation, skipping this function
+	// and the operation itself.
+	if pc, _, _, ok := runtime.Caller(2); ok {
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			e.caller = fn.Name()
+		}
+	}
+	if len(proxyPortEvents) >= proxyPortEventLogSize {
+		copy(proxyPortEvents, proxyPortEvents[1:])
+		proxyPortEvents = proxyPortEvents[:proxyPortEventLogSize-1]
+	}
+	proxyPortEvents = append(proxyPortEvents, e)
+
+	notifyPortObservers(ProxyPortEvent{
+		Type:      op.eventType(),
+		Listener:  pp.name,
+		ProxyType: pp.parserType,
+		Port:      pp.proxyPort,
+	})
+}
+
+// ProxyPortEventType classifies a proxy port lifecycle event delivered to
+// subscribed observers.
+type ProxyPortEventType string
+
+const (
+	// PortAllocated is emitted when a port is allocated or statically
+	// configured for a proxy listener.
+	PortAllocated ProxyPortEventType = "PortAllocated"
+
+	// PortAcked is emitted when a proxy listener has been confirmed on its
+	// port and a reference to the port is taken.
+	PortAcked ProxyPortEventType = "PortAcked"
+
+	// PortReleased is emitted when the last redirect using a proxy port is
+	// removed and the port is freed for reallocation.
+	PortReleased ProxyPortEventType = "PortReleased"
+)
+
+// eventType maps an internal proxy port operation to the event type exposed
+// to observers. Static port configuration is reported as an allocation.
+func (op proxyPortOp) eventType() ProxyPortEventType {
+	switch op {
+	case proxyPortOpAck:
+		return PortAcked
+	case proxyPortOpRelease:
+		return PortReleased
+	default:
+		return PortAllocated
+	}
+}
+
+// ProxyPortEvent describes a single proxy port lifecycle operation.
+type ProxyPortEvent struct {
+	// Type is the kind of the operation.
+	Type ProxyPortEventType
+
+	// Listener is the name of the proxy listener the port belongs to.
+	Listener string
+
+	// ProxyType is the L7 parser type served on the port.
+	ProxyType policy.L7ParserType
+
+	// Port is the proxy port number.
+	Port uint16
+}
+
+// ProxyPortObserver is implemented by agent subsystems interested in proxy
+// port lifecycle events.
+type ProxyPortObserver interface {
+	// OnProxyPortEvent is called once for each proxy port event.
+	// Implementations must not call back into the Proxy from this
+	// callback.
+	OnProxyPortEvent(ev ProxyPortEvent)
+}
+
+var (
+	// portObserversMutex protects portObservers.
+	portObserversMutex lock.RWMutex
+
+	// portObservers are notified of proxy port events.
+	portObservers = make(map[ProxyPortObserver]struct{})
+)
+
+// Subscribe registers an observer for proxy port events. Events are
+// delivered asynchronously and ordering between events is not guaranteed.
+func (p *Proxy) Subscribe(observer ProxyPortObserver) {
+	portObserversMutex.Lock()
+	defer portObserversMutex.Unlock()
+
+	portObservers[observer] = struct{}{}
+}
+
+// Unsubscribe removes an observer registered with Subscribe. Events already
+// dispatched may still be delivered after Unsubscribe returns.
+func (p *Proxy) Unsubscribe(observer ProxyPortObserver) {
+	portObserversMutex.Lock()
+	defer portObserversMutex.Unlock()
+
+	delete(portObservers, observer)
+}
+
+// notifyPortObservers dispatches the event to all subscribed observers
+// outside of the proxy port lock.
+func notifyPortObservers(ev ProxyPortEvent) {
+	portObserversMutex.RLock()
+	observers := make([]ProxyPortObserver, 0, len(portObservers))
+	for o := range portObservers {
+		observers = append(observers, o)
+	}
+	portObserversMutex.RUnlock()
+
+	if len(observers) == 0 {
+		return
+	}
+	go func() {
+		for _, o := range observers {
+			o.OnProxyPortEvent(ev)
+		}
+	}()
+}
+
+// DebugStatus returns the proxy port event log for debugging, implementing
+// debug.StatusObject.
+func (p *Proxy) DebugStatus() string {
+	proxyPortsMutex.Lock()
+	defer proxyPortsMutex.Unlock()
+
+	var sb strings.Builder
+	for _, e := range proxyPortEvents {
+		fmt.Fprintf(&sb, "%s %s %s port %d by %s\n",
+			e.timestamp.Format(time.RFC3339Nano), e.op, e.listener, e.port, e.caller)
+	}
+	return sb.String()
+}
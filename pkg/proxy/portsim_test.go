@@ -0,0 +1,283 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package proxy
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	ciliumrand "github.com/cilium/cilium/pkg/rand"
+	"github.com/cilium/cilium/pkg/testutils/simulation"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	check.TestingT(t)
+}
+
+type ProxyPortSuite struct{}
+
+var _ = check.Suite(&ProxyPortSuite{})
+
+// fakeDatapathUpdater records the installed proxy rules, modeling the
+// datapath the rules are leaked into when the port state loses track of
+// them.
+type fakeDatapathUpdater struct {
+	rules map[uint16]string
+}
+
+func (f *fakeDatapathUpdater) InstallProxyRules(proxyPort uint16, ingress bool, name string) error {
+	f.rules[proxyPort] = name
+	return nil
+}
+
+func (f *fakeDatapathUpdater) SupportsOriginalSourceAddr() bool {
+	return false
+}
+
+func (f *fakeDatapathUpdater) GetProxyRuleCounters() map[uint16]uint64 {
+	counters := make(map[uint16]uint64, len(f.rules))
+	for port := range f.rules {
+		counters[port] = 0
+	}
+	return counters
+}
+
+func (f *fakeDatapathUpdater) RemoveStaleProxyRules(validPorts map[uint16]struct{}) int {
+	removed := 0
+	for port := range f.rules {
+		if _, valid := validPorts[port]; !valid {
+			delete(f.rules, port)
+			removed++
+		}
+	}
+	return removed
+}
+
+// portSim models concurrent users of the proxy port allocator: redirects
+// acknowledging and releasing proxy ports, interleaved with agent crashes
+// that drop all in-memory state and restore it from the state directory.
+type portSim struct {
+	proxy    *Proxy
+	datapath *fakeDatapathUpdater
+	stateDir string
+
+	// refs is the expected number of redirects per listener name.
+	refs map[string]int
+}
+
+// resetProxyPortState drops all package-level proxy port state, as a
+// freshly started agent would see it.
+func resetProxyPortState() {
+	proxyPortsMutex.Lock()
+	for i := range proxyPorts {
+		pp := &proxyPorts[i]
+		pp.proxyPort = 0
+		pp.rulesPort = 0
+		pp.nRedirects = 0
+		pp.configured = false
+		pp.isStatic = false
+	}
+	allocatedPorts = make(map[uint16]struct{})
+	proxyPortsMutex.Unlock()
+
+	proxyStateMutex.Lock()
+	savedState = proxyState{}
+	proxyStateMutex.Unlock()
+}
+
+func (s *portSim) newProxy() *Proxy {
+	return &Proxy{
+		stateDir:               s.stateDir,
+		rangeMin:               10000,
+		rangeMax:               12000,
+		redirects:              make(map[string]*Redirect),
+		datapathUpdater:        s.datapath,
+		healthCheckedRedirects: make(map[string]struct{}),
+	}
+}
+
+func newPortSim(seed int64, stateDir string) *portSim {
+	resetProxyPortState()
+	// The port allocator itself randomizes the allocated ports, reseed it
+	// so the whole simulation replays from the seed.
+	portRandomizer = ciliumrand.NewSafeRand(seed)
+
+	s := &portSim{
+		datapath: &fakeDatapathUpdater{rules: make(map[uint16]string)},
+		stateDir: stateDir,
+		refs:     make(map[string]int),
+	}
+	s.proxy = s.newProxy()
+	return s
+}
+
+// ack models a redirect acquiring its proxy port: the port is allocated on
+// first use and the datapath rules are installed on acknowledgement.
+func (s *portSim) ack(rng *rand.Rand) error {
+	proxyPortsMutex.Lock()
+	defer proxyPortsMutex.Unlock()
+
+	pp := &proxyPorts[rng.Intn(len(proxyPorts))]
+	if !pp.configured {
+		port, err := allocatePort(pp.proxyPort, s.proxy.rangeMin, s.proxy.rangeMax)
+		if err != nil {
+			// Port exhaustion is a legitimate outcome, not an
+			// invariant violation.
+			return nil
+		}
+		pp.proxyPort = port
+		pp.reservePort()
+	}
+	if err := s.proxy.ackProxyPort(pp); err != nil {
+		return err
+	}
+	s.refs[pp.name]++
+	return nil
+}
+
+// release models a redirect releasing its proxy port.
+func (s *portSim) release(rng *rand.Rand) error {
+	proxyPortsMutex.Lock()
+	defer proxyPortsMutex.Unlock()
+
+	pp := &proxyPorts[rng.Intn(len(proxyPorts))]
+	if s.refs[pp.name] == 0 {
+		return nil
+	}
+	if err := s.proxy.releaseProxyPort(pp.name); err != nil {
+		return err
+	}
+	s.refs[pp.name]--
+	return nil
+}
+
+// restart models an agent crash: all in-memory state is lost, the proxy
+// port state is restored from the state directory and stale datapath rules
+// are swept, exactly as on agent start-up. Any datapath rule surviving the
+// sweep without a proxy port accounting for it has been leaked.
+func (s *portSim) restart(rng *rand.Rand) error {
+	resetProxyPortState()
+	for name := range s.refs {
+		delete(s.refs, name)
+	}
+
+	s.proxy = s.newProxy()
+	s.proxy.RestoreProxyPorts()
+	s.proxy.RemoveStaleProxyRules()
+
+	proxyPortsMutex.Lock()
+	defer proxyPortsMutex.Unlock()
+	for port := range s.datapath.rules {
+		if findProxyPortByRulesPort(port) == nil {
+			return fmt.Errorf("datapath rules for port %d leaked across restart", port)
+		}
+	}
+	return nil
+}
+
+// findProxyPortByRulesPort returns the proxy port accounting for datapath
+// rules on the given port, if any.
+// Must be called with proxyPortsMutex held!
+func findProxyPortByRulesPort(port uint16) *ProxyPort {
+	for i := range proxyPorts {
+		if proxyPorts[i].rulesPort == port || proxyPorts[i].proxyPort == port {
+			return &proxyPorts[i]
+		}
+	}
+	return nil
+}
+
+// checkPorts verifies that no proxy port is allocated twice and that the
+// allocation bookkeeping matches the configured ports.
+func (s *portSim) checkPorts() error {
+	proxyPortsMutex.Lock()
+	defer proxyPortsMutex.Unlock()
+
+	configured := make(map[uint16]string)
+	for i := range proxyPorts {
+		pp := &proxyPorts[i]
+		if !pp.configured {
+			continue
+		}
+		if pp.proxyPort == 0 {
+			return fmt.Errorf("listener %s is configured without a port", pp.name)
+		}
+		if other, dup := configured[pp.proxyPort]; dup {
+			return fmt.Errorf("port %d allocated to both %s and %s", pp.proxyPort, other, pp.name)
+		}
+		configured[pp.proxyPort] = pp.name
+		if _, reserved := allocatedPorts[pp.proxyPort]; !reserved {
+			return fmt.Errorf("port %d of %s is not accounted as allocated", pp.proxyPort, pp.name)
+		}
+	}
+	if len(allocatedPorts) != len(configured) {
+		return fmt.Errorf("%d ports allocated but %d listeners configured", len(allocatedPorts), len(configured))
+	}
+	return nil
+}
+
+// checkRefcounts verifies that the reference counts match the modeled
+// number of redirects.
+func (s *portSim) checkRefcounts() error {
+	proxyPortsMutex.Lock()
+	defer proxyPortsMutex.Unlock()
+
+	for i := range proxyPorts {
+		pp := &proxyPorts[i]
+		if pp.nRedirects != s.refs[pp.name] {
+			return fmt.Errorf("listener %s has refcount %d, expected %d", pp.name, pp.nRedirects, s.refs[pp.name])
+		}
+		if pp.nRedirects > 0 && !pp.configured {
+			return fmt.Errorf("listener %s has %d redirects but no configured port", pp.name, pp.nRedirects)
+		}
+	}
+	return nil
+}
+
+// checkRules verifies that every acknowledged proxy port has its datapath
+// rules installed on the port the proxy is listening on.
+func (s *portSim) checkRules() error {
+	proxyPortsMutex.Lock()
+	defer proxyPortsMutex.Unlock()
+
+	for i := range proxyPorts {
+		pp := &proxyPorts[i]
+		if pp.nRedirects == 0 {
+			continue
+		}
+		if pp.rulesPort != pp.proxyPort {
+			return fmt.Errorf("listener %s listens on %d but rules are installed for %d", pp.name, pp.proxyPort, pp.rulesPort)
+		}
+		if _, installed := s.datapath.rules[pp.rulesPort]; !installed {
+			return fmt.Errorf("listener %s has no datapath rules for port %d", pp.name, pp.rulesPort)
+		}
+	}
+	return nil
+}
+
+// TestProxyPortSimulation runs the proxy port allocator through randomized
+// acknowledge/release sequences with crash/restart injection and verifies
+// that ports are never double allocated, the redirect reference counts stay
+// consistent and no datapath rules are leaked.
+func (s *ProxyPortSuite) TestProxyPortSimulation(c *check.C) {
+	defer resetProxyPortState()
+
+	for _, seed := range []int64{1, 42, 1984} {
+		sim := newPortSim(seed, c.MkDir())
+		h := simulation.New(seed)
+		h.AddOp("ack", 5, sim.ack)
+		h.AddOp("release", 4, sim.release)
+		h.AddOp("restart", 1, sim.restart)
+		h.AddInvariant("no double allocation", sim.checkPorts)
+		h.AddInvariant("consistent refcounts", sim.checkRefcounts)
+		h.AddInvariant("no leaked rules", sim.checkRules)
+		c.Assert(h.Run(2000), check.IsNil)
+	}
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package proxy
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/cilium/cilium/pkg/lock"
+)
+
+// proxyStateFileName is the file in the state directory to which the proxy
+// port assignments and redirect identifiers are persisted, so that a
+// restarted agent can re-adopt the proxy ports of its previous run instead
+// of tearing down the corresponding datapath rules.
+const proxyStateFileName = "proxy_state.json"
+
+// proxyPortState is the on-disk representation of a single proxy port.
+type proxyPortState struct {
+	// Name is the proxy listener name.
+	Name string `json:"name"`
+
+	// ProxyPort is the port the listener was last configured on.
+	ProxyPort uint16 `json:"proxy-port"`
+
+	// RulesPort is the port the datapath rules were last installed for.
+	RulesPort uint16 `json:"rules-port"`
+}
+
+// proxyRedirectState is the on-disk representation of a single redirect.
+type proxyRedirectState struct {
+	// Listener is the name of the proxy listener serving the redirect.
+	Listener string `json:"listener"`
+
+	// DstPort is the destination port of the redirected traffic.
+	DstPort uint16 `json:"dst-port"`
+}
+
+// proxyState is the on-disk representation of the proxy state.
+type proxyState struct {
+	// Ports are the proxy port assignments, one entry per listener that
+	// has been allocated a port.
+	Ports []proxyPortState `json:"ports,omitempty"`
+
+	// Redirects are the active redirects indexed by redirect identifier.
+	Redirects map[string]proxyRedirectState `json:"redirects,omitempty"`
+}
+
+var (
+	// proxyStateMutex protects savedState and serializes writes of the
+	// proxy state file. It is a leaf lock so that the state can be saved
+	// while either proxyPortsMutex or Proxy.mutex is held.
+	proxyStateMutex lock.Mutex
+
+	// savedState is the last state written to the proxy state file.
+	savedState proxyState
+)
+
+// proxyStateFilePath returns the path of the proxy state file.
+func (p *Proxy) proxyStateFilePath() string {
+	return filepath.Join(p.stateDir, proxyStateFileName)
+}
+
+// saveProxyPorts persists the current proxy port assignments to the state
+// directory. Must be called with proxyPortsMutex held.
+func (p *Proxy) saveProxyPorts() {
+	ports := make([]proxyPortState, 0, len(proxyPorts))
+	for i := range proxyPorts {
+		pp := &proxyPorts[i]
+		if pp.proxyPort == 0 && pp.rulesPort == 0 {
+			continue
+		}
+		ports = append(ports, proxyPortState{
+			Name:      pp.name,
+			ProxyPort: pp.proxyPort,
+			RulesPort: pp.rulesPort,
+		})
+	}
+
+	proxyStateMutex.Lock()
+	savedState.Ports = ports
+	p.writeProxyState()
+	proxyStateMutex.Unlock()
+}
+
+// saveRedirects persists the identifiers of the current redirects to the
+// state directory. Must be called with p.mutex held.
+func (p *Proxy) saveRedirects() {
+	redirects := make(map[string]proxyRedirectState, len(p.redirects))
+	for id, r := range p.redirects {
+		redirects[id] = proxyRedirectState{
+			Listener: r.listener.name,
+			DstPort:  r.dstPort,
+		}
+	}
+
+	proxyStateMutex.Lock()
+	savedState.Redirects = redirects
+	p.writeProxyState()
+	proxyStateMutex.Unlock()
+}
+
+// writeProxyState writes the proxy state file. The file is written to a
+// temporary location first and renamed into place so that a crash never
+// leaves a partially written state file behind.
+// Must be called with proxyStateMutex held.
+func (p *Proxy) writeProxyState() {
+	data, err := json.MarshalIndent(&savedState, "", "  ")
+	if err != nil {
+		log.WithError(err).Warning("Unable to marshal proxy state")
+		return
+	}
+
+	path := p.proxyStateFilePath()
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		log.WithError(err).Warning("Unable to write proxy state file")
+		return
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		log.WithError(err).Warning("Unable to rename proxy state file")
+	}
+}
+
+// RestoreProxyPorts restores the proxy port assignments persisted by a
+// previous agent run. The restored ports are desired values only: port
+// allocation still verifies their availability when the first redirect is
+// created. Restoring the rules port allows the datapath rules of the
+// previous run to be re-adopted instead of swept as stale, so that L7
+// traffic keeps flowing while the endpoints regenerate their redirects.
+// Must be called before any redirects are created.
+func (p *Proxy) RestoreProxyPorts() {
+	data, err := os.ReadFile(p.proxyStateFilePath())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.WithError(err).Warning("Unable to read proxy state file")
+		}
+		return
+	}
+
+	var state proxyState
+	if err := json.Unmarshal(data, &state); err != nil {
+		log.WithError(err).Warning("Unable to parse proxy state file")
+		return
+	}
+
+	proxyPortsMutex.Lock()
+	for _, ps := range state.Ports {
+		pp := findProxyPort(ps.Name)
+		if pp == nil || pp.configured || pp.proxyPort != 0 {
+			continue
+		}
+		log.Infof("Restoring proxy port %d for %s", ps.ProxyPort, ps.Name)
+		pp.proxyPort = ps.ProxyPort
+		pp.rulesPort = ps.RulesPort
+	}
+	proxyPortsMutex.Unlock()
+
+	p.mutex.Lock()
+	p.restoredRedirects = make(map[string]string, len(state.Redirects))
+	for id, rs := range state.Redirects {
+		p.restoredRedirects[id] = rs.Listener
+	}
+	p.mutex.Unlock()
+}
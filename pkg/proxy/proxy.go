@@ -4,22 +4,32 @@
 package proxy
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/backoff"
 	"github.com/cilium/cilium/pkg/completion"
+	"github.com/cilium/cilium/pkg/controller"
 	"github.com/cilium/cilium/pkg/envoy"
+	"github.com/cilium/cilium/pkg/eventjournal"
 	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging"
 	"github.com/cilium/cilium/pkg/logging/logfields"
 	"github.com/cilium/cilium/pkg/metrics"
+	"github.com/cilium/cilium/pkg/mtu"
 	"github.com/cilium/cilium/pkg/node"
+	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/proxy/logger"
 	"github.com/cilium/cilium/pkg/rand"
 	"github.com/cilium/cilium/pkg/revert"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/sirupsen/logrus"
 )
 
@@ -34,13 +44,20 @@ const (
 	// portReuseDelay is the delay until a port is being reused
 	portReuseDelay = 5 * time.Minute
 
-	// redirectCreationAttempts is the number of attempts to create a redirect
-	redirectCreationAttempts = 5
+	// staleRuleSweepInterval is the interval between periodic sweeps of
+	// datapath proxy rules not backed by the current proxy port state.
+	staleRuleSweepInterval = 10 * time.Minute
+
+	// prewarmAckTimeout bounds the wait for pre-warmed proxy listeners to
+	// be acknowledged.
+	prewarmAckTimeout = 30 * time.Second
 )
 
 type DatapathUpdater interface {
 	InstallProxyRules(proxyPort uint16, ingress bool, name string) error
 	SupportsOriginalSourceAddr() bool
+	RemoveStaleProxyRules(validPorts map[uint16]struct{}) int
+	GetProxyRuleCounters() map[uint16]uint64
 }
 
 type ProxyPort struct {
@@ -67,6 +84,68 @@ type ProxyPort struct {
 	// is non-zero when a proxy has been successfully created and the
 	// datapath rules have been created.
 	rulesPort uint16
+	// rulesInstalled is the time the datapath rules for rulesPort were last
+	// successfully installed by this agent. Zero for rules restored from a
+	// previous agent run that have not been reinstalled since.
+	rulesInstalled time.Time
+}
+
+// PortRange is a range of ports proxy ports may be allocated from.
+type PortRange struct {
+	// Min is the first port of the range, inclusive.
+	Min uint16
+	// Max is the last port of the range, inclusive.
+	Max uint16
+}
+
+// portRangeProxyTypes are the proxy types a port allocation range may be
+// configured for, mapped to the parser type of the corresponding proxy ports.
+var portRangeProxyTypes = map[string]policy.L7ParserType{
+	"dns":      policy.ParserTypeDNS,
+	"http":     policy.ParserTypeHTTP,
+	"kafka":    policy.ParserTypeKafka,
+	"tcp":      policy.ParserTypeTCP,
+	"proxylib": policy.ParserTypeNone,
+}
+
+// ParsePortRanges parses and validates the per-proxy-type port allocation
+// ranges given in the form "<type>=<min>-<max>", e.g. "dns=10000-10100".
+// The supported proxy types are "dns", "http", "kafka", "tcp" and "proxylib".
+// The ranges must not overlap each other.
+func ParsePortRanges(ranges []string) (map[policy.L7ParserType]PortRange, error) {
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+
+	parsed := make(map[policy.L7ParserType]PortRange, len(ranges))
+	for _, r := range ranges {
+		idx := strings.Index(r, "=")
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid proxy port range %q, expected <type>=<min>-<max>", r)
+		}
+		proxyType := r[:idx]
+		parserType, ok := portRangeProxyTypes[proxyType]
+		if !ok {
+			return nil, fmt.Errorf("unknown proxy type %q in proxy port range %q", proxyType, r)
+		}
+		if _, ok := parsed[parserType]; ok {
+			return nil, fmt.Errorf("duplicate proxy port range for proxy type %q", proxyType)
+		}
+		var min, max uint16
+		if _, err := fmt.Sscanf(r[idx+1:], "%d-%d", &min, &max); err != nil {
+			return nil, fmt.Errorf("invalid proxy port range %q: %w", r, err)
+		}
+		if min == 0 || min > max {
+			return nil, fmt.Errorf("invalid proxy port range %q", r)
+		}
+		for other, pr := range parsed {
+			if min <= pr.Max && pr.Min <= max {
+				return nil, fmt.Errorf("proxy port range %q overlaps with the range of proxy type %q", r, other)
+			}
+		}
+		parsed[parserType] = PortRange{Min: min, Max: max}
+	}
+	return parsed, nil
 }
 
 // Proxy maintains state about redirects
@@ -88,6 +167,12 @@ type Proxy struct {
 	// ports out of the rangeMin-rangeMax range.
 	rangeMax uint16
 
+	// portRanges are the optional per-proxy-type port allocation ranges,
+	// keyed by the parser type of the proxy ports they apply to. Proxy
+	// ports of types without an entry are allocated from the
+	// rangeMin-rangeMax range.
+	portRanges map[policy.L7ParserType]PortRange
+
 	// redirects is the map of all redirect configurations indexed by
 	// the redirect identifier. Redirects may be implemented by different
 	// proxies.
@@ -96,16 +181,42 @@ type Proxy struct {
 	// Datapath updater for installing and removing proxy rules for a single
 	// proxy port
 	datapathUpdater DatapathUpdater
+
+	// restoredRedirects maps the identifiers of the redirects of a
+	// previous agent run to their listener names, as restored from the
+	// proxy state file. Entries are removed as the redirects are
+	// re-created.
+	restoredRedirects map[string]string
+
+	// healthCheckedRedirects is the set of redirect identifiers probed in
+	// the previous health check round, used to drop the health metrics of
+	// removed redirects. Accessed only from the health check controller.
+	healthCheckedRedirects map[string]struct{}
+
+	// clampedMSS is the TCP maximum segment size applied to proxied
+	// connections, derived from the route MTU. Zero if no clamping is
+	// needed, i.e. the route MTU does not differ from the device MTU.
+	clampedMSS int
 }
 
 // StartProxySupport starts the servers to support L7 proxies: xDS GRPC server
 // and access log server.
-func StartProxySupport(minPort uint16, maxPort uint16, stateDir string,
-	accessLogNotifier logger.LogRecordNotifier, accessLogMetadata []string,
-	datapathUpdater DatapathUpdater, mgr EndpointLookup) *Proxy {
+func StartProxySupport(minPort uint16, maxPort uint16, portRanges map[policy.L7ParserType]PortRange,
+	stateDir string, accessLogNotifier logger.LogRecordNotifier, accessLogMetadata []string,
+	datapathUpdater DatapathUpdater, mgr EndpointLookup, mtuConfig mtu.Configuration) *Proxy {
 	endpointManager = mgr
 	xdsServer := envoy.StartXDSServer(stateDir)
 
+	// When the route MTU towards the endpoints is lower than the device
+	// MTU, connections hairpinned through the proxy would negotiate their
+	// segment size based on the device MTU of the proxy listener socket.
+	// Clamp the MSS of the proxied connections to the route MTU instead.
+	clampedMSS := 0
+	if mtuConfig.GetRouteMTU() < mtuConfig.GetDeviceMTU() {
+		clampedMSS = mtuConfig.GetRouteMSS()
+		xdsServer.SetListenerMSS(clampedMSS)
+	}
+
 	if accessLogNotifier != nil {
 		logger.SetNotifier(accessLogNotifier)
 	}
@@ -114,6 +225,10 @@ func StartProxySupport(minPort uint16, maxPort uint16, stateDir string,
 		logger.SetMetadata(accessLogMetadata)
 	}
 
+	if len(option.Config.DelegatedIdentityHeaders) > 0 {
+		logger.SetDelegatedIdentityHeaders(option.Config.DelegatedIdentityHeaders)
+	}
+
 	envoy.StartAccessLogServer(stateDir, xdsServer, DefaultEndpointInfoRegistry)
 
 	return &Proxy{
@@ -121,8 +236,12 @@ func StartProxySupport(minPort uint16, maxPort uint16, stateDir string,
 		stateDir:        stateDir,
 		rangeMin:        minPort,
 		rangeMax:        maxPort,
+		portRanges:      portRanges,
 		redirects:       make(map[string]*Redirect),
 		datapathUpdater: datapathUpdater,
+
+		healthCheckedRedirects: make(map[string]struct{}),
+		clampedMSS:             clampedMSS,
 	}
 }
 
@@ -154,6 +273,26 @@ var (
 			ingress:    false,
 			name:       "cilium-dns-egress",
 		},
+		{
+			parserType: policy.ParserTypeKafka,
+			ingress:    false,
+			name:       "cilium-kafka-egress",
+		},
+		{
+			parserType: policy.ParserTypeKafka,
+			ingress:    true,
+			name:       "cilium-kafka-ingress",
+		},
+		{
+			parserType: policy.ParserTypeTCP,
+			ingress:    false,
+			name:       "cilium-tcp-egress",
+		},
+		{
+			parserType: policy.ParserTypeTCP,
+			ingress:    true,
+			name:       "cilium-tcp-ingress",
+		},
 		{
 			parserType: policy.ParserTypeNone,
 			ingress:    false,
@@ -204,6 +343,15 @@ func allocatePort(port, min, max uint16) (uint16, error) {
 	return 0, fmt.Errorf("no available proxy ports")
 }
 
+// portRange returns the port allocation range for the given proxy port,
+// either the range configured for its proxy type or the global range.
+func (p *Proxy) portRange(pp *ProxyPort) (min, max uint16) {
+	if r, ok := p.portRanges[pp.parserType]; ok {
+		return r.Min, r.Max
+	}
+	return p.rangeMin, p.rangeMax
+}
+
 // Called with proxyPortsMutex held!
 func (pp *ProxyPort) reservePort() {
 	if !pp.configured {
@@ -260,9 +408,12 @@ func (p *Proxy) ackProxyPort(pp *ProxyPort) error {
 				return fmt.Errorf("Cannot install proxy rules for %s: %s", pp.name, err)
 			}
 			pp.rulesPort = pp.proxyPort
+			pp.rulesInstalled = time.Now()
 		}
 	}
+	logProxyPortEvent(proxyPortOpAck, pp)
 	pp.nRedirects++
+	p.saveProxyPorts()
 	return nil
 }
 
@@ -282,6 +433,7 @@ func (p *Proxy) releaseProxyPort(name string) error {
 		if pp.isStatic {
 			return fmt.Errorf("Can't release proxy port: proxy %s on %d has a static listener", name, pp.proxyPort)
 		}
+		logProxyPortEvent(proxyPortOpRelease, pp)
 		delete(allocatedPorts, pp.proxyPort)
 		// Force new port allocation the next time this ProxyPort is used.
 		pp.configured = false
@@ -289,6 +441,7 @@ func (p *Proxy) releaseProxyPort(name string) error {
 		// This becomes possible when we are able to keep the proxy listeners
 		// configured also when there are no redirects.
 		log.WithField(fieldProxyRedirectID, name).Debugf("Delayed release of proxy port %d", pp.proxyPort)
+		p.saveProxyPorts()
 	}
 
 	return nil
@@ -298,7 +451,7 @@ func (p *Proxy) releaseProxyPort(name string) error {
 func getProxyPort(l7Type policy.L7ParserType, ingress bool) *ProxyPort {
 	portType := l7Type
 	switch l7Type {
-	case policy.ParserTypeDNS, policy.ParserTypeHTTP:
+	case policy.ParserTypeDNS, policy.ParserTypeHTTP, policy.ParserTypeKafka, policy.ParserTypeTCP:
 	default:
 		// "Unknown" parsers are assumed to be Proxylib (TCP) parsers, which
 		// is registered with an empty string.
@@ -352,9 +505,51 @@ func (p *Proxy) SetProxyPort(name string, port uint16) error {
 	pp.proxyPort = port
 	pp.isStatic = true // prevents release of the proxy port
 	pp.reservePort()   // marks 'port' as reserved, 'pp' as configured
+	logProxyPortEvent(proxyPortOpSet, pp)
+	p.saveProxyPorts()
 	return nil
 }
 
+// RemoveStaleProxyRules removes any datapath proxy rules not matching the
+// current proxy port state. Stale rules can be left behind if the agent is
+// restarted after datapath rules have been installed, but before the
+// corresponding proxy port was acknowledged.
+func (p *Proxy) RemoveStaleProxyRules() {
+	proxyPortsMutex.Lock()
+	validPorts := make(map[uint16]struct{}, len(proxyPorts))
+	for i := range proxyPorts {
+		pp := &proxyPorts[i]
+		if pp.proxyPort != 0 {
+			validPorts[pp.proxyPort] = struct{}{}
+		}
+		// Rules are intentionally left behind after the last redirect is
+		// removed in the hope that they can be reused, keep them.
+		if pp.rulesPort != 0 {
+			validPorts[pp.rulesPort] = struct{}{}
+		}
+	}
+	proxyPortsMutex.Unlock()
+
+	if removed := p.datapathUpdater.RemoveStaleProxyRules(validPorts); removed > 0 {
+		log.Infof("Removed %d stale proxy datapath rules", removed)
+	}
+}
+
+// StartStaleRuleSweeper sweeps stale datapath proxy rules once and keeps
+// doing so periodically to catch rules orphaned by failed removals. Must only
+// be called after the proxy port state of a previous agent run has been
+// restored.
+func (p *Proxy) StartStaleRuleSweeper() {
+	controller.NewManager().UpdateController("proxy-stale-rule-sweep",
+		controller.ControllerParams{
+			DoFunc: func(ctx context.Context) error {
+				p.RemoveStaleProxyRules()
+				return nil
+			},
+			RunInterval: staleRuleSweepInterval,
+		})
+}
+
 // ReinstallRules is called by daemon reconfiguration to re-install proxy ports rules that
 // were removed during the removal of all Cilium rules.
 func (p *Proxy) ReinstallRules() {
@@ -366,11 +561,139 @@ func (p *Proxy) ReinstallRules() {
 			err := p.datapathUpdater.InstallProxyRules(pp.rulesPort, pp.ingress, pp.name)
 			if err != nil {
 				log.WithError(err).Errorf("Can't install proxy rules for %s", pp.name)
+			} else {
+				pp.rulesInstalled = time.Now()
 			}
 		}
 	}
 }
 
+// waitForRedirectRetry waits before the next redirect creation attempt using
+// the configured exponential backoff with jitter. Returns an error when the
+// per-attempt timeout expires before the wait completes, in which case the
+// caller should give up retrying. Note that this waits with the proxy locks
+// held, so the configured backoff and timeout should be kept short.
+func waitForRedirectRetry(boff *backoff.Exponential) error {
+	if option.Config.ProxyRedirectCreationBackoff == 0 {
+		return nil
+	}
+	ctx := context.Background()
+	if timeout := option.Config.ProxyRedirectCreationTimeout; timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return boff.Wait(ctx)
+}
+
+// createRedirectImpl launches the proxy instance serving the given redirect.
+func (p *Proxy) createRedirectImpl(redir *Redirect, l7Parser policy.L7ParserType, wg *completion.WaitGroup) (RedirectImplementation, error) {
+	switch l7Parser {
+	case policy.ParserTypeDNS:
+		return createDNSRedirect(redir, dnsConfiguration{}, DefaultEndpointInfoRegistry)
+
+	case policy.ParserTypeKafka:
+		return createKafkaRedirect(redir, kafkaConfiguration{}, DefaultEndpointInfoRegistry)
+
+	case policy.ParserTypeHTTP, policy.ParserTypeTCP:
+		return createEnvoyRedirect(redir, p.stateDir, p.XDSServer, p.datapathUpdater.SupportsOriginalSourceAddr(), wg)
+	default:
+		return createEnvoyRedirect(redir, p.stateDir, p.XDSServer, p.datapathUpdater.SupportsOriginalSourceAddr(), wg)
+	}
+}
+
+// prewarmedListeners tracks the Envoy listeners brought up by
+// PrewarmRedirects that have not been adopted by a redirect yet.
+// Protected by proxyPortsMutex.
+var prewarmedListeners = map[string]struct{}{}
+
+// PrewarmRedirects allocates proxy ports and brings up the proxy listeners
+// required by the redirects of the given policy ahead of the datapath switch.
+// When a subsequent CreateOrUpdateRedirect call finds a pre-warmed listener it
+// only has to acknowledge the already-listening proxy instead of allocating a
+// port and possibly retrying in the regeneration critical path. Pre-warming is
+// best-effort: any failure is recovered from by redirect creation falling back
+// to allocating on demand. Blocks until the listeners have been acknowledged,
+// so this is meant to be called from its own goroutine, without the proxy or
+// any endpoint lock held.
+func (p *Proxy) PrewarmRedirects(l4Policy *policy.L4Policy) {
+	if l4Policy == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), prewarmAckTimeout)
+	defer cancel()
+	wg := completion.NewWaitGroup(ctx)
+
+	for _, m := range []policy.L4PolicyMap{l4Policy.Ingress, l4Policy.Egress} {
+		for _, l4 := range m {
+			if l4.IsRedirect() {
+				p.prewarmRedirect(l4, wg)
+			}
+		}
+	}
+
+	if err := wg.Wait(); err != nil {
+		log.WithError(err).Warning("Timed out waiting for pre-warmed proxy listeners to be acknowledged")
+	}
+}
+
+// prewarmRedirect allocates the proxy port serving the redirects of the given
+// L4 policy and brings up the corresponding Envoy listener, if any.
+func (p *Proxy) prewarmRedirect(l4 policy.ProxyPolicy, wg *completion.WaitGroup) {
+	proxyPortsMutex.Lock()
+	defer proxyPortsMutex.Unlock()
+
+	pp := getProxyPort(l4.GetL7Parser(), l4.GetIngress())
+	if pp == nil {
+		return
+	}
+
+	if !pp.configured {
+		min, max := p.portRange(pp)
+		port, err := allocatePort(pp.proxyPort, min, max)
+		if err != nil {
+			log.WithError(err).Warningf("Unable to pre-warm port for %s proxy", pp.name)
+			return
+		}
+		pp.proxyPort = port
+		logProxyPortEvent(proxyPortOpAllocate, pp)
+		pp.reservePort()
+	}
+
+	// Only Envoy-based proxies have a listener that can be brought up ahead
+	// of the redirect. The DNS proxy has a static listener and the Kafka
+	// proxy brings up a listener per redirect.
+	switch l4.GetL7Parser() {
+	case policy.ParserTypeDNS, policy.ParserTypeKafka:
+		return
+	}
+
+	// Listeners of redirects that are currently in use are listening
+	// already.
+	if pp.nRedirects > 0 {
+		return
+	}
+
+	listenerName := net.JoinHostPort(pp.name, fmt.Sprintf("%d", pp.proxyPort))
+	if _, ok := prewarmedListeners[listenerName]; ok {
+		return
+	}
+
+	startEnvoy(p.stateDir, p.XDSServer, wg)
+	if envoyProxy == nil {
+		return
+	}
+
+	// Same listener configuration as createEnvoyRedirect() would use for a
+	// redirect of this policy.
+	mayUseOriginalSourceAddr := p.datapathUpdater.SupportsOriginalSourceAddr() &&
+		!l4.GetIngress() && l4.GetPreserveOriginalSourceAddr()
+	p.XDSServer.AddListener(listenerName, pp.parserType, pp.proxyPort, pp.ingress,
+		mayUseOriginalSourceAddr, wg)
+	prewarmedListeners[listenerName] = struct{}{}
+}
+
 // CreateOrUpdateRedirect creates or updates a L4 redirect with corresponding
 // proxy configuration. This will allocate a proxy port as required and launch
 // a proxy instance. If the redirect is already in place, only the rules will be
@@ -445,49 +768,70 @@ func (p *Proxy) CreateOrUpdateRedirect(l4 policy.ProxyPolicy, id string, localEn
 		return 0, err, nil, nil
 	}
 
-	redir := newRedirect(localEndpoint, pp, l4.GetPort())
+	redir := newRedirect(localEndpoint, pp, l4.GetPort(), l4.GetPreserveOriginalSourceAddr())
 	redir.updateRules(l4)
 	// Rely on create*Redirect to update rules, unlike the update case above.
 
-	for nRetry := 0; nRetry < redirectCreationAttempts; nRetry++ {
+	creationBackoff := backoff.Exponential{
+		Min:    option.Config.ProxyRedirectCreationBackoff,
+		Jitter: true,
+		Name:   "proxy-redirect-" + id,
+	}
+	for nRetry := 0; nRetry < option.Config.ProxyRedirectCreationAttempts; nRetry++ {
 		if nRetry > 0 {
 			// an error occurred and we can retry
 			scopedLog.WithError(err).Warningf("Unable to create %s proxy, retrying", pp.name)
+			if backoffErr := waitForRedirectRetry(&creationBackoff); backoffErr != nil {
+				scopedLog.WithError(backoffErr).Warningf("Timed out waiting to retry %s proxy creation", pp.name)
+				break
+			}
 		}
 
 		if !pp.configured {
 			// Try allocate (the configured) port, but only if the proxy has not
 			// been already configured.
-			pp.proxyPort, err = allocatePort(pp.proxyPort, p.rangeMin, p.rangeMax)
+			min, max := p.portRange(pp)
+			pp.proxyPort, err = allocatePort(pp.proxyPort, min, max)
 			if err != nil {
+				eventjournal.Record(eventjournal.ReasonProxyPortAllocation,
+					"Unable to allocate port for %s proxy: %s", pp.name, err)
 				revertFunc() // Ignore errors while reverting. This is best-effort.
 				return 0, err, nil, nil
 			}
+			logProxyPortEvent(proxyPortOpAllocate, pp)
 		}
 
-		switch l4.GetL7Parser() {
-		case policy.ParserTypeDNS:
-			redir.implementation, err = createDNSRedirect(redir, dnsConfiguration{}, DefaultEndpointInfoRegistry)
-
-		case policy.ParserTypeHTTP:
-			redir.implementation, err = createEnvoyRedirect(redir, p.stateDir, p.XDSServer, p.datapathUpdater.SupportsOriginalSourceAddr(), wg)
-		default:
-			redir.implementation, err = createEnvoyRedirect(redir, p.stateDir, p.XDSServer, p.datapathUpdater.SupportsOriginalSourceAddr(), wg)
-		}
+		redir.implementation, err = p.createRedirectImpl(redir, l4.GetL7Parser(), wg)
 
 		if err == nil {
 			scopedLog.WithField(logfields.Object, logfields.Repr(redir)).
 				Debug("Created new ", l4.GetL7Parser(), " proxy instance")
 			p.redirects[id] = redir
+			if listener, restored := p.restoredRedirects[id]; restored {
+				if listener == pp.name {
+					scopedLog.Debugf("Re-adopted restored %s proxy redirect", pp.name)
+				}
+				delete(p.restoredRedirects, id)
+			}
+			p.saveRedirects()
 			// must mark the proxyPort configured while we still hold the lock to prevent racing between
 			// two parallel runs
 			pp.reservePort()
 
+			// Drop the reference held on a pre-warmed listener now that
+			// the redirect implementation has taken its own.
+			listenerName := net.JoinHostPort(pp.name, fmt.Sprintf("%d", pp.proxyPort))
+			if _, prewarmed := prewarmedListeners[listenerName]; prewarmed {
+				delete(prewarmedListeners, listenerName)
+				p.XDSServer.RemoveListener(listenerName, wg)
+			}
+
 			revertStack.Push(func() error {
 				// Proxy port refcount has not been incremented yet, so it must not be decremented
 				// when reverting. Undo what we have done above.
 				p.mutex.Lock()
 				delete(p.redirects, id)
+				p.saveRedirects()
 				p.updateRedirectMetrics()
 				p.mutex.Unlock()
 				implFinalizeFunc, _ := redir.implementation.Close(wg)
@@ -545,6 +889,7 @@ func (p *Proxy) removeRedirect(id string, wg *completion.WaitGroup) (err error,
 		return fmt.Errorf("unable to find redirect %s", id), nil, nil
 	}
 	delete(p.redirects, id)
+	p.saveRedirects()
 
 	implFinalizeFunc, implRevertFunc := r.implementation.Close(wg)
 
@@ -581,6 +926,7 @@ func (p *Proxy) removeRedirect(id string, wg *completion.WaitGroup) (err error,
 
 		p.mutex.Lock()
 		p.redirects[id] = r
+		p.saveRedirects()
 		p.mutex.Unlock()
 
 		return nil
@@ -596,8 +942,32 @@ func ChangeLogLevel(level logrus.Level) {
 	}
 }
 
+// portRangeString returns the global port allocation range, followed by the
+// per-proxy-type ranges, if any, as a human readable string.
+func (p *Proxy) portRangeString() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d-%d", p.rangeMin, p.rangeMax)
+
+	types := make([]string, 0, len(p.portRanges))
+	for proxyType, parserType := range portRangeProxyTypes {
+		if _, ok := p.portRanges[parserType]; ok {
+			types = append(types, proxyType)
+		}
+	}
+	sort.Strings(types)
+	for _, proxyType := range types {
+		r := p.portRanges[portRangeProxyTypes[proxyType]]
+		fmt.Fprintf(&sb, ", %s=%d-%d", proxyType, r.Min, r.Max)
+	}
+	return sb.String()
+}
+
 // GetStatusModel returns the proxy status as API model
 func (p *Proxy) GetStatusModel() *models.ProxyStatus {
+	// Gather the datapath rule counters before taking the locks, as this
+	// involves listing the installed rules.
+	counters := p.datapathUpdater.GetProxyRuleCounters()
+
 	p.mutex.RLock()
 	defer p.mutex.RUnlock()
 	proxyPortsMutex.Lock()
@@ -605,7 +975,7 @@ func (p *Proxy) GetStatusModel() *models.ProxyStatus {
 
 	result := &models.ProxyStatus{
 		IP:             node.GetInternalIPv4Router().String(),
-		PortRange:      fmt.Sprintf("%d-%d", p.rangeMin, p.rangeMax),
+		PortRange:      p.portRangeString(),
 		TotalRedirects: int64(len(p.redirects)),
 	}
 	for _, pp := range proxyPorts {
@@ -614,25 +984,130 @@ func (p *Proxy) GetStatusModel() *models.ProxyStatus {
 		}
 	}
 	for name, redirect := range p.redirects {
-		result.Redirects = append(result.Redirects, &models.ProxyRedirect{
+		modelRedirect := &models.ProxyRedirect{
 			Name:      name,
 			Proxy:     redirect.listener.name,
 			ProxyPort: int64(redirect.listener.rulesPort),
-		})
+		}
+		// Only the Envoy-based proxies clamp the segment size of their
+		// connections via the listener socket options.
+		if _, ok := redirect.implementation.(*envoyRedirect); ok {
+			modelRedirect.ClampedMss = int64(p.clampedMSS)
+		}
+		result.Redirects = append(result.Redirects, modelRedirect)
+	}
+	for i := range proxyPorts {
+		pp := &proxyPorts[i]
+		if pp.rulesPort == 0 {
+			continue
+		}
+		packets, installed := counters[pp.rulesPort]
+		rule := &models.ProxyDatapathRule{
+			Listener:  pp.name,
+			Port:      int64(pp.rulesPort),
+			Installed: installed,
+			Packets:   int64(packets),
+		}
+		if !pp.rulesInstalled.IsZero() {
+			rule.LastInstall = strfmt.DateTime(pp.rulesInstalled)
+		}
+		result.DatapathRules = append(result.DatapathRules, rule)
 	}
 	return result
 }
 
+// GetRedirectStats returns the traffic statistics of all currently configured
+// redirects as API model. The request counters are aggregated from the proxy
+// statistics collected on the proxied endpoints, which are updated by all
+// proxy integrations.
+func (p *Proxy) GetRedirectStats() []*models.ProxyRedirectStatistics {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	stats := make([]*models.ProxyRedirectStatistics, 0, len(p.redirects))
+	for name, redirect := range p.redirects {
+		stat := &models.ProxyRedirectStatistics{
+			Name:              name,
+			Proxy:             redirect.listener.name,
+			ActiveConnections: redirect.getActiveConns(),
+		}
+		// The redirect name doubles as the key of the proxy statistics
+		// on the endpoint.
+		if s := redirect.localEndpoint.GetProxyStatistics(name); s != nil {
+			stat.ProcessedRequests = s.Requests.Received
+			stat.DeniedRequests = s.Requests.Denied
+			stat.UpstreamErrors = s.Responses.Error
+		}
+		stats = append(stats, stat)
+	}
+	return stats
+}
+
+// NumRedirects returns the number of currently configured redirects.
+func (p *Proxy) NumRedirects() int {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return len(p.redirects)
+}
+
+// GetListenersModel returns the state of all proxy listeners as API model,
+// combining the static proxy port configuration with the current redirect
+// state.
+func (p *Proxy) GetListenersModel() []*models.ProxyListener {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	proxyPortsMutex.Lock()
+	defer proxyPortsMutex.Unlock()
+
+	listeners := make([]*models.ProxyListener, 0, len(proxyPorts))
+	for i := range proxyPorts {
+		pp := &proxyPorts[i]
+		parser := string(pp.parserType)
+		if parser == "" {
+			// Empty parser type is registered for Proxylib (TCP) parsers.
+			parser = "tcp"
+		}
+		listeners = append(listeners, &models.ProxyListener{
+			Name:         pp.name,
+			Parser:       parser,
+			Port:         int64(pp.proxyPort),
+			Ingress:      pp.ingress,
+			Redirects:    int64(pp.nRedirects),
+			Acknowledged: pp.rulesPort != 0 && pp.rulesPort == pp.proxyPort,
+		})
+	}
+	return listeners
+}
+
 // updateRedirectMetrics updates the redirect metrics per application protocol
 // in Prometheus. Lock needs to be held to call this function.
 func (p *Proxy) updateRedirectMetrics() {
-	result := map[string]int{}
+	// Seed the well-known parser types so that their gauges drop back to
+	// zero once the last redirect of that type has been removed. Custom
+	// parsers are reported under their own name as before.
+	result := map[string]int{
+		string(policy.ParserTypeDNS):   0,
+		string(policy.ParserTypeHTTP):  0,
+		string(policy.ParserTypeKafka): 0,
+	}
+	envoyRedirects := 0
 	for _, redirect := range p.redirects {
 		result[string(redirect.listener.parserType)]++
+		// All parser types but the DNS proxy are served by the
+		// embedded Envoy.
+		if redirect.listener.parserType != policy.ParserTypeDNS {
+			envoyRedirects++
+		}
 	}
 	for proto, count := range result {
 		metrics.ProxyRedirects.WithLabelValues(proto).Set(float64(count))
 	}
+
+	embedded := float64(0)
+	if envoyRedirects > 0 {
+		embedded = 1
+	}
+	metrics.ProxyDeploymentMode.WithLabelValues("embedded").Set(embedded)
 }
 
 // UpdateNetworkPolicy must update the redirect configuration of an endpoint in the proxy
@@ -4,6 +4,8 @@
 package proxy
 
 import (
+	"sync/atomic"
+
 	"github.com/cilium/cilium/pkg/completion"
 	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/policy"
@@ -37,18 +39,46 @@ type Redirect struct {
 	localEndpoint  logger.EndpointUpdater
 	implementation RedirectImplementation
 
+	// preserveOriginalSourceAddr is true if the proxy should preserve the
+	// original source address of redirected connections rather than SNAT
+	// to the proxy address, if supported by the datapath.
+	preserveOriginalSourceAddr bool
+
+	// activeConns is the number of connections currently proxied by the
+	// redirect implementation, if it tracks connections. Accessed
+	// atomically without holding the mutex.
+	activeConns int64
+
 	// The following fields are updated while the redirect is alive, the
 	// mutex must be held to read and write these fields
 	mutex lock.RWMutex
 	rules policy.L7DataMap
 }
 
-func newRedirect(localEndpoint logger.EndpointUpdater, listener *ProxyPort, dstPort uint16) *Redirect {
+// connOpened accounts for a new connection proxied by the redirect
+// implementation.
+func (r *Redirect) connOpened() {
+	atomic.AddInt64(&r.activeConns, 1)
+}
+
+// connClosed accounts for a proxied connection that has been closed.
+func (r *Redirect) connClosed() {
+	atomic.AddInt64(&r.activeConns, -1)
+}
+
+// getActiveConns returns the number of connections currently proxied by the
+// redirect implementation.
+func (r *Redirect) getActiveConns() int64 {
+	return atomic.LoadInt64(&r.activeConns)
+}
+
+func newRedirect(localEndpoint logger.EndpointUpdater, listener *ProxyPort, dstPort uint16, preserveOriginalSourceAddr bool) *Redirect {
 	return &Redirect{
-		listener:      listener,
-		dstPort:       dstPort,
-		endpointID:    localEndpoint.GetID(),
-		localEndpoint: localEndpoint,
+		listener:                   listener,
+		dstPort:                    dstPort,
+		endpointID:                 localEndpoint.GetID(),
+		localEndpoint:              localEndpoint,
+		preserveOriginalSourceAddr: preserveOriginalSourceAddr,
 	}
 }
 
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package proxy
+
+import (
+	"context"
+	"time"
+
+	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/metrics"
+)
+
+const (
+	// ruleStatusInterval is the interval between periodic refreshes of the
+	// per-proxy-port datapath rule status metrics.
+	ruleStatusInterval = 30 * time.Second
+)
+
+// StartRuleStatusUpdater starts the periodic refresh of the per-proxy-port
+// datapath rule status metrics, so that silently lost TPROXY rules become
+// visible on dashboards. Must only be called after the proxy port state of a
+// previous agent run has been restored.
+func (p *Proxy) StartRuleStatusUpdater() {
+	controller.NewManager().UpdateController("proxy-rule-status",
+		controller.ControllerParams{
+			DoFunc: func(ctx context.Context) error {
+				p.updateRuleStatusMetrics()
+				return nil
+			},
+			RunInterval: ruleStatusInterval,
+		})
+}
+
+// updateRuleStatusMetrics updates the datapath rule status metrics of all
+// acknowledged proxy ports from the currently installed datapath rules.
+func (p *Proxy) updateRuleStatusMetrics() {
+	// Gather the rule counters before taking the lock, as this involves
+	// listing the installed rules.
+	counters := p.datapathUpdater.GetProxyRuleCounters()
+
+	proxyPortsMutex.Lock()
+	defer proxyPortsMutex.Unlock()
+	for i := range proxyPorts {
+		pp := &proxyPorts[i]
+		if pp.rulesPort == 0 {
+			// Drop the metrics of ports whose rules have been
+			// removed on purpose, e.g. on a proxy port change.
+			metrics.ProxyDatapathRulesInstalled.DeleteLabelValues(pp.name)
+			metrics.ProxyDatapathRulesPackets.DeleteLabelValues(pp.name)
+			metrics.ProxyDatapathRulesInstallTime.DeleteLabelValues(pp.name)
+			continue
+		}
+		packets, installed := counters[pp.rulesPort]
+		installedGauge := float64(0)
+		if installed {
+			installedGauge = 1
+		}
+		metrics.ProxyDatapathRulesInstalled.WithLabelValues(pp.name).Set(installedGauge)
+		metrics.ProxyDatapathRulesPackets.WithLabelValues(pp.name).Set(float64(packets))
+		if !pp.rulesInstalled.IsZero() {
+			metrics.ProxyDatapathRulesInstallTime.WithLabelValues(pp.name).Set(float64(pp.rulesInstalled.Unix()))
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+// Package slo aggregates L7 proxy records into per-service success-rate and
+// latency metrics with bounded cardinality (service namespace and name only),
+// providing golden-signal metrics for services behind L7 policies without a
+// mesh sidecar.
+package slo
+
+import (
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/metrics"
+	"github.com/cilium/cilium/pkg/proxy/accesslog"
+	"github.com/cilium/cilium/pkg/proxy/logger"
+)
+
+const (
+	// maxPendingRequests bounds the number of in-flight requests tracked
+	// for latency correlation. Requests beyond the bound are counted but
+	// not observed for latency.
+	maxPendingRequests = 8192
+
+	// pendingExpiry is the duration after which an in-flight request is
+	// given up on without a matching response.
+	pendingExpiry = 2 * time.Minute
+)
+
+// ServiceResolver resolves an address to the Kubernetes service it belongs
+// to. Returns false when the address does not belong to a known service.
+type ServiceResolver func(ip net.IP, port uint16) (namespace, name string, ok bool)
+
+// connKey identifies the proxied connection a record was observed on.
+type connKey struct {
+	src string
+	dst string
+}
+
+// pendingRequest is the start of an in-flight request awaiting its response.
+type pendingRequest struct {
+	key connKey
+	ts  time.Time
+}
+
+// Aggregator aggregates L7 proxy records into per-service metrics. It
+// implements logger.RecordObserver.
+type Aggregator struct {
+	resolver ServiceResolver
+
+	mutex lock.Mutex
+
+	// pending tracks the start of in-flight requests per connection.
+	// Requests on the same connection are matched to responses in FIFO
+	// order, which holds for the sequential protocols served by the proxy.
+	pending map[connKey][]time.Time
+
+	// queue orders the in-flight requests by age for expiry and bounding.
+	// Entries already matched to a response are skipped lazily.
+	queue []pendingRequest
+}
+
+// NewAggregator returns a new aggregator resolving services via the given
+// resolver.
+func NewAggregator(resolver ServiceResolver) *Aggregator {
+	return &Aggregator{
+		resolver: resolver,
+		pending:  map[connKey][]time.Time{},
+	}
+}
+
+// ObserveRecord aggregates a single L7 record. Records which cannot be
+// attributed to a known service are ignored.
+func (a *Aggregator) ObserveRecord(lr *logger.LogRecord) {
+	if lr.HTTP == nil {
+		// Only HTTP records carry a response code suitable for
+		// success-rate accounting.
+		return
+	}
+
+	namespace, name, ok := a.resolveService(lr)
+	if !ok {
+		return
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, lr.Timestamp)
+	if err != nil {
+		return
+	}
+
+	key := connKey{
+		src: endpointAddr(&lr.SourceEndpoint),
+		dst: endpointAddr(&lr.DestinationEndpoint),
+	}
+
+	switch lr.Type {
+	case accesslog.TypeRequest:
+		a.trackRequest(key, ts)
+	case accesslog.TypeResponse:
+		outcome := metrics.LabelValueOutcomeSuccess
+		// Client errors (4xx) are intentional responses, only server
+		// errors count against the success rate.
+		if lr.HTTP.Code >= 500 {
+			outcome = metrics.LabelValueOutcomeFail
+		}
+		metrics.ProxyServiceRequests.WithLabelValues(namespace, name, outcome).Inc()
+
+		if start, ok := a.matchRequest(key, ts); ok {
+			metrics.ProxyServiceRequestDuration.WithLabelValues(namespace, name).
+				Observe(ts.Sub(start).Seconds())
+		}
+	}
+}
+
+// resolveService attributes the record to a service via the destination
+// address of the flow.
+func (a *Aggregator) resolveService(lr *logger.LogRecord) (string, string, bool) {
+	ip := net.ParseIP(lr.DestinationEndpoint.IPv4)
+	if ip == nil {
+		ip = net.ParseIP(lr.DestinationEndpoint.IPv6)
+	}
+	if ip == nil {
+		return "", "", false
+	}
+	return a.resolver(ip, lr.DestinationEndpoint.Port)
+}
+
+// endpointAddr returns the address of the endpoint in ip:port form.
+func endpointAddr(info *accesslog.EndpointInfo) string {
+	ip := info.IPv4
+	if ip == "" {
+		ip = info.IPv6
+	}
+	return net.JoinHostPort(ip, strconv.Itoa(int(info.Port)))
+}
+
+// trackRequest records the start of an in-flight request, expiring stale
+// entries and enforcing the bound.
+func (a *Aggregator) trackRequest(key connKey, ts time.Time) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.expire(ts)
+
+	if len(a.queue) >= maxPendingRequests {
+		return
+	}
+
+	a.pending[key] = append(a.pending[key], ts)
+	a.queue = append(a.queue, pendingRequest{key: key, ts: ts})
+}
+
+// matchRequest pops the oldest in-flight request of the connection and
+// returns its start time.
+func (a *Aggregator) matchRequest(key connKey, now time.Time) (time.Time, bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.expire(now)
+
+	starts := a.pending[key]
+	if len(starts) == 0 {
+		return time.Time{}, false
+	}
+
+	start := starts[0]
+	if len(starts) == 1 {
+		delete(a.pending, key)
+	} else {
+		a.pending[key] = starts[1:]
+	}
+	return start, true
+}
+
+// expire drops in-flight requests which have not seen a response within
+// pendingExpiry. Must be called with the mutex held.
+func (a *Aggregator) expire(now time.Time) {
+	for len(a.queue) > 0 && now.Sub(a.queue[0].ts) > pendingExpiry {
+		head := a.queue[0]
+		a.queue = a.queue[1:]
+
+		// The entry may already have been matched to a response, in
+		// which case the connection's oldest start time has moved on.
+		starts := a.pending[head.key]
+		if len(starts) == 0 || !starts[0].Equal(head.ts) {
+			continue
+		}
+		if len(starts) == 1 {
+			delete(a.pending, head.key)
+		} else {
+			a.pending[head.key] = starts[1:]
+		}
+	}
+}
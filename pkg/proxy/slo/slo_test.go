@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package slo
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cilium/cilium/pkg/proxy/accesslog"
+	"github.com/cilium/cilium/pkg/proxy/logger"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	check.TestingT(t)
+}
+
+type SLOSuite struct{}
+
+var _ = check.Suite(&SLOSuite{})
+
+func (s *SLOSuite) TestRequestCorrelation(c *check.C) {
+	a := NewAggregator(func(ip net.IP, port uint16) (string, string, bool) {
+		return "default", "echo", true
+	})
+
+	key := connKey{src: "10.0.0.1:34567", dst: "10.96.0.10:80"}
+	start := time.Now()
+
+	a.trackRequest(key, start)
+	matched, ok := a.matchRequest(key, start.Add(10*time.Millisecond))
+	c.Assert(ok, check.Equals, true)
+	c.Assert(matched.Equal(start), check.Equals, true)
+
+	// matched requests are gone
+	_, ok = a.matchRequest(key, start)
+	c.Assert(ok, check.Equals, false)
+
+	// requests on the same connection are matched in FIFO order
+	second := start.Add(time.Millisecond)
+	a.trackRequest(key, start)
+	a.trackRequest(key, second)
+	matched, ok = a.matchRequest(key, second)
+	c.Assert(ok, check.Equals, true)
+	c.Assert(matched.Equal(start), check.Equals, true)
+	matched, ok = a.matchRequest(key, second)
+	c.Assert(ok, check.Equals, true)
+	c.Assert(matched.Equal(second), check.Equals, true)
+
+	// requests without a response expire
+	a.trackRequest(key, start)
+	_, ok = a.matchRequest(key, start.Add(pendingExpiry+time.Second))
+	c.Assert(ok, check.Equals, false)
+}
+
+func (s *SLOSuite) TestObserveRecord(c *check.C) {
+	resolved := 0
+	a := NewAggregator(func(ip net.IP, port uint16) (string, string, bool) {
+		resolved++
+		c.Assert(ip.String(), check.Equals, "10.96.0.10")
+		c.Assert(port, check.Equals, uint16(80))
+		return "default", "echo", true
+	})
+
+	lr := &logger.LogRecord{
+		LogRecord: accesslog.LogRecord{
+			Type:      accesslog.TypeRequest,
+			Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+			SourceEndpoint: accesslog.EndpointInfo{
+				IPv4: "10.0.0.1",
+				Port: 34567,
+			},
+			DestinationEndpoint: accesslog.EndpointInfo{
+				IPv4: "10.96.0.10",
+				Port: 80,
+			},
+			HTTP: &accesslog.LogRecordHTTP{Method: "GET"},
+		},
+	}
+
+	a.ObserveRecord(lr)
+	c.Assert(resolved, check.Equals, 1)
+	c.Assert(len(a.queue), check.Equals, 1)
+
+	// non-HTTP records are ignored
+	a.ObserveRecord(&logger.LogRecord{})
+	c.Assert(resolved, check.Equals, 1)
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package proxy
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// RedirectState is a snapshot of the state of a single redirect, exposed
+// through the agent debuginfo API.
+type RedirectState struct {
+	// ID is the redirect identifier, e.g. "1234:ingress:TCP:80".
+	ID string `json:"id"`
+	// Listener is the name of the proxy port the redirect maps to.
+	Listener string `json:"listener"`
+	// ProxyPort is the port the proxy implementing the redirect listens on.
+	ProxyPort uint16 `json:"proxy-port"`
+	// ParserType is the L7 parser type of the redirect.
+	ParserType string `json:"parser-type"`
+	// Ingress is true for ingress redirects, false for egress.
+	Ingress bool `json:"ingress"`
+	// DstPort is the destination port of the redirected traffic.
+	DstPort uint16 `json:"dst-port"`
+	// EndpointID is the ID of the endpoint the redirect applies to.
+	EndpointID uint64 `json:"endpoint-id"`
+	// ActiveConns is the number of connections currently proxied by the
+	// redirect, if the implementation tracks connections.
+	ActiveConns int64 `json:"active-connections"`
+	// RuleCount is the number of L7 rule sets installed on the redirect.
+	RuleCount int `json:"rule-count"`
+}
+
+// ProxyPortState is a snapshot of the state of a single proxy port, exposed
+// through the agent debuginfo API.
+type ProxyPortState struct {
+	// Name is the listener name of the proxy port.
+	Name string `json:"name"`
+	// ParserType is the L7 parser type the proxy port applies to.
+	ParserType string `json:"parser-type"`
+	// Ingress is true for ingress proxy ports, false for egress.
+	Ingress bool `json:"ingress"`
+	// Static is true when the listener on the proxy port cannot be
+	// stopped or reconfigured with a new port once started.
+	Static bool `json:"static"`
+	// ProxyPort is the desired proxy listening port number, zero when no
+	// port has been allocated yet.
+	ProxyPort uint16 `json:"proxy-port"`
+	// RulesPort is the proxy port value configured to the datapath rules,
+	// zero when no datapath rules have been created.
+	RulesPort uint16 `json:"rules-port"`
+	// RedirectCount is the number of redirects using the proxy port.
+	RedirectCount int `json:"redirect-count"`
+	// Configured is true when the proxy on the port is (being)
+	// configured, but not necessarily acknowledged yet.
+	Configured bool `json:"configured"`
+}
+
+// GetRedirectStates returns a snapshot of all current redirects, sorted by
+// redirect identifier.
+func (p *Proxy) GetRedirectStates() []RedirectState {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	states := make([]RedirectState, 0, len(p.redirects))
+	for id, redir := range p.redirects {
+		redir.mutex.RLock()
+		ruleCount := len(redir.rules)
+		redir.mutex.RUnlock()
+
+		states = append(states, RedirectState{
+			ID:          id,
+			Listener:    redir.listener.name,
+			ProxyPort:   redir.listener.proxyPort,
+			ParserType:  string(redir.listener.parserType),
+			Ingress:     redir.listener.ingress,
+			DstPort:     redir.dstPort,
+			EndpointID:  redir.endpointID,
+			ActiveConns: atomic.LoadInt64(&redir.activeConns),
+			RuleCount:   ruleCount,
+		})
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].ID < states[j].ID
+	})
+	return states
+}
+
+// GetProxyPortStates returns a snapshot of all supported proxy ports, in
+// their fixed order.
+func GetProxyPortStates() []ProxyPortState {
+	proxyPortsMutex.Lock()
+	defer proxyPortsMutex.Unlock()
+
+	states := make([]ProxyPortState, 0, len(proxyPorts))
+	for i := range proxyPorts {
+		pp := &proxyPorts[i]
+		states = append(states, ProxyPortState{
+			Name:          pp.name,
+			ParserType:    string(pp.parserType),
+			Ingress:       pp.ingress,
+			Static:        pp.isStatic,
+			ProxyPort:     pp.proxyPort,
+			RulesPort:     pp.rulesPort,
+			RedirectCount: pp.nRedirects,
+			Configured:    pp.configured,
+		})
+	}
+	return states
+}
@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package service
+
+import (
+	"time"
+
+	lb "github.com/cilium/cilium/pkg/loadbalancer"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+)
+
+const (
+	// feedbackMaxWeight is the weight assigned to the backend with the
+	// lowest observed RTT. The weights of the other backends are scaled
+	// down relative to it.
+	feedbackMaxWeight = 100
+
+	// feedbackRetention is how long an observation remains valid. Backends
+	// without a valid observation are assigned the maximum weight.
+	feedbackRetention = 5 * time.Minute
+
+	// feedbackRefreshInterval bounds how often the Maglev lookup tables of
+	// services using the latency algorithm are recomputed from the
+	// accumulated observations.
+	feedbackRefreshInterval = 30 * time.Second
+)
+
+// backendObservation accumulates the feedback observed for a single backend.
+type backendObservation struct {
+	// rtt is an exponentially weighted moving average of the observed
+	// round-trip times.
+	rtt time.Duration
+
+	// failures is the number of connection failures observed since the
+	// last successful RTT observation.
+	failures int
+
+	// lastUpdate is when the observation was last updated.
+	lastUpdate time.Time
+}
+
+// backendFeedbackTracker maintains per-backend RTT and connection failure
+// observations, used to weight the backends of services using the latency
+// load balancing algorithm. Backends are keyed by their L3n4Addr string
+// representation.
+type backendFeedbackTracker struct {
+	lock.RWMutex
+
+	observations map[string]*backendObservation
+
+	// lastRefresh is when the lookup tables of the latency algorithm
+	// services were last recomputed. Accessed with the mutex held.
+	lastRefresh time.Time
+}
+
+var defaultBackendFeedback = &backendFeedbackTracker{
+	observations: map[string]*backendObservation{},
+}
+
+// observeRTT records a round-trip time observation for the given backend.
+func (t *backendFeedbackTracker) observeRTT(addr string, rtt time.Duration) {
+	t.Lock()
+	defer t.Unlock()
+
+	o, ok := t.observations[addr]
+	if !ok {
+		o = &backendObservation{}
+		t.observations[addr] = o
+	}
+	if o.rtt == 0 {
+		o.rtt = rtt
+	} else {
+		o.rtt = (3*o.rtt + rtt) / 4
+	}
+	o.failures = 0
+	o.lastUpdate = time.Now()
+}
+
+// observeFailure records a connection failure observation for the given
+// backend.
+func (t *backendFeedbackTracker) observeFailure(addr string) {
+	t.Lock()
+	defer t.Unlock()
+
+	o, ok := t.observations[addr]
+	if !ok {
+		o = &backendObservation{}
+		t.observations[addr] = o
+	}
+	o.failures++
+	o.lastUpdate = time.Now()
+}
+
+// backendWeights returns a weight in [1, feedbackMaxWeight] for each of the
+// given backends. The backend with the lowest observed RTT is assigned the
+// maximum weight and the other backends are scaled down proportionally to
+// their RTT. Each observed connection failure halves the weight. Returns nil
+// when no valid observation exists, in which case the backends should be
+// weighted equally.
+func (t *backendFeedbackTracker) backendWeights(backends map[string]uint16) map[string]uint16 {
+	t.RLock()
+	defer t.RUnlock()
+
+	deadline := time.Now().Add(-feedbackRetention)
+
+	var minRTT time.Duration
+	for addr := range backends {
+		if o, ok := t.observations[addr]; ok && o.rtt > 0 && o.lastUpdate.After(deadline) {
+			if minRTT == 0 || o.rtt < minRTT {
+				minRTT = o.rtt
+			}
+		}
+	}
+	if minRTT == 0 {
+		return nil
+	}
+
+	weights := make(map[string]uint16, len(backends))
+	for addr := range backends {
+		w := int64(feedbackMaxWeight)
+		if o, ok := t.observations[addr]; ok && o.lastUpdate.After(deadline) {
+			if o.rtt > 0 {
+				w = int64(feedbackMaxWeight) * int64(minRTT) / int64(o.rtt)
+			}
+			for i := 0; i < o.failures && w > 1; i++ {
+				w /= 2
+			}
+			if w < 1 {
+				w = 1
+			}
+		}
+		weights[addr] = uint16(w)
+	}
+	return weights
+}
+
+// ObserveBackendRTT records a round-trip time observation for the given
+// backend, e.g. from socket-level feedback or an L7 proxy. The observations
+// are periodically incorporated into the Maglev lookup tables of the
+// services using the latency load balancing algorithm.
+func (s *Service) ObserveBackendRTT(backend lb.L3n4Addr, rtt time.Duration) {
+	defaultBackendFeedback.observeRTT(backend.String(), rtt)
+	s.refreshLatencyServices()
+}
+
+// ObserveBackendFailure records a connection failure observation for the
+// given backend. See ObserveBackendRTT.
+func (s *Service) ObserveBackendFailure(backend lb.L3n4Addr) {
+	defaultBackendFeedback.observeFailure(backend.String())
+	s.refreshLatencyServices()
+}
+
+// refreshLatencyServices recomputes the Maglev lookup tables of the services
+// using the latency load balancing algorithm, at most once per
+// feedbackRefreshInterval.
+func (s *Service) refreshLatencyServices() {
+	t := defaultBackendFeedback
+
+	t.Lock()
+	if time.Since(t.lastRefresh) < feedbackRefreshInterval {
+		t.Unlock()
+		return
+	}
+	t.lastRefresh = time.Now()
+	t.Unlock()
+
+	s.Lock()
+	defer s.Unlock()
+
+	for _, svc := range s.svcByHash {
+		if !svc.useLatencyFeedback() {
+			continue
+		}
+
+		backends := make(map[string]uint16, len(svc.backends))
+		for _, b := range svc.backends {
+			backends[b.String()] = uint16(b.ID)
+		}
+		if len(backends) == 0 {
+			continue
+		}
+
+		weights := t.backendWeights(backends)
+		if err := s.lbmap.UpsertMaglevLookupTable(uint16(svc.frontend.ID),
+			backends, weights, svc.frontend.IsIPv6()); err != nil {
+			log.WithError(err).WithField(logfields.ServiceID, svc.frontend.ID).
+				Warn("Unable to update Maglev lookup table with backend weights")
+		}
+	}
+}
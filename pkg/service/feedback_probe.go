@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package service
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	lb "github.com/cilium/cilium/pkg/loadbalancer"
+)
+
+const (
+	// feedbackProbeInterval is the interval between probe rounds over the
+	// backends of the services using the latency algorithm.
+	feedbackProbeInterval = 30 * time.Second
+
+	// feedbackProbeTimeout bounds a single backend probe. A probe that
+	// does not complete within the timeout counts as a connection
+	// failure.
+	feedbackProbeTimeout = 1 * time.Second
+)
+
+// startBackendProber starts the backend latency prober the first time a
+// service using the latency load balancing algorithm is installed. The
+// prober measures the TCP connect time to the backends of such services and
+// feeds the observations into the backend feedback tracker, from which the
+// Maglev lookup tables of those services are weighted.
+//
+// UDP backends cannot be probed with a connect and keep the maximum weight
+// until socket-level feedback exists for them.
+func (s *Service) startBackendProber() {
+	s.proberOnce.Do(func() {
+		log.WithField("interval", feedbackProbeInterval).
+			Info("Starting backend latency prober")
+		go func() {
+			for {
+				time.Sleep(feedbackProbeInterval)
+				s.probeBackends(s.latencyFeedbackBackends())
+			}
+		}()
+	})
+}
+
+// latencyFeedbackBackends returns the distinct TCP backends of the services
+// using the latency load balancing algorithm.
+func (s *Service) latencyFeedbackBackends() []lb.L3n4Addr {
+	s.RLock()
+	defer s.RUnlock()
+
+	unique := make(map[string]lb.L3n4Addr)
+	for _, svc := range s.svcByHash {
+		if !svc.useLatencyFeedback() {
+			continue
+		}
+		for _, b := range svc.backends {
+			if b.Protocol == lb.UDP {
+				continue
+			}
+			unique[b.String()] = b.L3n4Addr
+		}
+	}
+
+	backends := make([]lb.L3n4Addr, 0, len(unique))
+	for _, addr := range unique {
+		backends = append(backends, addr)
+	}
+	return backends
+}
+
+// probeBackends probes the given backends in parallel and records the
+// observed connect times and failures.
+func (s *Service) probeBackends(backends []lb.L3n4Addr) {
+	var wg sync.WaitGroup
+	for _, backend := range backends {
+		wg.Add(1)
+		go func(backend lb.L3n4Addr) {
+			defer wg.Done()
+
+			addr := net.JoinHostPort(backend.IP.String(), strconv.Itoa(int(backend.Port)))
+			start := time.Now()
+			conn, err := net.DialTimeout("tcp", addr, feedbackProbeTimeout)
+			if err != nil {
+				s.ObserveBackendFailure(backend)
+				return
+			}
+			conn.Close()
+			s.ObserveBackendRTT(backend, time.Since(start))
+		}(backend)
+	}
+	wg.Wait()
+}
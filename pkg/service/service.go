@@ -6,6 +6,7 @@ package service
 import (
 	"fmt"
 	"net"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -35,7 +36,7 @@ var (
 // LBMap is the interface describing methods for manipulating service maps.
 type LBMap interface {
 	UpsertService(*lbmap.UpsertServiceParams) error
-	UpsertMaglevLookupTable(uint16, map[string]uint16, bool) error
+	UpsertMaglevLookupTable(uint16, map[string]uint16, map[string]uint16, bool) error
 	IsMaglevLookupTableRecreated(bool) bool
 	DeleteService(lb.L3n4AddrID, int, bool) error
 	AddBackend(uint16, net.IP, uint16, bool) error
@@ -68,6 +69,7 @@ type svcInfo struct {
 
 	svcType                   lb.SVCType
 	svcTrafficPolicy          lb.SVCTrafficPolicy
+	svcLoadBalancerAlgorithm  lb.SVCLoadBalancerAlgorithm
 	sessionAffinity           bool
 	sessionAffinityTimeoutSec uint32
 	svcHealthCheckNodePort    uint16
@@ -84,13 +86,14 @@ func (svc *svcInfo) deepCopyToLBSVC() *lb.SVC {
 		backends[i] = *backend.DeepCopy()
 	}
 	return &lb.SVC{
-		Frontend:            *svc.frontend.DeepCopy(),
-		Backends:            backends,
-		Type:                svc.svcType,
-		TrafficPolicy:       svc.svcTrafficPolicy,
-		HealthCheckNodePort: svc.svcHealthCheckNodePort,
-		Name:                svc.svcName,
-		Namespace:           svc.svcNamespace,
+		Frontend:              *svc.frontend.DeepCopy(),
+		Backends:              backends,
+		Type:                  svc.svcType,
+		TrafficPolicy:         svc.svcTrafficPolicy,
+		LoadBalancerAlgorithm: svc.svcLoadBalancerAlgorithm,
+		HealthCheckNodePort:   svc.svcHealthCheckNodePort,
+		Name:                  svc.svcName,
+		Namespace:             svc.svcNamespace,
 	}
 }
 
@@ -111,13 +114,37 @@ func (svc *svcInfo) requireNodeLocalBackends(frontend lb.L3n4AddrID) (bool, bool
 	}
 }
 
+// loadBalancerAlgorithm returns the backend selection algorithm effective for
+// the service, resolving a per-service override against the globally
+// configured algorithm.
+func (svc *svcInfo) loadBalancerAlgorithm() string {
+	if svc.svcLoadBalancerAlgorithm != lb.SVCLoadBalancerAlgorithmDefault {
+		return string(svc.svcLoadBalancerAlgorithm)
+	}
+	return option.Config.NodePortAlg
+}
+
 func (svc *svcInfo) useMaglev() bool {
-	return option.Config.NodePortAlg == option.NodePortAlgMaglev &&
+	// The Maglev lookup tables are only initialized when the globally
+	// configured algorithm is Maglev-based, so per-service overrides can
+	// only take effect in that case.
+	if option.Config.NodePortAlg != option.NodePortAlgMaglev &&
+		option.Config.NodePortAlg != option.NodePortAlgLatency {
+		return false
+	}
+	alg := svc.loadBalancerAlgorithm()
+	return (alg == option.NodePortAlgMaglev || alg == option.NodePortAlgLatency) &&
 		((svc.svcType == lb.SVCTypeNodePort && !isWildcardAddr(svc.frontend)) ||
 			svc.svcType == lb.SVCTypeExternalIPs ||
 			svc.svcType == lb.SVCTypeLoadBalancer)
 }
 
+// useLatencyFeedback returns true if the Maglev lookup table of the service
+// should be weighted by the observed backend latency.
+func (svc *svcInfo) useLatencyFeedback() bool {
+	return svc.useMaglev() && svc.loadBalancerAlgorithm() == option.NodePortAlgLatency
+}
+
 // Service is a service handler. Its main responsibility is to reflect
 // service-related changes into BPF maps used by datapath BPF programs.
 // The changes can be triggered either by k8s_watcher or directly by
@@ -136,6 +163,9 @@ type Service struct {
 
 	lbmap         LBMap
 	lastUpdatedTs atomic.Value
+
+	// proberOnce guards the lazy start of the backend latency prober.
+	proberOnce sync.Once
 }
 
 // NewService creates a new instance of the service handler.
@@ -554,6 +584,7 @@ func (s *Service) createSVCInfoIfNotExist(p *lb.SVC) (*svcInfo, bool, bool,
 			sessionAffinityTimeoutSec: p.SessionAffinityTimeoutSec,
 
 			svcTrafficPolicy:         p.TrafficPolicy,
+			svcLoadBalancerAlgorithm: p.LoadBalancerAlgorithm,
 			svcHealthCheckNodePort:   p.HealthCheckNodePort,
 			loadBalancerSourceRanges: p.LoadBalancerSourceRanges,
 		}
@@ -581,6 +612,7 @@ func (s *Service) createSVCInfoIfNotExist(p *lb.SVC) (*svcInfo, bool, bool,
 		prevLoadBalancerSourceRanges = svc.loadBalancerSourceRanges
 		svc.svcType = p.Type
 		svc.svcTrafficPolicy = p.TrafficPolicy
+		svc.svcLoadBalancerAlgorithm = p.LoadBalancerAlgorithm
 		svc.svcHealthCheckNodePort = p.HealthCheckNodePort
 		svc.sessionAffinity = p.SessionAffinity
 		svc.sessionAffinityTimeoutSec = p.SessionAffinityTimeoutSec
@@ -702,17 +734,39 @@ func (s *Service) upsertServiceIntoLBMaps(svc *svcInfo, onlyLocalBackends bool,
 		}
 	}
 
-	// Upsert service entries into BPF maps
+	// Upsert service entries into BPF maps. Terminating backends are
+	// excluded from the backend selection entries so that no new
+	// connections are routed to them, but their backend map entries
+	// created above remain in place, so established connections keep
+	// resolving the backend and drain until they close or the pod's
+	// grace period expires.
+	terminating := 0
 	backends := make(map[string]uint16, len(svc.backends))
 	for _, b := range svc.backends {
+		if b.Terminating {
+			terminating++
+			continue
+		}
 		backends[b.String()] = uint16(b.ID)
 	}
+	if terminating > 0 {
+		metrics.TerminatingBackendsPreserved.Add(float64(terminating))
+		scopedLog.WithField(logfields.Backends, terminating).
+			Debug("Preserving terminating backends for established connections")
+	}
+
+	var backendWeights map[string]uint16
+	if svc.useLatencyFeedback() {
+		backendWeights = defaultBackendFeedback.backendWeights(backends)
+		s.startBackendProber()
+	}
 
 	p := &lbmap.UpsertServiceParams{
 		ID:                        uint16(svc.frontend.ID),
 		IP:                        svc.frontend.L3n4Addr.IP,
 		Port:                      svc.frontend.L3n4Addr.L4Addr.Port,
 		Backends:                  backends,
+		BackendWeights:            backendWeights,
 		PrevBackendCount:          prevBackendCount,
 		IPv6:                      ipv6,
 		Type:                      svc.svcType,
@@ -839,7 +893,7 @@ func (s *Service) restoreServicesLocked() error {
 			for _, b := range newSVC.backends {
 				backends[b.String()] = uint16(b.ID)
 			}
-			if err := s.lbmap.UpsertMaglevLookupTable(uint16(newSVC.frontend.ID), backends, ipv6); err != nil {
+			if err := s.lbmap.UpsertMaglevLookupTable(uint16(newSVC.frontend.ID), backends, nil, ipv6); err != nil {
 				return err
 			}
 		}
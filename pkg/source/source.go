@@ -15,6 +15,11 @@ const (
 	// by other local state.
 	Local Source = "local"
 
+	// KubeAPIServer is the source used for state derived from the
+	// kube-apiserver service endpoints of the local cluster. It has the
+	// strongest ownership after Local.
+	KubeAPIServer Source = "kube-apiserver"
+
 	// KVStore is the source used for state derived from a key value store.
 	// State in the key value stored takes precedence over orchestration
 	// system state such as Kubernetes.
@@ -41,9 +46,15 @@ func AllowOverwrite(existing, new Source) bool {
 	case Local:
 		return new == Local
 
-	// KVStore can be overwritten by other kvstore or local state
+	// KubeAPIServer state can only be overwritten by other kube-apiserver
+	// or local state
+	case KubeAPIServer:
+		return new == KubeAPIServer || new == Local
+
+	// KVStore can be overwritten by other kvstore, kube-apiserver or local
+	// state
 	case KVStore:
-		return new == KVStore || new == Local
+		return new == KVStore || new == KubeAPIServer || new == Local
 
 	// Custom-resource state can be overwritten by everything except
 	// generated, unspecified and Kubernetes (non-CRD) state
@@ -40,6 +40,20 @@ func (s *SpanStat) Start() *SpanStat {
 	return s
 }
 
+// Add adds an externally measured duration to the cumulated success or
+// failure duration, without requiring a Start/End pair. It is used when a
+// span was measured elsewhere, e.g. in another process.
+func (s *SpanStat) Add(success bool, d time.Duration) *SpanStat {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if success {
+		s.successDuration += d
+	} else {
+		s.failureDuration += d
+	}
+	return s
+}
+
 // EndError calls End() based on the value of err
 func (s *SpanStat) EndError(err error) *SpanStat {
 	s.mutex.Lock()
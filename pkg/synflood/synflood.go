@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+// Package synflood detects SYN floods toward NodePort and LoadBalancer
+// service frontends and dynamically rate limits new connections toward the
+// affected frontends in the datapath. Floods are detected by periodically
+// scanning the connection tracking table for half-open connections, which
+// are attributed to frontends via their reverse NAT index.
+package synflood
+
+import (
+	"context"
+	"time"
+
+	"github.com/cilium/cilium/pkg/bpf"
+	"github.com/cilium/cilium/pkg/byteorder"
+	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/eventjournal"
+	"github.com/cilium/cilium/pkg/logging"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/maps/ctmap"
+	synfloodmap "github.com/cilium/cilium/pkg/maps/synflood"
+	"github.com/cilium/cilium/pkg/metrics"
+	"github.com/cilium/cilium/pkg/option"
+)
+
+var log = logging.DefaultLogger.WithField(logfields.LogSubsys, "synflood")
+
+const (
+	// scanInterval is the interval in which the connection tracking table
+	// is scanned for half-open connections.
+	scanInterval = 10 * time.Second
+
+	// minProbability is the minimum share of new connections dropped once
+	// a mitigation is installed, so progress is made against a flood
+	// hovering at the threshold.
+	minProbability = 5
+
+	// maxProbability caps the share of new connections dropped toward a
+	// frontend under mitigation, keeping a residual chance for legitimate
+	// clients to connect during the flood.
+	maxProbability = 95
+)
+
+// FrontendResolver describes the service frontend with the given reverse NAT
+// index, for events and log messages.
+type FrontendResolver func(revNatIndex uint16) string
+
+// Detector periodically scans the connection tracking table for SYN floods
+// and adjusts the datapath mitigations accordingly.
+type Detector struct {
+	threshold int
+	resolver  FrontendResolver
+
+	// mitigated holds the drop probability currently installed per
+	// frontend.
+	mitigated map[uint16]uint8
+
+	// apply and remove install and remove the datapath mitigation for a
+	// frontend. They default to updating the SYN flood BPF map.
+	apply  func(revNatIndex uint16, probability uint8) error
+	remove func(revNatIndex uint16) error
+}
+
+// NewDetector returns a new detector installing mitigations once the number
+// of half-open connections toward a frontend exceeds the given threshold.
+func NewDetector(threshold int, resolver FrontendResolver) *Detector {
+	return &Detector{
+		threshold: threshold,
+		resolver:  resolver,
+		mitigated: map[uint16]uint8{},
+		apply:     synfloodmap.Update,
+		remove:    synfloodmap.Delete,
+	}
+}
+
+// Run periodically scans the connection tracking table and adjusts the
+// mitigations until the given context is cancelled.
+func (d *Detector) Run(ctx context.Context) {
+	controller.NewManager().UpdateController("synflood-detection",
+		controller.ControllerParams{
+			DoFunc: func(ctx context.Context) error {
+				counts, err := halfOpenCounts()
+				if err != nil {
+					return err
+				}
+				d.update(counts)
+				return nil
+			},
+			RunInterval: scanInterval,
+			Context:     ctx,
+		})
+}
+
+// halfOpenCounts returns the number of half-open connections toward service
+// frontends per reverse NAT index, taken from the global TCP connection
+// tracking maps.
+func halfOpenCounts() (map[uint16]int, error) {
+	counts := map[uint16]int{}
+	for _, m := range ctmap.GlobalMaps(option.Config.EnableIPv4, option.Config.EnableIPv6) {
+		if m.Name() != ctmap.MapNameTCP4Global && m.Name() != ctmap.MapNameTCP6Global {
+			continue
+		}
+		if err := m.Open(); err != nil {
+			return nil, err
+		}
+		err := m.DumpWithCallback(func(_ bpf.MapKey, v bpf.MapValue) {
+			entry := v.(*ctmap.CtEntry)
+			if entry.Flags&ctmap.NodePort == 0 ||
+				entry.Flags&ctmap.SeenNonSyn != 0 ||
+				entry.RevNAT == 0 {
+				return
+			}
+			counts[byteorder.NetworkToHost16(entry.RevNAT)]++
+		})
+		m.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
+
+// update adjusts the installed mitigations to the observed number of
+// half-open connections per frontend.
+func (d *Detector) update(counts map[uint16]int) {
+	for id, count := range counts {
+		if count < d.threshold {
+			continue
+		}
+		probability := d.probability(count)
+		if current, ok := d.mitigated[id]; ok && current == probability {
+			continue
+		}
+		if err := d.apply(id, probability); err != nil {
+			log.WithError(err).WithField(logfields.ServiceID, id).
+				Warn("Unable to install SYN flood mitigation")
+			continue
+		}
+		if _, ok := d.mitigated[id]; !ok {
+			metrics.SynFloodDetected.Inc()
+			frontend := d.resolver(id)
+			log.WithField(logfields.ServiceID, id).
+				Warnf("SYN flood detected toward service frontend %s, dropping %d%% of new connections",
+					frontend, probability)
+			eventjournal.Record(eventjournal.ReasonSynFloodMitigation,
+				"SYN flood toward service frontend %s: %d half-open connections, dropping %d%% of new connections",
+				frontend, count, probability)
+		}
+		d.mitigated[id] = probability
+	}
+
+	// Remove mitigations once the flood subsides. The lower bound adds
+	// hysteresis so a flood hovering around the threshold does not
+	// toggle the mitigation.
+	for id := range d.mitigated {
+		if counts[id] >= d.threshold/2 {
+			continue
+		}
+		if err := d.remove(id); err != nil {
+			log.WithError(err).WithField(logfields.ServiceID, id).
+				Warn("Unable to remove SYN flood mitigation")
+			continue
+		}
+		delete(d.mitigated, id)
+		eventjournal.Record(eventjournal.ReasonSynFloodMitigation,
+			"SYN flood toward service frontend %s subsided, mitigation removed",
+			d.resolver(id))
+	}
+
+	metrics.SynFloodMitigatedFrontends.Set(float64(len(d.mitigated)))
+}
+
+// probability returns the share of new connections to drop so the accepted
+// rate of new connections approaches the threshold.
+func (d *Detector) probability(count int) uint8 {
+	p := (count - d.threshold) * 100 / count
+	if p < minProbability {
+		p = minProbability
+	}
+	if p > maxProbability {
+		p = maxProbability
+	}
+	return uint8(p)
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package synflood
+
+import (
+	"fmt"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	check.TestingT(t)
+}
+
+type SynFloodSuite struct{}
+
+var _ = check.Suite(&SynFloodSuite{})
+
+func testDetector(threshold int) (*Detector, map[uint16]uint8) {
+	installed := map[uint16]uint8{}
+	d := NewDetector(threshold, func(revNatIndex uint16) string {
+		return fmt.Sprintf("frontend %d", revNatIndex)
+	})
+	d.apply = func(revNatIndex uint16, probability uint8) error {
+		installed[revNatIndex] = probability
+		return nil
+	}
+	d.remove = func(revNatIndex uint16) error {
+		delete(installed, revNatIndex)
+		return nil
+	}
+	return d, installed
+}
+
+func (s *SynFloodSuite) TestUpdate(c *check.C) {
+	d, installed := testDetector(1000)
+
+	// below the threshold no mitigation is installed
+	d.update(map[uint16]int{1: 999, 2: 10})
+	c.Assert(installed, check.HasLen, 0)
+
+	// the flooded frontend is mitigated, the healthy one is not
+	d.update(map[uint16]int{1: 4000, 2: 10})
+	c.Assert(installed, check.HasLen, 1)
+	c.Assert(installed[1], check.Equals, uint8(75))
+
+	// the mitigation adapts to the observed flood
+	d.update(map[uint16]int{1: 2000, 2: 10})
+	c.Assert(installed[1], check.Equals, uint8(50))
+
+	// hysteresis: counts below the threshold but above half of it keep
+	// the mitigation in place
+	d.update(map[uint16]int{1: 600, 2: 10})
+	c.Assert(installed, check.HasLen, 1)
+
+	// once the flood subsides the mitigation is removed
+	d.update(map[uint16]int{1: 10, 2: 10})
+	c.Assert(installed, check.HasLen, 0)
+}
+
+func (s *SynFloodSuite) TestProbability(c *check.C) {
+	d, _ := testDetector(1000)
+
+	// just above the threshold the minimum probability applies
+	c.Assert(d.probability(1001), check.Equals, uint8(minProbability))
+
+	// an overwhelming flood is capped below 100%
+	c.Assert(d.probability(1000000), check.Equals, uint8(maxProbability))
+
+	c.Assert(d.probability(2000), check.Equals, uint8(50))
+}
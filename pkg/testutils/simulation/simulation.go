@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+// Package simulation provides a small deterministic simulation harness for
+// stress testing stateful subsystems. A harness runs a seed-reproducible
+// pseudo-random sequence of weighted operations against the subsystem under
+// test and verifies the registered invariants after every step, so a failure
+// report pinpoints the seed, step and operation breaking an invariant.
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Harness drives a deterministic sequence of operations against a subsystem
+// under test.
+type Harness struct {
+	seed        int64
+	rng         *rand.Rand
+	ops         []op
+	invariants  []invariant
+	totalWeight int
+}
+
+type op struct {
+	name   string
+	weight int
+	do     func(rng *rand.Rand) error
+}
+
+type invariant struct {
+	name  string
+	check func() error
+}
+
+// New returns a harness replaying the operation sequence determined by the
+// given seed.
+func New(seed int64) *Harness {
+	return &Harness{
+		seed: seed,
+		rng:  rand.New(rand.NewSource(seed)),
+	}
+}
+
+// AddOp registers an operation picked with the given relative weight. The
+// operation is passed the harness RNG so that any choices it makes are
+// reproducible from the seed. Outcomes that are legitimate under the model,
+// such as resource exhaustion, should be tolerated by the operation;
+// returning an error fails the simulation.
+func (h *Harness) AddOp(name string, weight int, do func(rng *rand.Rand) error) {
+	h.ops = append(h.ops, op{name: name, weight: weight, do: do})
+	h.totalWeight += weight
+}
+
+// AddInvariant registers a check run after every operation.
+func (h *Harness) AddInvariant(name string, check func() error) {
+	h.invariants = append(h.invariants, invariant{name: name, check: check})
+}
+
+// Run performs the given number of simulation steps. It returns an error
+// describing the failing seed, step and operation when an operation or an
+// invariant check fails.
+func (h *Harness) Run(steps int) error {
+	if h.totalWeight == 0 {
+		return fmt.Errorf("no operations registered")
+	}
+	for step := 0; step < steps; step++ {
+		o := h.pickOp()
+		if err := o.do(h.rng); err != nil {
+			return fmt.Errorf("seed %d step %d: op %q failed: %w", h.seed, step, o.name, err)
+		}
+		for _, inv := range h.invariants {
+			if err := inv.check(); err != nil {
+				return fmt.Errorf("seed %d step %d: invariant %q violated after op %q: %w",
+					h.seed, step, inv.name, o.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// pickOp selects an operation according to the registered weights.
+func (h *Harness) pickOp() *op {
+	n := h.rng.Intn(h.totalWeight)
+	for i := range h.ops {
+		if n < h.ops[i].weight {
+			return &h.ops[i]
+		}
+		n -= h.ops[i].weight
+	}
+	// Unreachable, the weights sum up to totalWeight.
+	return &h.ops[len(h.ops)-1]
+}
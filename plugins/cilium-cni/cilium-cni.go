@@ -338,6 +338,11 @@ func cmdAdd(args *skel.CmdArgs) (err error) {
 		}
 	}
 
+	if err = n.ValidateSysctls(); err != nil {
+		err = fmt.Errorf("invalid sysctls in CNI configuration: %s", err)
+		return
+	}
+
 	netNs, err = ns.GetNS(args.Netns)
 	if err != nil {
 		err = fmt.Errorf("failed to open netns %q: %s", args.Netns, err)
@@ -505,6 +510,11 @@ func cmdAdd(args *skel.CmdArgs) (err error) {
 				logger.WithError(err).Warn("unable to enable ipv6 on all interfaces")
 			}
 		}
+		for name, value := range n.Sysctls {
+			if err := sysctl.Write(name, value); err != nil {
+				return fmt.Errorf("unable to set sysctl %s=%s: %s", name, value, err)
+			}
+		}
 		macAddrStr, err = configureIface(ipam, args.IfName, &state)
 		return err
 	}); err != nil {
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultSysctlAllowlist is the built-in set of network sysctls which may be
+// applied inside the pod network namespace via the "sysctls" field of the
+// network configuration. It is limited to parameters which are scoped to the
+// network namespace and cannot affect other pods or the host.
+var defaultSysctlAllowlist = []string{
+	"net.core.somaxconn",
+	"net.ipv4.ip_local_port_range",
+	"net.ipv4.ip_unprivileged_port_start",
+	"net.ipv4.ping_group_range",
+	"net.ipv4.tcp_fin_timeout",
+	"net.ipv4.tcp_keepalive_intvl",
+	"net.ipv4.tcp_keepalive_probes",
+	"net.ipv4.tcp_keepalive_time",
+	"net.ipv4.tcp_syncookies",
+}
+
+// sysctlAllowed returns true if the sysctl name is covered by the allowlist.
+// An allowlist entry matches the name exactly or, if it ends in a dot, acts
+// as a prefix allowing all parameters below it.
+func sysctlAllowed(name string, allowlist []string) bool {
+	for _, allowed := range allowlist {
+		if strings.HasSuffix(allowed, ".") {
+			if strings.HasPrefix(name, allowed) {
+				return true
+			}
+		} else if name == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateSysctls validates the sysctls of the network configuration against
+// the configured allowlist, or against the built-in allowlist of namespaced
+// network parameters if none is configured. Only parameters in the "net"
+// namespace may be set regardless of the allowlist in use.
+func (n *NetConf) ValidateSysctls() error {
+	allowlist := n.SysctlAllowlist
+	if len(allowlist) == 0 {
+		allowlist = defaultSysctlAllowlist
+	}
+
+	for name := range n.Sysctls {
+		if !strings.HasPrefix(name, "net.") {
+			return fmt.Errorf("sysctl %q is not a network sysctl", name)
+		}
+		if !sysctlAllowed(name, allowlist) {
+			return fmt.Errorf("sysctl %q is not allowed by the sysctl allowlist", name)
+		}
+	}
+	return nil
+}
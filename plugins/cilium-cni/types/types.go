@@ -30,6 +30,14 @@ type NetConf struct {
 	AlibabaCloud alibabaCloudTypes.Spec `json:"alibaba-cloud,omitempty"`
 	EnableDebug  bool                   `json:"enable-debug"`
 	LogFormat    string                 `json:"log-format"`
+	// Sysctls are network sysctls applied inside the pod network namespace
+	// at ADD time, removing the need for privileged init containers. Each
+	// parameter must be covered by the allowlist, see SysctlAllowlist.
+	Sysctls map[string]string `json:"sysctls,omitempty"`
+	// SysctlAllowlist overrides the built-in allowlist of sysctls which
+	// may be set via Sysctls. An entry either names a parameter exactly
+	// or, if it ends in a dot, allows all parameters below that prefix.
+	SysctlAllowlist []string `json:"sysctl-allowlist,omitempty"`
 }
 
 // NetConfList is a CNI chaining configuration
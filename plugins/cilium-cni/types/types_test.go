@@ -273,3 +273,64 @@ func (t *CNITypesSuite) TestReadCNIConfError(c *check.C) {
 	_, err = ReadNetConf(p)
 	c.Assert(err, check.Not(check.IsNil))
 }
+
+func (t *CNITypesSuite) TestReadCNIConfSysctls(c *check.C) {
+	confFile1 := `
+{
+  "name": "cilium",
+  "type": "cilium-cni",
+  "sysctls": {
+    "net.core.somaxconn": "1024"
+  },
+  "sysctl-allowlist": [ "net.core.somaxconn", "net.ipv4." ]
+}
+`
+
+	netConf1 := NetConf{
+		NetConf: cnitypes.NetConf{
+			Name: "cilium",
+			Type: "cilium-cni",
+		},
+		Sysctls: map[string]string{
+			"net.core.somaxconn": "1024",
+		},
+		SysctlAllowlist: []string{"net.core.somaxconn", "net.ipv4."},
+	}
+	testConfRead(c, confFile1, &netConf1)
+}
+
+func (t *CNITypesSuite) TestValidateSysctls(c *check.C) {
+	// Sysctls from the built-in allowlist pass.
+	netConf := NetConf{
+		Sysctls: map[string]string{
+			"net.core.somaxconn":       "1024",
+			"net.ipv4.tcp_fin_timeout": "30",
+		},
+	}
+	c.Assert(netConf.ValidateSysctls(), check.IsNil)
+
+	// Sysctls outside the built-in allowlist are rejected.
+	netConf.Sysctls = map[string]string{
+		"net.ipv4.tcp_congestion_control": "bbr",
+	}
+	c.Assert(netConf.ValidateSysctls(), check.ErrorMatches,
+		`sysctl "net.ipv4.tcp_congestion_control" is not allowed by the sysctl allowlist`)
+
+	// A configured allowlist with a prefix entry overrides the built-in
+	// allowlist.
+	netConf.SysctlAllowlist = []string{"net.ipv4."}
+	c.Assert(netConf.ValidateSysctls(), check.IsNil)
+	netConf.Sysctls = map[string]string{
+		"net.core.somaxconn": "1024",
+	}
+	c.Assert(netConf.ValidateSysctls(), check.ErrorMatches,
+		`sysctl "net.core.somaxconn" is not allowed by the sysctl allowlist`)
+
+	// Non-network sysctls are rejected regardless of the allowlist.
+	netConf.SysctlAllowlist = []string{"kernel.shm_rmid_forced"}
+	netConf.Sysctls = map[string]string{
+		"kernel.shm_rmid_forced": "1",
+	}
+	c.Assert(netConf.ValidateSysctls(), check.ErrorMatches,
+		`sysctl "kernel.shm_rmid_forced" is not a network sysctl`)
+}
@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package mysql
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cilium/cilium/proxylib/proxylib"
+
+	"github.com/cilium/proxy/go/cilium/api"
+	log "github.com/sirupsen/logrus"
+)
+
+//
+// MySQL client/server protocol parser
+//
+// Spec: https://dev.mysql.com/doc/internals/en/client-server-protocol.html
+//
+// The parser enforces policy on COM_QUERY commands based on the type of the
+// statement and the table it accesses. The connection phase handshake and
+// all other commands are passed through. Replies are never parsed.
+//
+// Policy Examples:
+// {query_action : "select"} - Allow only select statements
+// {query_action : "select", query_table : "inventory\..*"} - Allow selects on the inventory schema
+// {query_action : "insert", query_table : "metrics"} - Allow inserts into the metrics table
+//
+// DDL statements (create, alter, drop, truncate) are denied unless a rule
+// explicitly allows their query_action.
+
+type mysqlRule struct {
+	queryActionExact   string
+	tableRegexCompiled *regexp.Regexp
+}
+
+type mysqlRequestData struct {
+	action string
+	table  string
+}
+
+var queryActions = map[string]bool{
+	"select":   true,
+	"insert":   true,
+	"update":   true,
+	"delete":   true,
+	"replace":  true,
+	"create":   true,
+	"alter":    true,
+	"drop":     true,
+	"truncate": true,
+	"begin":    true,
+	"commit":   true,
+	"rollback": true,
+	"grant":    true,
+	"revoke":   true,
+}
+
+func (rule *mysqlRule) Matches(data interface{}) bool {
+	reqData, ok := data.(mysqlRequestData)
+	if !ok {
+		log.Warning("Matches() called with type other than mysqlRequestData")
+		return false
+	}
+	if len(rule.queryActionExact) > 0 && rule.queryActionExact != reqData.action {
+		log.Debugf("MysqlRule: query_action mismatch %s, %s", rule.queryActionExact, reqData.action)
+		return false
+	}
+	if rule.tableRegexCompiled != nil &&
+		!rule.tableRegexCompiled.MatchString(reqData.table) {
+		log.Debugf("MysqlRule: query_table mismatch %s, %s", rule.tableRegexCompiled.String(), reqData.table)
+		return false
+	}
+	return true
+}
+
+// ruleParser parses protobuf L7 rules to enforcement objects
+// May panic
+func ruleParser(rule *cilium.PortNetworkPolicyRule) []proxylib.L7NetworkPolicyRule {
+	l7Rules := rule.GetL7Rules()
+	if l7Rules == nil {
+		return nil
+	}
+
+	allowRules := l7Rules.GetL7AllowRules()
+	rules := make([]proxylib.L7NetworkPolicyRule, 0, len(allowRules))
+	for _, l7Rule := range allowRules {
+		var mr mysqlRule
+		for k, v := range l7Rule.Rule {
+			switch k {
+			case "query_action":
+				mr.queryActionExact = strings.ToLower(v)
+			case "query_table":
+				if v != "" {
+					mr.tableRegexCompiled = regexp.MustCompile(v)
+				}
+			default:
+				proxylib.ParseError(fmt.Sprintf("Unsupported key: %s", k), rule)
+			}
+		}
+		if mr.queryActionExact != "" && !queryActions[mr.queryActionExact] {
+			proxylib.ParseError(fmt.Sprintf("Unable to parse L7 mysql rule with invalid query_action: '%s'", mr.queryActionExact), rule)
+		}
+		log.Debugf("Parsed MysqlRule pair: %v", mr)
+		rules = append(rules, &mr)
+	}
+	return rules
+}
+
+type factory struct{}
+
+func init() {
+	log.Debug("init(): Registering mysqlParserFactory")
+	proxylib.RegisterParserFactory("mysql", &factory{})
+	proxylib.RegisterL7RuleParser("mysql", ruleParser)
+}
+
+type parser struct {
+	connection *proxylib.Connection
+
+	// handshakeDone is true after the handshake response from the client
+	// has been passed. The server greeting is sent in the reply direction
+	// and is passed without parsing.
+	handshakeDone bool
+}
+
+func (f *factory) Create(connection *proxylib.Connection) interface{} {
+	log.Debugf("MysqlParserFactory: Create: %v", connection)
+
+	return &parser{connection: connection}
+}
+
+// comQuery is the command byte of a COM_QUERY packet
+const comQuery = 0x03
+
+var tableRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^select\s+.*\s+from\s+([^\s;,()]+)`),
+	regexp.MustCompile(`(?i)^(?:insert|replace)\s+into\s+([^\s;,()]+)`),
+	regexp.MustCompile(`(?i)^update\s+([^\s;,()]+)`),
+	regexp.MustCompile(`(?i)^delete\s+from\s+([^\s;,()]+)`),
+	regexp.MustCompile(`(?i)^(?:create|alter|drop|truncate)\s+table\s+(?:if\s+(?:not\s+)?exists\s+)?([^\s;,()]+)`),
+}
+
+// parseQuery returns the statement type and the table accessed by the given
+// query, both in lower case. The table is empty if it cannot be determined.
+func parseQuery(query string) (string, string) {
+	query = strings.TrimSpace(query)
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	action := strings.ToLower(fields[0])
+
+	for _, re := range tableRegexes {
+		if match := re.FindStringSubmatch(query); match != nil {
+			return action, strings.ToLower(match[1])
+		}
+	}
+	return action, ""
+}
+
+// createErrPacket returns an ERR packet with the given sequence id, to be
+// injected towards the client when a query is denied by policy.
+func createErrPacket(seq byte, message string) []byte {
+	payload := []byte{0xff}
+	// ER_TABLEACCESS_DENIED_ERROR
+	payload = append(payload, 0x76, 0x04)
+	payload = append(payload, '#')
+	payload = append(payload, []byte("42000")...)
+	payload = append(payload, []byte(message)...)
+
+	pkt := make([]byte, 0, len(payload)+4)
+	pkt = append(pkt,
+		byte(len(payload)), byte(len(payload)>>8), byte(len(payload)>>16),
+		seq)
+	return append(pkt, payload...)
+}
+
+func (p *parser) OnData(reply, endStream bool, dataArray [][]byte) (proxylib.OpType, int) {
+
+	// inefficient, but simple
+	data := bytes.Join(dataArray, []byte{})
+
+	if len(data) < 4 {
+		return proxylib.MORE, 4 - len(data)
+	}
+	payloadLen := int(data[0]) | int(data[1])<<8 | int(data[2])<<16
+	pktLen := 4 + payloadLen
+
+	if reply {
+		// Pass replies one packet at a time without parsing them
+		return proxylib.PASS, pktLen
+	}
+
+	if !p.handshakeDone {
+		// The first packet from the client is the handshake response
+		log.Debugf("Passing mysql handshake response of %d bytes", pktLen)
+		p.handshakeDone = true
+		return proxylib.PASS, pktLen
+	}
+
+	if payloadLen == 0 {
+		return proxylib.ERROR, int(proxylib.ERROR_INVALID_FRAME_LENGTH)
+	}
+
+	if len(data) < 5 {
+		return proxylib.MORE, 5 - len(data)
+	}
+
+	if data[4] != comQuery {
+		// Policy is only enforced on COM_QUERY, everything else is
+		// passed through.
+		return proxylib.PASS, pktLen
+	}
+
+	if len(data) < pktLen {
+		return proxylib.MORE, pktLen - len(data)
+	}
+
+	query := string(data[5:pktLen])
+	action, table := parseQuery(query)
+	reqData := mysqlRequestData{action: action, table: table}
+
+	matches := true
+	accessLogEntryType := cilium.EntryType_Request
+
+	if !p.connection.Matches(reqData) {
+		matches = false
+		accessLogEntryType = cilium.EntryType_Denied
+	}
+
+	p.connection.Log(accessLogEntryType,
+		&cilium.LogEntry_GenericL7{
+			GenericL7: &cilium.L7LogEntry{
+				Proto: "mysql",
+				Fields: map[string]string{
+					"query_action": reqData.action,
+					"query_table":  reqData.table,
+				},
+			},
+		})
+
+	if !matches {
+		p.connection.Inject(true, createErrPacket(data[3]+1, "access denied by policy"))
+		log.Debugf("Policy mismatch, dropping %d bytes", pktLen)
+		return proxylib.DROP, pktLen
+	}
+
+	return proxylib.PASS, pktLen
+}
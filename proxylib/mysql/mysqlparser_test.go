@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package mysql
+
+import (
+	"testing"
+
+	"github.com/cilium/cilium/proxylib/accesslog"
+	"github.com/cilium/cilium/proxylib/proxylib"
+	"github.com/cilium/cilium/proxylib/test"
+
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type MysqlSuite struct {
+	logServer *test.AccessLogServer
+	ins       *proxylib.Instance
+}
+
+var _ = Suite(&MysqlSuite{})
+
+// Set up access log server and Library instance for all the test cases
+func (s *MysqlSuite) SetUpSuite(c *C) {
+	s.logServer = test.StartAccessLogServer("access_log.sock", 10)
+	c.Assert(s.logServer, Not(IsNil))
+	s.ins = proxylib.NewInstance("node1", accesslog.NewClient(s.logServer.Path))
+	c.Assert(s.ins, Not(IsNil))
+}
+
+func (s *MysqlSuite) TearDownTest(c *C) {
+	s.logServer.Clear()
+}
+
+func (s *MysqlSuite) TearDownSuite(c *C) {
+	s.logServer.Close()
+}
+
+// packet builds a packet with the given sequence id and payload
+func packet(seq byte, payload []byte) []byte {
+	pkt := []byte{byte(len(payload)), byte(len(payload) >> 8), byte(len(payload) >> 16), seq}
+	return append(pkt, payload...)
+}
+
+// handshakeResponse builds a minimal handshake response packet
+func handshakeResponse() []byte {
+	return packet(1, []byte("\x8d\xa6\x03\x00alice\x00"))
+}
+
+// queryPacket builds a COM_QUERY packet
+func queryPacket(query string) []byte {
+	return packet(0, append([]byte{comQuery}, []byte(query)...))
+}
+
+func (s *MysqlSuite) TestMysqlOnDataIncomplete(c *C) {
+	conn := s.ins.CheckNewConnectionOK(c, "mysql", true, 1, 2, "1.1.1.1:34567", "2.2.2.2:3306", "no-policy")
+	data := [][]byte{{0x0, 0x0}}
+	conn.CheckOnDataOK(c, false, false, &data, []byte{}, proxylib.MORE, 2)
+}
+
+func (s *MysqlSuite) TestMysqlOnDataBasicPass(c *C) {
+
+	// allow all rule
+	s.ins.CheckInsertPolicyText(c, "1", []string{`
+		name: "cp1"
+		policy: 2
+		ingress_per_port_policies: <
+		  port: 3306
+		  rules: <
+		    l7_proto: "mysql"
+		  >
+		>
+		`})
+	conn := s.ins.CheckNewConnectionOK(c, "mysql", true, 1, 2, "1.1.1.1:34567", "2.2.2.2:3306", "cp1")
+	handshake := handshakeResponse()
+	msg1 := queryPacket("SELECT id FROM users")
+	msg2 := queryPacket("INSERT INTO metrics VALUES (1)")
+	data := [][]byte{handshake, msg1, msg2}
+	conn.CheckOnDataOK(c, false, false, &data, []byte{},
+		proxylib.PASS, len(handshake),
+		proxylib.PASS, len(msg1),
+		proxylib.PASS, len(msg2),
+		proxylib.MORE, 4)
+}
+
+func (s *MysqlSuite) TestMysqlOnDataAllowDenyAction(c *C) {
+
+	s.ins.CheckInsertPolicyText(c, "1", []string{`
+		name: "cp2"
+		policy: 2
+		ingress_per_port_policies: <
+		  port: 3306
+		  rules: <
+		    l7_proto: "mysql"
+		    l7_rules: <
+		      l7_allow_rules: <
+			rule: <
+			  key: "query_action"
+			  value: "select"
+			>
+		      >
+		    >
+		  >
+		>
+		`})
+	conn := s.ins.CheckNewConnectionOK(c, "mysql", true, 1, 2, "1.1.1.1:34567", "2.2.2.2:3306", "cp2")
+	handshake := handshakeResponse()
+	msg1 := queryPacket("SELECT id FROM users")
+	msg2 := queryPacket("DROP TABLE users")
+	data := [][]byte{handshake, msg1, msg2}
+	conn.CheckOnDataOK(c, false, false, &data, createErrPacket(1, "access denied by policy"),
+		proxylib.PASS, len(handshake),
+		proxylib.PASS, len(msg1),
+		proxylib.DROP, len(msg2),
+		proxylib.MORE, 4)
+}
+
+func (s *MysqlSuite) TestMysqlOnDataAllowDenyTable(c *C) {
+
+	s.ins.CheckInsertPolicyText(c, "1", []string{`
+		name: "cp3"
+		policy: 2
+		ingress_per_port_policies: <
+		  port: 3306
+		  rules: <
+		    l7_proto: "mysql"
+		    l7_rules: <
+		      l7_allow_rules: <
+			rule: <
+			  key: "query_table"
+			  value: "users"
+			>
+		      >
+		    >
+		  >
+		>
+		`})
+	conn := s.ins.CheckNewConnectionOK(c, "mysql", true, 1, 2, "1.1.1.1:34567", "2.2.2.2:3306", "cp3")
+	handshake := handshakeResponse()
+	msg1 := queryPacket("DELETE FROM users WHERE id = 1")
+	msg2 := queryPacket("SELECT secret FROM keys")
+	data := [][]byte{handshake, msg1, msg2}
+	conn.CheckOnDataOK(c, false, false, &data, createErrPacket(1, "access denied by policy"),
+		proxylib.PASS, len(handshake),
+		proxylib.PASS, len(msg1),
+		proxylib.DROP, len(msg2),
+		proxylib.MORE, 4)
+}
+
+func (s *MysqlSuite) TestMysqlParseQuery(c *C) {
+	action, table := parseQuery("SELECT id FROM users WHERE id = 1")
+	c.Check(action, Equals, "select")
+	c.Check(table, Equals, "users")
+
+	action, table = parseQuery("REPLACE INTO metrics VALUES (1)")
+	c.Check(action, Equals, "replace")
+	c.Check(table, Equals, "metrics")
+
+	action, table = parseQuery("COMMIT")
+	c.Check(action, Equals, "commit")
+	c.Check(table, Equals, "")
+}
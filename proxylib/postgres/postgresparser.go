@@ -0,0 +1,276 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+package postgres
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cilium/cilium/proxylib/proxylib"
+
+	"github.com/cilium/proxy/go/cilium/api"
+	log "github.com/sirupsen/logrus"
+)
+
+//
+// PostgreSQL v3 frontend/backend protocol parser
+//
+// Spec: https://www.postgresql.org/docs/current/protocol-message-formats.html
+//
+// The parser enforces policy on simple queries ('Q' messages) based on the
+// type of the statement and the table it accesses. All other protocol
+// messages, including the startup handshake, are passed through. Replies are
+// never parsed.
+//
+// Policy Examples:
+// {query_action : "select"} - Allow only select statements
+// {query_action : "select", query_table : "public\..*"} - Allow selects on the public schema
+// {query_action : "insert", query_table : "metrics"} - Allow inserts into the metrics table
+//
+// DDL statements (create, alter, drop, truncate) are denied unless a rule
+// explicitly allows their query_action.
+
+type postgresRule struct {
+	queryActionExact   string
+	tableRegexCompiled *regexp.Regexp
+}
+
+type postgresRequestData struct {
+	action string
+	table  string
+}
+
+var queryActions = map[string]bool{
+	"select":   true,
+	"insert":   true,
+	"update":   true,
+	"delete":   true,
+	"create":   true,
+	"alter":    true,
+	"drop":     true,
+	"truncate": true,
+	"begin":    true,
+	"commit":   true,
+	"rollback": true,
+	"grant":    true,
+	"revoke":   true,
+}
+
+func (rule *postgresRule) Matches(data interface{}) bool {
+	reqData, ok := data.(postgresRequestData)
+	if !ok {
+		log.Warning("Matches() called with type other than postgresRequestData")
+		return false
+	}
+	if len(rule.queryActionExact) > 0 && rule.queryActionExact != reqData.action {
+		log.Debugf("PostgresRule: query_action mismatch %s, %s", rule.queryActionExact, reqData.action)
+		return false
+	}
+	if rule.tableRegexCompiled != nil &&
+		!rule.tableRegexCompiled.MatchString(reqData.table) {
+		log.Debugf("PostgresRule: query_table mismatch %s, %s", rule.tableRegexCompiled.String(), reqData.table)
+		return false
+	}
+	return true
+}
+
+// ruleParser parses protobuf L7 rules to enforcement objects
+// May panic
+func ruleParser(rule *cilium.PortNetworkPolicyRule) []proxylib.L7NetworkPolicyRule {
+	l7Rules := rule.GetL7Rules()
+	if l7Rules == nil {
+		return nil
+	}
+
+	allowRules := l7Rules.GetL7AllowRules()
+	rules := make([]proxylib.L7NetworkPolicyRule, 0, len(allowRules))
+	for _, l7Rule := range allowRules {
+		var pr postgresRule
+		for k, v := range l7Rule.Rule {
+			switch k {
+			case "query_action":
+				pr.queryActionExact = strings.ToLower(v)
+			case "query_table":
+				if v != "" {
+					pr.tableRegexCompiled = regexp.MustCompile(v)
+				}
+			default:
+				proxylib.ParseError(fmt.Sprintf("Unsupported key: %s", k), rule)
+			}
+		}
+		if pr.queryActionExact != "" && !queryActions[pr.queryActionExact] {
+			proxylib.ParseError(fmt.Sprintf("Unable to parse L7 postgres rule with invalid query_action: '%s'", pr.queryActionExact), rule)
+		}
+		log.Debugf("Parsed PostgresRule pair: %v", pr)
+		rules = append(rules, &pr)
+	}
+	return rules
+}
+
+type factory struct{}
+
+func init() {
+	log.Debug("init(): Registering postgresParserFactory")
+	proxylib.RegisterParserFactory("postgres", &factory{})
+	proxylib.RegisterL7RuleParser("postgres", ruleParser)
+}
+
+type parser struct {
+	connection *proxylib.Connection
+
+	// startupSeen is true after the startup message from the client has
+	// been passed. The startup message is the only frontend message
+	// without a type byte.
+	startupSeen bool
+}
+
+func (f *factory) Create(connection *proxylib.Connection) interface{} {
+	log.Debugf("PostgresParserFactory: Create: %v", connection)
+
+	return &parser{connection: connection}
+}
+
+const (
+	sslRequestCode    = 80877103
+	cancelRequestCode = 80877102
+)
+
+var tableRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^select\s+.*\s+from\s+([^\s;,()]+)`),
+	regexp.MustCompile(`(?i)^insert\s+into\s+([^\s;,()]+)`),
+	regexp.MustCompile(`(?i)^update\s+([^\s;,()]+)`),
+	regexp.MustCompile(`(?i)^delete\s+from\s+([^\s;,()]+)`),
+	regexp.MustCompile(`(?i)^(?:create|alter|drop|truncate)\s+table\s+(?:if\s+(?:not\s+)?exists\s+)?([^\s;,()]+)`),
+}
+
+// parseQuery returns the statement type and the table accessed by the given
+// simple query, both in lower case. The table is empty if it cannot be
+// determined.
+func parseQuery(query string) (string, string) {
+	query = strings.TrimSpace(query)
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	action := strings.ToLower(fields[0])
+
+	for _, re := range tableRegexes {
+		if match := re.FindStringSubmatch(query); match != nil {
+			return action, strings.ToLower(match[1])
+		}
+	}
+	return action, ""
+}
+
+// createErrorResponse returns an ErrorResponse message followed by a
+// ReadyForQuery message, to be injected towards the client when a query is
+// denied by policy.
+func createErrorResponse(message string) []byte {
+	fields := []byte{}
+	fields = append(fields, 'S')
+	fields = append(fields, []byte("ERROR\x00")...)
+	fields = append(fields, 'C')
+	fields = append(fields, []byte("42501\x00")...) // insufficient_privilege
+	fields = append(fields, 'M')
+	fields = append(fields, []byte(message+"\x00")...)
+	fields = append(fields, 0)
+
+	msg := make([]byte, 0, len(fields)+11)
+	msg = append(msg, 'E')
+	msg = append(msg, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(msg[1:5], uint32(len(fields)+4))
+	msg = append(msg, fields...)
+
+	// ReadyForQuery, transaction status 'I' (idle)
+	msg = append(msg, 'Z', 0, 0, 0, 5, 'I')
+	return msg
+}
+
+func (p *parser) OnData(reply, endStream bool, dataArray [][]byte) (proxylib.OpType, int) {
+
+	// inefficient, but simple
+	data := bytes.Join(dataArray, []byte{})
+
+	if reply {
+		// Pass replies one message at a time without parsing them
+		if len(data) < 5 {
+			return proxylib.MORE, 5 - len(data)
+		}
+		msgLen := 1 + int(binary.BigEndian.Uint32(data[1:5]))
+		return proxylib.PASS, msgLen
+	}
+
+	if !p.startupSeen {
+		// Startup, SSLRequest and CancelRequest messages have no type
+		// byte, the first four bytes are the message length.
+		if len(data) < 8 {
+			return proxylib.MORE, 8 - len(data)
+		}
+		msgLen := int(binary.BigEndian.Uint32(data[0:4]))
+		if msgLen < 8 {
+			return proxylib.ERROR, int(proxylib.ERROR_INVALID_FRAME_LENGTH)
+		}
+		code := binary.BigEndian.Uint32(data[4:8])
+		if code != sslRequestCode && code != cancelRequestCode {
+			// Startup message with the protocol version and
+			// parameters. After this typed messages follow.
+			p.startupSeen = true
+		}
+		log.Debugf("Passing postgres startup message of %d bytes", msgLen)
+		return proxylib.PASS, msgLen
+	}
+
+	if len(data) < 5 {
+		return proxylib.MORE, 5 - len(data)
+	}
+	msgType := data[0]
+	msgLen := 1 + int(binary.BigEndian.Uint32(data[1:5]))
+	if msgLen < 5 {
+		return proxylib.ERROR, int(proxylib.ERROR_INVALID_FRAME_LENGTH)
+	}
+
+	if msgType != 'Q' {
+		// Policy is only enforced on simple queries, everything else
+		// is passed through.
+		return proxylib.PASS, msgLen
+	}
+
+	if len(data) < msgLen {
+		return proxylib.MORE, msgLen - len(data)
+	}
+
+	query := strings.TrimRight(string(data[5:msgLen]), "\x00")
+	action, table := parseQuery(query)
+	reqData := postgresRequestData{action: action, table: table}
+
+	matches := true
+	accessLogEntryType := cilium.EntryType_Request
+
+	if !p.connection.Matches(reqData) {
+		matches = false
+		accessLogEntryType = cilium.EntryType_Denied
+	}
+
+	p.connection.Log(accessLogEntryType,
+		&cilium.LogEntry_GenericL7{
+			GenericL7: &cilium.L7LogEntry{
+				Proto: "postgres",
+				Fields: map[string]string{
+					"query_action": reqData.action,
+					"query_table":  reqData.table,
+				},
+			},
+		})
+
+	if !matches {
+		p.connection.Inject(true, createErrorResponse("access denied by policy"))
+		log.Debugf("Policy mismatch, dropping %d bytes", msgLen)
+		return proxylib.DROP, msgLen
+	}
+
+	return proxylib.PASS, msgLen
+}
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of Cilium
+
+//go:build !privileged_tests
+// +build !privileged_tests
+
+package postgres
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/cilium/cilium/proxylib/accesslog"
+	"github.com/cilium/cilium/proxylib/proxylib"
+	"github.com/cilium/cilium/proxylib/test"
+
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type PostgresSuite struct {
+	logServer *test.AccessLogServer
+	ins       *proxylib.Instance
+}
+
+var _ = Suite(&PostgresSuite{})
+
+// Set up access log server and Library instance for all the test cases
+func (s *PostgresSuite) SetUpSuite(c *C) {
+	s.logServer = test.StartAccessLogServer("access_log.sock", 10)
+	c.Assert(s.logServer, Not(IsNil))
+	s.ins = proxylib.NewInstance("node1", accesslog.NewClient(s.logServer.Path))
+	c.Assert(s.ins, Not(IsNil))
+}
+
+func (s *PostgresSuite) TearDownTest(c *C) {
+	s.logServer.Clear()
+}
+
+func (s *PostgresSuite) TearDownSuite(c *C) {
+	s.logServer.Close()
+}
+
+// startupMessage builds a startup message with a single user parameter
+func startupMessage() []byte {
+	params := []byte("user\x00alice\x00\x00")
+	msg := make([]byte, 8, 8+len(params))
+	binary.BigEndian.PutUint32(msg[0:4], uint32(8+len(params)))
+	binary.BigEndian.PutUint32(msg[4:8], 196608) // protocol version 3.0
+	return append(msg, params...)
+}
+
+// queryMessage builds a simple query ('Q') message
+func queryMessage(query string) []byte {
+	msg := make([]byte, 5, 5+len(query)+1)
+	msg[0] = 'Q'
+	binary.BigEndian.PutUint32(msg[1:5], uint32(4+len(query)+1))
+	msg = append(msg, []byte(query)...)
+	return append(msg, 0)
+}
+
+func (s *PostgresSuite) TestPostgresOnDataIncomplete(c *C) {
+	conn := s.ins.CheckNewConnectionOK(c, "postgres", true, 1, 2, "1.1.1.1:34567", "2.2.2.2:5432", "no-policy")
+	data := [][]byte{{0x0, 0x0}}
+	conn.CheckOnDataOK(c, false, false, &data, []byte{}, proxylib.MORE, 6)
+}
+
+func (s *PostgresSuite) TestPostgresOnDataBasicPass(c *C) {
+
+	// allow all rule
+	s.ins.CheckInsertPolicyText(c, "1", []string{`
+		name: "cp1"
+		policy: 2
+		ingress_per_port_policies: <
+		  port: 5432
+		  rules: <
+		    l7_proto: "postgres"
+		  >
+		>
+		`})
+	conn := s.ins.CheckNewConnectionOK(c, "postgres", true, 1, 2, "1.1.1.1:34567", "2.2.2.2:5432", "cp1")
+	startup := startupMessage()
+	msg1 := queryMessage("SELECT id FROM public.users")
+	msg2 := queryMessage("INSERT INTO metrics VALUES (1)")
+	data := [][]byte{startup, msg1, msg2}
+	conn.CheckOnDataOK(c, false, false, &data, []byte{},
+		proxylib.PASS, len(startup),
+		proxylib.PASS, len(msg1),
+		proxylib.PASS, len(msg2),
+		proxylib.MORE, 5)
+}
+
+func (s *PostgresSuite) TestPostgresOnDataAllowDenyAction(c *C) {
+
+	s.ins.CheckInsertPolicyText(c, "1", []string{`
+		name: "cp2"
+		policy: 2
+		ingress_per_port_policies: <
+		  port: 5432
+		  rules: <
+		    l7_proto: "postgres"
+		    l7_rules: <
+		      l7_allow_rules: <
+			rule: <
+			  key: "query_action"
+			  value: "select"
+			>
+		      >
+		    >
+		  >
+		>
+		`})
+	conn := s.ins.CheckNewConnectionOK(c, "postgres", true, 1, 2, "1.1.1.1:34567", "2.2.2.2:5432", "cp2")
+	startup := startupMessage()
+	msg1 := queryMessage("SELECT id FROM public.users")
+	msg2 := queryMessage("DROP TABLE public.users")
+	data := [][]byte{startup, msg1, msg2}
+	conn.CheckOnDataOK(c, false, false, &data, createErrorResponse("access denied by policy"),
+		proxylib.PASS, len(startup),
+		proxylib.PASS, len(msg1),
+		proxylib.DROP, len(msg2),
+		proxylib.MORE, 5)
+}
+
+func (s *PostgresSuite) TestPostgresOnDataAllowDenyTable(c *C) {
+
+	s.ins.CheckInsertPolicyText(c, "1", []string{`
+		name: "cp3"
+		policy: 2
+		ingress_per_port_policies: <
+		  port: 5432
+		  rules: <
+		    l7_proto: "postgres"
+		    l7_rules: <
+		      l7_allow_rules: <
+			rule: <
+			  key: "query_table"
+			  value: "public\\..*"
+			>
+		      >
+		    >
+		  >
+		>
+		`})
+	conn := s.ins.CheckNewConnectionOK(c, "postgres", true, 1, 2, "1.1.1.1:34567", "2.2.2.2:5432", "cp3")
+	startup := startupMessage()
+	msg1 := queryMessage("UPDATE public.users SET name = 'bob'")
+	msg2 := queryMessage("SELECT secret FROM private.keys")
+	data := [][]byte{startup, msg1, msg2}
+	conn.CheckOnDataOK(c, false, false, &data, createErrorResponse("access denied by policy"),
+		proxylib.PASS, len(startup),
+		proxylib.PASS, len(msg1),
+		proxylib.DROP, len(msg2),
+		proxylib.MORE, 5)
+}
+
+func (s *PostgresSuite) TestPostgresParseQuery(c *C) {
+	action, table := parseQuery("SELECT id FROM public.users WHERE id = 1")
+	c.Check(action, Equals, "select")
+	c.Check(table, Equals, "public.users")
+
+	action, table = parseQuery("create table IF NOT EXISTS metrics (id int)")
+	c.Check(action, Equals, "create")
+	c.Check(table, Equals, "metrics")
+
+	action, table = parseQuery("BEGIN")
+	c.Check(action, Equals, "begin")
+	c.Check(table, Equals, "")
+}